@@ -22,6 +22,42 @@ import (
 	"sigs.k8s.io/external-dns/endpoint"
 )
 
+const (
+	// DNSEndpointFinalizer is added to DNSEndpoint resources when finalizer
+	// management is enabled. It is only removed once every record produced by
+	// the resource has been confirmed as deleted from the DNS provider.
+	DNSEndpointFinalizer string = "externaldns.k8s.io/dnsendpoint-cleanup"
+
+	// DeletingCondition reports the progress of record cleanup for a
+	// DNSEndpoint that is being deleted. It stays True with RecordsPendingReason
+	// while provider records still exist.
+	DeletingCondition string = "Deleting"
+
+	// Reasons for the Deleting condition.
+	RecordsPendingReason     string = "RecordsPending"
+	VerificationFailedReason string = "VerificationFailed"
+
+	// SyncedCondition reports the outcome of the last synchronization of the
+	// controller. It is True when the records match the DNSEndpoint after it.
+	SyncedCondition string = "Synced"
+
+	// Reasons for the Synced condition.
+	NoChangesReason         string = "NoChanges"
+	SkippedDueToCacheReason string = "SkippedDueToCache"
+	ChangesAppliedReason    string = "ChangesApplied"
+	PartiallyAppliedReason  string = "PartiallyApplied"
+	SyncFailedReason        string = "SyncFailed"
+	ChangesSkippedReason    string = "ChangesSkipped"
+
+	// DefaultedCondition lists the endpoints whose recordType or recordTTL was
+	// left empty and defaulted by the external-dns controller. It is absent when
+	// no value was defaulted.
+	DefaultedCondition string = "Defaulted"
+
+	// Reason for the Defaulted condition.
+	ValuesDefaultedReason string = "ValuesDefaulted"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -59,4 +95,20 @@ type DNSEndpointStatus struct {
 	// The generation observed by the external-dns controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the DNSEndpoint state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// AppliedChanges is the number of changes to the records of the DNSEndpoint
+	// applied by the last synchronization that planned some.
+	// +optional
+	AppliedChanges int32 `json:"appliedChanges,omitempty"`
+
+	// PendingChanges is the number of changes to the records of the DNSEndpoint
+	// that failed to be applied, they are retried by the next synchronization.
+	// +optional
+	PendingChanges int32 `json:"pendingChanges,omitempty"`
 }
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DNSPolicySpec defines guardrails applied to the endpoints of an external-dns
+// instance before they are planned. Endpoints within the scope of the policy
+// have their TTL clamped to the bounds of the policy, and are rejected when a
+// target is not allowed.
+// +kubebuilder:object:generate=true
+type DNSPolicySpec struct {
+	// Namespaces limits the policy to endpoints of resources in these namespaces.
+	// All namespaces are selected when empty.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// RecordTypes limits the policy to endpoints of these record types.
+	// All record types are selected when empty.
+	// +optional
+	RecordTypes []string `json:"recordTypes,omitempty"`
+	// MinTTL raises the TTL of the selected endpoints, in seconds, to at least this value.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinTTL *int64 `json:"minTTL,omitempty"`
+	// MaxTTL lowers the TTL of the selected endpoints, in seconds, to at most this value.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTTL *int64 `json:"maxTTL,omitempty"`
+	// AllowedTargets rejects the selected endpoints having a target that matches none
+	// of these patterns. A pattern is a hostname, a wildcard hostname such as
+	// *.corp.example.com, or a CIDR. All targets are allowed when empty.
+	// +optional
+	AllowedTargets []string `json:"allowedTargets,omitempty"`
+}
+
+// RejectedEndpoint is an endpoint rejected by a DNSPolicy.
+type RejectedEndpoint struct {
+	// DNSName of the rejected endpoint.
+	DNSName string `json:"dnsName"`
+	// RecordType of the rejected endpoint.
+	RecordType string `json:"recordType"`
+	// Resource the endpoint was generated from, e.g. ingress/default/app.
+	// +optional
+	Resource string `json:"resource,omitempty"`
+	// Reason the endpoint was rejected.
+	Reason string `json:"reason"`
+}
+
+// DNSPolicyStatus reports the endpoints rejected by a DNSPolicy.
+// +kubebuilder:object:generate=true
+type DNSPolicyStatus struct {
+	// ObservedGeneration is the generation of the policy the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// RejectedCount is the number of endpoints rejected on the last evaluation.
+	// +optional
+	RejectedCount int `json:"rejectedCount,omitempty"`
+	// RejectedEndpoints lists the endpoints rejected on the last evaluation,
+	// truncated to the first 50.
+	// +optional
+	RejectedEndpoints []RejectedEndpoint `json:"rejectedEndpoints,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSPolicy clamps the TTL of endpoints and rejects endpoints with disallowed
+// targets. All policies apply when external-dns runs with --dns-policies.
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:path=dnspolicies,scope=Cluster
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:annotations="api-approved.kubernetes.io=unapproved, experimental"
+// +kubebuilder:printcolumn:name="Min TTL",type=integer,JSONPath=`.spec.minTTL`
+// +kubebuilder:printcolumn:name="Max TTL",type=integer,JSONPath=`.spec.maxTTL`
+// +kubebuilder:printcolumn:name="Rejected",type=integer,JSONPath=`.status.rejectedCount`
+// +versionName=v1alpha1
+
+type DNSPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DNSPolicySpec   `json:"spec,omitempty"`
+	Status DNSPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// DNSPolicyList is a list of DNSPolicy objects
+type DNSPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSPolicy `json:"items"`
+}
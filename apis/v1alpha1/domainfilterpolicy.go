@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DomainFilterPolicyMode defines how a DomainFilterPolicy is combined with
+// the domain filter flags.
+// +kubebuilder:validation:Enum=Override;Augment
+type DomainFilterPolicyMode string
+
+const (
+	// DomainFilterPolicyOverride replaces the domain filter flags with the policy.
+	DomainFilterPolicyOverride DomainFilterPolicyMode = "Override"
+	// DomainFilterPolicyAugment manages the domains included by the policy in
+	// addition to the ones of the domain filter flags, the exclusions of both apply.
+	DomainFilterPolicyAugment DomainFilterPolicyMode = "Augment"
+)
+
+// DomainFilterPolicySpec defines the domains managed by external-dns. The
+// fields mirror the --domain-filter, --exclude-domains, --regex-domain-filter
+// and --regex-domain-exclusion flags, and the regular expressions take
+// precedence over the domain lists in the same way.
+// +kubebuilder:object:generate=true
+type DomainFilterPolicySpec struct {
+	// Mode defines how the policy is combined with the domain filter flags.
+	// +kubebuilder:default=Override
+	// +optional
+	Mode DomainFilterPolicyMode `json:"mode,omitempty"`
+	// Include limits the managed records to these domains and their subdomains.
+	// +optional
+	Include []string `json:"include,omitempty"`
+	// Exclude excludes these domains and their subdomains.
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+	// RegexInclude limits the managed records to the domains matching this regular expression.
+	// +optional
+	RegexInclude string `json:"regexInclude,omitempty"`
+	// RegexExclude excludes the domains matching this regular expression.
+	// +optional
+	RegexExclude string `json:"regexExclude,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DomainFilterPolicy adjusts the domain filter of an external-dns instance at
+// runtime. The instance selects it by name with --domain-filter-policy.
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:path=domainfilterpolicies,scope=Cluster
+// +kubebuilder:object:root=true
+// +kubebuilder:metadata:annotations="api-approved.kubernetes.io=unapproved, experimental"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.mode`
+// +kubebuilder:printcolumn:name="Include",type=string,JSONPath=`.spec.include`
+// +kubebuilder:printcolumn:name="Exclude",type=string,JSONPath=`.spec.exclude`
+// +versionName=v1alpha1
+
+type DomainFilterPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DomainFilterPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// DomainFilterPolicyList is a list of DomainFilterPolicy objects
+type DomainFilterPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DomainFilterPolicy `json:"items"`
+}
@@ -41,6 +41,6 @@ var (
 )
 
 func addKnownTypes(s *runtime.Scheme) error {
-	s.AddKnownTypes(GroupVersion, &DNSEndpoint{}, &DNSEndpointList{}, &DNSRecord{}, &DNSRecordList{})
+	s.AddKnownTypes(GroupVersion, &DNSEndpoint{}, &DNSEndpointList{}, &DNSRecord{}, &DNSRecordList{}, &DomainFilterPolicy{}, &DomainFilterPolicyList{}, &DNSPolicy{}, &DNSPolicyList{})
 	return nil
 }
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/sets"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// fullReconcileDue reports whether the sync starting at now must bypass the
+// record caches. The first sync reads cold caches and counts as a full reconcile.
+func (c *Controller) fullReconcileDue(now time.Time) bool {
+	if c.FullReconcileInterval <= 0 || c.lastFullReconcile.IsZero() {
+		return false
+	}
+	return !now.Before(c.lastFullReconcile.Add(c.FullReconcileInterval))
+}
+
+// resetRecordCaches returns the records as currently cached and drops the
+// registry and provider caches, so that the following read returns the live
+// provider state. It returns false when the registry does not cache records.
+func (c *Controller) resetRecordCaches(ctx context.Context) ([]*endpoint.Endpoint, bool, error) {
+	resetter, ok := c.Registry.(provider.CacheResetter)
+	if !ok {
+		return nil, false, nil
+	}
+	cached, err := c.Registry.Records(ctx)
+	if err != nil {
+		registryErrorsTotal.Counter.Inc()
+		deprecatedRegistryErrors.Counter.Inc()
+		return nil, false, err
+	}
+	log.Info("Full reconcile: dropping record caches to compare against the live provider state")
+	resetter.Reset()
+	return cached, true, nil
+}
+
+// outOfBandRecords returns the keys of records whose live state differs from
+// the cached one: created, deleted or modified outside of external-dns.
+func outOfBandRecords(cached, live []*endpoint.Endpoint) sets.Set[endpoint.EndpointKey] {
+	byKey := make(map[endpoint.EndpointKey]*endpoint.Endpoint, len(cached))
+	for _, ep := range cached {
+		byKey[ep.Key()] = ep
+	}
+
+	changed := sets.New[endpoint.EndpointKey]()
+	for _, ep := range live {
+		key := ep.Key()
+		old, ok := byKey[key]
+		delete(byKey, key)
+		if !ok || !old.Targets.Same(ep.Targets) || old.RecordTTL != ep.RecordTTL {
+			changed.Insert(key)
+		}
+	}
+	for key := range byKey {
+		changed.Insert(key)
+	}
+	return changed
+}
+
+// countCorrections counts the applied changes that revert out-of-band modifications.
+func countCorrections(changes *plan.Changes, outOfBand sets.Set[endpoint.EndpointKey]) int {
+	corrected := 0
+	for _, eps := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew, changes.Delete} {
+		for _, ep := range eps {
+			if outOfBand.Has(ep.Key()) {
+				outOfBandCorrectionsTotal.CounterVec.WithLabelValues(ep.RecordType).Inc()
+				corrected++
+			}
+		}
+	}
+	return corrected
+}
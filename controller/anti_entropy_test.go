@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry/txt"
+)
+
+func TestOutOfBandRecords(t *testing.T) {
+	a := endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	b := endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	c := endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	d := endpoint.NewEndpoint("d.example.com", endpoint.RecordTypeA, "1.2.3.4")
+
+	modified := endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "5.6.7.8")
+	retimed := endpoint.NewEndpointWithTTL("c.example.com", endpoint.RecordTypeA, 60, "1.2.3.4")
+
+	got := outOfBandRecords(
+		[]*endpoint.Endpoint{a, b, c, d},
+		[]*endpoint.Endpoint{a, modified, retimed, endpoint.NewEndpoint("e.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	)
+	assert.ElementsMatch(t, []endpoint.EndpointKey{b.Key(), c.Key(), d.Key(), {DNSName: "e.example.com", RecordType: endpoint.RecordTypeA}}, got.List())
+	assert.Empty(t, outOfBandRecords([]*endpoint.Endpoint{a}, []*endpoint.Endpoint{a}))
+}
+
+func TestFullReconcileDue(t *testing.T) {
+	now := time.Now()
+	c := &Controller{}
+	assert.False(t, c.fullReconcileDue(now), "disabled without interval")
+
+	c.FullReconcileInterval = time.Hour
+	assert.False(t, c.fullReconcileDue(now), "the first sync is a full reconcile already")
+
+	c.lastFullReconcile = now.Add(-30 * time.Minute)
+	assert.False(t, c.fullReconcileDue(now))
+
+	c.lastFullReconcile = now.Add(-time.Hour)
+	assert.True(t, c.fullReconcileDue(now))
+}
+
+func TestRunOnceFullReconcileCorrectsOutOfBandChanges(t *testing.T) {
+	ctx := t.Context()
+	im := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+	cached := provider.NewCachedProvider(im, time.Hour)
+
+	cfg := externaldns.NewConfig()
+	cfg.TXTOwnerID = "owner"
+	cfg.TXTCacheInterval = time.Hour
+	cfg.ManagedDNSRecordTypes = []string{endpoint.RecordTypeA}
+	reg, err := txt.New(cfg, cached)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:                testutils.NewMockSource(endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")),
+		Registry:              reg,
+		Policy:                &plan.SyncPolicy{},
+		DomainFilter:          &endpoint.DomainFilter{},
+		ManagedRecordTypes:    cfg.ManagedDNSRecordTypes,
+		FullReconcileInterval: time.Hour,
+	}
+	require.NoError(t, ctrl.RunOnce(ctx))
+	require.False(t, ctrl.lastFullReconcile.IsZero())
+
+	// someone edits the record in the provider console
+	require.NoError(t, im.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "6.6.6.6")},
+	}))
+
+	// the caches still hold the managed state, nothing is corrected
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.Equal(t, endpoint.Targets{"6.6.6.6"}, aRecordTargets(t, im))
+
+	before := correctionsCount(t)
+	ctrl.lastFullReconcile = time.Now().Add(-time.Hour)
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.Equal(t, endpoint.Targets{"1.2.3.4"}, aRecordTargets(t, im))
+	assert.InDelta(t, 1.0, correctionsCount(t)-before, 0)
+}
+
+func correctionsCount(t *testing.T) float64 {
+	t.Helper()
+	m := &dto.Metric{}
+	require.NoError(t, outOfBandCorrectionsTotal.CounterVec.WithLabelValues(endpoint.RecordTypeA).Write(m))
+	return m.GetCounter().GetValue()
+}
+
+func aRecordTargets(t *testing.T, p provider.Provider) endpoint.Targets {
+	t.Helper()
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	for _, r := range records {
+		if r.DNSName == "a.example.com" && r.RecordType == endpoint.RecordTypeA {
+			return r.Targets
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
+)
+
+// ApexRecord is the expected state of a record at the apex of a zone. An empty
+// TTL or list of targets is not checked.
+type ApexRecord struct {
+	RecordType string   `json:"type"`
+	TTL        int64    `json:"ttl,omitempty"`
+	Targets    []string `json:"targets,omitempty"`
+}
+
+// ApexDriftChecker reports the apex records, e.g. NS and SOA, that drift from their
+// expected state through metrics, logs and events. It never modifies them.
+type ApexDriftChecker struct {
+	// expected holds the expected records by zone name, without trailing dot
+	expected map[string][]ApexRecord
+	// pod is the object the events are about, nil when unknown
+	pod *events.ObjectReference
+	// reported holds the drift last reported by zone and record type
+	reported map[string]string
+}
+
+// NewApexDriftChecker reads the expected apex records from a YAML file mapping
+// zone names to records. It returns nil when file is empty.
+func NewApexDriftChecker(file string) (*ApexDriftChecker, error) {
+	if file == "" {
+		return nil, nil //nolint:nilnil // the checker is disabled without a file
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading apex records file: %w", err)
+	}
+	var zones map[string][]ApexRecord
+	if err := yaml.Unmarshal(data, &zones); err != nil {
+		return nil, fmt.Errorf("parsing apex records file %s: %w", file, err)
+	}
+	expected := make(map[string][]ApexRecord, len(zones))
+	for zone, records := range zones {
+		zone = strings.Trim(strings.ToLower(zone), ".")
+		for _, record := range records {
+			if zone == "" || record.RecordType == "" {
+				return nil, fmt.Errorf("invalid apex record %q: %+v in %s", zone, record, file)
+			}
+			record.RecordType = strings.ToUpper(record.RecordType)
+			expected[zone] = append(expected[zone], record)
+		}
+	}
+
+	c := &ApexDriftChecker{expected: expected, reported: map[string]string{}}
+	// set through the downward API, see docs/advanced/apex-drift.md
+	if name, namespace := os.Getenv("POD_NAME"), os.Getenv("POD_NAMESPACE"); name != "" && namespace != "" {
+		c.pod = events.NewObjectReferenceFromParts("Pod", "v1", namespace, name, "", "controller")
+	}
+	return c, nil
+}
+
+// check compares the apex records among the current records with their expected state.
+func (c *ApexDriftChecker) check(records []*endpoint.Endpoint, emitter events.EventEmitter) {
+	var evs []events.Event
+	for zone, expected := range c.expected {
+		for _, want := range expected {
+			drift := apexDrift(want, findApexRecord(records, zone, want.RecordType))
+			value := 0.0
+			if drift != "" {
+				value = 1
+			}
+			apexRecordDrift.SetWithLabels(value, zone, want.RecordType)
+
+			key := zone + "/" + want.RecordType
+			if drift == c.reported[key] {
+				continue
+			}
+			c.reported[key] = drift
+			if drift == "" {
+				log.Infof("Apex %s record of zone %s matches its expected state again", want.RecordType, zone)
+				continue
+			}
+			msg := fmt.Sprintf("Apex %s record of zone %s drifts from its expected state: %s", want.RecordType, zone, drift)
+			log.Warn(msg)
+			if c.pod != nil {
+				evs = append(evs, events.NewWarningEvent([]*events.ObjectReference{c.pod}, msg, events.ActionFailed, events.ApexRecordDrift))
+			}
+		}
+	}
+	if emitter != nil && len(evs) > 0 {
+		emitter.Add(evs...)
+	}
+}
+
+// findApexRecord returns the record of the given type at the apex of zone, nil when there is none.
+func findApexRecord(records []*endpoint.Endpoint, zone, recordType string) *endpoint.Endpoint {
+	for _, ep := range records {
+		if ep.RecordType == recordType && normalizeApexName(ep.DNSName) == zone {
+			return ep
+		}
+	}
+	return nil
+}
+
+// apexDrift describes how the record differs from its expected state, empty when it does not.
+func apexDrift(want ApexRecord, got *endpoint.Endpoint) string {
+	if got == nil {
+		return "missing"
+	}
+	var drifts []string
+	if want.TTL > 0 && int64(got.RecordTTL) != want.TTL {
+		drifts = append(drifts, fmt.Sprintf("TTL %d, expected %d", got.RecordTTL, want.TTL))
+	}
+	if len(want.Targets) > 0 {
+		gotTargets, wantTargets := normalizeApexTargets(got.Targets), normalizeApexTargets(want.Targets)
+		if !slices.Equal(gotTargets, wantTargets) {
+			drifts = append(drifts, fmt.Sprintf("targets %v, expected %v", gotTargets, wantTargets))
+		}
+	}
+	return strings.Join(drifts, ", ")
+}
+
+func normalizeApexName(name string) string {
+	return strings.Trim(strings.ToLower(name), ".")
+}
+
+func normalizeApexTargets(targets []string) []string {
+	normalized := make([]string, 0, len(targets))
+	for _, t := range targets {
+		normalized = append(normalized, strings.TrimSuffix(strings.ToLower(t), "."))
+	}
+	slices.Sort(normalized)
+	return normalized
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/events/fake"
+)
+
+func writeApexRecordsFile(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "apex.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	return file
+}
+
+func TestNewApexDriftChecker(t *testing.T) {
+	c, err := NewApexDriftChecker("")
+	require.NoError(t, err)
+	assert.Nil(t, c)
+
+	c, err = NewApexDriftChecker(writeApexRecordsFile(t, `
+Example.com.:
+  - type: ns
+    ttl: 172800
+    targets: [ns1.example.net, ns2.example.net]
+  - type: SOA
+    ttl: 3600
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]ApexRecord{
+		"example.com": {
+			{RecordType: endpoint.RecordTypeNS, TTL: 172800, Targets: []string{"ns1.example.net", "ns2.example.net"}},
+			{RecordType: "SOA", TTL: 3600},
+		},
+	}, c.expected)
+
+	_, err = NewApexDriftChecker(writeApexRecordsFile(t, "example.com:\n  - ttl: 300\n"))
+	require.ErrorContains(t, err, "invalid apex record")
+
+	_, err = NewApexDriftChecker(writeApexRecordsFile(t, "example.com: ns\n"))
+	require.ErrorContains(t, err, "parsing apex records file")
+
+	_, err = NewApexDriftChecker(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "reading apex records file")
+}
+
+func TestApexDriftCheckerCheck(t *testing.T) {
+	t.Setenv("POD_NAME", "external-dns-0")
+	t.Setenv("POD_NAMESPACE", "external-dns")
+	c, err := NewApexDriftChecker(writeApexRecordsFile(t, `
+example.com:
+  - type: NS
+    ttl: 172800
+    targets: [ns1.example.net., ns2.example.net.]
+  - type: SOA
+    ttl: 3600
+example.org:
+  - type: NS
+`))
+	require.NoError(t, err)
+
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com.", endpoint.RecordTypeNS, 172800, "ns2.example.net", "NS1.example.net"),
+		endpoint.NewEndpointWithTTL("example.com", "SOA", 300, "ns1.example.net. hostmaster.example.com. 1 10800 3600 604800 3600"),
+		endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeNS, 300, "ns1.example.net"),
+	}
+	emitter := fake.NewFakeEventEmitter()
+	c.check(records, emitter)
+
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, apexRecordDrift.Gauge, map[string]string{"zone": "example.com", "record_type": "NS"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, apexRecordDrift.Gauge, map[string]string{"zone": "example.com", "record_type": "SOA"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, apexRecordDrift.Gauge, map[string]string{"zone": "example.org", "record_type": "NS"})
+	assert.Equal(t, map[string]string{
+		"example.com/SOA": "TTL 300, expected 3600",
+		"example.org/NS":  "missing",
+	}, c.reported)
+	emitter.AssertNumberOfCalls(t, "Add", 2)
+
+	// the same drift is only reported once
+	c.check(records, emitter)
+	emitter.AssertNumberOfCalls(t, "Add", 2)
+
+	records[0].Targets = endpoint.Targets{"ns1.example.net"}
+	records[1].RecordTTL = 3600
+	c.check(records, emitter)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, apexRecordDrift.Gauge, map[string]string{"zone": "example.com", "record_type": "NS"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, apexRecordDrift.Gauge, map[string]string{"zone": "example.com", "record_type": "SOA"})
+	assert.Equal(t, "targets [ns1.example.net], expected [ns1.example.net ns2.example.net]", c.reported["example.com/NS"])
+	emitter.AssertNumberOfCalls(t, "Add", 3)
+}
+
+func TestApexDriftCheckerWithoutPod(t *testing.T) {
+	t.Setenv("POD_NAME", "")
+	c, err := NewApexDriftChecker(writeApexRecordsFile(t, "example.com:\n  - type: NS\n"))
+	require.NoError(t, err)
+	assert.Nil(t, c.pod)
+
+	emitter := fake.NewFakeEventEmitter()
+	c.check(nil, emitter)
+	emitter.AssertNotCalled(t, "Add")
+	assert.Equal(t, "missing", c.reported["example.com/NS"])
+}
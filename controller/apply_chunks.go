@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/publicsuffix"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// applyChanges applies the changes through the registry. With ApplyChunkSize
+// set, the changes are split in chunks per zone which are applied one after
+// the other: a failing chunk does not prevent the others from being applied.
+func (c *Controller) applyChanges(ctx context.Context, changes *plan.Changes) error {
+	if c.ApplyChunkSize <= 0 {
+		// computed before applying, providers may update the endpoints in place
+		diffs := changes.UpdateDiffs()
+		if err := c.Registry.ApplyChanges(ctx, changes); err != nil {
+			emitChangeEvent(c.EventEmitter, changes, diffs, events.RecordError)
+			countResourceChanges(ctx, changes, false)
+			return err
+		}
+		countResourceChanges(ctx, changes, true)
+		logUpdates(ctx, changes, diffs, c.DryRun)
+		emitChangeEvent(c.EventEmitter, changes, diffs, events.RecordReady)
+		return nil
+	}
+
+	chunks := splitChanges(changes, c.ApplyChunkSize, c.zoneOf)
+	var errs []error
+	for i, chunk := range chunks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		diffs := chunk.UpdateDiffs()
+		if err := c.Registry.ApplyChanges(ctx, chunk); err != nil {
+			log.Errorf("Failed to apply chunk %d/%d of %d changes: %v", i+1, len(chunks), countChanges(chunk), err)
+			applyChunksTotal.CounterVec.WithLabelValues("failure").Inc()
+			emitChangeEvent(c.EventEmitter, chunk, diffs, events.RecordError)
+			countResourceChanges(ctx, chunk, false)
+			errs = append(errs, err)
+			continue
+		}
+		log.Debugf("Applied chunk %d/%d of %d changes", i+1, len(chunks), countChanges(chunk))
+		applyChunksTotal.CounterVec.WithLabelValues("success").Inc()
+		countResourceChanges(ctx, chunk, true)
+		logUpdates(ctx, chunk, diffs, c.DryRun)
+		emitChangeEvent(c.EventEmitter, chunk, diffs, events.RecordReady)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	log.Warnf("Applied %d of %d chunks, %d failed", len(chunks)-len(errs), len(chunks), len(errs))
+	err := errors.Join(errs...)
+	// a single hard failure makes the whole synchronization fail hard
+	for _, e := range errs {
+		if !errors.Is(e, provider.SoftError) {
+			err = fmt.Errorf("%d of %d chunks failed: %w", len(errs), len(chunks), e)
+			break
+		}
+	}
+	if len(errs) < len(chunks) {
+		return &partialApplyError{err: err}
+	}
+	return err
+}
+
+// countResourceChanges reports the changes to the sources as applied, or as
+// pending when applied is false, per resource which produced their records.
+func countResourceChanges(ctx context.Context, changes *plan.Changes, applied bool) {
+	source.CountChanges(ctx, applied, changes.Create, changes.UpdateNew, changes.Delete)
+}
+
+// partialApplyError is returned when some chunks of the changes were applied and others failed.
+type partialApplyError struct {
+	err error
+}
+
+func (e *partialApplyError) Error() string {
+	return e.err.Error()
+}
+
+func (e *partialApplyError) Unwrap() error {
+	return e.err
+}
+
+// zoneOf returns the zone a DNS name is grouped in when chunking changes: the
+// domain filter entry including it, or its registrable domain.
+func (c *Controller) zoneOf(name string) string {
+	if c.DomainFilter != nil {
+		if m := endpoint.ExplainDomainFilterMatch(c.DomainFilter, name); m.Matched && m.Rule == endpoint.DomainFilterRuleInclude {
+			return strings.TrimPrefix(m.Value, ".")
+		}
+	}
+	if zone, err := publicsuffix.EffectiveTLDPlusOne(strings.TrimSuffix(name, ".")); err == nil {
+		return zone
+	}
+	return name
+}
+
+// nameChanges are the changes of a single DNS name, they are never split
+// across chunks.
+type nameChanges struct {
+	create, updateOld, updateNew, del []*endpoint.Endpoint
+}
+
+func (n *nameChanges) len() int {
+	return len(n.create) + len(n.updateNew) + len(n.del)
+}
+
+// splitChanges splits the changes in chunks of at most size changes, counting
+// creates, updates and deletes. A chunk holds changes of a single zone and all
+// the changes of a DNS name end up in the same chunk, so a name with more
+// changes than size gets a bigger chunk of its own. Updates are paired by
+// index; when they cannot be paired the changes are returned as one chunk.
+func splitChanges(changes *plan.Changes, size int, zoneOf func(string) string) []*plan.Changes {
+	if size <= 0 || countChanges(changes) <= size || len(changes.UpdateOld) != len(changes.UpdateNew) {
+		return []*plan.Changes{changes}
+	}
+
+	zones := map[string]map[string]*nameChanges{}
+	group := func(ep *endpoint.Endpoint) *nameChanges {
+		zone := zoneOf(ep.DNSName)
+		if zones[zone] == nil {
+			zones[zone] = map[string]*nameChanges{}
+		}
+		n, ok := zones[zone][ep.DNSName]
+		if !ok {
+			n = &nameChanges{}
+			zones[zone][ep.DNSName] = n
+		}
+		return n
+	}
+	for _, ep := range changes.Create {
+		n := group(ep)
+		n.create = append(n.create, ep)
+	}
+	for i, ep := range changes.UpdateNew {
+		n := group(ep)
+		n.updateOld = append(n.updateOld, changes.UpdateOld[i])
+		n.updateNew = append(n.updateNew, ep)
+	}
+	for _, ep := range changes.Delete {
+		n := group(ep)
+		n.del = append(n.del, ep)
+	}
+
+	var chunks []*plan.Changes
+	for _, zone := range slices.Sorted(maps.Keys(zones)) {
+		names := zones[zone]
+		var chunk *plan.Changes
+		for _, name := range slices.Sorted(maps.Keys(names)) {
+			n := names[name]
+			if chunk != nil && countChanges(chunk)+n.len() > size {
+				chunks = append(chunks, chunk)
+				chunk = nil
+			}
+			if chunk == nil {
+				chunk = &plan.Changes{}
+			}
+			chunk.Create = append(chunk.Create, n.create...)
+			chunk.UpdateOld = append(chunk.UpdateOld, n.updateOld...)
+			chunk.UpdateNew = append(chunk.UpdateNew, n.updateNew...)
+			chunk.Delete = append(chunk.Delete, n.del...)
+		}
+		if chunk != nil {
+			chunks = append(chunks, chunk)
+		}
+	}
+	return chunks
+}
+
+// countChanges returns the number of creates, updates and deletes.
+func countChanges(changes *plan.Changes) int {
+	return len(changes.Create) + len(changes.UpdateNew) + len(changes.Delete)
+}
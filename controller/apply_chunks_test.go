@@ -0,0 +1,301 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry/noop"
+	"sigs.k8s.io/external-dns/source"
+)
+
+func TestSplitChanges(t *testing.T) {
+	a := endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	aaaa := endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeAAAA, "::1")
+	bOld := endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	bNew := endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "5.6.7.8")
+	c := endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeCNAME, "a.example.com")
+	d := endpoint.NewEndpoint("d.example.org", endpoint.RecordTypeA, "1.2.3.4")
+
+	changes := &plan.Changes{
+		Create:    []*endpoint.Endpoint{a, aaaa, d},
+		UpdateOld: []*endpoint.Endpoint{bOld},
+		UpdateNew: []*endpoint.Endpoint{bNew},
+		Delete:    []*endpoint.Endpoint{c},
+	}
+	zoneOf := (&Controller{}).zoneOf
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, []*plan.Changes{changes}, splitChanges(changes, 0, zoneOf))
+		assert.Equal(t, []*plan.Changes{changes}, splitChanges(changes, 5, zoneOf))
+	})
+
+	t.Run("per zone and name", func(t *testing.T) {
+		chunks := splitChanges(changes, 2, zoneOf)
+		assert.Equal(t, []*plan.Changes{
+			{Create: []*endpoint.Endpoint{a, aaaa}},
+			{UpdateOld: []*endpoint.Endpoint{bOld}, UpdateNew: []*endpoint.Endpoint{bNew}, Delete: []*endpoint.Endpoint{c}},
+			{Create: []*endpoint.Endpoint{d}},
+		}, chunks)
+	})
+
+	t.Run("name bigger than size", func(t *testing.T) {
+		chunks := splitChanges(changes, 1, zoneOf)
+		require.Len(t, chunks, 4)
+		assert.Equal(t, []*endpoint.Endpoint{a, aaaa}, chunks[0].Create)
+	})
+
+	t.Run("unpaired updates", func(t *testing.T) {
+		unpaired := &plan.Changes{Create: []*endpoint.Endpoint{a, d}, UpdateNew: []*endpoint.Endpoint{bNew}}
+		assert.Equal(t, []*plan.Changes{unpaired}, splitChanges(unpaired, 1, zoneOf))
+	})
+}
+
+func TestControllerZoneOf(t *testing.T) {
+	ctrl := &Controller{DomainFilter: endpoint.NewDomainFilter([]string{".sub.example.com"})}
+	assert.Equal(t, "sub.example.com", ctrl.zoneOf("a.sub.example.com"))
+	assert.Equal(t, "example.org", ctrl.zoneOf("a.b.example.org"))
+	assert.Equal(t, "example.co.uk", ctrl.zoneOf("a.example.co.uk"))
+}
+
+// chunkRegistry records the applied chunks and fails the ones holding failName.
+type chunkRegistry struct {
+	noop.NoopRegistry
+	failName string
+	failErr  error
+	applied  []*plan.Changes
+}
+
+func (r *chunkRegistry) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	return []*endpoint.Endpoint{}, nil
+}
+
+func (r *chunkRegistry) ApplyChanges(_ context.Context, changes *plan.Changes) error {
+	if slices.ContainsFunc(changes.Create, func(ep *endpoint.Endpoint) bool { return ep.DNSName == r.failName }) {
+		return r.failErr
+	}
+	r.applied = append(r.applied, changes)
+	return nil
+}
+
+func (r *chunkRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
+	return &endpoint.DomainFilter{}
+}
+
+func (r *chunkRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return endpoints, nil
+}
+
+func TestRunOnce_ApplyChunks(t *testing.T) {
+	tests := []struct {
+		name     string
+		failErr  error
+		wantSoft bool
+	}{
+		{name: "hard failure", failErr: errors.New("apply failed")},
+		{name: "soft failure", failErr: provider.NewSoftError(errors.New("throttled")), wantSoft: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := new(testutils.MockSource)
+			source.On("Endpoints").Return([]*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("c.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+			}, nil)
+
+			r := &chunkRegistry{failName: "b.example.org", failErr: tt.failErr}
+			ctrl := &Controller{
+				Source:             source,
+				Registry:           r,
+				Policy:             &plan.SyncPolicy{},
+				ManagedRecordTypes: []string{endpoint.RecordTypeA},
+				ApplyChunkSize:     1,
+			}
+
+			err := ctrl.RunOnce(t.Context())
+			require.Error(t, err)
+			assert.Equal(t, tt.wantSoft, errors.Is(err, provider.SoftError))
+
+			// the chunks of the other zones are still applied
+			require.Len(t, r.applied, 2)
+			assert.Equal(t, "a.example.com", r.applied[0].Create[0].DNSName)
+			assert.Equal(t, "c.example.net", r.applied[1].Create[0].DNSName)
+		})
+	}
+}
+
+// outcomeSource records the sync outcomes reported to it.
+type outcomeSource struct {
+	testutils.MockSource
+	outcomes []source.SyncOutcome
+}
+
+func (s *outcomeSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	source.OnSyncOutcome(ctx, func(_ context.Context, outcome source.SyncOutcome) {
+		s.outcomes = append(s.outcomes, outcome)
+	})
+	return s.MockSource.Endpoints(ctx)
+}
+
+func TestRunOnce_SyncOutcome(t *testing.T) {
+	tests := []struct {
+		name     string
+		failName string
+		records  []*endpoint.Endpoint
+		want     source.SyncOutcome
+	}{
+		{name: "applied", want: source.SyncOutcomeApplied},
+		{name: "partial", failName: "b.example.org", want: source.SyncOutcomePartial},
+		{name: "failed", failName: "a.example.com", records: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		}, want: source.SyncOutcomeFailed},
+		{name: "noop", records: []*endpoint.Endpoint{}, want: source.SyncOutcomeNoop},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := tt.records
+			if records == nil {
+				records = []*endpoint.Endpoint{
+					endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+					endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				}
+			}
+			src := &outcomeSource{}
+			src.On("Endpoints").Return(records, nil)
+
+			ctrl := &Controller{
+				Source:             src,
+				Registry:           &chunkRegistry{failName: tt.failName, failErr: errors.New("apply failed")},
+				Policy:             &plan.SyncPolicy{},
+				ManagedRecordTypes: []string{endpoint.RecordTypeA},
+				ApplyChunkSize:     1,
+			}
+
+			before := testutil.ToFloat64(syncOutcomesTotal.CounterVec.WithLabelValues(string(tt.want)))
+			_ = ctrl.RunOnce(t.Context())
+
+			assert.Equal(t, []source.SyncOutcome{tt.want}, src.outcomes)
+			assert.InDelta(t, before+1, testutil.ToFloat64(syncOutcomesTotal.CounterVec.WithLabelValues(string(tt.want))), 0)
+		})
+	}
+}
+
+// changesSource records the changes counted for its resources.
+type changesSource struct {
+	testutils.MockSource
+	changes map[string]source.ResourceChanges
+}
+
+func (s *changesSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	source.OnSyncOutcome(ctx, func(ctx context.Context, _ source.SyncOutcome) {
+		for _, resource := range []string{"crd/default/a", "crd/default/b"} {
+			s.changes[resource] = source.ResourceChangesFromContext(ctx, resource)
+		}
+	})
+	return s.MockSource.Endpoints(ctx)
+}
+
+func TestRunOnce_ResourceChanges(t *testing.T) {
+	src := &changesSource{changes: map[string]source.ResourceChanges{}}
+	src.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "crd/default/a"),
+		endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "crd/default/b"),
+		endpoint.NewEndpoint("c.example.net", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "crd/default/b"),
+	}, nil)
+	ctrl := &Controller{
+		Source:             src,
+		Registry:           &chunkRegistry{failName: "b.example.org", failErr: errors.New("apply failed")},
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		ApplyChunkSize:     1,
+	}
+
+	require.Error(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, map[string]source.ResourceChanges{
+		"crd/default/a": {Applied: 1},
+		"crd/default/b": {Applied: 1, Pending: 1},
+	}, src.changes)
+}
+
+// failingRecordsRegistry fails to read the records when fail is set.
+type failingRecordsRegistry struct {
+	chunkRegistry
+	fail bool
+}
+
+func (r *failingRecordsRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	if r.fail {
+		return nil, errors.New("records failed")
+	}
+	return r.chunkRegistry.Records(ctx)
+}
+
+func TestRunOnce_SyncOutcomeFailedBeforeSources(t *testing.T) {
+	src := &outcomeSource{}
+	src.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+	r := &failingRecordsRegistry{}
+	ctrl := &Controller{
+		Source:             src,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	r.fail = true
+	require.Error(t, ctrl.RunOnce(t.Context()))
+	require.Error(t, ctrl.RunOnce(t.Context()))
+	r.fail = false
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+
+	assert.Equal(t, []source.SyncOutcome{
+		source.SyncOutcomeNoop, source.SyncOutcomeFailed, source.SyncOutcomeFailed, source.SyncOutcomeNoop,
+	}, src.outcomes, "the failures before reading the sources are reported to the sources of the previous sync")
+}
+
+func TestRunOnce_SyncOutcomeSkippedDueToCache(t *testing.T) {
+	src := &outcomeSource{}
+	src.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+	r, err := noop.New(nil, provider.NewCachedProvider(inmemory.NewInMemoryProvider(), time.Hour))
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source:             src,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, []source.SyncOutcome{source.SyncOutcomeNoop, source.SyncOutcomeSkippedDueToCache}, src.outcomes)
+}
@@ -20,12 +20,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/logging"
+	"sigs.k8s.io/external-dns/internal/sets"
 	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
@@ -46,6 +50,10 @@ type Controller struct {
 	Policy plan.Policy
 	// The interval between individual synchronizations
 	Interval time.Duration
+	// IntervalJitter delays each periodic synchronization by a random duration up to this value
+	IntervalJitter time.Duration
+	// Schedule replaces Interval to run periodic synchronizations at fixed times when set
+	Schedule *SyncSchedule
 	// The DomainFilter defines which DNS records to keep or exclude
 	DomainFilter endpoint.DomainFilterInterface
 	// The nextRunAt used for throttling and batching reconciliation
@@ -59,81 +67,223 @@ type Controller struct {
 	ManagedRecordTypes []string
 	// ExcludeRecordTypes are DNS record types that will be excluded from management.
 	ExcludeRecordTypes []string
+	// SupportedRecordTypes are DNS record types the provider can manage, nil means any
+	SupportedRecordTypes []string
 	// MinEventSyncInterval is used as a window for batching events
 	MinEventSyncInterval time.Duration
 	// Old txt-owner value we need to migrate from
 	TXTOwnerOld string
+	// TTLRollout stages and caps TTL-only updates when set
+	TTLRollout *plan.TTLRolloutPolicy
+	// ChangeWindow defers changes planned outside of the window when set
+	ChangeWindow *plan.ChangeWindow
+	// DeleteDelay defers deletions until they have been planned for a propagation delay when set
+	DeleteDelay *plan.DeleteDelayPolicy
+	// ProtectedRecords drops every change touching a protected DNS name when set
+	ProtectedRecords *plan.ProtectedRecordsPolicy
+	// ApexDrift reports the apex records drifting from their expected state when set
+	ApexDrift *ApexDriftChecker
+	// Suspension holds back all changes while the synchronizations are suspended when set
+	Suspension *Suspension
+	// DryRun logs the updates at debug level, the provider logging the changes it does not apply
+	DryRun bool
+	// ApplyChunkSize splits the changes in chunks per zone applied one after the other when set
+	ApplyChunkSize int
+	// MaxEndpoints aborts the synchronizations while the sources produce more endpoints when set
+	MaxEndpoints int
+	// Normalizers canonicalize the current and desired records before they are compared when set
+	Normalizers []plan.Normalizer
+	// RecordsZoneLimit caps the zones reported by the registry zone records metric, 0 means no cap
+	RecordsZoneLimit int
+	// drift tracks records planned by consecutive syncs
+	drift driftDetector
+	// FullReconcileInterval forces a sync against the live provider state, bypassing record caches, when set
+	FullReconcileInterval time.Duration
+	// lastFullReconcile is when the last full reconcile succeeded
+	lastFullReconcile time.Time
+	// syncs numbers the synchronizations and keeps the last finished one for /status
+	syncs syncTracker
+	// syncOutcomes reports the outcome of the synchronizations to the sources
+	syncOutcomes source.SyncOutcomeNotifier
 }
 
-// RunOnce runs a single iteration of a reconciliation loop.
+// RunOnce runs a single iteration of a reconciliation loop. Its outcome is
+// counted and reported to the sources registered with source.OnSyncOutcome.
 func (c *Controller) RunOnce(ctx context.Context) error {
+	status := syncStatus{ID: c.syncs.start(), StartedAt: time.Now()}
+	ctx = logging.StartSync(ctx, status.ID)
+	defer logging.FinishSync(ctx)
+	outcome, err := c.runOnce(source.ContextWithSyncOutcomeNotifier(ctx, &c.syncOutcomes))
+	syncOutcomesTotal.CounterVec.WithLabelValues(string(outcome)).Inc()
+	c.syncOutcomes.Notify(ctx, outcome)
+
+	status.Outcome, status.FinishedAt = outcome, time.Now()
+	if err != nil {
+		status.Error = err.Error()
+	}
+	c.syncs.finish(status)
+	log.WithContext(ctx).Debugf("Sync %d finished: %s", status.ID, outcome)
+	return err
+}
+
+func (c *Controller) runOnce(ctx context.Context) (source.SyncOutcome, error) {
 	lastReconcileTimestamp.Gauge.SetToCurrentTime()
 
+	now := time.Now()
 	c.runAtMutex.Lock()
-	c.lastRunAt = time.Now()
+	c.lastRunAt = now
 	c.runAtMutex.Unlock()
 
+	var cached []*endpoint.Endpoint
+	full := false
+	if c.fullReconcileDue(now) {
+		var err error
+		if cached, full, err = c.resetRecordCaches(ctx); err != nil {
+			return source.SyncOutcomeFailed, err
+		}
+	}
+
+	fromCache := &atomic.Bool{}
+	ctx, plan, err := c.calculatePlan(provider.ContextWithRecordsFromCache(ctx, fromCache))
+	if err != nil {
+		return source.SyncOutcomeFailed, err
+	}
+	if c.ApexDrift != nil {
+		c.ApexDrift.check(plan.Current, c.EventEmitter)
+	}
+	if c.Suspension != nil {
+		if c.Suspension.check(ctx) {
+			controllerSuspended.Gauge.Set(1)
+			if plan.Changes.HasChanges() {
+				log.WithContext(ctx).Warnf("Synchronizations are suspended, not applying %d changes", countChanges(plan.Changes))
+			}
+			return source.SyncOutcomeSuspended, nil
+		}
+		controllerSuspended.Gauge.Set(0)
+	}
+	if c.TTLRollout != nil {
+		plan.Changes = c.TTLRollout.Apply(plan.Changes)
+	}
+	planned := plan.Changes
+	if c.DeleteDelay != nil {
+		plan.Changes = c.DeleteDelay.Apply(plan.Changes, now)
+	}
+	if c.ChangeWindow != nil {
+		plan.Changes = c.ChangeWindow.Apply(plan.Changes, now)
+	}
+	deferred := 0
+	if c.DeleteDelay != nil || c.ChangeWindow != nil {
+		if deferred = countDeferredChanges(planned, plan.Changes); deferred > 0 {
+			log.WithContext(ctx).Infof("Deferring %d changes to a later synchronization", deferred)
+		}
+	}
+	driftRecords.Gauge.Set(float64(c.drift.observe(plan.Changes)))
+	// computed before applying, providers may update the current records in place
+	var outOfBand sets.Set[endpoint.EndpointKey]
+	if full {
+		outOfBand = outOfBandRecords(cached, plan.Current)
+	}
+
+	outcome := source.SyncOutcomeApplied
+	if plan.Changes.HasChanges() {
+		err = c.applyChanges(ctx, plan.Changes)
+		if err != nil {
+			registryErrorsTotal.Counter.Inc()
+			deprecatedRegistryErrors.Counter.Inc()
+			var partial *partialApplyError
+			if errors.As(err, &partial) {
+				return source.SyncOutcomePartial, err
+			}
+			return source.SyncOutcomeFailed, err
+		}
+		if full {
+			if n := countCorrections(plan.Changes, outOfBand); n > 0 {
+				log.WithContext(ctx).Infof("Full reconcile: corrected %d records modified outside of external-dns", n)
+			}
+		}
+	} else if deferred == 0 {
+		outcome = source.SyncOutcomeNoop
+		if fromCache.Load() {
+			outcome = source.SyncOutcomeSkippedDueToCache
+		}
+		controllerNoChangesTotal.Counter.Inc()
+		log.WithContext(ctx).Info("All records are already up to date")
+	} else {
+		outcome = source.SyncOutcomeSkipped
+	}
+	if deferred == 0 {
+		// the records match the sources, whether changes were applied or not
+		lastSuccessfulFullSyncTimestamp.Gauge.SetToCurrentTime()
+	}
+
+	lastSyncTimestamp.Gauge.SetToCurrentTime()
+	if c.FullReconcileInterval > 0 && (full || c.lastFullReconcile.IsZero()) {
+		c.lastFullReconcile = now
+	}
+
+	return outcome, nil
+}
+
+// calculatePlan reads the current records from the registry and the desired
+// endpoints from the source and calculates the changes between them. The
+// returned context carries the registry records for the provider.
+func (c *Controller) calculatePlan(ctx context.Context) (context.Context, *plan.Plan, error) {
 	regRecords, err := c.Registry.Records(ctx)
 	if err != nil {
 		registryErrorsTotal.Counter.Inc()
 		deprecatedRegistryErrors.Counter.Inc()
-		return err
+		return ctx, nil, err
 	}
 
 	registryEndpointsTotal.Gauge.Set(float64(len(regRecords)))
 
 	countAddressRecords(regRecords, registryRecords)
+	countZoneRecords(regRecords, c.zoneOf, c.RecordsZoneLimit, registryZoneRecords)
 
 	ctx = context.WithValue(ctx, provider.RecordsContextKey, regRecords)
+	ctx = events.ContextWithEmitter(ctx, c.EventEmitter)
 
 	sourceEndpoints, err := c.Source.Endpoints(ctx)
 	if err != nil {
 		sourceErrorsTotal.Counter.Inc()
 		deprecatedSourceErrors.Counter.Inc()
-		return err
+		return ctx, nil, err
 	}
 
 	sourceEndpointsTotal.Gauge.Set(float64(len(sourceEndpoints)))
+	if c.MaxEndpoints > 0 && len(sourceEndpoints) > c.MaxEndpoints {
+		endpointLimitExceededTotal.Counter.Inc()
+		return ctx, nil, provider.NewSoftErrorf("the sources produced %d endpoints, more than the %d allowed by --max-endpoints: not synchronizing", len(sourceEndpoints), c.MaxEndpoints)
+	}
 
 	countAddressRecords(sourceEndpoints, sourceRecords)
 	countMatchingAddressRecords(sourceEndpoints, regRecords, verifiedRecords)
 
 	endpoints, err := c.Registry.AdjustEndpoints(sourceEndpoints)
 	if err != nil {
-		return fmt.Errorf("adjusting endpoints: %w", err)
+		return ctx, nil, fmt.Errorf("adjusting endpoints: %w", err)
 	}
 	registryFilter := c.Registry.GetDomainFilter()
 
-	plan := &plan.Plan{
-		Policies:       []plan.Policy{c.Policy},
-		Current:        regRecords,
-		Desired:        endpoints,
-		DomainFilter:   endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
-		ManagedRecords: c.ManagedRecordTypes,
-		ExcludeRecords: c.ExcludeRecordTypes,
-		OwnerID:        c.Registry.OwnerID(),
-		OldOwnerID:     c.TXTOwnerOld,
+	policies := []plan.Policy{c.Policy}
+	if c.ProtectedRecords != nil {
+		policies = append(policies, c.ProtectedRecords)
 	}
 
-	plan = plan.Calculate()
-
-	if plan.Changes.HasChanges() {
-		err = c.Registry.ApplyChanges(ctx, plan.Changes)
-		if err != nil {
-			registryErrorsTotal.Counter.Inc()
-			deprecatedRegistryErrors.Counter.Inc()
-			emitChangeEvent(c.EventEmitter, plan.Changes, events.RecordError)
-			return err
-		}
-		emitChangeEvent(c.EventEmitter, plan.Changes, events.RecordReady)
-	} else {
-		controllerNoChangesTotal.Counter.Inc()
-		log.Info("All records are already up to date")
+	p := &plan.Plan{
+		Policies:         policies,
+		Current:          regRecords,
+		Desired:          endpoints,
+		DomainFilter:     endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
+		ManagedRecords:   c.ManagedRecordTypes,
+		ExcludeRecords:   c.ExcludeRecordTypes,
+		SupportedRecords: c.SupportedRecordTypes,
+		OwnerID:          c.Registry.OwnerID(),
+		OldOwnerID:       c.TXTOwnerOld,
+		Normalizers:      c.Normalizers,
 	}
 
-	lastSyncTimestamp.Gauge.SetToCurrentTime()
-
-	return nil
+	return ctx, p.Calculate(), nil
 }
 
 func earliest(r time.Time, times ...time.Time) time.Time {
@@ -173,10 +323,23 @@ func (c *Controller) ShouldRunOnce(now time.Time) bool {
 	if now.Before(c.nextRunAt) {
 		return false
 	}
-	c.nextRunAt = now.Add(c.Interval)
+	c.nextRunAt = c.nextPeriodicRun(now)
 	return true
 }
 
+// nextPeriodicRun returns when the periodic synchronization following now is due.
+// Events can still trigger earlier synchronizations through ScheduleRunOnce.
+func (c *Controller) nextPeriodicRun(now time.Time) time.Time {
+	next := now.Add(c.Interval)
+	if c.Schedule != nil {
+		next = c.Schedule.Next(now)
+	}
+	if c.IntervalJitter > 0 {
+		next = next.Add(rand.N(c.IntervalJitter))
+	}
+	return next
+}
+
 // Run runs RunOnce in a loop with a delay until context is canceled
 func (c *Controller) Run(ctx context.Context) error {
 	ticker := time.NewTicker(time.Second)
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/wrappers"
+)
+
+// benchmarkSizes are the numbers of Services, one endpoint each, the sync loop benchmarks run with.
+// The largest one is skipped with -short.
+var benchmarkSizes = []int{1_000, 10_000, 100_000}
+
+// BenchmarkSourceEndpoints measures collecting the endpoints of the Services through
+// the source wrappers, the first step of every synchronization.
+func BenchmarkSourceEndpoints(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("endpoints=%d", size), func(b *testing.B) {
+			_, src := newBenchmarkSource(b, size)
+			b.ReportAllocs()
+			for b.Loop() {
+				endpoints, err := src.Endpoints(b.Context())
+				require.NoError(b, err)
+				require.Len(b, endpoints, size)
+			}
+		})
+	}
+}
+
+// BenchmarkRunOnceCreate measures a synchronization creating every record in an empty zone.
+func BenchmarkRunOnceCreate(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("endpoints=%d", size), func(b *testing.B) {
+			cfg, src := newBenchmarkSource(b, size)
+			b.ReportAllocs()
+			for b.Loop() {
+				b.StopTimer()
+				ctrl := newBenchmarkController(b, cfg, src)
+				b.StartTimer()
+				require.NoError(b, ctrl.RunOnce(b.Context()))
+			}
+		})
+	}
+}
+
+// BenchmarkRunOnceSteady measures a synchronization finding every record up to date,
+// which is what most synchronizations of a large cluster do.
+func BenchmarkRunOnceSteady(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("endpoints=%d", size), func(b *testing.B) {
+			cfg, src := newBenchmarkSource(b, size)
+			ctrl := newBenchmarkController(b, cfg, src)
+			require.NoError(b, ctrl.RunOnce(b.Context()))
+			records, err := ctrl.Registry.Records(b.Context())
+			require.NoError(b, err)
+			require.Len(b, records, size)
+			b.ReportAllocs()
+			for b.Loop() {
+				require.NoError(b, ctrl.RunOnce(b.Context()))
+			}
+		})
+	}
+}
+
+// newBenchmarkSource builds the service source, wrapped like the controller does it, on a fake
+// cluster of size LoadBalancer Services spread over 100 namespaces.
+func newBenchmarkSource(b *testing.B, size int) (*externaldns.Config, source.Source) {
+	b.Helper()
+	if testing.Short() && size > 10_000 {
+		b.Skip("skipping the largest size in short mode")
+	}
+	level := log.GetLevel()
+	log.SetLevel(log.ErrorLevel)
+	b.Cleanup(func() { log.SetLevel(level) })
+
+	objects := make([]runtime.Object, 0, size)
+	for i := range size {
+		objects = append(objects, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fmt.Sprintf("svc-%d", i),
+				Namespace:   fmt.Sprintf("ns-%d", i%100),
+				Annotations: map[string]string{annotations.HostnameKey: fmt.Sprintf("svc-%d.example.org", i)},
+			},
+			Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: fmt.Sprintf("10.%d.%d.%d", i>>16&0xff, i>>8&0xff, i&0xff)}},
+			}},
+		})
+	}
+
+	cfg := externaldns.NewConfig()
+	require.NoError(b, cfg.ParseFlags([]string{
+		"--source=service",
+		"--provider=inmemory",
+		"--registry=txt",
+		"--txt-owner-id=benchmark",
+		"--policy=sync",
+		"--domain-filter=example.org",
+	}))
+	sCfg, err := source.NewSourceConfig(cfg, source.WithClientGenerator(testutils.NewFakeClientGenerator(fake.NewClientset(objects...))))
+	require.NoError(b, err)
+	src, err := wrappers.Build(b.Context(), sCfg)
+	require.NoError(b, err)
+	return cfg, src
+}
+
+// newBenchmarkController builds a controller synchronizing src to an empty in-memory zone.
+func newBenchmarkController(b *testing.B, cfg *externaldns.Config, src source.Source) *Controller {
+	b.Helper()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org"}))
+	ctrl, err := buildController(cfg, src, p, endpoint.NewDomainFilter(cfg.DomainFilter), nil)
+	require.NoError(b, err)
+	return ctrl
+}
@@ -36,6 +36,7 @@ import (
 	registryfactory "sigs.k8s.io/external-dns/registry/factory"
 	"sigs.k8s.io/external-dns/registry/noop"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -240,6 +241,122 @@ func TestRunOnce(t *testing.T) {
 	emitter.AssertNumberOfCalls(t, "Add", 6)
 }
 
+// TestRunOnce_ChangeWindow tests that changes planned outside of the change window are deferred.
+func TestRunOnce_ChangeWindow(t *testing.T) {
+	cfg := getTestConfig()
+	provider := getTestProvider()
+	expected := provider.(*mockProvider).ExpectChanges
+	// only the deletions are expected outside of the window
+	provider.(*mockProvider).ExpectChanges = &plan.Changes{Delete: expected.Delete}
+
+	r, err := registryfactory.Select(cfg, provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             getTestSource(),
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+		// a window without any day never opens
+		ChangeWindow: &plan.ChangeWindow{Location: time.UTC},
+	}
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 2, deferredChanges.Gauge, map[string]string{"action": "create"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 2, deferredChanges.Gauge, map[string]string{"action": "update"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, deferredChanges.Gauge, map[string]string{"action": "delete"})
+
+	// holding deletions leaves nothing to apply
+	provider.(*mockProvider).ExpectChanges = &plan.Changes{}
+	ctrl.ChangeWindow.HoldDeletes = true
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 2, deferredChanges.Gauge, map[string]string{"action": "delete"})
+}
+
+// TestRunOnce_DeleteDelay tests that deletions are applied by a later synchronization than the creates and updates.
+func TestRunOnce_DeleteDelay(t *testing.T) {
+	cfg := getTestConfig()
+	provider := getTestProvider()
+	expected := provider.(*mockProvider).ExpectChanges
+	// the deletions are held back by the first synchronization
+	provider.(*mockProvider).ExpectChanges = &plan.Changes{
+		Create:    expected.Create,
+		UpdateOld: expected.UpdateOld,
+		UpdateNew: expected.UpdateNew,
+	}
+
+	r, err := registryfactory.Select(cfg, provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             getTestSource(),
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+		DeleteDelay:        plan.NewDeleteDelayPolicy(time.Hour),
+	}
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 2, deferredChanges.Gauge, map[string]string{"action": "delete"})
+
+	// the deletions are applied once the delay elapsed
+	provider.(*mockProvider).ExpectChanges = expected
+	ctrl.DeleteDelay.Delay = time.Nanosecond
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, deferredChanges.Gauge, map[string]string{"action": "delete"})
+}
+
+// TestRunOnce_MaxEndpoints tests that a synchronization is aborted with a soft error when the sources produce too many endpoints.
+func TestRunOnce_MaxEndpoints(t *testing.T) {
+	cfg := getTestConfig()
+	r, err := registryfactory.Select(cfg, getTestProvider())
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             getTestSource(),
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+		MaxEndpoints:       1,
+	}
+
+	before := testutil.ToFloat64(endpointLimitExceededTotal.Counter)
+	err = ctrl.RunOnce(t.Context())
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, "more than the 1 allowed by --max-endpoints")
+	assert.InDelta(t, before+1, testutil.ToFloat64(endpointLimitExceededTotal.Counter), 0)
+
+	// the changes are applied again below the limit
+	ctrl.MaxEndpoints = 100
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+}
+
+// TestRunOnce_LastSuccessfulFullSync tests that a sync applying its changes counts as a full sync, unlike one deferring them.
+func TestRunOnce_LastSuccessfulFullSync(t *testing.T) {
+	cfg := getTestConfig()
+	r, err := registryfactory.Select(cfg, getTestProvider())
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             getTestSource(),
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+		// a window without any day never opens, the deletions are held as well
+		ChangeWindow: &plan.ChangeWindow{Location: time.UTC, HoldDeletes: true},
+	}
+
+	lastSuccessfulFullSyncTimestamp.Gauge.Set(0)
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Zero(t, testutil.ToFloat64(lastSuccessfulFullSyncTimestamp.Gauge))
+
+	ctrl.ChangeWindow = nil
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Positive(t, testutil.ToFloat64(lastSuccessfulFullSyncTimestamp.Gauge))
+}
+
 // TestRun tests that Run correctly starts and stops
 func TestRun(t *testing.T) {
 	source := getTestSource()
@@ -331,6 +448,37 @@ func TestShouldRunOnce(t *testing.T) {
 	assert.True(t, ctrl.ShouldRunOnce(now))
 }
 
+func TestShouldRunOnceSchedule(t *testing.T) {
+	schedule, err := ParseSyncSchedule("*/10 * * * *", time.UTC)
+	require.NoError(t, err)
+	ctrl := &Controller{Interval: time.Minute, MinEventSyncInterval: 15 * time.Second, Schedule: schedule}
+
+	now := time.Date(2026, 10, 18, 10, 3, 0, 0, time.UTC)
+	assert.True(t, ctrl.ShouldRunOnce(now))
+	assert.Equal(t, time.Date(2026, 10, 18, 10, 10, 0, 0, time.UTC), ctrl.nextRunAt)
+	assert.False(t, ctrl.ShouldRunOnce(now.Add(time.Minute)))
+	ctrl.lastRunAt = now
+
+	// events still trigger a synchronization after MinEventSyncInterval
+	now = now.Add(time.Minute)
+	ctrl.ScheduleRunOnce(now)
+	assert.False(t, ctrl.ShouldRunOnce(now))
+	assert.True(t, ctrl.ShouldRunOnce(now.Add(5*time.Second)))
+	assert.Equal(t, time.Date(2026, 10, 18, 10, 10, 0, 0, time.UTC), ctrl.nextRunAt)
+}
+
+func TestShouldRunOnceJitter(t *testing.T) {
+	ctrl := &Controller{Interval: 10 * time.Minute, IntervalJitter: time.Minute}
+
+	now := time.Now()
+	for range 20 {
+		ctrl.nextRunAt = time.Time{}
+		assert.True(t, ctrl.ShouldRunOnce(now))
+		assert.False(t, ctrl.nextRunAt.Before(now.Add(10*time.Minute)))
+		assert.True(t, ctrl.nextRunAt.Before(now.Add(11*time.Minute)))
+	}
+}
+
 func testControllerFiltersDomains(t *testing.T, configuredEndpoints []*endpoint.Endpoint, domainFilter *endpoint.DomainFilter, providerEndpoints []*endpoint.Endpoint, expectedChanges []*plan.Changes) {
 	t.Helper()
 	cfg := externaldns.NewConfig()
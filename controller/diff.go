@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// diffExitCodeChanges is the exit code of the diff mode when changes are pending,
+// following terraform plan -detailed-exitcode. Errors exit with 1.
+const diffExitCodeChanges = 2
+
+// Diff calculates the changes a synchronization would make and writes them to w,
+// without applying them.
+func (c *Controller) Diff(ctx context.Context, w io.Writer) (*plan.Changes, error) {
+	_, p, err := c.calculatePlan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeChanges(w, p.Changes); err != nil {
+		return nil, err
+	}
+	return p.Changes, nil
+}
+
+// writeChanges writes a human readable summary of changes to w.
+func writeChanges(w io.Writer, changes *plan.Changes) error {
+	if !changes.HasChanges() {
+		_, err := fmt.Fprintln(w, "No changes. All records are already up to date.")
+		return err
+	}
+
+	ew := &errWriter{w: w}
+	writeEndpoints(ew, "+", changes.Create)
+	for i := range changes.UpdateNew {
+		ew.printf("~ %s\n  -> %s\n", changes.UpdateOld[i], changes.UpdateNew[i])
+	}
+	writeEndpoints(ew, "-", changes.Delete)
+	ew.printf("\nPlan: %d to create, %d to update, %d to delete.\n",
+		len(changes.Create), len(changes.UpdateNew), len(changes.Delete))
+	return ew.err
+}
+
+func writeEndpoints(ew *errWriter, prefix string, endpoints []*endpoint.Endpoint) {
+	for _, ep := range endpoints {
+		ew.printf("%s %s\n", prefix, ep)
+	}
+}
+
+// errWriter keeps the first write error so that it is checked once.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...any) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = fmt.Fprintf(ew.w, format, args...)
+}
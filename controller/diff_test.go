@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	registryfactory "sigs.k8s.io/external-dns/registry/factory"
+)
+
+func TestDiff(t *testing.T) {
+	cfg := getTestConfig()
+	p := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("update-record", endpoint.RecordTypeA, "8.8.8.8"),
+			endpoint.NewEndpoint("delete-record", endpoint.RecordTypeA, "4.3.2.1"),
+		},
+	}
+	r, err := registryfactory.Select(cfg, p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             getTestSource(),
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+	}
+
+	var out bytes.Buffer
+	changes, err := ctrl.Diff(t.Context(), &out)
+	require.NoError(t, err)
+
+	assert.True(t, changes.HasChanges())
+	assert.Empty(t, p.ApplyChangesCalls)
+	assert.Contains(t, out.String(), "+ create-record 0 IN A  1.2.3.4 []\n")
+	assert.Contains(t, out.String(), "~ update-record 0 IN A  8.8.8.8 []\n  -> update-record 0 IN A  8.8.4.4 []\n")
+	assert.Contains(t, out.String(), "- delete-record 0 IN A  4.3.2.1 []\n")
+	assert.Contains(t, out.String(), "Plan: 3 to create, 1 to update, 1 to delete.\n")
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	cfg := getTestConfig()
+	records := []*endpoint.Endpoint{endpoint.NewEndpoint("record", endpoint.RecordTypeA, "1.2.3.4")}
+	p := &filteredMockProvider{RecordsStore: records}
+	r, err := registryfactory.Select(cfg, p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             testutils.NewMockSource(records...),
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+	}
+
+	var out bytes.Buffer
+	changes, err := ctrl.Diff(t.Context(), &out)
+	require.NoError(t, err)
+
+	assert.False(t, changes.HasChanges())
+	assert.Equal(t, "No changes. All records are already up to date.\n", out.String())
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/wrappers"
+)
+
+// maxReportedRejections caps the rejected endpoints listed in the status of a DNSPolicy.
+const maxReportedRejections = 50
+
+// dnsPolicies holds the DNSPolicies applied to the endpoints. They are kept in
+// sync with the cluster by watchDNSPolicies.
+type dnsPolicies struct {
+	mu       sync.RWMutex
+	policies []*apiv1alpha1.DNSPolicy
+
+	// status writes the rejected endpoints to the policies, nil when the status is not reported
+	status client.StatusClient
+}
+
+// set replaces the policies, evaluated in name order.
+func (p *dnsPolicies) set(policies []*apiv1alpha1.DNSPolicy) {
+	slices.SortFunc(policies, func(a, b *apiv1alpha1.DNSPolicy) int { return cmp.Compare(a.Name, b.Name) })
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.policies = policies
+}
+
+func (p *dnsPolicies) list() []*apiv1alpha1.DNSPolicy {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.policies
+}
+
+// dnsPolicyWrapper returns the source wrapper applying the policies to the endpoints.
+func dnsPolicyWrapper(p *dnsPolicies) wrappers.SourceWrapper {
+	return wrappers.SourceWrapper{
+		Name: "dns-policy",
+		Wrap: func(src source.Source, _ *wrappers.Config) (source.Source, error) {
+			return &dnsPolicySource{source: src, policies: p}, nil
+		},
+	}
+}
+
+// dnsPolicySource is a Source clamping the TTL of endpoints and dropping the
+// endpoints rejected by the DNSPolicies.
+type dnsPolicySource struct {
+	source   source.Source
+	policies *dnsPolicies
+}
+
+func (s *dnsPolicySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policies := s.policies.list()
+	if len(policies) == 0 {
+		return endpoints, nil
+	}
+
+	rejected := make(map[string][]apiv1alpha1.RejectedEndpoint, len(policies))
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if policy, reason := applyDNSPolicies(ep, policies); policy != nil {
+			log.Warnf("DNSPolicy %q rejected endpoint %s %s: %s", policy.Name, ep.DNSName, ep.RecordType, reason)
+			rejected[policy.Name] = append(rejected[policy.Name], apiv1alpha1.RejectedEndpoint{
+				DNSName:    ep.DNSName,
+				RecordType: ep.RecordType,
+				Resource:   ep.Labels[endpoint.ResourceLabelKey],
+				Reason:     reason,
+			})
+			continue
+		}
+		result = append(result, ep)
+	}
+	s.policies.reportRejected(ctx, policies, rejected)
+	return result, nil
+}
+
+func (s *dnsPolicySource) AddEventHandler(ctx context.Context, handler func()) {
+	s.source.AddEventHandler(ctx, handler)
+}
+
+// applyDNSPolicies clamps the TTL of the endpoint with the policies selecting it.
+// It returns the first policy rejecting the endpoint, with the reason.
+func applyDNSPolicies(ep *endpoint.Endpoint, policies []*apiv1alpha1.DNSPolicy) (*apiv1alpha1.DNSPolicy, string) {
+	for _, policy := range policies {
+		spec := policy.Spec
+		if !dnsPolicySelects(spec, ep) {
+			continue
+		}
+		if spec.MinTTL != nil && ep.RecordTTL < endpoint.TTL(*spec.MinTTL) {
+			ep.RecordTTL = endpoint.TTL(*spec.MinTTL)
+		}
+		if spec.MaxTTL != nil && ep.RecordTTL > endpoint.TTL(*spec.MaxTTL) {
+			ep.RecordTTL = endpoint.TTL(*spec.MaxTTL)
+		}
+		if len(spec.AllowedTargets) == 0 {
+			continue
+		}
+		for _, target := range ep.Targets {
+			if !targetAllowed(target, spec.AllowedTargets) {
+				return policy, fmt.Sprintf("target %q is not allowed", target)
+			}
+		}
+	}
+	return nil, ""
+}
+
+// dnsPolicySelects reports whether the endpoint is in the scope of the policy.
+func dnsPolicySelects(spec apiv1alpha1.DNSPolicySpec, ep *endpoint.Endpoint) bool {
+	if len(spec.RecordTypes) > 0 && !slices.Contains(spec.RecordTypes, ep.RecordType) {
+		return false
+	}
+	if len(spec.Namespaces) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(endpointNamespaces(ep), func(ns string) bool {
+		return slices.Contains(spec.Namespaces, ns)
+	})
+}
+
+// endpointNamespaces returns the namespaces of the resources the endpoint was generated from.
+func endpointNamespaces(ep *endpoint.Endpoint) []string {
+	var namespaces []string
+	for _, ref := range ep.RefObjects() {
+		if ref.Namespace() != "" {
+			namespaces = append(namespaces, ref.Namespace())
+		}
+	}
+	if len(namespaces) == 0 {
+		// each resource of the label has the form kind/namespace/name
+		for _, resource := range ep.Labels.Resources() {
+			if parts := strings.Split(resource, "/"); len(parts) == 3 {
+				namespaces = append(namespaces, parts[1])
+			}
+		}
+	}
+	return namespaces
+}
+
+// targetAllowed reports whether the target matches a hostname, a wildcard hostname or a CIDR pattern.
+func targetAllowed(target string, patterns []string) bool {
+	target = strings.TrimSuffix(strings.ToLower(target), ".")
+	addr, addrErr := netip.ParseAddr(target)
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(strings.ToLower(pattern), ".")
+		if prefix, err := netip.ParsePrefix(pattern); err == nil {
+			if addrErr == nil && prefix.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if suffix, ok := strings.CutPrefix(pattern, "*"); ok && strings.HasSuffix(target, suffix) {
+			return true
+		}
+		if target == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// reportRejected writes the rejected endpoints to the status of the policies whose status changed.
+func (p *dnsPolicies) reportRejected(ctx context.Context, policies []*apiv1alpha1.DNSPolicy, rejected map[string][]apiv1alpha1.RejectedEndpoint) {
+	if p.status == nil {
+		return
+	}
+	for _, policy := range policies {
+		status := dnsPolicyStatus(policy, rejected[policy.Name])
+		if equality.Semantic.DeepEqual(status, policy.Status) {
+			continue
+		}
+		updated := policy.DeepCopy()
+		updated.Status = status
+		if err := p.status.Status().Patch(ctx, updated, client.MergeFrom(policy)); err != nil {
+			log.Errorf("Failed to update the status of DNSPolicy %q: %v", policy.Name, err)
+		}
+	}
+}
+
+func dnsPolicyStatus(policy *apiv1alpha1.DNSPolicy, rejected []apiv1alpha1.RejectedEndpoint) apiv1alpha1.DNSPolicyStatus {
+	slices.SortFunc(rejected, func(a, b apiv1alpha1.RejectedEndpoint) int {
+		return cmp.Or(cmp.Compare(a.DNSName, b.DNSName), cmp.Compare(a.RecordType, b.RecordType), cmp.Compare(a.Resource, b.Resource))
+	})
+	status := apiv1alpha1.DNSPolicyStatus{
+		ObservedGeneration: policy.Generation,
+		RejectedCount:      len(rejected),
+	}
+	if len(rejected) > 0 {
+		status.RejectedEndpoints = rejected[:min(len(rejected), maxReportedRejections)]
+	}
+	return status
+}
+
+// reload replaces the policies with the ones read from reader.
+func (p *dnsPolicies) reload(ctx context.Context, reader client.Reader) error {
+	list := &apiv1alpha1.DNSPolicyList{}
+	if err := reader.List(ctx, list); err != nil {
+		return err
+	}
+	policies := make([]*apiv1alpha1.DNSPolicy, 0, len(list.Items))
+	for i := range list.Items {
+		policies = append(policies, &list.Items[i])
+	}
+	p.set(policies)
+	return nil
+}
+
+// watchDNSPolicies keeps p in sync with the DNSPolicies of the cluster and
+// calls onChange after every change of a policy spec. The rejected endpoints
+// are reported in the status of the policies unless dryRun is set. It returns
+// once the policies present on startup are loaded.
+func watchDNSPolicies(ctx context.Context, restConfig *rest.Config, timeout time.Duration, dryRun bool, p *dnsPolicies, onChange func()) error {
+	scheme := runtime.NewScheme()
+	if err := apiv1alpha1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	metav1.AddToGroupVersion(scheme, apiv1alpha1.GroupVersion)
+
+	c, err := crcache.New(restConfig, crcache.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("unable to create DNSPolicy cache: %w", err)
+	}
+	informer, err := c.GetInformer(ctx, &apiv1alpha1.DNSPolicy{})
+	if err != nil {
+		return fmt.Errorf("unable to get DNSPolicy informer: %w", err)
+	}
+	if !dryRun {
+		statusClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			return fmt.Errorf("unable to create DNSPolicy client: %w", err)
+		}
+		p.status = statusClient
+	}
+
+	go func() {
+		if err := c.Start(ctx); err != nil {
+			log.Errorf("DNSPolicy cache stopped: %v", err)
+		}
+	}()
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	syncCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if !c.WaitForCacheSync(syncCtx) {
+		return fmt.Errorf("DNSPolicy cache failed to sync: %w", syncCtx.Err())
+	}
+	if err := p.reload(ctx, c); err != nil {
+		return err
+	}
+	log.Infof("Loaded %d DNSPolicies", len(p.list()))
+
+	handler := func(specChanged bool) {
+		if err := p.reload(ctx, c); err != nil {
+			log.Errorf("Failed to reload DNSPolicies: %v", err)
+			return
+		}
+		// status updates are reloaded so that they are not written again, but
+		// don't trigger a synchronization
+		if specChanged {
+			onChange()
+		}
+	}
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(any) { handler(true) },
+		UpdateFunc: func(oldObj, newObj any) {
+			oldPolicy, okOld := oldObj.(*apiv1alpha1.DNSPolicy)
+			newPolicy, okNew := newObj.(*apiv1alpha1.DNSPolicy)
+			handler(!okOld || !okNew || oldPolicy.Generation != newPolicy.Generation)
+		},
+		DeleteFunc: func(any) { handler(true) },
+	})
+	return err
+}
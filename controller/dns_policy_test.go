@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+func newDNSPolicy(name string, spec apiv1alpha1.DNSPolicySpec) *apiv1alpha1.DNSPolicy {
+	return &apiv1alpha1.DNSPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Generation: 1},
+		Spec:       spec,
+	}
+}
+
+func policyTestEndpoint(dnsName, recordType string, ttl endpoint.TTL, resource string, targets ...string) *endpoint.Endpoint {
+	return endpoint.NewEndpointWithTTL(dnsName, recordType, ttl, targets...).WithLabel(endpoint.ResourceLabelKey, resource)
+}
+
+func TestApplyDNSPolicies(t *testing.T) {
+	policies := []*apiv1alpha1.DNSPolicy{
+		newDNSPolicy("cname-targets", apiv1alpha1.DNSPolicySpec{
+			RecordTypes:    []string{endpoint.RecordTypeCNAME},
+			AllowedTargets: []string{"*.corp.example.com", "lb.example.net"},
+		}),
+		newDNSPolicy("team-a", apiv1alpha1.DNSPolicySpec{
+			Namespaces:     []string{"team-a"},
+			MinTTL:         ptr.To[int64](300),
+			MaxTTL:         ptr.To[int64](3600),
+			AllowedTargets: []string{"10.0.0.0/8", "*.corp.example.com"},
+		}),
+	}
+
+	for _, tt := range []struct {
+		name       string
+		ep         *endpoint.Endpoint
+		rejectedBy string
+		ttl        endpoint.TTL
+	}{
+		{
+			name: "endpoints outside the scope are unchanged",
+			ep:   policyTestEndpoint("a.example.com", endpoint.RecordTypeA, 60, "service/team-b/a", "192.0.2.1"),
+			ttl:  60,
+		},
+		{
+			name: "ttl below the minimum is raised",
+			ep:   policyTestEndpoint("a.example.com", endpoint.RecordTypeA, 60, "service/team-a/a", "10.1.2.3"),
+			ttl:  300,
+		},
+		{
+			name: "unset ttl is raised",
+			ep:   policyTestEndpoint("a.example.com", endpoint.RecordTypeA, 0, "service/team-a/a", "10.1.2.3"),
+			ttl:  300,
+		},
+		{
+			name: "ttl above the maximum is lowered",
+			ep:   policyTestEndpoint("a.example.com", endpoint.RecordTypeA, 86400, "service/team-a/a", "10.1.2.3"),
+			ttl:  3600,
+		},
+		{
+			name: "merged resource label in the scope",
+			ep:   policyTestEndpoint("a.example.com", endpoint.RecordTypeA, 60, "service/team-a/a;service/team-b/a", "10.1.2.3"),
+			ttl:  300,
+		},
+		{
+			name:       "target outside the allowed cidr is rejected",
+			ep:         policyTestEndpoint("a.example.com", endpoint.RecordTypeA, 300, "service/team-a/a", "10.1.2.3", "192.0.2.1"),
+			rejectedBy: "team-a",
+			ttl:        300,
+		},
+		{
+			name: "cname target within the allowed wildcard",
+			ep:   policyTestEndpoint("b.example.com", endpoint.RecordTypeCNAME, 300, "ingress/team-b/b", "app.corp.example.com"),
+			ttl:  300,
+		},
+		{
+			name:       "cname target outside the allowed wildcard is rejected",
+			ep:         policyTestEndpoint("b.example.com", endpoint.RecordTypeCNAME, 300, "ingress/team-b/b", "app.example.org"),
+			rejectedBy: "cname-targets",
+			ttl:        300,
+		},
+		{
+			name: "exact target with a trailing dot",
+			ep:   policyTestEndpoint("b.example.com", endpoint.RecordTypeCNAME, 300, "ingress/team-b/b", "lb.example.net."),
+			ttl:  300,
+		},
+		{
+			name:       "wildcard does not match the bare domain",
+			ep:         policyTestEndpoint("b.example.com", endpoint.RecordTypeCNAME, 300, "ingress/team-b/b", "corp.example.com"),
+			rejectedBy: "cname-targets",
+			ttl:        300,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, reason := applyDNSPolicies(tt.ep, policies)
+			if tt.rejectedBy == "" {
+				assert.Nil(t, policy, reason)
+			} else {
+				require.NotNil(t, policy)
+				assert.Equal(t, tt.rejectedBy, policy.Name)
+				assert.Contains(t, reason, "is not allowed")
+			}
+			assert.Equal(t, tt.ttl, tt.ep.RecordTTL)
+		})
+	}
+}
+
+func TestDNSPolicySourceReportsRejectedEndpoints(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, apiv1alpha1.AddToScheme(s))
+	c := fake.NewClientBuilder().WithScheme(s).WithStatusSubresource(&apiv1alpha1.DNSPolicy{}).WithObjects(
+		newDNSPolicy("targets", apiv1alpha1.DNSPolicySpec{AllowedTargets: []string{"10.0.0.0/8"}}),
+		newDNSPolicy("ttl", apiv1alpha1.DNSPolicySpec{MinTTL: ptr.To[int64](300)}),
+	).Build()
+
+	policies := &dnsPolicies{status: c}
+	require.NoError(t, policies.reload(t.Context(), c))
+
+	src := &dnsPolicySource{
+		source: testutils.NewMockSource(
+			policyTestEndpoint("ok.example.com", endpoint.RecordTypeA, 0, "service/default/ok", "10.0.0.1"),
+			policyTestEndpoint("public.example.com", endpoint.RecordTypeA, 0, "service/default/public", "192.0.2.1"),
+		),
+		policies: policies,
+	}
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	testutils.ValidateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		policyTestEndpoint("ok.example.com", endpoint.RecordTypeA, 300, "service/default/ok", "10.0.0.1"),
+	})
+
+	updated := &apiv1alpha1.DNSPolicy{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Name: "targets"}, updated))
+	assert.Equal(t, apiv1alpha1.DNSPolicyStatus{
+		ObservedGeneration: 1,
+		RejectedCount:      1,
+		RejectedEndpoints: []apiv1alpha1.RejectedEndpoint{{
+			DNSName:    "public.example.com",
+			RecordType: endpoint.RecordTypeA,
+			Resource:   "service/default/public",
+			Reason:     `target "192.0.2.1" is not allowed`,
+		}},
+	}, updated.Status)
+
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Name: "ttl"}, updated))
+	assert.Equal(t, apiv1alpha1.DNSPolicyStatus{ObservedGeneration: 1}, updated.Status)
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+// policyDomainFilter is the domain filter of the controller when a
+// DomainFilterPolicy is selected. It combines the domain filter flags with the
+// policy, and is swapped at runtime whenever the policy changes.
+type policyDomainFilter struct {
+	// base holds the domain filter flags
+	base apiv1alpha1.DomainFilterPolicySpec
+
+	mu     sync.RWMutex
+	filter endpoint.DomainFilterInterface
+}
+
+func newPolicyDomainFilter(cfg *externaldns.Config) *policyDomainFilter {
+	base := apiv1alpha1.DomainFilterPolicySpec{
+		Include: cfg.DomainFilter,
+		Exclude: cfg.DomainExclude,
+	}
+	if cfg.RegexDomainFilter != nil {
+		base.RegexInclude = cfg.RegexDomainFilter.String()
+	}
+	if cfg.RegexDomainExclude != nil {
+		base.RegexExclude = cfg.RegexDomainExclude.String()
+	}
+	f := &policyDomainFilter{base: base}
+	// the flags were validated on startup
+	f.filter, _ = newDomainFilterFromSpec(base)
+	return f
+}
+
+// Match checks whether a domain is managed with the current policy.
+func (f *policyDomainFilter) Match(domain string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.filter.Match(domain)
+}
+
+// MatchExplain explains whether a domain is managed with the current policy.
+func (f *policyDomainFilter) MatchExplain(domain string) endpoint.DomainFilterMatch {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return endpoint.ExplainDomainFilterMatch(f.filter, domain)
+}
+
+// apply swaps the filter for the one of the given policy. A nil policy
+// restores the domain filter flags. An invalid policy keeps the current filter.
+func (f *policyDomainFilter) apply(policy *apiv1alpha1.DomainFilterPolicy) error {
+	filter, err := f.newFilter(policy)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.filter = filter
+	return nil
+}
+
+// newFilter returns the effective domain filter of a policy.
+func (f *policyDomainFilter) newFilter(policy *apiv1alpha1.DomainFilterPolicy) (endpoint.DomainFilterInterface, error) {
+	if policy == nil {
+		return newDomainFilterFromSpec(f.base)
+	}
+	var filter endpoint.DomainFilterInterface
+	var err error
+	if policy.Spec.Mode == apiv1alpha1.DomainFilterPolicyAugment {
+		filter, err = newAugmentedDomainFilter(f.base, policy.Spec)
+	} else {
+		filter, err = newDomainFilterFromSpec(policy.Spec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid DomainFilterPolicy %q: %w", policy.Name, err)
+	}
+	return filter, nil
+}
+
+// augmentedDomainFilter manages the domains included by the flags or by an
+// Augment policy, unless one of them excludes them. Each side keeps the
+// precedence of its regular expressions over its domain lists.
+type augmentedDomainFilter struct {
+	include []*endpoint.DomainFilter
+	exclude []*endpoint.DomainFilter
+}
+
+func newAugmentedDomainFilter(base, policy apiv1alpha1.DomainFilterPolicySpec) (*augmentedDomainFilter, error) {
+	f := &augmentedDomainFilter{}
+	for _, spec := range []apiv1alpha1.DomainFilterPolicySpec{base, policy} {
+		include, err := newDomainFilterFromSpec(apiv1alpha1.DomainFilterPolicySpec{Include: spec.Include, RegexInclude: spec.RegexInclude})
+		if err != nil {
+			return nil, err
+		}
+		exclude, err := newDomainFilterFromSpec(apiv1alpha1.DomainFilterPolicySpec{Exclude: spec.Exclude, RegexExclude: spec.RegexExclude})
+		if err != nil {
+			return nil, err
+		}
+		// a policy without includes adds no domain, the flags without includes match all of them
+		if include.IsConfigured() || len(f.include) == 0 {
+			f.include = append(f.include, include)
+		}
+		f.exclude = append(f.exclude, exclude)
+	}
+	return f, nil
+}
+
+// Match checks whether a domain is included by the flags or the policy and excluded by neither.
+func (f *augmentedDomainFilter) Match(domain string) bool {
+	return f.MatchExplain(domain).Matched
+}
+
+// MatchExplain explains the outcome of Match with the rule of the flags or the policy deciding it.
+func (f *augmentedDomainFilter) MatchExplain(domain string) endpoint.DomainFilterMatch {
+	for _, exclude := range f.exclude {
+		if result := exclude.MatchExplain(domain); !result.Matched {
+			return result
+		}
+	}
+	var result endpoint.DomainFilterMatch
+	for _, include := range f.include {
+		if result = include.MatchExplain(domain); result.Matched {
+			return result
+		}
+	}
+	return result
+}
+
+func newDomainFilterFromSpec(spec apiv1alpha1.DomainFilterPolicySpec) (*endpoint.DomainFilter, error) {
+	var include, exclude *regexp.Regexp
+	var err error
+	if spec.RegexInclude != "" {
+		if include, err = regexp.Compile(spec.RegexInclude); err != nil {
+			return nil, fmt.Errorf("regexInclude: %w", err)
+		}
+	}
+	if spec.RegexExclude != "" {
+		if exclude, err = regexp.Compile(spec.RegexExclude); err != nil {
+			return nil, fmt.Errorf("regexExclude: %w", err)
+		}
+	}
+	return endpoint.NewDomainFilterWithOptions(
+		endpoint.WithDomainFilter(spec.Include),
+		endpoint.WithDomainExclude(spec.Exclude),
+		endpoint.WithRegexDomainFilter(include),
+		endpoint.WithRegexDomainExclude(exclude),
+	), nil
+}
+
+// reload applies the named DomainFilterPolicy read from reader.
+func (f *policyDomainFilter) reload(ctx context.Context, reader client.Reader, name string) error {
+	policy := &apiv1alpha1.DomainFilterPolicy{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: name}, policy); err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return err
+		}
+		log.Infof("DomainFilterPolicy %q not found, using the domain filter flags", name)
+		return f.apply(nil)
+	}
+	if err := f.apply(policy); err != nil {
+		return err
+	}
+	log.Infof("Applied DomainFilterPolicy %q in %s mode", name, policy.Spec.Mode)
+	return nil
+}
+
+// watchDomainFilterPolicy keeps f in sync with the named DomainFilterPolicy
+// and calls onChange after every update. It returns once the policy present
+// on startup, if any, is applied.
+func watchDomainFilterPolicy(ctx context.Context, restConfig *rest.Config, name string, timeout time.Duration, f *policyDomainFilter, onChange func()) error {
+	scheme := runtime.NewScheme()
+	if err := apiv1alpha1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	metav1.AddToGroupVersion(scheme, apiv1alpha1.GroupVersion)
+
+	c, err := crcache.New(restConfig, crcache.Options{
+		Scheme: scheme,
+		ByObject: map[client.Object]crcache.ByObject{
+			&apiv1alpha1.DomainFilterPolicy{}: {
+				Field: fields.OneTermEqualSelector("metadata.name", name),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create DomainFilterPolicy cache: %w", err)
+	}
+	informer, err := c.GetInformer(ctx, &apiv1alpha1.DomainFilterPolicy{})
+	if err != nil {
+		return fmt.Errorf("unable to get DomainFilterPolicy informer: %w", err)
+	}
+
+	go func() {
+		if err := c.Start(ctx); err != nil {
+			log.Errorf("DomainFilterPolicy cache stopped: %v", err)
+		}
+	}()
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	syncCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if !c.WaitForCacheSync(syncCtx) {
+		return fmt.Errorf("DomainFilterPolicy cache failed to sync: %w", syncCtx.Err())
+	}
+	if err := f.reload(ctx, c, name); err != nil {
+		return err
+	}
+
+	handler := func() {
+		if err := f.reload(ctx, c, name); err != nil {
+			log.Errorf("Keeping the current domain filter: %v", err)
+			return
+		}
+		onChange()
+	}
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { handler() },
+		UpdateFunc: func(any, any) { handler() },
+		DeleteFunc: func(any) { handler() },
+	})
+	return err
+}
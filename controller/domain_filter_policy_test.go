@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+func newDomainFilterPolicy(name string, spec apiv1alpha1.DomainFilterPolicySpec) *apiv1alpha1.DomainFilterPolicy {
+	return &apiv1alpha1.DomainFilterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       spec,
+	}
+}
+
+func TestPolicyDomainFilterApply(t *testing.T) {
+	cfg := &externaldns.Config{
+		DomainFilter:  []string{"example.com"},
+		DomainExclude: []string{"internal.example.com"},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		spec     apiv1alpha1.DomainFilterPolicySpec
+		matching []string
+		ignored  []string
+	}{
+		{
+			name:     "override replaces the flags",
+			spec:     apiv1alpha1.DomainFilterPolicySpec{Mode: apiv1alpha1.DomainFilterPolicyOverride, Include: []string{"example.org"}},
+			matching: []string{"a.example.org", "internal.example.org"},
+			ignored:  []string{"a.example.com"},
+		},
+		{
+			name:     "override is the default mode",
+			spec:     apiv1alpha1.DomainFilterPolicySpec{Exclude: []string{"dev.example.org"}},
+			matching: []string{"a.example.com", "internal.example.com", "a.example.org"},
+			ignored:  []string{"a.dev.example.org"},
+		},
+		{
+			name:     "augment adds to the flags",
+			spec:     apiv1alpha1.DomainFilterPolicySpec{Mode: apiv1alpha1.DomainFilterPolicyAugment, Include: []string{"example.org"}, Exclude: []string{"dev.example.com"}},
+			matching: []string{"a.example.com", "a.example.org"},
+			ignored:  []string{"a.internal.example.com", "a.dev.example.com", "a.example.net"},
+		},
+		{
+			name:     "override with regex",
+			spec:     apiv1alpha1.DomainFilterPolicySpec{RegexInclude: `^team-a\.`, RegexExclude: `\.dev\.`},
+			matching: []string{"team-a.example.com", "team-a.example.net"},
+			ignored:  []string{"team-b.example.com", "team-a.dev.example.com"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPolicyDomainFilter(cfg)
+			require.NoError(t, f.apply(newDomainFilterPolicy("policy", tt.spec)))
+			for _, domain := range tt.matching {
+				assert.True(t, f.Match(domain), domain)
+			}
+			for _, domain := range tt.ignored {
+				assert.False(t, f.Match(domain), domain)
+			}
+
+			// removing the policy restores the flags
+			require.NoError(t, f.apply(nil))
+			assert.True(t, f.Match("a.example.com"))
+			assert.False(t, f.Match("a.internal.example.com"))
+			assert.False(t, f.Match("a.example.org"))
+		})
+	}
+}
+
+func TestPolicyDomainFilterAugmentRegex(t *testing.T) {
+	f := newPolicyDomainFilter(&externaldns.Config{RegexDomainFilter: regexp.MustCompile(`^a\.`)})
+	require.NoError(t, f.apply(newDomainFilterPolicy("policy", apiv1alpha1.DomainFilterPolicySpec{
+		Mode:         apiv1alpha1.DomainFilterPolicyAugment,
+		RegexInclude: `^b\.`,
+		RegexExclude: `\.dev\.`,
+	})))
+
+	assert.True(t, f.Match("a.example.com"))
+	assert.True(t, f.Match("b.example.com"))
+	assert.False(t, f.Match("c.example.com"))
+	assert.False(t, f.Match("a.dev.example.com"))
+}
+
+func TestPolicyDomainFilterAugmentNeverNarrows(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		cfg      *externaldns.Config
+		spec     apiv1alpha1.DomainFilterPolicySpec
+		matching []string
+		ignored  []string
+	}{
+		{
+			name:     "flags matching all domains",
+			cfg:      &externaldns.Config{},
+			spec:     apiv1alpha1.DomainFilterPolicySpec{Include: []string{"example.org"}},
+			matching: []string{"a.example.com", "a.example.org", "a.example.net"},
+		},
+		{
+			name:     "regex policy with domain list flags",
+			cfg:      &externaldns.Config{DomainFilter: []string{"example.com"}},
+			spec:     apiv1alpha1.DomainFilterPolicySpec{RegexInclude: `^team-b\.`},
+			matching: []string{"a.example.com", "team-b.example.org"},
+			ignored:  []string{"a.example.org"},
+		},
+		{
+			name:     "policy without includes",
+			cfg:      &externaldns.Config{DomainFilter: []string{"example.com"}},
+			spec:     apiv1alpha1.DomainFilterPolicySpec{Exclude: []string{"dev.example.com"}},
+			matching: []string{"a.example.com"},
+			ignored:  []string{"a.example.org", "a.dev.example.com"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.Mode = apiv1alpha1.DomainFilterPolicyAugment
+			f := newPolicyDomainFilter(tt.cfg)
+			require.NoError(t, f.apply(newDomainFilterPolicy("policy", tt.spec)))
+			for _, domain := range tt.matching {
+				assert.True(t, f.Match(domain), domain)
+				assert.True(t, f.MatchExplain(domain).Matched, domain)
+			}
+			for _, domain := range tt.ignored {
+				assert.False(t, f.Match(domain), domain)
+			}
+		})
+	}
+}
+
+func TestPolicyDomainFilterInvalidPolicy(t *testing.T) {
+	f := newPolicyDomainFilter(&externaldns.Config{DomainFilter: []string{"example.com"}})
+	require.NoError(t, f.apply(newDomainFilterPolicy("valid", apiv1alpha1.DomainFilterPolicySpec{Include: []string{"example.org"}})))
+
+	err := f.apply(newDomainFilterPolicy("invalid", apiv1alpha1.DomainFilterPolicySpec{RegexInclude: "("}))
+	require.ErrorContains(t, err, `invalid DomainFilterPolicy "invalid"`)
+	assert.True(t, f.Match("a.example.org"), "the previous policy is kept")
+	assert.False(t, f.Match("a.example.com"))
+}
+
+func TestPolicyDomainFilterReload(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, apiv1alpha1.AddToScheme(s))
+	f := newPolicyDomainFilter(&externaldns.Config{DomainFilter: []string{"example.com"}})
+
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		newDomainFilterPolicy("other", apiv1alpha1.DomainFilterPolicySpec{Include: []string{"example.net"}}),
+	).Build()
+	require.NoError(t, f.reload(t.Context(), c, "policy"))
+	assert.True(t, f.Match("a.example.com"), "flags are used without a policy")
+	assert.False(t, f.Match("a.example.net"))
+
+	require.NoError(t, c.Create(t.Context(), newDomainFilterPolicy("policy", apiv1alpha1.DomainFilterPolicySpec{Include: []string{"example.org"}})))
+	require.NoError(t, f.reload(t.Context(), c, "policy"))
+	assert.True(t, f.Match("a.example.org"))
+	assert.False(t, f.Match("a.example.com"))
+}
+
+func TestPolicyDomainFilterMatchExplain(t *testing.T) {
+	f := newPolicyDomainFilter(&externaldns.Config{DomainFilter: []string{"example.com"}})
+
+	result := f.MatchExplain("a.example.org")
+	assert.False(t, result.Matched)
+	assert.Equal(t, endpoint.DomainFilterRuleInclude, result.Rule)
+}
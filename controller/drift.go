@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// driftDetector compares the changes planned by consecutive syncs. A record
+// planned again right after a sync that should have applied it is drifting:
+// the provider rejected the change, or something else keeps reverting it.
+// A record planned again with another TTL is not drifting: its TTL is staged,
+// by the staging TTL of a create or by the TTL rollout, and progresses.
+type driftDetector struct {
+	previous map[endpoint.EndpointKey]endpoint.TTL
+}
+
+// observe records the planned changes and returns the number of records that
+// were also planned by the previous sync with the same TTL.
+func (d *driftDetector) observe(changes *plan.Changes) int {
+	planned := map[endpoint.EndpointKey]endpoint.TTL{}
+	if changes != nil {
+		for _, eps := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew, changes.Delete} {
+			for _, ep := range eps {
+				planned[ep.Key()] = ep.RecordTTL
+			}
+		}
+	}
+
+	drifting := 0
+	for key, ttl := range planned {
+		if previous, ok := d.previous[key]; ok && previous == ttl {
+			drifting++
+		}
+	}
+	d.previous = planned
+	return drifting
+}
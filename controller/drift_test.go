@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestDriftDetector(t *testing.T) {
+	a := endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	b := endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	c := endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeCNAME, "a.example.com")
+
+	d := driftDetector{}
+	assert.Equal(t, 0, d.observe(&plan.Changes{Create: []*endpoint.Endpoint{a, b}}))
+	// a was applied, b is planned again
+	assert.Equal(t, 1, d.observe(&plan.Changes{UpdateNew: []*endpoint.Endpoint{b}, Delete: []*endpoint.Endpoint{c}}))
+	assert.Equal(t, 2, d.observe(&plan.Changes{Create: []*endpoint.Endpoint{b}, Delete: []*endpoint.Endpoint{c}}))
+	assert.Equal(t, 0, d.observe(&plan.Changes{}))
+	assert.Equal(t, 0, d.observe(nil))
+}
+
+func TestDriftDetectorStagedTTL(t *testing.T) {
+	staged := endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 60, "1.2.3.4")
+	step := endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 1800, "1.2.3.4")
+	desired := endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 3600, "1.2.3.4")
+
+	d := driftDetector{}
+	// created with the staging TTL, then rolled out to its TTL over two steps
+	assert.Equal(t, 0, d.observe(&plan.Changes{Create: []*endpoint.Endpoint{staged}}))
+	assert.Equal(t, 0, d.observe(&plan.Changes{UpdateNew: []*endpoint.Endpoint{step}}))
+	assert.Equal(t, 0, d.observe(&plan.Changes{UpdateNew: []*endpoint.Endpoint{desired}}))
+	// the TTL keeps being reverted
+	assert.Equal(t, 1, d.observe(&plan.Changes{UpdateNew: []*endpoint.Endpoint{desired}}))
+}
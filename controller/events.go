@@ -17,27 +17,59 @@ limitations under the License.
 package controller
 
 import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
 	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/plan"
 )
 
 // emitChangeEvent emits a Kubernetes event for each DNS record change.
+// Updates describe the fields they change with diffs, as returned by UpdateDiffs.
 // Deletes use RecordDeleted on success and RecordError on failure.
-func emitChangeEvent(e events.EventEmitter, ch *plan.Changes, reason events.Reason) {
+// All events of a sync are added at once so the emitter can aggregate them per object.
+func emitChangeEvent(e events.EventEmitter, ch *plan.Changes, diffs []string, reason events.Reason) {
 	if e == nil {
 		return
 	}
+	evs := make([]events.Event, 0, len(ch.Create)+len(ch.UpdateNew)+len(ch.Delete))
 	for _, ep := range ch.Create {
-		e.Add(events.NewEventFromEndpoint(ep, events.ActionCreate, reason))
+		evs = append(evs, events.NewEventFromEndpoint(ep, events.ActionCreate, reason))
 	}
-	for _, ep := range ch.UpdateNew {
-		e.Add(events.NewEventFromEndpoint(ep, events.ActionUpdate, reason))
+	for i, ep := range ch.UpdateNew {
+		var changes string
+		if i < len(diffs) {
+			changes = diffs[i]
+		}
+		evs = append(evs, events.NewUpdateEventFromEndpoint(ep, changes, reason))
 	}
 	deleteReason := events.RecordDeleted
 	if reason == events.RecordError {
 		deleteReason = events.RecordError
 	}
 	for _, ep := range ch.Delete {
-		e.Add(events.NewEventFromEndpoint(ep, events.ActionDelete, deleteReason))
+		evs = append(evs, events.NewEventFromEndpoint(ep, events.ActionDelete, deleteReason))
+	}
+	e.Add(evs...)
+}
+
+// logUpdates logs the fields changed by each applied update, as returned by UpdateDiffs.
+// In dry-run they are logged at debug level, not to repeat the changes the provider logs.
+func logUpdates(ctx context.Context, ch *plan.Changes, diffs []string, dryRun bool) {
+	level := log.InfoLevel
+	if dryRun {
+		level = log.DebugLevel
+	}
+	for i, diff := range diffs {
+		if diff == "" {
+			continue
+		}
+		ep := ch.UpdateNew[i]
+		name := ep.DNSName
+		if ep.SetIdentifier != "" {
+			name += " (" + ep.SetIdentifier + ")"
+		}
+		log.WithContext(ctx).Logf(level, "Updated %s %s: %s", ep.RecordType, name, diff)
 	}
 }
@@ -19,10 +19,12 @@ package controller
 import (
 	"testing"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	logtest "sigs.k8s.io/external-dns/internal/testutils/log"
 	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/pkg/events/fake"
 	"sigs.k8s.io/external-dns/plan"
@@ -92,7 +94,7 @@ func TestEmit_RecordReady(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			emitter := fake.NewFakeEventEmitter()
 
-			emitChangeEvent(emitter, &tt.changes, events.RecordReady)
+			emitChangeEvent(emitter, &tt.changes, tt.changes.UpdateDiffs(), events.RecordReady)
 
 			tt.asserts(emitter, tt.changes)
 			mock.AssertExpectationsForObjects(t, emitter)
@@ -102,7 +104,7 @@ func TestEmit_RecordReady(t *testing.T) {
 
 func TestEmit_NilEmitter(t *testing.T) {
 	assert.NotPanics(t, func() {
-		emitChangeEvent(nil, &plan.Changes{}, events.RecordError)
+		emitChangeEvent(nil, &plan.Changes{}, nil, events.RecordError)
 	})
 }
 
@@ -157,10 +159,56 @@ func TestEmit_RecordError(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			emitter := fake.NewFakeEventEmitter()
 
-			emitChangeEvent(emitter, &tt.changes, events.RecordError)
+			emitChangeEvent(emitter, &tt.changes, tt.changes.UpdateDiffs(), events.RecordError)
 
 			tt.asserts(emitter, tt.changes)
 			mock.AssertExpectationsForObjects(t, emitter)
 		})
 	}
 }
+
+func TestEmit_UpdateChanges(t *testing.T) {
+	refObj := &events.ObjectReference{}
+	changes := plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("one.example.com", endpoint.RecordTypeA, 300, "10.10.10.0"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("one.example.com", endpoint.RecordTypeA, 60, "10.10.10.1").WithRefObject(refObj),
+		},
+	}
+	emitter := fake.NewFakeEventEmitter()
+
+	emitChangeEvent(emitter, &changes, changes.UpdateDiffs(), events.RecordReady)
+
+	emitter.AssertCalled(t, "Add", events.NewUpdateEventFromEndpoint(changes.UpdateNew[0], "ttl: 300 -> 60; targets: +10.10.10.1 -10.10.10.0", events.RecordReady))
+	emitter.AssertNumberOfCalls(t, "Add", 1)
+}
+
+func TestLogUpdates(t *testing.T) {
+	hook := logtest.LogsUnderTestWithLogLevel(log.InfoLevel, t)
+	changes := &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("one.example.com", endpoint.RecordTypeA, 300, "10.10.10.0"),
+			endpoint.NewEndpoint("two.example.com", endpoint.RecordTypeCNAME, "a.example.com").WithSetIdentifier("eu"),
+			endpoint.NewEndpoint("three.example.com", endpoint.RecordTypeA, "10.10.10.2", "10.10.10.3"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("one.example.com", endpoint.RecordTypeA, 60, "10.10.10.0"),
+			endpoint.NewEndpoint("two.example.com", endpoint.RecordTypeCNAME, "b.example.com").WithSetIdentifier("eu"),
+			// only the order of the targets changes
+			endpoint.NewEndpoint("three.example.com", endpoint.RecordTypeA, "10.10.10.3", "10.10.10.2"),
+		},
+	}
+
+	logUpdates(t.Context(), changes, changes.UpdateDiffs(), false)
+
+	logtest.TestHelperLogContains("Updated A one.example.com: ttl: 300 -> 60", hook, t)
+	logtest.TestHelperLogContains("Updated CNAME two.example.com (eu): targets: +b.example.com -a.example.com", hook, t)
+	logtest.TestHelperLogNotContains("three.example.com", hook, t)
+
+	// in dry-run, the provider logs the changes instead
+	hook.Reset()
+	logUpdates(t.Context(), changes, changes.UpdateDiffs(), true)
+	logtest.TestHelperLogNotContains("Updated", hook, t)
+}
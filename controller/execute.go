@@ -22,33 +22,54 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	eventsv1 "k8s.io/client-go/kubernetes/typed/events/v1"
 	"k8s.io/klog/v2"
 	crlog "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/logging"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns/validation"
+	"sigs.k8s.io/external-dns/pkg/credentials"
 	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/pkg/metrics"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	providerfactory "sigs.k8s.io/external-dns/provider/factory"
+	"sigs.k8s.io/external-dns/provider/inmemory"
 	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
 	registryfactory "sigs.k8s.io/external-dns/registry/factory"
 	"sigs.k8s.io/external-dns/source"
 	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
 	"sigs.k8s.io/external-dns/source/wrappers"
 )
 
 func Execute() {
+	if len(os.Args) > 1 && os.Args[1] == rbacGenCommand {
+		if err := rbacGen(os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	ctx, finalize := contextWithSigtermHandler(context.Background())
 	defer finalize()
+	if len(os.Args) > 1 && os.Args[1] == recordsCommand {
+		if err := records(ctx, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err) // nolint: gocritic // exitAfterDefer
+		}
+		return
+	}
 	execute(ctx)
 }
 
@@ -63,15 +84,23 @@ func execute(ctx context.Context) {
 	}
 
 	// Set annotation prefix (required since init() was removed)
-	annotations.SetAnnotationPrefix(cfg.AnnotationPrefix)
+	annotations.SetAnnotationPrefix(cfg.AnnotationPrefix, cfg.DeprecatedAnnotationPrefixes...)
 	if cfg.AnnotationPrefix != annotations.DefaultAnnotationPrefix {
 		log.Infof("Using custom annotation prefix: %s", cfg.AnnotationPrefix)
 	}
+	if len(cfg.DeprecatedAnnotationPrefixes) > 0 {
+		log.Infof("Honoring deprecated annotation prefixes: %v", cfg.DeprecatedAnnotationPrefixes)
+	}
 
 	if err := configureLogger(cfg); err != nil {
 		log.Fatal(err)
 	}
 
+	if cfg.Diff {
+		// the diff mode never applies changes
+		cfg.DryRun = true
+	}
+
 	if cfg.DryRun {
 		log.Info("running in dry-run mode. No changes to DNS records will be made.")
 	}
@@ -89,13 +118,27 @@ func execute(ctx context.Context) {
 
 	log.Info(externaldns.Banner())
 
-	go serveMetrics(cfg.MetricsAddress)
+	countEnabledFeatures(cfg)
+	go serveMetrics(cfg.MetricsAddress, cfg.KubeAPIWatchStaleTimeout)
 
 	sCfg, err := source.NewSourceConfig(cfg)
 	if err != nil {
 		log.Fatal(err) // nolint: gocritic // exitAfterDefer
 	}
-	endpointsSource, err := wrappers.Build(ctx, sCfg)
+	eventEmitter, err := buildEventEmitter(ctx, cfg, sCfg)
+	if err != nil {
+		log.Fatal(err) // nolint: gocritic // exitAfterDefer
+	}
+	wrapperOpts := []wrappers.Option{
+		wrappers.WithEventEmitter(eventEmitter),
+		wrappers.WithPropertyValidator(providerfactory.PropertyValidator(cfg.Provider)),
+	}
+	var policies *dnsPolicies
+	if cfg.DNSPolicies {
+		policies = &dnsPolicies{}
+		wrapperOpts = append(wrapperOpts, wrappers.WithSourceWrapper(dnsPolicyWrapper(policies)))
+	}
+	endpointsSource, err := wrappers.Build(ctx, sCfg, wrapperOpts...)
 	if err != nil {
 		log.Fatal(err) // nolint: gocritic // exitAfterDefer
 	}
@@ -107,20 +150,99 @@ func execute(ctx context.Context) {
 		endpoint.WithRegexDomainExclude(cfg.RegexDomainExclude),
 	)
 
+	if cfg.ExportFile != "" {
+		// the export mode only runs the sources, the provider is never built
+		if err := runExport(ctx, cfg, endpointsSource, domainFilter, eventEmitter); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
 	prvdr, err := providerfactory.Select(ctx, cfg, domainFilter)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	if cfg.WebhookServer {
-		webhookapi.StartHTTPApi(prvdr, nil, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout, "127.0.0.1:8888")
+		opts, err := webhookServerProviders(ctx, cfg, domainFilter)
+		if err != nil {
+			log.Fatal(err)
+		}
+		authOpts, err := webhookServerAuth(ctx, cfg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, authOpts...)
+		webhookapi.StartHTTPApi(prvdr, nil, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout, cfg.WebhookServerAddress, opts...)
 		os.Exit(0)
 	}
 
-	ctrl, err := buildController(ctx, cfg, sCfg, endpointsSource, prvdr, domainFilter)
+	if prvdr, err = withStateCache(cfg, sCfg, prvdr); err != nil {
+		log.Fatal(err)
+	}
+
+	var ctrlDomainFilter endpoint.DomainFilterInterface = domainFilter
+	var policyFilter *policyDomainFilter
+	if cfg.DomainFilterPolicy != "" {
+		policyFilter = newPolicyDomainFilter(cfg)
+		ctrlDomainFilter = policyFilter
+	}
+
+	ctrl, err := buildController(cfg, endpointsSource, prvdr, ctrlDomainFilter, eventEmitter)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if ctrl.Suspension, err = buildSuspension(cfg, sCfg); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Debugf("serving 'explain' on '%s/explain'", cfg.MetricsAddress)
+	http.Handle("/explain", explainHandler(endpoint.MatchAllDomainFilters{ctrlDomainFilter, ctrl.Registry.GetDomainFilter()}))
+	log.Debugf("serving 'config' on '%s/config'", cfg.MetricsAddress)
+	http.Handle("/config", configHandler(cfg))
+	if im, ok := inMemoryProvider(prvdr); ok {
+		log.Debugf("serving 'inmemory' on '%s/inmemory'", cfg.MetricsAddress)
+		http.Handle("/inmemory", im.InspectHandler())
+	}
+	log.Debugf("serving 'status' on '%s/status'", cfg.MetricsAddress)
+	http.Handle("/status", statusHandler(ctrl))
+	if err := registerSyncAPI(cfg, sCfg, ctrl); err != nil {
+		log.Fatal(err)
+	}
+
+	if policyFilter != nil {
+		restConfig, err := sCfg.ClientGenerator().RESTConfig()
+		if err != nil {
+			log.Fatal(err)
+		}
+		onChange := func() { ctrl.ScheduleRunOnce(time.Now()) }
+		if err := watchDomainFilterPolicy(ctx, restConfig, cfg.DomainFilterPolicy, cfg.RequestTimeout, policyFilter, onChange); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if policies != nil {
+		restConfig, err := sCfg.ClientGenerator().RESTConfig()
+		if err != nil {
+			log.Fatal(err)
+		}
+		onChange := func() { ctrl.ScheduleRunOnce(time.Now()) }
+		if err := watchDNSPolicies(ctx, restConfig, cfg.RequestTimeout, cfg.DryRun, policies, onChange); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if cfg.Diff {
+		changes, err := ctrl.Diff(ctx, os.Stdout)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if changes.HasChanges() {
+			os.Exit(diffExitCodeChanges)
+		}
+
+		os.Exit(0)
+	}
 
 	if cfg.Once {
 		err := ctrl.RunOnce(ctx)
@@ -145,50 +267,244 @@ func execute(ctx context.Context) {
 }
 
 func buildController(
-	ctx context.Context,
 	cfg *externaldns.Config,
-	sCfg *source.Config,
 	src source.Source,
 	p provider.Provider,
-	filter *endpoint.DomainFilter,
+	filter endpoint.DomainFilterInterface,
+	eventEmitter events.EventEmitter,
 ) (*Controller, error) {
 	policy, ok := plan.Policies[cfg.Policy]
 	if !ok {
 		return nil, fmt.Errorf("unknown policy: %s", cfg.Policy)
 	}
+	resolveManagedRecordTypes(cfg, p)
 	reg, err := registryfactory.Select(cfg, p)
 	if err != nil {
 		return nil, err
 	}
-	eventsCfg := events.NewConfig(
-		events.WithEmitEvents(cfg.EmitEvents),
-		events.WithDryRun(cfg.DryRun))
-	var eventEmitter events.EventEmitter
-	if eventsCfg.IsEnabled() {
-		kubeClient, err := sCfg.ClientGenerator().KubeClient()
+	changeWindow, err := plan.ParseChangeWindow(cfg.ChangeWindow, cfg.ChangeWindowHoldDeletes)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := ParseSyncSchedule(cfg.SyncSchedule, time.UTC)
+	if err != nil {
+		return nil, err
+	}
+	protected, err := plan.ParseProtectedRecords(cfg.ProtectedRecords, cfg.ProtectedRecordsFile)
+	if err != nil {
+		return nil, err
+	}
+	apexDrift, err := NewApexDriftChecker(cfg.ApexRecordsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Controller{
+		Source:                src,
+		Registry:              reg,
+		Policy:                policy,
+		Interval:              cfg.Interval,
+		IntervalJitter:        cfg.IntervalJitter,
+		Schedule:              schedule,
+		DomainFilter:          filter,
+		ManagedRecordTypes:    cfg.ManagedDNSRecordTypes,
+		ExcludeRecordTypes:    cfg.ExcludeDNSRecordTypes,
+		SupportedRecordTypes:  p.SupportedRecordTypes(),
+		MinEventSyncInterval:  cfg.MinEventSyncInterval,
+		FullReconcileInterval: cfg.FullReconcileInterval,
+		TXTOwnerOld:           cfg.TXTOwnerOld,
+		EventEmitter:          eventEmitter,
+		TTLRollout:            plan.NewTTLRolloutPolicy(cfg.TTLRolloutSteps, cfg.TTLMaxUpdatesPerSync),
+		ChangeWindow:          changeWindow,
+		DeleteDelay:           plan.NewDeleteDelayPolicy(cfg.DeleteDelay),
+		ProtectedRecords:      protected,
+		ApexDrift:             apexDrift,
+		DryRun:                cfg.DryRun,
+		ApplyChunkSize:        cfg.ApplyChunkSize,
+		MaxEndpoints:          cfg.MaxEndpoints,
+		RecordsZoneLimit:      cfg.RegistryRecordsZoneLimit,
+		Normalizers:           endpointNormalizers(cfg, p),
+	}, nil
+}
+
+// resolveManagedRecordTypes defaults --managed-record-types to the record types
+// declared by the provider, or the one it wraps, when it implements
+// provider.ManagedRecordTypesDefaulter, or else to the default record types it
+// supports. It runs before the registry is selected.
+func resolveManagedRecordTypes(cfg *externaldns.Config, p provider.Provider) {
+	if len(cfg.ManagedDNSRecordTypes) > 0 {
+		return
+	}
+	cfg.ManagedDNSRecordTypes = provider.DefaultManagedRecordTypes(p.SupportedRecordTypes())
+	for p != nil {
+		if d, ok := p.(provider.ManagedRecordTypesDefaulter); ok {
+			cfg.ManagedDNSRecordTypes = d.DefaultManagedRecordTypes()
+			return
+		}
+		u, ok := p.(provider.Unwrapper)
+		if !ok {
+			return
+		}
+		p = u.Unwrap()
+	}
+}
+
+// endpointNormalizers returns the normalizers applied before planning: the
+// default ones when --normalize-endpoints is set, followed by the one of the
+// provider, or of the provider it wraps, when it implements provider.EndpointNormalizer.
+func endpointNormalizers(cfg *externaldns.Config, p provider.Provider) []plan.Normalizer {
+	var normalizers []plan.Normalizer
+	if cfg.NormalizeEndpoints {
+		normalizers = plan.DefaultNormalizers()
+	}
+	for p != nil {
+		if n, ok := p.(provider.EndpointNormalizer); ok {
+			return append(normalizers, plan.NormalizerFunc(n.NormalizeEndpoint))
+		}
+		u, ok := p.(provider.Unwrapper)
+		if !ok {
+			break
+		}
+		p = u.Unwrap()
+	}
+	return normalizers
+}
+
+// webhookServerProviders builds the additional providers served by the webhook
+// server under their name. They share the configuration of the main provider.
+func webhookServerProviders(ctx context.Context, cfg *externaldns.Config, domainFilter *endpoint.DomainFilter) ([]webhookapi.HTTPApiOption, error) {
+	opts := make([]webhookapi.HTTPApiOption, 0, len(cfg.WebhookServerProviders))
+	for _, name := range cfg.WebhookServerProviders {
+		pCfg := *cfg
+		pCfg.Provider = name
+		p, err := providerfactory.Select(ctx, &pCfg, domainFilter)
+		if err != nil {
+			return nil, fmt.Errorf("webhook server provider %s: %w", name, err)
+		}
+		opts = append(opts, webhookapi.WithNamedProvider(name, p))
+	}
+	return opts, nil
+}
+
+// webhookServerAuth returns the TLS and bearer token options of the webhook server.
+func webhookServerAuth(ctx context.Context, cfg *externaldns.Config) ([]webhookapi.HTTPApiOption, error) {
+	var opts []webhookapi.HTTPApiOption
+	if cfg.WebhookServerTLSCert != "" {
+		opts = append(opts, webhookapi.WithTLS(cfg.WebhookServerTLSCert, cfg.WebhookServerTLSKey, cfg.WebhookServerTLSClientCA))
+	}
+	if cfg.WebhookProviderToken != "" {
+		token, err := credentials.Resolve(ctx, cfg.WebhookProviderToken)
+		if err != nil {
+			return nil, fmt.Errorf("webhook server token: %w", err)
+		}
+		opts = append(opts, webhookapi.WithBearerToken(token))
+	}
+	return opts, nil
+}
+
+// inMemoryProvider returns the inmemory provider p is, or wraps.
+func inMemoryProvider(p provider.Provider) (*inmemory.InMemoryProvider, bool) {
+	for p != nil {
+		if im, ok := p.(*inmemory.InMemoryProvider); ok {
+			return im, true
+		}
+		u, ok := p.(provider.Unwrapper)
+		if !ok {
+			break
+		}
+		p = u.Unwrap()
+	}
+	return nil, false
+}
+
+// withStateCache wraps p to persist its records across restarts when a state
+// cache is configured.
+func withStateCache(cfg *externaldns.Config, sCfg *source.Config, p provider.Provider) (provider.Provider, error) {
+	switch {
+	case cfg.StateCacheFile != "":
+		return provider.NewStateCachedProvider(p, provider.FileStateStore{Path: cfg.StateCacheFile}), nil
+	case cfg.StateCacheConfigMap != "":
+		kubeClient, err := source.KubeClient(sCfg.ClientGenerator())
 		if err != nil {
 			return nil, err
 		}
-		eventCtrl, err := events.NewEventController(kubeClient.EventsV1(), eventsCfg)
+		namespace, name, _ := strings.Cut(cfg.StateCacheConfigMap, "/")
+		return provider.NewStateCachedProvider(p, provider.ConfigMapStateStore{Client: kubeClient, Namespace: namespace, Name: name}), nil
+	default:
+		return p, nil
+	}
+}
+
+// buildEventEmitter starts the Kubernetes event controller when events are enabled.
+// It returns a nil emitter otherwise. The emitter is shared by the controller and
+// the source wrappers.
+func buildEventEmitter(ctx context.Context, cfg *externaldns.Config, sCfg *source.Config) (events.EventEmitter, error) {
+	opts := []events.ConfigOption{
+		events.WithEmitEvents(cfg.EmitEvents),
+		events.WithDryRun(cfg.DryRun),
+		events.WithRateLimit(cfg.EventsQPS, cfg.EventsBurst),
+		events.WithObserver(eventMetrics{}),
+		events.WithKubernetesEvents(slices.Contains(cfg.EventsSinks, "kubernetes")),
+	}
+	if slices.Contains(cfg.EventsSinks, "webhook") {
+		opts = append(opts, events.WithSinks(events.NewWebhookSink(cfg.EventsWebhookURL, cfg.EventsWebhookTimeout, cfg.DryRun)))
+	}
+	eventsCfg := events.NewConfig(opts...)
+	if !eventsCfg.IsEnabled() {
+		return nil, nil // nolint: nilnil // a nil emitter disables events
+	}
+	var client eventsv1.EventsV1Interface
+	if slices.Contains(cfg.EventsSinks, "kubernetes") {
+		kubeClient, err := source.KubeClient(sCfg.ClientGenerator())
 		if err != nil {
 			return nil, err
 		}
-		eventCtrl.Run(ctx)
-		eventEmitter = eventCtrl
+		client = kubeClient.EventsV1()
 	}
+	eventCtrl, err := events.NewEventController(client, eventsCfg)
+	if err != nil {
+		return nil, err
+	}
+	eventCtrl.Run(ctx)
+	return eventCtrl, nil
+}
 
-	return &Controller{
-		Source:               src,
-		Registry:             reg,
-		Policy:               policy,
-		Interval:             cfg.Interval,
-		DomainFilter:         filter,
-		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
-		ExcludeRecordTypes:   cfg.ExcludeDNSRecordTypes,
-		MinEventSyncInterval: cfg.MinEventSyncInterval,
-		TXTOwnerOld:          cfg.TXTOwnerOld,
-		EventEmitter:         eventEmitter,
-	}, nil
+// buildSuspension returns the suspension of the synchronizations, nil when they cannot be suspended.
+func buildSuspension(cfg *externaldns.Config, sCfg *source.Config) (*Suspension, error) {
+	if cfg.SuspendNamespace == "" {
+		return NewSuspension(cfg.Suspend, nil, ""), nil
+	}
+	kubeClient, err := source.KubeClient(sCfg.ClientGenerator())
+	if err != nil {
+		return nil, err
+	}
+	return NewSuspension(cfg.Suspend, kubeClient, cfg.SuspendNamespace), nil
+}
+
+// registerSyncAPI serves POST /sync when an authentication method is configured.
+func registerSyncAPI(cfg *externaldns.Config, sCfg *source.Config, ctrl *Controller) error {
+	var static, review tokenAuthenticator
+	if cfg.SyncAPITokenFile != "" {
+		authenticate, err := staticTokenAuthenticator(cfg.SyncAPITokenFile)
+		if err != nil {
+			return err
+		}
+		static = authenticate
+	}
+	if cfg.SyncAPITokenReview {
+		kubeClient, err := source.KubeClient(sCfg.ClientGenerator())
+		if err != nil {
+			return err
+		}
+		review = tokenReviewAuthenticator(kubeClient)
+	}
+	if static == nil && review == nil {
+		return nil
+	}
+	log.Debugf("serving 'sync' on '%s/sync'", cfg.MetricsAddress)
+	limiter := rate.NewLimiter(rate.Every(cfg.SyncAPIMinInterval), 1)
+	http.Handle("/sync", syncHandler(ctrl, static, review, limiter))
+	return nil
 }
 
 // This function configures the logger format and level based on the provided configuration.
@@ -201,6 +517,7 @@ func configureLogger(cfg *externaldns.Config) error {
 		return err
 	}
 	log.SetLevel(ll)
+	logging.Configure(cfg.LogSampleLimit)
 	return nil
 }
 
@@ -223,17 +540,21 @@ func contextWithSigtermHandler(ctx context.Context) (context.Context, func()) {
 	}
 }
 
-// serveMetrics starts an HTTP server that serves health and metrics endpoints.
+// serveMetrics starts an HTTP server that serves health, readiness and metrics endpoints.
 // The /healthz endpoint returns a 200 OK status to indicate the service is healthy.
+// The /readyz endpoint returns a 503 status listing the informer watches with no activity
+// for longer than watchStaleTimeout, e.g. behind a proxy silently dropping them.
 // The /metrics endpoint serves Prometheus metrics.
 // The server listens on the specified address and logs debug information about the endpoints.
-func serveMetrics(address string) {
+func serveMetrics(address string, watchStaleTimeout time.Duration) {
 	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
 	})
+	http.HandleFunc("/readyz", readyzHandler(watchStaleTimeout))
 
 	log.Debugf("serving 'healthz' on '%s/healthz'", address)
+	log.Debugf("serving 'readyz' on '%s/readyz'", address)
 	log.Debugf("serving 'metrics' on '%s/metrics'", address)
 	log.Debugf("registered '%d' metrics", len(metrics.RegisterMetric.Metrics))
 
@@ -241,3 +562,17 @@ func serveMetrics(address string) {
 
 	log.Fatal(http.ListenAndServe(address, nil))
 }
+
+// readyzHandler reports not ready when an informer watch is stale.
+func readyzHandler(watchStaleTimeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if err := informers.CheckWatches(watchStaleTimeout); err != nil {
+			log.Warnf("Not ready: %v", err)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	}
+}
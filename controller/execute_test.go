@@ -22,6 +22,8 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"strings"
@@ -34,7 +36,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	dnsprovider "sigs.k8s.io/external-dns/provider"
 	provider "sigs.k8s.io/external-dns/provider/factory"
 	"sigs.k8s.io/external-dns/source"
 	"sigs.k8s.io/external-dns/source/wrappers"
@@ -294,7 +299,7 @@ func TestControllerRunCancelContextStopsLoop(t *testing.T) {
 	)
 	p, err := provider.Select(ctx, cfg, domainFilter)
 	require.NoError(t, err)
-	ctrl, err := buildController(ctx, cfg, sCfg, src, p, domainFilter)
+	ctrl, err := buildController(cfg, src, p, domainFilter, nil)
 	require.NoError(t, err)
 
 	done := make(chan struct{})
@@ -310,6 +315,111 @@ func TestControllerRunCancelContextStopsLoop(t *testing.T) {
 	}
 }
 
+func TestBuildControllerSyncSchedule(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Policy = "sync"
+	cfg.Registry = externaldns.RegistryNoop
+	cfg.SyncSchedule = "*/10 * * * *"
+	cfg.IntervalJitter = time.Minute
+	p := &filteredMockProvider{}
+
+	ctrl, err := buildController(cfg, testutils.NewMockSource(), p, &endpoint.DomainFilter{}, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, ctrl.Schedule)
+	assert.Equal(t, time.Minute, ctrl.IntervalJitter)
+
+	cfg.SyncSchedule = "every ten minutes"
+	_, err = buildController(cfg, testutils.NewMockSource(), p, &endpoint.DomainFilter{}, nil)
+	assert.ErrorContains(t, err, "invalid sync schedule")
+}
+
+type ipv4MockProvider struct {
+	filteredMockProvider
+}
+
+func (p *ipv4MockProvider) SupportedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeTXT}
+}
+
+func TestBuildControllerManagedRecordTypes(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Policy = "sync"
+	cfg.Registry = externaldns.RegistryNoop
+
+	ctrl, err := buildController(cfg, testutils.NewMockSource(), &filteredMockProvider{}, &endpoint.DomainFilter{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}, ctrl.ManagedRecordTypes)
+
+	cfg.ManagedDNSRecordTypes = nil
+	ctrl, err = buildController(cfg, testutils.NewMockSource(), &ipv4MockProvider{}, &endpoint.DomainFilter{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{endpoint.RecordTypeA}, ctrl.ManagedRecordTypes, "the defaults are restricted to the supported record types")
+
+	cfg.ManagedDNSRecordTypes = []string{endpoint.RecordTypeA, endpoint.RecordTypeCAA}
+	ctrl, err = buildController(cfg, testutils.NewMockSource(), &ipv4MockProvider{}, &endpoint.DomainFilter{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{endpoint.RecordTypeA, endpoint.RecordTypeCAA}, ctrl.ManagedRecordTypes, "the configured record types are kept")
+
+	cfg.ManagedDNSRecordTypes = nil
+	ctrl, err = buildController(cfg, testutils.NewMockSource(), dnsprovider.NewCachedProvider(&caaMockProvider{}, time.Minute), &endpoint.DomainFilter{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{endpoint.RecordTypeA, endpoint.RecordTypeCAA}, ctrl.ManagedRecordTypes, "the wrapped provider declares its defaults")
+}
+
+type caaMockProvider struct {
+	filteredMockProvider
+}
+
+func (p *caaMockProvider) DefaultManagedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeCAA}
+}
+
+type normalizingMockProvider struct {
+	filteredMockProvider
+}
+
+func (p *normalizingMockProvider) NormalizeEndpoint(ep *endpoint.Endpoint) {
+	ep.DNSName = "normalized"
+}
+
+func TestEndpointNormalizers(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	assert.Empty(t, endpointNormalizers(cfg, &filteredMockProvider{}))
+
+	cfg.NormalizeEndpoints = true
+	assert.Len(t, endpointNormalizers(cfg, &filteredMockProvider{}), len(plan.DefaultNormalizers()))
+
+	// the normalizer of a wrapped provider runs after the default ones
+	normalizers := endpointNormalizers(cfg, dnsprovider.NewCachedProvider(&normalizingMockProvider{}, time.Minute))
+	require.Len(t, normalizers, len(plan.DefaultNormalizers())+1)
+	ep := endpoint.NewEndpoint("Example.com.", endpoint.RecordTypeA, "1.2.3.4")
+	normalizers[len(normalizers)-1].Normalize(ep)
+	assert.Equal(t, "normalized", ep.DNSName)
+}
+
+func TestInMemoryProviderUnwraps(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Provider = externaldns.ProviderInMemory
+	cfg.ProviderCacheTime = time.Minute
+	p, err := provider.Select(t.Context(), cfg, endpoint.NewDomainFilter(nil))
+	require.NoError(t, err)
+
+	im, ok := inMemoryProvider(p)
+	require.True(t, ok)
+	assert.NotNil(t, im.InspectHandler())
+
+	_, ok = inMemoryProvider(&filteredMockProvider{})
+	assert.False(t, ok)
+}
+
+func TestPolicyFlagAcceptsEveryPolicy(t *testing.T) {
+	for name := range plan.Policies {
+		cfg := externaldns.NewConfig()
+		require.NoError(t, cfg.ParseFlags([]string{"--provider=inmemory", "--source=service", "--policy=" + name}), name)
+		assert.Equal(t, name, cfg.Policy)
+	}
+}
+
 // TestContextWithSigtermHandlerHelper is a helper process that sets up the SIGTERM handler
 // and waits for it to be triggered.
 func TestContextWithSigtermHandlerHelper(t *testing.T) {
@@ -360,3 +470,11 @@ func TestContextWithSigtermHandler(t *testing.T) {
 
 	assert.Contains(t, buf.String(), "Received SIGTERM. Terminating...")
 }
+
+func TestReadyzHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	readyzHandler(0)(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "OK", rec.Body.String())
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestExplainHandler(t *testing.T) {
+	handler := explainHandler(endpoint.MatchAllDomainFilters{
+		endpoint.NewDomainFilterWithExclusions([]string{"example.com"}, []string{"internal.example.com"}),
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/explain?name=db.internal.example.com", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var result endpoint.DomainFilterMatch
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, endpoint.DomainFilterMatch{
+		Domain: "db.internal.example.com",
+		Rule:   endpoint.DomainFilterRuleExclude,
+		Value:  "internal.example.com",
+		Reason: `excluded by domain exclusion "internal.example.com"`,
+	}, result)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/explain", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/pkg/txt"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source"
+)
+
+const (
+	// ExportFormatJSON renders the endpoints as a JSON array.
+	ExportFormatJSON = "json"
+	// ExportFormatZone renders the endpoints in the zone file syntax of RFC 1035.
+	ExportFormatZone = "zone"
+
+	// exportDefaultTTL is the $TTL of exported zone files, used by records without a TTL.
+	exportDefaultTTL = 300
+)
+
+// Exporter writes the endpoints desired by the sources to a file, without any
+// provider or registry, for tools building the zones offline. The endpoints are
+// filtered and their conflicts resolved as in a synchronization.
+type Exporter struct {
+	Source         source.Source
+	DomainFilter   endpoint.DomainFilterInterface
+	ManagedRecords []string
+	ExcludeRecords []string
+	EventEmitter   events.EventEmitter
+	// Path is the file written, replaced atomically on each export.
+	Path string
+	// Format is ExportFormatJSON or ExportFormatZone.
+	Format string
+	// Interval between two exports when running continuously.
+	Interval time.Duration
+	// MinEventSyncInterval is the minimum interval between an export and the next one
+	// triggered by a change of the sources.
+	MinEventSyncInterval time.Duration
+	// ZoneSection writes the records between the section markers of the existing zone
+	// file at Path and increases its SOA serial, instead of writing the whole file.
+	ZoneSection bool
+	// ReloadCommand is run after each change of the file, e.g. "rndc reload example.org".
+	ReloadCommand string
+
+	// last is the content last written, the file is left untouched while it does not change.
+	last []byte
+	// reloadPending is set while the reload command failed after the last change of the file.
+	reloadPending bool
+}
+
+// runExport exports the endpoints desired by the sources to --export-file, once with
+// --once, and on changes of the sources with --events.
+func runExport(ctx context.Context, cfg *externaldns.Config, src source.Source, filter endpoint.DomainFilterInterface, eventEmitter events.EventEmitter) error {
+	managed := cfg.ManagedDNSRecordTypes
+	if len(managed) == 0 {
+		managed = provider.DefaultManagedRecordTypes(nil)
+	}
+	e := &Exporter{
+		Source:         src,
+		DomainFilter:   filter,
+		ManagedRecords: managed,
+		ExcludeRecords: cfg.ExcludeDNSRecordTypes,
+		EventEmitter:   eventEmitter,
+		Path:           cfg.ExportFile,
+		Format:         cfg.ExportFormat,
+		Interval:       cfg.Interval,
+		ZoneSection:    cfg.ExportZoneSection,
+		ReloadCommand:  cfg.ExportReloadCommand,
+
+		MinEventSyncInterval: cfg.MinEventSyncInterval,
+	}
+	if cfg.Once {
+		return e.RunOnce(ctx)
+	}
+	var trigger chan struct{}
+	if cfg.UpdateEvents {
+		trigger = make(chan struct{}, 1)
+		src.AddEventHandler(ctx, func() {
+			select {
+			case trigger <- struct{}{}:
+			default:
+			}
+		})
+	}
+	e.Run(ctx, trigger)
+	return nil
+}
+
+// RunOnce exports the endpoints desired by the sources once.
+func (e *Exporter) RunOnce(ctx context.Context) error {
+	ctx = events.ContextWithEmitter(ctx, e.EventEmitter)
+	endpoints, err := e.Source.Endpoints(ctx)
+	if err != nil {
+		sourceErrorsTotal.Counter.Inc()
+		return err
+	}
+	sourceEndpointsTotal.Gauge.Set(float64(len(endpoints)))
+
+	p := (&plan.Plan{
+		Policies:       []plan.Policy{&plan.SyncPolicy{}},
+		Desired:        endpoints,
+		DomainFilter:   endpoint.MatchAllDomainFilters{e.DomainFilter},
+		ManagedRecords: e.ManagedRecords,
+		ExcludeRecords: e.ExcludeRecords,
+	}).Calculate()
+
+	data, changed, err := e.render(p.Changes.Create)
+	if err != nil {
+		return err
+	}
+	if !changed && !e.reloadPending {
+		log.Debugf("Exported endpoints did not change, not writing %s", e.Path)
+		return nil
+	}
+	if changed {
+		if err := writeFileAtomically(e.Path, data); err != nil {
+			return fmt.Errorf("writing exported endpoints: %w", err)
+		}
+		log.Infof("Exported %d endpoints to %s", len(p.Changes.Create), e.Path)
+		e.reloadPending = e.ReloadCommand != ""
+	}
+	if e.reloadPending {
+		// a failed reload is retried by the next export, even when the file did not change
+		if err := runReloadCommand(ctx, e.ReloadCommand); err != nil {
+			return err
+		}
+		e.reloadPending = false
+	}
+	e.last = data
+	return nil
+}
+
+// render returns the content of the file with the endpoints, and whether it changed.
+func (e *Exporter) render(endpoints []*endpoint.Endpoint) ([]byte, bool, error) {
+	if e.ZoneSection {
+		// the zone file may be edited outside of the managed section, it is read on each export
+		zone, err := os.ReadFile(e.Path)
+		if err != nil {
+			return nil, false, err
+		}
+		return updateZoneSection(zone, endpoints)
+	}
+	data, err := renderEndpoints(endpoints, e.Format)
+	if err != nil {
+		return nil, false, err
+	}
+	return data, !bytes.Equal(data, e.last), nil
+}
+
+// Run exports the endpoints every Interval, and on trigger when it is not nil,
+// until ctx is done. Exports on trigger follow the previous one by at least
+// MinEventSyncInterval. Failed exports are logged and retried on the next run.
+func (e *Exporter) Run(ctx context.Context, trigger <-chan struct{}) {
+	ticker := time.NewTicker(e.Interval)
+	defer ticker.Stop()
+	for {
+		lastRunAt := time.Now()
+		if err := e.RunOnce(ctx); err != nil {
+			log.Errorf("Failed to export endpoints: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			log.Info("Terminating export loop")
+			return
+		case <-ticker.C:
+		case <-trigger:
+			// the changes of the sources within the interval are batched in one export
+			select {
+			case <-ctx.Done():
+				log.Info("Terminating export loop")
+				return
+			case <-time.After(time.Until(lastRunAt.Add(e.MinEventSyncInterval))):
+			}
+		}
+	}
+}
+
+// renderEndpoints renders the endpoints in format, see sortedEndpoints for their order.
+func renderEndpoints(endpoints []*endpoint.Endpoint, format string) ([]byte, error) {
+	endpoints = sortedEndpoints(endpoints)
+	switch format {
+	case ExportFormatJSON:
+		data, err := json.MarshalIndent(endpoints, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case ExportFormatZone:
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "$TTL %d\n", exportDefaultTTL)
+		writeZoneRecords(&b, endpoints, 0)
+		return b.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// sortedEndpoints returns copies of the endpoints sorted by name, type and set
+// identifier, with their targets sorted, so that the output only changes with them.
+func sortedEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	sorted := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		ep = ep.DeepCopy()
+		sort.Sort(ep.Targets)
+		sorted = append(sorted, ep)
+	}
+	slices.SortFunc(sorted, func(a, b *endpoint.Endpoint) int {
+		return cmp.Or(
+			strings.Compare(a.DNSName, b.DNSName),
+			strings.Compare(a.RecordType, b.RecordType),
+			strings.Compare(a.SetIdentifier, b.SetIdentifier),
+		)
+	})
+	return sorted
+}
+
+// writeZoneRecords writes one resource record per target, records without a TTL get
+// defaultTTL, or none when it is zero. Routing properties, like set identifiers, have
+// no zone file syntax: records differing only by them are all listed.
+func writeZoneRecords(b *bytes.Buffer, endpoints []*endpoint.Endpoint, defaultTTL endpoint.TTL) {
+	for _, ep := range endpoints {
+		ttl := ep.RecordTTL
+		if !ttl.IsConfigured() {
+			ttl = defaultTTL
+		}
+		ttlField := ""
+		if ttl.IsConfigured() {
+			ttlField = fmt.Sprintf(" %d", ttl)
+		}
+		for _, target := range ep.Targets {
+			fmt.Fprintf(b, "%s%s IN %s %s\n", provider.EnsureTrailingDot(ep.DNSName), ttlField, ep.RecordType, zoneTarget(ep.RecordType, target))
+		}
+	}
+}
+
+// zoneTarget returns the target in the zone file syntax: host names are made
+// absolute and TXT values are quoted.
+func zoneTarget(recordType, target string) string {
+	switch recordType {
+	case endpoint.RecordTypeCNAME, endpoint.RecordTypeNS, endpoint.RecordTypePTR:
+		return provider.EnsureTrailingDot(target)
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		// the host name is the last field, after the priority, weight and port
+		if i := strings.LastIndexByte(target, ' '); i >= 0 {
+			return target[:i+1] + provider.EnsureTrailingDot(target[i+1:])
+		}
+		return provider.EnsureTrailingDot(target)
+	case endpoint.RecordTypeTXT:
+		return txt.Normalize(target)
+	default:
+		return target
+	}
+}
+
+// writeFileAtomically writes data to a temporary file next to path and renames it
+// to path, so that readers never see a partially written file. The mode of an
+// existing file is kept.
+func writeFileAtomically(path string, data []byte) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
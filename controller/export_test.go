@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+func newTestExporter(t *testing.T, format string, endpoints ...*endpoint.Endpoint) *Exporter {
+	t.Helper()
+	return &Exporter{
+		Source:         testutils.NewMockSource(endpoints...),
+		DomainFilter:   endpoint.NewDomainFilter([]string{"example.org"}),
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX},
+		Path:           filepath.Join(t.TempDir(), "endpoints"),
+		Format:         format,
+	}
+}
+
+func TestExporterRunOnceJSON(t *testing.T) {
+	e := newTestExporter(t, ExportFormatJSON,
+		endpoint.NewEndpointWithTTL("b.example.org", endpoint.RecordTypeA, 60, "10.0.0.2", "10.0.0.1"),
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeCNAME, "lb.example.com"),
+		endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "10.0.0.3"),
+		endpoint.NewEndpoint("aaaa.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+	)
+	require.NoError(t, e.RunOnce(t.Context()))
+
+	data, err := os.ReadFile(e.Path)
+	require.NoError(t, err)
+	var exported []*endpoint.Endpoint
+	require.NoError(t, json.Unmarshal(data, &exported))
+	require.Len(t, exported, 2)
+	assert.Equal(t, "a.example.org", exported[0].DNSName)
+	assert.Equal(t, endpoint.Targets{"lb.example.com"}, exported[0].Targets)
+	assert.Equal(t, "b.example.org", exported[1].DNSName)
+	assert.Equal(t, endpoint.Targets{"10.0.0.1", "10.0.0.2"}, exported[1].Targets)
+	assert.Equal(t, endpoint.TTL(60), exported[1].RecordTTL)
+}
+
+func TestExporterRunOnceZone(t *testing.T) {
+	e := newTestExporter(t, ExportFormatZone,
+		endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 60, "10.0.0.1"),
+		endpoint.NewEndpoint("alias.example.org", endpoint.RecordTypeCNAME, "www.example.org"),
+		endpoint.NewEndpoint("example.org", endpoint.RecordTypeMX, "10 mail.example.org"),
+		endpoint.NewEndpoint("txt.example.org", endpoint.RecordTypeTXT, "v=spf1 -all"),
+	)
+	require.NoError(t, e.RunOnce(t.Context()))
+
+	data, err := os.ReadFile(e.Path)
+	require.NoError(t, err)
+	assert.Equal(t, `$TTL 300
+alias.example.org. IN CNAME www.example.org.
+example.org. IN MX 10 mail.example.org.
+txt.example.org. IN TXT "v=spf1 -all"
+www.example.org. 60 IN A 10.0.0.1
+`, string(data))
+}
+
+func TestExporterRunOnceUnchanged(t *testing.T) {
+	e := newTestExporter(t, ExportFormatJSON, endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "10.0.0.1"))
+	require.NoError(t, e.RunOnce(t.Context()))
+
+	// the file is not rewritten while the endpoints do not change
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(e.Path, past, past))
+	require.NoError(t, e.RunOnce(t.Context()))
+	info, err := os.Stat(e.Path)
+	require.NoError(t, err)
+	assert.Equal(t, past.Unix(), info.ModTime().Unix())
+
+	entries, err := os.ReadDir(filepath.Dir(e.Path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files are removed")
+}
+
+func TestExporterRunOnceSourceError(t *testing.T) {
+	src := new(testutils.MockSource)
+	src.On("Endpoints").Return(nil, errors.New("source failed"))
+	e := &Exporter{Source: src, Path: filepath.Join(t.TempDir(), "endpoints"), Format: ExportFormatJSON}
+
+	require.ErrorContains(t, e.RunOnce(t.Context()), "source failed")
+	assert.NoFileExists(t, e.Path)
+}
+
+func TestRenderEndpointsUnknownFormat(t *testing.T) {
+	_, err := renderEndpoints(nil, "yaml")
+	require.ErrorContains(t, err, "unknown export format")
+}
+
+func TestZoneTarget(t *testing.T) {
+	for _, tc := range []struct {
+		recordType string
+		target     string
+		expected   string
+	}{
+		{endpoint.RecordTypeA, "10.0.0.1", "10.0.0.1"},
+		{endpoint.RecordTypeCNAME, "lb.example.com", "lb.example.com."},
+		{endpoint.RecordTypeCNAME, "lb.example.com.", "lb.example.com."},
+		{endpoint.RecordTypeNS, "ns1.example.com", "ns1.example.com."},
+		{endpoint.RecordTypeMX, "10 mail.example.com", "10 mail.example.com."},
+		{endpoint.RecordTypeSRV, "10 5 5060 sip.example.com", "10 5 5060 sip.example.com."},
+		{endpoint.RecordTypeTXT, `say "hi"`, `"say \"hi\""`},
+	} {
+		t.Run(tc.recordType+" "+tc.target, func(t *testing.T) {
+			assert.Equal(t, tc.expected, zoneTarget(tc.recordType, tc.target))
+		})
+	}
+}
+
+func TestRunExportOnce(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.ExportFile = filepath.Join(t.TempDir(), "endpoints.zone")
+	cfg.ExportFormat = ExportFormatZone
+	cfg.ManagedDNSRecordTypes = []string{endpoint.RecordTypeA}
+	cfg.Once = true
+	src := testutils.NewMockSource(endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "10.0.0.1"))
+
+	require.NoError(t, runExport(t.Context(), cfg, src, endpoint.NewDomainFilter(nil), nil))
+
+	data, err := os.ReadFile(cfg.ExportFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "a.example.org. IN A 10.0.0.1\n")
+}
+
+// exportRunsSource reports each read of its endpoints on runs.
+type exportRunsSource struct {
+	runs chan struct{}
+}
+
+func (s *exportRunsSource) Endpoints(context.Context) ([]*endpoint.Endpoint, error) {
+	s.runs <- struct{}{}
+	return nil, nil
+}
+
+func (s *exportRunsSource) AddEventHandler(context.Context, func()) {}
+
+func TestExporterRunMinEventSyncInterval(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		interval time.Duration
+		batched  bool
+	}{
+		{name: "triggered export waits for the interval", interval: time.Hour, batched: true},
+		{name: "triggered export runs right away", interval: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			src := &exportRunsSource{runs: make(chan struct{}, 10)}
+			e := newTestExporter(t, ExportFormatJSON)
+			e.Source = src
+			e.Interval = time.Hour
+			e.MinEventSyncInterval = tc.interval
+
+			trigger := make(chan struct{}, 1)
+			go e.Run(t.Context(), trigger)
+			<-src.runs
+			trigger <- struct{}{}
+
+			select {
+			case <-src.runs:
+				assert.False(t, tc.batched, "export triggered before the minimum interval")
+			case <-time.After(200 * time.Millisecond):
+				assert.True(t, tc.batched, "triggered export did not run")
+			}
+		})
+	}
+}
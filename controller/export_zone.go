@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	// zoneSectionBegin and zoneSectionEnd are the lines delimiting the records
+	// written in an existing zone file with --export-zone-section.
+	zoneSectionBegin = "; external-dns: begin"
+	zoneSectionEnd   = "; external-dns: end"
+)
+
+// updateZoneSection returns the zone file with the lines between its section markers
+// replaced with the records of the endpoints and the serial of its SOA record increased.
+// It returns false, and the zone file unchanged, when the records are already in place.
+func updateZoneSection(zone []byte, endpoints []*endpoint.Endpoint) ([]byte, bool, error) {
+	var records bytes.Buffer
+	writeZoneRecords(&records, sortedEndpoints(endpoints), exportDefaultTTL)
+
+	lines := strings.SplitAfter(string(zone), "\n")
+	begin, end := -1, -1
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case zoneSectionBegin:
+			if begin >= 0 {
+				return nil, false, fmt.Errorf("zone file has several %q lines", zoneSectionBegin)
+			}
+			begin = i
+		case zoneSectionEnd:
+			if begin >= 0 && end < 0 {
+				end = i
+			}
+		}
+	}
+	if begin < 0 || end < 0 {
+		return nil, false, fmt.Errorf("zone file has no %q line followed by a %q line", zoneSectionBegin, zoneSectionEnd)
+	}
+	if strings.Join(lines[begin+1:end], "") == records.String() {
+		return zone, false, nil
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Join(lines[:begin+1], ""))
+	b.Write(records.Bytes())
+	b.WriteString(strings.Join(lines[end:], ""))
+	updated, err := increaseSOASerial(b.String())
+	if err != nil {
+		return nil, false, err
+	}
+	return []byte(updated), true, nil
+}
+
+// increaseSOASerial increases the serial of the first SOA record of the zone file by one,
+// wrapping around as in RFC 1982. The serial is the third field after the SOA type, after
+// the primary name server and the mailbox; comments and parentheses are skipped.
+func increaseSOASerial(zone string) (string, error) {
+	const separators = " \t\r\n();\""
+	soa := false
+	names := 0
+	for i := 0; i < len(zone); {
+		switch c := zone[i]; {
+		case c == ';':
+			for i < len(zone) && zone[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '"':
+			// a quoted character string, like a TXT value, is never the SOA type
+			for i++; i < len(zone) && zone[i] != '"'; i++ {
+				if zone[i] == '\\' {
+					i++
+				}
+			}
+			i++
+			continue
+		case strings.IndexByte(separators, c) >= 0:
+			i++
+			continue
+		}
+		start := i
+		for i < len(zone) && strings.IndexByte(separators, zone[i]) < 0 {
+			i++
+		}
+		token := zone[start:i]
+		switch {
+		case !soa:
+			soa = strings.EqualFold(token, "SOA")
+		case names < 2:
+			names++
+		default:
+			serial, err := strconv.ParseUint(token, 10, 32)
+			if err != nil {
+				return "", fmt.Errorf("invalid SOA serial %q", token)
+			}
+			return zone[:start] + strconv.FormatUint((serial+1)%(1<<32), 10) + zone[i:], nil
+		}
+	}
+	return "", errors.New("zone file has no SOA record")
+}
+
+// runReloadCommand runs command, split on spaces and without a shell, e.g. "rndc reload example.org".
+func runReloadCommand(ctx context.Context, command string) error {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil
+	}
+	out, err := exec.CommandContext(ctx, args[0], args[1:]...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("running %q: %w: %s", command, err, bytes.TrimSpace(out))
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const testZoneFile = `$ORIGIN example.org.
+$TTL 3600
+@ IN SOA ns1.example.org. hostmaster.example.org. (
+        2026101801 ; serial
+        7200 3600 1209600 3600 )
+@ IN NS ns1.example.org.
+; external-dns: begin
+old.example.org. 300 IN A 10.0.0.9
+; external-dns: end
+ns1 IN A 10.0.0.53
+`
+
+func TestUpdateZoneSection(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "10.0.0.1"),
+		endpoint.NewEndpointWithTTL("app.example.org", endpoint.RecordTypeCNAME, 60, "www.example.org"),
+	}
+
+	updated, changed, err := updateZoneSection([]byte(testZoneFile), endpoints)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, `$ORIGIN example.org.
+$TTL 3600
+@ IN SOA ns1.example.org. hostmaster.example.org. (
+        2026101802 ; serial
+        7200 3600 1209600 3600 )
+@ IN NS ns1.example.org.
+; external-dns: begin
+app.example.org. 60 IN CNAME www.example.org.
+www.example.org. 300 IN A 10.0.0.1
+; external-dns: end
+ns1 IN A 10.0.0.53
+`, string(updated))
+
+	again, changed, err := updateZoneSection(updated, endpoints)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, updated, again)
+}
+
+func TestUpdateZoneSectionMarkers(t *testing.T) {
+	for name, zone := range map[string]string{
+		"no markers":       "@ IN SOA ns1 hostmaster 1 7200 3600 1209600 3600\n",
+		"no end marker":    "; external-dns: begin\n",
+		"end before begin": "; external-dns: end\n; external-dns: begin\n",
+		"several begins":   "; external-dns: begin\n; external-dns: begin\n; external-dns: end\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := updateZoneSection([]byte(zone), nil)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestIncreaseSOASerial(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		zone     string
+		expected string
+		err      string
+	}{
+		{
+			name:     "single line",
+			zone:     "@ 3600 IN SOA ns1 hostmaster 41 7200 3600 1209600 3600\n",
+			expected: "@ 3600 IN SOA ns1 hostmaster 42 7200 3600 1209600 3600\n",
+		},
+		{
+			name:     "comments and parentheses",
+			zone:     "; the SOA record\n@ IN soa ns1 ( ; primary\n hostmaster ; mailbox\n 7 ; serial\n 7200 )\n",
+			expected: "; the SOA record\n@ IN soa ns1 ( ; primary\n hostmaster ; mailbox\n 8 ; serial\n 7200 )\n",
+		},
+		{
+			name:     "quoted SOA",
+			zone:     "txt IN TXT \"SOA \\\" 1 2 3\"\n@ IN SOA ns1 hostmaster 1 7200 3600 1209600 3600\n",
+			expected: "txt IN TXT \"SOA \\\" 1 2 3\"\n@ IN SOA ns1 hostmaster 2 7200 3600 1209600 3600\n",
+		},
+		{
+			name:     "wraps around",
+			zone:     "@ IN SOA ns1 hostmaster 4294967295 7200 3600 1209600 3600\n",
+			expected: "@ IN SOA ns1 hostmaster 0 7200 3600 1209600 3600\n",
+		},
+		{
+			name: "no SOA record",
+			zone: "@ IN NS ns1\n",
+			err:  "no SOA record",
+		},
+		{
+			name: "invalid serial",
+			zone: "@ IN SOA ns1 hostmaster serial 7200 3600 1209600 3600\n",
+			err:  "invalid SOA serial",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			zone, err := increaseSOASerial(tc.zone)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, zone)
+		})
+	}
+}
+
+func TestExporterZoneSection(t *testing.T) {
+	dir := t.TempDir()
+	reloaded := filepath.Join(dir, "reloaded")
+	e := newTestExporter(t, ExportFormatZone, endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "10.0.0.1"))
+	e.Path = filepath.Join(dir, "example.org.zone")
+	e.ZoneSection = true
+	e.ReloadCommand = "touch " + reloaded
+	require.NoError(t, os.WriteFile(e.Path, []byte(testZoneFile), 0o640))
+
+	require.NoError(t, e.RunOnce(t.Context()))
+	data, err := os.ReadFile(e.Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "2026101802 ; serial")
+	assert.Contains(t, string(data), "; external-dns: begin\nwww.example.org. 300 IN A 10.0.0.1\n; external-dns: end\n")
+	assert.FileExists(t, reloaded)
+	info, err := os.Stat(e.Path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+	// unchanged records neither increase the serial nor reload the zone
+	require.NoError(t, os.Remove(reloaded))
+	require.NoError(t, e.RunOnce(t.Context()))
+	data, err = os.ReadFile(e.Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "2026101802 ; serial")
+	assert.NoFileExists(t, reloaded)
+}
+
+func TestExporterZoneSectionMissingFile(t *testing.T) {
+	e := newTestExporter(t, ExportFormatZone)
+	e.ZoneSection = true
+	require.Error(t, e.RunOnce(t.Context()))
+}
+
+func TestExporterRetriesFailedReload(t *testing.T) {
+	reloaded := filepath.Join(t.TempDir(), "reloaded")
+	e := newTestExporter(t, ExportFormatZone, endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "10.0.0.1"))
+	e.ReloadCommand = "false"
+	require.Error(t, e.RunOnce(t.Context()))
+	require.FileExists(t, e.Path)
+
+	// the file did not change, the reload is retried anyway
+	e.ReloadCommand = "touch " + reloaded
+	require.NoError(t, e.RunOnce(t.Context()))
+	assert.FileExists(t, reloaded)
+
+	require.NoError(t, os.Remove(reloaded))
+	require.NoError(t, e.RunOnce(t.Context()))
+	assert.NoFileExists(t, reloaded)
+}
+
+func TestRunReloadCommand(t *testing.T) {
+	require.NoError(t, runReloadCommand(t.Context(), ""))
+	require.NoError(t, runReloadCommand(t.Context(), "true"))
+	require.ErrorContains(t, runReloadCommand(t.Context(), "false"), `running "false"`)
+}
@@ -17,11 +17,19 @@ limitations under the License.
 package controller
 
 import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/sets"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/source/types"
 )
 
 var (
@@ -98,6 +106,15 @@ var (
 		[]string{"record_type"},
 	)
 
+	registryZoneRecords = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "registry",
+			Name:      "zone_records",
+			Help:      "Number of registry records partitioned by zone and record type, the zones beyond --registry-records-zone-limit are reported as \"other\" (vector).",
+		},
+		[]string{"zone", "record_type"},
+	)
+
 	sourceRecords = metrics.NewGaugedVectorOpts(
 		prometheus.GaugeOpts{
 			Subsystem: "source",
@@ -116,6 +133,21 @@ var (
 		[]string{"record_type"},
 	)
 
+	driftRecords = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "drift_records",
+			Help:      "Number of records with changes planned again by consecutive syncs, i.e. out of sync despite being applied.",
+		},
+	)
+	lastSuccessfulFullSyncTimestamp = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "last_successful_full_sync_timestamp_seconds",
+			Help:      "Timestamp of the last sync that left all records in sync with the sources, after applying its changes if any.",
+		},
+	)
+
 	consecutiveSoftErrors = metrics.NewGaugeWithOpts(
 		prometheus.GaugeOpts{
 			Subsystem: "controller",
@@ -123,6 +155,87 @@ var (
 			Help:      "Number of consecutive soft errors in reconciliation loop.",
 		},
 	)
+
+	outOfBandCorrectionsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "controller",
+			Name:      "out_of_band_corrections_total",
+			Help:      "Number of records modified outside of external-dns and corrected by a full reconcile (vector).",
+		},
+		[]string{"record_type"},
+	)
+
+	deferredChanges = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "deferred_changes",
+			Help:      "Number of changes deferred by the last synchronization because they were planned outside of the change window or their delete delay has not elapsed (vector).",
+		},
+		[]string{"action"},
+	)
+
+	applyChunksTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "controller",
+			Name:      "apply_chunks_total",
+			Help:      "Number of change chunks applied when --apply-chunk-size is set, partitioned by result (success, failure).",
+		},
+		[]string{"result"},
+	)
+	endpointLimitExceededTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "controller",
+			Name:      "endpoint_limit_exceeded_total",
+			Help:      "Number of synchronizations aborted because the sources produced more endpoints than --max-endpoints.",
+		},
+	)
+	syncOutcomesTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "controller",
+			Name:      "sync_outcomes_total",
+			Help:      "Number of synchronizations partitioned by outcome (noop, skipped-due-to-cache, applied, partial, failed, skipped, suspended).",
+		},
+		[]string{"outcome"},
+	)
+
+	eventsDroppedTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "events",
+			Name:      "dropped_total",
+			Help:      "Number of Kubernetes events dropped before being sent, partitioned by reason (queue_full, rate_limited).",
+		},
+		[]string{"reason"},
+	)
+	eventsAggregatedTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "events",
+			Name:      "aggregated_total",
+			Help:      "Number of Kubernetes events folded into a per-object summary event.",
+		},
+	)
+
+	controllerSuspended = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "suspended",
+			Help:      "Whether the synchronizations are suspended (1) or not (0), see --suspend and --suspend-namespace.",
+		},
+	)
+	apexRecordDrift = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "apex_record_drift",
+			Help:      "Whether an apex record of --apex-records-file drifts (1) or not (0), partitioned by zone and record type.",
+		},
+		[]string{"zone", "record_type"},
+	)
+	featureEnabled = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Name: "feature_enabled",
+			Help: "Whether a capability is enabled (1) or not (0) by the configuration, partitioned by feature.",
+		},
+		[]string{"feature"},
+	)
 )
 
 func init() {
@@ -135,12 +248,39 @@ func init() {
 	metrics.RegisterMetric.MustRegister(deprecatedRegistryErrors)
 	metrics.RegisterMetric.MustRegister(deprecatedSourceErrors)
 	metrics.RegisterMetric.MustRegister(controllerNoChangesTotal)
+	metrics.RegisterMetric.MustRegister(endpointLimitExceededTotal)
+	metrics.RegisterMetric.MustRegister(syncOutcomesTotal)
 
 	metrics.RegisterMetric.MustRegister(registryRecords)
+	metrics.RegisterMetric.MustRegister(registryZoneRecords)
 	metrics.RegisterMetric.MustRegister(sourceRecords)
 	metrics.RegisterMetric.MustRegister(verifiedRecords)
 
+	metrics.RegisterMetric.MustRegister(driftRecords)
+	metrics.RegisterMetric.MustRegister(lastSuccessfulFullSyncTimestamp)
+	metrics.RegisterMetric.MustRegister(apexRecordDrift)
+	metrics.RegisterMetric.MustRegister(controllerSuspended)
+
 	metrics.RegisterMetric.MustRegister(consecutiveSoftErrors)
+	metrics.RegisterMetric.MustRegister(outOfBandCorrectionsTotal)
+	metrics.RegisterMetric.MustRegister(deferredChanges)
+	metrics.RegisterMetric.MustRegister(applyChunksTotal)
+
+	metrics.RegisterMetric.MustRegister(eventsDroppedTotal)
+	metrics.RegisterMetric.MustRegister(eventsAggregatedTotal)
+
+	metrics.RegisterMetric.MustRegister(featureEnabled)
+}
+
+// eventMetrics reports dropped and aggregated Kubernetes events as metrics.
+type eventMetrics struct{}
+
+func (eventMetrics) EventDropped(reason string) {
+	eventsDroppedTotal.CounterVec.WithLabelValues(reason).Inc()
+}
+
+func (eventMetrics) EventsAggregated(count int) {
+	eventsAggregatedTotal.Counter.Add(float64(count))
 }
 
 type dnsKey struct {
@@ -169,6 +309,51 @@ func countMatchingAddressRecords(endpoints []*endpoint.Endpoint, registryRecords
 	}
 }
 
+// otherZone is the zone label of the records of the zones beyond the limit.
+const otherZone = "other"
+
+// countZoneRecords counts the records of each zone and record type. Only the
+// limit zones with the most records get their own series, the records of the
+// other zones are counted under otherZone to bound the cardinality.
+func countZoneRecords(endpoints []*endpoint.Endpoint, zoneOf func(string) string, limit int, metric metrics.GaugeVecMetric) {
+	metric.Gauge.Reset()
+
+	counts := make(map[string]map[string]float64)
+	totals := make(map[string]int)
+	for _, ep := range endpoints {
+		zone := zoneOf(ep.DNSName)
+		if counts[zone] == nil {
+			counts[zone] = make(map[string]float64)
+		}
+		counts[zone][ep.RecordType]++
+		totals[zone]++
+	}
+
+	zones := slices.Collect(maps.Keys(totals))
+	slices.SortFunc(zones, func(a, b string) int {
+		if c := cmp.Compare(totals[b], totals[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+
+	other := make(map[string]float64)
+	for i, zone := range zones {
+		if limit > 0 && i >= limit {
+			for recordType, count := range counts[zone] {
+				other[recordType] += count
+			}
+			continue
+		}
+		for recordType, count := range counts[zone] {
+			metric.AddWithLabels(count, zone, recordType)
+		}
+	}
+	for recordType, count := range other {
+		metric.AddWithLabels(count, otherZone, recordType)
+	}
+}
+
 // countAddressRecords counts each record type in the provided endpoints slice.
 func countAddressRecords(endpoints []*endpoint.Endpoint, metric metrics.GaugeVecMetric) {
 	metric.Gauge.Reset()
@@ -182,3 +367,41 @@ func countAddressRecords(endpoints []*endpoint.Endpoint, metric metrics.GaugeVec
 		metric.AddWithLabels(count, recordType)
 	}
 }
+
+// countDeferredChanges reports the changes per action removed from planned by
+// the change window or the delete delay, and returns their total.
+func countDeferredChanges(planned, applied *plan.Changes) int {
+	deferred := map[string]int{
+		"create": len(planned.Create) - len(applied.Create),
+		"update": len(planned.UpdateNew) - len(applied.UpdateNew),
+		"delete": len(planned.Delete) - len(applied.Delete),
+	}
+	total := 0
+	for action, n := range deferred {
+		deferredChanges.SetWithLabels(float64(n), action)
+		total += n
+	}
+	return total
+}
+
+// enabledFeatures reports which of the capabilities inventoried by the feature
+// enabled metric are enabled by cfg.
+func enabledFeatures(cfg *externaldns.Config) map[string]bool {
+	return map[string]bool{
+		"events":             len(cfg.EmitEvents) > 0,
+		"dnsendpoint-status": slices.Contains(cfg.Sources, types.CRD) && !cfg.Diff,
+		"webhook-server":     cfg.WebhookServer,
+		"multi-provider":     len(cfg.WebhookServerProviders) > 0,
+	}
+}
+
+// countEnabledFeatures reports the capabilities enabled by cfg.
+func countEnabledFeatures(cfg *externaldns.Config) {
+	for feature, enabled := range enabledFeatures(cfg) {
+		value := 0.0
+		if enabled {
+			value = 1
+		}
+		featureEnabled.SetWithLabels(value, feature)
+	}
+}
@@ -302,6 +302,34 @@ func TestGaugeMetricsWithMixedRecords(t *testing.T) {
 	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 43, registryRecords.Gauge, map[string]string{"record_type": "ptr"})
 }
 
+func TestCountZoneRecords(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.big.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("b.big.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("c.big.com", endpoint.RecordTypeCNAME, "a.big.com"),
+		endpoint.NewEndpoint("a.medium.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("b.medium.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("a.small.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("a.tiny.co.uk", endpoint.RecordTypeAAAA, "::1"),
+	}
+	ctrl := &Controller{}
+
+	countZoneRecords(endpoints, ctrl.zoneOf, 2, registryZoneRecords)
+
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 2, registryZoneRecords.Gauge, map[string]string{"zone": "big.com", "record_type": "a"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, registryZoneRecords.Gauge, map[string]string{"zone": "big.com", "record_type": "cname"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 2, registryZoneRecords.Gauge, map[string]string{"zone": "medium.com", "record_type": "a"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, registryZoneRecords.Gauge, map[string]string{"zone": "other", "record_type": "a"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, registryZoneRecords.Gauge, map[string]string{"zone": "other", "record_type": "aaaa"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, registryZoneRecords.Gauge, map[string]string{"zone": "small.com", "record_type": "a"})
+
+	countZoneRecords(endpoints, ctrl.zoneOf, 0, registryZoneRecords)
+
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, registryZoneRecords.Gauge, map[string]string{"zone": "small.com", "record_type": "a"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, registryZoneRecords.Gauge, map[string]string{"zone": "tiny.co.uk", "record_type": "aaaa"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, registryZoneRecords.Gauge, map[string]string{"zone": "other", "record_type": "a"})
+}
+
 func newMixedRecordsFixture() *Controller {
 	configuredEndpoints := testutils.GenerateTestEndpointsByType(map[string]int{
 		endpoint.RecordTypeA:     534,
@@ -352,3 +380,26 @@ func BenchmarkGaugeMetricsWithMixedRecords(b *testing.B) {
 		}
 	}
 }
+
+func TestCountEnabledFeatures(t *testing.T) {
+	cfg := &externaldns.Config{
+		Sources:    []string{"service", "crd"},
+		EmitEvents: []string{"RecordReady"},
+	}
+	countEnabledFeatures(cfg)
+
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, featureEnabled.Gauge, map[string]string{"feature": "events"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, featureEnabled.Gauge, map[string]string{"feature": "dnsendpoint-status"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, featureEnabled.Gauge, map[string]string{"feature": "webhook-server"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, featureEnabled.Gauge, map[string]string{"feature": "multi-provider"})
+
+	// the diff mode leaves the DNSEndpoint status untouched
+	cfg.Diff = true
+	cfg.WebhookServer = true
+	cfg.WebhookServerProviders = []string{"inmemory"}
+	countEnabledFeatures(cfg)
+
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, featureEnabled.Gauge, map[string]string{"feature": "dnsendpoint-status"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, featureEnabled.Gauge, map[string]string{"feature": "webhook-server"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, featureEnabled.Gauge, map[string]string{"feature": "multi-provider"})
+}
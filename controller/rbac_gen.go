@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	registryfactory "sigs.k8s.io/external-dns/registry/factory"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// rbacGenCommand is the first argument selecting the rbac-gen command.
+const rbacGenCommand = "rbac-gen"
+
+// clusterScopedResources are the resources read by sources that can only be
+// granted by a ClusterRole.
+var clusterScopedResources = []string{"namespaces", "nodes"}
+
+type rbacManifest struct {
+	APIVersion string              `json:"apiVersion"`
+	Kind       string              `json:"kind"`
+	Metadata   rbacMetadata        `json:"metadata"`
+	Rules      []rbacv1.PolicyRule `json:"rules"`
+}
+
+type rbacMetadata struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// rbacGen prints the minimal RBAC manifests needed to run with the sources and
+// registry selected in args. With --namespace the rules are granted by a Role in
+// that namespace, plus a ClusterRole for cluster-scoped resources if needed.
+func rbacGen(args []string, w io.Writer) error {
+	var (
+		sources   []string
+		registry  string
+		name      string
+		namespace string
+	)
+	app := kingpin.New("external-dns "+rbacGenCommand, "Print the RBAC manifests needed for the selected sources and registry.")
+	app.Flag("sources", "Comma-separated sources to generate the rules for; specify multiple times for multiple lists (required)").Required().StringsVar(&sources)
+	app.Flag("registry", "The registry the rules are generated for").Default(externaldns.RegistryTXT).StringVar(&registry)
+	app.Flag("name", "The name of the generated Role or ClusterRole").Default("external-dns").StringVar(&name)
+	app.Flag("namespace", "Generate a Role in this namespace instead of a ClusterRole (optional)").StringVar(&namespace)
+	if _, err := app.Parse(args); err != nil {
+		return err
+	}
+
+	var names []string
+	for _, s := range sources {
+		for n := range strings.SplitSeq(s, ",") {
+			if n = strings.TrimSpace(n); n != "" {
+				names = append(names, n)
+			}
+		}
+	}
+	rules, err := source.RBACRules(names)
+	if err != nil {
+		return err
+	}
+	registryRules, err := registryfactory.RBACRules(registry)
+	if err != nil {
+		return err
+	}
+	rules = source.MergeRBACRules(append(rules, registryRules...))
+
+	var manifests []rbacManifest
+	if namespace == "" {
+		manifests = append(manifests, rbacManifest{Kind: "ClusterRole", Metadata: rbacMetadata{Name: name}, Rules: rules})
+	} else {
+		var namespaced, clusterScoped []rbacv1.PolicyRule
+		for _, rule := range rules {
+			for _, resource := range rule.Resources {
+				r := rule
+				r.Resources = []string{resource}
+				if rule.APIGroups[0] == "" && slices.Contains(clusterScopedResources, resource) {
+					clusterScoped = append(clusterScoped, r)
+				} else {
+					namespaced = append(namespaced, r)
+				}
+			}
+		}
+		namespaced, clusterScoped = source.MergeRBACRules(namespaced), source.MergeRBACRules(clusterScoped)
+		manifests = append(manifests, rbacManifest{Kind: "Role", Metadata: rbacMetadata{Name: name, Namespace: namespace}, Rules: namespaced})
+		if len(clusterScoped) > 0 {
+			manifests = append(manifests, rbacManifest{Kind: "ClusterRole", Metadata: rbacMetadata{Name: name + "-cluster"}, Rules: clusterScoped})
+		}
+	}
+
+	for i, m := range manifests {
+		m.APIVersion = rbacv1.SchemeGroupVersion.String()
+		if m.Rules == nil {
+			m.Rules = []rbacv1.PolicyRule{}
+		}
+		out, err := yaml.Marshal(m)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(out); err != nil {
+			return fmt.Errorf("writing manifests: %w", err)
+		}
+	}
+	return nil
+}
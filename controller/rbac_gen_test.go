@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRBACGenClusterRole(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, rbacGen([]string{"--sources=ingress,crd"}, &out))
+
+	assert.Equal(t, `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: external-dns
+rules:
+- apiGroups:
+  - externaldns.k8s.io
+  resources:
+  - dnsendpoints
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - externaldns.k8s.io
+  resources:
+  - dnsendpoints/status
+  verbs:
+  - update
+- apiGroups:
+  - networking.k8s.io
+  resources:
+  - ingresses
+  verbs:
+  - get
+  - list
+  - watch
+`, out.String())
+}
+
+func TestRBACGenNamespaced(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, rbacGen([]string{"--sources=pod", "--namespace=dns", "--name=edns"}, &out))
+
+	assert.Equal(t, `apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: edns
+  namespace: dns
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - get
+  - list
+  - watch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: edns-cluster
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - nodes
+  verbs:
+  - list
+  - watch
+`, out.String())
+}
+
+func TestRBACGenRegistry(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, rbacGen([]string{"--sources=fake", "--registry=crd"}, &out))
+	assert.Contains(t, out.String(), "dnsrecords")
+}
+
+func TestRBACGenErrors(t *testing.T) {
+	require.Error(t, rbacGen(nil, &bytes.Buffer{}))
+	require.EqualError(t, rbacGen([]string{"--sources=bogus"}, &bytes.Buffer{}), "unknown source: bogus")
+	require.EqualError(t, rbacGen([]string{"--sources=ingress", "--registry=bogus"}, &bytes.Buffer{}), "unknown registry: bogus")
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns/validation"
+	providerfactory "sigs.k8s.io/external-dns/provider/factory"
+	registryfactory "sigs.k8s.io/external-dns/registry/factory"
+)
+
+// recordsCommand is the first argument selecting the records command.
+const recordsCommand = "records"
+
+const (
+	recordsOutputTable = "table"
+	recordsOutputJSON  = "json"
+)
+
+// records prints the records of the provider selected in args, with the ownership
+// read from the registry, without running a synchronization. It takes the flags of
+// the controller, plus --zone to only list some zones of the provider and --output.
+func records(ctx context.Context, args []string, w io.Writer) error {
+	var (
+		zones  []string
+		output string
+	)
+	cfg := externaldns.NewConfig()
+	app := externaldns.App(cfg)
+	app.Name = "external-dns " + recordsCommand
+	app.Help = "Print the current records of the provider and their owners, without running a synchronization."
+	app.Flag("zone", "Only print the records of this zone; specify multiple times for multiple zones (optional, default: all zones)").StringsVar(&zones)
+	app.Flag("output", "The output format (default: table, options: table, json)").Default(recordsOutputTable).EnumVar(&output, recordsOutputTable, recordsOutputJSON)
+	if _, err := app.Parse(args); err != nil {
+		return err
+	}
+	if err := validation.ValidateConfig(cfg); err != nil {
+		return fmt.Errorf("config validation failed: %w", err)
+	}
+	if err := configureLogger(cfg); err != nil {
+		return err
+	}
+
+	domainFilter := endpoint.NewDomainFilterWithOptions(
+		endpoint.WithDomainFilter(cfg.DomainFilter),
+		endpoint.WithDomainExclude(cfg.DomainExclude),
+		endpoint.WithRegexDomainFilter(cfg.RegexDomainFilter),
+		endpoint.WithRegexDomainExclude(cfg.RegexDomainExclude),
+	)
+	if len(zones) > 0 {
+		// the provider only lists the given zones
+		domainFilter = zonesDomainFilter(zones)
+	}
+	p, err := providerfactory.Select(ctx, cfg, domainFilter)
+	if err != nil {
+		return err
+	}
+	resolveManagedRecordTypes(cfg, p)
+	reg, err := registryfactory.Select(cfg, p)
+	if err != nil {
+		return err
+	}
+	endpoints, err := reg.Records(ctx)
+	if err != nil {
+		return fmt.Errorf("listing the records: %w", err)
+	}
+	return writeRecords(w, zoneRecords(endpoints, zones), output)
+}
+
+// zonesDomainFilter returns a domain filter matching the zones exactly, and not
+// their subdomains, which may be delegated to other zones.
+func zonesDomainFilter(zones []string) *endpoint.DomainFilter {
+	quoted := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		quoted = append(quoted, regexp.QuoteMeta(strings.ToLower(strings.Trim(zone, "."))))
+	}
+	return endpoint.NewRegexDomainFilter(regexp.MustCompile(`(?i)^(`+strings.Join(quoted, "|")+`)$`), nil)
+}
+
+// zoneRecords returns the endpoints in any of the zones, all of them without zones.
+// It only matters for providers which do not restrict their zones to the domain filter.
+func zoneRecords(endpoints []*endpoint.Endpoint, zones []string) []*endpoint.Endpoint {
+	if len(zones) == 0 {
+		return endpoints
+	}
+	var filtered []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		name := strings.ToLower(strings.TrimSuffix(ep.DNSName, "."))
+		for _, zone := range zones {
+			zone = strings.ToLower(strings.Trim(zone, "."))
+			if name == zone || strings.HasSuffix(name, "."+zone) {
+				filtered = append(filtered, ep)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// writeRecords writes the endpoints to w, sorted like the exported ones, as a table
+// with one row per endpoint or as the JSON exported with --export-format=json.
+func writeRecords(w io.Writer, endpoints []*endpoint.Endpoint, output string) error {
+	if output == recordsOutputJSON {
+		data, err := renderEndpoints(endpoints, ExportFormatJSON)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	ew := &errWriter{w: tw}
+	ew.printf("NAME\tTYPE\tTTL\tTARGETS\tSET IDENTIFIER\tOWNER\tRESOURCE\n")
+	for _, ep := range sortedEndpoints(endpoints) {
+		ttl := "-"
+		if ep.RecordTTL.IsConfigured() {
+			ttl = fmt.Sprint(ep.RecordTTL)
+		}
+		ew.printf("%s\t%s\t%s\t%s\t%s\t%s\t%s\n", ep.DNSName, ep.RecordType, ttl, strings.Join(ep.Targets, ","),
+			orDash(ep.SetIdentifier), orDash(ep.Labels[endpoint.OwnerLabelKey]), orDash(ep.Labels[endpoint.ResourceLabelKey]))
+	}
+	if ew.err != nil {
+		return ew.err
+	}
+	return tw.Flush()
+}
+
+// orDash returns s, or "-" to keep the table columns aligned when it is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
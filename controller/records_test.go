@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func testRecords() []*endpoint.Endpoint {
+	return []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 300, "10.0.0.2", "10.0.0.1").
+			WithLabel(endpoint.OwnerLabelKey, "default").
+			WithLabel(endpoint.ResourceLabelKey, "ingress/default/web"),
+		endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "lb.example.com").
+			WithSetIdentifier("eu"),
+		endpoint.NewEndpoint("example.org", endpoint.RecordTypeTXT, "v=spf1 -all"),
+	}
+}
+
+func TestWriteRecordsTable(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeRecords(&out, testRecords(), recordsOutputTable))
+
+	assert.Equal(t, `NAME             TYPE   TTL  TARGETS            SET IDENTIFIER  OWNER    RESOURCE
+api.example.com  CNAME  -    lb.example.com     eu              -        -
+example.org      TXT    -    v=spf1 -all        -               -        -
+www.example.org  A      300  10.0.0.1,10.0.0.2  -               default  ingress/default/web
+`, out.String())
+}
+
+func TestWriteRecordsJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeRecords(&out, testRecords(), recordsOutputJSON))
+
+	var written []*endpoint.Endpoint
+	require.NoError(t, json.Unmarshal(out.Bytes(), &written))
+	require.Len(t, written, 3)
+	assert.Equal(t, "www.example.org", written[2].DNSName)
+	assert.Equal(t, "default", written[2].Labels[endpoint.OwnerLabelKey])
+	assert.Equal(t, "ingress/default/web", written[2].Labels[endpoint.ResourceLabelKey])
+}
+
+func TestZoneRecords(t *testing.T) {
+	names := func(endpoints []*endpoint.Endpoint) []string {
+		var names []string
+		for _, ep := range endpoints {
+			names = append(names, ep.DNSName)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"www.example.org", "api.example.com", "example.org"}, names(zoneRecords(testRecords(), nil)))
+	assert.Equal(t, []string{"www.example.org", "example.org"}, names(zoneRecords(testRecords(), []string{"Example.org."})))
+	assert.Equal(t, []string{"www.example.org"}, names(zoneRecords(testRecords(), []string{"www.example.org"})))
+	assert.Equal(t, []string{"www.example.org", "api.example.com", "example.org"}, names(zoneRecords(testRecords(), []string{"example.org", "example.com"})))
+	assert.Empty(t, zoneRecords(testRecords(), []string{"ample.org"}))
+}
+
+func TestZonesDomainFilter(t *testing.T) {
+	filter := zonesDomainFilter([]string{"Example.org.", "example.com"})
+	assert.True(t, filter.Match("example.org."))
+	assert.True(t, filter.Match("example.com"))
+	assert.False(t, filter.Match("sub.example.org."), "delegated subzones are not listed")
+	assert.False(t, filter.Match("example.org.uk"))
+	assert.False(t, filter.Match("exampleXorg"))
+}
+
+func TestRecordsCommand(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, records(t.Context(), []string{
+		"--provider=inmemory",
+		"--inmemory-zone=example.org",
+		"--source=service",
+		"--registry=noop",
+		"--zone=example.org",
+		"--output=json",
+	}, &out))
+	assert.Equal(t, "[]\n", out.String())
+
+	err := records(t.Context(), []string{"--provider=inmemory", "--source=service", "--output=yaml"}, &out)
+	assert.ErrorContains(t, err, "enum value must be one of table,json")
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyncSchedule is a cron schedule of periodic synchronizations, with the
+// standard five fields: minute, hour, day of month, month and day of week.
+// When both the day of month and the day of week are restricted, a day matching
+// either one matches, as in cron.
+type SyncSchedule struct {
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+	// anyDay is set when the day of month or the day of week is unrestricted
+	anyDay   bool
+	location *time.Location
+}
+
+// scheduleField describes the allowed values of a cron field.
+type scheduleField struct {
+	name     string
+	min, max int
+}
+
+var scheduleFields = []scheduleField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7}, // 0 and 7 are both Sunday
+}
+
+// ParseSyncSchedule parses a cron expression such as "*/10 * * * *". Each field
+// is "*" or a comma separated list of values and ranges, optionally followed by
+// a "/step". Times are evaluated in loc. An empty spec returns a nil schedule.
+func ParseSyncSchedule(spec string, loc *time.Location) (*SyncSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return nil, nil //nolint:nilnil // no schedule configured
+	}
+	if len(fields) != len(scheduleFields) {
+		return nil, fmt.Errorf("invalid sync schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+	bits := make([]uint64, len(fields))
+	for i, f := range fields {
+		var err error
+		if bits[i], err = scheduleFields[i].parse(f); err != nil {
+			return nil, fmt.Errorf("invalid sync schedule %q: %w", spec, err)
+		}
+	}
+	schedule := &SyncSchedule{
+		minutes:  bits[0],
+		hours:    bits[1],
+		days:     bits[2],
+		months:   bits[3],
+		weekdays: bits[4] | bits[4]>>7,
+		anyDay:   fields[2] == "*" || fields[4] == "*",
+		location: loc,
+	}
+	if schedule.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("invalid sync schedule %q: never matches", spec)
+	}
+	return schedule, nil
+}
+
+func (f scheduleField) parse(s string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(s, ",") {
+		expr, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepStr, f.name)
+			}
+		}
+		first, last := f.min, f.max
+		if expr != "*" {
+			from, to, isRange := strings.Cut(expr, "-")
+			var err error
+			if first, err = f.value(from); err != nil {
+				return 0, err
+			}
+			last = first
+			if isRange {
+				if last, err = f.value(to); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				last = f.max
+			}
+			if last < first {
+				return 0, fmt.Errorf("invalid range %q in %s field", expr, f.name)
+			}
+		}
+		for v := first; v <= last; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func (f scheduleField) value(s string) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, expected %d-%d", s, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first scheduled time after t, or the zero time when the
+// schedule never matches, e.g. on February 30th.
+func (s *SyncSchedule) Next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	// every schedule repeats within a leap year cycle
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.months&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+		case s.hours&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+		case s.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *SyncSchedule) matchesDay(t time.Time) bool {
+	day := s.days&(1<<uint(t.Day())) != 0
+	weekday := s.weekdays&(1<<uint(t.Weekday())) != 0
+	if s.anyDay {
+		return day && weekday
+	}
+	return day || weekday
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSyncSchedule(t *testing.T) {
+	schedule, err := ParseSyncSchedule("", time.UTC)
+	require.NoError(t, err)
+	assert.Nil(t, schedule)
+
+	for _, spec := range []string{
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"a * * * *",
+		"0 0 30 2 *",
+	} {
+		_, err := ParseSyncSchedule(spec, time.UTC)
+		assert.ErrorContains(t, err, "invalid sync schedule", spec)
+	}
+}
+
+func TestSyncScheduleNext(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return v
+	}
+	for _, tt := range []struct {
+		spec string
+		now  string
+		next string
+	}{
+		{"*/10 * * * *", "2026-10-18T10:00:00Z", "2026-10-18T10:10:00Z"},
+		{"*/10 * * * *", "2026-10-18T10:04:59Z", "2026-10-18T10:10:00Z"},
+		{"*/10 * * * *", "2026-10-18T23:55:00Z", "2026-10-19T00:00:00Z"},
+		{"5,35 * * * *", "2026-10-18T10:05:00Z", "2026-10-18T10:35:00Z"},
+		{"0 9-17/4 * * *", "2026-10-18T14:00:00Z", "2026-10-18T17:00:00Z"},
+		{"0 22 * * 1-5", "2026-10-17T23:00:00Z", "2026-10-19T22:00:00Z"},
+		{"30 2 * * 7", "2026-10-18T03:00:00Z", "2026-10-25T02:30:00Z"},
+		{"0 0 1 * *", "2026-10-18T10:00:00Z", "2026-11-01T00:00:00Z"},
+		{"0 0 29 2 *", "2026-10-18T10:00:00Z", "2028-02-29T00:00:00Z"},
+		// day of month or day of week when both are restricted
+		{"0 0 1 * 1", "2026-10-18T10:00:00Z", "2026-10-19T00:00:00Z"},
+	} {
+		t.Run(tt.spec+" after "+tt.now, func(t *testing.T) {
+			schedule, err := ParseSyncSchedule(tt.spec, time.UTC)
+			require.NoError(t, err)
+			assert.Equal(t, at(tt.next), schedule.Next(at(tt.now)))
+		})
+	}
+}
+
+func TestSyncScheduleNextInLocation(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+	schedule, err := ParseSyncSchedule("0 * * * *", kolkata)
+	require.NoError(t, err)
+
+	next := schedule.Next(time.Date(2026, 10, 18, 10, 10, 0, 0, kolkata))
+	assert.Equal(t, time.Date(2026, 10, 18, 11, 0, 0, 0, kolkata), next)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// Suspension holds back the changes of the synchronizations, e.g. to freeze DNS
+// during incident response, while the records are still read and reported. The
+// synchronizations are suspended by --suspend, or while the suspend annotation is
+// set to "true" on a namespace.
+type Suspension struct {
+	// Suspended suspends the synchronizations regardless of the annotation
+	Suspended bool
+	client    kubernetes.Interface
+	namespace string
+	// annotated is the suspend annotation last read, kept when the namespace cannot be read
+	annotated atomic.Bool
+}
+
+// NewSuspension returns the suspension set by the flag, or by the suspend annotation
+// of namespace. It returns nil when neither can suspend the synchronizations.
+func NewSuspension(suspended bool, client kubernetes.Interface, namespace string) *Suspension {
+	if !suspended && namespace == "" {
+		return nil
+	}
+	return &Suspension{Suspended: suspended, client: client, namespace: namespace}
+}
+
+// check reads the suspend annotation and reports whether the synchronizations are suspended.
+func (s *Suspension) check(ctx context.Context) bool {
+	if s.namespace != "" {
+		ns, err := s.client.CoreV1().Namespaces().Get(ctx, s.namespace, metav1.GetOptions{})
+		if err != nil {
+			log.Warnf("Unable to read the suspend annotation of namespace %s, keeping the last state: %v", s.namespace, err)
+		} else if annotated := ns.Annotations[annotations.SuspendKey] == "true"; annotated != s.annotated.Swap(annotated) {
+			if annotated {
+				log.Warnf("Suspending the synchronizations: namespace %s has the annotation %s", s.namespace, annotations.SuspendKey)
+			} else {
+				log.Infof("Resuming the synchronizations: namespace %s no longer has the annotation %s", s.namespace, annotations.SuspendKey)
+			}
+		}
+	}
+	return s.IsSuspended()
+}
+
+// IsSuspended reports whether the synchronizations are suspended, as of the last check.
+func (s *Suspension) IsSuspended() bool {
+	return s != nil && (s.Suspended || s.annotated.Load())
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/external-dns/plan"
+	registryfactory "sigs.k8s.io/external-dns/registry/factory"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+func TestNewSuspension(t *testing.T) {
+	assert.Nil(t, NewSuspension(false, nil, ""))
+	assert.False(t, NewSuspension(false, nil, "").IsSuspended())
+	assert.True(t, NewSuspension(true, nil, "").IsSuspended())
+	assert.True(t, NewSuspension(true, nil, "").check(t.Context()))
+}
+
+func TestSuspensionAnnotation(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "external-dns"}}
+	client := fake.NewClientset(ns)
+	s := NewSuspension(false, client, "external-dns")
+	assert.False(t, s.check(t.Context()))
+
+	ns.Annotations = map[string]string{annotations.SuspendKey: "true"}
+	_, err := client.CoreV1().Namespaces().Update(t.Context(), ns, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.True(t, s.check(t.Context()))
+	assert.True(t, s.IsSuspended())
+
+	// the last state is kept when the namespace cannot be read
+	client.PrependReactor("get", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	assert.True(t, s.check(t.Context()))
+
+	client.ReactionChain = client.ReactionChain[1:]
+	ns.Annotations[annotations.SuspendKey] = "false"
+	_, err = client.CoreV1().Namespaces().Update(t.Context(), ns, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.False(t, s.check(t.Context()))
+}
+
+func TestRunOnce_Suspended(t *testing.T) {
+	cfg := getTestConfig()
+	provider := getTestProvider()
+	expected := provider.(*mockProvider).ExpectChanges
+	// a suspended synchronization applies no change
+	provider.(*mockProvider).ExpectChanges = &plan.Changes{}
+
+	r, err := registryfactory.Select(cfg, provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             getTestSource(),
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+		Suspension:         NewSuspension(true, nil, ""),
+	}
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, source.SyncOutcomeSuspended, ctrl.syncs.lastFinished().Outcome)
+	assert.InDelta(t, 1, testutil.ToFloat64(controllerSuspended.Gauge), 0)
+
+	provider.(*mockProvider).ExpectChanges = expected
+	ctrl.Suspension.Suspended = false
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, source.SyncOutcomeApplied, ctrl.syncs.lastFinished().Outcome)
+	assert.InDelta(t, 0, testutil.ToFloat64(controllerSuspended.Gauge), 0)
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/source"
+)
+
+// syncStatus describes a finished synchronization, served by /status.
+type syncStatus struct {
+	ID         uint64             `json:"id"`
+	Outcome    source.SyncOutcome `json:"outcome"`
+	Error      string             `json:"error,omitempty"`
+	StartedAt  time.Time          `json:"startedAt"`
+	FinishedAt time.Time          `json:"finishedAt"`
+}
+
+// syncTracker numbers the synchronizations and keeps the status of the last
+// finished one.
+type syncTracker struct {
+	mu      sync.Mutex
+	started uint64
+	last    *syncStatus
+}
+
+// start returns the ID of a starting synchronization.
+func (t *syncTracker) start() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.started++
+	return t.started
+}
+
+// finish records the status of a finished synchronization.
+func (t *syncTracker) finish(status syncStatus) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.last = &status
+}
+
+// next returns the ID the next synchronization to start will get.
+func (t *syncTracker) next() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.started + 1
+}
+
+// lastFinished returns the status of the last finished synchronization, nil before the first one.
+func (t *syncTracker) lastFinished() *syncStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.last
+}
+
+// TriggerSync schedules a synchronization as soon as allowed, see ScheduleRunOnce.
+// It returns the ID of the next synchronization, which includes all changes made
+// before the call, and when it is due.
+func (c *Controller) TriggerSync(now time.Time) (uint64, time.Time) {
+	c.ScheduleRunOnce(now)
+	c.runAtMutex.Lock()
+	defer c.runAtMutex.Unlock()
+	return c.syncs.next(), latest(now, c.nextRunAt)
+}
+
+// syncResponse is the response of POST /sync.
+type syncResponse struct {
+	SyncID      uint64    `json:"syncId"`
+	ScheduledAt time.Time `json:"scheduledAt"`
+}
+
+// statusResponse is the response of GET /status.
+type statusResponse struct {
+	LastSync   *syncStatus `json:"lastSync,omitempty"`
+	NextSyncID uint64      `json:"nextSyncId"`
+	NextSyncAt time.Time   `json:"nextSyncAt"`
+	Suspended  bool        `json:"suspended"`
+}
+
+// statusHandler serves /status, reporting the last finished synchronization
+// and the next one as JSON.
+func statusHandler(ctrl *Controller) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		ctrl.runAtMutex.Lock()
+		nextRunAt := ctrl.nextRunAt
+		ctrl.runAtMutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(statusResponse{
+			LastSync:   ctrl.syncs.lastFinished(),
+			NextSyncID: ctrl.syncs.next(),
+			NextSyncAt: nextRunAt,
+			Suspended:  ctrl.Suspension.IsSuspended(),
+		})
+	})
+}
+
+// tokenAuthenticator reports whether a bearer token is accepted.
+type tokenAuthenticator func(ctx context.Context, token string) (bool, error)
+
+// staticTokenAuthenticator accepts the token stored in file.
+func staticTokenAuthenticator(file string) (tokenAuthenticator, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading sync API token: %w", err)
+	}
+	expected := []byte(strings.TrimSpace(string(data)))
+	if len(expected) == 0 {
+		return nil, fmt.Errorf("sync API token file %s is empty", file)
+	}
+	return func(_ context.Context, token string) (bool, error) {
+		return subtle.ConstantTimeCompare([]byte(token), expected) == 1, nil
+	}, nil
+}
+
+// syncAPIAudience is the audience of the tokens accepted with a TokenReview, so that
+// the tokens issued for other services, e.g. the Kubernetes API, are refused.
+const syncAPIAudience = "external-dns-sync-api"
+
+// tokenReviewAuthenticator accepts the tokens Kubernetes authenticates with a TokenReview
+// for the syncAPIAudience.
+func tokenReviewAuthenticator(client kubernetes.Interface) tokenAuthenticator {
+	return func(ctx context.Context, token string) (bool, error) {
+		review, err := client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+			Spec: authenticationv1.TokenReviewSpec{Token: token, Audiences: []string{syncAPIAudience}},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, err
+		}
+		if !review.Status.Authenticated || !slices.Contains(review.Status.Audiences, syncAPIAudience) {
+			return false, nil
+		}
+		log.Debugf("sync API: authenticated %s", review.Status.User.Username)
+		return true, nil
+	}
+}
+
+// syncHandler serves POST /sync, scheduling a synchronization for the callers
+// authenticated with a bearer token. The token is first checked by static without
+// any limit. The other requests are limited to one per limiter period before
+// authenticating them with review, so that they can neither flood the TokenReview
+// API nor keep the callers of the static token from syncing. Either authenticator
+// may be nil.
+func syncHandler(ctrl *Controller, static, review tokenAuthenticator, limiter *rate.Limiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		authenticated := false
+		if static != nil {
+			authenticated, _ = static(r.Context(), token)
+		}
+		if !authenticated && review != nil {
+			reservation := limiter.Reserve()
+			if delay := reservation.Delay(); delay > 0 {
+				reservation.Cancel()
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+				http.Error(w, "too many sync requests", http.StatusTooManyRequests)
+				return
+			}
+			var err error
+			if authenticated, err = review(r.Context(), token); err != nil {
+				log.Warnf("sync API: could not authenticate request: %v", err)
+			}
+		}
+		if !authenticated {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, at := ctrl.TriggerSync(time.Now())
+		log.Infof("sync API: scheduled sync %d at %s", id, at.Format(time.RFC3339))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(syncResponse{SyncID: id, ScheduledAt: at})
+	})
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/source"
+)
+
+func TestSyncHandler(t *testing.T) {
+	// the next periodic sync is far away
+	ctrl := &Controller{nextRunAt: time.Now().Add(time.Hour)}
+	authenticate := func(_ context.Context, token string) (bool, error) { return token == "secret", nil }
+	handler := syncHandler(ctrl, nil, authenticate, rate.NewLimiter(rate.Every(time.Minute), 1))
+
+	request := func(method, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/sync", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusMethodNotAllowed, request(http.MethodGet, "secret").Code)
+	assert.Equal(t, http.StatusUnauthorized, request(http.MethodPost, "").Code)
+
+	rec := request(http.MethodPost, "secret")
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var response syncResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, uint64(1), response.SyncID)
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), response.ScheduledAt, time.Second)
+	assert.False(t, ctrl.ShouldRunOnce(time.Now()), "the sync is batched like the ones triggered by events")
+	assert.True(t, ctrl.ShouldRunOnce(response.ScheduledAt))
+
+	rec = request(http.MethodPost, "secret")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+}
+
+func TestSyncHandlerRateLimitsBeforeAuthenticating(t *testing.T) {
+	ctrl := &Controller{nextRunAt: time.Now().Add(time.Hour)}
+	authentications := 0
+	authenticate := func(_ context.Context, token string) (bool, error) {
+		authentications++
+		return token == "secret", nil
+	}
+	handler := syncHandler(ctrl, nil, authenticate, rate.NewLimiter(rate.Every(time.Minute), 1))
+
+	request := func(token string) int {
+		req := httptest.NewRequest(http.MethodPost, "/sync", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, request("wrong"))
+	assert.Equal(t, http.StatusTooManyRequests, request("wrong"))
+	assert.Equal(t, http.StatusTooManyRequests, request("secret"))
+	assert.Equal(t, 1, authentications, "the rate limited requests are not authenticated")
+}
+
+func TestSyncHandlerDoesNotRateLimitStaticToken(t *testing.T) {
+	ctrl := &Controller{nextRunAt: time.Now().Add(time.Hour)}
+	static := func(_ context.Context, token string) (bool, error) { return token == "static", nil }
+	reviews := 0
+	review := func(_ context.Context, token string) (bool, error) {
+		reviews++
+		return token == "reviewed", nil
+	}
+	handler := syncHandler(ctrl, static, review, rate.NewLimiter(rate.Every(time.Minute), 1))
+
+	request := func(token string) int {
+		req := httptest.NewRequest(http.MethodPost, "/sync", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, request("wrong"))
+	assert.Equal(t, http.StatusTooManyRequests, request("wrong"))
+	assert.Equal(t, http.StatusTooManyRequests, request("reviewed"))
+	assert.Equal(t, http.StatusAccepted, request("static"), "the static token is checked without any limit")
+	assert.Equal(t, http.StatusAccepted, request("static"))
+	assert.Equal(t, 1, reviews, "the static token and the rate limited requests are not reviewed")
+}
+
+func TestStatusHandler(t *testing.T) {
+	src := new(testutils.MockSource)
+	src.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+	ctrl := &Controller{
+		Source:             src,
+		Registry:           &chunkRegistry{},
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	status := func() statusResponse {
+		rec := httptest.NewRecorder()
+		statusHandler(ctrl).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response statusResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		return response
+	}
+
+	before := status()
+	assert.Nil(t, before.LastSync)
+	assert.Equal(t, uint64(1), before.NextSyncID)
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	after := status()
+	require.NotNil(t, after.LastSync)
+	assert.Equal(t, uint64(1), after.LastSync.ID)
+	assert.Equal(t, source.SyncOutcomeNoop, after.LastSync.Outcome)
+	assert.Empty(t, after.LastSync.Error)
+	assert.Equal(t, uint64(2), after.NextSyncID)
+}
+
+func TestStaticTokenAuthenticator(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(file, []byte("secret\n"), 0o600))
+
+	authenticate, err := staticTokenAuthenticator(file)
+	require.NoError(t, err)
+	ok, err := authenticate(t.Context(), "secret")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = authenticate(t.Context(), "other")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(empty, nil, 0o600))
+	_, err = staticTokenAuthenticator(empty)
+	require.ErrorContains(t, err, "is empty")
+	_, err = staticTokenAuthenticator(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
+
+func TestTokenReviewAuthenticator(t *testing.T) {
+	client := fake.NewClientset()
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "broken" {
+			return true, nil, errors.New("apiserver unavailable")
+		}
+		review.Status.Authenticated = review.Spec.Token == "valid" || review.Spec.Token == "other-audience"
+		if review.Spec.Token == "valid" {
+			review.Status.Audiences = review.Spec.Audiences
+		}
+		return true, review, nil
+	})
+	authenticate := tokenReviewAuthenticator(client)
+
+	for token, expected := range map[string]bool{"valid": true, "invalid": false, "other-audience": false} {
+		ok, err := authenticate(t.Context(), token)
+		require.NoError(t, err)
+		assert.Equal(t, expected, ok, token)
+	}
+	ok, err := authenticate(t.Context(), "broken")
+	require.ErrorContains(t, err, "apiserver unavailable")
+	assert.False(t, ok)
+}
@@ -21,7 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
-	"sort"
+	"slices"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -43,10 +43,115 @@ func (f MatchAllDomainFilters) Match(domain string) bool {
 	return true
 }
 
+// MatchExplain explains the outcome of Match: the explanation of the first filter
+// rejecting domain, or of the last filter when all of them match.
+func (f MatchAllDomainFilters) MatchExplain(domain string) DomainFilterMatch {
+	result := DomainFilterMatch{Domain: domain, Matched: true, Reason: "no domain filter configured"}
+	for _, filter := range f {
+		if filter == nil {
+			continue
+		}
+		result = ExplainDomainFilterMatch(filter, domain)
+		if !result.Matched {
+			return result
+		}
+	}
+	return result
+}
+
+// MarshalJSON serializes the filters a domain must all match under "all", see
+// UnmarshalDomainFilter. Nil filters are left out, and filters which can't be
+// serialized are reported rather than silently matching every domain.
+func (f MatchAllDomainFilters) MarshalJSON() ([]byte, error) {
+	all := make([]json.RawMessage, 0, len(f))
+	for _, filter := range f {
+		if filter == nil {
+			continue
+		}
+		if _, ok := filter.(json.Marshaler); !ok {
+			return nil, fmt.Errorf("cannot serialize domain filter of type %T", filter)
+		}
+		b, err := json.Marshal(filter)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, b)
+	}
+	return json.Marshal(matchAllDomainFiltersSerde{All: all})
+}
+
+// matchAllDomainFiltersSerde is a helper type for serializing and deserializing MatchAllDomainFilters.
+type matchAllDomainFiltersSerde struct {
+	All []json.RawMessage `json:"all"`
+}
+
+// UnmarshalDomainFilter deserializes any domain filter serialized by
+// DomainFilter or MatchAllDomainFilters, the latter possibly nested.
+func UnmarshalDomainFilter(b []byte) (DomainFilterInterface, error) {
+	var probe struct {
+		All *[]json.RawMessage `json:"all"`
+	}
+	if err := json.Unmarshal(b, &probe); err != nil {
+		return nil, err
+	}
+	if probe.All == nil {
+		df := &DomainFilter{}
+		if err := df.UnmarshalJSON(b); err != nil {
+			return nil, err
+		}
+		return df, nil
+	}
+	filters := make(MatchAllDomainFilters, 0, len(*probe.All))
+	for i, raw := range *probe.All {
+		filter, err := UnmarshalDomainFilter(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid domain filter %d: %w", i, err)
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
 type DomainFilterInterface interface {
 	Match(domain string) bool
 }
 
+// DomainFilterExplainer is implemented by domain filters able to explain which rule decided a match.
+type DomainFilterExplainer interface {
+	MatchExplain(domain string) DomainFilterMatch
+}
+
+// Rules of a DomainFilter deciding whether a domain matches.
+const (
+	DomainFilterRuleInclude      = "include"
+	DomainFilterRuleExclude      = "exclude"
+	DomainFilterRuleRegexInclude = "regexInclude"
+	DomainFilterRuleRegexExclude = "regexExclude"
+)
+
+// DomainFilterMatch explains the outcome of matching a domain against a domain filter.
+type DomainFilterMatch struct {
+	Domain  string `json:"domain"`
+	Matched bool   `json:"matched"`
+	// Rule is the rule that decided the outcome, empty when no rule is configured.
+	Rule string `json:"rule,omitempty"`
+	// Value is the domain or regular expression of the rule that decided the outcome.
+	Value  string `json:"value,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// ExplainDomainFilterMatch explains whether filter matches domain. Filters that do not
+// implement DomainFilterExplainer only report the outcome.
+func ExplainDomainFilterMatch(filter DomainFilterInterface, domain string) DomainFilterMatch {
+	if explainer, ok := filter.(DomainFilterExplainer); ok {
+		return explainer.MatchExplain(domain)
+	}
+	if filter.Match(domain) {
+		return DomainFilterMatch{Domain: domain, Matched: true, Reason: fmt.Sprintf("matched by %T", filter)}
+	}
+	return DomainFilterMatch{Domain: domain, Reason: fmt.Sprintf("rejected by %T", filter)}
+}
+
 // DomainFilter holds a lists of valid domain names
 type DomainFilter struct {
 	// Filters define what domains to match
@@ -128,6 +233,62 @@ func (df *DomainFilter) Match(domain string) bool {
 	return matchFilter(df.Filters, domain, true) && !matchFilter(df.exclude, domain, false)
 }
 
+// MatchExplain explains the outcome of Match: whether domain matches and which
+// include, exclude or regular expression rule decided it.
+func (df *DomainFilter) MatchExplain(domain string) DomainFilterMatch {
+	result := DomainFilterMatch{Domain: domain}
+	if df == nil {
+		result.Matched, result.Reason = true, "no domain filter configured"
+		return result
+	}
+	if df.regex != nil && df.regex.String() != "" || df.regexExclusion != nil && df.regexExclusion.String() != "" {
+		return explainRegex(df.regex, df.regexExclusion, result)
+	}
+
+	include, included := matchingFilter(df.Filters, domain)
+	switch exclude, excluded := matchingFilter(df.exclude, domain); {
+	case len(df.Filters) > 0 && !included:
+		result.Rule = DomainFilterRuleInclude
+		result.Reason = fmt.Sprintf("does not match any domain filter of %q", df.Filters)
+	case excluded:
+		result.Rule, result.Value = DomainFilterRuleExclude, exclude
+		result.Reason = fmt.Sprintf("excluded by domain exclusion %q", exclude)
+	case len(df.Filters) > 0:
+		result.Matched, result.Rule, result.Value = true, DomainFilterRuleInclude, include
+		result.Reason = fmt.Sprintf("matches domain filter %q", include)
+	default:
+		result.Matched = true
+		result.Reason = "no domain filter configured"
+		if len(df.exclude) > 0 {
+			result.Reason = "not excluded by any domain exclusion"
+		}
+	}
+	return result
+}
+
+// explainRegex explains the outcome of matchRegex.
+func explainRegex(regex *regexp.Regexp, negativeRegex *regexp.Regexp, result DomainFilterMatch) DomainFilterMatch {
+	strippedDomain := normalizeDomain(result.Domain)
+	hasExclusion := negativeRegex != nil && negativeRegex.String() != ""
+
+	switch {
+	case hasExclusion && negativeRegex.MatchString(strippedDomain):
+		result.Rule, result.Value = DomainFilterRuleRegexExclude, negativeRegex.String()
+		result.Reason = fmt.Sprintf("excluded by regular expression %q", negativeRegex.String())
+	case regex != nil && regex.String() != "":
+		result.Rule, result.Value = DomainFilterRuleRegexInclude, regex.String()
+		result.Matched = regex.MatchString(strippedDomain)
+		result.Reason = fmt.Sprintf("does not match regular expression %q", regex.String())
+		if result.Matched {
+			result.Reason = fmt.Sprintf("matches regular expression %q", regex.String())
+		}
+	default:
+		result.Matched, result.Rule, result.Value = true, DomainFilterRuleRegexExclude, negativeRegex.String()
+		result.Reason = fmt.Sprintf("not excluded by regular expression %q", negativeRegex.String())
+	}
+	return result
+}
+
 // matchFilter determines if any `filters` match `domain`.
 // If no `filters` are provided, behavior depends on `emptyval`
 // (empty `df.filters` matches everything, while empty `df.exclude` excludes nothing)
@@ -135,6 +296,15 @@ func matchFilter(filters []string, domain string, emptyval bool) bool {
 	if len(filters) == 0 {
 		return emptyval
 	}
+	_, ok := matchingFilter(filters, domain)
+	return ok
+}
+
+// matchingFilter returns the first of `filters` matching `domain`.
+func matchingFilter(filters []string, domain string) (string, bool) {
+	if len(filters) == 0 {
+		return "", false
+	}
 
 	strippedDomain := normalizeDomain(domain)
 	for _, filter := range filters {
@@ -144,14 +314,14 @@ func matchFilter(filters []string, domain string, emptyval bool) bool {
 
 		switch {
 		case strings.HasPrefix(filter, ".") && strings.HasSuffix(strippedDomain, filter):
-			return true
+			return filter, true
 		case strings.Count(strippedDomain, ".") == strings.Count(filter, ".") && strippedDomain == filter:
-			return true
+			return filter, true
 		case strings.HasSuffix(strippedDomain, "."+filter):
-			return true
+			return filter, true
 		}
 	}
-	return false
+	return "", false
 }
 
 // matchRegex determines if a domain matches the configured regular expressions in DomainFilter.
@@ -214,11 +384,10 @@ func (df *DomainFilter) MarshalJSON() ([]byte, error) {
 			RegexExclude: exclude,
 		})
 	}
-	sort.Strings(df.Filters)
-	sort.Strings(df.exclude)
+	// sorted copies, serializing must not reorder the filters being matched
 	return json.Marshal(domainFilterSerde{
-		Include: df.Filters,
-		Exclude: df.exclude,
+		Include: slices.Sorted(slices.Values(df.Filters)),
+		Exclude: slices.Sorted(slices.Values(df.exclude)),
 	})
 }
 
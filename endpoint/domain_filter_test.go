@@ -563,6 +563,98 @@ func TestMatchAllDomainFilters(t *testing.T) {
 	})
 }
 
+func TestDomainFilterMatchExplainAgreesWithMatch(t *testing.T) {
+	for i, tt := range domainFilterTests {
+		df := NewDomainFilterWithExclusions(tt.domainFilter, tt.exclusions)
+		for _, domain := range tt.domains {
+			assert.Equal(t, df.Match(domain), df.MatchExplain(domain).Matched, "test %d domain %q", i, domain)
+		}
+	}
+	for i, tt := range regexDomainFilterTests {
+		df := NewRegexDomainFilter(tt.regex, tt.regexExclusion)
+		for _, domain := range tt.domains {
+			assert.Equal(t, df.Match(domain), df.MatchExplain(domain).Matched, "regex test %d domain %q", i, domain)
+		}
+	}
+}
+
+func TestDomainFilterMatchExplain(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		filter   *DomainFilter
+		domain   string
+		expected DomainFilterMatch
+	}{
+		{
+			name:     "nil filter",
+			domain:   "a.example.com",
+			expected: DomainFilterMatch{Domain: "a.example.com", Matched: true, Reason: "no domain filter configured"},
+		},
+		{
+			name:     "include",
+			filter:   NewDomainFilterWithExclusions([]string{"example.org", "example.com"}, []string{"internal.example.com"}),
+			domain:   "a.example.com",
+			expected: DomainFilterMatch{Domain: "a.example.com", Matched: true, Rule: DomainFilterRuleInclude, Value: "example.com", Reason: `matches domain filter "example.com"`},
+		},
+		{
+			name:     "not included",
+			filter:   NewDomainFilter([]string{"example.org"}),
+			domain:   "a.example.com",
+			expected: DomainFilterMatch{Domain: "a.example.com", Rule: DomainFilterRuleInclude, Reason: `does not match any domain filter of ["example.org"]`},
+		},
+		{
+			name:     "excluded",
+			filter:   NewDomainFilterWithExclusions([]string{"example.com"}, []string{"internal.example.com"}),
+			domain:   "db.internal.example.com",
+			expected: DomainFilterMatch{Domain: "db.internal.example.com", Rule: DomainFilterRuleExclude, Value: "internal.example.com", Reason: `excluded by domain exclusion "internal.example.com"`},
+		},
+		{
+			name:     "not excluded",
+			filter:   NewDomainFilterWithExclusions(nil, []string{"internal.example.com"}),
+			domain:   "a.example.com",
+			expected: DomainFilterMatch{Domain: "a.example.com", Matched: true, Reason: "not excluded by any domain exclusion"},
+		},
+		{
+			name:     "regex exclusion",
+			filter:   NewRegexDomainFilter(regexp.MustCompile(`example\.com$`), regexp.MustCompile(`^db\.`)),
+			domain:   "db.example.com",
+			expected: DomainFilterMatch{Domain: "db.example.com", Rule: DomainFilterRuleRegexExclude, Value: `^db\.`, Reason: `excluded by regular expression "^db\\."`},
+		},
+		{
+			name:     "regex include",
+			filter:   NewRegexDomainFilter(regexp.MustCompile(`example\.com$`), nil),
+			domain:   "example.org",
+			expected: DomainFilterMatch{Domain: "example.org", Rule: DomainFilterRuleRegexInclude, Value: `example\.com$`, Reason: `does not match regular expression "example\\.com$"`},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.MatchExplain(tt.domain))
+		})
+	}
+}
+
+func TestMatchAllDomainFiltersMatchExplain(t *testing.T) {
+	allow := NewDomainFilter([]string{"example.com"})
+	deny := NewDomainFilter([]string{"other.com"})
+
+	result := MatchAllDomainFilters{allow, nil, deny}.MatchExplain("a.example.com")
+	assert.False(t, result.Matched)
+	assert.Equal(t, `does not match any domain filter of ["other.com"]`, result.Reason)
+
+	result = MatchAllDomainFilters{allow, allow}.MatchExplain("a.example.com")
+	assert.True(t, result.Matched)
+	assert.Equal(t, "example.com", result.Value)
+
+	assert.True(t, MatchAllDomainFilters(nil).MatchExplain("a.example.com").Matched)
+
+	result = MatchAllDomainFilters{matchNothing{}}.MatchExplain("a.example.com")
+	assert.Equal(t, DomainFilterMatch{Domain: "a.example.com", Reason: "rejected by endpoint.matchNothing"}, result)
+}
+
+type matchNothing struct{}
+
+func (matchNothing) Match(string) bool { return false }
+
 func TestNewDomainFilterWithExclusionsHandlesEmptyInputs(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -873,6 +965,46 @@ func TestDomainFilterDeserializeError(t *testing.T) {
 	}
 }
 
+func TestMatchAllDomainFiltersSerialization(t *testing.T) {
+	list := NewDomainFilterWithExclusions([]string{"b.com", "a.com"}, []string{"x.a.com"})
+	filters := MatchAllDomainFilters{
+		list,
+		nil,
+		MatchAllDomainFilters{NewRegexDomainFilter(regexp.MustCompile(`^api\.`), regexp.MustCompile(`\.test\.`))},
+	}
+
+	b, err := json.Marshal(filters)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"all":[{"include":["a.com","b.com"],"exclude":["x.a.com"]},{"all":[{"regexInclude":"^api\\.","regexExclude":"\\.test\\."}]}]}`, string(b))
+	assert.Equal(t, []string{"b.com", "a.com"}, list.Filters, "serializing must not reorder the filters")
+
+	deserialized, err := UnmarshalDomainFilter(b)
+	require.NoError(t, err)
+	for _, domain := range []string{"api.a.com", "api.x.a.com", "api.test.b.com", "www.b.com", "api.c.com"} {
+		assert.Equal(t, filters.Match(domain), deserialized.Match(domain), domain)
+	}
+
+	t.Run("a domain filter is deserialized as such", func(t *testing.T) {
+		df, err := UnmarshalDomainFilter([]byte(`{"include":["a.com"]}`))
+		require.NoError(t, err)
+		assert.Equal(t, NewDomainFilter([]string{"a.com"}), df)
+	})
+
+	t.Run("invalid nested filter", func(t *testing.T) {
+		_, err := UnmarshalDomainFilter([]byte(`{"all":[{"include":["a.com"]},{"regexInclude":"*"}]}`))
+		require.ErrorContains(t, err, "invalid domain filter 1: invalid regexInclude")
+	})
+
+	t.Run("unserializable filter", func(t *testing.T) {
+		_, err := json.Marshal(MatchAllDomainFilters{unserializableDomainFilter{}})
+		require.ErrorContains(t, err, "cannot serialize domain filter of type endpoint.unserializableDomainFilter")
+	})
+}
+
+type unserializableDomainFilter struct{}
+
+func (unserializableDomainFilter) Match(string) bool { return true }
+
 func assertSerializes[T any](t *testing.T, domainFilter *DomainFilter, expectedSerialization map[string]T) {
 	serialized, err := json.Marshal(domainFilter)
 	assert.NoError(t, err, "serializing")
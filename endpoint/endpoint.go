@@ -18,6 +18,8 @@ package endpoint
 
 import (
 	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/netip"
 	"slices"
@@ -29,6 +31,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"k8s.io/utils/set"
 
+	"sigs.k8s.io/external-dns/internal/logging"
 	"sigs.k8s.io/external-dns/internal/sets"
 	"sigs.k8s.io/external-dns/pkg/events"
 )
@@ -52,6 +55,10 @@ const (
 	RecordTypeMX = "MX"
 	// RecordTypeNAPTR is a RecordType enum value
 	RecordTypeNAPTR = "NAPTR"
+	// RecordTypeDS is a RecordType enum value
+	RecordTypeDS = "DS"
+	// RecordTypeCAA is a RecordType enum value
+	RecordTypeCAA = "CAA"
 
 	// ProviderSpecificAlias indicates whether a CNAME endpoint maps to a
 	// provider-native alias record (e.g. AWS ALIAS).
@@ -60,6 +67,40 @@ const (
 	// ProviderSpecificRecordType is the provider-specific property name used to
 	// request a particular DNS record type (e.g. "ptr") on an endpoint.
 	ProviderSpecificRecordType = "record-type"
+
+	// ProviderSpecificViewPrefix prefixes the properties carrying the targets of a
+	// split-horizon view, e.g. "view/internal". They are consumed by the view source
+	// wrapper and never reach a provider.
+	ProviderSpecificViewPrefix = "view/"
+
+	// ProviderSpecificTargetFrom references the ConfigMap or Secret key holding the
+	// targets of an endpoint. It is consumed by the target-from source wrapper and
+	// never reaches a provider.
+	ProviderSpecificTargetFrom = "target-from"
+
+	// ProviderSpecificCollisionPriority ranks the resources of different namespaces
+	// claiming the same DNS name under the annotation-priority collision policy. It
+	// is consumed by the namespace-collision source wrapper and never reaches a provider.
+	ProviderSpecificCollisionPriority = "collision-priority"
+
+	// ProviderSpecificUnmanagedLifecycle marks an endpoint created without registry
+	// ownership and never updated or deleted afterwards (e.g. a delegation).
+	ProviderSpecificUnmanagedLifecycle = "unmanaged-lifecycle"
+
+	// ProviderSpecificStagingTTL is the TTL, in seconds, a new record is created
+	// with before being updated to its desired TTL on the next synchronization,
+	// so that the first version of the record does not stay cached for long. It is
+	// consumed by the plan.
+	ProviderSpecificStagingTTL = "staging-ttl"
+
+	// ProviderSpecificDryRun marks an endpoint whose changes are planned and
+	// reported but never applied, e.g. while onboarding a namespace.
+	ProviderSpecificDryRun = "dry-run"
+
+	// ProviderSpecificHealthCheck is the probe, e.g. "tcp:443" or "http:8080/healthz",
+	// of the targets to drop while failing. It is consumed by the health-check
+	// source wrapper and never reaches a provider.
+	ProviderSpecificHealthCheck = "health-check"
 )
 
 var (
@@ -73,6 +114,8 @@ var (
 		RecordTypePTR,
 		RecordTypeMX,
 		RecordTypeNAPTR,
+		RecordTypeCAA,
+		RecordTypeDS,
 	}
 )
 
@@ -93,6 +136,32 @@ type MXTarget struct {
 	host     string
 }
 
+// SRVTarget represents a single SRV (Service) record target, including its priority, weight, port and host.
+type SRVTarget struct {
+	priority uint16
+	weight   uint16
+	port     uint16
+	host     string
+}
+
+// CAATarget represents a single CAA (Certification Authority Authorization) record target,
+// including its flags, tag and value.
+type CAATarget struct {
+	flags uint8
+	tag   string
+	value string
+}
+
+// StructuredTarget is a target with its fields apart: the priority of an MX target, the
+// priority, weight and port of an SRV target, and the host. The fields a record type does
+// not have are nil.
+type StructuredTarget struct {
+	Priority *uint16
+	Weight   *uint16
+	Port     *uint16
+	Host     string
+}
+
 // NewTargets is a convenience method to create a new Targets object from a vararg of strings.
 // Returns a new Targets slice with duplicates removed and elements sorted in order.
 func NewTargets(target ...string) Targets {
@@ -284,8 +353,9 @@ func NewEndpointWithTTL(dnsName, recordType string, ttl TTL, targets ...string)
 		// Only trim trailing dots for domain name record types, not for TXT or NAPTR records
 		// TXT records can contain arbitrary text including multiple dots
 		// SRV can contain dots in their target part (RFC2782)
+		// CAA values are free-form quoted strings (RFC8659)
 		switch recordType {
-		case RecordTypeTXT, RecordTypeNAPTR, RecordTypeSRV:
+		case RecordTypeTXT, RecordTypeNAPTR, RecordTypeSRV, RecordTypeCAA:
 			cleanTargets[idx] = target
 		default:
 			cleanTargets[idx] = strings.TrimSuffix(target, ".")
@@ -538,9 +608,9 @@ func FilterEndpointsByOwnerID(ownerID string, eps []*Endpoint) []*Endpoint {
 		endpointOwner, ok := ep.Labels[OwnerLabelKey]
 		switch {
 		case !ok:
-			log.Debugf(`Skipping endpoint %v because of missing owner label (required: "%s")`, ep, ownerID)
+			logging.Debugf(`Skipping endpoint %v because of missing owner label (required: "%s")`, ep, ownerID)
 		case endpointOwner != ownerID:
-			log.Debugf(`Skipping endpoint %v because owner id does not match (found: "%s", required: "%s")`, ep, endpointOwner, ownerID)
+			logging.Debugf(`Skipping endpoint %v because owner id does not match (found: "%s", required: "%s")`, ep, endpointOwner, ownerID)
 		default:
 			filtered = append(filtered, ep)
 		}
@@ -563,7 +633,7 @@ func RemoveDuplicates(endpoints []*Endpoint) []*Endpoint {
 			result = append(result, ep)
 			visited.Insert(key)
 		} else {
-			log.Debugf(`Skipping duplicated endpoint: %v`, ep)
+			logging.Debugf(`Skipping duplicated endpoint: %v`, ep)
 		}
 	}
 
@@ -576,6 +646,43 @@ func (e *Endpoint) RequestedRecordType() (string, bool) {
 	return e.GetProviderSpecificProperty(ProviderSpecificRecordType)
 }
 
+// IsUnmanagedLifecycle reports whether the endpoint is created without registry
+// ownership and left alone afterwards.
+func (e *Endpoint) IsUnmanagedLifecycle() bool {
+	unmanaged, _ := e.GetBoolProviderSpecificProperty(ProviderSpecificUnmanagedLifecycle)
+	return unmanaged
+}
+
+// IsDryRun reports whether the changes of the endpoint are planned without
+// being applied.
+func (e *Endpoint) IsDryRun() bool {
+	dryRun, _ := e.GetBoolProviderSpecificProperty(ProviderSpecificDryRun)
+	return dryRun
+}
+
+// StagingTTL returns the TTL a new record is first created with, and false
+// when it is not set or not a positive number of seconds.
+func (e *Endpoint) StagingTTL() (TTL, bool) {
+	value, ok := e.GetProviderSpecificProperty(ProviderSpecificStagingTTL)
+	if !ok {
+		return 0, false
+	}
+	ttl, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ttl <= 0 {
+		return 0, false
+	}
+	return TTL(ttl), true
+}
+
+// DesiredHash returns a short hash of the targets and TTL of the endpoint. Written next to
+// a record, it tells whether the record still matches the state desired by the sources.
+func (e *Endpoint) DesiredHash() string {
+	targets := slices.Clone(e.Targets)
+	slices.Sort(targets)
+	sum := sha256.Sum256([]byte(strings.Join(targets, ",") + "|" + strconv.FormatInt(int64(e.RecordTTL), 10)))
+	return hex.EncodeToString(sum[:4])
+}
+
 // TODO: rename to Validate
 // CheckEndpoint Check if endpoint is properly formatted according to RFC standards
 func (e *Endpoint) CheckEndpoint() bool {
@@ -595,6 +702,8 @@ func (e *Endpoint) CheckEndpoint() bool {
 		return e.Targets.ValidateMXRecord()
 	case RecordTypeSRV:
 		return e.Targets.ValidateSRVRecord()
+	case RecordTypeCAA:
+		return e.Targets.ValidateCAARecord()
 	case RecordTypePTR:
 		return e.ValidatePTRRecord()
 	}
@@ -653,6 +762,193 @@ func (m *MXTarget) GetHost() *string {
 	return &m.host
 }
 
+// String returns the string representation of the MX record target, e.g. "10 mail.example.com".
+func (m *MXTarget) String() string {
+	return strconv.FormatUint(uint64(m.priority), 10) + " " + m.host
+}
+
+// NewSRVRecord parses a string representation of an SRV record target (e.g., "10 5 5060 sip.example.com.")
+// and returns an SRVTarget struct. Returns an error if the input is invalid.
+func NewSRVRecord(target string) (*SRVTarget, error) {
+	parts := strings.Fields(strings.TrimSpace(target))
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid SRV record target: %s. SRV records must have a priority, weight, a port value and a target host, e.g. '10 5 5060 example.com.'", target)
+	}
+
+	values := make([]uint16, 3)
+	for i, part := range parts[:3] {
+		value, err := strconv.ParseUint(part, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer value in target: %s", target)
+		}
+		values[i] = uint16(value)
+	}
+
+	return &SRVTarget{
+		priority: values[0],
+		weight:   values[1],
+		port:     values[2],
+		host:     parts[3],
+	}, nil
+}
+
+// GetPriority returns the priority of the SRV record target.
+func (s *SRVTarget) GetPriority() *uint16 {
+	return &s.priority
+}
+
+// GetWeight returns the weight of the SRV record target.
+func (s *SRVTarget) GetWeight() *uint16 {
+	return &s.weight
+}
+
+// GetPort returns the port of the SRV record target.
+func (s *SRVTarget) GetPort() *uint16 {
+	return &s.port
+}
+
+// GetHost returns the host of the SRV record target.
+func (s *SRVTarget) GetHost() *string {
+	return &s.host
+}
+
+// String returns the string representation of the SRV record target, e.g. "10 5 5060 sip.example.com.".
+func (s *SRVTarget) String() string {
+	return fmt.Sprintf("%d %d %d %s", s.priority, s.weight, s.port, s.host)
+}
+
+// NewCAARecord parses a string representation of a CAA record target (e.g., `0 issue "letsencrypt.org"`)
+// and returns a CAATarget struct. The value may be unquoted when it has no spaces. Returns an error if
+// the input is invalid.
+func NewCAARecord(target string) (*CAATarget, error) {
+	parts := strings.Fields(target)
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("invalid CAA record target: %s. CAA records must have flags, a tag and a value, e.g. '0 issue \"letsencrypt.org\"'", target)
+	}
+
+	flags, err := strconv.ParseUint(parts[0], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid integer value in target: %s", target)
+	}
+
+	tag := parts[1]
+	if strings.IndexFunc(tag, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+	}) >= 0 {
+		return nil, fmt.Errorf("invalid CAA record target: %s. The tag must be alphanumeric, e.g. 'issue'", target)
+	}
+
+	// the value is the rest of the target, spaces included
+	value := strings.TrimSpace(target)
+	for _, part := range parts[:2] {
+		value = strings.TrimSpace(strings.TrimPrefix(value, part))
+	}
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		value = value[1 : len(value)-1]
+	} else if strings.ContainsAny(value, "\" \t") {
+		return nil, fmt.Errorf("invalid CAA record target: %s. A value with spaces or quotes must be quoted", target)
+	}
+
+	return &CAATarget{
+		flags: uint8(flags),
+		tag:   strings.ToLower(tag),
+		value: value,
+	}, nil
+}
+
+// NewCAATarget returns a CAATarget with the given flags, tag and unquoted value.
+func NewCAATarget(flags uint8, tag, value string) *CAATarget {
+	return &CAATarget{flags: flags, tag: strings.ToLower(tag), value: value}
+}
+
+// GetFlags returns the flags of the CAA record target, 128 marking the tag as critical.
+func (c *CAATarget) GetFlags() *uint8 {
+	return &c.flags
+}
+
+// GetTag returns the tag of the CAA record target, e.g. "issue", "issuewild" or "iodef".
+func (c *CAATarget) GetTag() *string {
+	return &c.tag
+}
+
+// GetValue returns the unquoted value of the CAA record target, e.g. "letsencrypt.org".
+func (c *CAATarget) GetValue() *string {
+	return &c.value
+}
+
+// String returns the string representation of the CAA record target, e.g. `0 issue "letsencrypt.org"`.
+func (c *CAATarget) String() string {
+	return strconv.FormatUint(uint64(c.flags), 10) + " " + c.tag + ` "` + c.value + `"`
+}
+
+// ParseTarget returns the fields of a target of the given record type. The targets of
+// the record types other than MX and SRV are returned whole as the host.
+func ParseTarget(recordType, target string) (StructuredTarget, error) {
+	switch recordType {
+	case RecordTypeMX:
+		mx, err := NewMXRecord(target)
+		if err != nil {
+			return StructuredTarget{}, err
+		}
+		return StructuredTarget{Priority: &mx.priority, Host: mx.host}, nil
+	case RecordTypeSRV:
+		srv, err := NewSRVRecord(target)
+		if err != nil {
+			return StructuredTarget{}, err
+		}
+		return StructuredTarget{Priority: &srv.priority, Weight: &srv.weight, Port: &srv.port, Host: srv.host}, nil
+	}
+	return StructuredTarget{Host: target}, nil
+}
+
+// String returns the string form of the target, its fields separated by a space, e.g.
+// "10 5 5060 sip.example.com.".
+func (t StructuredTarget) String() string {
+	var fields []string
+	for _, v := range []*uint16{t.Priority, t.Weight, t.Port} {
+		if v != nil {
+			fields = append(fields, strconv.FormatUint(uint64(*v), 10))
+		}
+	}
+	return strings.Join(append(fields, t.Host), " ")
+}
+
+// StructuredTargets returns the targets of the endpoint with their fields apart, as
+// parsed by ParseTarget.
+func (e *Endpoint) StructuredTargets() ([]StructuredTarget, error) {
+	result := make([]StructuredTarget, 0, len(e.Targets))
+	for _, target := range e.Targets {
+		st, err := ParseTarget(e.RecordType, target)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, st)
+	}
+	return result, nil
+}
+
+// NewStructuredTargets returns the string form of the targets.
+func NewStructuredTargets(targets ...StructuredTarget) Targets {
+	result := make(Targets, 0, len(targets))
+	for _, t := range targets {
+		result = append(result, t.String())
+	}
+	return result
+}
+
+// CAATargets parses all targets as CAA record targets.
+func (t Targets) CAATargets() ([]*CAATarget, error) {
+	result := make([]*CAATarget, 0, len(t))
+	for _, target := range t {
+		caa, err := NewCAARecord(target)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, caa)
+	}
+	return result, nil
+}
+
 // ValidateIPRecord reports whether all targets are valid IP addresses of the given record type (A or AAAA).
 func (t Targets) ValidateIPRecord(recordType string) bool {
 	for _, target := range t {
@@ -689,24 +985,27 @@ func (t Targets) ValidateMXRecord() bool {
 // ValidateSRVRecord reports whether all targets are valid SRV record values (priority weight port host).
 func (t Targets) ValidateSRVRecord() bool {
 	for _, target := range t {
-		// SRV records must have a priority, weight, a port value and a target e.g. "10 5 5060 example.com."
-		// as per https://www.rfc-editor.org/rfc/rfc2782.txt the target host has to end with a dot.
-		targetParts := strings.Fields(strings.TrimSpace(target))
-		if len(targetParts) != 4 {
-			log.Debugf("Invalid SRV record target: %s. SRV records must have a priority, weight, a port value and a target host, e.g. '10 5 5060 example.com.'", target)
+		srv, err := NewSRVRecord(target)
+		if err != nil {
+			log.Debugf("Invalid SRV record target: %s. %v", target, err)
 			return false
 		}
-		if !strings.HasSuffix(targetParts[3], ".") {
+		// as per https://www.rfc-editor.org/rfc/rfc2782.txt the target host has to end with a dot.
+		if !strings.HasSuffix(srv.host, ".") {
 			log.Debugf("Invalid SRV record target: %s. Target host does not end with a dot.'", target)
 			return false
 		}
+	}
+	return true
+}
 
-		for _, part := range targetParts[:3] {
-			_, err := strconv.ParseUint(part, 10, 16)
-			if err != nil {
-				log.Debugf("Invalid SRV record target: %s. Invalid integer value in target.", target)
-				return false
-			}
+// ValidateCAARecord reports whether all targets are valid CAA record values (flags tag "value").
+func (t Targets) ValidateCAARecord() bool {
+	for _, target := range t {
+		_, err := NewCAARecord(target)
+		if err != nil {
+			log.Debugf("Invalid CAA record target: %s. %v", target, err)
+			return false
 		}
 	}
 	return true
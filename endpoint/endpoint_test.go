@@ -1144,6 +1144,186 @@ func TestMXTarget_Getters(t *testing.T) {
 	assert.Equal(t, "mail.example.com", *m.GetHost())
 }
 
+func TestMXTarget_String(t *testing.T) {
+	m, err := NewMXRecord(" 20   backup.example.com. ")
+	require.NoError(t, err)
+	assert.Equal(t, "20 backup.example.com.", m.String())
+}
+
+func TestNewSRVRecord(t *testing.T) {
+	tests := []struct {
+		description string
+		target      string
+		expected    *SRVTarget
+		expectError bool
+	}{
+		{
+			description: "Valid SRV record",
+			target:      "10 5 5060 sip.example.com.",
+			expected:    &SRVTarget{priority: 10, weight: 5, port: 5060, host: "sip.example.com."},
+		},
+		{
+			description: "Valid SRV record with extra spaces",
+			target:      " 10  5 5060   sip.example.com ",
+			expected:    &SRVTarget{priority: 10, weight: 5, port: 5060, host: "sip.example.com"},
+		},
+		{
+			description: "Invalid SRV record with missing port",
+			target:      "10 5 sip.example.com.",
+			expectError: true,
+		},
+		{
+			description: "Invalid SRV record with port out of range",
+			target:      "10 5 65536 sip.example.com.",
+			expectError: true,
+		},
+		{
+			description: "Invalid SRV record with non-integer weight",
+			target:      "10 abc 5060 sip.example.com.",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			actual, err := NewSRVRecord(tt.target)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSRVTarget_Getters(t *testing.T) {
+	s, err := NewSRVRecord("10 5 5060 sip.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, uint16(10), *s.GetPriority())
+	assert.Equal(t, uint16(5), *s.GetWeight())
+	assert.Equal(t, uint16(5060), *s.GetPort())
+	assert.Equal(t, "sip.example.com.", *s.GetHost())
+	assert.Equal(t, "10 5 5060 sip.example.com.", s.String())
+}
+
+func TestParseTarget(t *testing.T) {
+	priority, weight, port := uint16(10), uint16(5), uint16(5060)
+	tests := []struct {
+		recordType  string
+		target      string
+		expected    StructuredTarget
+		expectError bool
+	}{
+		{recordType: RecordTypeMX, target: "10  mail.example.com", expected: StructuredTarget{Priority: &priority, Host: "mail.example.com"}},
+		{recordType: RecordTypeSRV, target: "10 5 5060 sip.example.com.", expected: StructuredTarget{Priority: &priority, Weight: &weight, Port: &port, Host: "sip.example.com."}},
+		{recordType: RecordTypeCNAME, target: "example.com", expected: StructuredTarget{Host: "example.com"}},
+		{recordType: RecordTypeMX, target: "mail.example.com", expectError: true},
+		{recordType: RecordTypeSRV, target: "10 5060 sip.example.com.", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.recordType+" "+tt.target, func(t *testing.T) {
+			actual, err := ParseTarget(tt.recordType, tt.target)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestStructuredTargets(t *testing.T) {
+	ep := NewEndpoint("example.com", RecordTypeMX, "10  mail.example.com", "20 backup.example.com")
+	structured, err := ep.StructuredTargets()
+	require.NoError(t, err)
+	require.Len(t, structured, 2)
+	assert.Equal(t, uint16(20), *structured[1].Priority)
+	assert.Nil(t, structured[1].Weight)
+
+	structured[0].Host = "mail.example.com."
+	assert.Equal(t, Targets{"10 mail.example.com.", "20 backup.example.com"}, NewStructuredTargets(structured...))
+
+	_, err = NewEndpoint("_sip._tcp.example.com", RecordTypeSRV, "10 5060 sip.example.com.").StructuredTargets()
+	assert.Error(t, err)
+}
+
+func TestNewCAARecord(t *testing.T) {
+	tests := []struct {
+		description string
+		target      string
+		expected    *CAATarget
+		expectError bool
+	}{
+		{
+			description: "Valid CAA record",
+			target:      `0 issue "letsencrypt.org"`,
+			expected:    &CAATarget{flags: 0, tag: "issue", value: "letsencrypt.org"},
+		},
+		{
+			description: "Valid CAA record with an unquoted value and extra spaces",
+			target:      ` 128  ISSUEWILD   ;`,
+			expected:    &CAATarget{flags: 128, tag: "issuewild", value: ";"},
+		},
+		{
+			description: "Valid CAA record with a quoted value containing spaces",
+			target:      `0 issue "ca.example.net; account=230123"`,
+			expected:    &CAATarget{flags: 0, tag: "issue", value: "ca.example.net; account=230123"},
+		},
+		{
+			description: "Invalid CAA record with missing value",
+			target:      "0 issue",
+			expectError: true,
+		},
+		{
+			description: "Invalid CAA record with flags out of range",
+			target:      `256 issue "letsencrypt.org"`,
+			expectError: true,
+		},
+		{
+			description: "Invalid CAA record with a non-alphanumeric tag",
+			target:      `0 is-sue "letsencrypt.org"`,
+			expectError: true,
+		},
+		{
+			description: "Invalid CAA record with an unquoted value containing spaces",
+			target:      `0 issue ca.example.net; account=230123`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			actual, err := NewCAARecord(tt.target)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCAATarget_Getters(t *testing.T) {
+	c := NewCAATarget(128, "Issue", "letsencrypt.org")
+	assert.Equal(t, uint8(128), *c.GetFlags())
+	assert.Equal(t, "issue", *c.GetTag())
+	assert.Equal(t, "letsencrypt.org", *c.GetValue())
+	assert.Equal(t, `128 issue "letsencrypt.org"`, c.String())
+}
+
+func TestTargets_CAATargets(t *testing.T) {
+	caas, err := Targets{`0 issue "letsencrypt.org"`, `0 iodef "mailto:security@example.com"`}.CAATargets()
+	require.NoError(t, err)
+	assert.Equal(t, []*CAATarget{NewCAATarget(0, "issue", "letsencrypt.org"), NewCAATarget(0, "iodef", "mailto:security@example.com")}, caas)
+
+	_, err = Targets{`0 issue`}.CAATargets()
+	assert.Error(t, err)
+}
+
 func TestCheckEndpoint(t *testing.T) {
 	tests := []struct {
 		description string
@@ -1186,6 +1366,24 @@ func TestCheckEndpoint(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			description: "Valid CAA record target",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeCAA,
+				Targets:    Targets{`0 issue "letsencrypt.org"`},
+			},
+			expected: true,
+		},
+		{
+			description: "Invalid CAA record target",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeCAA,
+				Targets:    Targets{"letsencrypt.org"},
+			},
+			expected: false,
+		},
 		{
 			description: "Non-MX/SRV record type",
 			endpoint: Endpoint{
@@ -2080,6 +2278,36 @@ func TestRequestedRecordType(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestIsDryRun(t *testing.T) {
+	assert.True(t, NewEndpoint("example.com", RecordTypeA, "1.2.3.4").
+		WithProviderSpecific(ProviderSpecificDryRun, "true").IsDryRun())
+	assert.False(t, NewEndpoint("example.com", RecordTypeA, "1.2.3.4").
+		WithProviderSpecific(ProviderSpecificDryRun, "false").IsDryRun())
+	assert.False(t, NewEndpoint("example.com", RecordTypeA, "1.2.3.4").IsDryRun())
+}
+
+func TestStagingTTL(t *testing.T) {
+	ttl, ok := NewEndpoint("example.com", RecordTypeA, "1.2.3.4").
+		WithProviderSpecific(ProviderSpecificStagingTTL, "30").StagingTTL()
+	assert.True(t, ok)
+	assert.Equal(t, TTL(30), ttl)
+
+	_, ok = NewEndpoint("example.com", RecordTypeA, "1.2.3.4").StagingTTL()
+	assert.False(t, ok)
+	_, ok = NewEndpoint("example.com", RecordTypeA, "1.2.3.4").
+		WithProviderSpecific(ProviderSpecificStagingTTL, "0").StagingTTL()
+	assert.False(t, ok)
+}
+
+func TestDesiredHash(t *testing.T) {
+	ep := NewEndpointWithTTL("example.org", RecordTypeA, 300, "1.2.3.4", "5.6.7.8")
+	hash := ep.DesiredHash()
+	assert.Len(t, hash, 8)
+	assert.Equal(t, hash, NewEndpointWithTTL("example.org", RecordTypeA, 300, "5.6.7.8", "1.2.3.4").DesiredHash(), "target order does not matter")
+	assert.NotEqual(t, hash, NewEndpointWithTTL("example.org", RecordTypeA, 60, "1.2.3.4", "5.6.7.8").DesiredHash())
+	assert.NotEqual(t, hash, NewEndpointWithTTL("example.org", RecordTypeA, 300, "1.2.3.4").DesiredHash())
+}
+
 func TestNewPTREndpoint(t *testing.T) {
 	tests := []struct {
 		name      string
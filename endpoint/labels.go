@@ -21,6 +21,7 @@ import (
 
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -34,6 +35,18 @@ const (
 	OwnerLabelKey = "owner"
 	// ResourceLabelKey is the name of the label that identifies k8s resource which wants to acquire the DNS name
 	ResourceLabelKey = "resource"
+	// ResourceLabelSeparator separates the resources of a multi-valued ResourceLabelKey label,
+	// which is set when endpoints produced by several resources are merged into one
+	ResourceLabelSeparator = ";"
+	// ResourceUIDLabelKey is the name of the label that lists the UIDs of the source objects of the record,
+	// separated by ResourceLabelSeparator. It is only set when the TXT registry stores resource references.
+	ResourceUIDLabelKey = "resource-uid"
+	// ResourceNamespaceLabelKey is the name of the label that lists the namespaces of the source objects of the record,
+	// separated by ResourceLabelSeparator. It is only set when the TXT registry stores resource references.
+	ResourceNamespaceLabelKey = "resource-namespace"
+	// DesiredHashLabelKey is the name of the label that stores the DesiredHash of the record at its last write.
+	// It is only set when the TXT registry records desired hashes.
+	DesiredHashLabelKey = "desired-hash"
 	// OwnedRecordLabelKey is the name of the label that identifies the record that is owned by the labeled TXT registry record
 	OwnedRecordLabelKey = "ownedRecord"
 
@@ -54,6 +67,67 @@ func NewLabels() Labels {
 	return map[string]string{}
 }
 
+// Resources returns the resources recorded in the ResourceLabelKey label.
+func (l Labels) Resources() []string {
+	return l.multiValued(ResourceLabelKey)
+}
+
+// AddResource records resource in the ResourceLabelKey label. Resources are
+// kept sorted and unique so the label value is stable across syncs.
+func (l Labels) AddResource(resource string) {
+	if resource == "" {
+		return
+	}
+	resources := append(l.Resources(), resource)
+	sort.Strings(resources)
+	l[ResourceLabelKey] = strings.Join(slices.Compact(resources), ResourceLabelSeparator)
+}
+
+// SetResourceRefs records the UIDs and namespaces of refs in the ResourceUIDLabelKey and
+// ResourceNamespaceLabelKey labels. Values are kept sorted and unique so the labels are
+// stable across syncs, references without a UID or namespace are left out.
+func (l Labels) SetResourceRefs(refs []*ObjectRef) {
+	var uids, namespaces []string
+	for _, ref := range refs {
+		if uid := string(ref.UID()); uid != "" {
+			uids = append(uids, uid)
+		}
+		if ref.Namespace() != "" {
+			namespaces = append(namespaces, ref.Namespace())
+		}
+	}
+	l.setMultiValued(ResourceUIDLabelKey, uids)
+	l.setMultiValued(ResourceNamespaceLabelKey, namespaces)
+}
+
+// ResourceUIDs returns the source object UIDs recorded in the ResourceUIDLabelKey label,
+// or nil for records stored without resource references.
+func (l Labels) ResourceUIDs() []string {
+	return l.multiValued(ResourceUIDLabelKey)
+}
+
+// ResourceNamespaces returns the source object namespaces recorded in the ResourceNamespaceLabelKey
+// label, or nil for records stored without resource references.
+func (l Labels) ResourceNamespaces() []string {
+	return l.multiValued(ResourceNamespaceLabelKey)
+}
+
+func (l Labels) setMultiValued(key string, values []string) {
+	if len(values) == 0 {
+		delete(l, key)
+		return
+	}
+	sort.Strings(values)
+	l[key] = strings.Join(slices.Compact(values), ResourceLabelSeparator)
+}
+
+func (l Labels) multiValued(key string) []string {
+	if l[key] == "" {
+		return nil
+	}
+	return strings.Split(l[key], ResourceLabelSeparator)
+}
+
 // NewLabelsFromString constructs endpoints labels from a provided format string
 // if heritage set to another value is found then error is returned
 // no heritage automatically assumes is not owned by external-dns and returns invalidHeritage error
@@ -23,7 +23,11 @@ import (
 	"testing"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+
+	"sigs.k8s.io/external-dns/pkg/events"
 )
 
 type LabelsSuite struct {
@@ -225,3 +229,44 @@ func TestParseLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelsAddResource(t *testing.T) {
+	l := NewLabels()
+	assert.Empty(t, l.Resources())
+
+	l.AddResource("ingress/default/b")
+	l.AddResource("")
+	l.AddResource("crd/default/a")
+	l.AddResource("ingress/default/b")
+
+	assert.Equal(t, "crd/default/a;ingress/default/b", l[ResourceLabelKey])
+	assert.Equal(t, []string{"crd/default/a", "ingress/default/b"}, l.Resources())
+
+	parsed, err := NewLabelsFromStringPlain(l.SerializePlain(false))
+	require.NoError(t, err)
+	assert.Equal(t, l.Resources(), parsed.Resources(), "multi-valued resource label must survive serialization")
+}
+
+func TestLabelsSetResourceRefs(t *testing.T) {
+	l := NewLabels()
+	assert.Nil(t, l.ResourceUIDs())
+	assert.Nil(t, l.ResourceNamespaces())
+
+	l.SetResourceRefs([]*ObjectRef{
+		events.NewObjectReferenceFromParts("Service", "v1", "web", "b", "uid-b", "service"),
+		events.NewObjectReferenceFromParts("Ingress", "networking.k8s.io/v1", "default", "a", "uid-a", "ingress"),
+		events.NewObjectReferenceFromParts("Service", "v1", "web", "c", "", "service"),
+		events.NewObjectReferenceFromParts("Node", "v1", "", "node-1", "uid-n", "node"),
+	})
+	assert.Equal(t, "uid-a;uid-b;uid-n", l[ResourceUIDLabelKey])
+	assert.Equal(t, "default;web", l[ResourceNamespaceLabelKey])
+
+	parsed, err := NewLabelsFromStringPlain(l.SerializePlain(false))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"uid-a", "uid-b", "uid-n"}, parsed.ResourceUIDs())
+	assert.Equal(t, []string{"default", "web"}, parsed.ResourceNamespaces())
+
+	l.SetResourceRefs(nil)
+	assert.NotContains(t, l, ResourceUIDLabelKey)
+	assert.NotContains(t, l, ResourceNamespaceLabelKey)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownProperty is returned for a property in a registered namespace that no spec describes.
+	ErrUnknownProperty = errors.New("unknown provider-specific property")
+	// ErrInvalidPropertyValue is returned when a property value does not match its spec.
+	ErrInvalidPropertyValue = errors.New("invalid provider-specific property value")
+)
+
+// PropertyType is the value type of a provider-specific property.
+type PropertyType string
+
+const (
+	PropertyTypeString PropertyType = "string"
+	PropertyTypeBool   PropertyType = "bool"
+	PropertyTypeInt    PropertyType = "int"
+)
+
+// PropertySpec describes a provider-specific property a provider understands.
+type PropertySpec struct {
+	// Name is the full property name, e.g. "aws/weight".
+	Name string
+	// Prefix marks Name as a prefix matching a family of properties, e.g. "azure/metadata-".
+	Prefix bool
+	Type   PropertyType
+	// Allowed restricts the value to the listed ones when not empty.
+	Allowed []string
+}
+
+// namespace returns the part of the property name before the first "/".
+func namespace(name string) string {
+	ns, _, ok := strings.Cut(name, "/")
+	if !ok {
+		return ""
+	}
+	return ns
+}
+
+// validate checks value against the spec type and allowed values.
+func (s PropertySpec) validate(value string) error {
+	switch s.Type {
+	case PropertyTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%w: %s=%q is not a bool", ErrInvalidPropertyValue, s.Name, value)
+		}
+	case PropertyTypeInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("%w: %s=%q is not an integer", ErrInvalidPropertyValue, s.Name, value)
+		}
+	case PropertyTypeString:
+	}
+	if len(s.Allowed) > 0 && !slices.Contains(s.Allowed, value) {
+		return fmt.Errorf("%w: %s=%q is not one of [%s]", ErrInvalidPropertyValue, s.Name, value, strings.Join(s.Allowed, ", "))
+	}
+	return nil
+}
+
+// PropertyValidator validates provider-specific properties against the specs
+// registered by providers. Only properties in a namespace (the part of the name
+// before "/") that has at least one registered spec are validated; everything
+// else, e.g. "webhook/*" properties of out-of-tree providers, passes through.
+type PropertyValidator struct {
+	specs      map[string]PropertySpec
+	prefixes   []PropertySpec
+	namespaces map[string]struct{}
+}
+
+// NewPropertyValidator creates a PropertyValidator for the given specs.
+func NewPropertyValidator(specs ...PropertySpec) *PropertyValidator {
+	v := &PropertyValidator{
+		specs:      make(map[string]PropertySpec, len(specs)),
+		namespaces: make(map[string]struct{}),
+	}
+	for _, spec := range specs {
+		if spec.Prefix {
+			v.prefixes = append(v.prefixes, spec)
+		} else {
+			v.specs[spec.Name] = spec
+		}
+		if ns := namespace(spec.Name); ns != "" {
+			v.namespaces[ns] = struct{}{}
+		}
+	}
+	return v
+}
+
+// IsEnabled returns true when at least one spec is registered.
+func (v *PropertyValidator) IsEnabled() bool {
+	return v != nil && len(v.namespaces) > 0
+}
+
+// Validate returns an error when prop is unknown in a registered namespace or
+// its value does not match the registered spec.
+func (v *PropertyValidator) Validate(prop ProviderSpecificProperty) error {
+	if !v.IsEnabled() {
+		return nil
+	}
+	if _, ok := v.namespaces[namespace(prop.Name)]; !ok {
+		return nil
+	}
+	if spec, ok := v.specs[prop.Name]; ok {
+		return spec.validate(prop.Value)
+	}
+	for _, spec := range v.prefixes {
+		if strings.HasPrefix(prop.Name, spec.Name) {
+			return spec.validate(prop.Value)
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownProperty, prop.Name)
+}
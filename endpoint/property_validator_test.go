@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropertyValidator(t *testing.T) {
+	v := NewPropertyValidator(
+		PropertySpec{Name: "aws/weight", Type: PropertyTypeInt},
+		PropertySpec{Name: "aws/evaluate-target-health", Type: PropertyTypeBool},
+		PropertySpec{Name: "aws/failover", Type: PropertyTypeString, Allowed: []string{"PRIMARY", "SECONDARY"}},
+		PropertySpec{Name: "azure/metadata-", Prefix: true, Type: PropertyTypeString},
+	)
+	require.True(t, v.IsEnabled())
+
+	tests := []struct {
+		name    string
+		prop    ProviderSpecificProperty
+		wantErr error
+	}{
+		{name: "valid int", prop: ProviderSpecificProperty{Name: "aws/weight", Value: "10"}},
+		{name: "invalid int", prop: ProviderSpecificProperty{Name: "aws/weight", Value: "ten"}, wantErr: ErrInvalidPropertyValue},
+		{name: "valid bool", prop: ProviderSpecificProperty{Name: "aws/evaluate-target-health", Value: "false"}},
+		{name: "invalid bool", prop: ProviderSpecificProperty{Name: "aws/evaluate-target-health", Value: "maybe"}, wantErr: ErrInvalidPropertyValue},
+		{name: "allowed value", prop: ProviderSpecificProperty{Name: "aws/failover", Value: "PRIMARY"}},
+		{name: "disallowed value", prop: ProviderSpecificProperty{Name: "aws/failover", Value: "TERTIARY"}, wantErr: ErrInvalidPropertyValue},
+		{name: "prefix match", prop: ProviderSpecificProperty{Name: "azure/metadata-team", Value: "dns"}},
+		{name: "unknown in registered namespace", prop: ProviderSpecificProperty{Name: "aws/wieght", Value: "10"}, wantErr: ErrUnknownProperty},
+		{name: "unregistered namespace", prop: ProviderSpecificProperty{Name: "webhook/anything", Value: "x"}},
+		{name: "no namespace", prop: ProviderSpecificProperty{Name: "alias", Value: "true"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.prop)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPropertyValidatorDisabled(t *testing.T) {
+	var nilValidator *PropertyValidator
+	assert.False(t, nilValidator.IsEnabled())
+	assert.NoError(t, nilValidator.Validate(ProviderSpecificProperty{Name: "aws/weight", Value: "x"}))
+
+	empty := NewPropertyValidator()
+	assert.False(t, empty.IsEnabled())
+	assert.NoError(t, empty.Validate(ProviderSpecificProperty{Name: "aws/weight", Value: "x"}))
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging correlates the log lines of a synchronization and samples
+// its repetitive debug messages, so that a single synchronization can be
+// traced in the logs of a large cluster.
+package logging
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SyncField is the log field carrying the ID of the running synchronization.
+const SyncField = "sync"
+
+var (
+	// syncing is set while a synchronization runs.
+	syncing  atomic.Bool
+	sampler  = &debugSampler{counts: map[string]int{}}
+	hookOnce sync.Once
+)
+
+// Configure adds the ID of the synchronization to the lines logged with a context
+// returned by StartSync, and limits the debug messages logged with Debugf to sampleLimit of each kind
+// per synchronization, or logs all of them when sampleLimit is 0.
+func Configure(sampleLimit int) {
+	hookOnce.Do(func() { log.AddHook(syncHook{}) })
+	sampler.setLimit(sampleLimit)
+}
+
+// syncKey is the context key of the synchronization ID.
+type syncKey struct{}
+
+// StartSync marks the start of the synchronization id, and returns a context
+// carrying id to the lines logged with log.WithContext.
+func StartSync(ctx context.Context, id uint64) context.Context {
+	syncing.Store(true)
+	return context.WithValue(ctx, syncKey{}, id)
+}
+
+// FinishSync summarizes the debug messages left out during the running
+// synchronization, and marks its end.
+func FinishSync(ctx context.Context) {
+	sampler.flush(ctx)
+	syncing.Store(false)
+}
+
+// Debugf logs a repetitive debug message, e.g. one per endpoint, like log.Debugf.
+// During a synchronization, only the first messages of each format are logged,
+// and the others are counted and summarized when it finishes.
+func Debugf(format string, args ...any) {
+	if !log.IsLevelEnabled(log.DebugLevel) || !sampler.allow(format) {
+		return
+	}
+	log.Debugf(format, args...)
+}
+
+// syncHook adds the ID of the synchronization to the log entries whose context carries
+// one, leaving alone the lines logged meanwhile by informers or HTTP handlers.
+type syncHook struct{}
+
+func (syncHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (syncHook) Fire(entry *log.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	if id, ok := entry.Context.Value(syncKey{}).(uint64); ok {
+		entry.Data[SyncField] = id
+	}
+	return nil
+}
+
+// debugSampler counts the debug messages of each format logged during a synchronization.
+type debugSampler struct {
+	mu     sync.Mutex
+	limit  int
+	counts map[string]int
+}
+
+func (s *debugSampler) setLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit = limit
+}
+
+// allow reports whether a message of format is logged.
+func (s *debugSampler) allow(format string) bool {
+	if !syncing.Load() {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.limit <= 0 {
+		return true
+	}
+	s.counts[format]++
+	return s.counts[format] <= s.limit
+}
+
+// flush logs how many messages of each format were left out, and resets the counts.
+func (s *debugSampler) flush(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, format := range slices.Sorted(maps.Keys(s.counts)) {
+		if skipped := s.counts[format] - s.limit; skipped > 0 {
+			log.WithContext(ctx).Debugf("Left out %d more debug messages like %q", skipped, format)
+		}
+	}
+	clear(s.counts)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	logtest "sigs.k8s.io/external-dns/internal/testutils/log"
+)
+
+func TestSyncField(t *testing.T) {
+	Configure(0)
+	hook := logtest.LogsUnderTestWithLogLevel(log.DebugLevel, t)
+
+	log.Info("before")
+	ctx := StartSync(t.Context(), 7)
+	log.WithContext(ctx).WithField("zone", "example.org").Warn("during")
+	// e.g. an informer logging while the synchronization runs
+	log.Info("meanwhile")
+	FinishSync(ctx)
+	log.Info("after")
+
+	entries := hook.AllEntries()
+	require.Len(t, entries, 4)
+	assert.NotContains(t, entries[0].Data, SyncField)
+	assert.Equal(t, log.Fields{SyncField: uint64(7), "zone": "example.org"}, entries[1].Data)
+	assert.NotContains(t, entries[2].Data, SyncField)
+	assert.NotContains(t, entries[3].Data, SyncField)
+}
+
+func TestDebugfSampling(t *testing.T) {
+	Configure(2)
+	t.Cleanup(func() { Configure(0) })
+	hook := logtest.LogsUnderTestWithLogLevel(log.DebugLevel, t)
+
+	ctx := StartSync(t.Context(), 1)
+	for i := range 5 {
+		Debugf("Skipping endpoint %d", i)
+	}
+	Debugf("Removing duplicate endpoint %s", "a.example.org")
+	FinishSync(ctx)
+
+	var messages []string
+	for _, entry := range hook.AllEntries() {
+		messages = append(messages, entry.Message)
+	}
+	assert.Equal(t, []string{
+		"Skipping endpoint 0",
+		"Skipping endpoint 1",
+		"Removing duplicate endpoint a.example.org",
+		`Left out 3 more debug messages like "Skipping endpoint %d"`,
+	}, messages)
+
+	// the counts restart with every synchronization, and messages outside of one are not sampled
+	hook.Reset()
+	ctx = StartSync(t.Context(), 2)
+	Debugf("Skipping endpoint %d", 5)
+	FinishSync(ctx)
+	for i := range 3 {
+		Debugf("Skipping endpoint %d", i)
+	}
+	assert.Len(t, hook.AllEntries(), 4)
+}
+
+func TestDebugfWithoutLimit(t *testing.T) {
+	Configure(0)
+	hook := logtest.LogsUnderTestWithLogLevel(log.DebugLevel, t)
+
+	ctx := StartSync(t.Context(), 1)
+	for i := range 5 {
+		Debugf("Skipping endpoint %d", i)
+	}
+	FinishSync(ctx)
+	assert.Len(t, hook.AllEntries(), 5)
+
+	hook.Reset()
+	log.SetLevel(log.InfoLevel)
+	Debugf("Skipping endpoint %d", 0)
+	assert.Empty(t, hook.AllEntries())
+}
@@ -19,88 +19,51 @@ package testutils
 import (
 	"fmt"
 
-	openshift "github.com/openshift/client-go/route/clientset/versioned"
 	"github.com/stretchr/testify/mock"
-	istioclient "istio.io/client-go/pkg/clientset/versioned"
-	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
-	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 )
 
+// kubeClientName is the name source.KubeClientName of the Kubernetes client,
+// repeated here as the source package imports this one in its tests.
+const kubeClientName = "kubernetes"
+
 // StubClientGenerator is a ClientGenerator where all methods return errors.
 // Use it for sources that don't require any Kubernetes client (e.g. the "fake" source).
 type StubClientGenerator = stubClientGenerator
 
-// MockClientGenerator is a full testify mock of source.ClientGenerator.
-// Returned client values are stored in the *Value fields so tests can assert
-// whether a specific client was actually requested.
+// MockClientGenerator is a full testify mock of source.ClientGenerator. The
+// clients are mocked by name, e.g. On("Client", source.KubeClientName), and
+// the ones returned are stored in Clients so tests can assert whether a
+// specific client was actually requested.
 type MockClientGenerator struct {
 	mock.Mock
-	KubeClientValue              kubernetes.Interface
-	GatewayClientValue           gateway.Interface
-	IstioClientValue             istioclient.Interface
-	DynamicKubernetesClientValue dynamic.Interface
-	OpenShiftClientValue         openshift.Interface
-	RESTConfigValue              *rest.Config
+	Clients         map[string]any
+	RESTConfigValue *rest.Config
 }
 
 // stubClientGenerator implements source.ClientGenerator where all methods
-// return errors, except KubeClient when a clientset is provided.
+// return errors, except for the Kubernetes client when a clientset is provided.
 type stubClientGenerator struct {
 	kubeClient *fake.Clientset
 }
 
-// NewFakeClientGenerator returns a ClientGenerator whose KubeClient returns the
-// provided fake clientset. All other methods return errors.
+// NewFakeClientGenerator returns a ClientGenerator whose Kubernetes client is the
+// provided fake clientset. All other clients return errors.
 func NewFakeClientGenerator(client *fake.Clientset) stubClientGenerator {
 	return stubClientGenerator{kubeClient: client}
 }
 
-func (m *MockClientGenerator) KubeClient() (kubernetes.Interface, error) {
-	args := m.Called()
-	if args.Error(1) == nil {
-		m.KubeClientValue = args.Get(0).(kubernetes.Interface)
-		return m.KubeClientValue, nil
-	}
-	return nil, args.Error(1)
-}
-
-func (m *MockClientGenerator) GatewayClient() (gateway.Interface, error) {
-	args := m.Called()
-	if args.Error(1) == nil {
-		m.GatewayClientValue = args.Get(0).(gateway.Interface)
-		return m.GatewayClientValue, nil
+func (m *MockClientGenerator) Client(name string) (any, error) {
+	args := m.Called(name)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
 	}
-	return nil, args.Error(1)
-}
-
-func (m *MockClientGenerator) IstioClient() (istioclient.Interface, error) {
-	args := m.Called()
-	if args.Error(1) == nil {
-		m.IstioClientValue = args.Get(0).(istioclient.Interface)
-		return m.IstioClientValue, nil
+	if m.Clients == nil {
+		m.Clients = map[string]any{}
 	}
-	return nil, args.Error(1)
-}
-
-func (m *MockClientGenerator) DynamicKubernetesClient() (dynamic.Interface, error) {
-	args := m.Called()
-	if args.Error(1) == nil {
-		m.DynamicKubernetesClientValue = args.Get(0).(dynamic.Interface)
-		return m.DynamicKubernetesClientValue, nil
-	}
-	return nil, args.Error(1)
-}
-
-func (m *MockClientGenerator) OpenShiftClient() (openshift.Interface, error) {
-	args := m.Called()
-	if args.Error(1) == nil {
-		m.OpenShiftClientValue = args.Get(0).(openshift.Interface)
-		return m.OpenShiftClientValue, nil
-	}
-	return nil, args.Error(1)
+	m.Clients[name] = args.Get(0)
+	return args.Get(0), nil
 }
 
 func (m *MockClientGenerator) RESTConfig() (*rest.Config, error) {
@@ -112,29 +75,13 @@ func (m *MockClientGenerator) RESTConfig() (*rest.Config, error) {
 	return nil, args.Error(1)
 }
 
-func (s stubClientGenerator) KubeClient() (kubernetes.Interface, error) {
-	if s.kubeClient == nil {
-		return nil, errNotAvailable("KubeClient")
+func (s stubClientGenerator) Client(name string) (any, error) {
+	if name != kubeClientName || s.kubeClient == nil {
+		return nil, errNotAvailable("Client " + name)
 	}
 	return s.kubeClient, nil
 }
 
-func (stubClientGenerator) GatewayClient() (gateway.Interface, error) {
-	return nil, errNotAvailable("GatewayClient")
-}
-
-func (stubClientGenerator) IstioClient() (istioclient.Interface, error) {
-	return nil, errNotAvailable("IstioClient")
-}
-
-func (stubClientGenerator) DynamicKubernetesClient() (dynamic.Interface, error) {
-	return nil, errNotAvailable("DynamicKubernetesClient")
-}
-
-func (stubClientGenerator) OpenShiftClient() (openshift.Interface, error) {
-	return nil, errNotAvailable("OpenShiftClient")
-}
-
 func (stubClientGenerator) RESTConfig() (*rest.Config, error) {
 	return nil, errNotAvailable("RESTConfig")
 }
@@ -368,3 +368,32 @@ func distributeByWeight(keys []string, weights map[string]int, n int) []string {
 
 	return result
 }
+
+// TestHelperAdjustEndpointsContract verifies that adjust, a provider AdjustEndpoints
+// implementation, follows the contract documented on provider.Provider: the supplied
+// endpoints and slice are not modified, and adjusting the result again does not change it.
+func TestHelperAdjustEndpointsContract(t *testing.T, adjust func([]*endpoint.Endpoint) ([]*endpoint.Endpoint, error), endpoints []*endpoint.Endpoint) {
+	t.Helper()
+
+	supplied := slices.Clone(endpoints)
+	originals := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		originals = append(originals, ep.DeepCopy())
+	}
+
+	adjusted, err := adjust(endpoints)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, supplied, endpoints, "the supplied slice must not be modified")
+	assert.Equal(t, originals, endpoints, "the supplied endpoints must not be modified")
+	for _, ep := range adjusted {
+		assert.False(t, slices.Contains(supplied, ep), "adjusted endpoint %s must be a copy", ep)
+	}
+
+	readjusted, err := adjust(adjusted)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, adjusted, readjusted, "adjusting the adjusted endpoints must not change them")
+}
@@ -22,6 +22,7 @@ const (
 	RegistryDynamoDB = "dynamodb"
 	RegistryAWSSD    = "aws-sd"
 	RegistryCRD      = "crd"
+	RegistryTXTZone  = "txt-zone"
 
 	ProviderAlibabaCloud = "alibabacloud"
 	ProviderAWS          = "aws"
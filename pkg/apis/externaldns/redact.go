@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"net/url"
+	"reflect"
+	"time"
+)
+
+const (
+	// secureTag marks the Config fields holding sensitive values, e.g. `secure:"yes"`.
+	secureTag = "secure"
+	// secureMask masks the whole value of a field.
+	secureMask = "yes"
+	// secureURL masks the password and the query parameter values of a URL, which
+	// may carry credentials, and keeps the rest of it readable.
+	secureURL = "url"
+)
+
+// Redacted returns a copy of cfg with the values of the fields tagged as secure
+// masked, safe to log or to serve.
+func (cfg *Config) Redacted() *Config {
+	redacted := *cfg
+	v := reflect.ValueOf(&redacted).Elem()
+	t := v.Type()
+	for i := range t.NumField() {
+		mode, ok := t.Field(i).Tag.Lookup(secureTag)
+		if !ok {
+			continue
+		}
+		field := v.Field(i)
+		if field.String() == "" {
+			continue
+		}
+		switch mode {
+		case secureURL:
+			field.SetString(redactURL(field.String()))
+		default:
+			field.SetString(passwordMask)
+		}
+	}
+	return &redacted
+}
+
+// RedactedFields returns the fields of the redacted configuration by name, with
+// durations in their string form, e.g. to serve it as JSON.
+func (cfg *Config) RedactedFields() map[string]any {
+	v := reflect.ValueOf(cfg.Redacted()).Elem()
+	t := v.Type()
+	fields := make(map[string]any, t.NumField())
+	for i := range t.NumField() {
+		value := v.Field(i).Interface()
+		if d, ok := value.(time.Duration); ok {
+			value = d.String()
+		}
+		fields[t.Field(i).Name] = value
+	}
+	return fields
+}
+
+// redactURL masks the password and the query parameter values of raw, or all of
+// it when it cannot be parsed.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return passwordMask
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), passwordMask)
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			query.Set(key, passwordMask)
+		}
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
@@ -18,7 +18,6 @@ package externaldns
 
 import (
 	"fmt"
-	"reflect"
 	"regexp"
 	"slices"
 	"strings"
@@ -44,20 +43,26 @@ const (
 
 // Config is a project-wide configuration
 type Config struct {
-	APIServerURL                                  string
+	APIServerURL                                  string `secure:"url"`
 	KubeConfig                                    string
 	RequestTimeout                                time.Duration
 	KubeAPIRequestTimeout                         time.Duration
 	KubeAPIQPS                                    int
+	KubeAPIListPageSize                           int
+	KubeAPIWatchStaleTimeout                      time.Duration
 	KubeAPIBurst                                  int
 	DefaultTargets                                []string
 	GlooNamespaces                                []string
+	ContourEnvoyService                           string
 	SkipperRouteGroupVersion                      string
 	Sources                                       []string
 	Namespace                                     string
+	ExcludeNamespaces                             []string
 	AnnotationFilter                              string
 	AnnotationPrefix                              string
+	DeprecatedAnnotationPrefixes                  []string
 	LabelFilter                                   string
+	TargetServiceSelector                         string
 	IngressClassNames                             []string
 	FQDNTemplate                                  []string
 	TargetTemplate                                []string
@@ -69,6 +74,7 @@ type Config struct {
 	IgnoreIngressRulesSpec                        bool
 	ListenEndpointEvents                          bool
 	ExposeInternalIPV6                            bool
+	NodeZoneFQDNTemplate                          string
 	GatewayName                                   string
 	GatewayNamespace                              string
 	GatewayLabelFilter                            string
@@ -79,17 +85,45 @@ type Config struct {
 	PublishHostIP                                 bool
 	AlwaysPublishNotReadyAddresses                bool
 	ConnectorSourceServer                         string
+	DelegationConfig                              string
+	NomadAddress                                  string `secure:"url"`
+	NomadToken                                    string `secure:"yes"`
+	NomadNamespace                                string
+	NomadTags                                     []string
+	NomadPollInterval                             time.Duration
+	ConsulAddress                                 string `secure:"url"`
+	ConsulToken                                   string `secure:"yes"`
+	ConsulDatacenter                              string
+	ConsulTags                                    []string
 	Provider                                      string
 	ProviderCacheTime                             time.Duration
+	ProviderZoneConcurrency                       int
+	ProviderEndpoint                              string `secure:"url"`
+	StateCacheFile                                string
+	StateCacheConfigMap                           string
 	CreatePTR                                     bool
-	GoogleProject                                 string
+	MergeEndpoints                                bool
+	SourceWrapperOrder                            []string
+	DisabledSourceWrappers                        []string
+	View                                          string
+	ClusterName                                   string
+	RecordPrefix                                  string
+	RecordSuffix                                  string
+	RecordSharedParent                            bool
+	NamespaceCollisionPolicy                      string
+	TargetFromKinds                               []string
+	SourceTimeouts                                []string
+	GoogleProjects                                []string
 	GoogleBatchChangeSize                         int
 	GoogleBatchChangeInterval                     time.Duration
 	GoogleZoneVisibility                          string
+	GoogleZoneKinds                               []string
 	DomainFilter                                  []string
 	DomainExclude                                 []string
 	RegexDomainFilter                             *regexp.Regexp
 	RegexDomainExclude                            *regexp.Regexp
+	DomainFilterPolicy                            string
+	DNSPolicies                                   bool
 	ZoneNameFilter                                []string
 	ZoneIDFilter                                  []string
 	TargetNetFilter                               []string
@@ -109,9 +143,12 @@ type Config struct {
 	AWSAPIRetries                                 int
 	AWSPreferCNAME                                bool
 	AWSZoneCacheDuration                          time.Duration
+	AWSCanonicalHostedZonesFile                   string
 	AWSSDServiceCleanup                           bool
 	AWSSDCreateTag                                map[string]string
 	AWSZoneMatchParent                            bool
+	AWSSharedZones                                bool
+	AWSSharedZonesProfileIDs                      []string
 	AWSDynamoDBRegion                             string
 	AWSDynamoDBTable                              string
 	AzureConfigFile                               string
@@ -121,6 +158,9 @@ type Config struct {
 	AzureActiveDirectoryAuthorityHost             string
 	AzureZonesCacheDuration                       time.Duration
 	AzureMaxRetriesCount                          int
+	AzurePrivateDNSCheckVNetLinks                 bool
+	AzurePrivateDNSVNets                          []string
+	AzurePrivateDNSRecordMetadata                 bool
 	BatchChangeSize                               int
 	BatchChangeInterval                           time.Duration
 	CloudflareProxied                             bool
@@ -129,46 +169,81 @@ type Config struct {
 	CloudflareDNSRecordsComment                   string
 	CloudflareCustomHostnamesMinTLSVersion        string
 	CloudflareCustomHostnamesCertificateAuthority string
+	CloudflareCustomHostnamesFallbackOrigin       string
 	CloudflareRegionalServices                    bool
 	CloudflareRegionKey                           string
+	CloudflareNamespaceDefaults                   bool
 	CoreDNSPrefix                                 string
 	CoreDNSStrictlyOwned                          bool
 	OCIConfigFile                                 string
 	OCICompartmentOCID                            string
 	OCIAuthInstancePrincipal                      bool
+	OCIAuthWorkloadIdentity                       bool
 	OCIZoneScope                                  string
 	OCIZoneCacheDuration                          time.Duration
 	InMemoryZones                                 []string
-	OVHEndpoint                                   string
+	InMemoryStateFile                             string
+	OVHEndpoint                                   string `secure:"url"`
 	OVHApiRateLimit                               int
 	OVHEnableCNAMERelative                        bool
-	PDNSServer                                    string
+	PDNSServer                                    string `secure:"url"`
 	PDNSServerID                                  string
 	PDNSAPIKey                                    string `secure:"yes"`
 	PDNSSkipTLSVerify                             bool
+	PDNSIncreaseSOASerial                         bool
 	TLSCA                                         string
 	TLSClientCert                                 string
 	TLSClientCertKey                              string
 	Policy                                        string
+	TTLRolloutSteps                               int
+	TTLMaxUpdatesPerSync                          int
+	ChangeWindow                                  string
+	ChangeWindowHoldDeletes                       bool
+	DeleteDelay                                   time.Duration
+	ApplyChunkSize                                int
+	MaxEndpoints                                  int
+	ProtectedRecords                              []string
+	ProtectedRecordsFile                          string
+	ApexRecordsFile                               string
+	Suspend                                       bool
+	SuspendNamespace                              string
 	Registry                                      string
 	TXTOwnerID                                    string
 	TXTOwnerOld                                   string
 	TXTPrefix                                     string
 	TXTSuffix                                     string
 	TXTEncryptEnabled                             bool
+	TXTCleanupOrphans                             bool
+	TXTZoneApexes                                 []string
+	TXTResourceRefs                               bool
+	TXTLabelUpdatesRegistryOnly                   bool
+	NormalizeEndpoints                            bool
+	RecordDesiredHash                             bool
 	TXTEncryptAESKey                              string `secure:"yes"`
 	Interval                                      time.Duration
 	MinEventSyncInterval                          time.Duration
+	IntervalJitter                                time.Duration
+	SyncSchedule                                  string
 	MinTTL                                        time.Duration
 	Once                                          bool
 	DryRun                                        bool
+	Diff                                          bool
+	ExportFile                                    string
+	ExportFormat                                  string
+	ExportZoneSection                             bool
+	ExportReloadCommand                           string
 	UpdateEvents                                  bool
 	LogFormat                                     string
 	MetricsAddress                                string
+	SyncAPITokenFile                              string
+	SyncAPITokenReview                            bool
+	SyncAPIMinInterval                            time.Duration
+	RegistryRecordsZoneLimit                      int
 	LogLevel                                      string
+	LogSampleLimit                                int
 	TXTCacheInterval                              time.Duration
 	TXTWildcardReplacement                        string
-	ExoscaleEndpoint                              string
+	ExoscaleEndpoint                              string `secure:"url"`
 	ExoscaleAPIKey                                string `secure:"yes"`
 	ExoscaleAPISecret                             string `secure:"yes"`
 	ExoscaleAPIEnvironment                        string
@@ -176,6 +251,8 @@ type Config struct {
 	ExoscaleZoneCacheDuration                     time.Duration
 	CRDSourceAPIVersion                           string
 	CRDSourceKind                                 string
+	CRDDefaultTTL                                 time.Duration
+	EnableDNSEndpointFinalizer                    bool
 	ServiceTypeFilter                             []string
 	ResolveServiceLoadBalancerHostname            bool
 	RFC2136Host                                   []string
@@ -195,7 +272,7 @@ type Config struct {
 	RFC2136BatchChangeSize                        int
 	RFC2136UseTLS                                 bool
 	RFC2136SkipTLSVerify                          bool
-	NS1Endpoint                                   string
+	NS1Endpoint                                   string `secure:"url"`
 	NS1IgnoreSSL                                  bool
 	NS1MinTTLSeconds                              int
 	ManagedDNSRecordTypes                         []string
@@ -204,19 +281,36 @@ type Config struct {
 	GoDaddySecretKey                              string `secure:"yes"`
 	GoDaddyTTL                                    int64
 	GoDaddyOTE                                    bool
+	LinodeZoneTags                                []string
 	OCPRouterName                                 string
-	PiholeServer                                  string
+	PiholeServer                                  string `secure:"url"`
 	PiholePassword                                string `secure:"yes"`
 	PiholeTLSInsecureSkipVerify                   bool
-	WebhookProviderURL                            string
+	WebhookProviderURL                            string `secure:"url"`
 	WebhookProviderReadTimeout                    time.Duration
 	WebhookProviderWriteTimeout                   time.Duration
+	WebhookProviderToken                          string `secure:"yes"`
 	WebhookServer                                 bool
+	WebhookServerProviders                        []string
+	WebhookServerAddress                          string
+	WebhookServerTLSCert                          string
+	WebhookServerTLSKey                           string
+	WebhookServerTLSClientCA                      string
 	TraefikEnableLegacy                           bool
 	TraefikDisableNew                             bool
 	NAT64Networks                                 []string
+	HealthChecks                                  bool
+	HealthCheckTimeout                            time.Duration
+	HealthCheckFailureThreshold                   int
+	HealthCheckSuccessThreshold                   int
 	ExcludeUnschedulable                          bool
 	EmitEvents                                    []string
+	FullReconcileInterval                         time.Duration
+	EventsQPS                                     int
+	EventsBurst                                   int
+	EventsSinks                                   []string
+	EventsWebhookURL                              string `secure:"yes"`
+	EventsWebhookTimeout                          time.Duration
 	ForceDefaultTargets                           bool
 	UnstructuredResources                         []string
 	PreferAlias                                   bool
@@ -262,6 +356,7 @@ var defaultConfig = &Config{
 	CombineFQDNAndAnnotation:     false,
 	Compatibility:                "",
 	ConnectorSourceServer:        "localhost:8080",
+	ConsulAddress:                "http://127.0.0.1:8500",
 	CoreDNSPrefix:                "/skydns/",
 	CoreDNSStrictlyOwned:         false,
 	CRDSourceAPIVersion:          "externaldns.k8s.io/v1alpha1",
@@ -269,10 +364,14 @@ var defaultConfig = &Config{
 	DefaultTargets:               []string{},
 	DomainFilter:                 []string{},
 	DryRun:                       false,
+	Diff:                         false,
+	ExportFormat:                 "json",
 	ExcludeDNSRecordTypes:        []string{},
 	DomainExclude:                []string{},
 	ExcludeTargetNets:            []string{},
 	EmitEvents:                   []string{},
+	EventsSinks:                  []string{"kubernetes"},
+	EventsWebhookTimeout:         5 * time.Second,
 	ExcludeUnschedulable:         true,
 	ExoscaleAPIEnvironment:       "api",
 	ExoscaleAPIKey:               "",
@@ -293,7 +392,7 @@ var defaultConfig = &Config{
 	GoDaddyTTL:                   600,
 	GoogleBatchChangeInterval:    time.Second,
 	GoogleBatchChangeSize:        1000,
-	GoogleProject:                "",
+	GoogleProjects:               nil,
 	GoogleZoneVisibility:         "",
 	IgnoreHostnameAnnotation:     false,
 	IgnoreIngressRulesSpec:       false,
@@ -305,12 +404,19 @@ var defaultConfig = &Config{
 	LabelFilter:                  labels.Everything().String(),
 	LogFormat:                    "text",
 	LogLevel:                     logrus.InfoLevel.String(),
-	ManagedDNSRecordTypes:        []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
 	MetricsAddress:               ":7979",
+	RegistryRecordsZoneLimit:     10,
+	SyncAPIMinInterval:           10 * time.Second,
 	MinEventSyncInterval:         5 * time.Second,
 	MinTTL:                       0,
 	Namespace:                    "",
 	NAT64Networks:                []string{},
+	HealthCheckTimeout:           2 * time.Second,
+	HealthCheckFailureThreshold:  3,
+	HealthCheckSuccessThreshold:  2,
+	NomadAddress:                 "http://127.0.0.1:4646",
+	NomadNamespace:               "default",
+	NomadPollInterval:            30 * time.Second,
 	NS1Endpoint:                  "",
 	NS1IgnoreSSL:                 false,
 	OCIConfigFile:                "/etc/kubernetes/oci.yaml",
@@ -324,6 +430,7 @@ var defaultConfig = &Config{
 	PDNSServer:                   "http://localhost:8081",
 	PDNSServerID:                 "localhost",
 	PDNSSkipTLSVerify:            false,
+	PDNSIncreaseSOASerial:        false,
 	PiholePassword:               "",
 	PiholeServer:                 "",
 	PiholeTLSInsecureSkipVerify:  false,
@@ -331,6 +438,8 @@ var defaultConfig = &Config{
 	Policy:                       "sync",
 	Provider:                     "",
 	ProviderCacheTime:            0,
+	ProviderZoneConcurrency:      1,
+	ProviderEndpoint:             "",
 	CreatePTR:                    false,
 	PublishHostIP:                false,
 	PublishInternal:              false,
@@ -340,6 +449,7 @@ var defaultConfig = &Config{
 	RequestTimeout:               time.Second * 30,
 	KubeAPIRequestTimeout:        time.Second * 30,
 	KubeAPIQPS:                   int(rest.DefaultQPS),
+	KubeAPIListPageSize:          500,
 	KubeAPIBurst:                 rest.DefaultBurst,
 	RFC2136BatchChangeSize:       50,
 	RFC2136GSSTSIG:               false,
@@ -380,6 +490,7 @@ var defaultConfig = &Config{
 	WebhookProviderURL:           "http://localhost:8888",
 	WebhookProviderWriteTimeout:  10 * time.Second,
 	WebhookServer:                false,
+	WebhookServerAddress:         "127.0.0.1:8888",
 	ZoneIDFilter:                 []string{},
 	ForceDefaultTargets:          false,
 	UnstructuredResources:        []string{},
@@ -430,6 +541,9 @@ var allowedSources = []string{
 	"gloo-proxy",
 	"fake",
 	"connector",
+	"delegation",
+	"nomad",
+	"consul",
 	"crd",
 	"empty",
 	"skipper-routegroup",
@@ -452,20 +566,7 @@ func NewConfig() *Config {
 
 func (cfg *Config) String() string {
 	// prevent logging of sensitive information
-	temp := *cfg
-
-	t := reflect.TypeFor[Config]()
-	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
-		if val, ok := f.Tag.Lookup("secure"); ok && val == "yes" {
-			v := reflect.ValueOf(&temp).Elem().Field(i)
-			if v.String() != "" {
-				v.SetString(passwordMask)
-			}
-		}
-	}
-
-	return fmt.Sprintf("%+v", temp)
+	return fmt.Sprintf("%+v", *cfg.Redacted())
 }
 
 // allLogLevelsAsStrings returns all logrus levels as a list of strings
@@ -502,6 +603,41 @@ func (cfg *Config) IsPTRSupported() bool {
 	return slices.Contains(cfg.ManagedDNSRecordTypes, endpoint.RecordTypePTR)
 }
 
+// ExcludedNamespacePatterns returns the glob patterns of ExcludeNamespaces, each
+// value being a comma-separated list of patterns.
+func (cfg *Config) ExcludedNamespacePatterns() []string {
+	var patterns []string
+	for _, value := range cfg.ExcludeNamespaces {
+		for pattern := range strings.SplitSeq(value, ",") {
+			if pattern = strings.TrimSpace(pattern); pattern != "" {
+				patterns = append(patterns, pattern)
+			}
+		}
+	}
+	return patterns
+}
+
+// SourceTimeoutsByName parses SourceTimeouts into a map from source name to its
+// time budget. The timeout applying to every source is stored under the empty name.
+func (cfg *Config) SourceTimeoutsByName() (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(cfg.SourceTimeouts))
+	for _, value := range cfg.SourceTimeouts {
+		name, raw, found := strings.Cut(value, "=")
+		if !found {
+			name, raw = "", value
+		}
+		timeout, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source timeout %q: %w", value, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("invalid source timeout %q: must be positive", value)
+		}
+		timeouts[name] = timeout
+	}
+	return timeouts, nil
+}
+
 func bindFlags(b flags.FlagBinder, cfg *Config) {
 	// Flags related to Kubernetes
 	b.StringVar("server", "The Kubernetes API server to connect to (default: auto-detect)", defaultConfig.APIServerURL, &cfg.APIServerURL)
@@ -511,6 +647,9 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	// Flags related to Gloo
 	b.StringsVar("gloo-namespace", "The Gloo Proxy namespace; specify multiple times for multiple namespaces. (default: gloo-system)", []string{"gloo-system"}, &cfg.GlooNamespaces)
 
+	// Flags related to Contour
+	b.StringVar("contour-envoy-service", "The Envoy service (namespace/name) used as target for Contour HTTPProxies without a load balancer status; optional", "", &cfg.ContourEnvoyService)
+
 	// Flags related to Skipper RouteGroup
 	b.StringVar("skipper-routegroup-groupversion", "The resource version for skipper routegroup", defaultConfig.SkipperRouteGroupVersion, &cfg.SkipperRouteGroupVersion)
 
@@ -518,10 +657,23 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.BoolVar("always-publish-not-ready-addresses", "Always publish also not ready addresses for headless services (optional)", false, &cfg.AlwaysPublishNotReadyAddresses)
 	b.StringVar("annotation-filter", "Filter resources queried for endpoints by annotation, using label selector semantics", defaultConfig.AnnotationFilter, &cfg.AnnotationFilter)
 	b.StringVar("annotation-prefix", "Annotation prefix for external-dns annotations (default: external-dns.kubernetes.io/)", defaultConfig.AnnotationPrefix, &cfg.AnnotationPrefix)
+	b.StringsVar("deprecated-annotation-prefix", "Annotation prefix still honored while migrating to --annotation-prefix, with a lower precedence; specify multiple times in decreasing order of precedence (optional)", nil, &cfg.DeprecatedAnnotationPrefixes)
 	b.EnumVar("compatibility", "Process annotation semantics from legacy implementations (optional, options: mate, molecule, kops-dns-controller)", defaultConfig.Compatibility, &cfg.Compatibility, "", "mate", "molecule", "kops-dns-controller")
 	b.StringVar("connector-source-server", "The server to connect for connector source, valid only when using connector source", defaultConfig.ConnectorSourceServer, &cfg.ConnectorSourceServer)
+	b.StringVar("delegation-config", "The YAML file listing the child zones to delegate, valid only when using delegation source", "", &cfg.DelegationConfig)
+	b.StringVar("nomad-address", "The address of the Nomad HTTP API, valid only when using nomad source", defaultConfig.NomadAddress, &cfg.NomadAddress)
+	b.StringVar("nomad-token", "The ACL token used to read the Nomad services, valid only when using nomad source (optional)", defaultConfig.NomadToken, &cfg.NomadToken)
+	b.StringVar("nomad-namespace", "The Nomad namespace to read the services from, '*' for all namespaces, valid only when using nomad source", defaultConfig.NomadNamespace, &cfg.NomadNamespace)
+	b.StringsVar("nomad-tag", "Only publish the Nomad services with this tag; specify multiple times to require several tags, valid only when using nomad source (optional)", nil, &cfg.NomadTags)
+	b.DurationVar("nomad-poll-interval", "The interval at which the Nomad services are polled for changes to trigger a synchronization with --events, valid only when using nomad source", defaultConfig.NomadPollInterval, &cfg.NomadPollInterval)
+	b.StringVar("consul-address", "The address of the Consul HTTP API, valid only when using consul source", defaultConfig.ConsulAddress, &cfg.ConsulAddress)
+	b.StringVar("consul-token", "The ACL token used to read the Consul catalog, valid only when using consul source (optional)", defaultConfig.ConsulToken, &cfg.ConsulToken)
+	b.StringVar("consul-datacenter", "The Consul datacenter to read the services from, valid only when using consul source (default: the datacenter of the agent)", defaultConfig.ConsulDatacenter, &cfg.ConsulDatacenter)
+	b.StringsVar("consul-tag", "Only publish the Consul services with this tag; specify multiple times to require several tags, valid only when using consul source (optional)", nil, &cfg.ConsulTags)
 	b.StringVar("crd-source-apiversion", "API version of the CRD for crd source, e.g. `externaldns.k8s.io/v1alpha1`, valid only when using crd source", defaultConfig.CRDSourceAPIVersion, &cfg.CRDSourceAPIVersion)
 	b.StringVar("crd-source-kind", "Kind of the CRD for the crd source in API group and version specified by crd-source-apiversion", defaultConfig.CRDSourceKind, &cfg.CRDSourceKind)
+	b.DurationVar("crd-default-ttl", "The TTL of the DNSEndpoint endpoints without recordTTL, reported in their Defaulted condition, valid only when using crd source (default: 0, unset)", defaultConfig.CRDDefaultTTL, &cfg.CRDDefaultTTL)
+	b.BoolVar("enable-dnsendpoint-finalizer", "Add a finalizer to DNSEndpoint resources and only remove it once their records are deleted from the provider, valid only when using crd source (default: false)", false, &cfg.EnableDNSEndpointFinalizer)
 	b.StringsVar("default-targets", "Set globally default host/IP that will apply as a target instead of source addresses. Specify multiple times for multiple targets (optional)", nil, &cfg.DefaultTargets)
 	b.BoolVar("force-default-targets", "Force the application of --default-targets, overriding any targets provided by the source (DEPRECATED: This reverts to (improved) legacy behavior which allows empty CRD targets for migration to new state)", defaultConfig.ForceDefaultTargets, &cfg.ForceDefaultTargets)
 	b.BoolVar("prefer-alias", "When enabled, CNAME records will have the alias annotation set, signaling providers that support ALIAS records to use them instead of CNAMEs. Supported by: PowerDNS, AWS (with --aws-prefer-cname disabled)", defaultConfig.PreferAlias, &cfg.PreferAlias)
@@ -529,6 +681,7 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.StringsVar("exclude-target-net", "Exclude target nets (optional)", nil, &cfg.ExcludeTargetNets)
 	b.BoolVar("exclude-unschedulable", "Exclude nodes that are considered unschedulable (default: true)", defaultConfig.ExcludeUnschedulable, &cfg.ExcludeUnschedulable)
 	b.BoolVar("expose-internal-ipv6", "When using the node source, expose internal IPv6 addresses (optional, default: false)", false, &cfg.ExposeInternalIPV6)
+	b.StringVar("node-zone-fqdn-template", "When using the node source, a template executed against each node with a topology.kubernetes.io/zone label; nodes resolving to the same name are grouped into one record in addition to the flat node record (optional)", "", &cfg.NodeZoneFQDNTemplate)
 	b.StringVar("gateway-label-filter", "Filter Gateways of Route endpoints via label selector (default: all gateways)", defaultConfig.GatewayLabelFilter, &cfg.GatewayLabelFilter)
 	b.StringVar("gateway-name", "Limit Gateways of Route endpoints to a specific name (default: all names)", defaultConfig.GatewayName, &cfg.GatewayName)
 	b.StringVar("gateway-namespace", "Limit Gateways of Route endpoints to a specific namespace (default: all namespaces)", defaultConfig.GatewayNamespace, &cfg.GatewayNamespace)
@@ -539,10 +692,27 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.BoolVar("ignore-non-host-network-pods", "Ignore pods not running on host network when using pod source (default: false)", false, &cfg.IgnoreNonHostNetworkPods)
 	b.StringsVar("ingress-class", "Require an Ingress to have this class name; specify multiple times to allow more than one class (optional; defaults to any class)", nil, &cfg.IngressClassNames)
 	b.StringVar("label-filter", "Filter resources queried for endpoints by label selector; currently supported by source types crd, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, gloo-proxy, ingress, node, openshift-route, service and ambassador-host", defaultConfig.LabelFilter, &cfg.LabelFilter)
-	managedRecordTypesHelp := fmt.Sprintf("Record types to manage; specify multiple times to include many; (default: %s) (supported records: A, AAAA, CNAME, NS, SRV, TXT)", strings.Join(defaultConfig.ManagedDNSRecordTypes, ","))
-	b.StringsVar("managed-record-types", managedRecordTypesHelp, defaultConfig.ManagedDNSRecordTypes, &cfg.ManagedDNSRecordTypes)
+	b.StringVar("target-service-selector", "Only read the targets of istio-gateway and istio-virtualservice sources from the Services matching this label selector, e.g. when several load balancer Services front the same ingress gateway (default: all services)", defaultConfig.TargetServiceSelector, &cfg.TargetServiceSelector)
+	managedRecordTypesHelp := fmt.Sprintf("Record types to manage; specify multiple times to include many; (default: A,AAAA,CNAME, restricted to the record types supported by the provider, plus CAA with aws, cloudflare and pdns) (supported records: %s)", strings.Join(endpoint.KnownRecordTypes, ", "))
+	b.StringsVar("managed-record-types", managedRecordTypesHelp, nil, &cfg.ManagedDNSRecordTypes)
+	b.BoolVar("merge-endpoints", "Merge the targets of endpoints produced by different resources for the same DNS name, record type and set identifier, recording every contributing resource; conflicting endpoints are reported (default: false)", false, &cfg.MergeEndpoints)
+	b.StringsVar("source-wrapper-order", "The order in which the source wrappers are applied after deduplication; specify multiple times, unlisted wrappers follow in their default order (optional, default: namespace-collision, target-from, namespace-defaults, cluster-records, view, nat64, target-filter, health-check, ptr, post-processor)", nil, &cfg.SourceWrapperOrder)
+	b.StringsVar("disable-source-wrapper", "Disable a source wrapper; specify multiple times for multiple wrappers (optional, options: namespace-collision, target-from, namespace-defaults, cluster-records, view, nat64, target-filter, health-check, ptr, post-processor)", nil, &cfg.DisabledSourceWrappers)
+	b.StringsVar("source-timeout", "Limit the time a source may take to list its endpoints, as a duration for all sources or source=duration for one source; specify multiple times, a per-source value overrides the global one (optional, default: no limit)", nil, &cfg.SourceTimeouts)
+	b.StringVar("view", "Publish the targets of this split-horizon view, set with the view-target-<view> annotation, in place of the default targets (optional)", "", &cfg.View)
+	b.StringVar("cluster-name", "The name of this cluster, available as {{ .ClusterName }} in --record-prefix and --record-suffix and used as set identifier with --record-shared-parent (optional)", "", &cfg.ClusterName)
+	b.StringVar("record-prefix", "Template prepended to the first label of the DNS names of all endpoints, e.g. '{{ .ClusterName }}.' (optional, requires --cluster-name)", "", &cfg.RecordPrefix)
+	b.StringVar("record-suffix", "Template appended to the first label of the DNS names of all endpoints, e.g. '.{{ .ClusterName }}' publishes app.example.com as app.eu.example.com (optional, requires --cluster-name)", "", &cfg.RecordSuffix)
+	b.BoolVar("record-shared-parent", "Also publish the endpoints under their original DNS names, with --cluster-name as set identifier, e.g. for a weighted record shared by several clusters (default: disabled, requires --cluster-name)", false, &cfg.RecordSharedParent)
+	b.EnumVar("namespace-collision-policy", "Resolve the DNS names claimed by resources of different namespaces with this policy and emit a warning event to each of them (default: disabled, options: first-wins, deny-all, annotation-priority)", "", &cfg.NamespaceCollisionPolicy, "", "first-wins", "deny-all", "annotation-priority")
+	b.StringsVar("target-from-kind", "Resolve the targets referenced by the target-from annotation from objects of this kind, watched in --namespace; specify multiple times for multiple kinds (optional, options: configmap, secret)", nil, &cfg.TargetFromKinds)
 	b.StringVar("namespace", "Limit resources queried for endpoints to a specific namespace (default: all namespaces)", defaultConfig.Namespace, &cfg.Namespace)
+	b.StringsVar("exclude-namespaces", "Ignore the resources of the namespaces matching these glob patterns, e.g. 'kube-*'; specify multiple times or comma-separated for multiple patterns (optional)", nil, &cfg.ExcludeNamespaces)
 	b.StringsVar("nat64-networks", "Adding an A record for each AAAA record in NAT64-enabled networks; specify multiple times for multiple possible nets (optional)", nil, &cfg.NAT64Networks)
+	b.BoolVar("health-checks", "Probe the targets of the endpoints with the health-check annotation, e.g. tcp:443 or http:8080/healthz, on every synchronization and drop the failing ones, unless all of them fail (default: disabled)", false, &cfg.HealthChecks)
+	b.DurationVar("health-check-timeout", "When using --health-checks, the timeout of each probe", defaultConfig.HealthCheckTimeout, &cfg.HealthCheckTimeout)
+	b.IntVar("health-check-failure-threshold", "When using --health-checks, the number of consecutive failed probes dropping a target", defaultConfig.HealthCheckFailureThreshold, &cfg.HealthCheckFailureThreshold)
+	b.IntVar("health-check-success-threshold", "When using --health-checks, the number of consecutive successful probes restoring a dropped target", defaultConfig.HealthCheckSuccessThreshold, &cfg.HealthCheckSuccessThreshold)
 	b.StringVar("openshift-router-name", "if source is openshift-route then you can pass the ingress controller name. Based on this name external-dns will select the respective router from the route status and map that routerCanonicalHostname to the route host while creating a CNAME record.", defaultConfig.OCPRouterName, &cfg.OCPRouterName)
 	b.StringVar("pod-source-domain", "Domain to use for pods records (optional)", defaultConfig.PodSourceDomain, &cfg.PodSourceDomain)
 	b.BoolVar("publish-host-ip", "Allow external-dns to publish host-ip for headless services (optional)", false, &cfg.PublishHostIP)
@@ -553,19 +723,31 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.BoolVar("traefik-disable-new", "Disable listeners on Resources under the traefik.io API Group", defaultConfig.TraefikDisableNew, &cfg.TraefikDisableNew)
 
 	b.StringsVar("unstructured-resource", "When using the unstructured source, specify resources in resource.version.group format (e.g., virtualmachineinstances.v1.kubevirt.io, configmap.v1); specify multiple times for multiple resources", nil, &cfg.UnstructuredResources)
-	b.StringsVar("events-emit", "Events that should be emitted. Specify multiple times for multiple events support (optional, default: none, expected: RecordReady, RecordDeleted, RecordError)", defaultConfig.EmitEvents, &cfg.EmitEvents)
+	b.StringsVar("events-emit", "Events that should be emitted. Specify multiple times for multiple events support (optional, default: none, expected: RecordReady, RecordDeleted, RecordError, RecordConflict, CustomHostnamePending, ApexRecordDrift)", defaultConfig.EmitEvents, &cfg.EmitEvents)
+	b.IntVar("events-qps", "Maximum number of Kubernetes events created per second; events above the limit are dropped (default: 0, unlimited)", defaultConfig.EventsQPS, &cfg.EventsQPS)
+	b.IntVar("events-burst", "Maximum burst of Kubernetes events above --events-qps (default: same as --events-qps)", defaultConfig.EventsBurst, &cfg.EventsBurst)
+	b.StringsEnumVar("events-sink", "Destinations of the emitted events; specify multiple times for several sinks (default: kubernetes, options: kubernetes, webhook)", defaultConfig.EventsSinks, &cfg.EventsSinks, "kubernetes", "webhook")
+	b.StringVar("events-webhook-url", "When using the webhook events sink, the URL receiving a JSON payload with the events of each sync, e.g. a Slack incoming webhook (required with --events-sink=webhook)", defaultConfig.EventsWebhookURL, &cfg.EventsWebhookURL)
+	b.DurationVar("events-webhook-timeout", "When using the webhook events sink, the timeout of each request", defaultConfig.EventsWebhookTimeout, &cfg.EventsWebhookTimeout)
 	b.DurationVar("provider-cache-time", "The time to cache the DNS provider record list requests.", defaultConfig.ProviderCacheTime, &cfg.ProviderCacheTime)
+	b.IntVar("provider-zone-concurrency", "The number of zones whose records are listed in parallel; supported by azure, linode, oci and pdns (default: 1, one zone after the other)", defaultConfig.ProviderZoneConcurrency, &cfg.ProviderZoneConcurrency)
+	b.StringVar("provider-endpoint", "Override the base URL of the DNS provider API, e.g. to target a sandbox environment; supported by cloudflare, pdns (replaces --pdns-server) and ns1 (replaces --ns1-endpoint) (optional)", defaultConfig.ProviderEndpoint, &cfg.ProviderEndpoint)
+	b.StringVar("state-cache-file", "Persist the provider records in this file to serve the first sync after a restart, then refresh them in the background (optional)", defaultConfig.StateCacheFile, &cfg.StateCacheFile)
+	b.StringVar("state-cache-configmap", "Persist the provider records in this ConfigMap, in namespace/name format, to serve the first sync after a restart, then refresh them in the background (optional)", defaultConfig.StateCacheConfigMap, &cfg.StateCacheConfigMap)
 	b.BoolVar("create-ptr", "When enabled, automatically create PTR records for A/AAAA records. Per-resource annotations can override this default. The provider must have authority over the reverse DNS zones (e.g. in-addr.arpa). Include reverse zones in --domain-filter.", defaultConfig.CreatePTR, &cfg.CreatePTR)
 	b.StringsVar("domain-filter", "Limit possible target zones by a domain suffix; specify multiple times for multiple domains (optional)", []string{""}, &cfg.DomainFilter)
 	b.StringsVar("exclude-domains", "Exclude subdomains (optional)", []string{""}, &cfg.DomainExclude)
 	b.RegexpVar("regex-domain-filter", "Limit possible domains and target zones by a Regex filter; Overrides domain-filter (optional)", defaultConfig.RegexDomainFilter, &cfg.RegexDomainFilter)
 	b.RegexpVar("regex-domain-exclusion", "Regex filter that excludes domains and target zones matched by regex-domain-filter (optional)", defaultConfig.RegexDomainExclude, &cfg.RegexDomainExclude)
+	b.StringVar("domain-filter-policy", "Name of a cluster-scoped DomainFilterPolicy resource overriding or augmenting the domain filters at runtime; the zones of the provider are still selected with the flags (optional)", defaultConfig.DomainFilterPolicy, &cfg.DomainFilterPolicy)
+	b.BoolVar("dns-policies", "Apply the cluster-scoped DNSPolicy resources to the endpoints, clamping TTLs and rejecting disallowed targets, and report rejected endpoints in their status (default: disabled)", false, &cfg.DNSPolicies)
 	b.StringsVar("zone-name-filter", "Filter target zones by zone domain (For now, only AzureDNS provider is using this flag); specify multiple times for multiple zones (optional)", []string{""}, &cfg.ZoneNameFilter)
 	b.StringsVar("zone-id-filter", "Filter target zones by hosted zone id; specify multiple times for multiple zones (optional)", []string{""}, &cfg.ZoneIDFilter)
-	b.StringVar("google-project", "When using the Google provider, current project is auto-detected, when running on GCP. Specify other project with this. Must be specified when running outside GCP.", defaultConfig.GoogleProject, &cfg.GoogleProject)
+	b.StringsVar("google-project", "When using the Google provider, current project is auto-detected, when running on GCP. Specify other project with this. Must be specified when running outside GCP; specify multiple times to manage zones across several projects.", defaultConfig.GoogleProjects, &cfg.GoogleProjects)
 	b.IntVar("google-batch-change-size", "When using the Google provider, set the maximum number of changes that will be applied in each batch.", defaultConfig.GoogleBatchChangeSize, &cfg.GoogleBatchChangeSize)
 	b.DurationVar("google-batch-change-interval", "When using the Google provider, set the interval between batch changes.", defaultConfig.GoogleBatchChangeInterval, &cfg.GoogleBatchChangeInterval)
 	b.EnumVar("google-zone-visibility", "When using the Google provider, filter for zones with this visibility (optional, options: public, private)", defaultConfig.GoogleZoneVisibility, &cfg.GoogleZoneVisibility, "", "public", "private")
+	b.StringsVar("google-zone-kind", "When using the Google provider, filter for zones of this kind; specify multiple times for multiple kinds (optional, default: managed and forwarding, options: managed, forwarding, peering)", nil, &cfg.GoogleZoneKinds)
 	b.StringVar("alibaba-cloud-config-file", "When using the Alibaba Cloud provider, specify the Alibaba Cloud configuration file (required when --provider=alibabacloud)", defaultConfig.AlibabaCloudConfigFile, &cfg.AlibabaCloudConfigFile)
 	b.EnumVar("alibaba-cloud-zone-type", "When using the Alibaba Cloud provider, filter for zones of this type (optional, options: public, private)", defaultConfig.AlibabaCloudZoneType, &cfg.AlibabaCloudZoneType, "", "public", "private")
 	b.EnumVar("aws-zone-type", "When using the AWS provider, filter for zones of this type (optional, default: any, options: public, private)", defaultConfig.AWSZoneType, &cfg.AWSZoneType, "", "public", "private")
@@ -580,8 +762,11 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.BoolVar("aws-evaluate-target-health", "When using the AWS provider, set whether to evaluate the health of a DNS target (default: enabled, disable with --no-aws-evaluate-target-health)", defaultConfig.AWSEvaluateTargetHealth, &cfg.AWSEvaluateTargetHealth)
 	b.IntVar("aws-api-retries", "When using the AWS API, set the maximum number of retries before giving up.", defaultConfig.AWSAPIRetries, &cfg.AWSAPIRetries)
 	b.BoolVar("aws-prefer-cname", "When using the AWS provider, prefer using CNAME instead of ALIAS (default: disabled)", defaultConfig.AWSPreferCNAME, &cfg.AWSPreferCNAME)
+	b.StringVar("aws-canonical-hosted-zones-file", "When using the AWS provider, read additional canonical hosted zones of alias targets from this YAML file mapping hostname suffixes to hosted zone IDs, e.g. for services or regions not known yet (optional)", defaultConfig.AWSCanonicalHostedZonesFile, &cfg.AWSCanonicalHostedZonesFile)
 	b.DurationVar("aws-zones-cache-duration", "When using the AWS provider, set the zones list cache TTL (0s to disable).", defaultConfig.AWSZoneCacheDuration, &cfg.AWSZoneCacheDuration)
 	b.BoolVar("aws-zone-match-parent", "Expand limit possible target by sub-domains (default: disabled)", defaultConfig.AWSZoneMatchParent, &cfg.AWSZoneMatchParent)
+	b.BoolVar("aws-shared-zones", "When using the AWS provider, also manage hosted zones associated with Route 53 Profiles shared with this account through AWS RAM (default: disabled)", defaultConfig.AWSSharedZones, &cfg.AWSSharedZones)
+	b.StringsVar("aws-shared-zones-profile-id", "When using the AWS provider with --aws-shared-zones, only consider hosted zones of this Route 53 Profile ID; specify multiple times for multiple profiles (optional)", defaultConfig.AWSSharedZonesProfileIDs, &cfg.AWSSharedZonesProfileIDs)
 	b.BoolVar("aws-sd-service-cleanup", "When using the AWS CloudMap provider, delete empty Services without endpoints (default: disabled)", defaultConfig.AWSSDServiceCleanup, &cfg.AWSSDServiceCleanup)
 	b.StringMapVar("aws-sd-create-tag", "When using the AWS CloudMap provider, add tag to created services. The flag can be used multiple times", &cfg.AWSSDCreateTag)
 	b.StringVar("azure-config-file", "When using the Azure provider, specify the Azure configuration file (required when --provider=azure)", defaultConfig.AzureConfigFile, &cfg.AzureConfigFile)
@@ -590,6 +775,9 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.StringVar("azure-user-assigned-identity-client-id", "When using the Azure provider, override the client id of user assigned identity in config file (optional)", "", &cfg.AzureUserAssignedIdentityClientID)
 	b.DurationVar("azure-zones-cache-duration", "When using the Azure provider, set the zones list cache TTL (0s to disable).", defaultConfig.AzureZonesCacheDuration, &cfg.AzureZonesCacheDuration)
 	b.IntVar("azure-maxretries-count", "When using the Azure provider, set the number of retries for API calls (When less than 0, it disables retries). (optional)", defaultConfig.AzureMaxRetriesCount, &cfg.AzureMaxRetriesCount)
+	b.BoolVar("azure-private-dns-check-vnet-links", "When using the Azure Private DNS provider, warn when publishing records to a zone which is not linked to any virtual network (default: disabled)", defaultConfig.AzurePrivateDNSCheckVNetLinks, &cfg.AzurePrivateDNSCheckVNetLinks)
+	b.StringsVar("azure-private-dns-vnet", "When using the Azure Private DNS provider, warn when publishing records to a zone which is not linked to this virtual network resource ID; specify multiple times for multiple virtual networks (optional)", defaultConfig.AzurePrivateDNSVNets, &cfg.AzurePrivateDNSVNets)
+	b.BoolVar("azure-private-dns-record-metadata", "When using the Azure Private DNS provider, write the owner and resource of records as record set metadata (default: disabled)", defaultConfig.AzurePrivateDNSRecordMetadata, &cfg.AzurePrivateDNSRecordMetadata)
 
 	b.IntVar("batch-change-size", "Set the maximum number of DNS record changes that will be submitted to the provider in each batch (optional)", defaultConfig.BatchChangeSize, &cfg.BatchChangeSize)
 	b.DurationVar("batch-change-interval", "Set the interval between batch changes (optional, default: 1s)", defaultConfig.BatchChangeInterval, &cfg.BatchChangeInterval)
@@ -597,9 +785,11 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.BoolVar("cloudflare-custom-hostnames", "When using the Cloudflare provider, specify if the Custom Hostnames feature will be used. Requires \"Cloudflare for SaaS\" enabled. (default: disabled)", false, &cfg.CloudflareCustomHostnames)
 	b.EnumVar("cloudflare-custom-hostnames-min-tls-version", "When using the Cloudflare provider with the Custom Hostnames, specify which Minimum TLS Version will be used by default. (default: 1.0, options: 1.0, 1.1, 1.2, 1.3)", "1.0", &cfg.CloudflareCustomHostnamesMinTLSVersion, "1.0", "1.1", "1.2", "1.3")
 	b.EnumVar("cloudflare-custom-hostnames-certificate-authority", "When using the Cloudflare provider with the Custom Hostnames, specify which Certificate Authority will be used. A value of none indicates no Certificate Authority will be sent to the Cloudflare API (default: none, options: google, ssl_com, lets_encrypt, none)", "none", &cfg.CloudflareCustomHostnamesCertificateAuthority, "google", "ssl_com", "lets_encrypt", "none")
+	b.StringVar("cloudflare-custom-hostnames-fallback-origin", "When using the Cloudflare provider with the Custom Hostnames, specify the fallback origin of the zone that custom hostnames without a custom origin server are routed to (optional, default: unmanaged)", "", &cfg.CloudflareCustomHostnamesFallbackOrigin)
 	b.IntVar("cloudflare-dns-records-per-page", "When using the Cloudflare provider, specify how many DNS records listed per page, max possible 5,000 (default: 100)", defaultConfig.CloudflareDNSRecordsPerPage, &cfg.CloudflareDNSRecordsPerPage)
 	b.BoolVar("cloudflare-regional-services", "When using the Cloudflare provider, specify if Regional Services feature will be used (default: disabled)", defaultConfig.CloudflareRegionalServices, &cfg.CloudflareRegionalServices)
 	b.StringVar("cloudflare-region-key", "When using the Cloudflare provider, specify the default region for Regional Services. Any value other than an empty string will enable the Regional Services feature (optional)", "", &cfg.CloudflareRegionKey)
+	b.BoolVar("cloudflare-namespace-defaults", "When using the Cloudflare provider, default the cloudflare-proxied and cloudflare-region-key annotations of resources to the ones of their Namespace object; requires list and watch on namespaces (default: disabled)", false, &cfg.CloudflareNamespaceDefaults)
 	b.StringVar("cloudflare-record-comment", "When using the Cloudflare provider, specify the comment for the DNS records (default: '')", "", &cfg.CloudflareDNSRecordsComment)
 
 	b.StringVar("coredns-prefix", "When using the CoreDNS provider, specify the prefix name", defaultConfig.CoreDNSPrefix, &cfg.CoreDNSPrefix)
@@ -608,15 +798,18 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.StringVar("oci-compartment-ocid", "When using the OCI provider, specify the OCID of the OCI compartment containing all managed zones and records.  Required when using OCI IAM instance principal authentication.", defaultConfig.OCICompartmentOCID, &cfg.OCICompartmentOCID)
 	b.EnumVar("oci-zone-scope", "When using OCI provider, filter for zones with this scope (optional, options: GLOBAL, PRIVATE). Defaults to GLOBAL, setting to empty value will target both.", defaultConfig.OCIZoneScope, &cfg.OCIZoneScope, "", "GLOBAL", "PRIVATE")
 	b.BoolVar("oci-auth-instance-principal", "When using the OCI provider, specify whether OCI IAM instance principal authentication should be used (instead of key-based auth via the OCI config file).", defaultConfig.OCIAuthInstancePrincipal, &cfg.OCIAuthInstancePrincipal)
+	b.BoolVar("oci-auth-workload-identity", "When using the OCI provider, specify whether OCI IAM workload identity authentication of OKE should be used (instead of key-based auth via the OCI config file); it is used by default when the OCI config file has no credentials and ExternalDNS runs in a pod. The region is read from the OCI_RESOURCE_PRINCIPAL_REGION environment variable.", false, &cfg.OCIAuthWorkloadIdentity)
 	b.DurationVar("oci-zones-cache-duration", "When using the OCI provider, set the zones list cache TTL (0s to disable).", defaultConfig.OCIZoneCacheDuration, &cfg.OCIZoneCacheDuration)
 	b.StringsVar("inmemory-zone", "Provide a list of pre-configured zones for the inmemory provider; specify multiple times for multiple zones (optional)", []string{""}, &cfg.InMemoryZones)
+	b.StringVar("inmemory-state-file", "When using the inmemory provider, persist the zones and records to this JSON file, restored on start (optional)", defaultConfig.InMemoryStateFile, &cfg.InMemoryStateFile)
 	b.StringVar("ovh-endpoint", "When using the OVH provider, specify the endpoint (default: ovh-eu)", defaultConfig.OVHEndpoint, &cfg.OVHEndpoint)
 	b.IntVar("ovh-api-rate-limit", "When using the OVH provider, specify the API request rate limit, X operations by seconds (default: 20)", defaultConfig.OVHApiRateLimit, &cfg.OVHApiRateLimit)
 	b.BoolVar("ovh-enable-cname-relative", "When using the OVH provider, specify if CNAME should be treated as relative on target without final dot (default: false)", defaultConfig.OVHEnableCNAMERelative, &cfg.OVHEnableCNAMERelative)
 	b.StringVar("pdns-server", "When using the PowerDNS/PDNS provider, specify the URL to the pdns server (required when --provider=pdns)", defaultConfig.PDNSServer, &cfg.PDNSServer)
 	b.StringVar("pdns-server-id", "When using the PowerDNS/PDNS provider, specify the id of the server to retrieve. Should be `localhost` except when the server is behind a proxy (optional when --provider=pdns) (default: localhost)", defaultConfig.PDNSServerID, &cfg.PDNSServerID)
-	b.StringVar("pdns-api-key", "When using the PowerDNS/PDNS provider, specify the API key to use to authorize requests (required when --provider=pdns)", defaultConfig.PDNSAPIKey, &cfg.PDNSAPIKey)
+	b.StringVar("pdns-api-key", "When using the PowerDNS/PDNS provider, specify the API key to use to authorize requests , or file:<path> to read it from a file reloaded on changes (required when --provider=pdns)", defaultConfig.PDNSAPIKey, &cfg.PDNSAPIKey)
 	b.BoolVar("pdns-skip-tls-verify", "When using the PowerDNS/PDNS provider, disable verification of any TLS certificates (optional when --provider=pdns) (default: false)", defaultConfig.PDNSSkipTLSVerify, &cfg.PDNSSkipTLSVerify)
+	b.BoolVar("pdns-increase-soa-serial", "When using the PowerDNS/PDNS provider, increment the SOA serial of the changed Native zones without a SOA-EDIT-API setting, so that their secondaries pick up the changes (optional when --provider=pdns) (default: false)", defaultConfig.PDNSIncreaseSOASerial, &cfg.PDNSIncreaseSOASerial)
 	b.StringVar("ns1-endpoint", "When using the NS1 provider, specify the URL of the API endpoint to target (default: https://api.nsone.net/v1/)", defaultConfig.NS1Endpoint, &cfg.NS1Endpoint)
 	b.BoolVar("ns1-ignoressl", "When using the NS1 provider, specify whether to verify the SSL certificate (default: false)", defaultConfig.NS1IgnoreSSL, &cfg.NS1IgnoreSSL)
 	b.IntVar("ns1-min-ttl", "Minimal TTL (in seconds) for records. This value will be used if the provided TTL for a service/ingress is lower than this.", cfg.NS1MinTTLSeconds, &cfg.NS1MinTTLSeconds)
@@ -625,6 +818,7 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.StringVar("godaddy-api-secret", "When using the GoDaddy provider, specify the API secret (required when --provider=godaddy)", defaultConfig.GoDaddySecretKey, &cfg.GoDaddySecretKey)
 	b.Int64Var("godaddy-api-ttl", "TTL (in seconds) for records. This value will be used if the provided TTL for a service/ingress is not provided.", cfg.GoDaddyTTL, &cfg.GoDaddyTTL)
 	b.BoolVar("godaddy-api-ote", "When using the GoDaddy provider, use OTE api (optional, default: false, when --provider=godaddy)", defaultConfig.GoDaddyOTE, &cfg.GoDaddyOTE)
+	b.StringsVar("linode-zone-tag", "When using the Linode provider, only manage the domains having this tag, or this key=value tag; specify multiple times to require many (optional)", nil, &cfg.LinodeZoneTags)
 
 	// Flags related to TLS communication
 	b.StringVar("tls-ca", "When using TLS communication, the path to the certificate authority to verify server communications (optionally specify --tls-client-cert for two-way TLS)", defaultConfig.TLSCA, &cfg.TLSCA)
@@ -663,16 +857,34 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.BoolVar("pihole-tls-skip-verify", "When using the Pihole provider, disable verification of any TLS certificates", defaultConfig.PiholeTLSInsecureSkipVerify, &cfg.PiholeTLSInsecureSkipVerify)
 
 	// Flags related to policies
-	b.EnumVar("policy", "Modify how DNS records are synchronized between sources and providers (default: sync, options: sync, upsert-only, create-only)", defaultConfig.Policy, &cfg.Policy, "sync", "upsert-only", "create-only")
+	b.EnumVar("policy", "Modify how DNS records are synchronized between sources and providers (default: sync, options: sync, upsert-only, create-only, delete-only)", defaultConfig.Policy, &cfg.Policy, "sync", "upsert-only", "create-only", "delete-only")
+	b.IntVar("ttl-rollout-steps", "Apply TTL-only changes gradually, moving the TTL towards the desired value over this number of synchronizations (default: disabled)", defaultConfig.TTLRolloutSteps, &cfg.TTLRolloutSteps)
+	b.IntVar("ttl-max-updates-per-sync", "Maximum number of TTL-only updates applied per synchronization, the remaining ones are deferred (default: unlimited)", defaultConfig.TTLMaxUpdatesPerSync, &cfg.TTLMaxUpdatesPerSync)
+	b.StringVar("change-window", "Only apply creates and updates within this maintenance window, e.g. 'Mon-Fri 22:00-06:00 UTC'; changes planned outside of it are deferred (default: disabled)", defaultConfig.ChangeWindow, &cfg.ChangeWindow)
+	b.BoolVar("change-window-hold-deletes", "Also defer deletions planned outside of the --change-window (default: disabled)", defaultConfig.ChangeWindowHoldDeletes, &cfg.ChangeWindowHoldDeletes)
+	b.DurationVar("delete-delay", "Apply deletions only once they have been planned for this long, after the creates and updates planned with them, so that renamed records stay resolvable while the new records propagate (default: disabled)", defaultConfig.DeleteDelay, &cfg.DeleteDelay)
+	b.StringsVar("protected-records", "Never create, update or delete this DNS name, whoever owns it; prefix with 'regex:' for a regular expression; specify multiple times for multiple names (optional)", nil, &cfg.ProtectedRecords)
+	b.StringVar("protected-records-file", "Read protected DNS names, one per line in the format of --protected-records, from this file (optional)", defaultConfig.ProtectedRecordsFile, &cfg.ProtectedRecordsFile)
+	b.StringVar("apex-records-file", "Report the apex records, e.g. NS, drifting from the expected TTL and targets per zone in this YAML file through metrics, logs and ApexRecordDrift events, without ever modifying them (optional)", defaultConfig.ApexRecordsFile, &cfg.ApexRecordsFile)
+	b.BoolVar("suspend", "Suspend the synchronizations: the records are still read and reported through the metrics and /status, but no change is applied (default: false)", defaultConfig.Suspend, &cfg.Suspend)
+	b.StringVar("suspend-namespace", "Suspend the synchronizations while this namespace has the annotation external-dns.kubernetes.io/suspend=true, read on every synchronization (optional)", defaultConfig.SuspendNamespace, &cfg.SuspendNamespace)
+	b.IntVar("apply-chunk-size", "Split the changes of a synchronization in chunks of at most this many changes per zone, applied one after the other so a failing chunk does not block the others (default: disabled)", defaultConfig.ApplyChunkSize, &cfg.ApplyChunkSize)
+	b.IntVar("max-endpoints", "Abort the synchronizations while the sources produce more than this many endpoints, e.g. because of a misconfigured template, instead of flooding the provider (default: disabled)", defaultConfig.MaxEndpoints, &cfg.MaxEndpoints)
+	b.BoolVar("normalize-endpoints", "Remove the trailing dot of the hostname targets of copies of the current and desired records before comparing them, so that it does not result in updates (default: disabled)", defaultConfig.NormalizeEndpoints, &cfg.NormalizeEndpoints)
 
 	// Flags related to the registry
-	b.EnumVar("registry", "The registry implementation to use to keep track of DNS record ownership (default: txt, options: aws-sd, crd, dynamodb, noop, txt)", defaultConfig.Registry, &cfg.Registry, RegistryAWSSD, RegistryCRD, RegistryDynamoDB, RegistryNoop, RegistryTXT)
+	b.EnumVar("registry", "The registry implementation to use to keep track of DNS record ownership (default: txt, options: aws-sd, crd, dynamodb, noop, txt, txt-zone)", defaultConfig.Registry, &cfg.Registry, RegistryAWSSD, RegistryCRD, RegistryDynamoDB, RegistryNoop, RegistryTXT, RegistryTXTZone)
 	b.StringVar("txt-owner-id", "When using the TXT, DynamoDB or CRD registry, a name that identifies this instance of ExternalDNS (default: default)", defaultConfig.TXTOwnerID, &cfg.TXTOwnerID)
 	b.StringVar("txt-prefix", "When using the TXT registry, a custom string that's prefixed to each ownership DNS record (optional). Could contain record type template like '%{record_type}-prefix-'. Mutual exclusive with txt-suffix!", defaultConfig.TXTPrefix, &cfg.TXTPrefix)
 	b.StringVar("txt-suffix", "When using the TXT registry, a custom string that's suffixed to the host portion of each ownership DNS record (optional). Could contain record type template like '-%{record_type}-suffix'. Mutual exclusive with txt-prefix!", defaultConfig.TXTSuffix, &cfg.TXTSuffix)
 	b.StringVar("txt-wildcard-replacement", "When using the TXT registry, a custom string that's used instead of an asterisk for TXT records corresponding to wildcard DNS records (optional)", defaultConfig.TXTWildcardReplacement, &cfg.TXTWildcardReplacement)
 	b.BoolVar("txt-encrypt-enabled", "When using the TXT registry, set if TXT records should be encrypted before stored (default: disabled)", defaultConfig.TXTEncryptEnabled, &cfg.TXTEncryptEnabled)
 	b.StringVar("txt-encrypt-aes-key", "When using the TXT registry, set TXT record decryption and encryption 32 byte aes key (required when --txt-encrypt=true)", defaultConfig.TXTEncryptAESKey, &cfg.TXTEncryptAESKey)
+	b.BoolVar("txt-resource-refs", "When using the TXT registry, also store the UIDs and namespaces of the source objects of a record in its TXT record, for tooling cross-referencing records with Kubernetes objects; this makes the TXT records larger (default: disabled)", false, &cfg.TXTResourceRefs)
+	b.BoolVar("record-desired-hash", "Store a short hash of the desired targets and TTL of each record in provider-visible metadata, to compare the provider state against the sources: in the TXT registry record and in the Cloudflare record comment (default: disabled)", false, &cfg.RecordDesiredHash)
+	b.BoolVar("txt-cleanup-orphans", "When using the TXT registry, delete the ownership TXT records of this owner whose record no longer exists, e.g. after its set identifier changed (default: disabled)", false, &cfg.TXTCleanupOrphans)
+	b.BoolVar("txt-label-updates-registry-only", "When using the TXT registry, apply the updates changing only the owner of a record after --migrate-from-txt-owner to its TXT records only, without rewriting the record itself (default: disabled)", false, &cfg.TXTLabelUpdatesRegistryOnly)
+	b.StringsVar("txt-zone-apex", "When using the TXT zone registry, the apex of a zone whose ownership data is stored in its _external-dns TXT record set; specify multiple times for multiple zones (required)", nil, &cfg.TXTZoneApexes)
 	b.StringVar("migrate-from-txt-owner", "Old txt-owner-id that needs to be overwritten (default: default)", defaultConfig.TXTOwnerOld, &cfg.TXTOwnerOld)
 	b.StringVar("dynamodb-region", "When using the DynamoDB registry, the AWS region of the DynamoDB table (optional)", cfg.AWSDynamoDBRegion, &cfg.AWSDynamoDBRegion)
 	b.StringVar("dynamodb-table", "When using the DynamoDB registry, the name of the DynamoDB table (default: \"external-dns\")", defaultConfig.AWSDynamoDBTable, &cfg.AWSDynamoDBTable)
@@ -680,22 +892,41 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	// Flags related to the main control loop
 	b.DurationVar("txt-cache-interval", "The interval between cache synchronizations in duration format (default: disabled)", defaultConfig.TXTCacheInterval, &cfg.TXTCacheInterval)
 	b.DurationVar("interval", "The interval between two consecutive synchronizations in duration format (default: 1m)", defaultConfig.Interval, &cfg.Interval)
+	b.DurationVar("interval-jitter", "Delay each periodic synchronization by a random duration up to this value, to spread the load of many instances on the provider API (default: disabled)", defaultConfig.IntervalJitter, &cfg.IntervalJitter)
+	b.StringVar("sync-schedule", "Run periodic synchronizations on this cron schedule in UTC instead of every --interval, e.g. '*/10 * * * *'; event-triggered synchronizations are unaffected (default: disabled)", defaultConfig.SyncSchedule, &cfg.SyncSchedule)
+	b.DurationVar("full-reconcile-interval", "The interval between two synchronizations that bypass the provider and registry record caches to correct records modified outside of external-dns (default: disabled)", defaultConfig.FullReconcileInterval, &cfg.FullReconcileInterval)
 	b.DurationVar("min-event-sync-interval", "The minimum interval between two consecutive synchronizations triggered from kubernetes events in duration format (default: 5s)", defaultConfig.MinEventSyncInterval, &cfg.MinEventSyncInterval)
 	b.BoolVar("once", "When enabled, exits the synchronization loop after the first iteration (default: disabled)", defaultConfig.Once, &cfg.Once)
 	b.BoolVar("dry-run", "When enabled, prints DNS record changes rather than actually performing them (default: disabled)", defaultConfig.DryRun, &cfg.DryRun)
+	b.BoolVar("diff", "When enabled, prints the DNS record changes of a single synchronization without performing them and exits with code 2 when there are changes, 0 otherwise (default: disabled)", defaultConfig.Diff, &cfg.Diff)
+	b.StringVar("export-file", "When set, writes the endpoints desired by the sources to this file every interval instead of synchronizing the provider, which is not used (default: disabled)", defaultConfig.ExportFile, &cfg.ExportFile)
+	b.EnumVar("export-format", "The format of the file written with --export-file (default: json, options: json, zone)", defaultConfig.ExportFormat, &cfg.ExportFormat, "json", "zone")
+	b.BoolVar("export-zone-section", "When enabled, writes the records between the '; external-dns: begin' and '; external-dns: end' lines of the existing zone file given with --export-file and increases its SOA serial; requires --export-format=zone (default: disabled)", defaultConfig.ExportZoneSection, &cfg.ExportZoneSection)
+	b.StringVar("export-reload-command", "When using --export-file, the command run after each change of the file, without a shell, e.g. 'rndc reload example.org' (optional)", defaultConfig.ExportReloadCommand, &cfg.ExportReloadCommand)
 	b.BoolVar("events", "When enabled, in addition to running every interval, the reconciliation loop will get triggered when supported sources change (default: disabled)", defaultConfig.UpdateEvents, &cfg.UpdateEvents)
 	b.DurationVar("min-ttl", "Configure global TTL for records in duration format. This value is used when the TTL for a source is not set or set to 0. (optional; examples: 1m12s, 72s, 72)", defaultConfig.MinTTL, &cfg.MinTTL)
 
 	// Miscellaneous flags
 	b.EnumVar("log-format", "The format in which log messages are printed (default: text, options: text, json)", defaultConfig.LogFormat, &cfg.LogFormat, "text", "json")
 	b.StringVar("metrics-address", "Specify where to serve the metrics and health check endpoint (default: :7979)", defaultConfig.MetricsAddress, &cfg.MetricsAddress)
+	b.StringVar("sync-api-token-file", "Serve POST /sync on --metrics-address to trigger a synchronization, for callers presenting the bearer token stored in this file (optional)", defaultConfig.SyncAPITokenFile, &cfg.SyncAPITokenFile)
+	b.BoolVar("sync-api-token-review", "Serve POST /sync on --metrics-address to trigger a synchronization, for callers presenting a bearer token authenticated by a Kubernetes TokenReview (default: disabled)", defaultConfig.SyncAPITokenReview, &cfg.SyncAPITokenReview)
+	b.DurationVar("sync-api-min-interval", "The minimum interval between two POST /sync requests not authenticated with --sync-api-token-file, faster requests are refused (default: 10s)", defaultConfig.SyncAPIMinInterval, &cfg.SyncAPIMinInterval)
+	b.IntVar("registry-records-zone-limit", "Maximum number of zones reported by the registry_zone_records metric, the records of the other zones are reported under the zone \"other\"; 0 reports every zone (default: 10)", defaultConfig.RegistryRecordsZoneLimit, &cfg.RegistryRecordsZoneLimit)
 	b.EnumVar("log-level", "Set the level of logging. (default: info, options: panic, debug, info, warning, error, fatal)", defaultConfig.LogLevel, &cfg.LogLevel, allLogLevelsAsStrings()...)
+	b.IntVar("log-sample-limit", "At debug level, the number of repetitive per-endpoint messages of each kind logged per sync; the others are counted and summarized at the end of the sync (default: 0, all logged)", defaultConfig.LogSampleLimit, &cfg.LogSampleLimit)
 
 	// Webhook provider
 	b.StringVar("webhook-provider-url", "The URL of the remote endpoint to call for the webhook provider (default: http://localhost:8888)", defaultConfig.WebhookProviderURL, &cfg.WebhookProviderURL)
 	b.DurationVar("webhook-provider-read-timeout", "The read timeout for the webhook provider in duration format (default: 5s)", defaultConfig.WebhookProviderReadTimeout, &cfg.WebhookProviderReadTimeout)
 	b.DurationVar("webhook-provider-write-timeout", "The write timeout for the webhook provider in duration format (default: 10s)", defaultConfig.WebhookProviderWriteTimeout, &cfg.WebhookProviderWriteTimeout)
 	b.BoolVar("webhook-server", "When enabled, runs as a webhook server instead of a controller. (default: false).", defaultConfig.WebhookServer, &cfg.WebhookServer)
+	b.StringsVar("webhook-server-provider", "When running as a webhook server, also serve this provider under /providers/<provider>; specify multiple times for multiple providers (optional)", nil, &cfg.WebhookServerProviders)
+	b.StringVar("webhook-provider-token", "The bearer token authenticating the requests of the webhook provider, checked by the webhook server when set; file:<path> reads it from a file and reloads it on change (optional)", defaultConfig.WebhookProviderToken, &cfg.WebhookProviderToken)
+	b.StringVar("webhook-server-address", "When running as a webhook server, the address to listen on (default: 127.0.0.1:8888)", defaultConfig.WebhookServerAddress, &cfg.WebhookServerAddress)
+	b.StringVar("webhook-server-tls-cert", "When running as a webhook server, serve HTTPS with this certificate file (optional, requires --webhook-server-tls-key)", defaultConfig.WebhookServerTLSCert, &cfg.WebhookServerTLSCert)
+	b.StringVar("webhook-server-tls-key", "When running as a webhook server, the key file of --webhook-server-tls-cert", defaultConfig.WebhookServerTLSKey, &cfg.WebhookServerTLSKey)
+	b.StringVar("webhook-server-tls-client-ca", "When running as a webhook server, require client certificates signed by this CA file (optional, requires --webhook-server-tls-cert)", defaultConfig.WebhookServerTLSClientCA, &cfg.WebhookServerTLSClientCA)
 
 	// FQDN Templating
 	b.BoolVar("combine-fqdn-annotation", "Combine FQDN template and Annotations instead of overwriting (default: false)", false, &cfg.CombineFQDNAndAnnotation)
@@ -708,6 +939,8 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.DurationVar("request-timeout", "[DEPRECATED: use --kube-api-request-timeout] Request timeout when calling Kubernetes APIs. 0s means no timeout", defaultConfig.RequestTimeout, &cfg.RequestTimeout)
 	b.DurationVar("kube-api-request-timeout", "Request timeout when calling Kubernetes APIs. 0s means no timeout", defaultConfig.KubeAPIRequestTimeout, &cfg.KubeAPIRequestTimeout)
 	b.IntVar("kube-api-qps", "Maximum QPS to the Kubernetes API server from this client.", defaultConfig.KubeAPIQPS, &cfg.KubeAPIQPS)
+	b.IntVar("kube-api-list-page-size", "Maximum number of objects requested per page when listing Kubernetes resources; 0 leaves the page size to the client default", defaultConfig.KubeAPIListPageSize, &cfg.KubeAPIListPageSize)
+	b.DurationVar("kube-api-watch-stale-timeout", "Report not ready on /readyz when the watch of a Kubernetes informer saw no event, resync or bookmark for this long; 0 disables the check", 0, &cfg.KubeAPIWatchStaleTimeout)
 	b.IntVar("kube-api-burst", "Maximum burst for throttle to the Kubernetes API server from this client.", defaultConfig.KubeAPIBurst, &cfg.KubeAPIBurst)
 }
 
@@ -720,8 +953,9 @@ func App(cfg *Config) *kingpin.Application {
 
 	// Kingpin-only semantics: preserve Required/PlaceHolder and enum validation
 	// that Kingpin provided before the flags were migrated into the binder.
-	providerHelp := "The DNS provider where the DNS records will be created (required, options: " + strings.Join(ProviderNames, ", ") + ")"
-	app.Flag("provider", providerHelp).Required().PlaceHolder("provider").EnumVar(&cfg.Provider, ProviderNames...)
+	// --provider is not required with --export-file, ValidateConfig checks it otherwise.
+	providerHelp := "The DNS provider where the DNS records will be created (required unless --export-file is set, options: " + strings.Join(ProviderNames, ", ") + ")"
+	app.Flag("provider", providerHelp).PlaceHolder("provider").EnumVar(&cfg.Provider, ProviderNames...)
 
 	// Reintroduce source enum/required validation in Kingpin to match previous behavior.
 	sourceHelp := "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: " + strings.Join(allowedSources, ", ") + ")"
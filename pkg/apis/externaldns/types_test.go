@@ -39,6 +39,18 @@ var (
 		RequestTimeout:                         time.Second * 30,
 		KubeAPIRequestTimeout:                  time.Second * 30,
 		KubeAPIQPS:                             int(rest.DefaultQPS),
+		KubeAPIListPageSize:                    500,
+		EventsSinks:                            []string{"kubernetes"},
+		ExportFormat:                           "json",
+		NomadAddress:                           "http://127.0.0.1:4646",
+		NomadNamespace:                         "default",
+		NomadPollInterval:                      30 * time.Second,
+		ConsulAddress:                          "http://127.0.0.1:8500",
+		EventsWebhookTimeout:                   5 * time.Second,
+		HealthCheckTimeout:                     2 * time.Second,
+		HealthCheckFailureThreshold:            3,
+		HealthCheckSuccessThreshold:            2,
+		SyncAPIMinInterval:                     10 * time.Second,
 		KubeAPIBurst:                           rest.DefaultBurst,
 		GlooNamespaces:                         []string{"gloo-system"},
 		SkipperRouteGroupVersion:               "zalando.org/v1",
@@ -48,7 +60,8 @@ var (
 		FQDNTemplate:                           nil,
 		Compatibility:                          "",
 		Provider:                               ProviderGoogle,
-		GoogleProject:                          "",
+		ProviderZoneConcurrency:                1,
+		GoogleProjects:                         nil,
 		GoogleBatchChangeSize:                  1000,
 		GoogleBatchChangeInterval:              time.Second,
 		GoogleZoneVisibility:                   "",
@@ -112,6 +125,7 @@ var (
 		UpdateEvents:                                  false,
 		LogFormat:                                     "text",
 		MetricsAddress:                                ":7979",
+		RegistryRecordsZoneLimit:                      10,
 		LogLevel:                                      logrus.InfoLevel.String(),
 		ConnectorSourceServer:                         "localhost:8080",
 		ExoscaleAPIEnvironment:                        "api",
@@ -120,7 +134,6 @@ var (
 		ExoscaleAPISecret:                             "",
 		CRDSourceAPIVersion:                           "externaldns.k8s.io/v1alpha1",
 		CRDSourceKind:                                 "DNSEndpoint",
-		ManagedDNSRecordTypes:                         []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
 		RFC2136BatchChangeSize:                        50,
 		RFC2136Host:                                   []string{""},
 		RFC2136LoadBalancingStrategy:                  "disabled",
@@ -128,6 +141,7 @@ var (
 		WebhookProviderURL:                            "http://localhost:8888",
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
+		WebhookServerAddress:                          "127.0.0.1:8888",
 		ExcludeUnschedulable:                          true,
 	}
 
@@ -137,6 +151,18 @@ var (
 		RequestTimeout:                         time.Second * 77,
 		KubeAPIRequestTimeout:                  time.Second * 77,
 		KubeAPIQPS:                             int(rest.DefaultQPS),
+		KubeAPIListPageSize:                    500,
+		EventsSinks:                            []string{"kubernetes"},
+		ExportFormat:                           "json",
+		NomadAddress:                           "http://127.0.0.1:4646",
+		NomadNamespace:                         "default",
+		NomadPollInterval:                      30 * time.Second,
+		ConsulAddress:                          "http://127.0.0.1:8500",
+		EventsWebhookTimeout:                   5 * time.Second,
+		HealthCheckTimeout:                     2 * time.Second,
+		HealthCheckFailureThreshold:            3,
+		HealthCheckSuccessThreshold:            2,
+		SyncAPIMinInterval:                     10 * time.Second,
 		KubeAPIBurst:                           rest.DefaultBurst,
 		GlooNamespaces:                         []string{"gloo-not-system", "gloo-second-system"},
 		SkipperRouteGroupVersion:               "zalando.org/v2",
@@ -150,7 +176,8 @@ var (
 		FQDNTemplate:                           []string{"{{.Name}}.service.example.com"},
 		Compatibility:                          "mate",
 		Provider:                               ProviderGoogle,
-		GoogleProject:                          "project",
+		ProviderZoneConcurrency:                1,
+		GoogleProjects:                         []string{"project"},
 		GoogleBatchChangeSize:                  100,
 		GoogleBatchChangeInterval:              time.Second * 2,
 		GoogleZoneVisibility:                   "private",
@@ -204,6 +231,7 @@ var (
 		PDNSServerID:                                  "localhost",
 		PDNSAPIKey:                                    "some-secret-key",
 		PDNSSkipTLSVerify:                             true,
+		PDNSIncreaseSOASerial:                         true,
 		TLSCA:                                         "/path/to/ca.crt",
 		TLSClientCert:                                 "/path/to/cert.pem",
 		TLSClientCertKey:                              "/path/to/key.pem",
@@ -222,6 +250,7 @@ var (
 		UpdateEvents:                                  true,
 		LogFormat:                                     "json",
 		MetricsAddress:                                "127.0.0.1:9099",
+		RegistryRecordsZoneLimit:                      10,
 		LogLevel:                                      logrus.DebugLevel.String(),
 		ConnectorSourceServer:                         "localhost:8081",
 		ExoscaleAPIEnvironment:                        "api1",
@@ -230,6 +259,7 @@ var (
 		ExoscaleAPISecret:                             "2",
 		CRDSourceAPIVersion:                           "test.k8s.io/v1alpha1",
 		CRDSourceKind:                                 "Endpoint",
+		CRDDefaultTTL:                                 5 * time.Minute,
 		NS1Endpoint:                                   "https://api.example.com/v1",
 		NS1IgnoreSSL:                                  true,
 		ManagedDNSRecordTypes:                         []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeNS},
@@ -239,6 +269,7 @@ var (
 		WebhookProviderURL:                            "http://localhost:8888",
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
+		WebhookServerAddress:                          "127.0.0.1:8888",
 		ExcludeUnschedulable:                          false,
 	}
 )
@@ -334,6 +365,7 @@ func TestParseFlags(t *testing.T) {
 				"--pdns-server-id=localhost",
 				"--pdns-api-key=some-secret-key",
 				"--pdns-skip-tls-verify",
+				"--pdns-increase-soa-serial",
 				"--oci-config-file=oci.yaml",
 				"--oci-zone-scope=PRIVATE",
 				"--oci-zones-cache-duration=30s",
@@ -396,6 +428,7 @@ func TestParseFlags(t *testing.T) {
 				"--exoscale-apisecret=2",
 				"--crd-source-apiversion=test.k8s.io/v1alpha1",
 				"--crd-source-kind=Endpoint",
+				"--crd-default-ttl=5m",
 				"--ns1-endpoint=https://api.example.com/v1",
 				"--ns1-ignoressl",
 				"--managed-record-types=A",
@@ -466,6 +499,7 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_PDNS_ID":                                           "localhost",
 				"EXTERNAL_DNS_PDNS_API_KEY":                                      "some-secret-key",
 				"EXTERNAL_DNS_PDNS_SKIP_TLS_VERIFY":                              "1",
+				"EXTERNAL_DNS_PDNS_INCREASE_SOA_SERIAL":                          "1",
 				"EXTERNAL_DNS_RDNS_ROOT_DOMAIN":                                  "lb.rancher.cloud",
 				"EXTERNAL_DNS_TLS_CA":                                            "/path/to/ca.crt",
 				"EXTERNAL_DNS_TLS_CLIENT_CERT":                                   "/path/to/cert.pem",
@@ -513,6 +547,7 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_EXOSCALE_APISECRET":                                "2",
 				"EXTERNAL_DNS_CRD_SOURCE_APIVERSION":                             "test.k8s.io/v1alpha1",
 				"EXTERNAL_DNS_CRD_SOURCE_KIND":                                   "Endpoint",
+				"EXTERNAL_DNS_CRD_DEFAULT_TTL":                                   "5m",
 				"EXTERNAL_DNS_NS1_ENDPOINT":                                      "https://api.example.com/v1",
 				"EXTERNAL_DNS_NS1_IGNORESSL":                                     "1",
 				"EXTERNAL_DNS_MANAGED_RECORD_TYPES":                              "A\nAAAA\nCNAME\nNS",
@@ -650,6 +685,8 @@ func TestParseFlagsPublishingAndFilters(t *testing.T) {
 		"--service-type-filter=NodePort",
 		"--events-emit=RecordReady",
 		"--events-emit=RecordDeleted",
+		"--events-qps=5",
+		"--events-burst=20",
 	)
 	assert.True(t, cfg.AlwaysPublishNotReadyAddresses)
 	assert.Equal(t, "key=value", cfg.AnnotationFilter)
@@ -666,6 +703,8 @@ func TestParseFlagsPublishingAndFilters(t *testing.T) {
 	assert.True(t, cfg.ResolveServiceLoadBalancerHostname)
 	assert.ElementsMatch(t, []string{"ClusterIP", "NodePort"}, cfg.ServiceTypeFilter)
 	assert.ElementsMatch(t, []string{"RecordReady", "RecordDeleted"}, cfg.EmitEvents)
+	assert.Equal(t, 5, cfg.EventsQPS)
+	assert.Equal(t, 20, cfg.EventsBurst)
 }
 
 func TestParseFlagsGateway(t *testing.T) {
@@ -736,9 +775,11 @@ func TestParseFlagsProviderCacheAndDynamoDB(t *testing.T) {
 	t.Parallel()
 	cfg := parseCfg(t,
 		"--provider-cache-time=20s",
+		"--provider-zone-concurrency=8",
 		"--dynamodb-region=us-east-2",
 	)
 	assert.Equal(t, 20*time.Second, cfg.ProviderCacheTime)
+	assert.Equal(t, 8, cfg.ProviderZoneConcurrency)
 	assert.Equal(t, "us-east-2", cfg.AWSDynamoDBRegion)
 }
 
@@ -756,6 +797,15 @@ func TestParseFlagsGoDaddy(t *testing.T) {
 	assert.True(t, cfg.GoDaddyOTE)
 }
 
+func TestParseFlagsLinode(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t,
+		"--linode-zone-tag=external-dns",
+		"--linode-zone-tag=env=prod",
+	)
+	assert.Equal(t, []string{"external-dns", "env=prod"}, cfg.LinodeZoneTags)
+}
+
 func TestParseFlagsRFC2136(t *testing.T) {
 	t.Parallel()
 	cfg := parseCfg(t,
@@ -822,11 +872,21 @@ func TestParseFlagsWebhookProvider(t *testing.T) {
 		"--webhook-provider-read-timeout=7s",
 		"--webhook-provider-write-timeout=8s",
 		"--webhook-server",
+		"--webhook-provider-token=file:/token/token",
+		"--webhook-server-address=:8888",
+		"--webhook-server-tls-cert=/tls/tls.crt",
+		"--webhook-server-tls-key=/tls/tls.key",
+		"--webhook-server-tls-client-ca=/tls/ca.crt",
 	)
 	assert.Equal(t, "http://127.0.0.1:9999", cfg.WebhookProviderURL)
 	assert.Equal(t, 7*time.Second, cfg.WebhookProviderReadTimeout)
 	assert.Equal(t, 8*time.Second, cfg.WebhookProviderWriteTimeout)
 	assert.True(t, cfg.WebhookServer)
+	assert.Equal(t, "file:/token/token", cfg.WebhookProviderToken)
+	assert.Equal(t, ":8888", cfg.WebhookServerAddress)
+	assert.Equal(t, "/tls/tls.crt", cfg.WebhookServerTLSCert)
+	assert.Equal(t, "/tls/tls.key", cfg.WebhookServerTLSKey)
+	assert.Equal(t, "/tls/ca.crt", cfg.WebhookServerTLSClientCA)
 }
 
 func TestParseFlagsMiscListeners(t *testing.T) {
@@ -835,6 +895,71 @@ func TestParseFlagsMiscListeners(t *testing.T) {
 	assert.True(t, cfg.ListenEndpointEvents)
 }
 
+func TestParseFlagsLogSampleLimit(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 0, parseCfg(t).LogSampleLimit)
+	assert.Equal(t, 5, parseCfg(t, "--log-sample-limit=5").LogSampleLimit)
+}
+
+func TestParseFlagsExport(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t)
+	assert.Empty(t, cfg.ExportFile)
+	assert.Equal(t, "json", cfg.ExportFormat)
+
+	cfg = parseCfg(t, "--export-file=/tmp/endpoints.zone", "--export-format=zone", "--export-zone-section", "--export-reload-command=rndc reload example.org")
+	assert.Equal(t, "/tmp/endpoints.zone", cfg.ExportFile)
+	assert.Equal(t, "zone", cfg.ExportFormat)
+	assert.True(t, cfg.ExportZoneSection)
+	assert.Equal(t, "rndc reload example.org", cfg.ExportReloadCommand)
+}
+
+func TestParseFlagsNomad(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t)
+	assert.Equal(t, "http://127.0.0.1:4646", cfg.NomadAddress)
+	assert.Equal(t, "default", cfg.NomadNamespace)
+	assert.Empty(t, cfg.NomadTags)
+
+	cfg = parseCfg(t, "--source=nomad", "--nomad-address=https://nomad.example.org:4646", "--nomad-token=secret", "--nomad-namespace=*", "--nomad-tag=public", "--nomad-tag=http", "--nomad-poll-interval=10s")
+	assert.Contains(t, cfg.Sources, "nomad")
+	assert.Equal(t, "https://nomad.example.org:4646", cfg.NomadAddress)
+	assert.Equal(t, "secret", cfg.NomadToken)
+	assert.Equal(t, "*", cfg.NomadNamespace)
+	assert.Equal(t, []string{"public", "http"}, cfg.NomadTags)
+	assert.Equal(t, 10*time.Second, cfg.NomadPollInterval)
+	assert.NotContains(t, cfg.Redacted().NomadToken, "secret")
+}
+
+func TestParseFlagsConsul(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t)
+	assert.Equal(t, "http://127.0.0.1:8500", cfg.ConsulAddress)
+	assert.Empty(t, cfg.ConsulDatacenter)
+	assert.Empty(t, cfg.ConsulTags)
+
+	cfg = parseCfg(t, "--source=consul", "--consul-address=https://consul.example.org:8501", "--consul-token=secret", "--consul-datacenter=dc2", "--consul-tag=public", "--consul-tag=http")
+	assert.Contains(t, cfg.Sources, "consul")
+	assert.Equal(t, "https://consul.example.org:8501", cfg.ConsulAddress)
+	assert.Equal(t, "secret", cfg.ConsulToken)
+	assert.Equal(t, "dc2", cfg.ConsulDatacenter)
+	assert.Equal(t, []string{"public", "http"}, cfg.ConsulTags)
+	assert.NotContains(t, cfg.Redacted().ConsulToken, "secret")
+}
+
+func TestParseFlagsClusterRecords(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t)
+	assert.Empty(t, cfg.ClusterName)
+	assert.False(t, cfg.RecordSharedParent)
+
+	cfg = parseCfg(t, "--cluster-name=eu", "--record-prefix={{ .ClusterName }}-", "--record-suffix=.{{ .ClusterName }}", "--record-shared-parent")
+	assert.Equal(t, "eu", cfg.ClusterName)
+	assert.Equal(t, "{{ .ClusterName }}-", cfg.RecordPrefix)
+	assert.Equal(t, ".{{ .ClusterName }}", cfg.RecordSuffix)
+	assert.True(t, cfg.RecordSharedParent)
+}
+
 // Helpers to run bindFlags + parse for each binder.
 func runWithKingpin(t *testing.T, args []string) *Config {
 	t.Helper()
@@ -940,3 +1065,24 @@ func TestIsPTRSupported(t *testing.T) {
 	cfg.ManagedDNSRecordTypes = append(cfg.ManagedDNSRecordTypes, endpoint.RecordTypePTR)
 	assert.True(t, cfg.IsPTRSupported())
 }
+
+func TestExcludedNamespacePatterns(t *testing.T) {
+	cfg := &Config{ExcludeNamespaces: []string{"kube-*, sandbox", "team-?", ""}}
+	assert.Equal(t, []string{"kube-*", "sandbox", "team-?"}, cfg.ExcludedNamespacePatterns())
+
+	cfg.ExcludeNamespaces = nil
+	assert.Empty(t, cfg.ExcludedNamespacePatterns())
+}
+
+func TestSourceTimeoutsByName(t *testing.T) {
+	cfg := &Config{SourceTimeouts: []string{"30s", "service=5s", "ingress=1m"}}
+	timeouts, err := cfg.SourceTimeoutsByName()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"": 30 * time.Second, "service": 5 * time.Second, "ingress": time.Minute}, timeouts)
+
+	for _, value := range []string{"soon", "service=", "service=0s", "-1s"} {
+		cfg.SourceTimeouts = []string{value}
+		_, err := cfg.SourceTimeoutsByName()
+		assert.ErrorContains(t, err, "invalid source timeout", value)
+	}
+}
@@ -19,12 +19,16 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"path"
+	"slices"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/registry/mapper"
 )
 
 // ValidateConfig performs validation on the Config object
@@ -47,11 +51,19 @@ func ValidateConfig(cfg *externaldns.Config) error {
 		return errors.New("txt-prefix and txt-suffix are mutual exclusive")
 	}
 
+	if err := mapper.ValidateWildcardReplacement(cfg.TXTWildcardReplacement); err != nil {
+		return fmt.Errorf("--txt-wildcard-replacement: %w", err)
+	}
+
 	_, err := labels.Parse(cfg.LabelFilter)
 	if err != nil {
 		return errors.New("--label-filter does not specify a valid label selector")
 	}
 
+	if _, err := labels.Parse(cfg.TargetServiceSelector); err != nil {
+		return errors.New("--target-service-selector does not specify a valid label selector")
+	}
+
 	if _, err := metav1.ParseToLabelSelector(cfg.AnnotationFilter); err != nil {
 		return errors.New("--annotation-filter does not specify a valid label selector")
 	}
@@ -62,6 +74,14 @@ func ValidateConfig(cfg *externaldns.Config) error {
 	if !strings.HasSuffix(cfg.AnnotationPrefix, "/") {
 		return errors.New("--annotation-prefix must end with '/'")
 	}
+	for _, prefix := range cfg.DeprecatedAnnotationPrefixes {
+		if !strings.HasSuffix(prefix, "/") {
+			return errors.New("--deprecated-annotation-prefix must end with '/'")
+		}
+		if prefix == cfg.AnnotationPrefix {
+			return errors.New("--deprecated-annotation-prefix must differ from --annotation-prefix")
+		}
+	}
 
 	if cfg.KubeAPIQPS <= 0 {
 		return errors.New("--kube-api-qps must be greater than 0")
@@ -70,6 +90,101 @@ func ValidateConfig(cfg *externaldns.Config) error {
 		return errors.New("--kube-api-burst must be greater than 0")
 	}
 
+	if cfg.StateCacheFile != "" && cfg.StateCacheConfigMap != "" {
+		return errors.New("--state-cache-file and --state-cache-configmap are mutually exclusive")
+	}
+	if cfg.StateCacheConfigMap != "" {
+		if ns, name, ok := strings.Cut(cfg.StateCacheConfigMap, "/"); !ok || ns == "" || name == "" {
+			return errors.New("--state-cache-configmap must be in namespace/name format")
+		}
+	}
+
+	if cfg.ExportFile != "" && (cfg.Diff || cfg.WebhookServer) {
+		return errors.New("--export-file cannot be combined with --diff or --webhook-server")
+	}
+	if cfg.ExportZoneSection && (cfg.ExportFile == "" || cfg.ExportFormat != "zone") {
+		return errors.New("--export-zone-section requires --export-file and --export-format=zone")
+	}
+	if cfg.ExportReloadCommand != "" && cfg.ExportFile == "" {
+		return errors.New("--export-reload-command requires --export-file")
+	}
+
+	if (cfg.RecordPrefix != "" || cfg.RecordSuffix != "" || cfg.RecordSharedParent) && cfg.ClusterName == "" {
+		return errors.New("--record-prefix, --record-suffix and --record-shared-parent require --cluster-name")
+	}
+
+	if err := validateWebhookServerProviders(cfg); err != nil {
+		return err
+	}
+
+	if err := validateWebhookServerTLS(cfg); err != nil {
+		return err
+	}
+
+	if err := validateSourceTimeouts(cfg); err != nil {
+		return err
+	}
+
+	if cfg.IntervalJitter < 0 {
+		return errors.New("--interval-jitter must not be negative")
+	}
+
+	if cfg.ChangeWindowHoldDeletes && cfg.ChangeWindow == "" {
+		return errors.New("--change-window-hold-deletes requires --change-window")
+	}
+
+	if cfg.DeleteDelay < 0 {
+		return errors.New("--delete-delay must not be negative")
+	}
+
+	if cfg.ApplyChunkSize < 0 {
+		return errors.New("--apply-chunk-size must not be negative")
+	}
+	if cfg.MaxEndpoints < 0 {
+		return errors.New("--max-endpoints must not be negative")
+	}
+	if cfg.HealthChecks {
+		if cfg.HealthCheckTimeout <= 0 {
+			return errors.New("--health-check-timeout must be positive")
+		}
+		if cfg.HealthCheckFailureThreshold < 1 || cfg.HealthCheckSuccessThreshold < 1 {
+			return errors.New("--health-check-failure-threshold and --health-check-success-threshold must be at least 1")
+		}
+	}
+
+	if cfg.RegistryRecordsZoneLimit < 0 {
+		return errors.New("--registry-records-zone-limit must not be negative")
+	}
+
+	for _, pattern := range cfg.ExcludedNamespacePatterns() {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("--exclude-namespaces pattern %q is invalid: %w", pattern, err)
+		}
+	}
+
+	for _, kind := range cfg.TargetFromKinds {
+		if kind != "configmap" && kind != "secret" {
+			return fmt.Errorf("--target-from-kind %q is not supported, expected configmap or secret", kind)
+		}
+	}
+
+	for _, kind := range cfg.GoogleZoneKinds {
+		if kind != "managed" && kind != "forwarding" && kind != "peering" {
+			return fmt.Errorf("--google-zone-kind %q is not supported, expected managed, forwarding or peering", kind)
+		}
+	}
+
+	if slices.Contains(cfg.EventsSinks, "webhook") {
+		u, err := url.Parse(cfg.EventsWebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("--events-sink=webhook requires an http or https --events-webhook-url")
+		}
+	}
+
+	if err := validateProviderEndpoint(cfg); err != nil {
+		return err
+	}
+
 	if cfg.CreatePTR && !cfg.IsPTRSupported() {
 		return errors.New("--create-ptr requires PTR in --managed-record-types")
 	}
@@ -77,6 +192,67 @@ func ValidateConfig(cfg *externaldns.Config) error {
 	return nil
 }
 
+func validateWebhookServerProviders(cfg *externaldns.Config) error {
+	if len(cfg.WebhookServerProviders) == 0 {
+		return nil
+	}
+	if !cfg.WebhookServer {
+		return errors.New("--webhook-server-provider requires --webhook-server")
+	}
+	for _, name := range cfg.WebhookServerProviders {
+		if name == externaldns.ProviderWebhook || !slices.Contains(externaldns.ProviderNames, name) {
+			return fmt.Errorf("--webhook-server-provider: unsupported provider %q", name)
+		}
+	}
+	return nil
+}
+
+func validateWebhookServerTLS(cfg *externaldns.Config) error {
+	if (cfg.WebhookServerTLSCert == "") != (cfg.WebhookServerTLSKey == "") {
+		return errors.New("--webhook-server-tls-cert and --webhook-server-tls-key must be set together")
+	}
+	if cfg.WebhookServerTLSClientCA != "" && cfg.WebhookServerTLSCert == "" {
+		return errors.New("--webhook-server-tls-client-ca requires --webhook-server-tls-cert")
+	}
+	return nil
+}
+
+// validateProviderEndpoint checks that --provider-endpoint is an http(s) URL and that the
+// configured provider accepts a custom API base URL.
+func validateProviderEndpoint(cfg *externaldns.Config) error {
+	if cfg.ProviderEndpoint == "" {
+		return nil
+	}
+	switch cfg.Provider {
+	case externaldns.ProviderCloudflare, externaldns.ProviderPDNS, externaldns.ProviderNS1:
+	default:
+		return fmt.Errorf("--provider-endpoint is not supported by provider %q", cfg.Provider)
+	}
+	u, err := url.Parse(cfg.ProviderEndpoint)
+	if err != nil {
+		return fmt.Errorf("--provider-endpoint: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("--provider-endpoint %q must be an http or https URL", cfg.ProviderEndpoint)
+	}
+	return nil
+}
+
+// validateSourceTimeouts checks that --source-timeout values parse and only name
+// configured sources.
+func validateSourceTimeouts(cfg *externaldns.Config) error {
+	timeouts, err := cfg.SourceTimeoutsByName()
+	if err != nil {
+		return fmt.Errorf("--source-timeout: %w", err)
+	}
+	for name := range timeouts {
+		if name != "" && !slices.Contains(cfg.Sources, name) {
+			return fmt.Errorf("--source-timeout names source %q which is not configured with --source", name)
+		}
+	}
+	return nil
+}
+
 func preValidateConfig(cfg *externaldns.Config) error {
 	if cfg.LogFormat != externaldns.LogFormatText && cfg.LogFormat != externaldns.LogFormatJSON {
 		return fmt.Errorf("unsupported log format: %s", cfg.LogFormat)
@@ -84,7 +260,8 @@ func preValidateConfig(cfg *externaldns.Config) error {
 	if len(cfg.Sources) == 0 {
 		return errors.New("no sources specified")
 	}
-	if cfg.Provider == "" {
+	if cfg.Provider == "" && cfg.ExportFile == "" {
+		// the export mode never builds the provider
 		return errors.New("no provider specified")
 	}
 	return nil
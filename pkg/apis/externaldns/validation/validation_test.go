@@ -18,6 +18,7 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -98,6 +99,18 @@ func TestValidateFlags(t *testing.T) {
 	cfg.AnnotationPrefix = "external-dns.kubernetes.io/"
 	require.NoError(t, ValidateConfig(cfg))
 
+	cfg = newValidConfig(t)
+	cfg.DeprecatedAnnotationPrefixes = []string{"custom.io/"}
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.DeprecatedAnnotationPrefixes = []string{"custom.io"}
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.DeprecatedAnnotationPrefixes = []string{cfg.AnnotationPrefix}
+	require.Error(t, ValidateConfig(cfg))
+
 	t.Run("kube-api-qps and kube-api-burst", func(t *testing.T) {
 		for _, tc := range []struct {
 			name    string
@@ -362,3 +375,255 @@ func TestValidateCreatePTRWithPTRManagedPasses(t *testing.T) {
 	err := ValidateConfig(cfg)
 	assert.NoError(t, err)
 }
+
+func TestValidateChangeWindowHoldDeletesRequiresChangeWindow(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ChangeWindowHoldDeletes = true
+
+	err := ValidateConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--change-window-hold-deletes requires --change-window")
+
+	cfg.ChangeWindow = "Mon-Fri 22:00-06:00 UTC"
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateWebhookServerProviders(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.WebhookServerProviders = []string{"inmemory"}
+
+	err := ValidateConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--webhook-server-provider requires --webhook-server")
+
+	cfg.WebhookServer = true
+	assert.NoError(t, ValidateConfig(cfg))
+
+	for _, name := range []string{"webhook", "unknown"} {
+		cfg.WebhookServerProviders = []string{"inmemory", name}
+		assert.ErrorContains(t, ValidateConfig(cfg), "unsupported provider", name)
+	}
+}
+
+func TestValidateWebhookServerTLS(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.WebhookServerTLSCert = "/tls/tls.crt"
+	assert.ErrorContains(t, ValidateConfig(cfg), "must be set together")
+
+	cfg.WebhookServerTLSKey = "/tls/tls.key"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.WebhookServerTLSClientCA = "/tls/ca.crt"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.WebhookServerTLSCert, cfg.WebhookServerTLSKey = "", ""
+	assert.ErrorContains(t, ValidateConfig(cfg), "requires --webhook-server-tls-cert")
+}
+
+func TestValidateStateCache(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.StateCacheFile = "/var/lib/external-dns/state.json"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.StateCacheConfigMap = "external-dns/state"
+	assert.ErrorContains(t, ValidateConfig(cfg), "mutually exclusive")
+
+	cfg.StateCacheFile = ""
+	assert.NoError(t, ValidateConfig(cfg))
+
+	for _, cm := range []string{"state", "/state", "external-dns/"} {
+		cfg.StateCacheConfigMap = cm
+		assert.ErrorContains(t, ValidateConfig(cfg), "namespace/name", cm)
+	}
+}
+
+func TestValidateExportFile(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ExportFile = "/var/lib/external-dns/endpoints.json"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Diff = true
+	assert.ErrorContains(t, ValidateConfig(cfg), "--export-file")
+
+	cfg.Diff = false
+	cfg.WebhookServer = true
+	assert.ErrorContains(t, ValidateConfig(cfg), "--export-file")
+
+	cfg.WebhookServer = false
+	cfg.ExportReloadCommand = "rndc reload example.org"
+	cfg.ExportZoneSection = true
+	assert.ErrorContains(t, ValidateConfig(cfg), "--export-format=zone")
+
+	cfg.ExportFormat = "zone"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.ExportFile = ""
+	assert.ErrorContains(t, ValidateConfig(cfg), "requires --export-file")
+}
+
+func TestValidateProviderRequired(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Provider = ""
+	assert.ErrorContains(t, ValidateConfig(cfg), "no provider specified")
+
+	cfg.ExportFile = "/var/lib/external-dns/endpoints.json"
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateClusterRecords(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.RecordSuffix = ".{{ .ClusterName }}"
+	assert.ErrorContains(t, ValidateConfig(cfg), "require --cluster-name")
+
+	cfg.ClusterName = "eu"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.RecordSuffix = ""
+	cfg.RecordSharedParent = true
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.ClusterName = ""
+	assert.ErrorContains(t, ValidateConfig(cfg), "require --cluster-name")
+}
+
+func TestValidateSourceTimeouts(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.SourceTimeouts = []string{"30s", "test-source=5s"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.SourceTimeouts = []string{"ingress=5s"}
+	assert.ErrorContains(t, ValidateConfig(cfg), "not configured with --source")
+
+	cfg.SourceTimeouts = []string{"test-source=later"}
+	assert.ErrorContains(t, ValidateConfig(cfg), "--source-timeout")
+}
+
+func TestValidateIntervalJitter(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.IntervalJitter = 30 * time.Second
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.IntervalJitter = -time.Second
+	assert.ErrorContains(t, ValidateConfig(cfg), "--interval-jitter")
+}
+
+func TestValidateDeleteDelay(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.DeleteDelay = 5 * time.Minute
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.DeleteDelay = -time.Second
+	assert.ErrorContains(t, ValidateConfig(cfg), "--delete-delay")
+}
+
+func TestValidateExcludeNamespaces(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ExcludeNamespaces = []string{"kube-*,sandbox"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.ExcludeNamespaces = []string{"team-[a"}
+	assert.ErrorContains(t, ValidateConfig(cfg), "--exclude-namespaces")
+}
+
+func TestValidateTargetServiceSelector(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.TargetServiceSelector = "external-dns.kubernetes.io/target=public"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.TargetServiceSelector = "app in (a"
+	assert.ErrorContains(t, ValidateConfig(cfg), "--target-service-selector")
+}
+
+func TestValidateApplyChunkSize(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ApplyChunkSize = 100
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.ApplyChunkSize = -1
+	assert.ErrorContains(t, ValidateConfig(cfg), "--apply-chunk-size")
+}
+
+func TestValidateMaxEndpoints(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.MaxEndpoints = 10000
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.MaxEndpoints = -1
+	assert.ErrorContains(t, ValidateConfig(cfg), "--max-endpoints")
+}
+
+func TestValidateHealthChecks(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.HealthCheckTimeout = 0
+	assert.NoError(t, ValidateConfig(cfg), "the health check flags are ignored while disabled")
+
+	cfg.HealthChecks = true
+	assert.ErrorContains(t, ValidateConfig(cfg), "--health-check-timeout")
+
+	cfg.HealthCheckTimeout = time.Second
+	cfg.HealthCheckFailureThreshold = 3
+	cfg.HealthCheckSuccessThreshold = 0
+	assert.ErrorContains(t, ValidateConfig(cfg), "--health-check-success-threshold")
+
+	cfg.HealthCheckSuccessThreshold = 2
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateRegistryRecordsZoneLimit(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.RegistryRecordsZoneLimit = 0
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.RegistryRecordsZoneLimit = -1
+	assert.ErrorContains(t, ValidateConfig(cfg), "--registry-records-zone-limit")
+}
+
+func TestValidateTargetFromKinds(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.TargetFromKinds = []string{"configmap", "secret"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.TargetFromKinds = []string{"pod"}
+	assert.ErrorContains(t, ValidateConfig(cfg), "--target-from-kind")
+}
+
+func TestValidateGoogleZoneKinds(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.GoogleZoneKinds = []string{"managed", "forwarding", "peering"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.GoogleZoneKinds = []string{"reverse"}
+	assert.ErrorContains(t, ValidateConfig(cfg), "--google-zone-kind")
+}
+
+func TestValidateProviderEndpoint(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Provider = externaldns.ProviderCloudflare
+	cfg.ProviderEndpoint = "https://sandbox.example.com/client/v4/"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.ProviderEndpoint = "sandbox.example.com"
+	assert.ErrorContains(t, ValidateConfig(cfg), "must be an http or https URL")
+
+	cfg.Provider = "test-provider"
+	cfg.ProviderEndpoint = "https://sandbox.example.com"
+	assert.ErrorContains(t, ValidateConfig(cfg), "not supported by provider")
+}
+
+func TestValidateEventsWebhookSink(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.EventsSinks = []string{"kubernetes", "webhook"}
+	assert.ErrorContains(t, ValidateConfig(cfg), "--events-webhook-url")
+
+	cfg.EventsWebhookURL = "https://hooks.example.com/services/T000/B000"
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateTXTWildcardReplacement(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.TXTWildcardReplacement = "Wildcard"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.TXTWildcardReplacement = "any.thing"
+	assert.ErrorContains(t, ValidateConfig(cfg), "--txt-wildcard-replacement")
+}
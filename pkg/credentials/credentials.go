@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentials reads provider credentials that may be rotated while
+// external-dns runs, e.g. Kubernetes Secrets mounted as files.
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	log "github.com/sirupsen/logrus"
+)
+
+// FilePrefix marks a credential value as the path of the file holding it,
+// e.g. CF_API_TOKEN="file:/etc/secrets/cloudflare/token".
+const FilePrefix = "file:"
+
+// Credential holds a secret value. Credentials read from a file are reloaded
+// whenever the file changes, so that rotated secrets are picked up without a restart.
+type Credential struct {
+	path string
+
+	mu    sync.RWMutex
+	value string
+}
+
+// Static returns a Credential that never changes.
+func Static(value string) *Credential {
+	return &Credential{value: value}
+}
+
+// Resolve returns a file Credential when value has FilePrefix and a static
+// Credential otherwise.
+func Resolve(ctx context.Context, value string) (*Credential, error) {
+	if path, ok := strings.CutPrefix(value, FilePrefix); ok {
+		return NewFileCredential(ctx, path)
+	}
+	return Static(value), nil
+}
+
+// NewFileCredential reads a Credential from path and reloads it on changes
+// until ctx is done. Leading and trailing whitespace is trimmed.
+func NewFileCredential(ctx context.Context, path string) (*Credential, error) {
+	c := &Credential{path: path}
+	if err := c.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch credential file %s: %w", path, err)
+	}
+	// Secret volumes are updated by atomically swapping a symlink in the mount
+	// directory, which is not reported on the file itself: watch the directory.
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("failed to watch credential file %s: %w", path, err)
+	}
+	go c.watch(ctx, watcher)
+
+	return c, nil
+}
+
+// Get returns the current value of the Credential.
+func (c *Credential) Get() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.value
+}
+
+func (c *Credential) watch(ctx context.Context, watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Chmod) {
+				continue
+			}
+			if err := c.reload(); err != nil {
+				// the file may be missing while the secret is being swapped,
+				// keep the previous value until the next event
+				log.Debugf("Keeping previous credential from %s: %v", c.path, err)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Warnf("Error watching credential file %s: %v", c.path, err)
+		}
+	}
+}
+
+// reload reads the Credential from its file.
+func (c *Credential) reload() error {
+	contents, err := os.ReadFile(c.path)
+	if err != nil {
+		return fmt.Errorf("failed to read credential file %s: %w", c.path, err)
+	}
+	value := strings.TrimSpace(string(contents))
+	if value == "" {
+		return fmt.Errorf("credential file %s is empty", c.path)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.value != "" && c.value != value {
+		log.Infof("Reloaded credential from %s", c.path)
+	}
+	c.value = value
+	return nil
+}
+
+// headerRoundTripper sets a header to the current value of a Credential.
+type headerRoundTripper struct {
+	header     string
+	prefix     string
+	credential *Credential
+	next       http.RoundTripper
+}
+
+// NewHeaderRoundTripper returns a RoundTripper setting header to prefix followed
+// by the current value of c on every request, e.g. "Authorization" and "Bearer ".
+// A nil next uses http.DefaultTransport.
+func NewHeaderRoundTripper(next http.RoundTripper, header, prefix string, c *Credential) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &headerRoundTripper{header: header, prefix: prefix, credential: c, next: next}
+}
+
+func (rt *headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.Header.Set(rt.header, rt.prefix+rt.credential.Get())
+	return rt.next.RoundTrip(r)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveStatic(t *testing.T) {
+	c, err := Resolve(t.Context(), "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", c.Get())
+}
+
+func TestResolveFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("secret\n"), 0o600))
+
+	c, err := Resolve(t.Context(), FilePrefix+path)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", c.Get())
+}
+
+func TestResolveFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(empty, []byte(" \n"), 0o600))
+
+	_, err := Resolve(t.Context(), FilePrefix+filepath.Join(dir, "missing"))
+	require.ErrorContains(t, err, "failed to read credential file")
+
+	_, err = Resolve(t.Context(), FilePrefix+empty)
+	require.ErrorContains(t, err, "is empty")
+}
+
+func TestFileCredentialReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o600))
+
+	c, err := NewFileCredential(t.Context(), path)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("new"), 0o600))
+	assert.Eventually(t, func() bool { return c.Get() == "new" }, 5*time.Second, 10*time.Millisecond)
+}
+
+// TestFileCredentialReloadSymlinkSwap mimics the update of a Secret volume:
+// the file is a symlink through a data directory which is atomically swapped.
+func TestFileCredentialReloadSymlinkSwap(t *testing.T) {
+	dir := t.TempDir()
+	writeSecretVersion := func(version, value string) {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, version), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, version, "token"), []byte(value), 0o600))
+		require.NoError(t, os.Symlink(version, filepath.Join(dir, "..data_tmp")))
+		require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	}
+	writeSecretVersion("v1", "old")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "token"), filepath.Join(dir, "token")))
+
+	c, err := NewFileCredential(t.Context(), filepath.Join(dir, "token"))
+	require.NoError(t, err)
+	assert.Equal(t, "old", c.Get())
+
+	writeSecretVersion("v2", "new")
+	assert.Eventually(t, func() bool { return c.Get() == "new" }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileCredentialKeepsValueOnRemoval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("secret"), 0o600))
+
+	c, err := NewFileCredential(t.Context(), path)
+	require.NoError(t, err)
+
+	require.NoError(t, os.Remove(path))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, "secret", c.Get())
+}
+
+func TestHeaderRoundTripper(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	c := Static("one")
+	client := &http.Client{Transport: NewHeaderRoundTripper(nil, "Authorization", "Bearer ", c)}
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer stale")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	c.mu.Lock()
+	c.value = "two"
+	c.mu.Unlock()
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, []string{"Bearer one", "Bearer two"}, got)
+	assert.Equal(t, "Bearer stale", req.Header.Get("Authorization"))
+}
@@ -18,15 +18,13 @@ package events
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 	eventsv1 "k8s.io/api/events/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/wait"
 	v1 "k8s.io/client-go/kubernetes/typed/events/v1"
-	"k8s.io/client-go/util/workqueue"
 
 	"sigs.k8s.io/external-dns/internal/sets"
 )
@@ -36,102 +34,196 @@ const (
 	controllerName     = "external-dns"
 	maxRetriesPerEvent = 3
 	maxQueuedEvents    = 100
+
+	// DropReasonQueueFull is reported when the event queue has no room left.
+	DropReasonQueueFull = "queue_full"
+	// DropReasonRateLimited is reported when the event rate limit is exceeded.
+	DropReasonRateLimited = "rate_limited"
 )
 
 type EventEmitter interface {
 	Add(...Event)
 }
 
+// Observer is notified about events that never reach the API server as sent,
+// so that callers can expose them as metrics.
+type Observer interface {
+	// EventDropped is called for each event dropped before being queued.
+	EventDropped(reason string)
+	// EventsAggregated is called with the number of events folded into one summary event.
+	EventsAggregated(count int)
+}
+
+// Sink delivers the events, e.g. to the Kubernetes API or a chat webhook.
+// All sinks share the reason filter, the aggregation and the rate limit of the Controller.
+type Sink interface {
+	// Run delivers the events handed over by Send until ctx is done.
+	Run(ctx context.Context)
+	// Send hands over the events of one sync. It must not block.
+	Send(events []*eventsv1.Event)
+}
+
+type noopObserver struct{}
+
+func (noopObserver) EventDropped(string)  {}
+func (noopObserver) EventsAggregated(int) {}
+
 type Controller struct {
-	client          v1.EventsV1Interface
-	queue           workqueue.TypedRateLimitingInterface[*eventsv1.Event]
-	emitEvents      sets.Set[Reason]
-	maxQueuedEvents int
-	createOpts      metav1.CreateOptions
+	emitEvents sets.Set[Reason]
+	// limiter drops events once the configured rate is exceeded, nil means unlimited
+	limiter  *rate.Limiter
+	observer Observer
+	// sinks include the Kubernetes API unless Kubernetes events are disabled
+	sinks []Sink
 }
 
 func NewEventController(client v1.EventsV1Interface, cfg *Config) (*Controller, error) {
-	queue := workqueue.NewTypedRateLimitingQueueWithConfig(
-		workqueue.DefaultTypedControllerRateLimiter[*eventsv1.Event](),
-		workqueue.TypedRateLimitingQueueConfig[*eventsv1.Event]{Name: controllerName},
-	)
-	createOpts := metav1.CreateOptions{}
-	if cfg.dryRun {
-		createOpts.DryRun = []string{metav1.DryRunAll}
+	observer := cfg.observer
+	if observer == nil {
+		observer = noopObserver{}
+	}
+	var sinks []Sink
+	if !cfg.disableKubernetes {
+		sinks = append(sinks, newKubernetesSink(client, cfg.dryRun, observer))
 	}
 	return &Controller{
-		client:          client,
-		queue:           queue,
-		emitEvents:      cfg.emitEvents,
-		maxQueuedEvents: maxQueuedEvents,
-		createOpts:      createOpts,
+		emitEvents: cfg.emitEvents,
+		limiter:    newLimiter(cfg.qps, cfg.burst),
+		observer:   observer,
+		sinks:      append(sinks, cfg.sinks...),
 	}, nil
 }
 
+func newLimiter(qps, burst int) *rate.Limiter {
+	if qps <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = qps
+	}
+	return rate.NewLimiter(rate.Limit(qps), burst)
+}
+
 func (ec *Controller) Run(ctx context.Context) {
 	if len(ec.emitEvents) == 0 {
 		return
 	}
-	go ec.run(ctx)
+	for _, sink := range ec.sinks {
+		go sink.Run(ctx)
+	}
 }
 
-func (ec *Controller) run(ctx context.Context) {
-	log.Info("event Controller started")
-	defer log.Info("event Controller terminated")
-	defer utilruntime.HandleCrash()
-	var waitGroup wait.Group
-	for range workers {
-		waitGroup.StartWithContext(ctx, func(ctx context.Context) {
-			for ec.processNextWorkItem(ctx) {
+// Add hands the events of one sync over to the sinks. Events about the same object with
+// the same reason are folded into a single summary event before the rate limit applies.
+func (ec *Controller) Add(events ...Event) {
+	dropped := 0
+	var emitted []*eventsv1.Event
+	for _, e := range ec.aggregate(events) {
+		for _, event := range e.events() {
+			if !ec.emit(event) {
+				continue
+			}
+			if ec.limiter != nil && !ec.limiter.Allow() {
+				ec.observer.EventDropped(DropReasonRateLimited)
+				dropped++
+				continue
 			}
-		})
+			emitted = append(emitted, event)
+		}
+	}
+	if len(emitted) > 0 {
+		for _, sink := range ec.sinks {
+			sink.Send(emitted)
+		}
+	}
+	if dropped > 0 {
+		log.Warnf("event rate limit exceeded, dropped %d events", dropped)
 	}
-	<-ctx.Done()
-	ec.queue.ShutDownWithDrain()
-	waitGroup.Wait()
 }
 
-func (ec *Controller) processNextWorkItem(ctx context.Context) bool {
-	event, quit := ec.queue.Get()
-	if quit {
+// emit reports whether the event has a reason configured to be emitted.
+func (ec *Controller) emit(event *eventsv1.Event) bool {
+	if !ec.emitEvents.Has(Reason(event.Reason)) {
+		log.Debugf("skipping event %s/%s/%s with reason %s as not configured to emit", event.Kind, event.Namespace, event.Name, event.Reason)
 		return false
 	}
-	defer ec.queue.Done(event)
-	_, err := ec.client.Events(event.Namespace).Create(ctx, event, ec.createOpts)
-	switch {
-	case apierrors.IsNotFound(err):
-		log.Warnf("dropping event %s/%s: namespace not found. %v", event.Namespace, event.Name, err)
-	case err != nil && ec.queue.NumRequeues(event) < maxRetriesPerEvent:
-		log.Errorf("not able to create event %s/%s, retrying. %v", event.Namespace, event.Name, err)
-		ec.queue.AddRateLimited(event)
-		return true
-	case err != nil:
-		log.Errorf("dropping event %s/%s after %d retries. %v", event.Namespace, event.Name, ec.queue.NumRequeues(event), err)
-	}
-	ec.queue.Forget(event)
 	return true
 }
 
-func (ec *Controller) Add(events ...Event) {
-	dropped := 0
-	for _, e := range events {
-		if ec.queue.Len() >= ec.maxQueuedEvents {
-			dropped++
+// aggregateKey identifies the events folded together: same object, reason and type.
+type aggregateKey struct {
+	ref    ObjectReference
+	reason Reason
+	eType  EventType
+}
+
+// aggregate returns one event per object, reason and type, in order of first appearance.
+// Events with a reason that is not emitted are left out so they do not count as aggregated.
+func (ec *Controller) aggregate(events []Event) []Event {
+	var keys []aggregateKey
+	groups := map[aggregateKey][]*Event{}
+	for i := range events {
+		e := &events[i]
+		if !ec.emitEvents.Has(e.reason) {
 			continue
 		}
-		for _, event := range e.events() {
-			ec.emit(event)
+		for _, ref := range e.refs {
+			key := aggregateKey{ref: ref, reason: e.reason, eType: e.eType}
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], e)
 		}
 	}
-	if dropped > 0 {
-		log.Warnf("event queue is full, dropped %d events", dropped)
+
+	result := make([]Event, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) == 1 {
+			e := *group[0]
+			e.refs = []ObjectReference{key.ref}
+			result = append(result, e)
+			continue
+		}
+		ec.observer.EventsAggregated(len(group))
+		result = append(result, summarize(key, group))
 	}
+	return result
 }
 
-func (ec *Controller) emit(event *eventsv1.Event) {
-	if !ec.emitEvents.Has(Reason(event.Reason)) {
-		log.Debugf("skipping event %s/%s/%s with reason %s as not configured to emit", event.Kind, event.Namespace, event.Name, event.Reason)
-		return
+// summarize builds the event reporting the counts per action of an aggregated group.
+func summarize(key aggregateKey, group []*Event) Event {
+	var actions []Action
+	counts := map[Action]int{}
+	var records []string
+	for _, e := range group {
+		if counts[e.action] == 0 {
+			actions = append(actions, e.action)
+		}
+		counts[e.action]++
+		if e.record != "" {
+			records = append(records, e.record)
+		}
+	}
+
+	parts := make([]string, 0, len(actions))
+	for _, a := range actions {
+		parts = append(parts, fmt.Sprintf("%s %d", a, counts[a]))
+	}
+	msg := fmt.Sprintf("(external-dns) %d changes: %s", len(group), strings.Join(parts, ", "))
+	if len(records) > 0 {
+		msg += "; records:" + strings.Join(records, ",")
+	}
+
+	action := ActionSync
+	if len(actions) == 1 {
+		action = actions[0]
+	}
+	return Event{
+		refs:    []ObjectReference{key.ref},
+		message: msg,
+		action:  action,
+		eType:   key.eType,
+		reason:  key.reason,
 	}
-	ec.queue.Add(event)
 }
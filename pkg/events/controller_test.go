@@ -17,6 +17,7 @@ limitations under the License.
 package events
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -84,15 +85,12 @@ users:
 	ctrl, err := NewEventController(client, cfg)
 	require.NoError(t, err)
 	require.NotNil(t, ctrl)
-	require.Empty(t, ctrl.createOpts.DryRun)
+	require.Empty(t, kubernetesSinkOf(t, ctrl).createOpts.DryRun)
 }
 
 func TestController_Run_NoEmitEvents(t *testing.T) {
-	kClient := fake.NewClientset()
-	ctrl := &Controller{
-		client:     kClient.EventsV1(),
-		emitEvents: sets.New[Reason](),
-	}
+	ctrl, err := NewEventController(fake.NewClientset().EventsV1(), &Config{emitEvents: sets.New[Reason]()})
+	require.NoError(t, err)
 
 	require.NotPanics(t, func() {
 		ctrl.Run(t.Context())
@@ -153,7 +151,7 @@ func TestController_Queue_EmitEvents(t *testing.T) {
 
 	ctrl.Add(event)
 
-	item, shutdown := ctrl.queue.Get()
+	item, shutdown := kubernetesSinkOf(t, ctrl).queue.Get()
 	require.False(t, shutdown)
 	assert.NotNil(t, item)
 
@@ -187,14 +185,14 @@ func TestController_Add(t *testing.T) {
 		ctrl, err := NewEventController(fake.NewClientset().EventsV1(), &Config{emitEvents: sets.New(RecordReady)})
 		require.NoError(t, err)
 		ctrl.Add(NewEventFromEndpoint(ep(svcRef), ActionCreate, RecordReady))
-		assert.Equal(t, 1, ctrl.queue.Len())
+		assert.Equal(t, 1, kubernetesSinkOf(t, ctrl).queue.Len())
 	})
 
 	t.Run("multiple ref objects enqueue one k8s event per ref", func(t *testing.T) {
 		ctrl, err := NewEventController(fake.NewClientset().EventsV1(), &Config{emitEvents: sets.New(RecordReady)})
 		require.NoError(t, err)
 		ctrl.Add(NewEventFromEndpoint(ep(svcRef, crdRef), ActionCreate, RecordReady))
-		assert.Equal(t, 2, ctrl.queue.Len())
+		assert.Equal(t, 2, kubernetesSinkOf(t, ctrl).queue.Len())
 	})
 }
 
@@ -208,10 +206,10 @@ func TestController_ProcessNextWorkItem(t *testing.T) {
 		})
 		ctrl, err := NewEventController(kubeClient.EventsV1(), &Config{dryRun: true})
 		require.NoError(t, err)
-		ctrl.queue.Add(&eventsv1.Event{
+		kubernetesSinkOf(t, ctrl).queue.Add(&eventsv1.Event{
 			ObjectMeta: metav1.ObjectMeta{Name: "test-event", Namespace: "default"},
 		})
-		ctrl.processNextWorkItem(t.Context())
+		kubernetesSinkOf(t, ctrl).processNextWorkItem(t.Context())
 		assert.Equal(t, []string{metav1.DryRunAll}, capturedDryRun)
 	})
 
@@ -224,12 +222,12 @@ func TestController_ProcessNextWorkItem(t *testing.T) {
 		ctrl, err := NewEventController(kubeClient.EventsV1(), &Config{emitEvents: sets.New(RecordReady)})
 		require.NoError(t, err)
 
-		ctrl.queue.Add(&eventsv1.Event{
+		kubernetesSinkOf(t, ctrl).queue.Add(&eventsv1.Event{
 			ObjectMeta: metav1.ObjectMeta{Name: "test-event", Namespace: "default"},
 		})
 		// First maxTriesPerEvent calls requeue; the final call exhausts retries and drops.
 		for range maxRetriesPerEvent + 1 {
-			ctrl.processNextWorkItem(t.Context())
+			kubernetesSinkOf(t, ctrl).processNextWorkItem(t.Context())
 		}
 		logtest.TestHelperLogContains("dropping event", hook, t)
 	})
@@ -241,12 +239,12 @@ func TestController_Emit_SkipsUnconfiguredReason(t *testing.T) {
 	ctrl, err := NewEventController(kubeClient.EventsV1(), &Config{emitEvents: sets.New(RecordReady)})
 	require.NoError(t, err)
 
-	ctrl.emit(&eventsv1.Event{
+	assert.False(t, ctrl.emit(&eventsv1.Event{
 		ObjectMeta: metav1.ObjectMeta{Name: "test-event", Namespace: "default"},
 		Reason:     string(RecordDeleted), // not in emitEvents
-	})
+	}))
 
-	assert.Equal(t, 0, ctrl.queue.Len())
+	assert.Equal(t, 0, kubernetesSinkOf(t, ctrl).queue.Len())
 	logtest.TestHelperLogContains("skipping event", hook, t)
 }
 
@@ -292,3 +290,150 @@ func TestController_ProcessNextWorkItem_RequestOnError(t *testing.T) {
 		t.Fatalf("event was not retried and delivered; only %d attempt(s) made", createAttempts)
 	}
 }
+
+// kubernetesSinkOf returns the sink creating the Kubernetes events of ctrl.
+func kubernetesSinkOf(t *testing.T, ctrl *Controller) *kubernetesSink {
+	t.Helper()
+	for _, sink := range ctrl.sinks {
+		if k, ok := sink.(*kubernetesSink); ok {
+			return k
+		}
+	}
+	t.Fatal("no Kubernetes sink")
+	return nil
+}
+
+type countingObserver struct {
+	dropped    map[string]int
+	aggregated int
+}
+
+func (o *countingObserver) EventDropped(reason string) {
+	if o.dropped == nil {
+		o.dropped = map[string]int{}
+	}
+	o.dropped[reason]++
+}
+
+func (o *countingObserver) EventsAggregated(count int) {
+	o.aggregated += count
+}
+
+func TestController_Add_Aggregates(t *testing.T) {
+	svcRef := NewObjectReferenceFromParts("Service", "v1", "default", "my-svc", "uid-svc", "service")
+	ingRef := NewObjectReferenceFromParts("Ingress", "networking.k8s.io/v1", "default", "my-ing", "uid-ing", "ingress")
+	ep := func(name string, refs ...*ObjectReference) EndpointInfo {
+		return &mockEndpointInfo{dnsName: name, recordType: "A", targets: []string{"1.2.3.4"}, refObjects: refs}
+	}
+
+	observer := &countingObserver{}
+	ctrl, err := NewEventController(fake.NewClientset().EventsV1(), &Config{
+		emitEvents: sets.New(RecordReady, RecordDeleted),
+		observer:   observer,
+	})
+	require.NoError(t, err)
+
+	ctrl.Add(
+		NewEventFromEndpoint(ep("a.example.com", svcRef), ActionCreate, RecordReady),
+		NewEventFromEndpoint(ep("b.example.com", svcRef), ActionCreate, RecordReady),
+		NewEventFromEndpoint(ep("c.example.com", svcRef), ActionUpdate, RecordReady),
+		NewEventFromEndpoint(ep("d.example.com", svcRef), ActionDelete, RecordDeleted),
+		NewEventFromEndpoint(ep("e.example.com", ingRef), ActionCreate, RecordReady),
+		NewEventFromEndpoint(ep("f.example.com", svcRef), ActionDelete, RecordError),
+	)
+
+	// svc/RecordReady is aggregated, svc/RecordDeleted and ing/RecordReady are sent as is,
+	// RecordError is not configured to be emitted.
+	require.Equal(t, 3, kubernetesSinkOf(t, ctrl).queue.Len())
+	assert.Equal(t, 3, observer.aggregated)
+
+	summary, _ := kubernetesSinkOf(t, ctrl).queue.Get()
+	assert.Equal(t, "my-svc", summary.Regarding.Name)
+	assert.Equal(t, string(ActionSync), summary.Action)
+	assert.Equal(t, "(external-dns) 3 changes: Created 2, Updated 1; records:a.example.com,b.example.com,c.example.com", summary.Note)
+
+	deleted, _ := kubernetesSinkOf(t, ctrl).queue.Get()
+	assert.Equal(t, string(ActionDelete), deleted.Action)
+	assert.Contains(t, deleted.Note, "record:d.example.com")
+}
+
+func TestController_Add_RateLimited(t *testing.T) {
+	hook := logtest.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+	observer := &countingObserver{}
+	ctrl, err := NewEventController(fake.NewClientset().EventsV1(), &Config{
+		emitEvents: sets.New(RecordReady),
+		qps:        1,
+		burst:      2,
+		observer:   observer,
+	})
+	require.NoError(t, err)
+
+	for i := range 5 {
+		ref := NewObjectReferenceFromParts("Service", "v1", "default", fmt.Sprintf("svc-%d", i), "", "service")
+		ctrl.Add(NewEvent(ref, "record created", ActionCreate, RecordReady))
+	}
+
+	assert.Equal(t, 2, kubernetesSinkOf(t, ctrl).queue.Len())
+	assert.Equal(t, map[string]int{DropReasonRateLimited: 3}, observer.dropped)
+	logtest.TestHelperLogContains("event rate limit exceeded, dropped 1 events", hook, t)
+}
+
+type recordingSink struct {
+	batches [][]*eventsv1.Event
+}
+
+func (s *recordingSink) Run(context.Context) {}
+
+func (s *recordingSink) Send(events []*eventsv1.Event) {
+	s.batches = append(s.batches, events)
+}
+
+func TestController_Add_Sinks(t *testing.T) {
+	svcRef := NewObjectReferenceFromParts("Service", "v1", "default", "my-svc", "uid-svc", "service")
+	ingRef := NewObjectReferenceFromParts("Ingress", "networking.k8s.io/v1", "default", "my-ing", "uid-ing", "ingress")
+
+	for _, kubernetes := range []bool{true, false} {
+		t.Run(fmt.Sprintf("kubernetes=%t", kubernetes), func(t *testing.T) {
+			sink := &recordingSink{}
+			cfg := NewConfig(WithEmitEvents([]string{string(RecordReady)}), WithKubernetesEvents(kubernetes), WithSinks(sink))
+			ctrl, err := NewEventController(fake.NewClientset().EventsV1(), cfg)
+			require.NoError(t, err)
+
+			ctrl.Add(
+				NewEvent(svcRef, "record created", ActionCreate, RecordReady),
+				NewEvent(ingRef, "record created", ActionCreate, RecordReady),
+				NewEvent(svcRef, "record deleted", ActionDelete, RecordDeleted),
+			)
+			ctrl.Add(NewEvent(svcRef, "record deleted", ActionDelete, RecordDeleted))
+
+			require.Len(t, sink.batches, 1, "one batch per sync, syncs without emitted events are skipped")
+			assert.Len(t, sink.batches[0], 2)
+			if kubernetes {
+				assert.Equal(t, 2, kubernetesSinkOf(t, ctrl).queue.Len())
+			} else {
+				assert.Equal(t, []Sink{sink}, ctrl.sinks)
+			}
+		})
+	}
+}
+
+func TestKubernetesSink_Send_QueueFull(t *testing.T) {
+	hook := logtest.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+	observer := &countingObserver{}
+	sink := newKubernetesSink(fake.NewClientset().EventsV1(), false, observer)
+	sink.maxQueuedEvents = 2
+
+	event := NewEvent(NewObjectReferenceFromParts("Service", "v1", "default", "my-svc", "", "service"), "record created", ActionCreate, RecordReady)
+	sink.Send(append(event.events(), event.events()...))
+	sink.Send(event.events())
+
+	assert.Equal(t, 2, sink.queue.Len())
+	assert.Equal(t, map[string]int{DropReasonQueueFull: 1}, observer.dropped)
+	logtest.TestHelperLogContains("event queue is full, dropped 1 events", hook, t)
+}
+
+func TestNewLimiter(t *testing.T) {
+	assert.Nil(t, newLimiter(0, 10))
+	assert.Equal(t, 5, newLimiter(5, 0).Burst())
+	assert.Equal(t, 20, newLimiter(5, 20).Burst())
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	v1 "k8s.io/client-go/kubernetes/typed/events/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// kubernetesSink creates the events through the Kubernetes API, retrying failed creations.
+type kubernetesSink struct {
+	client          v1.EventsV1Interface
+	queue           workqueue.TypedRateLimitingInterface[*eventsv1.Event]
+	maxQueuedEvents int
+	createOpts      metav1.CreateOptions
+	observer        Observer
+}
+
+func newKubernetesSink(client v1.EventsV1Interface, dryRun bool, observer Observer) *kubernetesSink {
+	queue := workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[*eventsv1.Event](),
+		workqueue.TypedRateLimitingQueueConfig[*eventsv1.Event]{Name: controllerName},
+	)
+	createOpts := metav1.CreateOptions{}
+	if dryRun {
+		createOpts.DryRun = []string{metav1.DryRunAll}
+	}
+	return &kubernetesSink{
+		client:          client,
+		queue:           queue,
+		maxQueuedEvents: maxQueuedEvents,
+		createOpts:      createOpts,
+		observer:        observer,
+	}
+}
+
+// Send enqueues the events, dropping them once maxQueuedEvents are waiting.
+func (s *kubernetesSink) Send(events []*eventsv1.Event) {
+	dropped := 0
+	for _, event := range events {
+		if s.queue.Len() >= s.maxQueuedEvents {
+			s.observer.EventDropped(DropReasonQueueFull)
+			dropped++
+			continue
+		}
+		s.queue.Add(event)
+	}
+	if dropped > 0 {
+		log.Warnf("event queue is full, dropped %d events", dropped)
+	}
+}
+
+// Run creates the queued events until ctx is done.
+func (s *kubernetesSink) Run(ctx context.Context) {
+	log.Info("event Controller started")
+	defer log.Info("event Controller terminated")
+	defer utilruntime.HandleCrash()
+	var waitGroup wait.Group
+	for range workers {
+		waitGroup.StartWithContext(ctx, func(ctx context.Context) {
+			for s.processNextWorkItem(ctx) {
+			}
+		})
+	}
+	<-ctx.Done()
+	s.queue.ShutDownWithDrain()
+	waitGroup.Wait()
+}
+
+func (s *kubernetesSink) processNextWorkItem(ctx context.Context) bool {
+	event, quit := s.queue.Get()
+	if quit {
+		return false
+	}
+	defer s.queue.Done(event)
+	_, err := s.client.Events(event.Namespace).Create(ctx, event, s.createOpts)
+	switch {
+	case apierrors.IsNotFound(err):
+		log.Warnf("dropping event %s/%s: namespace not found. %v", event.Namespace, event.Name, err)
+	case err != nil && s.queue.NumRequeues(event) < maxRetriesPerEvent:
+		log.Errorf("not able to create event %s/%s, retrying. %v", event.Namespace, event.Name, err)
+		s.queue.AddRateLimited(event)
+		return true
+	case err != nil:
+		log.Errorf("dropping event %s/%s after %d retries. %v", event.Namespace, event.Name, s.queue.NumRequeues(event), err)
+	}
+	s.queue.Forget(event)
+	return true
+}
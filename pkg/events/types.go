@@ -17,6 +17,7 @@ limitations under the License.
 package events
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -37,13 +38,24 @@ import (
 )
 
 const (
-	ActionCreate  Action = "Created"
-	ActionUpdate  Action = "Updated"
-	ActionDelete  Action = "Deleted"
-	ActionFailed  Action = "FailedSync"
-	RecordReady   Reason = "RecordReady"
-	RecordDeleted Reason = "RecordDeleted"
-	RecordError   Reason = "RecordError"
+	ActionCreate   Action = "Created"
+	ActionUpdate   Action = "Updated"
+	ActionDelete   Action = "Deleted"
+	ActionFailed   Action = "FailedSync"
+	ActionConflict Action = "Conflict"
+	// ActionSync is used for aggregated events summarizing several actions on one object.
+	ActionSync     Action = "Synced"
+	RecordReady    Reason = "RecordReady"
+	RecordDeleted  Reason = "RecordDeleted"
+	RecordError    Reason = "RecordError"
+	RecordConflict Reason = "RecordConflict"
+	// CustomHostnamePending is emitted by providers when a custom hostname awaits ownership or certificate validation.
+	CustomHostnamePending Reason = "CustomHostnamePending"
+	// ApexRecordDrift is emitted when an apex record drifts from its expected state, see --apex-records-file.
+	ApexRecordDrift Reason = "ApexRecordDrift"
+
+	// ChangesAnnotation holds the fields changed by an update on its Kubernetes event.
+	ChangesAnnotation = "external-dns.alpha.kubernetes.io/changes"
 
 	EventTypeNormal  EventType = EventType(apiv1.EventTypeNormal)
 	EventTypeWarning EventType = EventType(apiv1.EventTypeWarning)
@@ -70,6 +82,10 @@ type (
 		action  Action
 		eType   EventType
 		reason  Reason
+		// record is the DNS name the event is about, used when summarizing aggregated events.
+		record string
+		// changes describes the fields an update changed, see NewUpdateEventFromEndpoint.
+		changes string
 	}
 
 	// ObjectReference holds metadata about a Kubernetes object for event correlation.
@@ -80,11 +96,20 @@ type (
 		name       string
 		uid        types.UID
 		source     string
+		// created is the creation timestamp of the object.
+		created time.Time
 	}
 
 	Config struct {
 		emitEvents sets.Set[Reason]
 		dryRun     bool
+		// qps and burst configure the token bucket limiting event creation, zero qps disables it.
+		qps      int
+		burst    int
+		observer Observer
+		// disableKubernetes leaves out Kubernetes events, so only the sinks receive the events.
+		disableKubernetes bool
+		sinks             []Sink
 	}
 
 	// EndpointInfo defines the interface for endpoint data needed to create events.
@@ -118,6 +143,7 @@ func NewObjectReference(obj runtime.Object, source string) *ObjectReference {
 		name:       obj.GetName(),
 		uid:        obj.GetUID(),
 		source:     source,
+		created:    obj.GetCreationTimestamp().Time,
 	}
 }
 
@@ -134,6 +160,36 @@ func NewEvent(obj *ObjectReference, msg string, a Action, r Reason) Event {
 	}
 }
 
+// NewWarningEvent creates a Warning event attached to every non-nil ref.
+// One Kubernetes event is emitted per ref when the event is processed by the Controller.
+func NewWarningEvent(refs []*ObjectReference, msg string, a Action, r Reason) Event {
+	return newEventWithRefs(refs, msg, EventTypeWarning, a, r)
+}
+
+// NewNormalEvent creates a Normal event attached to every non-nil ref.
+func NewNormalEvent(refs []*ObjectReference, msg string, a Action, r Reason) Event {
+	return newEventWithRefs(refs, msg, EventTypeNormal, a, r)
+}
+
+func newEventWithRefs(refs []*ObjectReference, msg string, t EventType, a Action, r Reason) Event {
+	var objs []ObjectReference
+	for _, ref := range refs {
+		if ref != nil {
+			objs = append(objs, *ref)
+		}
+	}
+	if len(objs) == 0 {
+		return Event{}
+	}
+	return Event{
+		refs:    objs,
+		message: msg,
+		eType:   t,
+		action:  a,
+		reason:  r,
+	}
+}
+
 // NewEventFromEndpoint creates an Event from an EndpointInfo with formatted message.
 // All ref objects on the endpoint are stored in the event; one Kubernetes event is
 // emitted per ref when the event is processed by the Controller.
@@ -159,9 +215,23 @@ func NewEventFromEndpoint(ep EndpointInfo, a Action, r Reason) Event {
 		eType:   EventTypeNormal,
 		action:  a,
 		reason:  r,
+		record:  ep.GetDNSName(),
 	}
 }
 
+// NewUpdateEventFromEndpoint creates an Updated event like NewEventFromEndpoint, with
+// changes describing the fields the update changed. The changes are appended to the
+// message and annotated on the Kubernetes events, so the sinks report them as well.
+func NewUpdateEventFromEndpoint(ep EndpointInfo, changes string, r Reason) Event {
+	e := NewEventFromEndpoint(ep, ActionUpdate, r)
+	if len(e.refs) == 0 || changes == "" {
+		return e
+	}
+	e.message += ",changes:" + changes
+	e.changes = changes
+	return e
+}
+
 // Action returns the action associated with the event (e.g. Created, Updated, Deleted).
 func (e *Event) Action() Action {
 	return e.action
@@ -172,6 +242,11 @@ func (e *Event) Reason() Reason {
 	return e.reason
 }
 
+// Message returns the note of the event.
+func (e *Event) Message() string {
+	return e.message
+}
+
 // EventType returns the Kubernetes event type (Normal or Warning).
 func (e *Event) EventType() EventType {
 	return e.eType
@@ -221,6 +296,9 @@ func (e *Event) eventForRef(ref ObjectReference) *eventsv1.Event {
 		Note:                message,
 		Type:                string(e.eType),
 	}
+	if e.changes != "" {
+		event.Annotations = map[string]string{ChangesAnnotation: e.changes}
+	}
 	objRef := ref.objectRef()
 	event.Regarding = *objRef
 	if ref.uid != "" {
@@ -253,19 +331,51 @@ func sanitize(input string, t time.Time) string {
 	return sanitized + suffix
 }
 
-// WithDryRun returns a ConfigOption that sets dry-run mode; events are skipped when enabled.
+// WithDryRun returns a ConfigOption that sets dry-run mode; Kubernetes events are then created
+// with the DryRunAll option, so the API server validates them without persisting them.
 func WithDryRun(dryRun bool) ConfigOption {
 	return func(c *Config) {
 		c.dryRun = dryRun
 	}
 }
 
+// WithRateLimit returns a ConfigOption that limits event creation to qps events per second
+// with the given burst. A non-positive qps disables the limit; a non-positive burst defaults to qps.
+func WithRateLimit(qps, burst int) ConfigOption {
+	return func(c *Config) {
+		c.qps = qps
+		c.burst = burst
+	}
+}
+
+// WithObserver returns a ConfigOption that reports dropped and aggregated events to o.
+func WithObserver(o Observer) ConfigOption {
+	return func(c *Config) {
+		c.observer = o
+	}
+}
+
+// WithKubernetesEvents returns a ConfigOption that enables or disables the creation of Kubernetes events.
+// They are enabled by default.
+func WithKubernetesEvents(enabled bool) ConfigOption {
+	return func(c *Config) {
+		c.disableKubernetes = !enabled
+	}
+}
+
+// WithSinks returns a ConfigOption that delivers the events to sinks as well.
+func WithSinks(sinks ...Sink) ConfigOption {
+	return func(c *Config) {
+		c.sinks = append(c.sinks, sinks...)
+	}
+}
+
 func WithEmitEvents(events []string) ConfigOption {
 	return func(c *Config) {
 		if len(events) > 0 {
 			c.emitEvents = sets.New[Reason]()
 			for _, event := range events {
-				if slices.Contains([]string{string(RecordReady), string(RecordError)}, event) {
+				if slices.Contains([]string{string(RecordReady), string(RecordError), string(RecordConflict), string(CustomHostnamePending), string(ApexRecordDrift)}, event) {
 					c.emitEvents.Insert(Reason(event))
 				}
 			}
@@ -325,6 +435,11 @@ func (r *ObjectReference) UID() types.UID {
 	return r.uid
 }
 
+// CreationTimestamp returns the creation timestamp of the referenced Kubernetes object.
+func (r *ObjectReference) CreationTimestamp() time.Time {
+	return r.created
+}
+
 func (r *ObjectReference) objectRef() *apiv1.ObjectReference {
 	return &apiv1.ObjectReference{
 		Kind:       r.kind,
@@ -348,3 +463,20 @@ func NewObjectReferenceFromParts(kind, apiVersion, namespace, name string, uid t
 		source:     source,
 	}
 }
+
+type emitterContextKey struct{}
+
+// ContextWithEmitter returns a copy of ctx carrying the emitter, so that
+// components without a direct reference, e.g. providers, can emit events.
+func ContextWithEmitter(ctx context.Context, e EventEmitter) context.Context {
+	if e == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, emitterContextKey{}, e)
+}
+
+// EmitterFromContext returns the emitter stored in ctx, or nil.
+func EmitterFromContext(ctx context.Context) EventEmitter {
+	e, _ := ctx.Value(emitterContextKey{}).(EventEmitter)
+	return e
+}
@@ -225,6 +225,23 @@ func TestWithEmitEvents(t *testing.T) {
 				require.True(t, c.IsEnabled())
 			},
 		},
+		{
+			name:     "conflict event",
+			input:    []string{string(RecordConflict)},
+			expected: sets.New(RecordConflict),
+			assert: func(c *Config) {
+				require.Equal(t, sets.New(RecordConflict), c.emitEvents)
+				require.True(t, c.IsEnabled())
+			},
+		},
+		{
+			name:     "custom hostname pending event",
+			input:    []string{string(CustomHostnamePending)},
+			expected: sets.New(CustomHostnamePending),
+			assert: func(c *Config) {
+				require.Equal(t, sets.New(CustomHostnamePending), c.emitEvents)
+			},
+		},
 		{
 			name:     "invalid event",
 			input:    []string{"InvalidEvent"},
@@ -264,6 +281,50 @@ func TestWithEmitEvents(t *testing.T) {
 	}
 }
 
+func TestNewWarningEvent(t *testing.T) {
+	refA := NewObjectReferenceFromParts("Ingress", "networking.k8s.io/v1", "default", "a", "", "ingress")
+	refB := NewObjectReferenceFromParts("DNSEndpoint", "externaldns.k8s.io/v1alpha1", "default", "b", "", "crd")
+
+	event := NewWarningEvent([]*ObjectReference{refA, nil, refB}, "conflict", ActionConflict, RecordConflict)
+	require.Equal(t, EventTypeWarning, event.EventType())
+	require.Equal(t, ActionConflict, event.Action())
+	require.Equal(t, RecordConflict, event.Reason())
+
+	evs := event.events()
+	require.Len(t, evs, 2)
+	for _, ev := range evs {
+		require.Equal(t, string(EventTypeWarning), ev.Type)
+		require.Equal(t, "conflict", ev.Note)
+	}
+
+	require.Equal(t, Event{}, NewWarningEvent(nil, "conflict", ActionConflict, RecordConflict))
+}
+
+func TestNewNormalEvent(t *testing.T) {
+	ref := NewObjectReferenceFromParts("DNSEndpoint", "externaldns.k8s.io/v1alpha1", "default", "a", "", "crd")
+
+	event := NewNormalEvent([]*ObjectReference{ref}, "pending", ActionCreate, CustomHostnamePending)
+	require.Equal(t, EventTypeNormal, event.EventType())
+	require.Equal(t, CustomHostnamePending, event.Reason())
+	require.Equal(t, "pending", event.Message())
+	require.Len(t, event.events(), 1)
+
+	require.Equal(t, Event{}, NewNormalEvent(nil, "pending", ActionCreate, CustomHostnamePending))
+}
+
+func TestEmitterContext(t *testing.T) {
+	ctx := t.Context()
+	require.Nil(t, EmitterFromContext(ctx))
+	require.Equal(t, ctx, ContextWithEmitter(ctx, nil))
+
+	emitter := &fakeEmitter{}
+	require.Equal(t, emitter, EmitterFromContext(ContextWithEmitter(ctx, emitter)))
+}
+
+type fakeEmitter struct{}
+
+func (f *fakeEmitter) Add(...Event) {}
+
 // mockEndpointInfo implements EndpointInfo for testing
 type mockEndpointInfo struct {
 	dnsName    string
@@ -431,6 +492,31 @@ func TestNewEventFromEndpoint(t *testing.T) {
 	}
 }
 
+func TestNewUpdateEventFromEndpoint(t *testing.T) {
+	ep := &mockEndpointInfo{
+		dnsName:    "test.example.com",
+		recordType: "A",
+		recordTTL:  60,
+		targets:    []string{"10.0.0.2"},
+		owner:      "my-owner",
+		refObjects: []*ObjectReference{{kind: "Service", namespace: "default", name: "my-service", source: "service"}},
+	}
+
+	ev := NewUpdateEventFromEndpoint(ep, "ttl: 300 -> 60", RecordReady)
+	require.Equal(t, ActionUpdate, ev.Action())
+	require.Equal(t, RecordReady, ev.Reason())
+	assert.True(t, strings.HasSuffix(ev.Message(), "targets:10.0.0.2,changes:ttl: 300 -> 60"), ev.Message())
+	evs := ev.events()
+	require.Len(t, evs, 1)
+	assert.Equal(t, "ttl: 300 -> 60", evs[0].Annotations[ChangesAnnotation])
+
+	ev = NewUpdateEventFromEndpoint(ep, "", RecordReady)
+	assert.Equal(t, NewEventFromEndpoint(ep, ActionUpdate, RecordReady), ev)
+	assert.Nil(t, ev.events()[0].Annotations)
+
+	assert.Equal(t, Event{}, NewUpdateEventFromEndpoint(nil, "ttl: 300 -> 60", RecordReady))
+}
+
 func TestNewObjectReference(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -602,6 +688,12 @@ func TestObjectReference_Key(t *testing.T) {
 	}
 }
 
+func TestObjectReferenceCreationTimestamp(t *testing.T) {
+	created := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	ref := NewObjectReference(&apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default", CreationTimestamp: created}}, "pod")
+	assert.Equal(t, created.Time, ref.CreationTimestamp())
+}
+
 func TestWithDryRun(t *testing.T) {
 	cfg := NewConfig(WithDryRun(true))
 	assert.True(t, cfg.dryRun)
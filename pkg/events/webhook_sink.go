@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	eventsv1 "k8s.io/api/events/v1"
+)
+
+// maxQueuedBatches is the number of batches a WebhookSink holds before dropping new ones.
+const maxQueuedBatches = 10
+
+// WebhookPayload is the JSON body posted by a WebhookSink for the events of one sync.
+// Text summarizes the events one per line, so the payload can be posted to Slack
+// incoming webhooks as is.
+type WebhookPayload struct {
+	Text   string         `json:"text"`
+	Events []WebhookEvent `json:"events"`
+	// DryRun is set when the events report changes that --dry-run did not apply.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// WebhookEvent is an event in a WebhookPayload.
+type WebhookEvent struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Reason  string    `json:"reason"`
+	Action  string    `json:"action"`
+	Kind    string    `json:"kind"`
+	Name    string    `json:"name"`
+	Message string    `json:"message"`
+	// Namespace is empty for cluster-scoped objects.
+	Namespace string `json:"namespace,omitempty"`
+	// Changes describes the fields changed by an update, e.g. "ttl: 300 -> 60".
+	Changes string `json:"changes,omitempty"`
+}
+
+// WebhookSink posts the events of each sync as one JSON payload to an HTTP endpoint.
+type WebhookSink struct {
+	url     string
+	client  *http.Client
+	batches chan []*eventsv1.Event
+	dryRun  bool
+}
+
+// NewWebhookSink returns a sink posting to url, each request is cancelled after timeout.
+// With dryRun, the payloads are labelled as reporting changes that were not applied.
+func NewWebhookSink(url string, timeout time.Duration, dryRun bool) *WebhookSink {
+	return &WebhookSink{
+		url:     url,
+		client:  &http.Client{Timeout: timeout},
+		batches: make(chan []*eventsv1.Event, maxQueuedBatches),
+		dryRun:  dryRun,
+	}
+}
+
+// Send queues the events, dropping them when maxQueuedBatches batches are still waiting.
+func (s *WebhookSink) Send(events []*eventsv1.Event) {
+	select {
+	case s.batches <- events:
+	default:
+		log.Warnf("event webhook queue is full, dropped %d events", len(events))
+	}
+}
+
+// Run posts the queued batches until ctx is done.
+func (s *WebhookSink) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case events := <-s.batches:
+			if err := s.post(ctx, events); err != nil {
+				log.Errorf("dropping %d events, not able to post them to the event webhook: %v", len(events), err)
+			}
+		}
+	}
+}
+
+func (s *WebhookSink) post(ctx context.Context, events []*eventsv1.Event) error {
+	body, err := json.Marshal(newWebhookPayload(events, s.dryRun))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func newWebhookPayload(events []*eventsv1.Event, dryRun bool) WebhookPayload {
+	payload := WebhookPayload{Events: make([]WebhookEvent, 0, len(events)), DryRun: dryRun}
+	prefix := ""
+	if dryRun {
+		prefix = "[dry-run] "
+	}
+	lines := make([]string, 0, len(events))
+	for _, e := range events {
+		we := WebhookEvent{
+			Time:      e.EventTime.Time,
+			Type:      e.Type,
+			Reason:    e.Reason,
+			Action:    e.Action,
+			Kind:      e.Regarding.Kind,
+			Namespace: e.Regarding.Namespace,
+			Name:      e.Regarding.Name,
+			Message:   e.Note,
+			Changes:   e.Annotations[ChangesAnnotation],
+		}
+		payload.Events = append(payload.Events, we)
+		ref := ObjectReference{kind: we.Kind, namespace: we.Namespace, name: we.Name}
+		lines = append(lines, fmt.Sprintf("%s[%s] %s %s: %s", prefix, we.Type, we.Reason, ref.description(), we.Message))
+	}
+	payload.Text = strings.Join(lines, "\n")
+	return payload
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestWebhookSink(t *testing.T) {
+	received := make(chan WebhookPayload, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var payload WebhookPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		received <- payload
+	}))
+	defer ts.Close()
+
+	sink := NewWebhookSink(ts.URL, time.Second, false)
+	go sink.Run(t.Context())
+
+	svcRef := NewObjectReferenceFromParts("Service", "v1", "default", "my-svc", "uid-svc", "service")
+	nodeRef := NewObjectReferenceFromParts("Node", "v1", "", "node-1", "uid-node", "node")
+	created := NewEvent(svcRef, "record created", ActionCreate, RecordReady)
+	failed := NewWarningEvent([]*ObjectReference{nodeRef}, "record failed", ActionFailed, RecordError)
+	updated := Event{refs: []ObjectReference{*svcRef}, message: "record updated", action: ActionUpdate, eType: EventTypeNormal, reason: RecordReady, changes: "ttl: 300 -> 60"}
+	sink.Send(append(append(created.events(), failed.events()...), updated.events()...))
+
+	select {
+	case payload := <-received:
+		require.Len(t, payload.Events, 3)
+		assert.Equal(t, "Service", payload.Events[0].Kind)
+		assert.Equal(t, "default", payload.Events[0].Namespace)
+		assert.Equal(t, "my-svc", payload.Events[0].Name)
+		assert.Equal(t, string(RecordReady), payload.Events[0].Reason)
+		assert.Equal(t, string(ActionCreate), payload.Events[0].Action)
+		assert.Equal(t, "record created", payload.Events[0].Message)
+		assert.Empty(t, payload.Events[0].Changes)
+		assert.Equal(t, "ttl: 300 -> 60", payload.Events[2].Changes)
+		assert.Equal(t, "[Normal] RecordReady Service/default/my-svc: record created\n[Warning] RecordError Node/node-1: record failed\n[Normal] RecordReady Service/default/my-svc: record updated", payload.Text)
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("payload not posted")
+	}
+}
+
+func TestWebhookSinkQueueFull(t *testing.T) {
+	sink := NewWebhookSink("http://127.0.0.1", time.Second, false)
+	event := NewEvent(NewObjectReferenceFromParts("Service", "v1", "default", "my-svc", "", "service"), "record created", ActionCreate, RecordReady)
+	for range maxQueuedBatches + 5 {
+		sink.Send(event.events())
+	}
+	assert.Len(t, sink.batches, maxQueuedBatches)
+}
+
+func TestNewWebhookPayloadDryRun(t *testing.T) {
+	event := NewEvent(NewObjectReferenceFromParts("Service", "v1", "default", "my-svc", "", "service"), "record created", ActionCreate, RecordReady)
+
+	payload := newWebhookPayload(event.events(), true)
+	assert.True(t, payload.DryRun)
+	assert.Equal(t, "[dry-run] [Normal] RecordReady Service/default/my-svc: record created", payload.Text)
+
+	payload = newWebhookPayload(event.events(), false)
+	assert.False(t, payload.DryRun)
+	assert.Equal(t, "[Normal] RecordReady Service/default/my-svc: record created", payload.Text)
+}
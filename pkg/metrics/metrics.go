@@ -40,12 +40,13 @@ func init() {
 		Namespace: Namespace,
 		Name:      "build_info",
 		Help: fmt.Sprintf(
-			"A metric with a constant '1' value labeled with 'version' and 'revision' of %s and the 'go_version', 'os' and the 'arch' used the build.",
+			"A metric with a constant '1' value labeled with 'version', 'revision' and 'commit' of %s and the 'go_version', 'os' and the 'arch' used the build.",
 			Namespace,
 		),
 		ConstLabels: prometheus.Labels{
 			"version":    cfg.Version,
 			"revision":   version.GetRevision(),
+			"commit":     cfg.GitCommit,
 			"go_version": version.GoVersion,
 			"os":         version.GoOS,
 			"arch":       version.GoArch,
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txt
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func FuzzEncodeRoundTrip(f *testing.F) {
+	f.Add("heritage=external-dns,external-dns/owner=default")
+	f.Add("v=spf1 include:_spf.example.com -all")
+	f.Add(`say "hi" \o/`)
+	f.Add(strings.Repeat("a", 254) + "é")
+	f.Add(strings.Repeat("x", 600))
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, value string) {
+		if len(value) > 4096 {
+			t.Skip()
+		}
+		for _, str := range Split(value) {
+			if len(str) > MaxStringLength {
+				t.Errorf("Split(%q) returned a string of %d bytes", value, len(str))
+			}
+			if utf8.ValidString(value) && !utf8.ValidString(str) {
+				t.Errorf("Split(%q) split a UTF-8 character: %q", value, str)
+			}
+		}
+		if got := strings.Join(Split(value), ""); got != value {
+			t.Errorf("Split(%q) joined is %q", value, got)
+		}
+		if got := Decode(Encode(value)); got != value {
+			t.Errorf("Decode(Encode(%q)) = %q", value, got)
+		}
+	})
+}
+
+func FuzzNormalize(f *testing.F) {
+	f.Add(`"heritage=external-dns,external-dns/owner=default"`)
+	f.Add(`"v=DKIM1; k=rsa; p=MIIBIj" "ANBgkqhkiG9w0B"`)
+	f.Add(`"escaped \"quote\" \059"`)
+	f.Add(`"unterminated\"`)
+	f.Add("v=spf1 -all")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, target string) {
+		if len(target) > 4096 {
+			t.Skip()
+		}
+		// Idempotency: a target read back from a provider in the canonical form is unchanged
+		normalized := Normalize(target)
+		if again := Normalize(normalized); again != normalized {
+			t.Errorf("Normalize is not idempotent: %q -> %q -> %q", target, normalized, again)
+		}
+		if got := Decode(normalized); got != Decode(target) {
+			t.Errorf("Normalize(%q) changed the value from %q to %q", target, Decode(target), got)
+		}
+	})
+}
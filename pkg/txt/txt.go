@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package txt converts TXT record targets between the forms used by the DNS providers.
+//
+// The canonical form of a TXT target, the one read back from and written to every provider,
+// is the zone file presentation format of RFC 1035: the value is split in character strings
+// of at most 255 bytes, each quoted, with quotes and backslashes escaped, e.g.
+// `"v=spf1 -all"` or `"v=DKIM1; p=MIIBIj..." "...IDAQAB"`.
+package txt
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// MaxStringLength is the longest character string a TXT record can hold.
+const MaxStringLength = 255
+
+// Decode returns the value of a TXT target. The character strings of a target in the
+// presentation format are unescaped and joined; a target which is not entirely made of
+// quoted strings, like `v=spf1 -all`, is the value itself and is returned as is.
+func Decode(target string) string {
+	if len(target) < 2 || target[0] != '"' || target[len(target)-1] != '"' {
+		return target
+	}
+	var b strings.Builder
+	quoted := false
+	for i := 0; i < len(target); i++ {
+		c := target[i]
+		switch {
+		case !quoted && c == '"':
+			quoted = true
+		case !quoted && (c == ' ' || c == '\t'):
+		case !quoted:
+			return target
+		case c == '"':
+			quoted = false
+		case c == '\\' && i+3 < len(target) && isDecimalEscape(target[i+1:i+4]):
+			b.WriteByte((target[i+1]-'0')*100 + (target[i+2]-'0')*10 + target[i+3] - '0')
+			i += 3
+		case c == '\\' && i+1 < len(target):
+			i++
+			b.WriteByte(target[i])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if quoted {
+		return target
+	}
+	return b.String()
+}
+
+// isDecimalEscape reports whether digits are the three digits of a \DDD escape of a byte.
+func isDecimalEscape(digits string) bool {
+	for i := range 3 {
+		if digits[i] < '0' || digits[i] > '9' {
+			return false
+		}
+	}
+	return digits <= "255"
+}
+
+// Split splits a value in character strings of at most MaxStringLength bytes, never in the
+// middle of a UTF-8 encoded character. An empty value is a single empty string.
+func Split(value string) []string {
+	strs := make([]string, 0, len(value)/MaxStringLength+1)
+	for len(value) > MaxStringLength {
+		end := MaxStringLength
+		for end > 0 && !utf8.RuneStart(value[end]) {
+			end--
+		}
+		if end == 0 {
+			end = MaxStringLength
+		}
+		strs = append(strs, value[:end])
+		value = value[end:]
+	}
+	return append(strs, value)
+}
+
+// Encode returns a value in the presentation format: its character strings, quoted, with
+// quotes and backslashes escaped, and separated by a space.
+func Encode(value string) string {
+	var b strings.Builder
+	for i, str := range Split(value) {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteByte('"')
+		for j := 0; j < len(str); j++ {
+			if str[j] == '"' || str[j] == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(str[j])
+		}
+		b.WriteByte('"')
+	}
+	return b.String()
+}
+
+// Normalize returns a TXT target in the canonical form.
+func Normalize(target string) string {
+	return Encode(Decode(target))
+}
+
+// NormalizeEndpoint converts the targets of a TXT endpoint to the canonical form, so that
+// they compare equal to the targets read back from the provider. Other endpoints are left as is.
+func NormalizeEndpoint(ep *endpoint.Endpoint) {
+	if ep.RecordType != endpoint.RecordTypeTXT {
+		return
+	}
+	for i, target := range ep.Targets {
+		ep.Targets[i] = Normalize(target)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+var dkim = "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8A", 12)
+
+func TestDecode(t *testing.T) {
+	for _, tt := range []struct {
+		target string
+		want   string
+	}{
+		{target: "plain", want: "plain"},
+		{target: "v=spf1 -all", want: "v=spf1 -all"},
+		{target: `"quoted"`, want: "quoted"},
+		{target: `""`, want: ""},
+		{target: `"first" "second"`, want: "firstsecond"},
+		{target: "\"first\"\t\"second\"", want: "firstsecond"},
+		{target: `"first""second"`, want: "firstsecond"},
+		{target: `"escaped \"quote\" and \\ backslash"`, want: `escaped "quote" and \ backslash`},
+		{target: `"decimal \059 escape"`, want: "decimal ; escape"},
+		{target: `"not a decimal \256 escape"`, want: "not a decimal 256 escape"},
+		{target: `"heritage=external-dns,external-dns/owner=default"`, want: "heritage=external-dns,external-dns/owner=default"},
+		{target: `"`, want: `"`},
+		{target: `"unterminated\"`, want: `"unterminated\"`},
+		{target: `"first" and "second"`, want: `"first" and "second"`},
+		{target: `text with "quotes"`, want: `text with "quotes"`},
+	} {
+		t.Run(tt.target, func(t *testing.T) {
+			assert.Equal(t, tt.want, Decode(tt.target))
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{name: "empty value", value: "", want: []string{""}},
+		{name: "short value", value: "v=spf1 -all", want: []string{"v=spf1 -all"}},
+		{name: "value of 255 bytes", value: strings.Repeat("a", 255), want: []string{strings.Repeat("a", 255)}},
+		{name: "long value", value: dkim, want: []string{dkim[:255], dkim[255:]}},
+		{name: "multi-byte character across the limit", value: strings.Repeat("a", 254) + "éb", want: []string{strings.Repeat("a", 254), "éb"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Split(tt.value))
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "empty value", value: "", want: `""`},
+		{name: "registry value", value: "heritage=external-dns,external-dns/owner=default", want: `"heritage=external-dns,external-dns/owner=default"`},
+		{name: "quotes and backslashes", value: `say "hi" \o/`, want: `"say \"hi\" \\o/"`},
+		{name: "long value", value: dkim, want: `"` + dkim[:255] + `" "` + dkim[255:] + `"`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Encode(tt.value))
+			assert.Equal(t, tt.value, Decode(tt.want))
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, `"v=spf1 -all"`, Normalize("v=spf1 -all"))
+	assert.Equal(t, `"v=spf1 -all"`, Normalize(`"v=spf1 -all"`))
+	assert.Equal(t, `"v=spf1 -all"`, Normalize(`"v=spf1" " -all"`))
+	assert.Equal(t, `"`+dkim[:255]+`" "`+dkim[255:]+`"`, Normalize(dkim))
+}
+
+func TestNormalizeEndpoint(t *testing.T) {
+	txt := endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, "v=spf1 -all", `"heritage=external-dns"`)
+	NormalizeEndpoint(txt)
+	assert.Equal(t, endpoint.Targets{`"v=spf1 -all"`, `"heritage=external-dns"`}, txt.Targets)
+
+	cname := endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "example.com")
+	NormalizeEndpoint(cname)
+	assert.Equal(t, endpoint.Targets{"example.com"}, cname.Targets)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ChangeWindow restricts when non-urgent changes are applied. Creates and
+// updates planned outside of the window are deferred until it opens again,
+// deletions are deferred as well when HoldDeletes is set.
+//
+// A window opens on each of its days at Start and closes at End, on the next
+// day when End is not after Start, e.g. "Mon-Fri 22:00-06:00 UTC" includes
+// Saturday 02:00 but not Monday 02:00.
+type ChangeWindow struct {
+	Days        [7]bool
+	Start       time.Duration
+	End         time.Duration
+	Location    *time.Location
+	HoldDeletes bool
+}
+
+// ParseChangeWindow parses a window of the form "<days> <HH:MM>-<HH:MM> [zone]".
+// Days are a comma separated list of weekdays or weekday ranges, e.g.
+// "Mon-Fri" or "Sat,Sun". The zone is an IANA time zone name and defaults to
+// UTC. An empty spec returns a nil window.
+func ParseChangeWindow(spec string, holdDeletes bool) (*ChangeWindow, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return nil, nil //nolint:nilnil // no window configured
+	}
+	if len(fields) > 3 {
+		return nil, fmt.Errorf("invalid change window %q: expected \"<days> <HH:MM>-<HH:MM> [zone]\"", spec)
+	}
+	w := &ChangeWindow{Location: time.UTC, HoldDeletes: holdDeletes}
+	if err := w.parseDays(fields[0]); err != nil {
+		return nil, fmt.Errorf("invalid change window %q: %w", spec, err)
+	}
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("invalid change window %q: missing time range", spec)
+	}
+	start, end, ok := strings.Cut(fields[1], "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid change window %q: time range must be <HH:MM>-<HH:MM>", spec)
+	}
+	var err error
+	if w.Start, err = parseClock(start); err != nil {
+		return nil, fmt.Errorf("invalid change window %q: %w", spec, err)
+	}
+	if w.End, err = parseClock(end); err != nil {
+		return nil, fmt.Errorf("invalid change window %q: %w", spec, err)
+	}
+	if len(fields) == 3 {
+		if w.Location, err = time.LoadLocation(fields[2]); err != nil {
+			return nil, fmt.Errorf("invalid change window %q: %w", spec, err)
+		}
+	}
+	return w, nil
+}
+
+func (w *ChangeWindow) parseDays(s string) error {
+	for _, part := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		first, ok := weekdays[strings.ToLower(from)]
+		if !ok {
+			return fmt.Errorf("unknown weekday %q", from)
+		}
+		last := first
+		if isRange {
+			if last, ok = weekdays[strings.ToLower(to)]; !ok {
+				return fmt.Errorf("unknown weekday %q", to)
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			w.Days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains reports whether t falls within the window.
+func (w *ChangeWindow) Contains(t time.Time) bool {
+	t = t.In(w.Location)
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	today, yesterday := t.Weekday(), (t.Weekday()+6)%7
+	if w.Start < w.End {
+		return w.Days[today] && clock >= w.Start && clock < w.End
+	}
+	// the window wraps around midnight, or spans a whole day when start equals end
+	return (w.Days[today] && clock >= w.Start) || (w.Days[yesterday] && clock < w.End)
+}
+
+// Apply returns the changes allowed at now, the remaining changes are deferred
+// to a later synchronization. The deletions paired with a deferred create of the
+// same name, e.g. on a switch from A to CNAME, are deferred with it so that the
+// record is not removed until its replacement is created.
+func (w *ChangeWindow) Apply(changes *Changes, now time.Time) *Changes {
+	if w.Contains(now) {
+		return changes
+	}
+	result := &Changes{}
+	if w.HoldDeletes {
+		return result
+	}
+	replaced := replacedNames(changes.Create)
+	for _, ep := range changes.Delete {
+		if !replaced[nameKey(ep)] {
+			result.Delete = append(result.Delete, ep)
+		}
+	}
+	return result
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestParseChangeWindow(t *testing.T) {
+	w, err := ParseChangeWindow("", false)
+	require.NoError(t, err)
+	assert.Nil(t, w)
+
+	w, err = ParseChangeWindow("Mon-Fri 22:00-06:00 UTC", true)
+	require.NoError(t, err)
+	assert.Equal(t, [7]bool{false, true, true, true, true, true, false}, w.Days)
+	assert.Equal(t, 22*time.Hour, w.Start)
+	assert.Equal(t, 6*time.Hour, w.End)
+	assert.Equal(t, time.UTC, w.Location)
+	assert.True(t, w.HoldDeletes)
+
+	w, err = ParseChangeWindow("Fri-Mon,wed 09:30-17:00", false)
+	require.NoError(t, err)
+	assert.Equal(t, [7]bool{true, true, false, true, false, true, true}, w.Days)
+	assert.Equal(t, 9*time.Hour+30*time.Minute, w.Start)
+
+	for _, spec := range []string{
+		"Mon-Fri",
+		"Mon-Fri 22:00",
+		"Mon-Foo 22:00-06:00",
+		"Mon-Fri 25:00-06:00",
+		"Mon-Fri 22:00-06:00 Mars/Olympus",
+		"Mon-Fri 22:00-06:00 UTC extra",
+	} {
+		_, err := ParseChangeWindow(spec, false)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestChangeWindow_Contains(t *testing.T) {
+	overnight, err := ParseChangeWindow("Mon-Fri 22:00-06:00 UTC", false)
+	require.NoError(t, err)
+	daytime, err := ParseChangeWindow("Sat 09:00-17:00 Europe/Berlin", false)
+	require.NoError(t, err)
+
+	// 2026-10-12 is a Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, 12+day, hour, minute, 0, 0, time.UTC)
+	}
+	for _, tc := range []struct {
+		name   string
+		window *ChangeWindow
+		t      time.Time
+		want   bool
+	}{
+		{"monday before opening", overnight, at(0, 21, 59), false},
+		{"monday after opening", overnight, at(0, 22, 0), true},
+		{"monday early morning belongs to sunday", overnight, at(0, 2, 0), false},
+		{"tuesday early morning", overnight, at(1, 5, 59), true},
+		{"tuesday closing", overnight, at(1, 6, 0), false},
+		{"saturday early morning", overnight, at(5, 3, 0), true},
+		{"saturday night", overnight, at(5, 23, 0), false},
+		{"saturday in local time", daytime, at(5, 7, 0), true},
+		{"saturday before opening in local time", daytime, at(5, 6, 59), false},
+		{"sunday", daytime, at(6, 12, 0), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.window.Contains(tc.t))
+		})
+	}
+}
+
+func TestChangeWindow_Apply(t *testing.T) {
+	current, desired := ttlUpdate("update.example.com", 300, 600)
+	changes := &Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("create.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateOld: []*endpoint.Endpoint{current},
+		UpdateNew: []*endpoint.Endpoint{desired},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("delete.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	}
+	inside := time.Date(2026, 10, 12, 23, 0, 0, 0, time.UTC)
+	outside := time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)
+
+	w, err := ParseChangeWindow("Mon-Fri 22:00-06:00", false)
+	require.NoError(t, err)
+	assert.Same(t, changes, w.Apply(changes, inside))
+
+	applied := w.Apply(changes, outside)
+	assert.Empty(t, applied.Create)
+	assert.Empty(t, applied.UpdateOld)
+	assert.Empty(t, applied.UpdateNew)
+	assert.Equal(t, changes.Delete, applied.Delete)
+
+	w.HoldDeletes = true
+	assert.False(t, w.Apply(changes, outside).HasChanges())
+}
+
+func TestChangeWindow_ApplyDefersReplacedRecordDeletions(t *testing.T) {
+	changes := &Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.com")},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("delete.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	}
+	w, err := ParseChangeWindow("Mon-Fri 22:00-06:00", false)
+	require.NoError(t, err)
+
+	applied := w.Apply(changes, time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC))
+	assert.Empty(t, applied.Create)
+	assert.Equal(t, changes.Delete[1:], applied.Delete, "the record switching type is kept until its replacement is created")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"sync"
+	"time"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// DeleteDelayPolicy splits the application of changes in two phases spread
+// over consecutive synchronizations. Creates and updates are applied right
+// away, while a deletion is deferred until it has been planned for at least
+// Delay, e.g. so that the records of a renamed hostname have propagated to
+// resolvers before the records of the old hostname are removed.
+type DeleteDelayPolicy struct {
+	Delay time.Duration
+
+	mu sync.Mutex
+	// pending holds when each deferred deletion was first planned
+	pending map[endpoint.EndpointKey]time.Time
+}
+
+// NewDeleteDelayPolicy returns a DeleteDelayPolicy, or nil when delay is not positive.
+func NewDeleteDelayPolicy(delay time.Duration) *DeleteDelayPolicy {
+	if delay <= 0 {
+		return nil
+	}
+	return &DeleteDelayPolicy{
+		Delay:   delay,
+		pending: map[endpoint.EndpointKey]time.Time{},
+	}
+}
+
+// Apply returns the changes allowed at now, the deletions planned for less than
+// Delay are deferred to a later synchronization. Deletions no longer planned,
+// e.g. because the record is desired again, are forgotten. The deletions paired
+// with a create of the same name, e.g. on a switch from A to CNAME, are applied
+// with it: deferring them would leave both record sets, or a conflicting CNAME.
+func (p *DeleteDelayPolicy) Apply(changes *Changes, now time.Time) *Changes {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := &Changes{
+		Create:    changes.Create,
+		UpdateOld: changes.UpdateOld,
+		UpdateNew: changes.UpdateNew,
+	}
+	replaced := replacedNames(changes.Create)
+	pending := make(map[endpoint.EndpointKey]time.Time, len(changes.Delete))
+	for _, ep := range changes.Delete {
+		if replaced[nameKey(ep)] {
+			result.Delete = append(result.Delete, ep)
+			continue
+		}
+		key := ep.Key()
+		since, ok := p.pending[key]
+		if !ok {
+			since = now
+		}
+		if now.Sub(since) >= p.Delay {
+			result.Delete = append(result.Delete, ep)
+			continue
+		}
+		pending[key] = since
+	}
+	p.pending = pending
+
+	return result
+}
+
+// nameKey identifies the records of ep's name and set identifier, whatever their type.
+func nameKey(ep *endpoint.Endpoint) endpoint.EndpointKey {
+	return endpoint.EndpointKey{DNSName: ep.DNSName, SetIdentifier: ep.SetIdentifier}
+}
+
+// replacedNames returns the nameKey of the records created by creates: a deletion
+// of the same name in the same changes replaces the record by another type.
+func replacedNames(creates []*endpoint.Endpoint) map[endpoint.EndpointKey]bool {
+	names := make(map[endpoint.EndpointKey]bool, len(creates))
+	for _, ep := range creates {
+		names[nameKey(ep)] = true
+	}
+	return names
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestNewDeleteDelayPolicy(t *testing.T) {
+	assert.Nil(t, NewDeleteDelayPolicy(0))
+	assert.Nil(t, NewDeleteDelayPolicy(-time.Minute))
+	assert.NotNil(t, NewDeleteDelayPolicy(time.Minute))
+}
+
+func TestDeleteDelayPolicy_Apply(t *testing.T) {
+	created := endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	renamed := endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	removed := endpoint.NewEndpoint("gone.example.com", endpoint.RecordTypeA, "1.2.3.5")
+	start := time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)
+	p := NewDeleteDelayPolicy(5 * time.Minute)
+
+	// the creation is applied right away, the deletion is deferred
+	applied := p.Apply(&Changes{
+		Create: []*endpoint.Endpoint{created},
+		Delete: []*endpoint.Endpoint{renamed},
+	}, start)
+	assert.Equal(t, []*endpoint.Endpoint{created}, applied.Create)
+	assert.Empty(t, applied.Delete)
+
+	// the delay counts from the first synchronization planning the deletion
+	applied = p.Apply(&Changes{Delete: []*endpoint.Endpoint{renamed, removed}}, start.Add(time.Minute))
+	assert.False(t, applied.HasChanges())
+
+	applied = p.Apply(&Changes{Delete: []*endpoint.Endpoint{renamed, removed}}, start.Add(5*time.Minute))
+	assert.Equal(t, []*endpoint.Endpoint{renamed}, applied.Delete)
+
+	applied = p.Apply(&Changes{Delete: []*endpoint.Endpoint{removed}}, start.Add(6*time.Minute))
+	assert.Equal(t, []*endpoint.Endpoint{removed}, applied.Delete)
+}
+
+func TestDeleteDelayPolicy_ForgetsDeletionsNoLongerPlanned(t *testing.T) {
+	ep := endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	start := time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)
+	p := NewDeleteDelayPolicy(5 * time.Minute)
+
+	assert.Empty(t, p.Apply(&Changes{Delete: []*endpoint.Endpoint{ep}}, start).Delete)
+	// the record is desired again, then removed once more
+	assert.Empty(t, p.Apply(&Changes{}, start.Add(time.Minute)).Delete)
+	assert.Empty(t, p.Apply(&Changes{Delete: []*endpoint.Endpoint{ep}}, start.Add(6*time.Minute)).Delete)
+	assert.Equal(t, []*endpoint.Endpoint{ep}, p.Apply(&Changes{Delete: []*endpoint.Endpoint{ep}}, start.Add(11*time.Minute)).Delete)
+}
+
+func TestDeleteDelayPolicy_AppliesReplacedRecordDeletions(t *testing.T) {
+	cname := endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.com")
+	a := endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	removed := endpoint.NewEndpoint("gone.example.com", endpoint.RecordTypeA, "1.2.3.5")
+	p := NewDeleteDelayPolicy(5 * time.Minute)
+
+	applied := p.Apply(&Changes{
+		Create: []*endpoint.Endpoint{cname},
+		Delete: []*endpoint.Endpoint{a, removed},
+	}, time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, []*endpoint.Endpoint{cname}, applied.Create)
+	assert.Equal(t, []*endpoint.Endpoint{a}, applied.Delete, "the record switching type is replaced at once")
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// skipDryRunChanges drops the creates and updates of desired records in
+// dry-run mode after reporting them, so that the changes of a resource can be
+// observed before they are applied. Deletions are kept: the desired record,
+// and so its mode, is unknown once a resource is removed. The deletions of the
+// records replaced by a held back create, e.g. on a switch from A to CNAME,
+// are held back too, so that the record is not deleted without its replacement.
+func skipDryRunChanges(changes *Changes) {
+	dryRunChangesPerSync.Gauge.Reset()
+
+	if slices.ContainsFunc(changes.Create, (*endpoint.Endpoint).IsDryRun) {
+		var create []*endpoint.Endpoint
+		replaced := map[endpoint.EndpointKey]bool{}
+		for _, desired := range changes.Create {
+			if desired.IsDryRun() {
+				log.Infof("Dry run: would create %s %s with targets %v", desired.DNSName, desired.RecordType, desired.Targets)
+				dryRunChangesPerSync.AddWithLabels(1.0, desired.RecordType, "create")
+				replaced[endpoint.EndpointKey{DNSName: desired.DNSName, SetIdentifier: desired.SetIdentifier}] = true
+				continue
+			}
+			create = append(create, desired)
+		}
+		changes.Create = create
+
+		var deletes []*endpoint.Endpoint
+		for _, current := range changes.Delete {
+			if replaced[endpoint.EndpointKey{DNSName: current.DNSName, SetIdentifier: current.SetIdentifier}] {
+				log.Infof("Dry run: would delete %s %s with targets %v", current.DNSName, current.RecordType, current.Targets)
+				dryRunChangesPerSync.AddWithLabels(1.0, current.RecordType, "delete")
+				continue
+			}
+			deletes = append(deletes, current)
+		}
+		changes.Delete = deletes
+	}
+
+	if len(changes.UpdateOld) != len(changes.UpdateNew) ||
+		!slices.ContainsFunc(changes.UpdateNew, (*endpoint.Endpoint).IsDryRun) {
+		return
+	}
+	var updateOld, updateNew []*endpoint.Endpoint
+	for i, desired := range changes.UpdateNew {
+		if desired.IsDryRun() {
+			log.Infof("Dry run: would update %s %s from targets %v to %v", desired.DNSName, desired.RecordType, changes.UpdateOld[i].Targets, desired.Targets)
+			dryRunChangesPerSync.AddWithLabels(1.0, desired.RecordType, "update")
+			continue
+		}
+		updateOld = append(updateOld, changes.UpdateOld[i])
+		updateNew = append(updateNew, desired)
+	}
+	changes.UpdateOld, changes.UpdateNew = updateOld, updateNew
+}
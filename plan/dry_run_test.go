@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+func TestPlanDryRun(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("observed.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("synced.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("gone.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("observed.example.com", endpoint.RecordTypeA, "2.2.2.2").
+			WithProviderSpecific(endpoint.ProviderSpecificDryRun, "true"),
+		endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "2.2.2.2").
+			WithProviderSpecific(endpoint.ProviderSpecificDryRun, "true"),
+		endpoint.NewEndpoint("synced.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+		endpoint.NewEndpoint("created.example.com", endpoint.RecordTypeA, "2.2.2.2").
+			WithProviderSpecific(endpoint.ProviderSpecificDryRun, "false"),
+	}
+
+	changes := (&Plan{Current: current, Desired: desired, ManagedRecords: []string{endpoint.RecordTypeA}}).Calculate().Changes
+	require.Len(t, changes.Create, 1)
+	assert.Equal(t, "created.example.com", changes.Create[0].DNSName)
+	require.Len(t, changes.UpdateNew, 1)
+	require.Len(t, changes.UpdateOld, 1)
+	assert.Equal(t, "synced.example.com", changes.UpdateNew[0].DNSName)
+	require.Len(t, changes.Delete, 1)
+	assert.Equal(t, "gone.example.com", changes.Delete[0].DNSName)
+
+	for _, action := range []string{"create", "update"} {
+		testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1.0, dryRunChangesPerSync.Gauge,
+			map[string]string{"record_type": endpoint.RecordTypeA, "action": action})
+	}
+}
+
+func TestPlanDryRunUnchanged(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("observed.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("synced.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("observed.example.com", endpoint.RecordTypeA, "1.1.1.1").
+			WithProviderSpecific(endpoint.ProviderSpecificDryRun, "true"),
+		endpoint.NewEndpoint("synced.example.com", endpoint.RecordTypeA, "1.1.1.1").
+			WithProviderSpecific(endpoint.ProviderSpecificDryRun, "false"),
+	}
+
+	changes := (&Plan{Current: current, Desired: desired, ManagedRecords: []string{endpoint.RecordTypeA}}).Calculate().Changes
+	assert.False(t, changes.HasChanges(), "the dry-run property is never set on the provider records")
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0.0, dryRunChangesPerSync.Gauge,
+		map[string]string{"record_type": endpoint.RecordTypeA, "action": "update"})
+}
+
+func TestPlanDryRunTypeSwitch(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("observed.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("observed.example.com", endpoint.RecordTypeCNAME, "lb.example.com").
+			WithProviderSpecific(endpoint.ProviderSpecificDryRun, "true"),
+	}
+
+	changes := (&Plan{
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+	}).Calculate().Changes
+	assert.Empty(t, changes.Create)
+	assert.Empty(t, changes.Delete, "the record replaced by a held back create is kept")
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1.0, dryRunChangesPerSync.Gauge,
+		map[string]string{"record_type": endpoint.RecordTypeA, "action": "delete"})
+}
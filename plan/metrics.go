@@ -36,10 +36,69 @@ var (
 		},
 		[]string{"record_type", "owner", "foreign_owner", "domain"},
 	)
+
+	// unsupportedRecordsPerSync tracks desired records skipped because the provider
+	// does not support their record type.
+	unsupportedRecordsPerSync = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "skipped_records_unsupported_type_per_sync",
+			Help:      "Number of desired records skipped because the provider does not support their record type (vector).",
+		},
+		[]string{"record_type"},
+	)
+
+	// domainFilteredRecordsPerSync tracks desired records skipped because they do not
+	// match the domain filter.
+	domainFilteredRecordsPerSync = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "skipped_records_domain_filter_per_sync",
+			Help:      "Number of desired records skipped because they do not match the domain filter (vector).",
+		},
+		[]string{"record_type"},
+	)
+
+	// unmanagedLifecycleRecordsPerSync tracks desired records with an unmanaged
+	// lifecycle, and the changes planned or skipped for them.
+	unmanagedLifecycleRecordsPerSync = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "unmanaged_lifecycle_records_per_sync",
+			Help:      "Number of desired records with an unmanaged lifecycle for each record type and state (desired, create, update_skipped) (vector).",
+		},
+		[]string{"record_type", "state"},
+	)
+
+	// dryRunChangesPerSync tracks the changes of desired records in dry-run mode,
+	// planned but not applied.
+	dryRunChangesPerSync = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "dry_run_changes_per_sync",
+			Help:      "Number of changes planned but not applied because their desired record is in dry-run mode, for each record type and action (create, update, delete) (vector).",
+		},
+		[]string{"record_type", "action"},
+	)
+
+	// protectedRecordsPerSync tracks changes dropped because they touch a protected record.
+	protectedRecordsPerSync = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "skipped_records_protected_per_sync",
+			Help:      "Number of changes dropped because they touch a protected record, for each record type and action (vector).",
+		},
+		[]string{"record_type", "action"},
+	)
 )
 
 func init() {
 	metrics.RegisterMetric.MustRegister(registryOwnerMismatchPerSync)
+	metrics.RegisterMetric.MustRegister(unsupportedRecordsPerSync)
+	metrics.RegisterMetric.MustRegister(domainFilteredRecordsPerSync)
+	metrics.RegisterMetric.MustRegister(unmanagedLifecycleRecordsPerSync)
+	metrics.RegisterMetric.MustRegister(dryRunChangesPerSync)
+	metrics.RegisterMetric.MustRegister(protectedRecordsPerSync)
 }
 
 // recordOwnerMismatch increments the per-sync gauge for a single skipped record due to an
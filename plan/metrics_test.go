@@ -212,3 +212,103 @@ func TestFlushOwnerMismatch(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateSkipsUnsupportedRecordTypes(t *testing.T) {
+	desiredA := &endpoint.Endpoint{
+		DNSName:    "a.example.com",
+		Targets:    endpoint.Targets{"1.2.3.4"},
+		RecordType: endpoint.RecordTypeA,
+	}
+	desiredMX := &endpoint.Endpoint{
+		DNSName:    "mx.example.com",
+		Targets:    endpoint.Targets{"10 mail.example.com"},
+		RecordType: endpoint.RecordTypeMX,
+	}
+
+	hook := logtest.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+
+	p := &Plan{
+		Policies:         []Policy{&SyncPolicy{}},
+		Desired:          []*endpoint.Endpoint{desiredA, desiredMX},
+		ManagedRecords:   []string{endpoint.RecordTypeA, endpoint.RecordTypeMX},
+		SupportedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+	}
+
+	changes := p.Calculate().Changes
+	assert.Equal(t, []*endpoint.Endpoint{desiredA}, changes.Create)
+	logtest.TestHelperLogContains("record type MX is not supported by the provider", hook, t)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1.0, unsupportedRecordsPerSync.Gauge,
+		map[string]string{"record_type": endpoint.RecordTypeMX})
+
+	p.SupportedRecords = nil
+	assert.Len(t, p.Calculate().Changes.Create, 2, "nil supported records must not restrict the plan")
+}
+
+func TestCalculateCountsDomainFilteredRecords(t *testing.T) {
+	desiredA := &endpoint.Endpoint{
+		DNSName:    "a.example.com",
+		Targets:    endpoint.Targets{"1.2.3.4"},
+		RecordType: endpoint.RecordTypeA,
+	}
+	desiredExcluded := &endpoint.Endpoint{
+		DNSName:    "db.internal.example.com",
+		Targets:    endpoint.Targets{"1.2.3.5"},
+		RecordType: endpoint.RecordTypeA,
+	}
+
+	hook := logtest.LogsUnderTestWithLogLevel(log.DebugLevel, t)
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Desired:        []*endpoint.Endpoint{desiredA, desiredExcluded},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		DomainFilter:   endpoint.MatchAllDomainFilters{endpoint.NewDomainFilterWithExclusions([]string{"example.com"}, []string{"internal.example.com"})},
+	}
+
+	changes := p.Calculate().Changes
+	assert.Equal(t, []*endpoint.Endpoint{desiredA}, changes.Create)
+	logtest.TestHelperLogContains(`ignoring record db.internal.example.com that does not match domain filter: excluded by domain exclusion "internal.example.com"`, hook, t)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1.0, domainFilteredRecordsPerSync.Gauge,
+		map[string]string{"record_type": endpoint.RecordTypeA})
+}
+
+func TestCalculateUnmanagedLifecycle(t *testing.T) {
+	unmanaged := endpoint.ProviderSpecific{{Name: endpoint.ProviderSpecificUnmanagedLifecycle, Value: "true"}}
+	currentNS := &endpoint.Endpoint{
+		DNSName:    "sub.example.com",
+		Targets:    endpoint.Targets{"ns1.example.net"},
+		RecordType: endpoint.RecordTypeNS,
+		Labels:     map[string]string{endpoint.OwnerLabelKey: "owner"},
+	}
+	desiredNS := &endpoint.Endpoint{
+		DNSName:          "sub.example.com",
+		Targets:          endpoint.Targets{"ns2.example.net"},
+		RecordType:       endpoint.RecordTypeNS,
+		ProviderSpecific: unmanaged,
+	}
+	desiredA := &endpoint.Endpoint{
+		DNSName:          "new.example.com",
+		Targets:          endpoint.Targets{"1.2.3.4"},
+		RecordType:       endpoint.RecordTypeA,
+		ProviderSpecific: unmanaged,
+	}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{currentNS},
+		Desired:        []*endpoint.Endpoint{desiredNS, desiredA},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeNS},
+		OwnerID:        "owner",
+	}
+
+	changes := p.Calculate().Changes
+	assert.Equal(t, []*endpoint.Endpoint{desiredA}, changes.Create)
+	assert.Empty(t, changes.UpdateOld)
+	assert.Empty(t, changes.UpdateNew)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1.0, unmanagedLifecycleRecordsPerSync.Gauge,
+		map[string]string{"record_type": endpoint.RecordTypeNS, "state": "desired"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1.0, unmanagedLifecycleRecordsPerSync.Gauge,
+		map[string]string{"record_type": endpoint.RecordTypeA, "state": "create"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1.0, unmanagedLifecycleRecordsPerSync.Gauge,
+		map[string]string{"record_type": endpoint.RecordTypeNS, "state": "update_skipped"})
+}
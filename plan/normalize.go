@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Normalizer canonicalizes an endpoint before it is compared by the plan, so
+// that representation differences, e.g. the order in which a provider returns
+// the targets, do not result in changes. Normalize modifies the endpoint in place.
+type Normalizer interface {
+	Normalize(ep *endpoint.Endpoint)
+}
+
+// NormalizerFunc adapts a function to the Normalizer interface.
+type NormalizerFunc func(ep *endpoint.Endpoint)
+
+// Normalize calls f(ep).
+func (f NormalizerFunc) Normalize(ep *endpoint.Endpoint) {
+	f(ep)
+}
+
+// TrimTrailingDots removes the trailing dot of the hostname targets of an endpoint. The plan
+// already ignores the case and the trailing dot of the DNS names, and the case and the order
+// of the targets.
+var TrimTrailingDots Normalizer = NormalizerFunc(func(ep *endpoint.Endpoint) {
+	mapHostnameTargets(ep, trimTrailingDot)
+})
+
+// DefaultNormalizers returns the normalizers enabled by --normalize-endpoints.
+func DefaultNormalizers() []Normalizer {
+	return []Normalizer{TrimTrailingDots}
+}
+
+func trimTrailingDot(name string) string {
+	return strings.TrimSuffix(name, ".")
+}
+
+// mapHostnameTargets applies f to the hostnames held by the targets of the
+// record types pointing to another name: the whole target of CNAME and NS
+// records and the last field of MX and SRV records.
+func mapHostnameTargets(ep *endpoint.Endpoint, f func(string) string) {
+	switch ep.RecordType {
+	case endpoint.RecordTypeCNAME, endpoint.RecordTypeNS:
+		for i, target := range ep.Targets {
+			ep.Targets[i] = f(target)
+		}
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		for i, target := range ep.Targets {
+			fields := strings.Fields(target)
+			if len(fields) == 0 {
+				continue
+			}
+			fields[len(fields)-1] = f(fields[len(fields)-1])
+			ep.Targets[i] = strings.Join(fields, " ")
+		}
+	}
+}
+
+// normalize returns normalized copies of endpoints, recording the endpoint
+// each copy was made from in originals.
+func (p *Plan) normalize(endpoints []*endpoint.Endpoint, originals map[*endpoint.Endpoint]*endpoint.Endpoint) []*endpoint.Endpoint {
+	normalized := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		c := ep.DeepCopy()
+		for _, n := range p.Normalizers {
+			n.Normalize(c)
+		}
+		originals[c] = ep
+		normalized = append(normalized, c)
+	}
+	return normalized
+}
+
+// restoreOriginals replaces the normalized copies in changes by the endpoints
+// they were made from, so that providers apply the records as they were
+// desired and update or delete the records as they were returned. The labels
+// set while planning, e.g. the owner inherited by an update, are kept.
+func restoreOriginals(changes *Changes, originals map[*endpoint.Endpoint]*endpoint.Endpoint) {
+	for _, list := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateOld, changes.UpdateNew, changes.Delete} {
+		for i, ep := range list {
+			if original, ok := originals[ep]; ok {
+				original.Labels = ep.Labels
+				list[i] = original
+			}
+		}
+	}
+}
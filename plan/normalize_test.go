@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestDefaultNormalizers(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		ep       *endpoint.Endpoint
+		expected *endpoint.Endpoint
+	}{
+		{
+			name:     "a record",
+			ep:       endpoint.NewEndpoint("Foo.Example.com.", endpoint.RecordTypeA, "5.6.7.8", "1.2.3.4"),
+			expected: endpoint.NewEndpoint("Foo.Example.com.", endpoint.RecordTypeA, "5.6.7.8", "1.2.3.4"),
+		},
+		{
+			name:     "cname record",
+			ep:       endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeCNAME, "LB.Example.org."),
+			expected: endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeCNAME, "LB.Example.org"),
+		},
+		{
+			name:     "mx record",
+			ep:       endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "20 Mail2.Example.com.", "10 mail1.example.com"),
+			expected: endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "20 Mail2.Example.com", "10 mail1.example.com"),
+		},
+		{
+			name:     "srv record",
+			ep:       endpoint.NewEndpoint("_sip._tcp.example.com", endpoint.RecordTypeSRV, "10 5 5060 SIP.Example.com."),
+			expected: endpoint.NewEndpoint("_sip._tcp.example.com", endpoint.RecordTypeSRV, "10 5 5060 SIP.Example.com"),
+		},
+		{
+			name:     "txt record keeps its trailing dot",
+			ep:       endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, "Value."),
+			expected: endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, "Value."),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, n := range DefaultNormalizers() {
+				n.Normalize(tt.ep)
+			}
+			assert.Equal(t, tt.expected, tt.ep)
+		})
+	}
+}
+
+func TestPlanNormalizers(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.com.", endpoint.RecordTypeA, "5.6.7.8", "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner"),
+		endpoint.NewEndpoint("_sip._tcp.example.com", endpoint.RecordTypeSRV, "10 5 5060 sip.example.org.").WithLabel(endpoint.OwnerLabelKey, "owner"),
+		endpoint.NewEndpoint("baz.example.com", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.OwnerLabelKey, "owner"),
+		endpoint.NewEndpoint("gone.example.com.", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.OwnerLabelKey, "owner"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4", "5.6.7.8"),
+		endpoint.NewEndpoint("_SIP._tcp.example.com", endpoint.RecordTypeSRV, "10 5 5060 SIP.example.org"),
+		endpoint.NewEndpoint("baz.example.com", endpoint.RecordTypeA, "3.3.3.3"),
+	}
+
+	managed := []string{endpoint.RecordTypeA, endpoint.RecordTypeSRV}
+
+	// without normalizers, the trailing dot of the SRV target is planned as an update
+	changes := (&Plan{Current: current, Desired: desired, ManagedRecords: managed, OwnerID: "owner"}).Calculate().Changes
+	assert.Len(t, changes.UpdateNew, 2)
+
+	p := &Plan{
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: managed,
+		OwnerID:        "owner",
+		Normalizers:    DefaultNormalizers(),
+	}
+	changes = p.Calculate().Changes
+	require.Len(t, changes.UpdateOld, 1)
+	require.Len(t, changes.UpdateNew, 1)
+	require.Len(t, changes.Delete, 1)
+	assert.Empty(t, changes.Create)
+	// the changes hold the records as they were passed to the plan
+	assert.Same(t, current[2], changes.UpdateOld[0])
+	assert.Same(t, desired[2], changes.UpdateNew[0])
+	assert.Equal(t, "owner", changes.UpdateNew[0].Labels[endpoint.OwnerLabelKey])
+	assert.Same(t, current[3], changes.Delete[0])
+	assert.Equal(t, "_SIP._tcp.example.com", desired[1].DNSName)
+	assert.Equal(t, "10 5 5060 SIP.example.org", desired[1].Targets[0])
+}
+
+func TestPlanProviderNormalizer(t *testing.T) {
+	current := []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4")}
+	desired := []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific("quirk", "true")}
+	// e.g. a provider not returning a property it does not store
+	dropQuirk := NormalizerFunc(func(ep *endpoint.Endpoint) {
+		ep.DeleteProviderSpecificProperty("quirk")
+	})
+
+	p := &Plan{Current: current, Desired: desired, ManagedRecords: []string{endpoint.RecordTypeA}}
+	assert.True(t, p.Calculate().Changes.HasChanges())
+	p.Normalizers = []Normalizer{dropQuirk}
+	assert.False(t, p.Calculate().Changes.HasChanges())
+}
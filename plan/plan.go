@@ -25,6 +25,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/idna"
+	"sigs.k8s.io/external-dns/internal/logging"
 )
 
 // Plan can convert a list of desired and current records to a series of create,
@@ -47,10 +48,16 @@ type Plan struct {
 	ManagedRecords []string
 	// ExcludeRecords are DNS record types that will be excluded from management.
 	ExcludeRecords []string
+	// SupportedRecords are DNS record types the provider is able to manage.
+	// Desired records of other types are left out of the plan. Nil means no restriction.
+	SupportedRecords []string
 	// OwnerID of records to manage
 	OwnerID string
 	// Old owner ID we migrate from
 	OldOwnerID string
+	// Normalizers canonicalize copies of the current and desired records before they are compared.
+	// The changes hold the records as they were passed in.
+	Normalizers []Normalizer
 }
 
 // Changes holds lists of actions to be executed by dns providers
@@ -170,10 +177,25 @@ func (p *Plan) Calculate() *Plan {
 		p.DomainFilter = endpoint.MatchAllDomainFilters(nil)
 	}
 
-	for _, current := range filterRecordsForPlan(p.Current, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
+	current, desired := p.Current, p.Desired
+	var originals map[*endpoint.Endpoint]*endpoint.Endpoint
+	if len(p.Normalizers) > 0 {
+		originals = make(map[*endpoint.Endpoint]*endpoint.Endpoint, len(current)+len(desired))
+		current = p.normalize(current, originals)
+		desired = p.normalize(desired, originals)
+	}
+
+	currentRecords, _ := filterRecordsForPlan(current, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords)
+	for _, current := range currentRecords {
 		t.addCurrent(current)
 	}
-	for _, desired := range filterRecordsForPlan(p.Desired, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
+	desiredRecords, outOfDomain := filterRecordsForPlan(desired, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords)
+	countDomainFilteredRecords(outOfDomain)
+	unmanagedLifecycleRecordsPerSync.Gauge.Reset()
+	for _, desired := range p.filterSupportedRecords(desiredRecords) {
+		if desired.IsUnmanagedLifecycle() {
+			unmanagedLifecycleRecordsPerSync.AddWithLabels(1.0, desired.RecordType, "desired")
+		}
 		t.addCandidate(desired)
 	}
 
@@ -181,6 +203,15 @@ func (p *Plan) Calculate() *Plan {
 		registryOwnerMismatchPerSync.Gauge.Reset()
 	}
 	changes := p.calculateChanges(t)
+	if originals != nil {
+		restoreOriginals(changes, originals)
+	}
+	stageCreates(changes.Create, p.Policies)
+	for _, create := range changes.Create {
+		if create.IsUnmanagedLifecycle() {
+			unmanagedLifecycleRecordsPerSync.AddWithLabels(1.0, create.RecordType, "create")
+		}
+	}
 
 	// Return a minimal plan with only the fields relevant to callers.
 	// ManagedRecords is reset to the canonical defaults (A/AAAA/CNAME) —
@@ -233,10 +264,34 @@ func (p *Plan) calculateChanges(t planTable) *Changes {
 		changes.UpdateOld = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateOld)
 		changes.UpdateNew = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateNew)
 	}
+	skipUnmanagedLifecycleUpdates(changes)
+	skipDryRunChanges(changes)
 
 	return changes
 }
 
+// skipUnmanagedLifecycleUpdates drops the updates of records with an unmanaged
+// lifecycle: they are created once and left alone afterwards. Such records are
+// not owned, so they are only updated here when no owner ID is configured or
+// when they were owned before being marked.
+func skipUnmanagedLifecycleUpdates(changes *Changes) {
+	if len(changes.UpdateOld) != len(changes.UpdateNew) ||
+		!slices.ContainsFunc(changes.UpdateNew, (*endpoint.Endpoint).IsUnmanagedLifecycle) {
+		return
+	}
+	var updateOld, updateNew []*endpoint.Endpoint
+	for i, desired := range changes.UpdateNew {
+		if desired.IsUnmanagedLifecycle() {
+			log.Debugf("Skipping update of %s: its lifecycle is not managed by external-dns", desired)
+			unmanagedLifecycleRecordsPerSync.AddWithLabels(1.0, desired.RecordType, "update_skipped")
+			continue
+		}
+		updateOld = append(updateOld, changes.UpdateOld[i])
+		updateNew = append(updateNew, desired)
+	}
+	changes.UpdateOld, changes.UpdateNew = updateOld, updateNew
+}
+
 func (p *Plan) appendTakenDNSNameChanges(
 	t planTable,
 	changes *Changes,
@@ -259,7 +314,7 @@ func (p *Plan) appendTakenDNSNameChanges(
 				ownersMatch = false
 				recordOwnerMismatch(p.OwnerID, current)
 				if log.IsLevelEnabled(log.DebugLevel) {
-					log.Debugf(`Skipping endpoint %v because owner id does not match for one or more items to create, found: "%s", required: "%s"`, current, current.Labels[endpoint.OwnerLabelKey], p.OwnerID)
+					logging.Debugf(`Skipping endpoint %v because owner id does not match for one or more items to create, found: "%s", required: "%s"`, current, current.Labels[endpoint.OwnerLabelKey], p.OwnerID)
 				}
 			}
 		}
@@ -300,14 +355,20 @@ func (p *Plan) calculatePlanTableRowChanges(t planTable, key planKey, row *planT
 func (p *Plan) appendEndpointUpdates(t planTable, changes *Changes, current *endpoint.Endpoint, candidates []*endpoint.Endpoint) {
 	update := t.resolver.ResolveUpdate(current, candidates)
 
-	if shouldUpdateTTL(update, current) || targetChanged(update, current) ||
-		p.providerSpecificChanged(update, current) || p.isOldOwnerIDSetAndDifferent(current) {
+	if RecordChanged(update, current) || p.isOldOwnerIDSetAndDifferent(current) {
 		inheritOwner(current, update)
 		changes.UpdateNew = append(changes.UpdateNew, update)
 		changes.UpdateOld = append(changes.UpdateOld, current)
 	}
 }
 
+// RecordChanged returns whether the DNS data of desired differs from current: its TTL,
+// its targets or its provider specific properties. An update for which it is false
+// only changes the labels of the record, e.g. its owner, which are kept by the registry.
+func RecordChanged(desired, current *endpoint.Endpoint) bool {
+	return shouldUpdateTTL(desired, current) || targetChanged(desired, current) || providerSpecificChanged(desired, current)
+}
+
 func (p *Plan) isOldOwnerIDSetAndDifferent(current *endpoint.Endpoint) bool {
 	return p.OldOwnerID != "" && current.Labels[endpoint.OwnerLabelKey] != p.OldOwnerID
 }
@@ -333,13 +394,25 @@ func shouldUpdateTTL(desired, current *endpoint.Endpoint) bool {
 	return desired.RecordTTL != current.RecordTTL
 }
 
-func (p *Plan) providerSpecificChanged(desired, current *endpoint.Endpoint) bool {
+// planOnlyProperties are the provider specific properties read by the planner and
+// never set on the provider records: the staging TTL, which only applies to creates,
+// and the dry-run mode.
+var planOnlyProperties = []string{endpoint.ProviderSpecificStagingTTL, endpoint.ProviderSpecificDryRun}
+
+// providerSpecificChanged ignores the planOnlyProperties.
+func providerSpecificChanged(desired, current *endpoint.Endpoint) bool {
 	desiredProperties := make(map[string]endpoint.ProviderSpecificProperty, len(desired.ProviderSpecific))
 
 	for _, d := range desired.ProviderSpecific {
+		if slices.Contains(planOnlyProperties, d.Name) {
+			continue
+		}
 		desiredProperties[d.Name] = d
 	}
 	for _, c := range current.ProviderSpecific {
+		if slices.Contains(planOnlyProperties, c.Name) {
+			continue
+		}
 		if d, ok := desiredProperties[c.Name]; ok {
 			if c.Value != d.Value {
 				return true
@@ -360,13 +433,19 @@ func (p *Plan) providerSpecificChanged(desired, current *endpoint.Endpoint) bool
 // Per RFC 1034, CNAME records conflict with all other records - it is the
 // only record with this property. The behavior of the planner may need to be
 // made more sophisticated to codify this.
-func filterRecordsForPlan(records []*endpoint.Endpoint, domainFilter endpoint.MatchAllDomainFilters, managedRecords, excludeRecords []string) []*endpoint.Endpoint {
+//
+// The records ignored because they do not match the domain filter are returned separately.
+func filterRecordsForPlan(records []*endpoint.Endpoint, domainFilter endpoint.MatchAllDomainFilters, managedRecords, excludeRecords []string) ([]*endpoint.Endpoint, []*endpoint.Endpoint) {
 	filtered := make([]*endpoint.Endpoint, 0, len(records))
+	var outOfDomain []*endpoint.Endpoint
 
 	for _, record := range records {
 		// Ignore records that do not match the domain filter provided
 		if !domainFilter.Match(record.DNSName) {
-			log.Debugf("ignoring record %s that does not match domain filter", record.DNSName)
+			if log.IsLevelEnabled(log.DebugLevel) {
+				logging.Debugf("ignoring record %s that does not match domain filter: %s", record.DNSName, domainFilter.MatchExplain(record.DNSName).Reason)
+			}
+			outOfDomain = append(outOfDomain, record)
 			continue
 		}
 		if IsManagedRecord(record.RecordType, managedRecords, excludeRecords) {
@@ -374,6 +453,35 @@ func filterRecordsForPlan(records []*endpoint.Endpoint, domainFilter endpoint.Ma
 		}
 	}
 
+	return filtered, outOfDomain
+}
+
+// countDomainFilteredRecords counts the desired records skipped because they do not match the domain filter.
+func countDomainFilteredRecords(records []*endpoint.Endpoint) {
+	domainFilteredRecordsPerSync.Gauge.Reset()
+	for _, record := range records {
+		domainFilteredRecordsPerSync.AddWithLabels(1.0, record.RecordType)
+	}
+}
+
+// filterSupportedRecords removes desired records with a type the provider does not support.
+// Sending them would fail the whole batch on most providers, so they are skipped and counted instead.
+func (p *Plan) filterSupportedRecords(records []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if p.SupportedRecords == nil {
+		return records
+	}
+	unsupportedRecordsPerSync.Gauge.Reset()
+
+	filtered := make([]*endpoint.Endpoint, 0, len(records))
+	for _, record := range records {
+		if !slices.Contains(p.SupportedRecords, record.RecordType) {
+			log.Warnf("Skipping record %s: record type %s is not supported by the provider", record.DNSName, record.RecordType)
+			unsupportedRecordsPerSync.AddWithLabels(1.0, record.RecordType)
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+
 	return filtered
 }
 
@@ -1137,17 +1137,55 @@ func TestShouldUpdateProviderSpecific(tt *testing.T) {
 		},
 	} {
 		tt.Run(test.name, func(t *testing.T) {
-			plan := &Plan{
-				Current:        []*endpoint.Endpoint{test.current},
-				Desired:        []*endpoint.Endpoint{test.desired},
-				ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
-			}
-			b := plan.providerSpecificChanged(test.desired, test.current)
+			b := providerSpecificChanged(test.desired, test.current)
 			assert.Equal(t, test.shouldUpdate, b)
 		})
 	}
 }
 
+func TestRecordChanged(t *testing.T) {
+	current := &endpoint.Endpoint{
+		DNSName:    "foo.example.com",
+		RecordType: endpoint.RecordTypeA,
+		Targets:    endpoint.Targets{"1.2.3.4"},
+		RecordTTL:  300,
+		Labels:     endpoint.Labels{endpoint.OwnerLabelKey: "old"},
+	}
+	for _, test := range []struct {
+		name    string
+		desired func(ep *endpoint.Endpoint)
+		changed bool
+	}{
+		{
+			name:    "labels only",
+			desired: func(ep *endpoint.Endpoint) { ep.Labels = endpoint.Labels{endpoint.OwnerLabelKey: "new"} },
+		},
+		{
+			name:    "ttl",
+			desired: func(ep *endpoint.Endpoint) { ep.RecordTTL = 60 },
+			changed: true,
+		},
+		{
+			name:    "targets",
+			desired: func(ep *endpoint.Endpoint) { ep.Targets = endpoint.Targets{"5.6.7.8"} },
+			changed: true,
+		},
+		{
+			name: "provider specific",
+			desired: func(ep *endpoint.Endpoint) {
+				ep.ProviderSpecific = endpoint.ProviderSpecific{{Name: "alias", Value: "true"}}
+			},
+			changed: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			desired := current.DeepCopy()
+			test.desired(desired)
+			assert.Equal(t, test.changed, RecordChanged(desired, current))
+		})
+	}
+}
+
 func TestOwnerMismatchLogsDebug(t *testing.T) {
 	const wantMsg = "owner id does not match"
 
@@ -16,6 +16,8 @@ limitations under the License.
 
 package plan
 
+import "sigs.k8s.io/external-dns/endpoint"
+
 // Policy allows to apply different rules to a set of changes.
 type Policy interface {
 	Apply(changes *Changes) *Changes
@@ -26,6 +28,7 @@ var Policies = map[string]Policy{
 	"sync":        &SyncPolicy{},
 	"upsert-only": &UpsertOnlyPolicy{},
 	"create-only": &CreateOnlyPolicy{},
+	"delete-only": &DeleteOnlyPolicy{},
 }
 
 // SyncPolicy allows for full synchronization of DNS records.
@@ -57,3 +60,30 @@ func (p *CreateOnlyPolicy) Apply(changes *Changes) *Changes {
 		Create: changes.Create,
 	}
 }
+
+// DeleteOnlyPolicy allows only deleting orphaned DNS records, e.g. to clean up after a migration.
+type DeleteOnlyPolicy struct{}
+
+// Apply applies the delete-only policy which strips out creations and updates, and the
+// deletions of records replaced by a creation, like a record changing type, so that no
+// name still desired loses its records.
+func (p *DeleteOnlyPolicy) Apply(changes *Changes) *Changes {
+	replaced := make(map[string]struct{}, len(changes.Create))
+	for _, ep := range changes.Create {
+		replaced[orphanKey(ep)] = struct{}{}
+	}
+	deletes := make([]*endpoint.Endpoint, 0, len(changes.Delete))
+	for _, ep := range changes.Delete {
+		if _, ok := replaced[orphanKey(ep)]; !ok {
+			deletes = append(deletes, ep)
+		}
+	}
+	return &Changes{
+		Delete: deletes,
+	}
+}
+
+// orphanKey identifies the records of a name, whatever their type.
+func orphanKey(ep *endpoint.Endpoint) string {
+	return ep.DNSName + "/" + ep.SetIdentifier
+}
@@ -34,6 +34,10 @@ func TestApply(t *testing.T) {
 	// another two simple entries
 	bar := []*endpoint.Endpoint{{DNSName: "bar", Targets: endpoint.Targets{"v1"}}}
 	baz := []*endpoint.Endpoint{{DNSName: "baz", Targets: endpoint.Targets{"v1"}}}
+	// bar changing type, replacing its A record
+	barCNAME := []*endpoint.Endpoint{{DNSName: "bar", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"baz"}}}
+	// bar with a set identifier, not replaced by barCNAME
+	barWeighted := []*endpoint.Endpoint{{DNSName: "bar", SetIdentifier: "weighted", Targets: endpoint.Targets{"v1"}}}
 
 	for _, tc := range []struct {
 		policy   Policy
@@ -58,6 +62,18 @@ func TestApply(t *testing.T) {
 			&Changes{Create: baz, UpdateOld: fooV1, UpdateNew: fooV2, Delete: bar},
 			&Changes{Create: baz, UpdateOld: empty, UpdateNew: empty, Delete: empty},
 		},
+		{
+			// DeleteOnlyPolicy clears the list of creations and updates.
+			&DeleteOnlyPolicy{},
+			&Changes{Create: baz, UpdateOld: fooV1, UpdateNew: fooV2, Delete: bar},
+			&Changes{Create: empty, UpdateOld: empty, UpdateNew: empty, Delete: bar},
+		},
+		{
+			// DeleteOnlyPolicy keeps the records replaced by a creation.
+			&DeleteOnlyPolicy{},
+			&Changes{Create: barCNAME, Delete: append(bar, barWeighted...)},
+			&Changes{Create: empty, UpdateOld: empty, UpdateNew: empty, Delete: barWeighted},
+		},
 	} {
 		// apply policy
 		changes := tc.policy.Apply(tc.changes)
@@ -75,6 +91,7 @@ func TestPolicies(t *testing.T) {
 	validatePolicy(t, Policies["sync"], &SyncPolicy{})
 	validatePolicy(t, Policies["upsert-only"], &UpsertOnlyPolicy{})
 	validatePolicy(t, Policies["create-only"], &CreateOnlyPolicy{})
+	validatePolicy(t, Policies["delete-only"], &DeleteOnlyPolicy{})
 }
 
 // validatePolicy validates that a given policy is of the given type.
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/idna"
+)
+
+// protectedRegexPrefix marks a protected records entry as a regular expression.
+const protectedRegexPrefix = "regex:"
+
+// ProtectedRecordsPolicy drops every change touching a protected DNS name,
+// whoever owns the record. It is applied on top of the configured policy.
+type ProtectedRecordsPolicy struct {
+	names   map[string]struct{}
+	regexes []*regexp.Regexp
+}
+
+// ParseProtectedRecords parses the protected records from the entries and the
+// lines of file, when set. An entry is an exact DNS name, or a regular
+// expression matched against DNS names when prefixed with "regex:". Empty lines
+// and lines starting with "#" are ignored. No entries return a nil policy.
+func ParseProtectedRecords(entries []string, file string) (*ProtectedRecordsPolicy, error) {
+	if file != "" {
+		lines, err := readProtectedRecordsFile(file)
+		if err != nil {
+			return nil, err
+		}
+		entries = slices.Concat(entries, lines)
+	}
+
+	p := &ProtectedRecordsPolicy{names: map[string]struct{}{}}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		if expr, ok := strings.CutPrefix(entry, protectedRegexPrefix); ok {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid protected records regular expression %q: %w", expr, err)
+			}
+			p.regexes = append(p.regexes, re)
+			continue
+		}
+		p.names[normalizeProtectedName(entry)] = struct{}{}
+	}
+	if len(p.names) == 0 && len(p.regexes) == 0 {
+		return nil, nil //nolint:nilnil // no protected records configured
+	}
+	return p, nil
+}
+
+func readProtectedRecordsFile(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading protected records: %w", err)
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading protected records: %w", err)
+	}
+	return lines, nil
+}
+
+func normalizeProtectedName(name string) string {
+	return strings.TrimSuffix(idna.NormalizeDNSName(name), ".")
+}
+
+// Protects reports whether the DNS name is protected.
+func (p *ProtectedRecordsPolicy) Protects(name string) bool {
+	name = normalizeProtectedName(name)
+	if _, ok := p.names[name]; ok {
+		return true
+	}
+	for _, re := range p.regexes {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply drops the creates, updates and deletes of protected DNS names. An
+// update is dropped when either its current or desired record is protected.
+func (p *ProtectedRecordsPolicy) Apply(changes *Changes) *Changes {
+	protectedRecordsPerSync.Gauge.Reset()
+	filtered := &Changes{
+		Create: p.filter(changes.Create, "create"),
+		Delete: p.filter(changes.Delete, "delete"),
+	}
+	paired := len(changes.UpdateOld) == len(changes.UpdateNew)
+	for i, desired := range changes.UpdateNew {
+		if p.Protects(desired.DNSName) || paired && p.Protects(changes.UpdateOld[i].DNSName) {
+			p.report(desired, "update")
+			if !paired {
+				// the updates cannot be paired, drop them all rather than letting one through
+				filtered.UpdateOld, filtered.UpdateNew = nil, nil
+				return filtered
+			}
+			continue
+		}
+		if paired {
+			filtered.UpdateOld = append(filtered.UpdateOld, changes.UpdateOld[i])
+		}
+		filtered.UpdateNew = append(filtered.UpdateNew, desired)
+	}
+	if !paired {
+		filtered.UpdateOld = changes.UpdateOld
+	}
+	return filtered
+}
+
+func (p *ProtectedRecordsPolicy) filter(endpoints []*endpoint.Endpoint, action string) []*endpoint.Endpoint {
+	var filtered []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		if p.Protects(ep.DNSName) {
+			p.report(ep, action)
+			continue
+		}
+		filtered = append(filtered, ep)
+	}
+	return filtered
+}
+
+func (p *ProtectedRecordsPolicy) report(ep *endpoint.Endpoint, action string) {
+	log.Errorf("Refusing to %s protected record %s %s", action, ep.DNSName, ep.RecordType)
+	protectedRecordsPerSync.AddWithLabels(1.0, ep.RecordType, action)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+func TestParseProtectedRecords(t *testing.T) {
+	p, err := ParseProtectedRecords(nil, "")
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	file := filepath.Join(t.TempDir(), "protected")
+	require.NoError(t, os.WriteFile(file, []byte("# apex and mail\nexample.com.\n\nregex:^mail[0-9]*\\.example\\.org$\n"), 0o600))
+
+	p, err = ParseProtectedRecords([]string{"WWW.example.com"}, file)
+	require.NoError(t, err)
+	assert.True(t, p.Protects("example.com"))
+	assert.True(t, p.Protects("www.example.com."))
+	assert.True(t, p.Protects("mail2.example.org"))
+	assert.False(t, p.Protects("app.example.com"))
+	assert.False(t, p.Protects("mail.example.org.evil.com"))
+
+	_, err = ParseProtectedRecords([]string{"regex:("}, "")
+	require.ErrorContains(t, err, "invalid protected records regular expression")
+
+	_, err = ParseProtectedRecords(nil, filepath.Join(t.TempDir(), "missing"))
+	require.ErrorContains(t, err, "reading protected records")
+}
+
+func TestProtectedRecordsPolicy(t *testing.T) {
+	p, err := ParseProtectedRecords([]string{"example.com"}, "")
+	require.NoError(t, err)
+
+	apex := endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "1.2.3.4")
+	apexNew := endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "5.6.7.8")
+	app := endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	appNew := endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "5.6.7.8")
+	mx := endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "10 mail.example.com")
+
+	changes := p.Apply(&Changes{
+		Create:    []*endpoint.Endpoint{apex, app},
+		UpdateOld: []*endpoint.Endpoint{apex, app},
+		UpdateNew: []*endpoint.Endpoint{apexNew, appNew},
+		Delete:    []*endpoint.Endpoint{mx},
+	})
+	assert.Equal(t, &Changes{
+		Create:    []*endpoint.Endpoint{app},
+		UpdateOld: []*endpoint.Endpoint{app},
+		UpdateNew: []*endpoint.Endpoint{appNew},
+	}, changes)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1.0, protectedRecordsPerSync.Gauge,
+		map[string]string{"record_type": endpoint.RecordTypeMX, "action": "delete"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1.0, protectedRecordsPerSync.Gauge,
+		map[string]string{"record_type": endpoint.RecordTypeA, "action": "update"})
+
+	t.Run("unpaired updates", func(t *testing.T) {
+		changes := p.Apply(&Changes{UpdateOld: []*endpoint.Endpoint{app}, UpdateNew: []*endpoint.Endpoint{appNew, apexNew}})
+		assert.Empty(t, changes.UpdateOld)
+		assert.Empty(t, changes.UpdateNew)
+	})
+}
+
+func TestCalculateProtectedRecords(t *testing.T) {
+	p, err := ParseProtectedRecords([]string{"regex:^example\\.com$"}, "")
+	require.NoError(t, err)
+
+	current := endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner")
+	plan := &Plan{
+		Policies:       []Policy{&SyncPolicy{}, p},
+		Current:        []*endpoint.Endpoint{current},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "owner",
+	}
+	assert.Empty(t, plan.Calculate().Changes.Delete, "an owned protected record must not be deleted")
+}
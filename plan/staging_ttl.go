@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// stageCreates creates the records with a staging TTL in two steps: they are
+// first created with the staging TTL, and the next plan updates them to their
+// desired TTL since the staging TTL property is ignored when comparing records.
+// Records without a desired TTL higher than the staging TTL, or with an
+// unmanaged lifecycle, are never updated and are created right away, as are
+// all the records when the policies strip out the updates.
+func stageCreates(creates []*endpoint.Endpoint, policies []Policy) {
+	updates := allowUpdates(policies)
+	for i, create := range creates {
+		ttl, ok := create.StagingTTL()
+		if !ok {
+			continue
+		}
+		if !updates {
+			log.Debugf("Creating %s without the staging TTL %d: the policy does not allow updating its TTL", create, ttl)
+			continue
+		}
+		if ttl >= create.RecordTTL || create.IsUnmanagedLifecycle() {
+			log.Debugf("Creating %s without the staging TTL %d: its TTL is not higher or it is never updated", create, ttl)
+			continue
+		}
+		staged := create.DeepCopy()
+		staged.RecordTTL = ttl
+		log.Infof("Creating %s %s with the staging TTL %d before its TTL %d", create.DNSName, create.RecordType, ttl, create.RecordTTL)
+		creates[i] = staged
+	}
+}
+
+// allowUpdates reports whether the policies keep the updates of a plan.
+func allowUpdates(policies []Policy) bool {
+	for _, policy := range policies {
+		switch policy.(type) {
+		case *CreateOnlyPolicy, *DeleteOnlyPolicy:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestPlanStagingTTL(t *testing.T) {
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 3600, "1.2.3.4").
+			WithProviderSpecific(endpoint.ProviderSpecificStagingTTL, "30"),
+	}
+	managed := []string{endpoint.RecordTypeA}
+
+	// the record is first created with the staging TTL
+	changes := (&Plan{Desired: desired, ManagedRecords: managed}).Calculate().Changes
+	require.Len(t, changes.Create, 1)
+	assert.Equal(t, endpoint.TTL(30), changes.Create[0].RecordTTL)
+	assert.Equal(t, endpoint.TTL(3600), desired[0].RecordTTL)
+
+	// then updated to its TTL, the staging TTL property not being returned by the provider
+	current := []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 30, "1.2.3.4")}
+	changes = (&Plan{Current: current, Desired: desired, ManagedRecords: managed}).Calculate().Changes
+	assert.Empty(t, changes.Create)
+	require.Len(t, changes.UpdateNew, 1)
+	assert.Equal(t, endpoint.TTL(3600), changes.UpdateNew[0].RecordTTL)
+
+	// and left alone afterwards
+	current[0].RecordTTL = 3600
+	changes = (&Plan{Current: current, Desired: desired, ManagedRecords: managed}).Calculate().Changes
+	assert.False(t, changes.HasChanges())
+}
+
+func TestPlanStagingTTLSkipped(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		ep       *endpoint.Endpoint
+		policies []Policy
+	}{
+		{
+			name: "ttl not configured",
+			ep:   endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		{
+			name: "ttl below the staging ttl",
+			ep:   endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 10, "1.2.3.4"),
+		},
+		{
+			name: "unmanaged lifecycle",
+			ep: endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 3600, "1.2.3.4").
+				WithProviderSpecific(endpoint.ProviderSpecificUnmanagedLifecycle, "true"),
+		},
+		{
+			name:     "create-only policy",
+			ep:       endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+			policies: []Policy{&CreateOnlyPolicy{}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := tt.ep.WithProviderSpecific(endpoint.ProviderSpecificStagingTTL, "30")
+			changes := (&Plan{Policies: tt.policies, Desired: []*endpoint.Endpoint{desired}, ManagedRecords: []string{endpoint.RecordTypeA}}).Calculate().Changes
+			require.Len(t, changes.Create, 1)
+			assert.Same(t, desired, changes.Create[0])
+		})
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// ttlRollout tracks a TTL change staged over several synchronizations.
+type ttlRollout struct {
+	from endpoint.TTL
+	to   endpoint.TTL
+	step int
+}
+
+// TTLRolloutPolicy spreads TTL-only updates over time to avoid resolver
+// thundering herds after mass TTL changes. Updates changing anything besides
+// the TTL are passed through untouched.
+//
+// With Steps > 1 a TTL change moves linearly from the current to the desired
+// value over Steps synchronizations. With MaxUpdates > 0 at most MaxUpdates
+// TTL-only updates are applied per synchronization, the rest are deferred.
+type TTLRolloutPolicy struct {
+	Steps      int
+	MaxUpdates int
+
+	mu       sync.Mutex
+	rollouts map[endpoint.EndpointKey]ttlRollout
+}
+
+// NewTTLRolloutPolicy returns a TTLRolloutPolicy, or nil when neither staging
+// nor capping is configured.
+func NewTTLRolloutPolicy(steps, maxUpdates int) *TTLRolloutPolicy {
+	if steps <= 1 && maxUpdates <= 0 {
+		return nil
+	}
+	return &TTLRolloutPolicy{
+		Steps:      steps,
+		MaxUpdates: maxUpdates,
+		rollouts:   map[endpoint.EndpointKey]ttlRollout{},
+	}
+}
+
+// Apply stages and caps the TTL-only updates of changes.
+func (p *TTLRolloutPolicy) Apply(changes *Changes) *Changes {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var ttlOnly []int
+	result := &Changes{Create: changes.Create, Delete: changes.Delete}
+	for i := range changes.UpdateNew {
+		if isTTLOnlyUpdate(changes.UpdateOld[i], changes.UpdateNew[i]) {
+			ttlOnly = append(ttlOnly, i)
+			continue
+		}
+		result.UpdateOld = append(result.UpdateOld, changes.UpdateOld[i])
+		result.UpdateNew = append(result.UpdateNew, changes.UpdateNew[i])
+	}
+
+	// process in a stable order so that the same records progress across syncs
+	slices.SortFunc(ttlOnly, func(a, b int) int {
+		ka, kb := changes.UpdateNew[a].Key(), changes.UpdateNew[b].Key()
+		return cmp.Or(
+			cmp.Compare(ka.DNSName, kb.DNSName),
+			cmp.Compare(ka.RecordType, kb.RecordType),
+			cmp.Compare(ka.SetIdentifier, kb.SetIdentifier))
+	})
+
+	active := make(map[endpoint.EndpointKey]ttlRollout, len(ttlOnly))
+	deferred := 0
+	for n, i := range ttlOnly {
+		current, desired := changes.UpdateOld[i], changes.UpdateNew[i]
+		key := desired.Key()
+		if p.MaxUpdates > 0 && n >= p.MaxUpdates {
+			if r, ok := p.rollouts[key]; ok {
+				active[key] = r
+			}
+			deferred++
+			continue
+		}
+		update := p.stage(key, current, desired, active)
+		result.UpdateOld = append(result.UpdateOld, current)
+		result.UpdateNew = append(result.UpdateNew, update)
+	}
+	if deferred > 0 {
+		log.Infof("Deferring %d TTL-only updates to the next synchronizations", deferred)
+	}
+	p.rollouts = active
+
+	return result
+}
+
+// stage returns desired with the TTL of the next rollout step.
+func (p *TTLRolloutPolicy) stage(key endpoint.EndpointKey, current, desired *endpoint.Endpoint, active map[endpoint.EndpointKey]ttlRollout) *endpoint.Endpoint {
+	if p.Steps <= 1 {
+		return desired
+	}
+	r, ok := p.rollouts[key]
+	if !ok || r.to != desired.RecordTTL {
+		r = ttlRollout{from: current.RecordTTL, to: desired.RecordTTL}
+	}
+	r.step++
+	if r.step >= p.Steps {
+		return desired
+	}
+	active[key] = r
+
+	staged := *desired
+	staged.RecordTTL = r.from + (r.to-r.from)*endpoint.TTL(r.step)/endpoint.TTL(p.Steps)
+	log.Debugf("Staging TTL of %s %s from %d to %d (step %d/%d, target %d)",
+		desired.DNSName, desired.RecordType, current.RecordTTL, staged.RecordTTL, r.step, p.Steps, r.to)
+	return &staged
+}
+
+// isTTLOnlyUpdate returns true when current and desired only differ by TTL.
+func isTTLOnlyUpdate(current, desired *endpoint.Endpoint) bool {
+	return shouldUpdateTTL(desired, current) &&
+		current.RecordTTL.IsConfigured() &&
+		!targetChanged(desired, current) &&
+		!providerSpecificChanged(desired, current)
+}
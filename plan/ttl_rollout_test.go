@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func ttlUpdate(name string, from, to endpoint.TTL) (*endpoint.Endpoint, *endpoint.Endpoint) {
+	return endpoint.NewEndpointWithTTL(name, endpoint.RecordTypeA, from, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL(name, endpoint.RecordTypeA, to, "1.2.3.4")
+}
+
+func TestNewTTLRolloutPolicy(t *testing.T) {
+	assert.Nil(t, NewTTLRolloutPolicy(1, 0))
+	assert.Nil(t, NewTTLRolloutPolicy(0, 0))
+	assert.NotNil(t, NewTTLRolloutPolicy(3, 0))
+	assert.NotNil(t, NewTTLRolloutPolicy(1, 10))
+}
+
+func TestTTLRolloutPolicy_Steps(t *testing.T) {
+	p := NewTTLRolloutPolicy(4, 0)
+	current, desired := ttlUpdate("foo.example.com", 3600, 400)
+
+	var applied []endpoint.TTL
+	for range 4 {
+		changes := p.Apply(&Changes{UpdateOld: []*endpoint.Endpoint{current}, UpdateNew: []*endpoint.Endpoint{desired}})
+		require.Len(t, changes.UpdateNew, 1)
+		applied = append(applied, changes.UpdateNew[0].RecordTTL)
+		// the provider now serves the staged TTL
+		current = endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, changes.UpdateNew[0].RecordTTL, "1.2.3.4")
+	}
+
+	assert.Equal(t, []endpoint.TTL{2800, 2000, 1200, 400}, applied)
+	assert.Equal(t, endpoint.TTL(400), desired.RecordTTL, "desired endpoint must not be modified")
+	assert.Empty(t, p.rollouts)
+}
+
+func TestTTLRolloutPolicy_TargetChangedRestartsRollout(t *testing.T) {
+	p := NewTTLRolloutPolicy(2, 0)
+	current, desired := ttlUpdate("foo.example.com", 1000, 200)
+
+	changes := p.Apply(&Changes{UpdateOld: []*endpoint.Endpoint{current}, UpdateNew: []*endpoint.Endpoint{desired}})
+	assert.Equal(t, endpoint.TTL(600), changes.UpdateNew[0].RecordTTL)
+
+	// the desired TTL changes mid-rollout, the rollout restarts from the current TTL
+	current, desired = ttlUpdate("foo.example.com", 600, 1600)
+	changes = p.Apply(&Changes{UpdateOld: []*endpoint.Endpoint{current}, UpdateNew: []*endpoint.Endpoint{desired}})
+	assert.Equal(t, endpoint.TTL(1100), changes.UpdateNew[0].RecordTTL)
+}
+
+func TestTTLRolloutPolicy_MaxUpdates(t *testing.T) {
+	p := NewTTLRolloutPolicy(1, 2)
+
+	var old, updated []*endpoint.Endpoint
+	for _, name := range []string{"c.example.com", "a.example.com", "b.example.com"} {
+		c, d := ttlUpdate(name, 300, 60)
+		old = append(old, c)
+		updated = append(updated, d)
+	}
+	// an update that changes targets is never deferred
+	targetChange := endpoint.NewEndpointWithTTL("d.example.com", endpoint.RecordTypeA, 60, "5.6.7.8")
+	old = append(old, endpoint.NewEndpointWithTTL("d.example.com", endpoint.RecordTypeA, 300, "1.2.3.4"))
+	updated = append(updated, targetChange)
+	create := endpoint.NewEndpoint("e.example.com", endpoint.RecordTypeA, "1.2.3.4")
+
+	changes := p.Apply(&Changes{Create: []*endpoint.Endpoint{create}, UpdateOld: old, UpdateNew: updated})
+
+	assert.Equal(t, []*endpoint.Endpoint{create}, changes.Create)
+	require.Len(t, changes.UpdateNew, 3)
+	require.Len(t, changes.UpdateOld, 3)
+	var names []string
+	for i, ep := range changes.UpdateNew {
+		assert.Equal(t, ep.DNSName, changes.UpdateOld[i].DNSName)
+		names = append(names, ep.DNSName)
+	}
+	assert.Equal(t, []string{"d.example.com", "a.example.com", "b.example.com"}, names)
+}
+
+func TestIsTTLOnlyUpdate(t *testing.T) {
+	current, desired := ttlUpdate("foo.example.com", 300, 60)
+	assert.True(t, isTTLOnlyUpdate(current, desired))
+
+	unconfigured := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	assert.False(t, isTTLOnlyUpdate(unconfigured, desired))
+	assert.False(t, isTTLOnlyUpdate(current, unconfigured))
+
+	withProperty := endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 60, "1.2.3.4").
+		WithProviderSpecific("aws/weight", "10")
+	assert.False(t, isTTLOnlyUpdate(current, withProperty))
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// UpdateDiff describes the fields an update changes from current to desired, e.g.
+// "ttl: 300 -> 60; targets: +10.0.0.2 -10.0.0.1". Targets are compared as sets,
+// so a reordering is not reported. It is empty when none of the TTL, the
+// targets, the owner and the provider specific properties changed.
+func UpdateDiff(current, desired *endpoint.Endpoint) string {
+	var fields []string
+	if current.RecordTTL != desired.RecordTTL {
+		fields = append(fields, fmt.Sprintf("ttl: %d -> %d", current.RecordTTL, desired.RecordTTL))
+	}
+	if targets := targetsDiff(current.Targets, desired.Targets); targets != "" {
+		fields = append(fields, "targets: "+targets)
+	}
+	if o, n := current.Labels[endpoint.OwnerLabelKey], desired.Labels[endpoint.OwnerLabelKey]; o != n {
+		fields = append(fields, fmt.Sprintf("owner: %q -> %q", o, n))
+	}
+	fields = append(fields, providerSpecificDiff(current, desired)...)
+	return strings.Join(fields, "; ")
+}
+
+// UpdateDiffs returns the UpdateDiff of each update, paired by index, in the
+// order of UpdateNew. It is nil when the updates cannot be paired.
+func (c *Changes) UpdateDiffs() []string {
+	if len(c.UpdateOld) != len(c.UpdateNew) {
+		return nil
+	}
+	diffs := make([]string, len(c.UpdateNew))
+	for i := range c.UpdateNew {
+		diffs[i] = UpdateDiff(c.UpdateOld[i], c.UpdateNew[i])
+	}
+	return diffs
+}
+
+func targetsDiff(current, desired endpoint.Targets) string {
+	var parts []string
+	for _, t := range desired {
+		if !containsTarget(current, t) {
+			parts = append(parts, "+"+t)
+		}
+	}
+	for _, t := range current {
+		if !containsTarget(desired, t) {
+			parts = append(parts, "-"+t)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func containsTarget(targets endpoint.Targets, target string) bool {
+	for _, t := range targets {
+		if strings.EqualFold(t, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func providerSpecificDiff(current, desired *endpoint.Endpoint) []string {
+	var fields []string
+	for _, p := range desired.ProviderSpecific {
+		o, ok := current.GetProviderSpecificProperty(p.Name)
+		switch {
+		case !ok:
+			fields = append(fields, fmt.Sprintf("%s: +%q", p.Name, p.Value))
+		case o != p.Value:
+			fields = append(fields, fmt.Sprintf("%s: %q -> %q", p.Name, o, p.Value))
+		}
+	}
+	for _, p := range current.ProviderSpecific {
+		if _, ok := desired.GetProviderSpecificProperty(p.Name); !ok {
+			fields = append(fields, fmt.Sprintf("%s: -%q", p.Name, p.Value))
+		}
+	}
+	return fields
+}
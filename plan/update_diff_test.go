@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestUpdateDiff(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		current  *endpoint.Endpoint
+		desired  *endpoint.Endpoint
+		expected string
+	}{
+		{
+			name:     "unchanged",
+			current:  endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+			desired:  endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 300, "5.6.7.8", "1.2.3.4"),
+			expected: "",
+		},
+		{
+			name:     "ttl",
+			current:  endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 300, "1.2.3.4"),
+			desired:  endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 60, "1.2.3.4"),
+			expected: "ttl: 300 -> 60",
+		},
+		{
+			name:     "targets",
+			current:  endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4", "5.6.7.8"),
+			desired:  endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4", "9.9.9.9"),
+			expected: "targets: +9.9.9.9 -5.6.7.8",
+		},
+		{
+			name:     "targets compared case insensitively",
+			current:  endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeCNAME, "LB.example.com"),
+			desired:  endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeCNAME, "lb.example.com"),
+			expected: "",
+		},
+		{
+			name:     "owner",
+			current:  endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "old"),
+			desired:  endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "new"),
+			expected: `owner: "old" -> "new"`,
+		},
+		{
+			name: "provider specific",
+			current: endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4").
+				WithProviderSpecific("aws/weight", "10").
+				WithProviderSpecific("aws/region", "eu-west-1"),
+			desired: endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4").
+				WithProviderSpecific("aws/weight", "20").
+				WithProviderSpecific("alias", "true"),
+			expected: `aws/weight: "10" -> "20"; alias: +"true"; aws/region: -"eu-west-1"`,
+		},
+		{
+			name:     "several fields",
+			current:  endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 300, "1.2.3.4"),
+			desired:  endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 60, "5.6.7.8"),
+			expected: "ttl: 300 -> 60; targets: +5.6.7.8 -1.2.3.4",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, UpdateDiff(tc.current, tc.desired))
+		})
+	}
+}
+
+func TestChangesUpdateDiffs(t *testing.T) {
+	current, desired := ttlUpdate("foo.example.org", 300, 60)
+	changes := &Changes{
+		UpdateOld: []*endpoint.Endpoint{current},
+		UpdateNew: []*endpoint.Endpoint{desired},
+	}
+	assert.Equal(t, []string{"ttl: 300 -> 60"}, changes.UpdateDiffs())
+
+	changes.UpdateOld = nil
+	assert.Nil(t, changes.UpdateDiffs())
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// EndpointAdjuster implements AdjustEndpoints on top of a function adjusting a
+// single endpoint. The function receives a deep copy of each candidate endpoint,
+// which it may modify, and returns the endpoints replacing it: none to drop the
+// endpoint, the copy itself to keep it, or additional endpoints derived from it.
+//
+// AdjustEndpoints built on an EndpointAdjuster never modifies the supplied
+// endpoints and always returns a new slice, as required by Provider.
+type EndpointAdjuster func(ep *endpoint.Endpoint) []*endpoint.Endpoint
+
+// AdjustEndpoints returns the endpoints replacing each of the candidate endpoints, in order.
+func (a EndpointAdjuster) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	adjusted := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		adjusted = append(adjusted, a(ep.DeepCopy())...)
+	}
+	return adjusted, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+func TestEndpointAdjuster(t *testing.T) {
+	adjuster := EndpointAdjuster(func(ep *endpoint.Endpoint) []*endpoint.Endpoint {
+		ep.RecordTTL = 300
+		switch ep.DNSName {
+		case "drop.example.com":
+			return nil
+		case "expand.example.com":
+			if ep.RecordType == endpoint.RecordTypeCNAME {
+				ep.RecordType = endpoint.RecordTypeA
+				aaaa := ep.DeepCopy()
+				aaaa.RecordType = endpoint.RecordTypeAAAA
+				return []*endpoint.Endpoint{ep, aaaa}
+			}
+		}
+		return []*endpoint.Endpoint{ep}
+	})
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("keep.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("drop.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("expand.example.com", endpoint.RecordTypeCNAME, "lb.example.com"),
+	}
+
+	adjusted, err := adjuster.AdjustEndpoints(endpoints)
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("keep.example.com", endpoint.RecordTypeA, 300, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("expand.example.com", endpoint.RecordTypeA, 300, "lb.example.com"),
+		endpoint.NewEndpointWithTTL("expand.example.com", endpoint.RecordTypeAAAA, 300, "lb.example.com"),
+	}, adjusted)
+
+	adjusted, err = adjuster.AdjustEndpoints(nil)
+	require.NoError(t, err)
+	assert.NotNil(t, adjusted)
+	assert.Empty(t, adjusted)
+
+	testutils.TestHelperAdjustEndpointsContract(t, adjuster.AdjustEndpoints, endpoints)
+}
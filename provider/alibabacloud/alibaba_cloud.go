@@ -289,6 +289,11 @@ func (p *AlibabaCloudProvider) refreshStsToken(sleepTime time.Duration) {
 	}
 }
 
+// SupportedRecordTypes returns the record types managed with the Alibaba Cloud provider.
+func (p *AlibabaCloudProvider) SupportedRecordTypes() []string {
+	return provider.DefaultSupportedRecordTypes()
+}
+
 // Records gets the current records.
 //
 // Returns the current records or an error if the operation failed.
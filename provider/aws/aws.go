@@ -20,19 +20,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"regexp"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53profiles"
+	"github.com/goccy/go-yaml"
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/txt"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
@@ -67,6 +72,8 @@ const (
 	providerSpecificGeoProximityLocationLocalZoneGroup = "aws/geoproximity-local-zone-group"
 	providerSpecificMultiValueAnswer                   = "aws/multi-value-answer"
 	providerSpecificHealthCheckID                      = "aws/health-check-id"
+	providerSpecificCIDRCollection                     = "aws/cidr-collection"
+	providerSpecificCIDRLocation                       = "aws/cidr-location"
 	sameZoneAlias                                      = "same-zone"
 	// Currently supported up to 10 health checks or hosted zones.
 	// https://docs.aws.amazon.com/Route53/latest/APIReference/API_ListTagsForResources.html#API_ListTagsForResources_RequestSyntax
@@ -77,6 +84,10 @@ const (
 	maxLongitude = 180.0
 )
 
+// cloudFrontHostedZoneID is the canonical hosted zone of CloudFront distributions.
+// Route53 refuses to evaluate the health of alias targets in it.
+const cloudFrontHostedZoneID = "Z2FDTNDATAQYW2"
+
 // see elb: https://docs.aws.amazon.com/general/latest/gr/elb.html
 var canonicalHostedZones = map[string]string{
 	// Application Load Balancers and Classic Load Balancers
@@ -160,7 +171,7 @@ var canonicalHostedZones = map[string]string{
 	// Global Accelerator
 	"awsglobalaccelerator.com": "Z2BJ6XQ5FK7U4H",
 	// Cloudfront and AWS API Gateway edge-optimized endpoints
-	"cloudfront.net": "Z2FDTNDATAQYW2",
+	"cloudfront.net": cloudFrontHostedZoneID,
 	// VPC Endpoint (PrivateLink) https://github.com/kubernetes-sigs/external-dns/issues/3429#issuecomment-1440415806
 	"eu-west-2.vpce.amazonaws.com":      "Z7K1066E3PUKB",
 	"us-east-2.vpce.amazonaws.com":      "ZC8PG0KIFKBRI",
@@ -192,6 +203,32 @@ var canonicalHostedZones = map[string]string{
 	"us-gov-west-1.vpce.amazonaws.com":  "Z12529ZODG2B6H",
 	"us-west-1.vpce.amazonaws.com":      "Z12I86A8N7VCZO",
 	"us-west-2.vpce.amazonaws.com":      "Z1YSA3EXCYUU9Z",
+	// S3 website endpoints https://docs.aws.amazon.com/general/latest/gr/s3.html#s3_website_region_endpoints
+	"s3-website-us-east-1.amazonaws.com":      "Z3AQBSTGFYJSTF",
+	"s3-website.us-east-1.amazonaws.com":      "Z3AQBSTGFYJSTF",
+	"s3-website.us-east-2.amazonaws.com":      "Z2O1EMRO9K5GLX",
+	"s3-website-us-west-1.amazonaws.com":      "Z2F56UZL2M1ACD",
+	"s3-website.us-west-1.amazonaws.com":      "Z2F56UZL2M1ACD",
+	"s3-website-us-west-2.amazonaws.com":      "Z3BJ6K6RIION7M",
+	"s3-website.us-west-2.amazonaws.com":      "Z3BJ6K6RIION7M",
+	"s3-website.ca-central-1.amazonaws.com":   "Z1QDHH18159H29",
+	"s3-website.ap-south-1.amazonaws.com":     "Z11RGJOFQNVJUP",
+	"s3-website.ap-northeast-2.amazonaws.com": "Z3W03O7B5YMIYP",
+	"s3-website.ap-northeast-3.amazonaws.com": "Z2YQB5RD63NC85",
+	"s3-website-ap-southeast-1.amazonaws.com": "Z3O0J2DXBE1FTB",
+	"s3-website.ap-southeast-1.amazonaws.com": "Z3O0J2DXBE1FTB",
+	"s3-website-ap-southeast-2.amazonaws.com": "Z1WCIGYICN2BYD",
+	"s3-website.ap-southeast-2.amazonaws.com": "Z1WCIGYICN2BYD",
+	"s3-website-ap-northeast-1.amazonaws.com": "Z2M4EHUR26P7ZW",
+	"s3-website.ap-northeast-1.amazonaws.com": "Z2M4EHUR26P7ZW",
+	"s3-website.eu-central-1.amazonaws.com":   "Z21DNDUVLTQW6Q",
+	"s3-website-eu-west-1.amazonaws.com":      "Z1BKCTXD74EZPE",
+	"s3-website.eu-west-1.amazonaws.com":      "Z1BKCTXD74EZPE",
+	"s3-website.eu-west-2.amazonaws.com":      "Z3GKZC51ZF0DB4",
+	"s3-website.eu-west-3.amazonaws.com":      "Z3R1K369G5AVDG",
+	"s3-website.eu-north-1.amazonaws.com":     "Z3BAZG2TWCNX0D",
+	"s3-website-sa-east-1.amazonaws.com":      "Z7KQH4QJS55SO",
+	"s3-website.sa-east-1.amazonaws.com":      "Z7KQH4QJS55SO",
 	// AWS API Gateway (Regional endpoints)
 	// See: https://docs.aws.amazon.com/general/latest/gr/apigateway.html
 	"execute-api.us-east-2.amazonaws.com":      "ZOJJZC49E0EPZ",
@@ -221,6 +258,31 @@ var canonicalHostedZones = map[string]string{
 	"execute-api.us-gov-west-1.amazonaws.com":  "Z1K6XKP9SAGWDV",
 }
 
+// PropertySpecs returns the provider-specific properties understood by the AWS provider.
+func PropertySpecs() []endpoint.PropertySpec {
+	return []endpoint.PropertySpec{
+		{Name: providerSpecificTargetHostedZone, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificEvaluateTargetHealth, Type: endpoint.PropertyTypeBool},
+		{Name: providerSpecificWeight, Type: endpoint.PropertyTypeInt},
+		{Name: providerSpecificRegion, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificFailover, Type: endpoint.PropertyTypeString, Allowed: []string{
+			string(route53types.ResourceRecordSetFailoverPrimary),
+			string(route53types.ResourceRecordSetFailoverSecondary),
+		}},
+		{Name: providerSpecificGeolocationContinentCode, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificGeolocationCountryCode, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificGeolocationSubdivisionCode, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificGeoProximityLocationAWSRegion, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificGeoProximityLocationBias, Type: endpoint.PropertyTypeInt},
+		{Name: providerSpecificGeoProximityLocationCoordinates, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificGeoProximityLocationLocalZoneGroup, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificMultiValueAnswer, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificHealthCheckID, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificCIDRCollection, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificCIDRLocation, Type: endpoint.PropertyTypeString},
+	}
+}
+
 // Route53API is the subset of the AWS Route53 API that we actually use.  Add methods as required. Signatures must match exactly.
 // https://github.com/aws/aws-sdk-go-v2/tree/main/service/route53
 type Route53API interface {
@@ -229,6 +291,10 @@ type Route53API interface {
 	CreateHostedZone(ctx context.Context, input *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error)
 	ListHostedZones(ctx context.Context, input *route53.ListHostedZonesInput, optFns ...func(options *route53.Options)) (*route53.ListHostedZonesOutput, error)
 	ListTagsForResources(ctx context.Context, input *route53.ListTagsForResourcesInput, optFns ...func(options *route53.Options)) (*route53.ListTagsForResourcesOutput, error)
+	GetHostedZone(ctx context.Context, input *route53.GetHostedZoneInput, optFns ...func(options *route53.Options)) (*route53.GetHostedZoneOutput, error)
+	ListCidrCollections(ctx context.Context, input *route53.ListCidrCollectionsInput, optFns ...func(options *route53.Options)) (*route53.ListCidrCollectionsOutput, error)
+	CreateCidrCollection(ctx context.Context, input *route53.CreateCidrCollectionInput, optFns ...func(options *route53.Options)) (*route53.CreateCidrCollectionOutput, error)
+	DeleteCidrCollection(ctx context.Context, input *route53.DeleteCidrCollectionInput, optFns ...func(options *route53.Options)) (*route53.DeleteCidrCollectionOutput, error)
 }
 
 // Route53Change wrapper to handle ownership relation throughout the provider implementation
@@ -237,6 +303,8 @@ type Route53Change struct {
 	OwnedRecord string
 	sizeBytes   int
 	sizeValues  int
+	// cidrCollection is the name of the CIDR collection, resolved to its ID on submission
+	cidrCollection string
 }
 
 type Route53Changes []*Route53Change
@@ -303,10 +371,23 @@ type AWSProvider struct {
 	zoneTagFilter provider.ZoneTagFilter
 	// extend filter for subdomains in the zone (e.g. first.us-east-1.example.com)
 	zoneMatchParent bool
-	preferCNAME     bool
-	zonesCache      *blueprint.ZoneCache[map[string]*profiledZone]
+	// also consider hosted zones of Route 53 Profiles shared with the account
+	sharedZones bool
+	// restrict shared hosted zones to these Route 53 Profile IDs
+	sharedZonesProfileIDs []string
+	profilesClients       map[string]Route53ProfilesAPI
+	// tags of the shared hosted zones, looked up once per sharedZoneTagsCacheDuration
+	sharedZoneTags     map[string]cachedZoneTags
+	sharedZoneTagsLock sync.Mutex
+	preferCNAME        bool
+	// additional canonical hosted zones of alias targets by hostname suffix
+	canonicalHostedZones map[string]string
+	zonesCache           *blueprint.ZoneCache[map[string]*profiledZone]
 	// queue for collecting changes to submit them in the next iteration, but after all other changes
 	failedChangesQueue map[string]Route53Changes
+	// CIDR collection IDs by name per AWS profile, looked up on demand
+	cidrCollections     map[string]map[string]string
+	cidrCollectionsLock sync.Mutex
 }
 
 // AWSConfig contains configuration to create a new AWS provider.
@@ -324,6 +405,9 @@ type AWSConfig struct {
 	PreferCNAME           bool
 	DryRun                bool
 	ZoneCacheDuration     time.Duration
+	SharedZones           bool
+	SharedZonesProfileIDs []string
+	CanonicalHostedZones  map[string]string
 }
 
 // New creates an AWS Route53 provider from the given configuration.
@@ -333,7 +417,21 @@ func New(_ context.Context, cfg *externaldns.Config, domainFilter *endpoint.Doma
 	for profile, config := range configs {
 		clients[profile] = route53.NewFromConfig(config)
 	}
-	return newProvider(
+	var canonicalZones map[string]string
+	if cfg.AWSCanonicalHostedZonesFile != "" {
+		var err error
+		if canonicalZones, err = loadCanonicalHostedZones(cfg.AWSCanonicalHostedZonesFile); err != nil {
+			return nil, err
+		}
+	}
+	var profilesClients map[string]Route53ProfilesAPI
+	if cfg.AWSSharedZones {
+		profilesClients = make(map[string]Route53ProfilesAPI, len(configs))
+		for profile, config := range configs {
+			profilesClients[profile] = route53profiles.NewFromConfig(config)
+		}
+	}
+	pr := newProvider(
 		AWSConfig{
 			DomainFilter:          domainFilter,
 			ZoneIDFilter:          provider.NewZoneIDFilter(cfg.ZoneIDFilter),
@@ -348,9 +446,14 @@ func New(_ context.Context, cfg *externaldns.Config, domainFilter *endpoint.Doma
 			PreferCNAME:           cfg.AWSPreferCNAME,
 			DryRun:                cfg.DryRun,
 			ZoneCacheDuration:     cfg.AWSZoneCacheDuration,
+			SharedZones:           cfg.AWSSharedZones,
+			SharedZonesProfileIDs: cfg.AWSSharedZonesProfileIDs,
+			CanonicalHostedZones:  canonicalZones,
 		},
 		clients,
-	), nil
+	)
+	pr.profilesClients = profilesClients
+	return pr, nil
 }
 
 // newProvider initializes a new AWS Route53 based Provider.
@@ -368,9 +471,14 @@ func newProvider(cfg AWSConfig, clients map[string]Route53API) *AWSProvider {
 		batchChangeInterval:   cfg.BatchChangeInterval,
 		evaluateTargetHealth:  cfg.EvaluateTargetHealth,
 		preferCNAME:           cfg.PreferCNAME,
+		sharedZones:           cfg.SharedZones,
+		sharedZonesProfileIDs: cfg.SharedZonesProfileIDs,
+		sharedZoneTags:        make(map[string]cachedZoneTags),
+		canonicalHostedZones:  cfg.CanonicalHostedZones,
 		dryRun:                cfg.DryRun,
 		zonesCache:            blueprint.NewZoneCache[map[string]*profiledZone](cfg.ZoneCacheDuration),
 		failedChangesQueue:    make(map[string]Route53Changes),
+		cidrCollections:       make(map[string]map[string]string),
 	}
 	return pr
 }
@@ -416,23 +524,10 @@ func (p *AWSProvider) zones(ctx context.Context) (map[string]*profiledZone, erro
 			}
 			var zonesToTagFilter []string
 			for _, zone := range resp.HostedZones {
-				if !p.zoneIDFilter.Match(*zone.Id) {
-					continue
-				}
-
-				if !p.zoneTypeFilter.Match(zone) {
+				if !p.matchZone(zone) {
 					continue
 				}
 
-				if !p.domainFilter.Match(*zone.Name) {
-					if !p.zoneMatchParent {
-						continue
-					}
-					if !p.domainFilter.MatchParent(*zone.Name) {
-						continue
-					}
-				}
-
 				if !p.zoneTagFilter.IsEmpty() {
 					zonesToTagFilter = append(zonesToTagFilter, cleanZoneID(*zone.Id))
 				}
@@ -453,6 +548,33 @@ func (p *AWSProvider) zones(ctx context.Context) (map[string]*profiledZone, erro
 		}
 	}
 
+	if p.sharedZones {
+		for profile := range p.clients {
+			shared, err := p.listSharedZones(ctx, profile)
+			if err != nil {
+				return nil, err
+			}
+			var zonesToTagFilter []string
+			for id, zone := range shared {
+				// zones owned by one of the accounts take precedence
+				if _, ok := zones[id]; ok || !p.matchZone(*zone.zone) {
+					continue
+				}
+				if !p.zoneTagFilter.IsEmpty() {
+					zonesToTagFilter = append(zonesToTagFilter, cleanZoneID(id))
+				}
+				zones[id] = zone
+			}
+			if len(zonesToTagFilter) > 0 {
+				if zTags, err := p.tagsForSharedZones(ctx, zonesToTagFilter, profile); err != nil {
+					return nil, provider.NewSoftErrorf("failed to list tags for shared zones %w", err)
+				} else {
+					zTags.filterZonesByTags(p, zones)
+				}
+			}
+		}
+	}
+
 	if log.IsLevelEnabled(log.DebugLevel) {
 		for _, zone := range zones {
 			log.Debugf("Considering zone: %s (domain: %s)", *zone.zone.Id, *zone.zone.Name)
@@ -463,6 +585,20 @@ func (p *AWSProvider) zones(ctx context.Context) (map[string]*profiledZone, erro
 	return zones, nil
 }
 
+// matchZone returns true when the zone passes the zone ID, type and domain filters.
+func (p *AWSProvider) matchZone(zone route53types.HostedZone) bool {
+	if !p.zoneIDFilter.Match(*zone.Id) {
+		return false
+	}
+	if !p.zoneTypeFilter.Match(zone) {
+		return false
+	}
+	if !p.domainFilter.Match(*zone.Name) {
+		return p.zoneMatchParent && p.domainFilter.MatchParent(*zone.Name)
+	}
+	return true
+}
+
 // wildcardUnescape converts \\052.abc back to *.abc
 // Route53 stores wildcards escaped: http://docs.aws.amazon.com/Route53/latest/DeveloperGuide/DomainNameFormat.html?shortFooter=true#domain-name-format-asterisk
 func wildcardUnescape(s string) string {
@@ -483,6 +619,31 @@ func convertOctalToAscii(input string) string {
 	return result
 }
 
+// octalToDecimalEscapes rewrites the \ooo octal escapes Route53 returns the special characters
+// of TXT values in to the \DDD decimal escapes of the zone file presentation format.
+func octalToDecimalEscapes(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	isOctal := func(c byte, highest byte) bool { return c >= '0' && c <= highest }
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] != '\\' || i+1 == len(value):
+			b.WriteByte(value[i])
+		case i+4 <= len(value) && isOctal(value[i+1], '3') && isOctal(value[i+2], '7') && isOctal(value[i+3], '7'):
+			code, _ := strconv.ParseUint(value[i+1:i+4], 8, 8)
+			fmt.Fprintf(&b, `\%03d`, code)
+			i += 3
+		default:
+			// an escaped character, like \" or \\, is kept as is
+			b.WriteString(value[i : i+2])
+			i++
+		}
+	}
+	return b.String()
+}
+
 // validateDomainName checks if the domain name contains valid octal escape sequences.
 func containsOctalSequence(domain string) bool {
 	// Pattern to match valid octal escape sequences
@@ -491,6 +652,17 @@ func containsOctalSequence(domain string) bool {
 	return octalEscapeRegex.MatchString(domain)
 }
 
+// SupportedRecordTypes returns the record types managed with Route53.
+func (p *AWSProvider) SupportedRecordTypes() []string {
+	return provider.DefaultSupportedRecordTypes(endpoint.RecordTypeMX, endpoint.RecordTypeNAPTR, endpoint.RecordTypeCAA)
+}
+
+// DefaultManagedRecordTypes adds CAA to the default managed record types, since
+// Route53 stores CAA record sets like any other value-based record set.
+func (p *AWSProvider) DefaultManagedRecordTypes() []string {
+	return append(provider.DefaultManagedRecordTypes(p.SupportedRecordTypes()), endpoint.RecordTypeCAA)
+}
+
 // Records returns the list of records in a given hosted zone.
 func (p *AWSProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.zones(ctx)
@@ -536,6 +708,9 @@ func (p *AWSProvider) records(ctx context.Context, zones map[string]*profiledZon
 					targets := make([]string, len(r.ResourceRecords))
 					for idx, rr := range r.ResourceRecords {
 						targets[idx] = *rr.Value
+						if r.Type == route53types.RRTypeTxt {
+							targets[idx] = txt.Normalize(octalToDecimalEscapes(targets[idx]))
+						}
 					}
 
 					ep := endpoint.NewEndpointWithTTL(name, string(r.Type), ttl, targets...)
@@ -582,6 +757,10 @@ func (p *AWSProvider) records(ctx context.Context, zones map[string]*profiledZon
 							}
 						case r.GeoProximityLocation != nil:
 							handleGeoProximityLocationRecord(&r, ep)
+						case r.CidrRoutingConfig != nil:
+							if err := p.handleCIDRRoutingRecord(ctx, z.profile, &r, ep); err != nil {
+								return nil, err
+							}
 						default:
 							// one of the above needs to be set, otherwise SetIdentifier doesn't make sense
 						}
@@ -642,9 +821,9 @@ func (p *AWSProvider) requiresDeleteCreate(old *endpoint.Endpoint, newE *endpoin
 
 	// a change of routing policy
 	// defaults to true for geolocation properties if any geolocation property exists in old/new but not the other
-	for _, propType := range [7]string{providerSpecificWeight, providerSpecificRegion, providerSpecificFailover,
+	for _, propType := range [8]string{providerSpecificWeight, providerSpecificRegion, providerSpecificFailover,
 		providerSpecificFailover, providerSpecificGeolocationContinentCode, providerSpecificGeolocationCountryCode,
-		providerSpecificGeolocationSubdivisionCode} {
+		providerSpecificGeolocationSubdivisionCode, providerSpecificCIDRCollection} {
 		_, oldPolicy := old.GetProviderSpecificProperty(propType)
 		_, newPolicy := newE.GetProviderSpecificProperty(propType)
 		if oldPolicy != newPolicy {
@@ -730,6 +909,8 @@ func (p *AWSProvider) submitChanges(ctx context.Context, changes Route53Changes,
 
 	var failedZones []string
 	debugLevel := log.DebugLevel
+	// CIDR collections referenced by deleted records, by AWS profile
+	deletedCIDRCollections := make(map[string][]string)
 	for z, cs := range changesByZone {
 		log := log.WithFields(log.Fields{
 			"zoneName": *zones[z].zone.Name,
@@ -759,6 +940,13 @@ func (p *AWSProvider) submitChanges(ctx context.Context, changes Route53Changes,
 				continue
 			}
 
+			if err := p.resolveCIDRCollections(ctx, zones[z].profile, b); err != nil {
+				log.Errorf("Failure in zone %s when resolving CIDR collections: %v", *zones[z].zone.Name, err)
+				failedUpdate = true
+				continue
+			}
+			deletedCIDRCollections[zones[z].profile] = append(deletedCIDRCollections[zones[z].profile], deletedCIDRCollectionIDs(b)...)
+
 			params := &route53.ChangeResourceRecordSetsInput{
 				HostedZoneId: aws.String(z),
 				ChangeBatch: &route53types.ChangeBatch{
@@ -815,6 +1003,10 @@ func (p *AWSProvider) submitChanges(ctx context.Context, changes Route53Changes,
 		}
 	}
 
+	for profile, ids := range deletedCIDRCollections {
+		p.deleteUnusedCIDRCollections(ctx, profile, ids)
+	}
+
 	if len(failedZones) > 0 {
 		return provider.NewSoftErrorf("failed to submit all changes for the following zones: %v", failedZones)
 	}
@@ -834,22 +1026,22 @@ func (p *AWSProvider) newChanges(action route53types.ChangeAction, endpoints []*
 	return changes
 }
 
-// AdjustEndpoints modifies the provided endpoints (coming from various sources) to match
+// AdjustEndpoints adjusts the provided endpoints (coming from various sources) to match
 // the endpoints that the provider returns in `Records` so that the change plan will not have
 // unneeded (potentially failing) changes.
 // Example: CNAME endpoints pointing to ELBs will have a `alias` provider-specific property
 // added to match the endpoints generated from existing alias records in Route53.
 func (p *AWSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
-	// Holds CNAME targets that we will treat as Alias records. Such records are
-	// hard coded to 'A' type aliases but we also need their 'AAAA' counterparts.
-	var aliasCnameAaaaEndpoints []*endpoint.Endpoint
+	return provider.EndpointAdjuster(p.adjustEndpoint).AdjustEndpoints(endpoints)
+}
 
-	for _, ep := range endpoints {
-		if aaaa := p.adjustEndpointAndNewAaaaIfNeeded(ep); aaaa != nil {
-			aliasCnameAaaaEndpoints = append(aliasCnameAaaaEndpoints, aaaa)
-		}
+// adjustEndpoint adjusts ep. CNAME targets treated as Alias records are hard coded
+// to 'A' type aliases, their 'AAAA' counterpart is returned along with them.
+func (p *AWSProvider) adjustEndpoint(ep *endpoint.Endpoint) []*endpoint.Endpoint {
+	if aaaa := p.adjustEndpointAndNewAaaaIfNeeded(ep); aaaa != nil {
+		return []*endpoint.Endpoint{ep, aaaa}
 	}
-	return append(endpoints, aliasCnameAaaaEndpoints...), nil
+	return []*endpoint.Endpoint{ep}
 }
 
 func (p *AWSProvider) adjustEndpointAndNewAaaaIfNeeded(ep *endpoint.Endpoint) *endpoint.Endpoint {
@@ -858,39 +1050,83 @@ func (p *AWSProvider) adjustEndpointAndNewAaaaIfNeeded(ep *endpoint.Endpoint) *e
 		p.adjustAandAAAARecord(ep)
 	case endpoint.RecordTypeCNAME:
 		return p.adjustCNAMERecordAndNewAaaaIfNeeded(ep)
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSRV, endpoint.RecordTypeCAA:
+		adjustStructuredRecord(ep)
+	case endpoint.RecordTypeTXT:
+		txt.NormalizeEndpoint(ep)
 	}
 	return nil
 }
 
+// adjustStructuredRecord formats the MX, SRV and CAA targets like the values returned
+// by Route53, e.g. "10 mail.example.com" for "10   mail.example.com" or
+// `0 issue "letsencrypt.org"` for "0 ISSUE letsencrypt.org", so that they do not
+// show up as changes on every sync. Invalid targets are left for Route53 to reject.
+func adjustStructuredRecord(ep *endpoint.Endpoint) {
+	targets := make(endpoint.Targets, 0, len(ep.Targets))
+	switch ep.RecordType {
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		structured, err := ep.StructuredTargets()
+		if err != nil {
+			return
+		}
+		targets = endpoint.NewStructuredTargets(structured...)
+	case endpoint.RecordTypeCAA:
+		caas, err := ep.Targets.CAATargets()
+		if err != nil {
+			return
+		}
+		for _, caa := range caas {
+			targets = append(targets, caa.String())
+		}
+	}
+	ep.Targets = targets
+}
+
 func (p *AWSProvider) adjustAliasRecord(ep *endpoint.Endpoint) {
 	if ep.RecordTTL.IsConfigured() {
 		log.Debugf("Modifying endpoint: %v, setting ttl=%v", ep, defaultTTL)
 		ep.RecordTTL = defaultTTL
 	}
 
-	if enable, exists := ep.GetBoolProviderSpecificProperty(providerSpecificEvaluateTargetHealth); exists {
-		// normalize to string "true"/"false"
-		ep.SetProviderSpecificProperty(providerSpecificEvaluateTargetHealth, strconv.FormatBool(enable))
-	} else {
-		// if not set, use provider default
-		ep.SetProviderSpecificProperty(providerSpecificEvaluateTargetHealth, strconv.FormatBool(p.evaluateTargetHealth))
+	targetHostedZone, ok := ep.GetProviderSpecificProperty(providerSpecificTargetHostedZone)
+	if !ok && len(ep.Targets) > 0 {
+		targetHostedZone = p.canonicalHostedZone(ep.Targets[0])
+	}
+	// normalize to string "true"/"false", with the provider default if not set
+	ep.SetProviderSpecificProperty(providerSpecificEvaluateTargetHealth, strconv.FormatBool(p.evaluateTargetHealthOf(ep, targetHostedZone)))
+}
+
+// evaluateTargetHealthOf returns whether the health of the alias target of ep,
+// in targetHostedZone, is evaluated: the per-record property if set, else the
+// provider default. It is never evaluated for CloudFront distributions.
+func (p *AWSProvider) evaluateTargetHealthOf(ep *endpoint.Endpoint, targetHostedZone string) bool {
+	evaluate := p.evaluateTargetHealth
+	if prop, exists := ep.GetBoolProviderSpecificProperty(providerSpecificEvaluateTargetHealth); exists {
+		evaluate = prop
+	}
+	if evaluate && cleanZoneID(targetHostedZone) == cloudFrontHostedZoneID {
+		log.Debugf("Not evaluating the target health of %s, CloudFront alias targets do not support it", ep.DNSName)
+		return false
 	}
+	return evaluate
 }
 
 func (p *AWSProvider) adjustAandAAAARecord(ep *endpoint.Endpoint) {
-	if ep.GetAliasProperty() == endpoint.AliasTrue {
+	if ep.GetAliasProperty() != endpoint.AliasNone && ep.GetAliasProperty() != endpoint.AliasFalse {
 		p.adjustAliasRecord(ep)
 	} else {
 		ep.DeleteProviderSpecificProperty(endpoint.ProviderSpecificAlias)
 		ep.DeleteProviderSpecificProperty(providerSpecificEvaluateTargetHealth)
 	}
 	adjustGeoProximityLocationEndpoint(ep)
+	adjustCIDRRoutingEndpoint(ep)
 }
 
 func (p *AWSProvider) adjustCNAMERecordAndNewAaaaIfNeeded(ep *endpoint.Endpoint) *endpoint.Endpoint {
 	// ensure alias property is set
 	if ep.GetAliasProperty() == endpoint.AliasNone {
-		isAlias := useAlias(ep, p.preferCNAME)
+		isAlias := p.useAlias(ep)
 		log.Debugf("Modifying endpoint: %v, setting %s=%v", ep, endpoint.ProviderSpecificAlias, isAlias)
 		ep.SetProviderSpecificProperty(endpoint.ProviderSpecificAlias, strconv.FormatBool(isAlias))
 	}
@@ -900,6 +1136,7 @@ func (p *AWSProvider) adjustCNAMERecordAndNewAaaaIfNeeded(ep *endpoint.Endpoint)
 		ep.RecordType = endpoint.RecordTypeA
 		p.adjustAliasRecord(ep)
 		adjustGeoProximityLocationEndpoint(ep)
+		adjustCIDRRoutingEndpoint(ep)
 		aaaa := ep.DeepCopy()
 		aaaa.RecordType = endpoint.RecordTypeAAAA
 		return aaaa
@@ -914,6 +1151,7 @@ func (p *AWSProvider) adjustCNAMERecordAndNewAaaaIfNeeded(ep *endpoint.Endpoint)
 	}
 
 	adjustGeoProximityLocationEndpoint(ep)
+	adjustCIDRRoutingEndpoint(ep)
 	return nil
 }
 
@@ -949,15 +1187,11 @@ func (p *AWSProvider) newChange(action route53types.ChangeAction, ep *endpoint.E
 		},
 	}
 	change.ResourceRecordSet.Type = route53types.RRType(ep.RecordType)
-	if targetHostedZone := isAWSAlias(ep); targetHostedZone != "" {
-		evalTargetHealth := p.evaluateTargetHealth
-		if prop, exists := ep.GetBoolProviderSpecificProperty(providerSpecificEvaluateTargetHealth); exists {
-			evalTargetHealth = prop
-		}
+	if targetHostedZone := p.isAWSAlias(ep); targetHostedZone != "" {
 		change.ResourceRecordSet.AliasTarget = &route53types.AliasTarget{
 			DNSName:              aws.String(ep.Targets[0]),
 			HostedZoneId:         aws.String(cleanZoneID(targetHostedZone)),
-			EvaluateTargetHealth: evalTargetHealth,
+			EvaluateTargetHealth: p.evaluateTargetHealthOf(ep, targetHostedZone),
 		}
 		change.sizeBytes += len([]byte(ep.Targets[0]))
 		change.sizeValues += 1
@@ -969,6 +1203,9 @@ func (p *AWSProvider) newChange(action route53types.ChangeAction, ep *endpoint.E
 		}
 		change.ResourceRecordSet.ResourceRecords = make([]route53types.ResourceRecord, len(ep.Targets))
 		for idx, val := range ep.Targets {
+			if ep.RecordType == endpoint.RecordTypeTXT {
+				val = txt.Normalize(val)
+			}
 			change.ResourceRecordSet.ResourceRecords[idx] = route53types.ResourceRecord{
 				Value: aws.String(val),
 			}
@@ -1025,6 +1262,7 @@ func (p *AWSProvider) newChange(action route53types.ChangeAction, ep *endpoint.E
 	}
 
 	withChangeForGeoProximityEndpoint(change, ep)
+	withChangeForCIDRRoutingEndpoint(change, ep)
 
 	if prop, ok := ep.GetProviderSpecificProperty(providerSpecificHealthCheckID); ok {
 		change.ResourceRecordSet.HealthCheckId = aws.String(prop)
@@ -1359,13 +1597,13 @@ func suitableZones(hostname string, zones map[string]*profiledZone) []*profiledZ
 }
 
 // useAlias determines if AWS ALIAS should be used.
-func useAlias(ep *endpoint.Endpoint, preferCNAME bool) bool {
-	if preferCNAME {
+func (p *AWSProvider) useAlias(ep *endpoint.Endpoint) bool {
+	if p.preferCNAME {
 		return false
 	}
 
 	if ep.RecordType == endpoint.RecordTypeCNAME && len(ep.Targets) > 0 {
-		return canonicalHostedZone(ep.Targets[0]) != ""
+		return p.canonicalHostedZone(ep.Targets[0]) != ""
 	}
 
 	return false
@@ -1373,7 +1611,7 @@ func useAlias(ep *endpoint.Endpoint, preferCNAME bool) bool {
 
 // isAWSAlias determines if a given endpoint is supposed to create an AWS Alias record
 // and (if so) returns the target hosted zone ID
-func isAWSAlias(ep *endpoint.Endpoint) string {
+func (p *AWSProvider) isAWSAlias(ep *endpoint.Endpoint) string {
 	isAlias, _ := ep.GetBoolProviderSpecificProperty(endpoint.ProviderSpecificAlias)
 	if isAlias && slices.Contains([]string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA}, ep.RecordType) && len(ep.Targets) > 0 {
 		// alias records can only point to canonical hosted zones (e.g. to ELBs) or other records in the same zone
@@ -1384,7 +1622,7 @@ func isAWSAlias(ep *endpoint.Endpoint) string {
 		}
 
 		// check if the target is in a canonical hosted zone
-		if canonicalHostedZone := canonicalHostedZone(ep.Targets[0]); canonicalHostedZone != "" {
+		if canonicalHostedZone := p.canonicalHostedZone(ep.Targets[0]); canonicalHostedZone != "" {
 			return canonicalHostedZone
 		}
 
@@ -1394,7 +1632,78 @@ func isAWSAlias(ep *endpoint.Endpoint) string {
 	return ""
 }
 
-// canonicalHostedZone returns the matching canonical zone for a given hostname.
+// canonicalHostedZone returns the matching canonical zone for a given hostname,
+// looking up the zones of --aws-canonical-hosted-zones-file before the built-in ones.
+func (p *AWSProvider) canonicalHostedZone(hostname string) string {
+	if len(p.canonicalHostedZones) > 0 {
+		parts := strings.Split(strings.TrimSuffix(hostname, "."), ".")
+		// the longest matching suffix wins
+		for i := range parts {
+			if zone, exists := p.canonicalHostedZones[strings.Join(parts[i:], ".")]; exists {
+				return zone
+			}
+		}
+	}
+	return canonicalHostedZone(hostname)
+}
+
+// loadCanonicalHostedZones reads a YAML file mapping hostname suffixes, e.g.
+// elb.us-east-1.amazonaws.com, to the canonical hosted zone IDs of their alias targets.
+func loadCanonicalHostedZones(file string) (map[string]string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading canonical hosted zones file: %w", err)
+	}
+	var zones map[string]string
+	if err := yaml.Unmarshal(data, &zones); err != nil {
+		return nil, fmt.Errorf("parsing canonical hosted zones file %s: %w", file, err)
+	}
+	normalized := make(map[string]string, len(zones))
+	for suffix, zone := range zones {
+		suffix = strings.Trim(strings.ToLower(suffix), ".")
+		if suffix == "" || zone == "" {
+			return nil, fmt.Errorf("invalid canonical hosted zone %q: %q in %s", suffix, zone, file)
+		}
+		normalized[suffix] = cleanZoneID(zone)
+	}
+	return normalized, nil
+}
+
+// NormalizeEndpoint drops the "dualstack." prefix of the load balancer alias targets before
+// the plan compares the records, as Route53 resolves the load balancer names with and without
+// it for both A and AAAA alias records. The records are written and read as they are.
+func (p *AWSProvider) NormalizeEndpoint(ep *endpoint.Endpoint) {
+	if ep.GetAliasProperty() != endpoint.AliasTrue {
+		return
+	}
+	for i, target := range ep.Targets {
+		ep.Targets[i] = normalizeAliasTarget(target)
+	}
+}
+
+// normalizeAliasTarget returns the alias target hostname without the "dualstack." prefix
+// of load balancer names.
+func normalizeAliasTarget(target string) string {
+	if len(target) > len("dualstack.") && strings.EqualFold(target[:len("dualstack.")], "dualstack.") &&
+		isELBHostname(target[len("dualstack."):]) {
+		return target[len("dualstack."):]
+	}
+	return target
+}
+
+// isELBHostname returns whether hostname is the DNS name of an Elastic Load Balancing load
+// balancer: <name>.<region>.elb.amazonaws.com for the classic and application load balancers
+// and <name>.elb.<region>.amazonaws.com for the network load balancers.
+func isELBHostname(hostname string) bool {
+	hostname = strings.TrimSuffix(strings.ToLower(hostname), ".")
+	if strings.HasSuffix(hostname, ".elb.amazonaws.com") || strings.HasSuffix(hostname, ".elb.amazonaws.com.cn") {
+		return true
+	}
+	return strings.Contains(hostname, ".elb.") &&
+		(strings.HasSuffix(hostname, ".amazonaws.com") || strings.HasSuffix(hostname, ".amazonaws.com.cn"))
+}
+
+// canonicalHostedZone returns the matching built-in canonical zone for a given hostname.
 func canonicalHostedZone(hostname string) string {
 	// strings.HasSuffix is optimized for this specific task and avoids the overhead associated with compiling and executing a regular expression.
 	if strings.HasSuffix(hostname, "aws.com") || strings.HasSuffix(hostname, "aws.com.cn") || strings.HasSuffix(hostname, "tor.com") || strings.HasSuffix(hostname, "ont.com") || strings.HasSuffix(hostname, "ont.net") {
@@ -1424,7 +1733,7 @@ func cleanZoneID(id string) string {
 
 func (p *AWSProvider) SupportedRecordType(recordType route53types.RRType) bool {
 	switch recordType {
-	case route53types.RRTypeMx, route53types.RRTypeNaptr:
+	case route53types.RRTypeMx, route53types.RRTypeNaptr, route53types.RRTypeCaa:
 		return true
 	default:
 		return provider.SupportedRecordType(string(recordType))
@@ -23,7 +23,10 @@ import (
 	"maps"
 	"math"
 	"net"
+	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -31,6 +34,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/smithy-go"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -63,8 +67,12 @@ type Route53APIStub struct {
 	zones      map[string]*route53types.HostedZone
 	recordSets map[string]map[string][]route53types.ResourceRecordSet
 	zoneTags   map[string][]route53types.Tag
-	m          dynamicMock
-	t          *testing.T
+	// zones readable with GetHostedZone but owned by another account
+	sharedZones map[string]*route53types.HostedZone
+	// CIDR collection IDs by name
+	cidrCollections map[string]string
+	m               dynamicMock
+	t               *testing.T
 }
 
 // MockMethod starts a description of an expectation of the specified method
@@ -78,10 +86,12 @@ func (r *Route53APIStub) MockMethod(method string, args ...any) *mock.Call {
 // NewRoute53APIStub returns an initialized Route53APIStub
 func NewRoute53APIStub(t *testing.T) *Route53APIStub {
 	return &Route53APIStub{
-		zones:      make(map[string]*route53types.HostedZone),
-		recordSets: make(map[string]map[string][]route53types.ResourceRecordSet),
-		zoneTags:   make(map[string][]route53types.Tag),
-		t:          t,
+		zones:           make(map[string]*route53types.HostedZone),
+		recordSets:      make(map[string]map[string][]route53types.ResourceRecordSet),
+		zoneTags:        make(map[string][]route53types.Tag),
+		sharedZones:     make(map[string]*route53types.HostedZone),
+		cidrCollections: make(map[string]string),
+		t:               t,
 	}
 }
 
@@ -142,6 +152,26 @@ func (c *Route53APICounter) ListTagsForResources(ctx context.Context, input *rou
 	return c.wrapped.ListTagsForResources(ctx, input, optFns...)
 }
 
+func (c *Route53APICounter) GetHostedZone(ctx context.Context, input *route53.GetHostedZoneInput, optFns ...func(options *route53.Options)) (*route53.GetHostedZoneOutput, error) {
+	c.calls["GetHostedZone"]++
+	return c.wrapped.GetHostedZone(ctx, input, optFns...)
+}
+
+func (c *Route53APICounter) ListCidrCollections(ctx context.Context, input *route53.ListCidrCollectionsInput, optFns ...func(options *route53.Options)) (*route53.ListCidrCollectionsOutput, error) {
+	c.calls["ListCidrCollections"]++
+	return c.wrapped.ListCidrCollections(ctx, input, optFns...)
+}
+
+func (c *Route53APICounter) CreateCidrCollection(ctx context.Context, input *route53.CreateCidrCollectionInput, optFns ...func(options *route53.Options)) (*route53.CreateCidrCollectionOutput, error) {
+	c.calls["CreateCidrCollection"]++
+	return c.wrapped.CreateCidrCollection(ctx, input, optFns...)
+}
+
+func (c *Route53APICounter) DeleteCidrCollection(ctx context.Context, input *route53.DeleteCidrCollectionInput, optFns ...func(options *route53.Options)) (*route53.DeleteCidrCollectionOutput, error) {
+	c.calls["DeleteCidrCollection"]++
+	return c.wrapped.DeleteCidrCollection(ctx, input, optFns...)
+}
+
 // Route53 stores wildcards escaped: http://docs.aws.amazon.com/Route53/latest/DeveloperGuide/DomainNameFormat.html?shortFooter=true#domain-name-format-asterisk
 func wildcardEscape(s string) string {
 	if strings.Contains(s, "*") {
@@ -253,6 +283,16 @@ func (r *Route53APIStub) ListHostedZones(_ context.Context, _ *route53.ListHoste
 	return output, nil
 }
 
+func (r *Route53APIStub) GetHostedZone(_ context.Context, input *route53.GetHostedZoneInput, _ ...func(options *route53.Options)) (*route53.GetHostedZoneOutput, error) {
+	if zone, ok := r.zones[*input.Id]; ok {
+		return &route53.GetHostedZoneOutput{HostedZone: zone}, nil
+	}
+	if zone, ok := r.sharedZones[*input.Id]; ok {
+		return &route53.GetHostedZoneOutput{HostedZone: zone}, nil
+	}
+	return nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized to access hosted zone " + *input.Id}
+}
+
 func (r *Route53APIStub) CreateHostedZone(_ context.Context, input *route53.CreateHostedZoneInput, _ ...func(options *route53.Options)) (*route53.CreateHostedZoneOutput, error) {
 	name := *input.Name
 	id := "/hostedzone/" + name
@@ -267,6 +307,44 @@ func (r *Route53APIStub) CreateHostedZone(_ context.Context, input *route53.Crea
 	return &route53.CreateHostedZoneOutput{HostedZone: r.zones[id]}, nil
 }
 
+func (r *Route53APIStub) ListCidrCollections(_ context.Context, _ *route53.ListCidrCollectionsInput, _ ...func(options *route53.Options)) (*route53.ListCidrCollectionsOutput, error) {
+	output := &route53.ListCidrCollectionsOutput{}
+	for name, id := range r.cidrCollections {
+		output.CidrCollections = append(output.CidrCollections, route53types.CollectionSummary{Id: aws.String(id), Name: aws.String(name)})
+	}
+	return output, nil
+}
+
+func (r *Route53APIStub) CreateCidrCollection(_ context.Context, input *route53.CreateCidrCollectionInput, _ ...func(options *route53.Options)) (*route53.CreateCidrCollectionOutput, error) {
+	name := *input.Name
+	if _, ok := r.cidrCollections[name]; ok {
+		return nil, &route53types.CidrCollectionAlreadyExistsException{Message: aws.String(name + " already exists")}
+	}
+	id := "cidr-" + name
+	r.cidrCollections[name] = id
+	return &route53.CreateCidrCollectionOutput{Collection: &route53types.CidrCollection{Id: aws.String(id), Name: aws.String(name)}}, nil
+}
+
+func (r *Route53APIStub) DeleteCidrCollection(_ context.Context, input *route53.DeleteCidrCollectionInput, _ ...func(options *route53.Options)) (*route53.DeleteCidrCollectionOutput, error) {
+	id := *input.Id
+	for _, zoneRecordSets := range r.recordSets {
+		for _, recordSets := range zoneRecordSets {
+			for _, rs := range recordSets {
+				if rs.CidrRoutingConfig != nil && aws.ToString(rs.CidrRoutingConfig.CollectionId) == id {
+					return nil, &route53types.CidrCollectionInUseException{Message: aws.String(id + " is in use")}
+				}
+			}
+		}
+	}
+	for name, collectionID := range r.cidrCollections {
+		if collectionID == id {
+			delete(r.cidrCollections, name)
+			return &route53.DeleteCidrCollectionOutput{}, nil
+		}
+	}
+	return nil, &route53types.NoSuchCidrCollectionException{Message: aws.String(id + " does not exist")}
+}
+
 type dynamicMock struct {
 	mock.Mock
 }
@@ -464,6 +542,15 @@ func TestAWSRecords(t *testing.T) {
 				HostedZoneId:         aws.String("Z215JYRZR1TBD5"),
 			},
 		},
+		{
+			Name: aws.String("list-test-alias-dualstack.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type: route53types.RRTypeA,
+			AliasTarget: &route53types.AliasTarget{
+				DNSName:              aws.String("dualstack.foo.eu-central-1.elb.amazonaws.com."),
+				EvaluateTargetHealth: false,
+				HostedZoneId:         aws.String("Z215JYRZR1TBD5"),
+			},
+		},
 		{
 			Name: aws.String("*.wildcard-test-alias.zone-1.ext-dns-test-2.teapot.zalan.do."),
 			Type: route53types.RRTypeA,
@@ -648,6 +735,12 @@ func TestAWSRecords(t *testing.T) {
 			TTL:             aws.Int64(defaultTTL),
 			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String(`10 "U" "SIP+DTU" "" _sip._udp.sip1.example.com`)}, {Value: aws.String(`10 "U" "SIPS+D2T" "" _sips._tcp.sip1.example.com`)}},
 		},
+		{
+			Name:            aws.String("caa.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeCaa,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String(`0 issue "letsencrypt.org"`)}},
+		},
 	})
 
 	records, err := provider.Records(t.Context())
@@ -663,12 +756,13 @@ func TestAWSRecords(t *testing.T) {
 		endpoint.NewEndpointWithTTL("escape-%!s(<nil>)-codes-alias.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeAAAA, endpoint.TTL(defaultTTL), "escape-codes.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "false").WithAliasProperty(endpoint.AliasTrue),
 		endpoint.NewEndpointWithTTL("list-test-alias.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "false").WithAliasProperty(endpoint.AliasTrue),
 		endpoint.NewEndpointWithTTL("list-test-alias.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeAAAA, endpoint.TTL(defaultTTL), "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "false").WithAliasProperty(endpoint.AliasTrue),
+		endpoint.NewEndpointWithTTL("list-test-alias-dualstack.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "dualstack.foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "false").WithAliasProperty(endpoint.AliasTrue),
 		endpoint.NewEndpointWithTTL("*.wildcard-test-alias.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "false").WithAliasProperty(endpoint.AliasTrue),
 		endpoint.NewEndpointWithTTL("*.wildcard-test-alias.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeAAAA, endpoint.TTL(defaultTTL), "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "false").WithAliasProperty(endpoint.AliasTrue),
 		endpoint.NewEndpointWithTTL("list-test-alias-evaluate.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "true").WithAliasProperty(endpoint.AliasTrue),
 		endpoint.NewEndpointWithTTL("list-test-alias-evaluate.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeAAAA, endpoint.TTL(defaultTTL), "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "true").WithAliasProperty(endpoint.AliasTrue),
 		endpoint.NewEndpointWithTTL("list-test-multiple.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "8.8.8.8", "8.8.4.4"),
-		endpoint.NewEndpointWithTTL("prefix-*.wildcard.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeTXT, endpoint.TTL(defaultTTL), "random"),
+		endpoint.NewEndpointWithTTL("prefix-*.wildcard.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeTXT, endpoint.TTL(defaultTTL), "\"random\""),
 		endpoint.NewEndpointWithTTL("weight-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.2.3.4").WithSetIdentifier("test-set-1").WithProviderSpecific(providerSpecificWeight, "10"),
 		endpoint.NewEndpointWithTTL("weight-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "4.3.2.1").WithSetIdentifier("test-set-2").WithProviderSpecific(providerSpecificWeight, "20"),
 		endpoint.NewEndpointWithTTL("latency-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.2.3.4").WithSetIdentifier("test-set").WithProviderSpecific(providerSpecificRegion, "us-east-1"),
@@ -684,6 +778,7 @@ func TestAWSRecords(t *testing.T) {
 		endpoint.NewEndpointWithTTL("healthcheck-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "4.3.2.1").WithSetIdentifier("test-set-2").WithProviderSpecific(providerSpecificWeight, "20").WithProviderSpecific(providerSpecificHealthCheckID, "abc-def-healthcheck-id"),
 		endpoint.NewEndpointWithTTL("mail.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeMX, endpoint.TTL(defaultTTL), "10 mailhost1.example.com", "20 mailhost2.example.com"),
 		endpoint.NewEndpointWithTTL("naptr.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeNAPTR, endpoint.TTL(defaultTTL), `10 "U" "SIP+DTU" "" _sip._udp.sip1.example.com`, `10 "U" "SIPS+D2T" "" _sips._tcp.sip1.example.com`),
+		endpoint.NewEndpointWithTTL("caa.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCAA, endpoint.TTL(defaultTTL), `0 issue "letsencrypt.org"`),
 	})
 }
 
@@ -716,8 +811,15 @@ func TestAWSAdjustEndpoints(t *testing.T) {
 		endpoint.NewEndpoint("cname-test-elb-no-eth.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCNAME, "foo.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificEvaluateTargetHealth, "false"), // eth = evaluate target health
 		endpoint.NewEndpoint("cname-test-elb-alias.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCNAME, "foo.eu-central-1.elb.amazonaws.com").WithAliasProperty(endpoint.AliasTrue).WithProviderSpecific(providerSpecificEvaluateTargetHealth, "true"),
 		endpoint.NewEndpoint("a-test-geoproximity-no-bias.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.8.8").WithSetIdentifier("test-set-1").WithProviderSpecific(providerSpecificGeoProximityLocationAWSRegion, "us-west-2"),
+		endpoint.NewEndpoint("mx-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeMX, "10  mail.example.com", " 20 backup.example.com"),
+		endpoint.NewEndpoint("srv-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeSRV, "10 5  5060 sip.example.com."),
+		endpoint.NewEndpoint("caa-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCAA, "0 ISSUE letsencrypt.org"),
+		endpoint.NewEndpoint("mx-test-invalid.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeMX, "mail.example.com"),
+		endpoint.NewEndpoint("txt-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeTXT, "heritage=external-dns"),
 	}
 
+	testutils.TestHelperAdjustEndpointsContract(t, provider.AdjustEndpoints, records)
+
 	records, err := provider.AdjustEndpoints(records)
 	require.NoError(t, err)
 
@@ -734,6 +836,11 @@ func TestAWSAdjustEndpoints(t *testing.T) {
 		endpoint.NewEndpoint("cname-test-elb-alias.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "foo.eu-central-1.elb.amazonaws.com").WithAliasProperty(endpoint.AliasTrue).WithProviderSpecific(providerSpecificEvaluateTargetHealth, "true"),
 		endpoint.NewEndpoint("cname-test-elb-alias.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeAAAA, "foo.eu-central-1.elb.amazonaws.com").WithAliasProperty(endpoint.AliasTrue).WithProviderSpecific(providerSpecificEvaluateTargetHealth, "true"),
 		endpoint.NewEndpoint("a-test-geoproximity-no-bias.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.8.8").WithSetIdentifier("test-set-1").WithProviderSpecific(providerSpecificGeoProximityLocationAWSRegion, "us-west-2").WithProviderSpecific(providerSpecificGeoProximityLocationBias, "0"),
+		endpoint.NewEndpoint("mx-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeMX, "10 mail.example.com", "20 backup.example.com"),
+		endpoint.NewEndpoint("srv-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeSRV, "10 5 5060 sip.example.com."),
+		endpoint.NewEndpoint("caa-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCAA, `0 issue "letsencrypt.org"`),
+		endpoint.NewEndpoint("mx-test-invalid.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeMX, "mail.example.com"),
+		endpoint.NewEndpoint("txt-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeTXT, `"heritage=external-dns"`),
 	})
 }
 
@@ -2193,7 +2300,7 @@ func TestAWSisLoadBalancer(t *testing.T) {
 			Targets:    endpoint.Targets{tc.target},
 			RecordType: tc.recordType,
 		}
-		assert.Equal(t, tc.expected, useAlias(ep, tc.preferCNAME))
+		assert.Equal(t, tc.expected, (&AWSProvider{preferCNAME: tc.preferCNAME}).useAlias(ep))
 	}
 }
 
@@ -2221,7 +2328,7 @@ func TestAWSisAWSAlias(t *testing.T) {
 			ep = ep.WithAliasProperty(endpoint.AliasTrue)
 			ep = ep.WithProviderSpecific(providerSpecificTargetHostedZone, tc.hz)
 		}
-		assert.Equal(t, tc.hz, isAWSAlias(ep), "%v", tc)
+		assert.Equal(t, tc.hz, (&AWSProvider{}).isAWSAlias(ep), "%v", tc)
 	}
 }
 
@@ -2243,6 +2350,99 @@ func TestAWSCanonicalHostedZoneNotExist(t *testing.T) {
 	assert.Containsf(t, buf.String(), "Could not find canonical hosted zone for domain", host)
 }
 
+func TestAWSCanonicalHostedZoneFromFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "zones.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+service.example.aws: Z0000000SERVICE
+Beta.Service.Example.AWS.: /hostedzone/Z00000000000BETA
+eu-central-1.elb.amazonaws.com: Z000000OVERRIDE
+`), 0o600))
+	zones, err := loadCanonicalHostedZones(file)
+	require.NoError(t, err)
+	p := &AWSProvider{canonicalHostedZones: zones}
+
+	assert.Equal(t, "Z0000000SERVICE", p.canonicalHostedZone("foo.service.example.aws"))
+	assert.Equal(t, "Z00000000000BETA", p.canonicalHostedZone("foo.beta.service.example.aws."))
+	assert.Equal(t, "Z000000OVERRIDE", p.canonicalHostedZone("foo.eu-central-1.elb.amazonaws.com"))
+	assert.Equal(t, "Z2FDTNDATAQYW2", p.canonicalHostedZone("d111111abcdef8.cloudfront.net"))
+	assert.Empty(t, p.canonicalHostedZone("foo.example.org"))
+
+	assert.True(t, p.useAlias(&endpoint.Endpoint{RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"foo.service.example.aws"}}))
+}
+
+func TestAWSLoadCanonicalHostedZonesErrors(t *testing.T) {
+	_, err := loadCanonicalHostedZones(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "reading canonical hosted zones file")
+
+	for name, content := range map[string]string{
+		"not a mapping": "- foo",
+		"empty zone":    `foo.example.aws: ""`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "zones.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+			_, err := loadCanonicalHostedZones(file)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestAWSEvaluateTargetHealthPrecedence(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		target   string
+		def      bool
+		override string
+		expected bool
+	}{
+		{name: "provider default enabled", target: "foo.eu-central-1.elb.amazonaws.com", def: true, expected: true},
+		{name: "provider default disabled", target: "foo.eu-central-1.elb.amazonaws.com", expected: false},
+		{name: "record disables", target: "foo.eu-central-1.elb.amazonaws.com", def: true, override: "false", expected: false},
+		{name: "record enables", target: "foo.eu-central-1.elb.amazonaws.com", override: "true", expected: true},
+		{name: "s3 website", target: "bucket.s3-website-us-east-1.amazonaws.com", def: true, expected: true},
+		{name: "cloudfront ignores default", target: "d111111abcdef8.cloudfront.net", def: true, expected: false},
+		{name: "cloudfront ignores record", target: "d111111abcdef8.cloudfront.net", override: "true", expected: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &AWSProvider{evaluateTargetHealth: tc.def}
+			ep := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeCNAME, tc.target)
+			if tc.override != "" {
+				ep = ep.WithProviderSpecific(providerSpecificEvaluateTargetHealth, tc.override)
+			}
+
+			adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{ep})
+			require.NoError(t, err)
+			require.Len(t, adjusted, 2)
+			for _, a := range adjusted {
+				value, _ := a.GetProviderSpecificProperty(providerSpecificEvaluateTargetHealth)
+				assert.Equal(t, strconv.FormatBool(tc.expected), value)
+
+				change := p.newChange(route53types.ChangeActionCreate, a)
+				require.NotNil(t, change.ResourceRecordSet.AliasTarget)
+				assert.Equal(t, tc.expected, change.ResourceRecordSet.AliasTarget.EvaluateTargetHealth)
+			}
+		})
+	}
+}
+
+func TestAWSNewChangeTXT(t *testing.T) {
+	p := &AWSProvider{}
+	long := strings.Repeat("a", 300)
+	ep := endpoint.NewEndpoint("txt.example.org", endpoint.RecordTypeTXT, "heritage=external-dns", long, `"already quoted"`)
+
+	change := p.newChange(route53types.ChangeActionCreate, ep)
+
+	var values []string
+	for _, rr := range change.ResourceRecordSet.ResourceRecords {
+		values = append(values, *rr.Value)
+	}
+	assert.Equal(t, []string{
+		`"heritage=external-dns"`,
+		`"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 45) + `"`,
+		`"already quoted"`,
+	}, values)
+}
+
 func BenchmarkTestAWSCanonicalHostedZone(b *testing.B) {
 	for b.Loop() {
 		for suffix := range canonicalHostedZones {
@@ -2518,6 +2718,41 @@ func TestConvertOctalToAscii(t *testing.T) {
 	}
 }
 
+func TestOctalToDecimalEscapes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "no escapes",
+			input:    `"heritage=external-dns"`,
+			expected: `"heritage=external-dns"`,
+		},
+		{
+			name:     "utf-8 bytes escaped in octal",
+			input:    `"caf\303\251"`,
+			expected: `"caf\195\169"`,
+		},
+		{
+			name:     "escaped characters kept",
+			input:    `"say \"hi\" \\ \052"`,
+			expected: `"say \"hi\" \\ \042"`,
+		},
+		{
+			name:     "trailing backslash kept",
+			input:    `value\`,
+			expected: `value\`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, octalToDecimalEscapes(tt.input))
+		})
+	}
+}
+
 func TestGeoProximityWithAWSRegion(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -3368,6 +3603,74 @@ func TestAWSProvider_adjustEndpointAndNewAaaaIfNeeded(t *testing.T) {
 			},
 			expectedAaaa: nil,
 		},
+		{
+			name: "CNAME record with a dualstack load balancer target should keep the prefix and create AAAA",
+			ep: &endpoint.Endpoint{
+				DNSName:    "test.foo.bar.",
+				RecordType: endpoint.RecordTypeCNAME,
+				Targets:    endpoint.Targets{"dualstack.my-lb-1234.us-east-1.elb.amazonaws.com"},
+			},
+			expected: &endpoint.Endpoint{
+				DNSName:    "test.foo.bar.",
+				RecordType: endpoint.RecordTypeA,
+				Targets:    endpoint.Targets{"dualstack.my-lb-1234.us-east-1.elb.amazonaws.com"},
+				ProviderSpecific: endpoint.ProviderSpecific{
+					{
+						Name:  endpoint.ProviderSpecificAlias,
+						Value: "true",
+					},
+					{
+						Name:  providerSpecificEvaluateTargetHealth,
+						Value: "false",
+					},
+				},
+			},
+			expectedAaaa: &endpoint.Endpoint{
+				DNSName:    "test.foo.bar.",
+				RecordType: endpoint.RecordTypeAAAA,
+				Targets:    endpoint.Targets{"dualstack.my-lb-1234.us-east-1.elb.amazonaws.com"},
+				ProviderSpecific: endpoint.ProviderSpecific{
+					{
+						Name:  endpoint.ProviderSpecificAlias,
+						Value: "true",
+					},
+					{
+						Name:  providerSpecificEvaluateTargetHealth,
+						Value: "false",
+					},
+				},
+			},
+		},
+		{
+			name: "AAAA record with alias=AAAA should be an alias record",
+			ep: &endpoint.Endpoint{
+				DNSName:    "test.foo.bar.",
+				RecordType: endpoint.RecordTypeAAAA,
+				Targets:    endpoint.Targets{"my-lb-1234.us-east-1.elb.amazonaws.com."},
+				ProviderSpecific: endpoint.ProviderSpecific{
+					{
+						Name:  endpoint.ProviderSpecificAlias,
+						Value: "AAAA",
+					},
+				},
+			},
+			expected: &endpoint.Endpoint{
+				DNSName:    "test.foo.bar.",
+				RecordType: endpoint.RecordTypeAAAA,
+				Targets:    endpoint.Targets{"my-lb-1234.us-east-1.elb.amazonaws.com."},
+				ProviderSpecific: endpoint.ProviderSpecific{
+					{
+						Name:  endpoint.ProviderSpecificAlias,
+						Value: "AAAA",
+					},
+					{
+						Name:  providerSpecificEvaluateTargetHealth,
+						Value: "false",
+					},
+				},
+			},
+			expectedAaaa: nil,
+		},
 
 		// --- MX / other records ---
 		{
@@ -3413,3 +3716,57 @@ func TestAWSProvider_adjustEndpointAndNewAaaaIfNeeded(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeAliasTarget(t *testing.T) {
+	for _, tt := range []struct {
+		target   string
+		expected string
+	}{
+		{"dualstack.my-lb-1234.us-east-1.elb.amazonaws.com", "my-lb-1234.us-east-1.elb.amazonaws.com"},
+		{"dualstack.my-lb-1234.us-east-1.elb.amazonaws.com.", "my-lb-1234.us-east-1.elb.amazonaws.com."},
+		{"DualStack.My-LB-1234.cn-north-1.elb.amazonaws.com.cn", "My-LB-1234.cn-north-1.elb.amazonaws.com.cn"},
+		{"my-nlb-1234.elb.us-east-1.amazonaws.com", "my-nlb-1234.elb.us-east-1.amazonaws.com"},
+		{"dualstack.my-nlb-1234.elb.us-east-1.amazonaws.com.", "my-nlb-1234.elb.us-east-1.amazonaws.com."},
+		{"dualstack.my-nlb-1234.elb.cn-north-1.amazonaws.com.cn", "my-nlb-1234.elb.cn-north-1.amazonaws.com.cn"},
+		{"dualstack.bucket.s3.amazonaws.com", "dualstack.bucket.s3.amazonaws.com"},
+		{"dualstack.example.com", "dualstack.example.com"},
+		{"dualstack.", "dualstack."},
+		{"d1234.cloudfront.net", "d1234.cloudfront.net"},
+	} {
+		t.Run(tt.target, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizeAliasTarget(tt.target))
+		})
+	}
+}
+
+func TestAWSProvider_NormalizeEndpoint(t *testing.T) {
+	p := &AWSProvider{}
+
+	alias := endpoint.NewEndpoint("test.foo.bar", endpoint.RecordTypeA, "dualstack.my-lb-1234.us-east-1.elb.amazonaws.com").
+		WithAliasProperty(endpoint.AliasTrue)
+	p.NormalizeEndpoint(alias)
+	assert.Equal(t, endpoint.Targets{"my-lb-1234.us-east-1.elb.amazonaws.com"}, alias.Targets)
+
+	cname := endpoint.NewEndpoint("test.foo.bar", endpoint.RecordTypeCNAME, "dualstack.my-lb-1234.us-east-1.elb.amazonaws.com").
+		WithAliasProperty(endpoint.AliasFalse)
+	p.NormalizeEndpoint(cname)
+	assert.Equal(t, endpoint.Targets{"dualstack.my-lb-1234.us-east-1.elb.amazonaws.com"}, cname.Targets)
+}
+
+func TestAWSPlanIgnoresDualstackPrefix(t *testing.T) {
+	p := &AWSProvider{}
+	current := endpoint.NewEndpoint("test.foo.bar", endpoint.RecordTypeA, "dualstack.my-lb-1234.us-east-1.elb.amazonaws.com").
+		WithAliasProperty(endpoint.AliasTrue)
+	desired := endpoint.NewEndpoint("test.foo.bar", endpoint.RecordTypeA, "my-lb-1234.us-east-1.elb.amazonaws.com").
+		WithAliasProperty(endpoint.AliasTrue)
+
+	changes := (&plan.Plan{
+		Policies:       []plan.Policy{&plan.SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current},
+		Desired:        []*endpoint.Endpoint{desired},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		Normalizers:    []plan.Normalizer{plan.NormalizerFunc(p.NormalizeEndpoint)},
+	}).Calculate().Changes
+	assert.False(t, changes.HasChanges())
+	assert.Equal(t, endpoint.Targets{"dualstack.my-lb-1234.us-east-1.elb.amazonaws.com"}, current.Targets, "the records are not modified")
+}
@@ -117,6 +117,26 @@ func (r Route53APIFixtureStub) CreateHostedZone(_ context.Context, _ *route53.Cr
 	panic("implement me")
 }
 
+func (r Route53APIFixtureStub) GetHostedZone(_ context.Context, _ *route53.GetHostedZoneInput, _ ...func(options *route53.Options)) (*route53.GetHostedZoneOutput, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (r Route53APIFixtureStub) ListCidrCollections(_ context.Context, _ *route53.ListCidrCollectionsInput, _ ...func(options *route53.Options)) (*route53.ListCidrCollectionsOutput, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (r Route53APIFixtureStub) CreateCidrCollection(_ context.Context, _ *route53.CreateCidrCollectionInput, _ ...func(options *route53.Options)) (*route53.CreateCidrCollectionOutput, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (r Route53APIFixtureStub) DeleteCidrCollection(_ context.Context, _ *route53.DeleteCidrCollectionInput, _ ...func(options *route53.Options)) (*route53.DeleteCidrCollectionOutput, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (r Route53APIFixtureStub) ListHostedZones(_ context.Context, _ *route53.ListHostedZonesInput, _ ...func(options *route53.Options)) (*route53.ListHostedZonesOutput, error) {
 	r.calls["listhostedzones"]++
 	output := &route53.ListHostedZonesOutput{}
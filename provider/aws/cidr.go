@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// adjustCIDRRoutingEndpoint drops the CIDR routing properties of an endpoint
+// which cannot be rendered into a CIDR routing policy: both the collection and
+// the location are required, as well as a set identifier.
+func adjustCIDRRoutingEndpoint(ep *endpoint.Endpoint) {
+	collection, hasCollection := ep.GetProviderSpecificProperty(providerSpecificCIDRCollection)
+	location, hasLocation := ep.GetProviderSpecificProperty(providerSpecificCIDRLocation)
+	if !hasCollection && !hasLocation {
+		return
+	}
+	if ep.SetIdentifier != "" && collection != "" && location != "" {
+		return
+	}
+	log.Warnf("Ignoring CIDR routing of name=%s setIdentifier=%s: %s, %s and a set identifier are all required",
+		ep.DNSName, ep.SetIdentifier, providerSpecificCIDRCollection, providerSpecificCIDRLocation)
+	ep.DeleteProviderSpecificProperty(providerSpecificCIDRCollection)
+	ep.DeleteProviderSpecificProperty(providerSpecificCIDRLocation)
+}
+
+// withChangeForCIDRRoutingEndpoint sets the CIDR routing policy of the change.
+// The collection is referenced by name until resolveCIDRCollections looks up its ID.
+func withChangeForCIDRRoutingEndpoint(change *Route53Change, ep *endpoint.Endpoint) {
+	collection, ok := ep.GetProviderSpecificProperty(providerSpecificCIDRCollection)
+	if !ok {
+		return
+	}
+	location, ok := ep.GetProviderSpecificProperty(providerSpecificCIDRLocation)
+	if !ok {
+		return
+	}
+	change.cidrCollection = collection
+	change.ResourceRecordSet.CidrRoutingConfig = &route53types.CidrRoutingConfig{
+		LocationName: aws.String(location),
+	}
+}
+
+// handleCIDRRoutingRecord sets the CIDR routing properties of ep from the record
+// set, referencing the collection by name.
+func (p *AWSProvider) handleCIDRRoutingRecord(ctx context.Context, profile string, r *route53types.ResourceRecordSet, ep *endpoint.Endpoint) error {
+	id := aws.ToString(r.CidrRoutingConfig.CollectionId)
+	name, err := p.cidrCollectionName(ctx, profile, id)
+	if err != nil {
+		return provider.NewSoftErrorf("failed to list CIDR collections using aws profile %q: %w", profile, err)
+	}
+	ep.WithProviderSpecific(providerSpecificCIDRCollection, name)
+	ep.WithProviderSpecific(providerSpecificCIDRLocation, aws.ToString(r.CidrRoutingConfig.LocationName))
+	return nil
+}
+
+// resolveCIDRCollections sets the collection ID of the changes with a CIDR
+// routing policy, creating the collections which do not exist yet.
+func (p *AWSProvider) resolveCIDRCollections(ctx context.Context, profile string, changes Route53Changes) error {
+	for _, c := range changes {
+		if c.cidrCollection == "" {
+			continue
+		}
+		id, err := p.cidrCollectionID(ctx, profile, c.cidrCollection)
+		if err != nil {
+			return err
+		}
+		c.ResourceRecordSet.CidrRoutingConfig.CollectionId = aws.String(id)
+	}
+	return nil
+}
+
+// cidrCollectionID returns the ID of the named CIDR collection of the profile,
+// creating the collection when it does not exist. Locations and their CIDR
+// blocks are not managed and must be added to the collection separately.
+func (p *AWSProvider) cidrCollectionID(ctx context.Context, profile, name string) (string, error) {
+	p.cidrCollectionsLock.Lock()
+	defer p.cidrCollectionsLock.Unlock()
+
+	if id, ok := p.cidrCollections[profile][name]; ok {
+		return id, nil
+	}
+	if err := p.refreshCIDRCollections(ctx, profile); err != nil {
+		return "", fmt.Errorf("failed to list CIDR collections: %w", err)
+	}
+	if id, ok := p.cidrCollections[profile][name]; ok {
+		return id, nil
+	}
+
+	log.Infof("Creating CIDR collection %q using aws profile %q", name, profile)
+	out, err := p.clients[profile].CreateCidrCollection(ctx, &route53.CreateCidrCollectionInput{
+		Name:            aws.String(name),
+		CallerReference: aws.String(fmt.Sprintf("external-dns-%s-%d", name, time.Now().UnixNano())),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create CIDR collection %q: %w", name, err)
+	}
+	id := aws.ToString(out.Collection.Id)
+	p.cidrCollections[profile][name] = id
+	return id, nil
+}
+
+// cidrCollectionName returns the name of the CIDR collection with the given ID,
+// or the ID itself when the collection is unknown.
+func (p *AWSProvider) cidrCollectionName(ctx context.Context, profile, id string) (string, error) {
+	p.cidrCollectionsLock.Lock()
+	defer p.cidrCollectionsLock.Unlock()
+
+	for refreshed := false; ; refreshed = true {
+		for name, collectionID := range p.cidrCollections[profile] {
+			if collectionID == id {
+				return name, nil
+			}
+		}
+		if refreshed {
+			return id, nil
+		}
+		if err := p.refreshCIDRCollections(ctx, profile); err != nil {
+			return "", err
+		}
+	}
+}
+
+// refreshCIDRCollections lists the CIDR collections of the profile. The caller
+// must hold cidrCollectionsLock.
+func (p *AWSProvider) refreshCIDRCollections(ctx context.Context, profile string) error {
+	collections := make(map[string]string)
+	paginator := route53.NewListCidrCollectionsPaginator(p.clients[profile], &route53.ListCidrCollectionsInput{})
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, c := range resp.CidrCollections {
+			collections[aws.ToString(c.Name)] = aws.ToString(c.Id)
+		}
+	}
+	if p.cidrCollections == nil {
+		p.cidrCollections = make(map[string]map[string]string)
+	}
+	p.cidrCollections[profile] = collections
+	return nil
+}
+
+// deletedCIDRCollectionIDs returns the IDs of the CIDR collections referenced
+// by the deleted records of the changes.
+func deletedCIDRCollectionIDs(changes Route53Changes) []string {
+	var ids []string
+	for _, c := range changes {
+		if c.Action != route53types.ChangeActionDelete || c.cidrCollection == "" {
+			continue
+		}
+		ids = append(ids, aws.ToString(c.ResourceRecordSet.CidrRoutingConfig.CollectionId))
+	}
+	return ids
+}
+
+// deleteUnusedCIDRCollections deletes the CIDR collections of the profile which
+// are neither referenced by a record nor hold locations anymore. Route53 refuses
+// to delete the others, which are kept.
+func (p *AWSProvider) deleteUnusedCIDRCollections(ctx context.Context, profile string, ids []string) {
+	slices.Sort(ids)
+	for _, id := range slices.Compact(ids) {
+		_, err := p.clients[profile].DeleteCidrCollection(ctx, &route53.DeleteCidrCollectionInput{Id: aws.String(id)})
+		var inUse *route53types.CidrCollectionInUseException
+		var noSuch *route53types.NoSuchCidrCollectionException
+		switch {
+		case errors.As(err, &inUse), errors.As(err, &noSuch):
+			log.Debugf("Keeping CIDR collection %q using aws profile %q: %v", id, profile, err)
+			continue
+		case err != nil:
+			log.Warnf("Failed to delete CIDR collection %q using aws profile %q: %v", id, profile, err)
+			continue
+		}
+
+		log.Infof("Deleted unused CIDR collection %q using aws profile %q", id, profile)
+		p.cidrCollectionsLock.Lock()
+		for name, collectionID := range p.cidrCollections[profile] {
+			if collectionID == id {
+				delete(p.cidrCollections[profile], name)
+			}
+		}
+		p.cidrCollectionsLock.Unlock()
+	}
+}
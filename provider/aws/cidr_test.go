@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+func TestAWSCIDRRouting(t *testing.T) {
+	p, client := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, false, nil)
+	client.cidrCollections["offices"] = "cidr-offices-id"
+
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("cidr-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.2.3.4").
+			WithSetIdentifier("hq").
+			WithProviderSpecific(providerSpecificCIDRCollection, "offices").
+			WithProviderSpecific(providerSpecificCIDRLocation, "hq"),
+		endpoint.NewEndpoint("cidr-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "5.6.7.8").
+			WithSetIdentifier("default").
+			WithProviderSpecific(providerSpecificCIDRCollection, "branches").
+			WithProviderSpecific(providerSpecificCIDRLocation, "*"),
+	}
+	adjusted, err := p.AdjustEndpoints(records)
+	require.NoError(t, err)
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Create: adjusted}))
+
+	// the missing collection is created
+	assert.Equal(t, map[string]string{"offices": "cidr-offices-id", "branches": "cidr-branches"}, client.cidrCollections)
+
+	recordSets := listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do.")
+	configs := map[string]*route53types.CidrRoutingConfig{}
+	for _, rs := range recordSets {
+		configs[aws.ToString(rs.SetIdentifier)] = rs.CidrRoutingConfig
+	}
+	assert.Equal(t, map[string]*route53types.CidrRoutingConfig{
+		"hq":      {CollectionId: aws.String("cidr-offices-id"), LocationName: aws.String("hq")},
+		"default": {CollectionId: aws.String("cidr-branches"), LocationName: aws.String("*")},
+	}, configs)
+
+	// records reference the collections by name again
+	p.cidrCollections = nil
+	endpoints, err := p.Records(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+	for _, ep := range endpoints {
+		collection, _ := ep.GetProviderSpecificProperty(providerSpecificCIDRCollection)
+		location, _ := ep.GetProviderSpecificProperty(providerSpecificCIDRLocation)
+		switch ep.SetIdentifier {
+		case "hq":
+			assert.Equal(t, "offices", collection)
+			assert.Equal(t, "hq", location)
+		case "default":
+			assert.Equal(t, "branches", collection)
+			assert.Equal(t, "*", location)
+		default:
+			t.Errorf("unexpected endpoint %v", ep)
+		}
+	}
+}
+
+func TestAWSCIDRRoutingDeletesUnusedCollections(t *testing.T) {
+	p, client := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, false, nil)
+
+	newRecord := func(setIdentifier, collection string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint("cidr-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.2.3.4").
+			WithSetIdentifier(setIdentifier).
+			WithProviderSpecific(providerSpecificCIDRCollection, collection).
+			WithProviderSpecific(providerSpecificCIDRLocation, "*")
+	}
+	records := []*endpoint.Endpoint{newRecord("a", "offices"), newRecord("b", "offices"), newRecord("c", "branches")}
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Create: records}))
+	require.Len(t, client.cidrCollections, 2)
+
+	// offices is still referenced by the record b
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Delete: []*endpoint.Endpoint{newRecord("a", "offices"), newRecord("c", "branches")}}))
+	assert.Equal(t, map[string]string{"offices": "cidr-offices"}, client.cidrCollections)
+	assert.Equal(t, map[string]string{"offices": "cidr-offices"}, p.cidrCollections[defaultAWSProfile])
+
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Delete: []*endpoint.Endpoint{newRecord("b", "offices")}}))
+	assert.Empty(t, client.cidrCollections)
+}
+
+func TestAWSCIDRRoutingDryRun(t *testing.T) {
+	p, client := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, true, nil)
+
+	ep := endpoint.NewEndpoint("cidr-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.2.3.4").
+		WithSetIdentifier("hq").
+		WithProviderSpecific(providerSpecificCIDRCollection, "offices").
+		WithProviderSpecific(providerSpecificCIDRLocation, "hq")
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Create: []*endpoint.Endpoint{ep}}))
+
+	assert.Empty(t, client.cidrCollections)
+}
+
+func TestAdjustCIDRRoutingEndpoint(t *testing.T) {
+	tests := []struct {
+		name          string
+		setIdentifier string
+		collection    string
+		location      string
+		expectKept    bool
+	}{
+		{name: "complete", setIdentifier: "hq", collection: "offices", location: "hq", expectKept: true},
+		{name: "default location", setIdentifier: "other", collection: "offices", location: "*", expectKept: true},
+		{name: "missing location", setIdentifier: "hq", collection: "offices"},
+		{name: "missing collection", setIdentifier: "hq", location: "hq"},
+		{name: "missing set identifier", collection: "offices", location: "hq"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep := endpoint.NewEndpoint("cidr-test.example.com", endpoint.RecordTypeA, "1.2.3.4").WithSetIdentifier(tt.setIdentifier)
+			if tt.collection != "" {
+				ep.WithProviderSpecific(providerSpecificCIDRCollection, tt.collection)
+			}
+			if tt.location != "" {
+				ep.WithProviderSpecific(providerSpecificCIDRLocation, tt.location)
+			}
+
+			adjustCIDRRoutingEndpoint(ep)
+
+			_, hasCollection := ep.GetProviderSpecificProperty(providerSpecificCIDRCollection)
+			_, hasLocation := ep.GetProviderSpecificProperty(providerSpecificCIDRLocation)
+			assert.Equal(t, tt.expectKept, hasCollection)
+			assert.Equal(t, tt.expectKept, hasLocation)
+		})
+	}
+}
+
+func TestAWSRequiresDeleteCreateCIDRRouting(t *testing.T) {
+	p, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, false, nil)
+
+	weighted := endpoint.NewEndpoint("cidr-test.example.com", endpoint.RecordTypeA, "1.2.3.4").
+		WithSetIdentifier("hq").
+		WithProviderSpecific(providerSpecificWeight, "10")
+	cidr := endpoint.NewEndpoint("cidr-test.example.com", endpoint.RecordTypeA, "1.2.3.4").
+		WithSetIdentifier("hq").
+		WithProviderSpecific(providerSpecificCIDRCollection, "offices").
+		WithProviderSpecific(providerSpecificCIDRLocation, "hq")
+	otherLocation := cidr.DeepCopy()
+	otherLocation.SetProviderSpecificProperty(providerSpecificCIDRLocation, "branch")
+
+	assert.True(t, p.requiresDeleteCreate(weighted, cidr))
+	assert.False(t, p.requiresDeleteCreate(cidr, otherLocation))
+}
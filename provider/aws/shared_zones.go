@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53profiles"
+	profilestypes "github.com/aws/aws-sdk-go-v2/service/route53profiles/types"
+	"github.com/aws/smithy-go"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// hostedZoneResourceType is the resource type of hosted zones associated with a Route 53 Profile.
+	hostedZoneResourceType = "AWS::Route53::HostedZone"
+	// hostedZoneARNPrefix precedes the hosted zone ID in its ARN, e.g. arn:aws:route53:::hostedzone/Z123.
+	hostedZoneARNPrefix = "hostedzone/"
+	// sharedZoneTagsCacheDuration is how long the tags of a shared hosted zone are cached.
+	sharedZoneTagsCacheDuration = time.Hour
+)
+
+// cachedZoneTags are the tags of a hosted zone.
+type cachedZoneTags struct {
+	tags    map[string]string
+	expires time.Time
+}
+
+// Route53ProfilesAPI is the subset of the AWS Route53 Profiles API that we actually use.
+// Add methods as required. Signatures must match exactly.
+type Route53ProfilesAPI interface {
+	ListProfiles(ctx context.Context, input *route53profiles.ListProfilesInput, optFns ...func(*route53profiles.Options)) (*route53profiles.ListProfilesOutput, error)
+	ListProfileResourceAssociations(ctx context.Context, input *route53profiles.ListProfileResourceAssociationsInput, optFns ...func(*route53profiles.Options)) (*route53profiles.ListProfileResourceAssociationsOutput, error)
+}
+
+// listSharedZones returns the hosted zones associated with the Route 53 Profiles
+// shared with the account of the given AWS profile through AWS RAM. Zones
+// the account is not allowed to read are skipped with a warning so that the
+// zones it owns are still managed.
+func (p *AWSProvider) listSharedZones(ctx context.Context, profile string) (map[string]*profiledZone, error) {
+	zones := make(map[string]*profiledZone)
+	profilesClient, ok := p.profilesClients[profile]
+	if !ok {
+		return zones, nil
+	}
+
+	profileIDs, err := p.sharedProfileIDs(ctx, profilesClient)
+	if err != nil {
+		if isAccessDenied(err) {
+			log.Warnf("Skipping shared zones of AWS profile %q, not allowed to list Route 53 Profiles: %v", profile, err)
+			return zones, nil
+		}
+		return nil, provider.NewSoftErrorf("failed to list Route 53 Profiles: %w", err)
+	}
+
+	for _, profileID := range profileIDs {
+		zoneIDs, err := sharedZoneIDs(ctx, profilesClient, profileID)
+		if err != nil {
+			var te *profilestypes.ThrottlingException
+			if errors.As(err, &te) || isAccessDenied(err) {
+				log.Warnf("Skipping shared zones of Route 53 Profile %q: %v", profileID, err)
+				continue
+			}
+			return nil, provider.NewSoftErrorf("failed to list resources of Route 53 Profile %q: %w", profileID, err)
+		}
+
+		for _, zoneID := range zoneIDs {
+			resp, err := p.clients[profile].GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+			if err != nil {
+				if isAccessDenied(err) {
+					log.Warnf("Skipping hosted zone %q shared by Route 53 Profile %q, not allowed to read it: %v", zoneID, profileID, err)
+					continue
+				}
+				return nil, provider.NewSoftErrorf("failed to get shared hosted zone %q: %w", zoneID, err)
+			}
+			zones[*resp.HostedZone.Id] = &profiledZone{
+				profile: profile,
+				zone:    resp.HostedZone,
+			}
+		}
+	}
+	return zones, nil
+}
+
+// sharedProfileIDs returns the IDs of the Route 53 Profiles to discover shared
+// zones from: the configured ones, or all profiles shared with the account.
+func (p *AWSProvider) sharedProfileIDs(ctx context.Context, client Route53ProfilesAPI) ([]string, error) {
+	var ids []string
+	paginator := route53profiles.NewListProfilesPaginator(client, &route53profiles.ListProfilesInput{})
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range resp.ProfileSummaries {
+			id := aws.ToString(summary.Id)
+			if len(p.sharedZonesProfileIDs) > 0 {
+				if slices.Contains(p.sharedZonesProfileIDs, id) {
+					ids = append(ids, id)
+				}
+				continue
+			}
+			// zones of profiles owned by the account are already listed as hosted zones
+			if summary.ShareStatus == profilestypes.ShareStatusSharedWithMe {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// sharedZoneIDs returns the IDs of the hosted zones associated with a Route 53 Profile.
+func sharedZoneIDs(ctx context.Context, client Route53ProfilesAPI, profileID string) ([]string, error) {
+	var ids []string
+	paginator := route53profiles.NewListProfileResourceAssociationsPaginator(client, &route53profiles.ListProfileResourceAssociationsInput{
+		ProfileId:    aws.String(profileID),
+		ResourceType: aws.String(hostedZoneResourceType),
+	})
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, association := range resp.ProfileResourceAssociations {
+			if association.Status != profilestypes.ProfileStatusComplete {
+				log.Debugf("Ignoring resource %q of Route 53 Profile %q in status %s", aws.ToString(association.ResourceArn), profileID, association.Status)
+				continue
+			}
+			arn := aws.ToString(association.ResourceArn)
+			i := strings.LastIndex(arn, hostedZoneARNPrefix)
+			if i < 0 {
+				continue
+			}
+			ids = append(ids, "/hostedzone/"+arn[i+len(hostedZoneARNPrefix):])
+		}
+	}
+	return ids, nil
+}
+
+// tagsForSharedZones returns the tags of the shared hosted zones, only looking
+// up the zones whose tags are not cached or expired. Unlike the zones owned
+// by the account, shared zones are not covered by --aws-zones-cache-duration,
+// as they are listed through the Route 53 Profiles API.
+func (p *AWSProvider) tagsForSharedZones(ctx context.Context, zoneIDs []string, profile string) (zoneTags, error) {
+	p.sharedZoneTagsLock.Lock()
+	defer p.sharedZoneTagsLock.Unlock()
+
+	if p.sharedZoneTags == nil {
+		p.sharedZoneTags = make(map[string]cachedZoneTags)
+	}
+	now := time.Now()
+	for id, cached := range p.sharedZoneTags {
+		if now.After(cached.expires) {
+			delete(p.sharedZoneTags, id)
+		}
+	}
+	var missing []string
+	for _, id := range zoneIDs {
+		if _, ok := p.sharedZoneTags[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		fetched, err := p.tagsForZone(ctx, missing, profile)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range missing {
+			tags := fetched["/hostedzone/"+id]
+			if tags == nil {
+				tags = map[string]string{}
+			}
+			p.sharedZoneTags[id] = cachedZoneTags{
+				tags:    tags,
+				expires: now.Add(sharedZoneTagsCacheDuration),
+			}
+		}
+	}
+
+	result := zoneTags{}
+	for _, id := range zoneIDs {
+		result["/hostedzone/"+id] = p.sharedZoneTags[id].tags
+	}
+	return result, nil
+}
+
+// isAccessDenied returns true when err is an AWS authorization failure.
+func isAccessDenied(err error) bool {
+	var ae smithy.APIError
+	if !errors.As(err, &ae) {
+		return false
+	}
+	return strings.HasPrefix(ae.ErrorCode(), "AccessDenied")
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53profiles"
+	profilestypes "github.com/aws/aws-sdk-go-v2/service/route53profiles/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/blueprint"
+)
+
+var _ Route53ProfilesAPI = &route53ProfilesStub{}
+
+// route53ProfilesStub serves Route 53 Profiles and their hosted zone associations.
+type route53ProfilesStub struct {
+	profiles        []profilestypes.ProfileSummary
+	associations    map[string][]profilestypes.ProfileResourceAssociation
+	listErr         error
+	associationsErr map[string]error
+}
+
+func (r *route53ProfilesStub) ListProfiles(_ context.Context, _ *route53profiles.ListProfilesInput, _ ...func(*route53profiles.Options)) (*route53profiles.ListProfilesOutput, error) {
+	if r.listErr != nil {
+		return nil, r.listErr
+	}
+	return &route53profiles.ListProfilesOutput{ProfileSummaries: r.profiles}, nil
+}
+
+func (r *route53ProfilesStub) ListProfileResourceAssociations(_ context.Context, input *route53profiles.ListProfileResourceAssociationsInput, _ ...func(*route53profiles.Options)) (*route53profiles.ListProfileResourceAssociationsOutput, error) {
+	if err := r.associationsErr[*input.ProfileId]; err != nil {
+		return nil, err
+	}
+	return &route53profiles.ListProfileResourceAssociationsOutput{ProfileResourceAssociations: r.associations[*input.ProfileId]}, nil
+}
+
+func sharedProfile(id string, status profilestypes.ShareStatus) profilestypes.ProfileSummary {
+	return profilestypes.ProfileSummary{Id: aws.String(id), ShareStatus: status}
+}
+
+func zoneAssociation(zoneID string, status profilestypes.ProfileStatus) profilestypes.ProfileResourceAssociation {
+	return profilestypes.ProfileResourceAssociation{
+		ResourceArn:  aws.String("arn:aws:route53:::hostedzone/" + zoneID),
+		ResourceType: aws.String(hostedZoneResourceType),
+		OwnerId:      aws.String("111111111111"),
+		Status:       status,
+	}
+}
+
+func newSharedZonesProvider(t *testing.T, profiles *route53ProfilesStub, profileIDs ...string) (*AWSProvider, *Route53APIStub) {
+	t.Helper()
+	p, client := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, false, nil)
+	p.sharedZones = true
+	p.sharedZonesProfileIDs = profileIDs
+	p.profilesClients = map[string]Route53ProfilesAPI{defaultAWSProfile: profiles}
+	// zones were cached while the provider was set up
+	p.zonesCache = blueprint.NewZoneCache[map[string]*profiledZone](0)
+
+	for _, name := range []string{"shared-1", "shared-2", "shared-3"} {
+		id := "/hostedzone/" + name
+		client.sharedZones[id] = &route53types.HostedZone{
+			Id:     aws.String(id),
+			Name:   aws.String(name + ".ext-dns-test-2.teapot.zalan.do."),
+			Config: &route53types.HostedZoneConfig{PrivateZone: true},
+		}
+	}
+	client.sharedZones["/hostedzone/other-domain"] = &route53types.HostedZone{
+		Id:     aws.String("/hostedzone/other-domain"),
+		Name:   aws.String("example.org."),
+		Config: &route53types.HostedZoneConfig{PrivateZone: true},
+	}
+	return p, client
+}
+
+func TestAWSSharedZones(t *testing.T) {
+	accessDenied := &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized"}
+
+	for _, tt := range []struct {
+		name       string
+		profiles   *route53ProfilesStub
+		profileIDs []string
+		expected   []string
+		wantErr    bool
+	}{
+		{
+			name: "zones of profiles shared with the account",
+			profiles: &route53ProfilesStub{
+				profiles: []profilestypes.ProfileSummary{
+					sharedProfile("rp-shared", profilestypes.ShareStatusSharedWithMe),
+					sharedProfile("rp-owned", profilestypes.ShareStatusNotShared),
+				},
+				associations: map[string][]profilestypes.ProfileResourceAssociation{
+					"rp-shared": {zoneAssociation("shared-1", profilestypes.ProfileStatusComplete)},
+					"rp-owned":  {zoneAssociation("shared-2", profilestypes.ProfileStatusComplete)},
+				},
+			},
+			expected: []string{"/hostedzone/shared-1"},
+		},
+		{
+			name: "filtered by profile ID",
+			profiles: &route53ProfilesStub{
+				profiles: []profilestypes.ProfileSummary{
+					sharedProfile("rp-1", profilestypes.ShareStatusSharedWithMe),
+					sharedProfile("rp-2", profilestypes.ShareStatusSharedWithMe),
+				},
+				associations: map[string][]profilestypes.ProfileResourceAssociation{
+					"rp-1": {zoneAssociation("shared-1", profilestypes.ProfileStatusComplete)},
+					"rp-2": {zoneAssociation("shared-2", profilestypes.ProfileStatusComplete)},
+				},
+			},
+			profileIDs: []string{"rp-2"},
+			expected:   []string{"/hostedzone/shared-2"},
+		},
+		{
+			name: "incomplete associations and other domains are ignored",
+			profiles: &route53ProfilesStub{
+				profiles: []profilestypes.ProfileSummary{sharedProfile("rp-1", profilestypes.ShareStatusSharedWithMe)},
+				associations: map[string][]profilestypes.ProfileResourceAssociation{
+					"rp-1": {
+						zoneAssociation("shared-1", profilestypes.ProfileStatusCreating),
+						zoneAssociation("shared-2", profilestypes.ProfileStatusComplete),
+						zoneAssociation("other-domain", profilestypes.ProfileStatusComplete),
+					},
+				},
+			},
+			expected: []string{"/hostedzone/shared-2"},
+		},
+		{
+			name: "owned zone takes precedence over shared zone",
+			profiles: &route53ProfilesStub{
+				profiles: []profilestypes.ProfileSummary{sharedProfile("rp-1", profilestypes.ShareStatusSharedWithMe)},
+				associations: map[string][]profilestypes.ProfileResourceAssociation{
+					"rp-1": {zoneAssociation("zone-1.ext-dns-test-2.teapot.zalan.do.", profilestypes.ProfileStatusComplete)},
+				},
+			},
+		},
+		{
+			name: "unreadable zone is skipped",
+			profiles: &route53ProfilesStub{
+				profiles: []profilestypes.ProfileSummary{sharedProfile("rp-1", profilestypes.ShareStatusSharedWithMe)},
+				associations: map[string][]profilestypes.ProfileResourceAssociation{
+					"rp-1": {
+						zoneAssociation("not-readable", profilestypes.ProfileStatusComplete),
+						zoneAssociation("shared-3", profilestypes.ProfileStatusComplete),
+					},
+				},
+			},
+			expected: []string{"/hostedzone/shared-3"},
+		},
+		{
+			name:     "not allowed to list profiles",
+			profiles: &route53ProfilesStub{listErr: accessDenied},
+		},
+		{
+			name: "not allowed to list profile resources",
+			profiles: &route53ProfilesStub{
+				profiles: []profilestypes.ProfileSummary{
+					sharedProfile("rp-1", profilestypes.ShareStatusSharedWithMe),
+					sharedProfile("rp-2", profilestypes.ShareStatusSharedWithMe),
+				},
+				associations: map[string][]profilestypes.ProfileResourceAssociation{
+					"rp-2": {zoneAssociation("shared-2", profilestypes.ProfileStatusComplete)},
+				},
+				associationsErr: map[string]error{"rp-1": accessDenied},
+			},
+			expected: []string{"/hostedzone/shared-2"},
+		},
+		{
+			name: "throttled profile is skipped",
+			profiles: &route53ProfilesStub{
+				profiles: []profilestypes.ProfileSummary{sharedProfile("rp-1", profilestypes.ShareStatusSharedWithMe)},
+				associationsErr: map[string]error{
+					"rp-1": &profilestypes.ThrottlingException{Message: aws.String("rate exceeded")},
+				},
+			},
+		},
+		{
+			name:     "other errors fail the zone listing",
+			profiles: &route53ProfilesStub{listErr: &profilestypes.InternalServiceErrorException{Message: aws.String("boom")}},
+			wantErr:  true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := newSharedZonesProvider(t, tt.profiles, tt.profileIDs...)
+
+			zones, err := p.zones(context.Background())
+			if tt.wantErr {
+				require.ErrorIs(t, err, provider.SoftError)
+				return
+			}
+			require.NoError(t, err)
+
+			var shared []string
+			for id, zone := range zones {
+				if _, ok := p.clients[defaultAWSProfile].(*Route53APIStub).zones[id]; ok {
+					continue
+				}
+				assert.Equal(t, defaultAWSProfile, zone.profile)
+				shared = append(shared, id)
+			}
+			assert.ElementsMatch(t, tt.expected, shared)
+			assert.Contains(t, zones, "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do.")
+		})
+	}
+}
+
+func TestAWSSharedZonesDisabled(t *testing.T) {
+	profiles := &route53ProfilesStub{
+		profiles: []profilestypes.ProfileSummary{sharedProfile("rp-1", profilestypes.ShareStatusSharedWithMe)},
+		associations: map[string][]profilestypes.ProfileResourceAssociation{
+			"rp-1": {zoneAssociation("shared-1", profilestypes.ProfileStatusComplete)},
+		},
+	}
+	p, _ := newSharedZonesProvider(t, profiles)
+	p.sharedZones = false
+
+	zones, err := p.zones(context.Background())
+	require.NoError(t, err)
+	assert.NotContains(t, zones, "/hostedzone/shared-1")
+}
+
+func TestAWSSharedZonesTagsCached(t *testing.T) {
+	profiles := &route53ProfilesStub{
+		profiles: []profilestypes.ProfileSummary{sharedProfile("rp-1", profilestypes.ShareStatusSharedWithMe)},
+		associations: map[string][]profilestypes.ProfileResourceAssociation{
+			"rp-1": {
+				zoneAssociation("shared-1", profilestypes.ProfileStatusComplete),
+				zoneAssociation("shared-2", profilestypes.ProfileStatusComplete),
+			},
+		},
+	}
+	p, client := newSharedZonesProvider(t, profiles)
+	client.zoneTags["/hostedzone/shared-1"] = []route53types.Tag{{Key: aws.String("team"), Value: aws.String("a")}}
+	p.zoneTagFilter = provider.NewZoneTagFilter([]string{"team=a"})
+	p.sharedZoneTags = map[string]cachedZoneTags{"gone": {expires: time.Now().Add(-time.Second)}}
+
+	var counter *Route53APICounter
+	for range 2 {
+		counter = NewRoute53APICounter(client)
+		p.clients[defaultAWSProfile] = counter
+		p.zonesCache = blueprint.NewZoneCache[map[string]*profiledZone](0)
+
+		zones, err := p.zones(t.Context())
+		require.NoError(t, err)
+		assert.Contains(t, zones, "/hostedzone/shared-1")
+		assert.NotContains(t, zones, "/hostedzone/shared-2")
+	}
+	// the second sync only looks up the tags of the owned zones
+	assert.Equal(t, 1, counter.calls["ListTagsForResource"])
+	assert.NotContains(t, p.sharedZoneTags, "gone")
+
+	// expired tags are looked up again
+	p.sharedZoneTags["shared-2"] = cachedZoneTags{expires: time.Now().Add(-time.Second)}
+	client.zoneTags["/hostedzone/shared-2"] = []route53types.Tag{{Key: aws.String("team"), Value: aws.String("a")}}
+	p.zonesCache = blueprint.NewZoneCache[map[string]*profiledZone](0)
+	zones, err := p.zones(t.Context())
+	require.NoError(t, err)
+	assert.Contains(t, zones, "/hostedzone/shared-2")
+}
@@ -149,6 +149,11 @@ func awsTags(tags map[string]string) []sdtypes.Tag {
 	return awsTags
 }
 
+// SupportedRecordTypes returns the record types managed with Cloud Map service instances.
+func (p *AWSSDProvider) SupportedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}
+}
+
 // Records returns list of all endpoints.
 func (p *AWSSDProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	namespaces, err := p.ListNamespaces(ctx)
@@ -27,6 +27,7 @@ import (
 
 	"sigs.k8s.io/external-dns/internal/sets"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/txt"
 
 	"sigs.k8s.io/external-dns/provider/blueprint"
 
@@ -46,6 +47,15 @@ const (
 	providerSpecificMetadataKeys   = "azure/metadata-keys"
 )
 
+// PropertySpecs returns the provider-specific properties understood by the Azure providers.
+func PropertySpecs() []endpoint.PropertySpec {
+	return []endpoint.PropertySpec{
+		{Name: providerSpecificAzureTags, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificMetadataKeys, Type: endpoint.PropertyTypeString},
+		{Name: providerSpecificMetadataPrefix, Prefix: true, Type: endpoint.PropertyTypeString},
+	}
+}
+
 // ZonesClient is an interface of dns.ZoneClient that can be stubbed for testing.
 type ZonesClient interface {
 	NewListByResourceGroupPager(resourceGroupName string, options *dns.ZonesClientListByResourceGroupOptions) *azcoreruntime.Pager[dns.ZonesClientListByResourceGroupResponse]
@@ -72,11 +82,13 @@ type AzureProvider struct {
 	zonesCache                   *blueprint.ZoneCache[[]dns.Zone]
 	recordSetsClient             RecordSetsClient
 	maxRetriesCount              int
+	// zoneConcurrency is the number of zones whose records are listed in parallel
+	zoneConcurrency int
 }
 
 // New creates an Azure DNS provider from the given configuration.
 func New(_ context.Context, cfg *externaldns.Config, domainFilter *endpoint.DomainFilter) (provider.Provider, error) {
-	return newProvider(
+	p, err := newProvider(
 		cfg.AzureConfigFile,
 		domainFilter,
 		endpoint.NewDomainFilter(cfg.ZoneNameFilter),
@@ -89,6 +101,11 @@ func New(_ context.Context, cfg *externaldns.Config, domainFilter *endpoint.Doma
 		cfg.AzureMaxRetriesCount,
 		cfg.DryRun,
 	)
+	if err != nil {
+		return nil, err
+	}
+	p.zoneConcurrency = cfg.ProviderZoneConcurrency
+	return p, nil
 }
 
 // newProvider creates a new Azure provider.
@@ -128,6 +145,11 @@ func newProvider(configFile string, domainFilter, zoneNameFilter *endpoint.Domai
 	}, nil
 }
 
+// SupportedRecordTypes returns the record types managed with Azure DNS.
+func (p *AzureProvider) SupportedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeMX, endpoint.RecordTypeNS, endpoint.RecordTypeTXT}
+}
+
 // Records gets the current records.
 //
 // Returns the current records or an error if the operation failed.
@@ -137,48 +159,50 @@ func (p *AzureProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, erro
 		return nil, err
 	}
 
-	endpoints := make([]*endpoint.Endpoint, 0)
+	return provider.ZoneRecords(ctx, zones, p.zoneConcurrency, p.zoneRecords)
+}
 
-	for _, zone := range zones {
-		pager := p.recordSetsClient.NewListAllByDNSZonePager(p.resourceGroup, *zone.Name, &dns.RecordSetsClientListAllByDNSZoneOptions{Top: nil})
-		for pager.More() {
-			nextResult, err := pager.NextPage(ctx)
-			if err != nil {
-				return nil, provider.NewSoftErrorf("failed to fetch dns records: %w", err)
+// zoneRecords returns the records of zone.
+func (p *AzureProvider) zoneRecords(ctx context.Context, zone dns.Zone) ([]*endpoint.Endpoint, error) {
+	var endpoints []*endpoint.Endpoint
+	pager := p.recordSetsClient.NewListAllByDNSZonePager(p.resourceGroup, *zone.Name, &dns.RecordSetsClientListAllByDNSZoneOptions{Top: nil})
+	for pager.More() {
+		nextResult, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, provider.NewSoftErrorf("failed to fetch dns records: %w", err)
+		}
+		for _, recordSet := range nextResult.Value {
+			if recordSet.Name == nil || recordSet.Type == nil {
+				log.Error("Skipping invalid record set with nil name or type.")
+				continue
 			}
-			for _, recordSet := range nextResult.Value {
-				if recordSet.Name == nil || recordSet.Type == nil {
-					log.Error("Skipping invalid record set with nil name or type.")
-					continue
-				}
-				recordType := strings.TrimPrefix(*recordSet.Type, "Microsoft.Network/dnszones/")
-				if !p.SupportedRecordType(recordType) {
-					continue
-				}
-				name := formatAzureDNSName(*recordSet.Name, *zone.Name)
-				if len(p.zoneNameFilter.Filters) > 0 && !p.domainFilter.Match(name) {
-					log.Debugf("Skipping return of record %s because it was filtered out by the specified --domain-filter", name)
-					continue
-				}
-				targets := extractAzureTargets(recordSet)
-				if len(targets) == 0 {
-					log.Debugf("Failed to extract targets for '%s' with type '%s'.", name, recordType)
-					continue
-				}
-				var ttl endpoint.TTL
-				if recordSet.Properties.TTL != nil {
-					ttl = endpoint.TTL(*recordSet.Properties.TTL)
-				}
-				ep := endpoint.NewEndpointWithTTL(name, recordType, ttl, targets...)
-				extractMetadataFromRecordSet(ep, recordSet)
-				log.Debugf(
-					"Found %s record for '%s' with target '%s'.",
-					ep.RecordType,
-					ep.DNSName,
-					ep.Targets,
-				)
-				endpoints = append(endpoints, ep)
+			recordType := strings.TrimPrefix(*recordSet.Type, "Microsoft.Network/dnszones/")
+			if !p.SupportedRecordType(recordType) {
+				continue
+			}
+			name := formatAzureDNSName(*recordSet.Name, *zone.Name)
+			if len(p.zoneNameFilter.Filters) > 0 && !p.domainFilter.Match(name) {
+				log.Debugf("Skipping return of record %s because it was filtered out by the specified --domain-filter", name)
+				continue
+			}
+			targets := extractAzureTargets(recordSet)
+			if len(targets) == 0 {
+				log.Debugf("Failed to extract targets for '%s' with type '%s'.", name, recordType)
+				continue
 			}
+			var ttl endpoint.TTL
+			if recordSet.Properties.TTL != nil {
+				ttl = endpoint.TTL(*recordSet.Properties.TTL)
+			}
+			ep := endpoint.NewEndpointWithTTL(name, recordType, ttl, targets...)
+			extractMetadataFromRecordSet(ep, recordSet)
+			log.Debugf(
+				"Found %s record for '%s' with target '%s'.",
+				ep.RecordType,
+				ep.DNSName,
+				ep.Targets,
+			)
+			endpoints = append(endpoints, ep)
 		}
 	}
 	return endpoints, nil
@@ -444,17 +468,17 @@ func (p *AzureProvider) newRecordSet(endpoint *endpoint.Endpoint) (dns.RecordSet
 			},
 		}, nil
 	case dns.RecordTypeTXT:
+		txtRecords := make([]*dns.TxtRecord, len(endpoint.Targets))
+		for i, target := range endpoint.Targets {
+			txtRecords[i] = &dns.TxtRecord{
+				Value: txtValue(target),
+			}
+		}
 		return dns.RecordSet{
 			Properties: &dns.RecordSetProperties{
-				TTL: new(ttl),
-				TxtRecords: []*dns.TxtRecord{
-					{
-						Value: []*string{
-							&endpoint.Targets[0],
-						},
-					},
-				},
-				Metadata: metadata,
+				TTL:        new(ttl),
+				TxtRecords: txtRecords,
+				Metadata:   metadata,
 			},
 		}, nil
 	}
@@ -525,10 +549,11 @@ func extractAzureTargets(recordSet *dns.RecordSet) []string {
 	// Check for TXT records
 	txtRecords := properties.TxtRecords
 	if len(txtRecords) > 0 && (txtRecords)[0].Value != nil {
-		values := (txtRecords)[0].Value
-		if len(values) > 0 {
-			return []string{*(values)[0]}
+		targets := make([]string, len(txtRecords))
+		for i, txtRecord := range txtRecords {
+			targets[i] = txtTarget(txtRecord.Value)
 		}
+		return targets
 	}
 	return []string{}
 }
@@ -593,9 +618,11 @@ func parseAzureTagsAnnotation(ep *endpoint.Endpoint, tagString string) []string
 }
 
 // AdjustEndpoints modifies the endpoints as needed by the Azure provider.
-// It parses the azure-tags annotation and converts it to individual metadata properties.
+// It parses the azure-tags annotation and converts it to individual metadata properties,
+// and converts the TXT targets to the form they are read back in.
 func (p *AzureProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	for _, ep := range endpoints {
+		txt.NormalizeEndpoint(ep)
 		if val, ok := ep.GetProviderSpecificProperty(providerSpecificAzureTags); ok {
 			keys := parseAzureTagsAnnotation(ep, val)
 			if len(keys) > 0 {
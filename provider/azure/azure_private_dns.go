@@ -32,6 +32,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/txt"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -48,6 +49,17 @@ type PrivateRecordSetsClient interface {
 	CreateOrUpdate(ctx context.Context, resourceGroupName string, privateZoneName string, recordType privatedns.RecordType, relativeRecordSetName string, parameters privatedns.RecordSet, options *privatedns.RecordSetsClientCreateOrUpdateOptions) (privatedns.RecordSetsClientCreateOrUpdateResponse, error)
 }
 
+// PrivateVirtualNetworkLinksClient is an interface of privatedns.VirtualNetworkLinksClient that can be stubbed for testing.
+type PrivateVirtualNetworkLinksClient interface {
+	NewListPager(resourceGroupName string, privateZoneName string, options *privatedns.VirtualNetworkLinksClientListOptions) *azcoreruntime.Pager[privatedns.VirtualNetworkLinksClientListResponse]
+}
+
+const (
+	// metadata keys of the record sets written with --azure-private-dns-record-metadata
+	privateMetadataOwnerKey    = "externaldns_owner"
+	privateMetadataResourceKey = "externaldns_resource"
+)
+
 // AzurePrivateDNSProvider implements the DNS provider for Microsoft's Azure Private DNS service
 type AzurePrivateDNSProvider struct {
 	provider.BaseProvider
@@ -61,13 +73,20 @@ type AzurePrivateDNSProvider struct {
 	zonesClient                  PrivateZonesClient
 	zonesCache                   *blueprint.ZoneCache[[]privatedns.PrivateZone]
 	recordSetsClient             PrivateRecordSetsClient
+	vnetLinksClient              PrivateVirtualNetworkLinksClient
 	maxRetriesCount              int
+	// warn when publishing to a zone which is not linked to a virtual network
+	checkVNetLinks bool
+	// virtual network resource IDs every zone published to is expected to be linked to
+	vnets []string
+	// write the owner and resource labels as record set metadata
+	recordMetadata bool
 }
 
 // newPrivateDNSProvider creates a new Azure Private DNS provider.
 //
 // Returns the provider or an error if a provider could not be created.
-func newPrivateDNSProvider(configFile string, domainFilter, zoneNameFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID, resourceGroup, userAssignedIdentityClientID, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, maxRetriesCount int, dryRun bool) (*AzurePrivateDNSProvider, error) {
+func newPrivateDNSProvider(configFile string, domainFilter, zoneNameFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID, resourceGroup, userAssignedIdentityClientID, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, maxRetriesCount int, checkVNetLinks bool, vnets []string, recordMetadata, dryRun bool) (*AzurePrivateDNSProvider, error) {
 	cfg, err := getConfig(configFile, subscriptionID, resourceGroup, userAssignedIdentityClientID, activeDirectoryAuthorityHost)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read Azure config file '%s': %w", configFile, err)
@@ -86,6 +105,10 @@ func newPrivateDNSProvider(configFile string, domainFilter, zoneNameFilter *endp
 	if err != nil {
 		return nil, err
 	}
+	vnetLinksClient, err := privatedns.NewVirtualNetworkLinksClient(cfg.SubscriptionID, cred, clientOpts)
+	if err != nil {
+		return nil, err
+	}
 	return &AzurePrivateDNSProvider{
 		domainFilter:                 domainFilter,
 		zoneNameFilter:               zoneNameFilter,
@@ -97,7 +120,11 @@ func newPrivateDNSProvider(configFile string, domainFilter, zoneNameFilter *endp
 		zonesClient:                  zonesClient,
 		zonesCache:                   blueprint.NewZoneCache[[]privatedns.PrivateZone](zonesCacheDuration),
 		recordSetsClient:             recordSetsClient,
+		vnetLinksClient:              vnetLinksClient,
 		maxRetriesCount:              maxRetriesCount,
+		checkVNetLinks:               checkVNetLinks || len(vnets) > 0,
+		vnets:                        vnets,
+		recordMetadata:               recordMetadata,
 	}, nil
 }
 
@@ -114,10 +141,26 @@ func NewPrivate(_ context.Context, cfg *externaldns.Config, domainFilter *endpoi
 		cfg.AzureActiveDirectoryAuthorityHost,
 		cfg.AzureZonesCacheDuration,
 		cfg.AzureMaxRetriesCount,
+		cfg.AzurePrivateDNSCheckVNetLinks,
+		cfg.AzurePrivateDNSVNets,
+		cfg.AzurePrivateDNSRecordMetadata,
 		cfg.DryRun,
 	)
 }
 
+// SupportedRecordTypes returns the record types managed with Azure Private DNS.
+func (p *AzurePrivateDNSProvider) SupportedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeMX, endpoint.RecordTypeTXT}
+}
+
+// AdjustEndpoints converts the TXT targets to the form they are read back in.
+func (p *AzurePrivateDNSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return provider.EndpointAdjuster(func(ep *endpoint.Endpoint) []*endpoint.Endpoint {
+		txt.NormalizeEndpoint(ep)
+		return []*endpoint.Endpoint{ep}
+	}).AdjustEndpoints(endpoints)
+}
+
 // Records gets the current records.
 //
 // Returns the current records or an error if the operation failed.
@@ -197,6 +240,9 @@ func (p *AzurePrivateDNSProvider) ApplyChanges(ctx context.Context, changes *pla
 	}
 
 	deleted, updated := p.mapChanges(zones, changes)
+	if p.checkVNetLinks {
+		p.warnUnlinkedZones(ctx, updated)
+	}
 	p.deleteRecords(ctx, deleted)
 	p.updateRecords(ctx, updated)
 	return nil
@@ -328,6 +374,9 @@ func (p *AzurePrivateDNSProvider) updateRecords(ctx context.Context, updated azu
 
 			recordSet, err := p.newRecordSet(ep)
 			if err == nil {
+				if p.recordMetadata {
+					recordSet.Properties.Metadata = labelMetadata(ep)
+				}
 				_, err = p.recordSetsClient.CreateOrUpdate(
 					ctx,
 					p.resourceGroup,
@@ -352,6 +401,63 @@ func (p *AzurePrivateDNSProvider) updateRecords(ctx context.Context, updated azu
 	}
 }
 
+// warnUnlinkedZones logs a warning for every zone records are published to
+// which is not linked to a virtual network, or not to the configured ones,
+// as those records can not be resolved from the virtual networks.
+func (p *AzurePrivateDNSProvider) warnUnlinkedZones(ctx context.Context, updated azurePrivateDNSChangeMap) {
+	for zone := range updated {
+		linked, err := p.linkedVNets(ctx, zone)
+		if err != nil {
+			log.Warnf("Failed to list virtual network links of Azure Private DNS zone '%s': %v", zone, err)
+			continue
+		}
+		if len(linked) == 0 {
+			log.Warnf("Azure Private DNS zone '%s' is not linked to any virtual network, its records can not be resolved.", zone)
+			continue
+		}
+		for _, vnet := range p.vnets {
+			if !linked.Has(strings.ToLower(vnet)) {
+				log.Warnf("Azure Private DNS zone '%s' is not linked to virtual network '%s'.", zone, vnet)
+			}
+		}
+	}
+}
+
+// linkedVNets returns the lower-cased resource IDs of the virtual networks linked to the zone.
+func (p *AzurePrivateDNSProvider) linkedVNets(ctx context.Context, zone string) (sets.Set[string], error) {
+	linked := sets.New[string]()
+	pager := p.vnetLinksClient.NewListPager(p.resourceGroup, zone, nil)
+	for pager.More() {
+		nextResult, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, link := range nextResult.Value {
+			if link.Properties == nil || link.Properties.VirtualNetwork == nil || link.Properties.VirtualNetwork.ID == nil {
+				continue
+			}
+			// resource IDs are case-insensitive
+			linked.Insert(strings.ToLower(*link.Properties.VirtualNetwork.ID))
+		}
+	}
+	return linked, nil
+}
+
+// labelMetadata returns the record set metadata tracing a record back to its owner and resource.
+func labelMetadata(ep *endpoint.Endpoint) map[string]*string {
+	metadata := make(map[string]*string)
+	if owner := ep.Labels[endpoint.OwnerLabelKey]; owner != "" {
+		metadata[privateMetadataOwnerKey] = new(owner)
+	}
+	if resource := ep.Labels[endpoint.ResourceLabelKey]; resource != "" {
+		metadata[privateMetadataResourceKey] = new(resource)
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
 func (p *AzurePrivateDNSProvider) recordSetNameForZone(zone string, endpoint *endpoint.Endpoint) string {
 	// Remove the zone from the record set
 	name := endpoint.DNSName
@@ -422,16 +528,16 @@ func (p *AzurePrivateDNSProvider) newRecordSet(endpoint *endpoint.Endpoint) (pri
 			},
 		}, nil
 	case privatedns.RecordTypeTXT:
+		txtRecords := make([]*privatedns.TxtRecord, len(endpoint.Targets))
+		for i, target := range endpoint.Targets {
+			txtRecords[i] = &privatedns.TxtRecord{
+				Value: txtValue(target),
+			}
+		}
 		return privatedns.RecordSet{
 			Properties: &privatedns.RecordSetProperties{
-				TTL: new(ttl),
-				TxtRecords: []*privatedns.TxtRecord{
-					{
-						Value: []*string{
-							&endpoint.Targets[0],
-						},
-					},
-				},
+				TTL:        new(ttl),
+				TxtRecords: txtRecords,
 			},
 		}, nil
 	}
@@ -484,10 +590,11 @@ func extractAzurePrivateDNSTargets(recordSet *privatedns.RecordSet) []string {
 	// Check for TXT records
 	txtRecords := properties.TxtRecords
 	if len(txtRecords) > 0 && (txtRecords)[0].Value != nil {
-		values := (txtRecords)[0].Value
-		if len(values) > 0 {
-			return []string{*(values)[0]}
+		targets := make([]string, len(txtRecords))
+		for i, txtRecord := range txtRecords {
+			targets[i] = txtTarget(txtRecord.Value)
 		}
+		return targets
 	}
 	return []string{}
 }
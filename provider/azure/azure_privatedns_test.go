@@ -18,14 +18,19 @@ package azure
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	azcoreruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	privatedns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/external-dns/provider/blueprint"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	logtest "sigs.k8s.io/external-dns/internal/testutils/log"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -68,6 +73,7 @@ type mockPrivateRecordSetsClient struct {
 	pagingHandler    azcoreruntime.PagingHandler[privatedns.RecordSetsClientListResponse]
 	deletedEndpoints []*endpoint.Endpoint
 	updatedEndpoints []*endpoint.Endpoint
+	updatedMetadata  map[string]map[string]*string
 }
 
 func newMockPrivateRecordSectsClient(recordSets []*privatedns.RecordSet) mockPrivateRecordSetsClient {
@@ -109,6 +115,12 @@ func (client *mockPrivateRecordSetsClient) CreateOrUpdate(_ context.Context, _ s
 	if parameters.Properties.TTL != nil {
 		ttl = endpoint.TTL(*parameters.Properties.TTL)
 	}
+	if parameters.Properties.Metadata != nil {
+		if client.updatedMetadata == nil {
+			client.updatedMetadata = map[string]map[string]*string{}
+		}
+		client.updatedMetadata[formatAzureDNSName(relativeRecordSetName, privateZoneName)] = parameters.Properties.Metadata
+	}
 	client.updatedEndpoints = append(
 		client.updatedEndpoints,
 		endpoint.NewEndpointWithTTL(
@@ -121,6 +133,32 @@ func (client *mockPrivateRecordSetsClient) CreateOrUpdate(_ context.Context, _ s
 	return privatedns.RecordSetsClientCreateOrUpdateResponse{}, nil
 }
 
+// mockPrivateVirtualNetworkLinksClient returns the virtual networks linked to each zone.
+type mockPrivateVirtualNetworkLinksClient struct {
+	links map[string][]string
+}
+
+func (client *mockPrivateVirtualNetworkLinksClient) NewListPager(_ string, privateZoneName string, _ *privatedns.VirtualNetworkLinksClientListOptions) *azcoreruntime.Pager[privatedns.VirtualNetworkLinksClientListResponse] {
+	var links []*privatedns.VirtualNetworkLink
+	for _, vnet := range client.links[privateZoneName] {
+		links = append(links, &privatedns.VirtualNetworkLink{
+			Properties: &privatedns.VirtualNetworkLinkProperties{
+				VirtualNetwork: &privatedns.SubResource{ID: new(vnet)},
+			},
+		})
+	}
+	return azcoreruntime.NewPager(azcoreruntime.PagingHandler[privatedns.VirtualNetworkLinksClientListResponse]{
+		More: func(privatedns.VirtualNetworkLinksClientListResponse) bool {
+			return false
+		},
+		Fetcher: func(context.Context, *privatedns.VirtualNetworkLinksClientListResponse) (privatedns.VirtualNetworkLinksClientListResponse, error) {
+			return privatedns.VirtualNetworkLinksClientListResponse{
+				VirtualNetworkLinkListResult: privatedns.VirtualNetworkLinkListResult{Value: links},
+			}, nil
+		},
+	})
+}
+
 func createMockPrivateZone(zone string, id string) *privatedns.PrivateZone {
 	return &privatedns.PrivateZone{
 		ID:   new(id),
@@ -270,10 +308,10 @@ func TestAzurePrivateDNSRecord(t *testing.T) {
 	expected := []*endpoint.Endpoint{
 		endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "123.123.123.122"),
 		endpoint.NewEndpoint("example.com", endpoint.RecordTypeAAAA, "2001::123:123:123:122"),
-		endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"),
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, `"heritage=external-dns,external-dns/owner=default"`),
 		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeA, 3600, "123.123.123.123"),
 		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeAAAA, 3600, "2001::123:123:123:123"),
-		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeTXT, recordTTL, "heritage=external-dns,external-dns/owner=default"),
+		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeTXT, recordTTL, `"heritage=external-dns,external-dns/owner=default"`),
 		endpoint.NewEndpointWithTTL("hack.example.com", endpoint.RecordTypeCNAME, 10, "hack.azurewebsites.net"),
 		endpoint.NewEndpointWithTTL("mail.example.com", endpoint.RecordTypeMX, 4000, "10 example.com"),
 	}
@@ -306,10 +344,10 @@ func TestAzurePrivateDNSMultiRecord(t *testing.T) {
 	expected := []*endpoint.Endpoint{
 		endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "123.123.123.122", "234.234.234.233"),
 		endpoint.NewEndpoint("example.com", endpoint.RecordTypeAAAA, "2001::123:123:123:122", "2001::234:234:234:233"),
-		endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"),
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, `"heritage=external-dns,external-dns/owner=default"`),
 		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeA, 3600, "123.123.123.123", "234.234.234.234"),
 		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeAAAA, 3600, "2001::123:123:123:123", "2001::234:234:234:234"),
-		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeTXT, recordTTL, "heritage=external-dns,external-dns/owner=default"),
+		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeTXT, recordTTL, `"heritage=external-dns,external-dns/owner=default"`),
 		endpoint.NewEndpointWithTTL("hack.example.com", endpoint.RecordTypeCNAME, 10, "hack.azurewebsites.net"),
 		endpoint.NewEndpointWithTTL("mail.example.com", endpoint.RecordTypeMX, 4000, "10 example.com", "20 backup.example.com"),
 	}
@@ -331,21 +369,21 @@ func TestAzurePrivateDNSApplyChanges(t *testing.T) {
 	validateAzureEndpoints(t, recordsClient.updatedEndpoints, []*endpoint.Endpoint{
 		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, endpoint.TTL(recordTTL), "1.2.3.4"),
 		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeAAAA, endpoint.TTL(recordTTL), "2001::1:2:3:4"),
-		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), "tag"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), `"tag"`),
 		endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, endpoint.TTL(recordTTL), "1.2.3.4", "1.2.3.5"),
 		endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeAAAA, endpoint.TTL(recordTTL), "2001::1:2:3:4", "2001::1:2:3:5"),
-		endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), "tag"),
+		endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), `"tag"`),
 		endpoint.NewEndpointWithTTL("bar.example.com", endpoint.RecordTypeCNAME, endpoint.TTL(recordTTL), "other.com"),
-		endpoint.NewEndpointWithTTL("bar.example.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), "tag"),
+		endpoint.NewEndpointWithTTL("bar.example.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), `"tag"`),
 		endpoint.NewEndpointWithTTL("other.com", endpoint.RecordTypeA, endpoint.TTL(recordTTL), "5.6.7.8"),
 		endpoint.NewEndpointWithTTL("other.com", endpoint.RecordTypeAAAA, endpoint.TTL(recordTTL), "2001::5:6:7:8"),
-		endpoint.NewEndpointWithTTL("other.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), "tag"),
+		endpoint.NewEndpointWithTTL("other.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), `"tag"`),
 		endpoint.NewEndpointWithTTL("new.example.com", endpoint.RecordTypeA, 3600, "111.222.111.222"),
 		endpoint.NewEndpointWithTTL("new.example.com", endpoint.RecordTypeAAAA, 3600, "2001::111:222:111:222"),
 		endpoint.NewEndpointWithTTL("newcname.example.com", endpoint.RecordTypeCNAME, 10, "other.com"),
 		endpoint.NewEndpointWithTTL("newmail.example.com", endpoint.RecordTypeMX, 7200, "40 bar.other.com"),
 		endpoint.NewEndpointWithTTL("mail.example.com", endpoint.RecordTypeMX, endpoint.TTL(recordTTL), "10 other.com"),
-		endpoint.NewEndpointWithTTL("mail.example.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), "tag"),
+		endpoint.NewEndpointWithTTL("mail.example.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), `"tag"`),
 	})
 }
 
@@ -457,7 +495,7 @@ func TestAzurePrivateDNSNameFilter(t *testing.T) {
 	expected := []*endpoint.Endpoint{
 		endpoint.NewEndpointWithTTL("test.nginx.example.com", endpoint.RecordTypeA, 3600, "123.123.123.123"),
 		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeA, 3600, "123.123.123.123"),
-		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeTXT, recordTTL, "heritage=external-dns,external-dns/owner=default"),
+		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeTXT, recordTTL, `"heritage=external-dns,external-dns/owner=default"`),
 		endpoint.NewEndpointWithTTL("mail.nginx.example.com", endpoint.RecordTypeMX, recordTTL, "20 example.com"),
 	}
 
@@ -478,7 +516,7 @@ func TestAzurePrivateDNSApplyChangesZoneName(t *testing.T) {
 	validateAzureEndpoints(t, recordsClient.updatedEndpoints, []*endpoint.Endpoint{
 		endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, endpoint.TTL(recordTTL), "1.2.3.4", "1.2.3.5"),
 		endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeAAAA, endpoint.TTL(recordTTL), "2001::1:2:3:4", "2001::1:2:3:5"),
-		endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), "tag"),
+		endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), `"tag"`),
 		endpoint.NewEndpointWithTTL("new.foo.example.com", endpoint.RecordTypeA, 3600, "111.222.111.222"),
 		endpoint.NewEndpointWithTTL("new.foo.example.com", endpoint.RecordTypeAAAA, 3600, "2001::111:222:111:222"),
 		endpoint.NewEndpointWithTTL("newcname.foo.example.com", endpoint.RecordTypeCNAME, 10, "other.com"),
@@ -548,3 +586,82 @@ func testAzurePrivateDNSApplyChangesInternalZoneName(t *testing.T, dryRun bool,
 		t.Fatal(err)
 	}
 }
+
+func TestAzurePrivateDNSWarnUnlinkedZones(t *testing.T) {
+	const vnet = "/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/virtualNetworks/vnet-1"
+	zonesClient := newMockPrivateZonesClient([]*privatedns.PrivateZone{
+		createMockPrivateZone("example.com", "/privateDnsZones/example.com"),
+		createMockPrivateZone("other.com", "/privateDnsZones/other.com"),
+		createMockPrivateZone("linked.com", "/privateDnsZones/linked.com"),
+	})
+	p := newAzurePrivateDNSProvider(endpoint.NewDomainFilter([]string{""}), endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), true, "group", &zonesClient, &mockPrivateRecordSetsClient{}, 3)
+	p.checkVNetLinks = true
+	p.vnets = []string{vnet}
+	p.vnetLinksClient = &mockPrivateVirtualNetworkLinksClient{links: map[string][]string{
+		"other.com":  {"/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/virtualNetworks/vnet-2"},
+		"linked.com": {strings.ToUpper(vnet)},
+	}}
+
+	hook := logtest.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("a.other.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("a.linked.com", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	}))
+
+	logtest.TestHelperLogContains("Azure Private DNS zone 'example.com' is not linked to any virtual network", hook, t)
+	logtest.TestHelperLogContains("Azure Private DNS zone 'other.com' is not linked to virtual network '"+vnet+"'", hook, t)
+	logtest.TestHelperLogNotContains("'linked.com' is not linked", hook, t)
+}
+
+func TestAzurePrivateDNSRecordMetadata(t *testing.T) {
+	zonesClient := newMockPrivateZonesClient([]*privatedns.PrivateZone{
+		createMockPrivateZone("example.com", "/privateDnsZones/example.com"),
+	})
+
+	for _, enabled := range []bool{true, false} {
+		recordsClient := mockPrivateRecordSetsClient{}
+		p := newAzurePrivateDNSProvider(endpoint.NewDomainFilter([]string{""}), endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), false, "group", &zonesClient, &recordsClient, 3)
+		p.recordMetadata = enabled
+
+		owned := endpoint.NewEndpoint("owned.example.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.OwnerLabelKey, "default").
+			WithLabel(endpoint.ResourceLabelKey, "service/default/nginx")
+		require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{
+			Create: []*endpoint.Endpoint{
+				owned,
+				endpoint.NewEndpoint("unlabeled.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		}))
+
+		if !enabled {
+			assert.Empty(t, recordsClient.updatedMetadata)
+			continue
+		}
+		assert.Equal(t, map[string]map[string]*string{
+			"owned.example.com": {
+				privateMetadataOwnerKey:    new("default"),
+				privateMetadataResourceKey: new("service/default/nginx"),
+			},
+		}, recordsClient.updatedMetadata)
+	}
+}
+
+func TestAzurePrivateDNSNewRecordSetTXT(t *testing.T) {
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8A", 12)
+	ep := endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, dkim, `"v=spf1 -all"`)
+
+	recordSet, err := (&AzurePrivateDNSProvider{}).newRecordSet(ep)
+	require.NoError(t, err)
+	txtRecords := recordSet.Properties.TxtRecords
+	require.Len(t, txtRecords, 2)
+	assert.Equal(t, []*string{new(dkim[:255]), new(dkim[255:])}, txtRecords[0].Value)
+	assert.Equal(t, []*string{new("v=spf1 -all")}, txtRecords[1].Value)
+	assert.Equal(t, []string{`"` + dkim[:255] + `" "` + dkim[255:] + `"`, `"v=spf1 -all"`}, extractAzurePrivateDNSTargets(&recordSet))
+
+	adjusted, err := (&AzurePrivateDNSProvider{}).AdjustEndpoints([]*endpoint.Endpoint{ep})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{`"` + dkim[:255] + `" "` + dkim[255:] + `"`, `"v=spf1 -all"`}, adjusted[0].Targets)
+}
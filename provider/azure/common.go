@@ -24,6 +24,8 @@ import (
 
 	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
 	privatedns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+
+	"sigs.k8s.io/external-dns/pkg/txt"
 )
 
 // Helper function (shared with test code)
@@ -44,3 +46,25 @@ func parseMxTarget[T dns.MxRecord | privatedns.MxRecord](mxTarget string) (T, er
 		Exchange:   new(exchange),
 	}, nil
 }
+
+// txtValue converts a TXT target to the character strings of an Azure TXT record value,
+// which are stored unquoted.
+func txtValue(target string) []*string {
+	strs := txt.Split(txt.Decode(target))
+	value := make([]*string, len(strs))
+	for i, str := range strs {
+		value[i] = new(str)
+	}
+	return value
+}
+
+// txtTarget converts the character strings of an Azure TXT record value back to a target.
+func txtTarget(value []*string) string {
+	var b strings.Builder
+	for _, str := range value {
+		if str != nil {
+			b.WriteString(*str)
+		}
+	}
+	return txt.Encode(b.String())
+}
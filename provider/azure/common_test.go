@@ -18,6 +18,7 @@ package azure
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
@@ -84,3 +85,38 @@ func Test_parseMxTarget(t *testing.T) {
 		})
 	}
 }
+
+func Test_txtValue(t *testing.T) {
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8A", 12)
+	for _, tt := range []struct {
+		name   string
+		target string
+		value  []*string
+		want   string
+	}{
+		{
+			name:   "registry value",
+			target: `"heritage=external-dns,external-dns/owner=default"`,
+			value:  []*string{new("heritage=external-dns,external-dns/owner=default")},
+			want:   `"heritage=external-dns,external-dns/owner=default"`,
+		},
+		{
+			name:   "unquoted value",
+			target: "v=spf1 -all",
+			value:  []*string{new("v=spf1 -all")},
+			want:   `"v=spf1 -all"`,
+		},
+		{
+			name:   "long value",
+			target: dkim,
+			value:  []*string{new(dkim[:255]), new(dkim[255:])},
+			want:   `"` + dkim[:255] + `" "` + dkim[255:] + `"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			value := txtValue(tt.target)
+			assert.Equal(t, tt.value, value)
+			assert.Equal(t, tt.want, txtTarget(value))
+		})
+	}
+}
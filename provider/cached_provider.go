@@ -18,6 +18,7 @@ package provider
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -53,6 +54,33 @@ func init() {
 	metrics.RegisterMetric.MustRegister(cachedApplyChangesCallsTotal)
 }
 
+// CacheResetter is implemented by providers and registries that keep records
+// between syncs. Reset drops them so the next Records call reads the live state.
+type CacheResetter interface {
+	Reset()
+}
+
+type recordsFromCacheKey struct{}
+
+// ContextWithRecordsFromCache returns a copy of ctx in which the providers caching
+// records, like CachedProvider, set fromCache when they serve the records from their cache.
+func ContextWithRecordsFromCache(ctx context.Context, fromCache *atomic.Bool) context.Context {
+	return context.WithValue(ctx, recordsFromCacheKey{}, fromCache)
+}
+
+// markRecordsFromCache reports to the caller of Records that the records were served
+// from a cache, see ContextWithRecordsFromCache.
+func markRecordsFromCache(ctx context.Context) {
+	if fromCache, ok := ctx.Value(recordsFromCacheKey{}).(*atomic.Bool); ok {
+		fromCache.Store(true)
+	}
+}
+
+// Unwrapper is implemented by providers wrapping another provider, like CachedProvider.
+type Unwrapper interface {
+	Unwrap() Provider
+}
+
 type CachedProvider struct {
 	Provider
 	RefreshDelay time.Duration
@@ -80,6 +108,7 @@ func (c *CachedProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 		cachedRecordsCallsTotal.CounterVec.WithLabelValues("false").Inc()
 	} else {
 		log.Debug("Records cache provider: using records list from cache")
+		markRecordsFromCache(ctx)
 		cachedRecordsCallsTotal.CounterVec.WithLabelValues("true").Inc()
 	}
 	return c.cache, nil
@@ -94,11 +123,17 @@ func (c *CachedProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 	return c.Provider.ApplyChanges(ctx, changes)
 }
 
+// Reset drops the cached records list.
 func (c *CachedProvider) Reset() {
 	c.cache = nil
 	c.lastRead = time.Time{}
 }
 
+// Unwrap returns the wrapped provider.
+func (c *CachedProvider) Unwrap() Provider {
+	return c.Provider
+}
+
 func (c *CachedProvider) needRefresh() bool {
 	if c.cache == nil {
 		log.Debug("Records cache provider is not initialized")
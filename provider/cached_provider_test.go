@@ -18,6 +18,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -52,7 +53,14 @@ func (p *testProviderFunc) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*e
 	return p.adjustEndpoints(endpoints)
 }
 
+func (p *testProviderFunc) SupportedRecordTypes() []string {
+	return nil
+}
+
 func (p *testProviderFunc) GetDomainFilter() endpoint.DomainFilterInterface {
+	if p.getDomainFilter == nil {
+		return nil
+	}
 	return p.getDomainFilter()
 }
 
@@ -166,6 +174,23 @@ func TestCachedProviderUsesCacheWhileValid(t *testing.T) {
 	})
 }
 
+func TestCachedProviderReportsRecordsFromCache(t *testing.T) {
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = func(_ context.Context) ([]*endpoint.Endpoint, error) {
+		return []*endpoint.Endpoint{{DNSName: "domain.fqdn"}}, nil
+	}
+	provider := NewCachedProvider(testProvider, time.Hour)
+
+	fromCache := &atomic.Bool{}
+	_, err := provider.Records(ContextWithRecordsFromCache(t.Context(), fromCache))
+	require.NoError(t, err)
+	assert.False(t, fromCache.Load(), "the first records are read from the provider")
+
+	_, err = provider.Records(ContextWithRecordsFromCache(t.Context(), fromCache))
+	require.NoError(t, err)
+	assert.True(t, fromCache.Load())
+}
+
 func TestCachedProviderForcesCacheRefreshOnUpdate(t *testing.T) {
 	testProvider := newTestProviderFunc(t)
 	testProvider.records = func(_ context.Context) ([]*endpoint.Endpoint, error) {
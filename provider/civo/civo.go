@@ -115,6 +115,11 @@ func (p *CivoProvider) Zones(_ context.Context) ([]civogo.DNSDomain, error) {
 	return zones, nil
 }
 
+// SupportedRecordTypes returns the record types managed with Civo.
+func (p *CivoProvider) SupportedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeSRV}
+}
+
 // Records returns the list of records in a given zone.
 func (p *CivoProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.Zones(ctx)
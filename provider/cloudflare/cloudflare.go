@@ -20,7 +20,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"sort"
@@ -40,6 +39,8 @@ import (
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/sets"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/credentials"
+	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/source/annotations"
@@ -65,6 +66,8 @@ const (
 	// Cloudflare tier limitations https://developers.cloudflare.com/dns/manage-dns-records/reference/record-attributes/#availability
 	freeZoneMaxCommentLength = 100
 	paidZoneMaxCommentLength = 500
+	// desiredHashCommentPrefix precedes the desired hash appended to record comments with --record-desired-hash.
+	desiredHashCommentPrefix = "external-dns-hash:"
 )
 
 var changeActionNames = map[changeAction]string{
@@ -86,6 +89,7 @@ type DNSRecordIndex struct {
 type DNSRecordsMap map[DNSRecordIndex]dns.RecordResponse
 
 var recordTypeProxyNotSupported = sets.New(
+	"CAA",
 	"LOC",
 	"MX",
 	"NS",
@@ -111,6 +115,8 @@ type cloudFlareDNS interface {
 	CustomHostnames(ctx context.Context, zoneID string) autoPager[custom_hostnames.CustomHostnameListResponse]
 	DeleteCustomHostname(ctx context.Context, customHostnameID string, params custom_hostnames.CustomHostnameDeleteParams) error
 	CreateCustomHostname(ctx context.Context, zoneID string, ch customHostname) error
+	CustomHostnameFallbackOrigin(ctx context.Context, zoneID string) (string, error)
+	UpdateCustomHostnameFallbackOrigin(ctx context.Context, zoneID string, origin string) error
 }
 
 type zoneService struct {
@@ -170,6 +176,7 @@ func listZonesV4Params() zones.ZoneListParams {
 type DNSRecordsConfig struct {
 	PerPage             int
 	Comment             string
+	DesiredHash         bool
 	BatchChangeSize     int
 	BatchChangeInterval time.Duration
 }
@@ -192,6 +199,30 @@ func (c *DNSRecordsConfig) trimAndValidateComment(dnsName, comment string, paidZ
 	return comment
 }
 
+// withDesiredHashComment appends the desired hash of a record to its comment, shortening the
+// comment to keep both within maxLength.
+func withDesiredHashComment(comment, hash string, maxLength int) string {
+	suffix := desiredHashCommentPrefix + hash
+	if comment == "" {
+		return suffix
+	}
+	if maxLength -= len(suffix) + 1; len(comment) > maxLength {
+		log.Warnf("DNS record comment is too long to append the desired hash. Trimming comment to %d chars.", maxLength)
+		comment = comment[:maxLength]
+	}
+	return comment + " " + suffix
+}
+
+// trimDesiredHashComment removes the desired hash appended by withDesiredHashComment, so the
+// comment read back matches the desired one.
+func trimDesiredHashComment(comment string) string {
+	i := strings.LastIndex(comment, desiredHashCommentPrefix)
+	if i < 0 || (i > 0 && comment[i-1] != ' ') {
+		return comment
+	}
+	return strings.TrimSuffix(comment[:i], " ")
+}
+
 func (p *CloudFlareProvider) ZoneHasPaidPlan(hostname string) bool {
 	zone, err := publicsuffix.EffectiveTLDPlusOne(hostname)
 	if err != nil {
@@ -225,6 +256,12 @@ type CloudFlareProvider struct {
 	CustomHostnamesConfig  CustomHostnamesConfig
 	DNSRecordsConfig       DNSRecordsConfig
 	RegionalServicesConfig RegionalServicesConfig
+	// zoneNames and entitlements are the zones read by the last Records call, to
+	// adjust the endpoints to what their zone supports
+	zoneNames    provider.ZoneIDName
+	entitlements map[string]zoneEntitlements
+	// pendingCustomHostnames are the pending messages last logged per zone and custom hostname
+	pendingCustomHostnames map[string]map[string]string
 }
 
 // cloudFlareChange differentiates between ChangeActions
@@ -234,6 +271,7 @@ type cloudFlareChange struct {
 	RegionalHostname    regionalHostname
 	CustomHostnames     map[string]customHostname
 	CustomHostnamesPrev []string
+	RefObjects          []*events.ObjectReference
 }
 
 func convertCloudflareError(err error) error {
@@ -241,22 +279,21 @@ func convertCloudflareError(err error) error {
 	// https://github.com/cloudflare/cloudflare-go?tab=readme-ov-file#errors
 	var apierr *cloudflare.Error
 	if errors.As(err, &apierr) {
-		// Rate limit errors (429) and server errors (5xx) should be treated as soft errors
-		// so that external-dns will retry them later
-		if apierr.StatusCode == http.StatusTooManyRequests || apierr.StatusCode >= http.StatusInternalServerError {
-			return provider.NewSoftError(err)
-		}
-		// For other structured API errors (4xx), return the error unchanged
+		// Rate limit errors (429) and server errors (5xx) are soft errors, so that
+		// external-dns will retry them later; other structured API errors (4xx) are
+		// returned unchanged.
 		// Note: We must NOT call err.Error() on v5 cloudflare.Error types with nil internal fields
-		return err
+		var header http.Header
+		if apierr.Response != nil {
+			header = apierr.Response.Header
+		}
+		return provider.ClassifyHTTPError(err, apierr.StatusCode, header)
 	}
 
-	// Transport-level errors that the SDK does not wrap as *cloudflare.Error.
-	// Both are transient and worth retrying at the external-dns level.
-	//   ErrUnexpectedEOF – connection closed mid-response (during body read)
-	//   EOF              – connection closed before any response bytes arrived
-	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
-		return provider.NewSoftError(err)
+	// Transport-level errors that the SDK does not wrap as *cloudflare.Error, like
+	// connections closed before or while reading the response.
+	if err = provider.ClassifyError(err); errors.Is(err, provider.SoftError) {
+		return err
 	}
 
 	// The v5 SDK's retry logic and error wrapping can hide the structured error type,
@@ -275,6 +312,7 @@ func convertCloudflareError(err error) error {
 
 // newProvider initializes a new CloudFlare DNS based Provider.
 func newProvider(
+	ctx context.Context,
 	domainFilter *endpoint.DomainFilter,
 	zoneIDFilter provider.ZoneIDFilter,
 	proxiedByDefault bool,
@@ -282,33 +320,39 @@ func newProvider(
 	regionalServicesConfig RegionalServicesConfig,
 	customHostnamesConfig CustomHostnamesConfig,
 	dnsRecordsConfig DNSRecordsConfig,
+	baseURL string,
 ) (*CloudFlareProvider, error) {
 	// initialize via chosen auth method and returns new API object
 
 	var client *cloudflare.Client
+	var opts []option.RequestOption
+	if baseURL != "" {
+		log.Infof("provider-endpoint flag is set, targeting Cloudflare API at %s", baseURL)
+		opts = append(opts, option.WithBaseURL(baseURL))
+	}
 
-	token := os.Getenv(cfAPITokenEnvKey)
-	if token != "" {
-		if trimmed, ok := strings.CutPrefix(token, "file:"); ok {
-			tokenBytes, err := os.ReadFile(trimmed)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read %s from file: %w", cfAPITokenEnvKey, err)
-			}
-			token = strings.TrimSpace(string(tokenBytes))
+	if token := os.Getenv(cfAPITokenEnvKey); token != "" {
+		// a token read from a file is reloaded when the file changes, e.g. on Secret rotation
+		credential, err := credentials.Resolve(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", cfAPITokenEnvKey, err)
 		}
-		client = cloudflare.NewClient(
-			option.WithAPIToken(token),
-		)
+		client = cloudflare.NewClient(append(opts,
+			option.WithMiddleware(func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+				req.Header.Set("Authorization", "Bearer "+credential.Get())
+				return next(req)
+			}),
+		)...)
 	} else {
 		apiKey := os.Getenv(cfAPIKeyEnvKey)
 		apiEmail := os.Getenv(cfAPIEmailEnvKey)
 		if apiKey == "" || apiEmail == "" {
 			return nil, fmt.Errorf("cloudflare credentials are not configured: set either %s or both %s and %s environment variables", cfAPITokenEnvKey, cfAPIKeyEnvKey, cfAPIEmailEnvKey)
 		}
-		client = cloudflare.NewClient(
+		client = cloudflare.NewClient(append(opts,
 			option.WithAPIKey(apiKey),
 			option.WithAPIEmail(apiEmail),
-		)
+		)...)
 	}
 
 	if regionalServicesConfig.RegionKey != "" {
@@ -328,8 +372,9 @@ func newProvider(
 }
 
 // New creates a Cloudflare provider from the given configuration.
-func New(_ context.Context, cfg *externaldns.Config, domainFilter *endpoint.DomainFilter) (provider.Provider, error) {
+func New(ctx context.Context, cfg *externaldns.Config, domainFilter *endpoint.DomainFilter) (provider.Provider, error) {
 	return newProvider(
+		ctx,
 		domainFilter,
 		provider.NewZoneIDFilter(cfg.ZoneIDFilter),
 		cfg.CloudflareProxied,
@@ -342,13 +387,16 @@ func New(_ context.Context, cfg *externaldns.Config, domainFilter *endpoint.Doma
 			Enabled:              cfg.CloudflareCustomHostnames,
 			MinTLSVersion:        cfg.CloudflareCustomHostnamesMinTLSVersion,
 			CertificateAuthority: cfg.CloudflareCustomHostnamesCertificateAuthority,
+			FallbackOrigin:       cfg.CloudflareCustomHostnamesFallbackOrigin,
 		},
 		DNSRecordsConfig{
 			PerPage:             cfg.CloudflareDNSRecordsPerPage,
 			Comment:             cfg.CloudflareDNSRecordsComment,
+			DesiredHash:         cfg.RecordDesiredHash,
 			BatchChangeSize:     cfg.BatchChangeSize,
 			BatchChangeInterval: cfg.BatchChangeInterval,
 		},
+		cfg.ProviderEndpoint,
 	)
 }
 
@@ -398,12 +446,28 @@ func (p *CloudFlareProvider) Zones(ctx context.Context) ([]zones.Zone, error) {
 	return result, nil
 }
 
+// SupportedRecordTypes returns the record types managed with Cloudflare.
+func (p *CloudFlareProvider) SupportedRecordTypes() []string {
+	return provider.DefaultSupportedRecordTypes(endpoint.RecordTypeMX, endpoint.RecordTypeCAA)
+}
+
+// DefaultManagedRecordTypes adds CAA to the default managed record types. CAA
+// records are never proxied, whatever the proxied setting of their endpoint.
+func (p *CloudFlareProvider) DefaultManagedRecordTypes() []string {
+	return append(provider.DefaultManagedRecordTypes(p.SupportedRecordTypes()), endpoint.RecordTypeCAA)
+}
+
 // Records returns the list of records.
 func (p *CloudFlareProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.Zones(ctx)
 	if err != nil {
 		return nil, err
 	}
+	p.zoneNames = provider.ZoneIDName{}
+	for _, zone := range zones {
+		p.zoneNames.Add(zone.ID, zone.Name)
+	}
+	p.entitlements = zoneEntitlementsByID(zones)
 
 	var endpoints []*endpoint.Endpoint
 	for _, zone := range zones {
@@ -414,9 +478,13 @@ func (p *CloudFlareProvider) Records(ctx context.Context) ([]*endpoint.Endpoint,
 
 		// nil if custom hostnames are not enabled
 		chs, chErr := p.listCustomHostnamesWithPagination(ctx, zone.ID)
+		if errors.Is(chErr, errCustomHostnamesUnavailable) {
+			chs, chErr = nil, nil
+		}
 		if chErr != nil {
 			return nil, chErr
 		}
+		p.logPendingCustomHostnames(zone.ID, chs)
 
 		// As CloudFlare does not support "sets" of targets, but instead returns
 		// a single entry for each name/type/target, we have to group by name
@@ -526,11 +594,21 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 	}
 	// separate into per-zone change sets to be passed to the API.
 	changesByZone := p.changesByZone(zones, changes)
+	entitlements := zoneEntitlementsByID(zones)
 
 	var failedZones []string
 	for zoneID, zoneChanges := range changesByZone {
 		var failedChange bool
 
+		// nil if custom hostnames are not enabled
+		chs, chErr := p.listCustomHostnamesWithPagination(ctx, zoneID)
+		zoneEntitlements := entitlements[zoneID]
+		if errors.Is(chErr, errCustomHostnamesUnavailable) {
+			zoneEntitlements.customHostnames = false
+			chs, chErr = nil, nil
+		}
+		p.enforceZoneEntitlements(ctx, zoneID, zoneEntitlements, zoneChanges)
+
 		for _, change := range zoneChanges {
 			logFields := log.Fields{
 				"record": change.ResourceRecord.Name,
@@ -573,16 +651,20 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 		if err != nil {
 			return fmt.Errorf("could not fetch records from zone, %w", err)
 		}
-		chs, chErr := p.listCustomHostnamesWithPagination(ctx, zoneID)
 		if chErr != nil {
 			return fmt.Errorf("could not fetch custom hostnames from zone, %w", chErr)
 		}
 
 		// Apply custom hostname side-effects (separate Cloudflare API), then
 		// classify DNS record changes into batch collections.
+		if err := p.ensureFallbackOrigin(ctx, zones, zoneID); err != nil {
+			log.WithField("zone", zoneID).Errorf("failed to set custom hostnames fallback origin: %v", err)
+			failedChange = true
+		}
 		if p.processCustomHostnameChanges(ctx, zoneID, zoneChanges, chs) {
 			failedChange = true
 		}
+		p.emitPendingCustomHostnameEvents(ctx, zoneID, zoneChanges)
 		bc := p.buildBatchCollections(zoneID, zoneChanges, records)
 
 		if p.submitDNSRecordChanges(ctx, zoneID, bc, records) {
@@ -634,41 +716,48 @@ func parseTagsAnnotation(tagString string) []string {
 
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
 func (p *CloudFlareProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
-	var adjustedEndpoints []*endpoint.Endpoint
-	for _, e := range endpoints {
-		proxied := shouldBeProxied(e, p.proxiedByDefault)
-		if proxied {
-			e.RecordTTL = 0
-		}
-		e.SetProviderSpecificProperty(annotations.CloudflareProxiedKey, strconv.FormatBool(proxied))
-
-		if p.CustomHostnamesConfig.Enabled {
-			// sort custom hostnames in annotation to properly detect changes
-			if customHostnames := getEndpointCustomHostnames(e); len(customHostnames) > 1 {
-				sort.Strings(customHostnames)
-				e.SetProviderSpecificProperty(annotations.CloudflareCustomHostnameKey, strings.Join(customHostnames, ","))
-			}
-		} else {
-			// ignore custom hostnames annotations if not enabled
-			e.DeleteProviderSpecificProperty(annotations.CloudflareCustomHostnameKey)
-		}
+	return provider.EndpointAdjuster(p.adjustEndpoint).AdjustEndpoints(endpoints)
+}
+
+func (p *CloudFlareProvider) adjustEndpoint(e *endpoint.Endpoint) []*endpoint.Endpoint {
+	warnUnsupportedProxied(e)
+	proxied := shouldBeProxied(e, p.proxiedByDefault)
+	if proxied && !p.canProxy(e.DNSName) {
+		// the record is created unproxied, so that the plan converges
+		log.Warnf("The zone of %q does not support proxied records, creating it unproxied: remove the %s annotation or proxy it from the primary zone",
+			e.DNSName, annotations.CloudflareProxiedKey)
+		proxied = false
+	}
+	if proxied {
+		e.RecordTTL = 0
+	}
+	e.SetProviderSpecificProperty(annotations.CloudflareProxiedProperty, strconv.FormatBool(proxied))
 
-		if val, ok := e.GetProviderSpecificProperty(annotations.CloudflareTagsKey); ok {
-			sortedTags := parseTagsAnnotation(val)
-			e.SetProviderSpecificProperty(annotations.CloudflareTagsKey, strings.Join(sortedTags, ","))
+	if p.CustomHostnamesConfig.Enabled {
+		// sort custom hostnames in annotation to properly detect changes
+		if customHostnames := getEndpointCustomHostnames(e); len(customHostnames) > 1 {
+			sort.Strings(customHostnames)
+			e.SetProviderSpecificProperty(annotations.CloudflareCustomHostnameProperty, strings.Join(customHostnames, ","))
 		}
+	} else {
+		// ignore custom hostnames annotations if not enabled
+		e.DeleteProviderSpecificProperty(annotations.CloudflareCustomHostnameProperty)
+	}
 
-		p.adjustEndpointProviderSpecificRegionKeyProperty(e)
+	if val, ok := e.GetProviderSpecificProperty(annotations.CloudflareTagsProperty); ok {
+		sortedTags := parseTagsAnnotation(val)
+		e.SetProviderSpecificProperty(annotations.CloudflareTagsProperty, strings.Join(sortedTags, ","))
+	}
 
-		if p.DNSRecordsConfig.Comment != "" {
-			if _, found := e.GetProviderSpecificProperty(annotations.CloudflareRecordCommentKey); !found {
-				e.SetProviderSpecificProperty(annotations.CloudflareRecordCommentKey, p.DNSRecordsConfig.Comment)
-			}
-		}
+	p.adjustEndpointProviderSpecificRegionKeyProperty(e)
 
-		adjustedEndpoints = append(adjustedEndpoints, e)
+	if p.DNSRecordsConfig.Comment != "" {
+		if _, found := e.GetProviderSpecificProperty(annotations.CloudflareRecordCommentProperty); !found {
+			e.SetProviderSpecificProperty(annotations.CloudflareRecordCommentProperty, p.DNSRecordsConfig.Comment)
+		}
 	}
-	return adjustedEndpoints, nil
+
+	return []*endpoint.Endpoint{e}
 }
 
 // changesByZone separates a multi-zone change into a single change per zone.
@@ -694,7 +783,7 @@ func (p *CloudFlareProvider) changesByZone(zones []zones.Zone, changeSet []*clou
 }
 
 func (p *CloudFlareProvider) getRecordID(records DNSRecordsMap, record dns.RecordResponse) string {
-	if zoneRecord, ok := records[DNSRecordIndex{Name: record.Name, Type: string(record.Type), Content: record.Content}]; ok {
+	if zoneRecord, ok := records[newDNSRecordIndex(record)]; ok {
 		return zoneRecord.ID
 	}
 	return ""
@@ -721,19 +810,27 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 
 	// Load comment from program flag
 	comment := p.DNSRecordsConfig.Comment
-	if val, ok := ep.GetProviderSpecificProperty(annotations.CloudflareRecordCommentKey); ok {
+	if val, ok := ep.GetProviderSpecificProperty(annotations.CloudflareRecordCommentProperty); ok {
 		// Replace comment with Ingress annotation
 		comment = val
 	}
 
 	var tags []string
-	if val, ok := ep.GetProviderSpecificProperty(annotations.CloudflareTagsKey); ok {
+	if val, ok := ep.GetProviderSpecificProperty(annotations.CloudflareTagsProperty); ok {
 		tags = parseTagsAnnotation(val)
 	}
 
 	if len(comment) > freeZoneMaxCommentLength {
 		comment = p.DNSRecordsConfig.trimAndValidateComment(ep.DNSName, comment, p.ZoneHasPaidPlan)
 	}
+	if p.DNSRecordsConfig.DesiredHash {
+		hash := ep.DesiredHash()
+		maxLength := freeZoneMaxCommentLength
+		if len(comment)+len(desiredHashCommentPrefix)+len(hash)+1 > maxLength && p.ZoneHasPaidPlan(ep.DNSName) {
+			maxLength = paidZoneMaxCommentLength
+		}
+		comment = withDesiredHashComment(comment, hash, maxLength)
+	}
 
 	var priority float64
 	if ep.RecordType == "MX" {
@@ -746,6 +843,18 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 		}
 	}
 
+	// CAA records are submitted with their data, the content is kept normalized
+	// to match the records returned by the API
+	var data any
+	if ep.RecordType == endpoint.RecordTypeCAA {
+		caa, err := endpoint.NewCAARecord(target)
+		if err != nil {
+			return &cloudFlareChange{}, fmt.Errorf("failed to parse CAA record target %q: %w", target, err)
+		}
+		target = caa.String()
+		data = dns.CAARecordData{Flags: float64(*caa.GetFlags()), Tag: *caa.GetTag(), Value: *caa.GetValue()}
+	}
+
 	return &cloudFlareChange{
 		Action: action,
 		ResourceRecord: dns.RecordResponse{
@@ -757,15 +866,47 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 			Comment:  comment,
 			Tags:     tags,
 			Priority: priority,
+			Data:     data,
 		},
 		RegionalHostname:    p.regionalHostname(ep),
 		CustomHostnamesPrev: prevCustomHostnames,
 		CustomHostnames:     newCustomHostnames,
+		RefObjects:          ep.RefObjects(),
 	}, nil
 }
 
 func newDNSRecordIndex(r dns.RecordResponse) DNSRecordIndex {
-	return DNSRecordIndex{Name: r.Name, Type: string(r.Type), Content: r.Content}
+	return DNSRecordIndex{Name: r.Name, Type: string(r.Type), Content: recordContent(r)}
+}
+
+// recordContent returns the content of a record. The content of a CAA record is
+// normalized, or built from its data when missing, so that the records of the API
+// and the changes compare equal.
+func recordContent(r dns.RecordResponse) string {
+	if r.Type != dns.RecordResponseTypeCAA {
+		return r.Content
+	}
+	if caa, err := endpoint.NewCAARecord(r.Content); err == nil {
+		return caa.String()
+	}
+	if data, ok := r.Data.(dns.CAARecordData); ok {
+		return endpoint.NewCAATarget(uint8(data.Flags), data.Tag, data.Value).String()
+	}
+	return r.Content
+}
+
+// recordDataParam returns the data parameter of the records submitted with their
+// data rather than their content, i.e. CAA records.
+func recordDataParam(r dns.RecordResponse) (dns.CAARecordDataParam, bool) {
+	data, ok := r.Data.(dns.CAARecordData)
+	if !ok {
+		return dns.CAARecordDataParam{}, false
+	}
+	return dns.CAARecordDataParam{
+		Flags: cloudflare.F(data.Flags),
+		Tag:   cloudflare.F(data.Tag),
+		Value: cloudflare.F(data.Value),
+	}, true
 }
 
 // getDNSRecordsMap retrieves all DNS records for a given zone and returns them as a DNSRecordsMap.
@@ -790,10 +931,10 @@ func shouldBeProxied(ep *endpoint.Endpoint, proxiedByDefault bool) bool {
 	proxied := proxiedByDefault
 
 	for _, v := range ep.ProviderSpecific {
-		if v.Name == annotations.CloudflareProxiedKey {
+		if v.Name == annotations.CloudflareProxiedProperty {
 			b, err := strconv.ParseBool(v.Value)
 			if err != nil {
-				log.Errorf("Failed to parse annotation [%q]: %v", annotations.CloudflareProxiedKey, err)
+				log.Errorf("Failed to parse annotation [%q]: %v", annotations.CloudflareProxiedProperty, err)
 			} else {
 				proxied = b
 			}
@@ -809,7 +950,7 @@ func shouldBeProxied(ep *endpoint.Endpoint, proxiedByDefault bool) bool {
 
 func getEndpointCustomHostnames(ep *endpoint.Endpoint) []string {
 	for _, v := range ep.ProviderSpecific {
-		if v.Name == annotations.CloudflareCustomHostnameKey {
+		if v.Name == annotations.CloudflareCustomHostnameProperty {
 			customHostnames := strings.Split(v.Value, ",")
 			return customHostnames
 		}
@@ -853,7 +994,7 @@ func (p *CloudFlareProvider) groupByNameAndTypeWithCustomHostnames(records DNSRe
 			if records[i].Type == "MX" {
 				targets[i] = fmt.Sprintf("%v %v", record.Priority, record.Content)
 			} else {
-				targets[i] = record.Content
+				targets[i] = recordContent(record)
 			}
 		}
 		e := endpoint.NewEndpointWithTTL(
@@ -865,21 +1006,21 @@ func (p *CloudFlareProvider) groupByNameAndTypeWithCustomHostnames(records DNSRe
 		if e == nil {
 			continue
 		}
-		e = e.WithProviderSpecific(annotations.CloudflareProxiedKey, strconv.FormatBool(proxied))
+		e = e.WithProviderSpecific(annotations.CloudflareProxiedProperty, strconv.FormatBool(proxied))
 		// noop (customHostnames is empty) if custom hostnames feature is not in use
 		if customHostnames, ok := customHostnames[records[0].Name]; ok {
 			sort.Strings(customHostnames)
-			e = e.WithProviderSpecific(annotations.CloudflareCustomHostnameKey, strings.Join(customHostnames, ","))
+			e = e.WithProviderSpecific(annotations.CloudflareCustomHostnameProperty, strings.Join(customHostnames, ","))
 		}
 
-		if records[0].Comment != "" {
-			e = e.WithProviderSpecific(annotations.CloudflareRecordCommentKey, records[0].Comment)
+		if comment := trimDesiredHashComment(records[0].Comment); comment != "" {
+			e = e.WithProviderSpecific(annotations.CloudflareRecordCommentProperty, comment)
 		}
 
 		if records[0].Tags != nil {
 			if tags, ok := records[0].Tags.([]string); ok && len(tags) > 0 {
 				sort.Strings(tags)
-				e = e.WithProviderSpecific(annotations.CloudflareTagsKey, strings.Join(tags, ","))
+				e = e.WithProviderSpecific(annotations.CloudflareTagsProperty, strings.Join(tags, ","))
 			}
 		}
 
@@ -891,7 +1032,7 @@ func (p *CloudFlareProvider) groupByNameAndTypeWithCustomHostnames(records DNSRe
 // SupportedRecordType returns true if the record type is supported by the provider
 func (p *CloudFlareProvider) SupportedAdditionalRecordTypes(recordType string) bool {
 	switch recordType {
-	case endpoint.RecordTypeMX:
+	case endpoint.RecordTypeMX, endpoint.RecordTypeCAA:
 		return true
 	default:
 		return provider.SupportedRecordType(recordType)
@@ -70,35 +70,45 @@ func (z zoneService) BatchDNSRecords(ctx context.Context, params dns.RecordBatch
 
 // getUpdateDNSRecordParam returns the RecordUpdateParams for an individual update.
 func getUpdateDNSRecordParam(zoneID string, cfc cloudFlareChange) dns.RecordUpdateParams {
+	body := dns.RecordUpdateParamsBody{
+		Name:     cloudflare.F(cfc.ResourceRecord.Name),
+		TTL:      cloudflare.F(cfc.ResourceRecord.TTL),
+		Proxied:  cloudflare.F(cfc.ResourceRecord.Proxied),
+		Type:     cloudflare.F(dns.RecordUpdateParamsBodyType(cfc.ResourceRecord.Type)),
+		Priority: cloudflare.F(cfc.ResourceRecord.Priority),
+		Comment:  cloudflare.F(cfc.ResourceRecord.Comment),
+		Tags:     cloudflare.F(cfc.ResourceRecord.Tags),
+	}
+	if data, ok := recordDataParam(cfc.ResourceRecord); ok {
+		body.Data = cloudflare.F[any](data)
+	} else {
+		body.Content = cloudflare.F(cfc.ResourceRecord.Content)
+	}
 	return dns.RecordUpdateParams{
 		ZoneID: cloudflare.F(zoneID),
-		Body: dns.RecordUpdateParamsBody{
-			Name:     cloudflare.F(cfc.ResourceRecord.Name),
-			TTL:      cloudflare.F(cfc.ResourceRecord.TTL),
-			Proxied:  cloudflare.F(cfc.ResourceRecord.Proxied),
-			Type:     cloudflare.F(dns.RecordUpdateParamsBodyType(cfc.ResourceRecord.Type)),
-			Content:  cloudflare.F(cfc.ResourceRecord.Content),
-			Priority: cloudflare.F(cfc.ResourceRecord.Priority),
-			Comment:  cloudflare.F(cfc.ResourceRecord.Comment),
-			Tags:     cloudflare.F(cfc.ResourceRecord.Tags),
-		},
+		Body:   body,
 	}
 }
 
 // getCreateDNSRecordParam returns the RecordNewParams for an individual create.
 func getCreateDNSRecordParam(zoneID string, cfc *cloudFlareChange) dns.RecordNewParams {
+	body := dns.RecordNewParamsBody{
+		Name:     cloudflare.F(cfc.ResourceRecord.Name),
+		TTL:      cloudflare.F(cfc.ResourceRecord.TTL),
+		Proxied:  cloudflare.F(cfc.ResourceRecord.Proxied),
+		Type:     cloudflare.F(dns.RecordNewParamsBodyType(cfc.ResourceRecord.Type)),
+		Priority: cloudflare.F(cfc.ResourceRecord.Priority),
+		Comment:  cloudflare.F(cfc.ResourceRecord.Comment),
+		Tags:     cloudflare.F(cfc.ResourceRecord.Tags),
+	}
+	if data, ok := recordDataParam(cfc.ResourceRecord); ok {
+		body.Data = cloudflare.F[any](data)
+	} else {
+		body.Content = cloudflare.F(cfc.ResourceRecord.Content)
+	}
 	return dns.RecordNewParams{
 		ZoneID: cloudflare.F(zoneID),
-		Body: dns.RecordNewParamsBody{
-			Name:     cloudflare.F(cfc.ResourceRecord.Name),
-			TTL:      cloudflare.F(cfc.ResourceRecord.TTL),
-			Proxied:  cloudflare.F(cfc.ResourceRecord.Proxied),
-			Type:     cloudflare.F(dns.RecordNewParamsBodyType(cfc.ResourceRecord.Type)),
-			Content:  cloudflare.F(cfc.ResourceRecord.Content),
-			Priority: cloudflare.F(cfc.ResourceRecord.Priority),
-			Comment:  cloudflare.F(cfc.ResourceRecord.Comment),
-			Tags:     cloudflare.F(cfc.ResourceRecord.Tags),
-		},
+		Body:   body,
 	}
 }
 
@@ -158,16 +168,21 @@ func tagsFromResponse(tags any) []dns.RecordTagsParam {
 
 // buildBatchPostParam constructs a RecordBatchParamsPost for creating a DNS record in a batch.
 func buildBatchPostParam(r dns.RecordResponse) dns.RecordBatchParamsPost {
-	return dns.RecordBatchParamsPost{
+	post := dns.RecordBatchParamsPost{
 		Name:     cloudflare.F(r.Name),
 		TTL:      cloudflare.F(r.TTL),
 		Type:     cloudflare.F(dns.RecordBatchParamsPostsType(r.Type)),
-		Content:  cloudflare.F(r.Content),
 		Proxied:  cloudflare.F(r.Proxied),
 		Priority: cloudflare.F(r.Priority),
 		Comment:  cloudflare.F(r.Comment),
 		Tags:     cloudflare.F[any](tagsFromResponse(r.Tags)),
 	}
+	if data, ok := recordDataParam(r); ok {
+		post.Data = cloudflare.F[any](data)
+	} else {
+		post.Content = cloudflare.F(r.Content)
+	}
+	return post
 }
 
 // buildBatchPutParam constructs a BatchPutUnionParam for updating a DNS record in a batch.
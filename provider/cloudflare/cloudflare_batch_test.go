@@ -98,14 +98,16 @@ func (m *mockCloudFlareClient) BatchDNSRecords(_ context.Context, params dns.Rec
 			continue
 		}
 		typeStr := string(post.Type.Value)
+		content, data := mockRecordContent(post.Content.Value, post.Data.Value)
 		record := dns.RecordResponse{
-			ID:       generateDNSRecordID(typeStr, post.Name.Value, post.Content.Value),
+			ID:       generateDNSRecordID(typeStr, post.Name.Value, content),
 			Name:     post.Name.Value,
 			TTL:      dns.TTL(post.TTL.Value),
 			Proxied:  post.Proxied.Value,
 			Type:     dns.RecordResponseType(typeStr),
-			Content:  post.Content.Value,
+			Content:  content,
 			Priority: post.Priority.Value,
+			Data:     data,
 		}
 		m.Actions = append(m.Actions, MockAction{
 			Name:       "Create",
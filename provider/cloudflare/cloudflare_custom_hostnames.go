@@ -21,15 +21,19 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/cloudflare/cloudflare-go/v5"
 	"github.com/cloudflare/cloudflare-go/v5/custom_hostnames"
 	"github.com/cloudflare/cloudflare-go/v5/dns"
 	"github.com/cloudflare/cloudflare-go/v5/option"
+	"github.com/cloudflare/cloudflare-go/v5/zones"
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/internal/sets"
+	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/provider"
 )
 
@@ -40,6 +44,25 @@ type customHostname struct {
 	customOriginServer string
 	customOriginSNI    string
 	ssl                *customHostnameSSL
+	status             string
+	sslStatus          string
+	validationRecords  []customHostnameValidationRecord
+}
+
+// customHostnameValidationRecord holds the tokens a custom hostname owner must
+// publish to prove ownership or to complete certificate validation
+type customHostnameValidationRecord struct {
+	txtName  string
+	txtValue string
+	httpURL  string
+	httpBody string
+}
+
+func (r customHostnameValidationRecord) String() string {
+	if r.txtName != "" {
+		return fmt.Sprintf("TXT %s=%q", r.txtName, r.txtValue)
+	}
+	return fmt.Sprintf("HTTP %s=%q", r.httpURL, r.httpBody)
 }
 
 // customHostnameSSL represents SSL configuration for custom hostname
@@ -67,8 +90,12 @@ type CustomHostnamesConfig struct {
 	Enabled              bool
 	MinTLSVersion        string
 	CertificateAuthority string
+	// FallbackOrigin is the zone fallback origin for custom hostnames, left unmanaged when empty
+	FallbackOrigin string
 }
 
+const customHostnameStatusActive = "active"
+
 var recordTypeCustomHostnameSupported = sets.New[dns.RecordResponseType](
 	"A",
 	"CNAME",
@@ -86,6 +113,24 @@ func (z zoneService) DeleteCustomHostname(ctx context.Context, customHostnameID
 	return err
 }
 
+func (z zoneService) CustomHostnameFallbackOrigin(ctx context.Context, zoneID string) (string, error) {
+	res, err := z.service.CustomHostnames.FallbackOrigin.Get(ctx, custom_hostnames.FallbackOriginGetParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.Origin, nil
+}
+
+func (z zoneService) UpdateCustomHostnameFallbackOrigin(ctx context.Context, zoneID string, origin string) error {
+	_, err := z.service.CustomHostnames.FallbackOrigin.Update(ctx, custom_hostnames.FallbackOriginUpdateParams{
+		ZoneID: cloudflare.F(zoneID),
+		Origin: cloudflare.F(origin),
+	})
+	return err
+}
+
 func (z zoneService) CreateCustomHostname(ctx context.Context, zoneID string, ch customHostname) error {
 	params := buildCustomHostnameNewParams(zoneID, ch)
 	_, err := z.service.CustomHostnames.New(ctx, params,
@@ -258,6 +303,10 @@ func newCustomHostnameIndex(ch customHostname) customHostnameIndex {
 	return customHostnameIndex{hostname: ch.hostname}
 }
 
+// errCustomHostnamesUnavailable is returned when the custom hostnames API refuses to
+// list the custom hostnames of a zone, i.e. the zone has no access to Cloudflare for SaaS.
+var errCustomHostnamesUnavailable = errors.New("custom hostnames are not available in the zone")
+
 // listCustomHostnamesWithPagination performs automatic pagination of results on requests to cloudflare.CustomHostnames
 func (p *CloudFlareProvider) listCustomHostnamesWithPagination(ctx context.Context, zoneID string) (customHostnamesMap, error) {
 	if !p.CustomHostnamesConfig.Enabled {
@@ -266,6 +315,11 @@ func (p *CloudFlareProvider) listCustomHostnamesWithPagination(ctx context.Conte
 	chs := make(customHostnamesMap)
 	iter := p.Client.CustomHostnames(ctx, zoneID)
 	customHostnames, err := listAllCustomHostnames(iter)
+	var apiErr *cloudflare.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden {
+		log.WithField("zone", zoneID).Debugf("Custom hostnames are not available in the zone, Cloudflare for SaaS is not enabled or the API token lacks the permission: %v", err)
+		return nil, errCustomHostnamesUnavailable
+	}
 	if err != nil {
 		convertedError := convertCloudflareError(err)
 		if !errors.Is(convertedError, provider.SoftError) {
@@ -312,6 +366,9 @@ func listAllCustomHostnames(iter autoPager[custom_hostnames.CustomHostnameListRe
 			hostname:           ch.Hostname,
 			customOriginServer: ch.CustomOriginServer,
 			customOriginSNI:    ch.CustomOriginSNI,
+			status:             string(ch.Status),
+			sslStatus:          string(ch.SSL.Status),
+			validationRecords:  customHostnameValidationRecords(ch),
 		})
 	}
 	if iter.Err() != nil {
@@ -319,3 +376,132 @@ func listAllCustomHostnames(iter autoPager[custom_hostnames.CustomHostnameListRe
 	}
 	return customHostnames, nil
 }
+
+// customHostnameValidationRecords collects the ownership and certificate
+// validation tokens of a custom hostname
+func customHostnameValidationRecords(ch custom_hostnames.CustomHostnameListResponse) []customHostnameValidationRecord {
+	var records []customHostnameValidationRecord
+	if ch.OwnershipVerification.Name != "" {
+		records = append(records, customHostnameValidationRecord{
+			txtName:  ch.OwnershipVerification.Name,
+			txtValue: ch.OwnershipVerification.Value,
+		})
+	}
+	if ch.OwnershipVerificationHTTP.HTTPURL != "" {
+		records = append(records, customHostnameValidationRecord{
+			httpURL:  ch.OwnershipVerificationHTTP.HTTPURL,
+			httpBody: ch.OwnershipVerificationHTTP.HTTPBody,
+		})
+	}
+	for _, r := range ch.SSL.ValidationRecords {
+		if r.TXTName == "" && r.HTTPURL == "" {
+			continue
+		}
+		records = append(records, customHostnameValidationRecord{
+			txtName:  r.TXTName,
+			txtValue: r.TXTValue,
+			httpURL:  r.HTTPURL,
+			httpBody: r.HTTPBody,
+		})
+	}
+	return records
+}
+
+// isPending returns true while the custom hostname or its certificate awaits validation
+func (ch customHostname) isPending() bool {
+	return (ch.status != "" && ch.status != customHostnameStatusActive) ||
+		(ch.sslStatus != "" && ch.sslStatus != customHostnameStatusActive)
+}
+
+// pendingMessage describes what is needed to complete the validation of a custom hostname
+func (ch customHostname) pendingMessage() string {
+	tokens := make([]string, 0, len(ch.validationRecords))
+	for _, r := range ch.validationRecords {
+		tokens = append(tokens, r.String())
+	}
+	return fmt.Sprintf("custom hostname %q is pending validation (status: %s, ssl: %s), validation records: [%s]",
+		ch.hostname, ch.status, ch.sslStatus, strings.Join(tokens, ", "))
+}
+
+// logPendingCustomHostnames reports custom hostnames awaiting validation so
+// that the validation tokens are visible until the hostname becomes active.
+// Only new or changed pending states are logged at info level.
+func (p *CloudFlareProvider) logPendingCustomHostnames(zoneID string, chs customHostnamesMap) {
+	if p.pendingCustomHostnames == nil {
+		p.pendingCustomHostnames = make(map[string]map[string]string)
+	}
+	logged := p.pendingCustomHostnames[zoneID]
+	pending := make(map[string]string)
+	for _, ch := range chs {
+		if !ch.isPending() {
+			continue
+		}
+		msg := ch.pendingMessage()
+		pending[ch.hostname] = msg
+		if logged[ch.hostname] == msg {
+			log.WithField("zone", zoneID).Debug(msg)
+			continue
+		}
+		log.WithField("zone", zoneID).Info(msg)
+	}
+	p.pendingCustomHostnames[zoneID] = pending
+}
+
+// emitPendingCustomHostnameEvents emits an event on the source objects of each
+// change whose custom hostnames await validation. The custom hostnames are
+// listed again to pick up the validation tokens of those just created.
+func (p *CloudFlareProvider) emitPendingCustomHostnameEvents(ctx context.Context, zoneID string, changes []*cloudFlareChange) {
+	emitter := events.EmitterFromContext(ctx)
+	if emitter == nil || !p.CustomHostnamesConfig.Enabled {
+		return
+	}
+	if !slices.ContainsFunc(changes, func(c *cloudFlareChange) bool {
+		return c.Action != cloudFlareDelete && len(c.CustomHostnames) > 0 && len(c.RefObjects) > 0
+	}) {
+		return
+	}
+	chs, err := p.listCustomHostnamesWithPagination(ctx, zoneID)
+	if err != nil {
+		log.WithField("zone", zoneID).Warnf("failed to list custom hostnames to report their validation status: %v", err)
+		return
+	}
+	for _, change := range changes {
+		if change.Action == cloudFlareDelete {
+			continue
+		}
+		for name := range change.CustomHostnames {
+			if ch, err := getCustomHostname(chs, name); err == nil && ch.isPending() {
+				emitter.Add(events.NewNormalEvent(change.RefObjects, ch.pendingMessage(), events.ActionCreate, events.CustomHostnamePending))
+			}
+		}
+	}
+}
+
+// ensureFallbackOrigin sets the fallback origin for custom hostnames when
+// configured. Only the zone the fallback origin belongs to is updated.
+func (p *CloudFlareProvider) ensureFallbackOrigin(ctx context.Context, zoneList []zones.Zone, zoneID string) error {
+	origin := p.CustomHostnamesConfig.FallbackOrigin
+	if !p.CustomHostnamesConfig.Enabled || origin == "" {
+		return nil
+	}
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, z := range zoneList {
+		zoneNameIDMapper.Add(z.ID, z.Name)
+	}
+	if originZoneID, _ := zoneNameIDMapper.FindZone(origin); originZoneID != zoneID {
+		return nil
+	}
+	current, err := p.Client.CustomHostnameFallbackOrigin(ctx, zoneID)
+	if err != nil {
+		// a zone without a fallback origin answers with an error, so try to set it anyway
+		log.WithField("zone", zoneID).Debugf("failed to get custom hostnames fallback origin: %v", err)
+	}
+	if current == origin {
+		return nil
+	}
+	log.WithField("zone", zoneID).Infof("Setting custom hostnames fallback origin to %q", origin)
+	if err := p.Client.UpdateCustomHostnameFallbackOrigin(ctx, zoneID, origin); err != nil {
+		return convertCloudflareError(err)
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -28,13 +29,22 @@ import (
 	"github.com/cloudflare/cloudflare-go/v5/dns"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	logtest "sigs.k8s.io/external-dns/internal/testutils/log"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/pkg/events/fake"
 	"sigs.k8s.io/external-dns/plan"
 )
 
 func (m *mockCloudFlareClient) CustomHostnames(ctx context.Context, zoneID string) autoPager[custom_hostnames.CustomHostnameListResponse] {
+	if m.saaslessZones[zoneID] {
+		return &mockAutoPager[custom_hostnames.CustomHostnameListResponse]{
+			err: newCloudflareError(http.StatusForbidden),
+		}
+	}
 	if strings.HasPrefix(zoneID, "newerror-") {
 		return &mockAutoPager[custom_hostnames.CustomHostnameListResponse]{
 			err: errors.New("failed to list custom hostnames"),
@@ -51,11 +61,17 @@ func (m *mockCloudFlareClient) CustomHostnames(ctx context.Context, zoneID strin
 					err: errors.New("failed to list erroring custom hostname"),
 				}
 			}
-			result = append(result, custom_hostnames.CustomHostnameListResponse{
+			item := custom_hostnames.CustomHostnameListResponse{
 				ID:                 ch.id,
 				Hostname:           ch.hostname,
 				CustomOriginServer: ch.customOriginServer,
-			})
+				Status:             custom_hostnames.CustomHostnameListResponseStatus(ch.status),
+			}
+			for _, r := range ch.validationRecords {
+				item.OwnershipVerification.Name = r.txtName
+				item.OwnershipVerification.Value = r.txtValue
+			}
+			result = append(result, item)
 		}
 	}
 	return &mockAutoPager[custom_hostnames.CustomHostnameListResponse]{
@@ -72,10 +88,35 @@ func (m *mockCloudFlareClient) CreateCustomHostname(_ context.Context, zoneID st
 	}
 	newCustomHostname := ch
 	newCustomHostname.id = fmt.Sprintf("ID-%s", ch.hostname)
+	if strings.HasPrefix(ch.hostname, "pending-") {
+		newCustomHostname.status = "pending"
+		newCustomHostname.validationRecords = []customHostnameValidationRecord{
+			{txtName: "_cf-custom-hostname." + ch.hostname, txtValue: "token-" + ch.hostname},
+		}
+	}
 	m.customHostnames[zoneID] = append(m.customHostnames[zoneID], newCustomHostname)
 	return nil
 }
 
+func (m *mockCloudFlareClient) CustomHostnameFallbackOrigin(_ context.Context, zoneID string) (string, error) {
+	origin, ok := m.fallbackOrigins[zoneID]
+	if !ok {
+		return "", errors.New("fallback origin not found")
+	}
+	return origin, nil
+}
+
+func (m *mockCloudFlareClient) UpdateCustomHostnameFallbackOrigin(_ context.Context, zoneID string, origin string) error {
+	if origin == "newerror-fallback.bar.com" {
+		return errors.New("failed to update fallback origin")
+	}
+	if m.fallbackOrigins == nil {
+		m.fallbackOrigins = map[string]string{}
+	}
+	m.fallbackOrigins[zoneID] = origin
+	return nil
+}
+
 func (m *mockCloudFlareClient) DeleteCustomHostname(_ context.Context, customHostnameID string, params custom_hostnames.CustomHostnameDeleteParams) error {
 	zoneID := params.ZoneID.String()
 	idx := 0
@@ -806,3 +847,123 @@ func TestSubmitCustomHostnameChanges(t *testing.T) {
 		)
 	})
 }
+
+func TestCloudflareCustomHostnamePendingValidation(t *testing.T) {
+	client := NewMockCloudFlareClient()
+	p := &CloudFlareProvider{
+		Client:                client,
+		CustomHostnamesConfig: CustomHostnamesConfig{Enabled: true},
+	}
+	emitter := fake.NewFakeEventEmitter()
+	ctx := events.ContextWithEmitter(t.Context(), emitter)
+
+	ep := endpoint.NewEndpoint("origin.bar.com", endpoint.RecordTypeA, "1.2.3.4").
+		WithProviderSpecific("external-dns.kubernetes.io/cloudflare-custom-hostname", "pending-a.fancybar.com").
+		WithRefObject(events.NewObjectReferenceFromParts("DNSEndpoint", "externaldns.k8s.io/v1alpha1", "default", "saas", "", "crd"))
+
+	err := p.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{ep}})
+	require.NoError(t, err)
+
+	emitter.AssertNumberOfCalls(t, "Add", 1)
+	event, ok := emitter.Calls[0].Arguments.Get(0).(events.Event)
+	require.True(t, ok)
+	assert.Equal(t, events.CustomHostnamePending, event.Reason())
+	assert.Contains(t, event.Message(), `TXT _cf-custom-hostname.pending-a.fancybar.com="token-pending-a.fancybar.com"`)
+
+	hook := logtest.LogsUnderTestWithLogLevel(log.InfoLevel, t)
+	_, err = p.Records(t.Context())
+	require.NoError(t, err)
+	logtest.TestHelperLogContains(`custom hostname "pending-a.fancybar.com" is pending validation`, hook, t)
+
+	// an unchanged pending state is not logged again
+	hook.Reset()
+	_, err = p.Records(t.Context())
+	require.NoError(t, err)
+	logtest.TestHelperLogNotContains(`custom hostname "pending-a.fancybar.com" is pending validation`, hook, t)
+}
+
+func TestCloudflareCustomHostnameActiveNoEvent(t *testing.T) {
+	client := NewMockCloudFlareClient()
+	p := &CloudFlareProvider{
+		Client:                client,
+		CustomHostnamesConfig: CustomHostnamesConfig{Enabled: true},
+	}
+	emitter := fake.NewFakeEventEmitter()
+	ctx := events.ContextWithEmitter(t.Context(), emitter)
+
+	ep := endpoint.NewEndpoint("origin.bar.com", endpoint.RecordTypeA, "1.2.3.4").
+		WithProviderSpecific("external-dns.kubernetes.io/cloudflare-custom-hostname", "a.fancybar.com").
+		WithRefObject(events.NewObjectReferenceFromParts("DNSEndpoint", "externaldns.k8s.io/v1alpha1", "default", "saas", "", "crd"))
+
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{ep}}))
+	emitter.AssertNotCalled(t, "Add", mock.Anything)
+}
+
+func TestCloudflareCustomHostnameFallbackOrigin(t *testing.T) {
+	client := NewMockCloudFlareClient()
+	p := &CloudFlareProvider{
+		Client:                client,
+		CustomHostnamesConfig: CustomHostnamesConfig{Enabled: true, FallbackOrigin: "fallback.bar.com"},
+	}
+
+	ep := endpoint.NewEndpoint("origin.bar.com", endpoint.RecordTypeA, "1.2.3.4")
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Create: []*endpoint.Endpoint{ep}}))
+	assert.Equal(t, map[string]string{"001": "fallback.bar.com"}, client.fallbackOrigins)
+
+	zoneList, err := p.Zones(t.Context())
+	require.NoError(t, err)
+
+	// already configured, nothing to do
+	require.NoError(t, p.ensureFallbackOrigin(t.Context(), zoneList, "001"))
+
+	p.CustomHostnamesConfig.FallbackOrigin = "newerror-fallback.bar.com"
+	require.Error(t, p.ensureFallbackOrigin(t.Context(), zoneList, "001"))
+
+	// the fallback origin does not belong to the zone
+	require.NoError(t, p.ensureFallbackOrigin(t.Context(), zoneList, "002"))
+
+	p.CustomHostnamesConfig.FallbackOrigin = ""
+	require.NoError(t, p.ensureFallbackOrigin(t.Context(), zoneList, "001"))
+	assert.Equal(t, map[string]string{"001": "fallback.bar.com"}, client.fallbackOrigins)
+}
+
+func TestCustomHostnameValidationRecords(t *testing.T) {
+	ch := custom_hostnames.CustomHostnameListResponse{
+		Hostname: "a.fancybar.com",
+		Status:   custom_hostnames.CustomHostnameListResponseStatusPending,
+		OwnershipVerification: custom_hostnames.CustomHostnameListResponseOwnershipVerification{
+			Name:  "_cf-custom-hostname.a.fancybar.com",
+			Value: "owner-token",
+		},
+		OwnershipVerificationHTTP: custom_hostnames.CustomHostnameListResponseOwnershipVerificationHTTP{
+			HTTPURL:  "http://a.fancybar.com/.well-known/cf-custom-hostname-challenge/id",
+			HTTPBody: "http-token",
+		},
+		SSL: custom_hostnames.CustomHostnameListResponseSSL{
+			Status: custom_hostnames.CustomHostnameListResponseSSLStatusPendingValidation,
+			ValidationRecords: []custom_hostnames.CustomHostnameListResponseSSLValidationRecord{
+				{TXTName: "_acme-challenge.a.fancybar.com", TXTValue: "ssl-token"},
+				{Emails: []string{"admin@fancybar.com"}},
+			},
+		},
+	}
+
+	records := customHostnameValidationRecords(ch)
+	assert.Equal(t, []customHostnameValidationRecord{
+		{txtName: "_cf-custom-hostname.a.fancybar.com", txtValue: "owner-token"},
+		{httpURL: "http://a.fancybar.com/.well-known/cf-custom-hostname-challenge/id", httpBody: "http-token"},
+		{txtName: "_acme-challenge.a.fancybar.com", txtValue: "ssl-token"},
+	}, records)
+
+	pending := customHostname{hostname: ch.Hostname, status: "pending", sslStatus: "pending_validation", validationRecords: records}
+	assert.True(t, pending.isPending())
+	assert.Equal(t,
+		`custom hostname "a.fancybar.com" is pending validation (status: pending, ssl: pending_validation), validation records: [`+
+			`TXT _cf-custom-hostname.a.fancybar.com="owner-token", `+
+			`HTTP http://a.fancybar.com/.well-known/cf-custom-hostname-challenge/id="http-token", `+
+			`TXT _acme-challenge.a.fancybar.com="ssl-token"]`,
+		pending.pendingMessage())
+
+	assert.False(t, customHostname{status: "active", sslStatus: "active"}.isPending())
+	assert.False(t, customHostname{}.isPending())
+}
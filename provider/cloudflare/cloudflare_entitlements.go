@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/cloudflare/cloudflare-go/v5/zones"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// zoneEntitlements holds the features a zone's plan and setup allow, as
+// reported by the zone discovery, to reject unsupported changes before
+// submitting them to the API.
+type zoneEntitlements struct {
+	zoneName string
+	// plan is the legacy identifier of the zone's plan, e.g. free or enterprise
+	plan string
+	// proxied is false for the zones not answering for the records themselves,
+	// i.e. secondary and internal zones
+	proxied bool
+	// customHostnames requires Cloudflare for SaaS, it is false once the custom
+	// hostnames API refused to list the zone's custom hostnames
+	customHostnames bool
+}
+
+func newZoneEntitlements(zone zones.Zone) zoneEntitlements {
+	plan := zone.Plan.LegacyID //nolint:staticcheck // SA1019: Plan is deprecated but no replacement available yet
+	if plan == "" {
+		plan = "unknown"
+	}
+	return zoneEntitlements{
+		zoneName:        zone.Name,
+		plan:            plan,
+		proxied:         zone.Type != zones.TypeSecondary && zone.Type != zones.TypeInternal,
+		customHostnames: true,
+	}
+}
+
+// zoneEntitlementsByID returns the entitlements of the zones by zone ID.
+func zoneEntitlementsByID(zoneList []zones.Zone) map[string]zoneEntitlements {
+	entitlements := make(map[string]zoneEntitlements, len(zoneList))
+	for _, z := range zoneList {
+		entitlements[z.ID] = newZoneEntitlements(z)
+	}
+	return entitlements
+}
+
+// canProxy reports whether the zone of name, as read by the last Records call, can
+// proxy records. Names outside of the known zones are assumed to be proxiable.
+func (p *CloudFlareProvider) canProxy(name string) bool {
+	zoneID, _ := p.zoneNames.FindZone(name)
+	entitlements, ok := p.entitlements[zoneID]
+	return !ok || entitlements.proxied
+}
+
+// enforceZoneEntitlements strips the features the zone is not entitled to from
+// the created and updated records, so that the records themselves are still
+// submitted, and reports each of them through a log and a RecordError event on
+// the source objects instead of a late API failure. Deletions are kept as is.
+func (p *CloudFlareProvider) enforceZoneEntitlements(ctx context.Context, zoneID string, entitlements zoneEntitlements, changes []*cloudFlareChange) {
+	emitter := events.EmitterFromContext(ctx)
+	report := func(change *cloudFlareChange, msg string) {
+		log.WithFields(log.Fields{
+			"zone": zoneID,
+			"plan": entitlements.plan,
+		}).Error(msg)
+		if emitter != nil && len(change.RefObjects) > 0 {
+			emitter.Add(events.NewWarningEvent(change.RefObjects, msg, events.ActionFailed, events.RecordError))
+		}
+	}
+
+	for _, change := range changes {
+		if change.Action == cloudFlareDelete {
+			continue
+		}
+		if change.ResourceRecord.Proxied && !entitlements.proxied {
+			change.ResourceRecord.Proxied = false
+			report(change, fmt.Sprintf("zone %q does not support proxied records, creating %q unproxied: remove the %s annotation or proxy it from the primary zone",
+				entitlements.zoneName, change.ResourceRecord.Name, annotations.CloudflareProxiedKey))
+		}
+		if entitlements.customHostnames {
+			continue
+		}
+		// the custom hostnames the record already had are kept, so that a
+		// downgraded zone does not delete them
+		var hostnames []string
+		maps.DeleteFunc(change.CustomHostnames, func(name string, _ customHostname) bool {
+			if slices.Contains(change.CustomHostnamesPrev, name) {
+				return false
+			}
+			hostnames = append(hostnames, name)
+			return true
+		})
+		if len(hostnames) > 0 {
+			slices.Sort(hostnames)
+			report(change, fmt.Sprintf("zone %q on the %s plan has no access to Cloudflare for SaaS, not creating the custom hostnames %q of %q: upgrade the zone or remove the %s annotation",
+				entitlements.zoneName, entitlements.plan, hostnames, change.ResourceRecord.Name, annotations.CloudflareCustomHostnameKey))
+		}
+	}
+}
+
+// warnUnsupportedProxied reports an endpoint asking to be proxied although its
+// record type can't be, since it is then silently created unproxied.
+func warnUnsupportedProxied(ep *endpoint.Endpoint) {
+	if !recordTypeProxyNotSupported.Has(ep.RecordType) {
+		return
+	}
+	value, ok := ep.GetProviderSpecificProperty(annotations.CloudflareProxiedProperty)
+	if proxied, err := strconv.ParseBool(value); !ok || err != nil || !proxied {
+		return
+	}
+	log.Warnf("Cloudflare does not proxy %s records, creating %q unproxied: remove the %s annotation from it",
+		ep.RecordType, ep.DNSName, annotations.CloudflareProxiedKey)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v5/dns"
+	"github.com/cloudflare/cloudflare-go/v5/zones"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	logtest "sigs.k8s.io/external-dns/internal/testutils/log"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/pkg/events/fake"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+func TestNewZoneEntitlements(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		zone     zones.Zone
+		expected zoneEntitlements
+	}{
+		{
+			name:     "free full zone",
+			zone:     zones.Zone{Name: "foo.com", Type: zones.TypeFull, Plan: zones.ZonePlan{LegacyID: "free"}},
+			expected: zoneEntitlements{zoneName: "foo.com", plan: "free", proxied: true, customHostnames: true},
+		},
+		{
+			name:     "paid zone without type",
+			zone:     zones.Zone{Name: "bar.com", Plan: zones.ZonePlan{LegacyID: "business", IsSubscribed: true}},
+			expected: zoneEntitlements{zoneName: "bar.com", plan: "business", proxied: true, customHostnames: true},
+		},
+		{
+			name:     "secondary zone without plan",
+			zone:     zones.Zone{Name: "baz.com", Type: zones.TypeSecondary},
+			expected: zoneEntitlements{zoneName: "baz.com", plan: "unknown", customHostnames: true},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newZoneEntitlements(tt.zone))
+		})
+	}
+}
+
+func TestEnforceZoneEntitlements(t *testing.T) {
+	ref := events.NewObjectReferenceFromParts("DNSEndpoint", "externaldns.k8s.io/v1alpha1", "default", "saas", "", "crd")
+	newChange := func(action changeAction, hostnames []string, prev ...string) *cloudFlareChange {
+		chs := map[string]customHostname{}
+		for _, h := range hostnames {
+			chs[h] = customHostname{hostname: h, customOriginServer: "origin.foo.com"}
+		}
+		return &cloudFlareChange{
+			Action:              action,
+			ResourceRecord:      dns.RecordResponse{Name: "origin.foo.com", Type: endpoint.RecordTypeA, Content: "1.2.3.4", Proxied: true},
+			CustomHostnames:     chs,
+			CustomHostnamesPrev: prev,
+			RefObjects:          []*events.ObjectReference{ref},
+		}
+	}
+
+	t.Run("entitled zone is left alone", func(t *testing.T) {
+		emitter := fake.NewFakeEventEmitter()
+		change := newChange(cloudFlareCreate, []string{"a.fancybar.com"})
+		p := &CloudFlareProvider{}
+		p.enforceZoneEntitlements(events.ContextWithEmitter(t.Context(), emitter), "001",
+			zoneEntitlements{zoneName: "foo.com", plan: "enterprise", proxied: true, customHostnames: true}, []*cloudFlareChange{change})
+
+		assert.True(t, change.ResourceRecord.Proxied)
+		assert.Contains(t, change.CustomHostnames, "a.fancybar.com")
+		emitter.AssertNotCalled(t, "Add")
+	})
+
+	t.Run("unsupported features are stripped and reported", func(t *testing.T) {
+		emitter := fake.NewFakeEventEmitter()
+		hook := logtest.LogsUnderTestWithLogLevel(log.ErrorLevel, t)
+		create := newChange(cloudFlareCreate, []string{"a.fancybar.com"})
+		update := newChange(cloudFlareUpdate, []string{"b.fancybar.com", "c.fancybar.com"}, "b.fancybar.com")
+		update.ResourceRecord.Proxied = false
+		deletion := newChange(cloudFlareDelete, []string{"d.fancybar.com"})
+		p := &CloudFlareProvider{}
+		p.enforceZoneEntitlements(events.ContextWithEmitter(t.Context(), emitter), "001",
+			zoneEntitlements{zoneName: "foo.com", plan: "free"}, []*cloudFlareChange{create, update, deletion})
+
+		assert.False(t, create.ResourceRecord.Proxied)
+		assert.Empty(t, create.CustomHostnames)
+		// the custom hostname the record already had is kept
+		assert.Equal(t, []string{"b.fancybar.com"}, slices.Collect(maps.Keys(update.CustomHostnames)))
+		assert.True(t, deletion.ResourceRecord.Proxied)
+		assert.Contains(t, deletion.CustomHostnames, "d.fancybar.com")
+
+		logtest.TestHelperLogContains(`zone "foo.com" does not support proxied records`, hook, t)
+		logtest.TestHelperLogContains(`zone "foo.com" on the free plan has no access to Cloudflare for SaaS, not creating the custom hostnames ["c.fancybar.com"]`, hook, t)
+		emitter.AssertNumberOfCalls(t, "Add", 3)
+		for _, call := range emitter.Calls {
+			event, ok := call.Arguments.Get(0).(events.Event)
+			require.True(t, ok)
+			assert.Equal(t, events.RecordError, event.Reason())
+		}
+	})
+}
+
+func TestCloudflareCustomHostnameWithoutSaaS(t *testing.T) {
+	client := NewMockCloudFlareClient()
+	client.saaslessZones = map[string]bool{"002": true}
+	p := &CloudFlareProvider{
+		Client:                client,
+		CustomHostnamesConfig: CustomHostnamesConfig{Enabled: true},
+	}
+	emitter := fake.NewFakeEventEmitter()
+	ctx := events.ContextWithEmitter(t.Context(), emitter)
+
+	ep := endpoint.NewEndpoint("origin.foo.com", endpoint.RecordTypeA, "1.2.3.4").
+		WithProviderSpecific("external-dns.kubernetes.io/cloudflare-custom-hostname", "a.fancybar.com").
+		WithRefObject(events.NewObjectReferenceFromParts("DNSEndpoint", "externaldns.k8s.io/v1alpha1", "default", "saas", "", "crd"))
+
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{ep}}))
+
+	assert.Empty(t, client.customHostnames["002"], "no custom hostname should be created on a zone without Cloudflare for SaaS")
+	assert.Len(t, client.Records["002"], 1, "the record should still be created")
+	emitter.AssertNumberOfCalls(t, "Add", 1)
+	event, ok := emitter.Calls[0].Arguments.Get(0).(events.Event)
+	require.True(t, ok)
+	assert.Equal(t, events.RecordError, event.Reason())
+	assert.Contains(t, event.Message(), "has no access to Cloudflare for SaaS")
+
+	records, err := p.Records(ctx)
+	require.NoError(t, err, "the zones without Cloudflare for SaaS are listed without custom hostnames")
+	assert.Len(t, records, 1)
+}
+
+func TestCloudflareCustomHostnameOnFreePlan(t *testing.T) {
+	client := NewMockCloudFlareClient()
+	p := &CloudFlareProvider{
+		Client:                client,
+		CustomHostnamesConfig: CustomHostnamesConfig{Enabled: true},
+	}
+	ep := endpoint.NewEndpoint("origin.foo.com", endpoint.RecordTypeA, "1.2.3.4").
+		WithProviderSpecific("external-dns.kubernetes.io/cloudflare-custom-hostname", "a.fancybar.com")
+
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Create: []*endpoint.Endpoint{ep}}))
+	assert.Len(t, client.customHostnames["002"], 1, "the free plan gives access to Cloudflare for SaaS")
+}
+
+func TestCloudflareAdjustEndpointsUnproxiesSecondaryZones(t *testing.T) {
+	hook := logtest.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+	p := &CloudFlareProvider{
+		zoneNames: provider.ZoneIDName{"001": "bar.com", "002": "secondary.bar.com"},
+		entitlements: map[string]zoneEntitlements{
+			"001": {zoneName: "bar.com", proxied: true},
+			"002": {zoneName: "secondary.bar.com"},
+		},
+	}
+	proxied := func(name string) *endpoint.Endpoint {
+		return endpoint.NewEndpointWithTTL(name, endpoint.RecordTypeA, 300, "1.2.3.4").
+			WithProviderSpecific("external-dns.kubernetes.io/cloudflare-proxied", "true")
+	}
+
+	adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{proxied("www.bar.com"), proxied("www.secondary.bar.com")})
+	require.NoError(t, err)
+	require.Len(t, adjusted, 2)
+
+	value, _ := adjusted[0].GetProviderSpecificProperty("external-dns.kubernetes.io/cloudflare-proxied")
+	assert.Equal(t, "true", value)
+	value, _ = adjusted[1].GetProviderSpecificProperty("external-dns.kubernetes.io/cloudflare-proxied")
+	assert.Equal(t, "false", value, "the records of a secondary zone are unproxied, so that the plan converges")
+	assert.Equal(t, endpoint.TTL(300), adjusted[1].RecordTTL)
+	logtest.TestHelperLogContains(`The zone of "www.secondary.bar.com" does not support proxied records`, hook, t)
+}
+
+func TestWarnUnsupportedProxied(t *testing.T) {
+	hook := logtest.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+
+	warnUnsupportedProxied(endpoint.NewEndpoint("txt.foo.com", endpoint.RecordTypeTXT, "text").
+		WithProviderSpecific("external-dns.kubernetes.io/cloudflare-proxied", "false"))
+	warnUnsupportedProxied(endpoint.NewEndpoint("a.foo.com", endpoint.RecordTypeA, "1.2.3.4").
+		WithProviderSpecific("external-dns.kubernetes.io/cloudflare-proxied", "true"))
+	assert.Empty(t, hook.AllEntries())
+
+	warnUnsupportedProxied(endpoint.NewEndpoint("mx.foo.com", endpoint.RecordTypeMX, "10 mail.foo.com").
+		WithProviderSpecific("external-dns.kubernetes.io/cloudflare-proxied", "true"))
+	logtest.TestHelperLogContains(`Cloudflare does not proxy MX records, creating "mx.foo.com" unproxied`, hook, t)
+}
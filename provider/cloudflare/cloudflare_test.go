@@ -17,6 +17,7 @@ limitations under the License.
 package cloudflare
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
@@ -108,7 +109,9 @@ type mockCloudFlareClient struct {
 	getZoneError         error // For v4 GetZone
 	dnsRecordsError      error
 	customHostnames      map[string][]customHostname
+	saaslessZones        map[string]bool // zones the custom hostnames API refuses
 	regionalHostnames    map[string][]regionalHostname
+	fallbackOrigins      map[string]string
 	dnsRecordsListParams dns.RecordListParams
 }
 
@@ -144,14 +147,16 @@ func NewMockCloudFlareClientWithRecords(records map[string][]dns.RecordResponse)
 func (m *mockCloudFlareClient) CreateDNSRecord(_ context.Context, params dns.RecordNewParams) (*dns.RecordResponse, error) {
 	body := params.Body.(dns.RecordNewParamsBody)
 
+	content, data := mockRecordContent(body.Content.Value, body.Data.Value)
 	record := dns.RecordResponse{
-		ID:       generateDNSRecordID(body.Type.String(), body.Name.Value, body.Content.Value),
+		ID:       generateDNSRecordID(body.Type.String(), body.Name.Value, content),
 		Name:     body.Name.Value,
 		TTL:      dns.TTL(body.TTL.Value),
 		Proxied:  body.Proxied.Value,
 		Type:     dns.RecordResponseType(body.Type.String()),
-		Content:  body.Content.Value,
+		Content:  content,
 		Priority: body.Priority.Value,
+		Data:     data,
 	}
 
 	m.Actions = append(m.Actions, MockAction{
@@ -170,6 +175,18 @@ func (m *mockCloudFlareClient) CreateDNSRecord(_ context.Context, params dns.Rec
 	return &record, nil
 }
 
+// mockRecordContent returns the content and data the API returns for a record
+// submitted with the given content or data, the data of CAA records being
+// formatted as their content.
+func mockRecordContent(content string, data any) (string, any) {
+	caa, ok := data.(dns.CAARecordDataParam)
+	if !ok {
+		return content, nil
+	}
+	return endpoint.NewCAATarget(uint8(caa.Flags.Value), caa.Tag.Value, caa.Value.Value).String(),
+		dns.CAARecordData{Flags: caa.Flags.Value, Tag: caa.Tag.Value, Value: caa.Value.Value}
+}
+
 func (m *mockCloudFlareClient) ListDNSRecords(ctx context.Context, params dns.RecordListParams) autoPager[dns.RecordResponse] {
 	m.dnsRecordsListParams = params
 	if m.dnsRecordsError != nil {
@@ -193,14 +210,16 @@ func (m *mockCloudFlareClient) UpdateDNSRecord(_ context.Context, recordID strin
 	zoneID := params.ZoneID.String()
 	body := params.Body.(dns.RecordUpdateParamsBody)
 
+	content, data := mockRecordContent(body.Content.Value, body.Data.Value)
 	record := dns.RecordResponse{
 		ID:       recordID,
 		Name:     body.Name.Value,
 		TTL:      dns.TTL(body.TTL.Value),
 		Proxied:  body.Proxied.Value,
 		Type:     dns.RecordResponseType(body.Type.String()),
-		Content:  body.Content.Value,
+		Content:  content,
 		Priority: body.Priority.Value,
+		Data:     data,
 	}
 
 	m.Actions = append(m.Actions, MockAction{
@@ -968,6 +987,7 @@ func TestCloudflareProvider(t *testing.T) {
 			}
 
 			_, err = newProvider(
+				t.Context(),
 				endpoint.NewDomainFilter([]string{"bar.com"}),
 				provider.NewZoneIDFilter([]string{""}),
 				false,
@@ -975,6 +995,7 @@ func TestCloudflareProvider(t *testing.T) {
 				RegionalServicesConfig{Enabled: false},
 				CustomHostnamesConfig{Enabled: false},
 				DNSRecordsConfig{PerPage: 5000, Comment: ""},
+				"",
 			)
 			if err != nil && !tc.ShouldFail {
 				t.Errorf("should not fail, %s", err)
@@ -1440,6 +1461,68 @@ func TestGroupByNameAndTypeWithCustomHostnames_MX(t *testing.T) {
 	assert.Equal(t, endpoint.TTL(3600), mxEndpoint.RecordTTL)
 }
 
+func TestCloudflareCAARecord(t *testing.T) {
+	client := NewMockCloudFlareClient()
+	p := &CloudFlareProvider{Client: client}
+
+	caa := endpoint.NewEndpoint("bar.com", endpoint.RecordTypeCAA, "0 ISSUE letsencrypt.org", `0 iodef "mailto:security@bar.com"`)
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Create: []*endpoint.Endpoint{caa}}))
+
+	var created []dns.RecordResponse
+	for _, action := range client.Actions {
+		if action.Name == "Create" {
+			created = append(created, action.RecordData)
+		}
+	}
+	require.Len(t, created, 2)
+	for _, record := range created {
+		assert.False(t, record.Proxied, "CAA records can't be proxied")
+		assert.IsType(t, dns.CAARecordData{}, record.Data, "CAA records are submitted with their data")
+	}
+
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	var got *endpoint.Endpoint
+	for _, ep := range records {
+		if ep.RecordType == endpoint.RecordTypeCAA {
+			got = ep
+		}
+	}
+	require.NotNil(t, got)
+	assert.Equal(t, "bar.com", got.DNSName)
+	assert.ElementsMatch(t, endpoint.Targets{`0 issue "letsencrypt.org"`, `0 iodef "mailto:security@bar.com"`}, got.Targets)
+
+	// an unchanged CAA record is found by its normalized content
+	client.Actions = nil
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{got},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("bar.com", endpoint.RecordTypeCAA, 300, `0 issue "letsencrypt.org"`, `0 iodef "mailto:security@bar.com"`)},
+	}))
+	require.NotEmpty(t, client.Actions)
+	for _, action := range client.Actions {
+		assert.Equal(t, "Update", action.Name)
+	}
+}
+
+func TestCloudflareCAARecordParams(t *testing.T) {
+	p := &CloudFlareProvider{}
+	change, err := p.newCloudFlareChange(cloudFlareCreate, endpoint.NewEndpoint("bar.com", endpoint.RecordTypeCAA), `128 issuewild ";"`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `128 issuewild ";"`, change.ResourceRecord.Content)
+
+	body, ok := getCreateDNSRecordParam("001", change).Body.(dns.RecordNewParamsBody)
+	require.True(t, ok)
+	assert.False(t, body.Content.Present, "the content of a CAA record is read-only")
+	assert.Equal(t, dns.CAARecordDataParam{Flags: cloudflare.F(128.0), Tag: cloudflare.F("issuewild"), Value: cloudflare.F(";")}, body.Data.Value)
+
+	post := buildBatchPostParam(change.ResourceRecord)
+	assert.False(t, post.Content.Present)
+	assert.Equal(t, body.Data.Value, post.Data.Value)
+
+	_, err = p.newCloudFlareChange(cloudFlareCreate, endpoint.NewEndpoint("bar.com", endpoint.RecordTypeCAA), "letsencrypt.org", nil)
+	assert.ErrorContains(t, err, "failed to parse CAA record target")
+}
+
 func TestProviderPropertiesIdempotency(t *testing.T) {
 	t.Parallel()
 
@@ -1752,7 +1835,8 @@ func TestCustomTTLWithEnabledProxyNotChanged(t *testing.T) {
 		},
 	}
 
-	provider.AdjustEndpoints(endpoints)
+	endpoints, err = provider.AdjustEndpoints(endpoints)
+	require.NoError(t, err)
 
 	domainFilter := endpoint.NewDomainFilter([]string{"bar.com"})
 	plan := &plan.Plan{
@@ -1776,6 +1860,7 @@ func TestCloudFlareProvider_Region(t *testing.T) {
 		cfAPIEmailEnvKey: "test@test.com",
 	})
 	provider, err := newProvider(
+		t.Context(),
 		endpoint.NewDomainFilter([]string{"example.com"}),
 		provider.ZoneIDFilter{},
 		true,
@@ -1783,11 +1868,65 @@ func TestCloudFlareProvider_Region(t *testing.T) {
 		RegionalServicesConfig{Enabled: false, RegionKey: "us"},
 		CustomHostnamesConfig{Enabled: false},
 		DNSRecordsConfig{PerPage: 50, Comment: ""},
+		"",
 	)
 	assert.NoError(t, err, "should not fail to create provider")
 	assert.True(t, provider.RegionalServicesConfig.Enabled, "expect regional services to be enabled")
 	assert.Equal(t, "us", provider.RegionalServicesConfig.RegionKey, "expected region key to be 'us'")
 }
+func TestCloudFlareProviderEndpoint(t *testing.T) {
+	testutils.TestHelperEnvSetter(t, map[string]string{
+		cfAPITokenEnvKey: "abc123def",
+	})
+	var requested string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"result":[],"result_info":{"count":0,"total_count":0,"page":1,"per_page":20},"success":true,"errors":[],"messages":[]}`))
+	}))
+	defer ts.Close()
+
+	p, err := newProvider(
+		t.Context(),
+		endpoint.NewDomainFilter([]string{"example.com"}),
+		provider.ZoneIDFilter{},
+		false,
+		false,
+		RegionalServicesConfig{},
+		CustomHostnamesConfig{},
+		DNSRecordsConfig{PerPage: 50},
+		ts.URL+"/sandbox/",
+	)
+	require.NoError(t, err)
+
+	_, err = p.Zones(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "/sandbox/zones", requested)
+}
+
+func TestCloudFlareDesiredHashComment(t *testing.T) {
+	ep := endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 300, "192.0.2.1")
+	p := &CloudFlareProvider{
+		Client:           NewMockCloudFlareClient(),
+		DNSRecordsConfig: DNSRecordsConfig{Comment: "managed by platform", DesiredHash: true},
+	}
+
+	change, err := p.newCloudFlareChange(cloudFlareCreate, ep, ep.Targets[0], nil)
+	require.NoError(t, err)
+	assert.Equal(t, "managed by platform external-dns-hash:"+ep.DesiredHash(), change.ResourceRecord.Comment)
+	assert.Equal(t, "managed by platform", trimDesiredHashComment(change.ResourceRecord.Comment))
+
+	p.DNSRecordsConfig.Comment = ""
+	change, err = p.newCloudFlareChange(cloudFlareCreate, ep, ep.Targets[0], nil)
+	require.NoError(t, err)
+	assert.Equal(t, "external-dns-hash:"+ep.DesiredHash(), change.ResourceRecord.Comment)
+	assert.Empty(t, trimDesiredHashComment(change.ResourceRecord.Comment))
+
+	long := strings.Repeat("a", freeZoneMaxCommentLength)
+	assert.Len(t, withDesiredHashComment(long, ep.DesiredHash(), freeZoneMaxCommentLength), freeZoneMaxCommentLength)
+	assert.Equal(t, "not-external-dns-hash:1234", trimDesiredHashComment("not-external-dns-hash:1234"))
+}
+
 func TestCloudFlareProvider_newCloudFlareChange(t *testing.T) {
 	t.Parallel()
 
@@ -2661,6 +2800,16 @@ func TestConvertCloudflareError(t *testing.T) {
 			expectSoftError: true,
 			description:     "String error containing rate limit message should be converted to soft error regardless of context",
 		},
+		{
+			name: "Rate limit reported by headers",
+			inputError: func() error {
+				err := newCloudflareError(403)
+				err.Response.Header = http.Header{"Retry-After": []string{"30"}}
+				return err
+			}(),
+			expectSoftError: true,
+			description:     "Client error with a Retry-After header should be converted to soft error",
+		},
 		{
 			name:            "Client error 400",
 			inputError:      newCloudflareError(400),
@@ -3146,6 +3295,90 @@ func TestAdjustEndpoints_TagsAnnotation(t *testing.T) {
 	assert.Equal(t, "alpha,beta,gamma", val)
 }
 
+func TestAdjustEndpoints_Precedence(t *testing.T) {
+	// The properties set on an endpoint, from the resource annotations or the
+	// namespace defaults, take precedence over the provider flags.
+	tests := []struct {
+		name           string
+		proxiedDefault bool
+		regional       RegionalServicesConfig
+		specific       endpoint.ProviderSpecific
+		recordType     string
+		wantProxied    string
+		wantRegionKey  string
+		wantTTL        endpoint.TTL
+	}{
+		{name: "flag proxied", proxiedDefault: true, wantProxied: "true"},
+		{name: "flag not proxied", wantProxied: "false", wantTTL: 300},
+		{
+			name:           "annotation disables flag proxied",
+			proxiedDefault: true,
+			specific:       endpoint.ProviderSpecific{{Name: annotations.CloudflareProxiedProperty, Value: "false"}},
+			wantProxied:    "false",
+			wantTTL:        300,
+		},
+		{
+			name:        "annotation enables proxied",
+			specific:    endpoint.ProviderSpecific{{Name: annotations.CloudflareProxiedProperty, Value: "true"}},
+			wantProxied: "true",
+		},
+		{
+			name:        "proxied not supported by record type",
+			specific:    endpoint.ProviderSpecific{{Name: annotations.CloudflareProxiedProperty, Value: "true"}},
+			recordType:  endpoint.RecordTypeTXT,
+			wantProxied: "false",
+			wantTTL:     300,
+		},
+		{
+			name:          "flag region key",
+			regional:      RegionalServicesConfig{Enabled: true, RegionKey: "earth"},
+			wantProxied:   "false",
+			wantRegionKey: "earth",
+			wantTTL:       300,
+		},
+		{
+			name:          "annotation overrides flag region key",
+			regional:      RegionalServicesConfig{Enabled: true, RegionKey: "earth"},
+			specific:      endpoint.ProviderSpecific{{Name: annotations.CloudflareRegionKeyProperty, Value: "eu"}},
+			wantProxied:   "false",
+			wantRegionKey: "eu",
+			wantTTL:       300,
+		},
+		{
+			name:          "per-record region key without default",
+			regional:      RegionalServicesConfig{Enabled: true},
+			specific:      endpoint.ProviderSpecific{{Name: annotations.CloudflareRegionKeyProperty, Value: "us"}},
+			wantProxied:   "false",
+			wantRegionKey: "us",
+			wantTTL:       300,
+		},
+		{
+			name:        "region key dropped without regional services",
+			specific:    endpoint.ProviderSpecific{{Name: annotations.CloudflareRegionKeyProperty, Value: "eu"}},
+			wantProxied: "false",
+			wantTTL:     300,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &CloudFlareProvider{proxiedByDefault: tt.proxiedDefault, RegionalServicesConfig: tt.regional}
+			ep := endpoint.NewEndpointWithTTL("test.bar.com", cmp.Or(tt.recordType, endpoint.RecordTypeA), 300, "1.2.3.4")
+			ep.ProviderSpecific = tt.specific
+
+			adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{ep})
+			require.NoError(t, err)
+			require.Len(t, adjusted, 1)
+
+			proxied, _ := adjusted[0].GetProviderSpecificProperty(annotations.CloudflareProxiedProperty)
+			assert.Equal(t, tt.wantProxied, proxied)
+			regionKey, _ := adjusted[0].GetProviderSpecificProperty(annotations.CloudflareRegionKeyProperty)
+			assert.Equal(t, tt.wantRegionKey, regionKey)
+			assert.Equal(t, tt.wantTTL, adjusted[0].RecordTTL)
+		})
+	}
+}
+
 func TestZoneServiceZoneIDByName(t *testing.T) {
 	// Build a minimal cloudflare API response page for /zones.
 	writeZonesPage := func(w http.ResponseWriter, zones []map[string]any) {
@@ -3225,3 +3458,20 @@ func TestZoneServiceZoneIDByName(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to list zones from CloudFlare API")
 	})
 }
+
+func TestCloudflareAdjustEndpointsContract(t *testing.T) {
+	p := &CloudFlareProvider{
+		proxiedByDefault:      true,
+		CustomHostnamesConfig: CustomHostnamesConfig{Enabled: true},
+		DNSRecordsConfig:      DNSRecordsConfig{Comment: "managed by external-dns"},
+	}
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("proxied.bar.com", endpoint.RecordTypeA, 300, "1.2.3.4"),
+		endpoint.NewEndpoint("not-proxied.bar.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(annotations.CloudflareProxiedKey, "false").
+			WithProviderSpecific(annotations.CloudflareTagsKey, "beta, alpha").
+			WithProviderSpecific(annotations.CloudflareCustomHostnameKey, "b.foo.com,a.foo.com"),
+	}
+
+	testutils.TestHelperAdjustEndpointsContract(t, p.AdjustEndpoints, endpoints)
+}
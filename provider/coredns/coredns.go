@@ -56,6 +56,13 @@ var (
 	skipLabels = []string{originalTextLabel, randomPrefixLabel, "resource", endpoint.OwnerLabelKey}
 )
 
+// PropertySpecs returns the provider-specific properties understood by the CoreDNS provider.
+func PropertySpecs() []endpoint.PropertySpec {
+	return []endpoint.PropertySpec{
+		{Name: providerSpecificGroup, Type: endpoint.PropertyTypeString},
+	}
+}
+
 // coreDNSClient is an interface to work with CoreDNS service records in etcd
 type coreDNSClient interface {
 	GetServices(ctx context.Context, prefix string) ([]*Service, error)
@@ -312,6 +319,11 @@ func findEp(slice []*endpoint.Endpoint, dnsName string) (*endpoint.Endpoint, boo
 	return nil, false
 }
 
+// SupportedRecordTypes returns the record types managed with the SkyDNS message format.
+func (p coreDNSProvider) SupportedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypePTR}
+}
+
 // Records returns all DNS records found in CoreDNS etcd backend. Depending on the record fields
 // it may be mapped to one or two records of type A, CNAME, TXT, A+TXT, CNAME+TXT
 func (p coreDNSProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
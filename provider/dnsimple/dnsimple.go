@@ -195,6 +195,11 @@ func (p *dnsimpleProvider) Zones(ctx context.Context) (map[string]dnsimple.Zone,
 	return zones, nil
 }
 
+// SupportedRecordTypes returns the record types managed with DNSimple.
+func (p *dnsimpleProvider) SupportedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT}
+}
+
 // Records returns a list of endpoints in a given zone
 func (p *dnsimpleProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.Zones(ctx)
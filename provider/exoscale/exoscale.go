@@ -302,6 +302,11 @@ func (ep *ExoscaleProvider) ApplyChanges(ctx context.Context, changes *plan.Chan
 	return nil
 }
 
+// SupportedRecordTypes returns the record types managed with Exoscale.
+func (ep *ExoscaleProvider) SupportedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT}
+}
+
 // Records returns the list of endpoints
 func (ep *ExoscaleProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := ep.getZones(ctx)
@@ -50,3 +50,15 @@ func (p *AliasNormalizingMiddleware) AdjustEndpoints(endpoints []*endpoint.Endpo
 	}
 	return eps, nil
 }
+
+// Reset drops the records cached by the wrapped provider.
+func (p *AliasNormalizingMiddleware) Reset() {
+	if r, ok := p.Provider.(provider.CacheResetter); ok {
+		r.Reset()
+	}
+}
+
+// Unwrap returns the wrapped provider.
+func (p *AliasNormalizingMiddleware) Unwrap() provider.Provider {
+	return p.Provider
+}
@@ -19,6 +19,7 @@ package factory
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -105,3 +106,30 @@ func TestAliasNormalizingMiddleware(t *testing.T) {
 		})
 	}
 }
+
+type countingProvider struct {
+	stubProvider
+	calls int
+}
+
+func (c *countingProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	c.calls++
+	return []*endpoint.Endpoint{}, nil
+}
+
+func TestAliasNormalizingMiddlewareReset(t *testing.T) {
+	inner := &countingProvider{}
+	p := newAliasNormalizingMiddleware(provider.NewCachedProvider(inner, time.Hour))
+
+	for range 2 {
+		_, err := p.Records(t.Context())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, inner.calls)
+
+	// the middleware must not hide the provider cache from a full reconcile
+	p.Reset()
+	_, err := p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.calls)
+}
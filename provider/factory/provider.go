@@ -73,6 +73,26 @@ func Select(
 	return newAliasNormalizingMiddleware(p), nil
 }
 
+// PropertyValidator returns a validator for the provider-specific properties
+// registered by the named provider. Providers without registered properties
+// get a validator that accepts everything.
+func PropertyValidator(selector string) *endpoint.PropertyValidator {
+	m := map[string]func() []endpoint.PropertySpec{
+		externaldns.ProviderAWS:          aws.PropertySpecs,
+		externaldns.ProviderAzure:        azure.PropertySpecs,
+		externaldns.ProviderAzureDNS:     azure.PropertySpecs,
+		externaldns.ProviderAzurePrivate: azure.PropertySpecs,
+		externaldns.ProviderCoreDNS:      coredns.PropertySpecs,
+		externaldns.ProviderSkyDNS:       coredns.PropertySpecs,
+		externaldns.ProviderScaleway:     scaleway.PropertySpecs,
+	}
+	specs, ok := m[selector]
+	if !ok {
+		return endpoint.NewPropertyValidator()
+	}
+	return endpoint.NewPropertyValidator(specs()...)
+}
+
 // providers looks up the constructor for the named provider.
 func providers(selector string) (ProviderConstructor, bool) {
 	m := map[string]ProviderConstructor{
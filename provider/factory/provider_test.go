@@ -28,6 +28,8 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
 )
 
 func TestSelectProvider(t *testing.T) {
@@ -171,3 +173,50 @@ func TestSelectProvider_Webhook(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, p)
 }
+
+func TestPropertyValidator(t *testing.T) {
+	awsValidator := PropertyValidator(externaldns.ProviderAWS)
+	require.True(t, awsValidator.IsEnabled())
+	require.NoError(t, awsValidator.Validate(endpoint.ProviderSpecificProperty{Name: "aws/weight", Value: "10"}))
+	require.Error(t, awsValidator.Validate(endpoint.ProviderSpecificProperty{Name: "aws/weight", Value: "heavy"}))
+
+	assert.False(t, PropertyValidator(externaldns.ProviderWebhook).IsEnabled())
+}
+
+func TestSelectProviderCacheReset(t *testing.T) {
+	cfg := &externaldns.Config{
+		Provider:          externaldns.ProviderInMemory,
+		InMemoryZones:     []string{"example.org"},
+		ProviderCacheTime: time.Hour,
+	}
+	p, err := Select(t.Context(), cfg, endpoint.NewDomainFilter(nil))
+	require.NoError(t, err)
+
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	// a record created out of band, behind the provider cache
+	inner := p
+	for {
+		u, ok := inner.(provider.Unwrapper)
+		if !ok {
+			break
+		}
+		inner = u.Unwrap()
+	}
+	require.NoError(t, inner.ApplyChanges(t.Context(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "10.0.0.1")},
+	}))
+	records, err = p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, records, "the records are served from the cache")
+
+	// a full reconcile drops the cache through the wrappers of the provider
+	resetter, ok := p.(provider.CacheResetter)
+	require.True(t, ok, "the selected provider must forward cache resets")
+	resetter.Reset()
+	records, err = p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
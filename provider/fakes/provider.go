@@ -43,3 +43,7 @@ func (m *MockProvider) AdjustEndpoints(eps []*endpoint.Endpoint) ([]*endpoint.En
 func (m *MockProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return &endpoint.DomainFilter{}
 }
+
+func (m *MockProvider) SupportedRecordTypes() []string {
+	return nil
+}
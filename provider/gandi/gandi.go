@@ -111,6 +111,11 @@ func (p *GandiProvider) Zones() ([]string, error) {
 	return zones, nil
 }
 
+// SupportedRecordTypes returns the record types managed with Gandi.
+func (p *GandiProvider) SupportedRecordTypes() []string {
+	return provider.DefaultSupportedRecordTypes()
+}
+
 func (p *GandiProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
 	liveDNSZones, err := p.Zones()
 	if err != nil {
@@ -32,6 +32,7 @@ import (
 	"golang.org/x/time/rate"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/provider"
 )
 
 const (
@@ -309,7 +310,7 @@ func (c *Client) CallAPI(method, path string, reqBody, resType any) error {
 // the required Content-Type header.
 //
 // If everything went fine, unmarshall response into resType and return nil
-// otherwise, return the error
+// otherwise, return the error, as a SoftError when the request failed transiently
 func (c *Client) CallAPIWithContext(ctx context.Context, method, path string, reqBody, resType any) error {
 	req, err := c.NewRequest(method, path, reqBody)
 	if err != nil {
@@ -318,9 +319,9 @@ func (c *Client) CallAPIWithContext(ctx context.Context, method, path string, re
 	req = req.WithContext(ctx)
 	response, err := c.Do(req)
 	if err != nil {
-		return err
+		return provider.ClassifyError(err)
 	}
-	return c.UnmarshalResponse(response, resType)
+	return provider.ClassifyHTTPError(c.UnmarshalResponse(response, resType), response.StatusCode, response.Header)
 }
 
 // UnmarshalResponse checks the response and unmarshals it into the response
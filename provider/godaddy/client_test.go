@@ -24,7 +24,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/time/rate"
+
+	"sigs.k8s.io/external-dns/provider"
 )
 
 // Tests that
@@ -70,3 +73,39 @@ func TestClient_DoWhenQuotaExceeded(t *testing.T) {
 		assert.Equal("rate limit exceeded", apiErr.Message)
 	}
 }
+
+func TestClient_CallAPIClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		soft   bool
+	}{
+		{name: "quota exceeded", status: http.StatusTooManyRequests, body: `{"code": "QUOTA_EXCEEDED", "message": "rate limit exceeded"}`, soft: true},
+		{name: "server error", status: http.StatusBadGateway, body: `<html>bad gateway</html>`, soft: true},
+		{name: "invalid request", status: http.StatusUnprocessableEntity, body: `{"code": "INVALID_BODY", "message": "invalid record"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer mockServer.Close()
+
+			client := Client{
+				APIEndPoint: mockServer.URL,
+				Client:      &http.Client{},
+				Ratelimiter: rate.NewLimiter(rate.Every(time.Second), 60),
+				Timeout:     DefaultTimeout,
+			}
+			err := client.Get("/v1/domains/example.net/records", nil)
+			require.Error(t, err)
+			if tt.soft {
+				assert.ErrorIs(t, err, provider.SoftError)
+			} else {
+				assert.NotErrorIs(t, err, provider.SoftError)
+			}
+		})
+	}
+}
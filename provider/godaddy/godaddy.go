@@ -315,6 +315,11 @@ func (p *GDProvider) groupByNameAndType(zoneRecords []gdRecords) []*endpoint.End
 	return endpoints
 }
 
+// SupportedRecordTypes returns the record types managed with GoDaddy.
+func (p *GDProvider) SupportedRecordTypes() []string {
+	return provider.DefaultSupportedRecordTypes()
+}
+
 // Records returns the list of records in all relevant zones.
 func (p *GDProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	_, records, err := p.zonesRecords(ctx, false)
@@ -19,7 +19,10 @@ package google
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/compute/metadata"
@@ -33,14 +36,23 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/txt"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
 
 const (
 	defaultTTL = 300
+
+	zoneKindManaged    = "managed"
+	zoneKindForwarding = "forwarding"
+	zoneKindPeering    = "peering"
 )
 
+// defaultZoneKinds leaves peering zones out, as they only point at zones
+// owned by another network and cannot hold records of their own.
+var defaultZoneKinds = []string{zoneKindManaged, zoneKindForwarding}
+
 type managedZonesCreateCallInterface interface {
 	Do(opts ...googleapi.CallOption) (*dns.ManagedZone, error)
 }
@@ -101,8 +113,8 @@ func (c changesService) Create(project string, managedZone string, change *dns.C
 // GoogleProvider is an implementation of Provider for Google CloudDNS.
 type GoogleProvider struct {
 	provider.BaseProvider
-	// The Google project to work in
-	project string
+	// The Google projects to work in
+	projects []string
 	// Enabled dry-run will print any modifying actions rather than execute them.
 	dryRun bool
 	// Max batch size to submit to Google Cloud DNS per transaction.
@@ -113,6 +125,8 @@ type GoogleProvider struct {
 	domainFilter *endpoint.DomainFilter
 	// filter for zones based on visibility
 	zoneTypeFilter provider.ZoneTypeFilter
+	// only consider zones of these kinds (managed, forwarding, peering)
+	zoneKinds []string
 	// only consider hosted zones ending with this zone id
 	zoneIDFilter provider.ZoneIDFilter
 	// A client for managing resource record sets
@@ -127,11 +141,11 @@ type GoogleProvider struct {
 
 // New creates a Google Cloud DNS provider from the given configuration.
 func New(ctx context.Context, cfg *externaldns.Config, domainFilter *endpoint.DomainFilter) (provider.Provider, error) {
-	return newProvider(ctx, cfg.GoogleProject, domainFilter, provider.NewZoneIDFilter(cfg.ZoneIDFilter), cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.DryRun)
+	return newProvider(ctx, cfg.GoogleProjects, domainFilter, provider.NewZoneIDFilter(cfg.ZoneIDFilter), cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.GoogleZoneKinds, cfg.DryRun)
 }
 
 // newProvider initializes a new Google CloudDNS based Provider.
-func newProvider(ctx context.Context, project string, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, batchChangeSize int, batchChangeInterval time.Duration, zoneVisibility string, dryRun bool) (*GoogleProvider, error) {
+func newProvider(ctx context.Context, projects []string, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, batchChangeSize int, batchChangeInterval time.Duration, zoneVisibility string, zoneKinds []string, dryRun bool) (*GoogleProvider, error) {
 	gcloud, err := google.DefaultClient(ctx, dns.NdevClouddnsReadwriteScope)
 	if err != nil {
 		return nil, err
@@ -144,24 +158,25 @@ func newProvider(ctx context.Context, project string, domainFilter *endpoint.Dom
 		return nil, err
 	}
 
-	if project == "" {
+	if len(projects) == 0 {
 		mProject, mErr := metadata.ProjectIDWithContext(ctx)
 		if mErr != nil {
 			return nil, fmt.Errorf("failed to auto-detect the project id: %w", mErr)
 		}
 		log.Infof("Google project auto-detected: %s", mProject)
-		project = mProject
+		projects = []string{mProject}
 	}
 
 	zoneTypeFilter := provider.NewZoneTypeFilter(zoneVisibility)
 
 	return &GoogleProvider{
-		project:                  project,
+		projects:                 projects,
 		dryRun:                   dryRun,
 		batchChangeSize:          batchChangeSize,
 		batchChangeInterval:      batchChangeInterval,
 		domainFilter:             domainFilter,
 		zoneTypeFilter:           zoneTypeFilter,
+		zoneKinds:                zoneKinds,
 		zoneIDFilter:             zoneIDFilter,
 		resourceRecordSetsClient: resourceRecordSetsService{dnsClient.ResourceRecordSets},
 		managedZonesClient:       managedZonesService{dnsClient.ManagedZones},
@@ -170,43 +185,93 @@ func newProvider(ctx context.Context, project string, domainFilter *endpoint.Dom
 	}, nil
 }
 
-// Zones returns the list of hosted zones.
+// Zones returns the list of hosted zones across all projects, keyed by
+// project and zone name (see zoneKey).
 func (p *GoogleProvider) Zones(ctx context.Context) (map[string]*dns.ManagedZone, error) {
 	zones := make(map[string]*dns.ManagedZone)
 
-	f := func(resp *dns.ManagedZonesListResponse) error {
-		for _, zone := range resp.ManagedZones {
-			if zone.PeeringConfig == nil {
+	log.Debugf("Matching zones against domain filters: %v", p.domainFilter)
+	for _, project := range p.projects {
+		f := func(resp *dns.ManagedZonesListResponse) error {
+			for _, zone := range resp.ManagedZones {
+				kind := zoneKind(zone)
+				if !p.matchZoneKind(kind) {
+					log.Debugf("Filtered %s zone %s (zone: %s) (project: %s) (visibility: %s)", kind, zone.DnsName, zone.Name, project, zone.Visibility)
+					continue
+				}
 				if p.domainFilter.Match(zone.DnsName) && p.zoneTypeFilter.Match(zone.Visibility) && (p.zoneIDFilter.Match(fmt.Sprintf("%v", zone.Id)) || p.zoneIDFilter.Match(fmt.Sprintf("%v", zone.Name))) {
-					zones[zone.Name] = zone
-					log.Debugf("Matched %s (zone: %s) (visibility: %s)", zone.DnsName, zone.Name, zone.Visibility)
+					zones[zoneKey(project, zone.Name)] = zone
+					log.Debugf("Matched %s (zone: %s) (project: %s) (visibility: %s)", zone.DnsName, zone.Name, project, zone.Visibility)
 				} else {
-					log.Debugf("Filtered %s (zone: %s) (visibility: %s)", zone.DnsName, zone.Name, zone.Visibility)
+					log.Debugf("Filtered %s (zone: %s) (project: %s) (visibility: %s)", zone.DnsName, zone.Name, project, zone.Visibility)
 				}
-			} else {
-				log.Debugf("Filtered peering zone %s (zone: %s) (visibility: %s)", zone.DnsName, zone.Name, zone.Visibility)
 			}
-		}
 
-		return nil
-	}
+			return nil
+		}
 
-	log.Debugf("Matching zones against domain filters: %v", p.domainFilter)
-	if err := p.managedZonesClient.List(p.project).Pages(ctx, f); err != nil {
-		return nil, provider.NewSoftErrorf("failed to list zones: %w", err)
+		if err := p.managedZonesClient.List(project).Pages(ctx, f); err != nil {
+			return nil, provider.NewSoftErrorf("failed to list zones in project %s: %w", project, err)
+		}
 	}
 
 	if len(zones) == 0 {
-		log.Warnf("No zones in the project, %s, match domain filters: %v", p.project, p.domainFilter)
+		log.Warnf("No zones in the projects, %v, match domain filters: %v", p.projects, p.domainFilter)
 	}
 
-	for _, zone := range zones {
-		log.Debugf("Considering zone: %s (domain: %s)", zone.Name, zone.DnsName)
+	for key, zone := range zones {
+		log.Debugf("Considering zone: %s (domain: %s)", key, zone.DnsName)
 	}
 
 	return zones, nil
 }
 
+// matchZoneKind reports whether zones of the given kind are managed.
+func (p *GoogleProvider) matchZoneKind(kind string) bool {
+	kinds := p.zoneKinds
+	if len(kinds) == 0 {
+		kinds = defaultZoneKinds
+	}
+	return slices.Contains(kinds, kind)
+}
+
+// zoneKind classifies a zone as a peering, forwarding or regular managed zone.
+func zoneKind(zone *dns.ManagedZone) string {
+	switch {
+	case zone.PeeringConfig != nil:
+		return zoneKindPeering
+	case zone.ForwardingConfig != nil:
+		return zoneKindForwarding
+	default:
+		return zoneKindManaged
+	}
+}
+
+// zoneKey identifies a zone across projects, as zone names are only unique
+// within a single project.
+func zoneKey(project, zone string) string {
+	return project + "/" + zone
+}
+
+// splitZoneKey returns the project and zone name of a key built by zoneKey.
+func splitZoneKey(key string) (string, string) {
+	project, zone, _ := strings.Cut(key, "/")
+	return project, zone
+}
+
+// SupportedRecordTypes returns the record types managed with Google Cloud DNS.
+func (p *GoogleProvider) SupportedRecordTypes() []string {
+	return provider.DefaultSupportedRecordTypes(endpoint.RecordTypeMX)
+}
+
+// AdjustEndpoints converts the TXT targets to the form Cloud DNS returns them in.
+func (p *GoogleProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return provider.EndpointAdjuster(func(ep *endpoint.Endpoint) []*endpoint.Endpoint {
+		txt.NormalizeEndpoint(ep)
+		return []*endpoint.Endpoint{ep}
+	}).AdjustEndpoints(endpoints)
+}
+
 // Records returns the list of records in all relevant zones.
 func (p *GoogleProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.Zones(ctx)
@@ -221,14 +286,17 @@ func (p *GoogleProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 			if !p.SupportedRecordType(r.Type) {
 				continue
 			}
-			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(r.Name, r.Type, endpoint.TTL(r.Ttl), r.Rrdatas...))
+			ep := endpoint.NewEndpointWithTTL(r.Name, r.Type, endpoint.TTL(r.Ttl), r.Rrdatas...)
+			txt.NormalizeEndpoint(ep)
+			endpoints = append(endpoints, ep)
 		}
 
 		return nil
 	}
 
-	for _, z := range zones {
-		if err := p.resourceRecordSetsClient.List(p.project, z.Name).Pages(ctx, f); err != nil {
+	for key, z := range zones {
+		project, _ := splitZoneKey(key)
+		if err := p.resourceRecordSetsClient.List(project, z.Name).Pages(ctx, f); err != nil {
 			return nil, provider.NewSoftErrorf("failed to list records in zone %s: %v", z.Name, err)
 		}
 	}
@@ -288,9 +356,12 @@ func (p *GoogleProvider) submitChange(ctx context.Context, change *dns.Change) e
 	// separate into per-zone change sets to be passed to the API.
 	changes := separateChange(zones, change)
 
-	for zone, change := range changes {
-		for batch, c := range batchChange(change, p.batchChangeSize) {
-			log.Infof("Change zone: %v batch #%d", zone, batch)
+	// the interval is only waited between two submissions, not after the last one
+	submitted := false
+	for _, key := range slices.Sorted(maps.Keys(changes)) {
+		project, zone := splitZoneKey(key)
+		for batch, c := range batchChange(changes[key], p.batchChangeSize) {
+			log.Infof("Change zone: %v (project: %s) batch #%d", zone, project, batch)
 			for _, del := range c.Deletions {
 				log.Infof("Del records: %s %s %s %d", del.Name, del.Type, del.Rrdatas, del.Ttl)
 			}
@@ -302,18 +373,22 @@ func (p *GoogleProvider) submitChange(ctx context.Context, change *dns.Change) e
 				continue
 			}
 
-			if _, err := p.changesClient.Create(p.project, zone, c).Do(); err != nil {
+			if submitted {
+				time.Sleep(p.batchChangeInterval)
+			}
+			if _, err := p.changesClient.Create(project, zone, c).Do(); err != nil {
 				return provider.NewSoftErrorf("failed to create changes: %w", err)
 			}
-
-			time.Sleep(p.batchChangeInterval)
+			submitted = true
 		}
 	}
 
 	return nil
 }
 
-// batchChange separates a zone in multiple transaction.
+// batchChange separates a zone in multiple transaction. The deletions and additions
+// of a DNS name are kept in the same transaction, so that an update is applied
+// atomically; the name is compared case-insensitively as done by Cloud DNS.
 func batchChange(change *dns.Change, batchSize int) []*dns.Change {
 	var changes []*dns.Change
 
@@ -329,20 +404,22 @@ func batchChange(change *dns.Change, batchSize int) []*dns.Change {
 	changesByName := map[string]*dnsChange{}
 
 	for _, a := range change.Additions {
-		change, ok := changesByName[a.Name]
+		name := strings.ToLower(a.Name)
+		change, ok := changesByName[name]
 		if !ok {
 			change = &dnsChange{}
-			changesByName[a.Name] = change
+			changesByName[name] = change
 		}
 
 		change.additions = append(change.additions, a)
 	}
 
 	for _, a := range change.Deletions {
-		change, ok := changesByName[a.Name]
+		name := strings.ToLower(a.Name)
+		change, ok := changesByName[name]
 		if !ok {
 			change = &dnsChange{}
-			changesByName[a.Name] = change
+			changesByName[name] = change
 		}
 
 		change.deletions = append(change.deletions, a)
@@ -390,9 +467,9 @@ func batchChange(change *dns.Change, batchSize int) []*dns.Change {
 func separateChange(zones map[string]*dns.ManagedZone, change *dns.Change) map[string]*dns.Change {
 	changes := make(map[string]*dns.Change)
 	zoneNameIDMapper := provider.ZoneIDName{}
-	for _, z := range zones {
-		zoneNameIDMapper[z.Name] = z.DnsName
-		changes[z.Name] = &dns.Change{
+	for key, z := range zones {
+		zoneNameIDMapper[key] = z.DnsName
+		changes[key] = &dns.Change{
 			Additions: []*dns.ResourceRecordSet{},
 			Deletions: []*dns.ResourceRecordSet{},
 		}
@@ -452,6 +529,12 @@ func newRecord(ep *endpoint.Endpoint) *dns.ResourceRecordSet {
 		}
 	}
 
+	if ep.RecordType == endpoint.RecordTypeTXT {
+		for i, txtRecord := range ep.Targets {
+			targets[i] = txt.Normalize(txtRecord)
+		}
+	}
+
 	// no annotation results in a Ttl of 0, default to 300 for backwards-compatibility
 	var ttl int64 = defaultTTL
 	if ep.RecordTTL.IsConfigured() {
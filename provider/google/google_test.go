@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"slices"
 	"sort"
@@ -172,10 +173,6 @@ func (m *mockChangesClient) Create(project string, managedZone string, change *d
 	return &mockChangesCreateCall{project: project, managedZone: managedZone, change: change}
 }
 
-func zoneKey(project, zoneName string) string {
-	return project + "/" + zoneName
-}
-
 func recordKey(recordType, recordName string) string {
 	return recordType + "/" + recordName
 }
@@ -214,7 +211,7 @@ func TestGoogleZonesIDFilter(t *testing.T) {
 	require.NoError(t, err)
 
 	validateZones(t, zones, map[string]*dns.ManagedZone{
-		"internal-2": {Name: "internal-2", DnsName: "cluster.local.", Id: 10002, Visibility: "private"},
+		"zalando-external-dns-test/internal-2": {Name: "internal-2", DnsName: "cluster.local.", Id: 10002, Visibility: "private"},
 	})
 }
 
@@ -225,7 +222,7 @@ func TestGoogleZonesNameFilter(t *testing.T) {
 	require.NoError(t, err)
 
 	validateZones(t, zones, map[string]*dns.ManagedZone{
-		"internal-2": {Name: "internal-2", DnsName: "cluster.local.", Id: 10002, Visibility: "private"},
+		"zalando-external-dns-test/internal-2": {Name: "internal-2", DnsName: "cluster.local.", Id: 10002, Visibility: "private"},
 	})
 }
 
@@ -236,7 +233,7 @@ func TestGoogleZonesVisibilityFilterPublic(t *testing.T) {
 	require.NoError(t, err)
 
 	validateZones(t, zones, map[string]*dns.ManagedZone{
-		"split-horizon-1": {Name: "split-horizon-1", DnsName: "cluster.local.", Id: 10001, Visibility: "public"},
+		"zalando-external-dns-test/split-horizon-1": {Name: "split-horizon-1", DnsName: "cluster.local.", Id: 10001, Visibility: "public"},
 	})
 }
 
@@ -247,7 +244,7 @@ func TestGoogleZonesVisibilityFilterPrivate(t *testing.T) {
 	require.NoError(t, err)
 
 	validateZones(t, zones, map[string]*dns.ManagedZone{
-		"split-horizon-1": {Name: "split-horizon-1", DnsName: "cluster.local.", Id: 10001, Visibility: "public"},
+		"zalando-external-dns-test/split-horizon-1": {Name: "split-horizon-1", DnsName: "cluster.local.", Id: 10001, Visibility: "public"},
 	})
 }
 
@@ -258,10 +255,88 @@ func TestGoogleZonesVisibilityFilterPrivatePeering(t *testing.T) {
 	require.NoError(t, err)
 
 	validateZones(t, zones, map[string]*dns.ManagedZone{
-		"svc-local": {Name: "svc-local", DnsName: "svc.local.", Id: 1005, Visibility: "private"},
+		"zalando-external-dns-test/svc-local": {Name: "svc-local", DnsName: "svc.local.", Id: 1005, Visibility: "private"},
 	})
 }
 
+func TestGoogleZonesKindFilter(t *testing.T) {
+	project := "zone-kind-test"
+	for _, zone := range []*dns.ManagedZone{
+		{Name: "kind-managed", DnsName: "kind.local.", Visibility: "private"},
+		{Name: "kind-forwarding", DnsName: "kind.local.", Visibility: "private", ForwardingConfig: &dns.ManagedZoneForwardingConfig{}},
+		{Name: "kind-peering", DnsName: "kind.local.", Visibility: "private", PeeringConfig: &dns.ManagedZonePeeringConfig{}},
+	} {
+		_, err := (&mockManagedZonesClient{}).Create(project, zone).Do()
+		require.NoError(t, err)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		kinds    []string
+		expected []string
+	}{
+		{name: "default", kinds: nil, expected: []string{"kind-managed", "kind-forwarding"}},
+		{name: "peering only", kinds: []string{"peering"}, expected: []string{"kind-peering"}},
+		{name: "managed and peering", kinds: []string{"managed", "peering"}, expected: []string{"kind-managed", "kind-peering"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &GoogleProvider{
+				projects:           []string{project},
+				domainFilter:       endpoint.NewDomainFilter([]string{"kind.local."}),
+				zoneIDFilter:       provider.NewZoneIDFilter([]string{""}),
+				zoneKinds:          tt.kinds,
+				managedZonesClient: &mockManagedZonesClient{},
+			}
+
+			zones, err := p.Zones(t.Context())
+			require.NoError(t, err)
+
+			var names []string
+			for _, zone := range zones {
+				names = append(names, zone.Name)
+			}
+			assert.ElementsMatch(t, tt.expected, names)
+		})
+	}
+}
+
+func TestGoogleMultipleProjects(t *testing.T) {
+	p := &GoogleProvider{
+		projects:                 []string{"multi-project-a", "multi-project-b"},
+		domainFilter:             endpoint.NewDomainFilter([]string{"example.org"}),
+		zoneIDFilter:             provider.NewZoneIDFilter([]string{""}),
+		resourceRecordSetsClient: &mockResourceRecordSetsClient{},
+		managedZonesClient:       &mockManagedZonesClient{},
+		changesClient:            &mockChangesClient{},
+	}
+
+	// zone names only need to be unique within a project
+	_, err := p.managedZonesClient.Create("multi-project-a", &dns.ManagedZone{Name: "shared", DnsName: "a.example.org."}).Do()
+	require.NoError(t, err)
+	_, err = p.managedZonesClient.Create("multi-project-b", &dns.ManagedZone{Name: "shared", DnsName: "b.example.org."}).Do()
+	require.NoError(t, err)
+
+	zones, err := p.Zones(t.Context())
+	require.NoError(t, err)
+	validateZones(t, zones, map[string]*dns.ManagedZone{
+		"multi-project-a/shared": {Name: "shared", DnsName: "a.example.org."},
+		"multi-project-b/shared": {Name: "shared", DnsName: "b.example.org."},
+	})
+
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("foo.a.example.org", endpoint.RecordTypeA, endpoint.TTL(300), "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("bar.b.example.org", endpoint.RecordTypeA, endpoint.TTL(300), "5.6.7.8"),
+	}
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Create: records}))
+
+	assert.Contains(t, testRecords[zoneKey("multi-project-a", "shared")], "A/foo.a.example.org.")
+	assert.Contains(t, testRecords[zoneKey("multi-project-b", "shared")], "A/bar.b.example.org.")
+
+	actual, err := p.Records(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, actual, records)
+}
+
 func TestGoogleRecords(t *testing.T) {
 	originalEndpoints := []*endpoint.Endpoint{
 		endpoint.NewEndpointWithTTL("list-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, endpoint.TTL(1), "1.2.3.4"),
@@ -472,6 +547,7 @@ func TestNewFilteredRecords(t *testing.T) {
 		endpoint.NewEndpoint("delete-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, "8.8.8.8"),
 		endpoint.NewEndpoint("delete-test-cname.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeCNAME, "qux.elb.amazonaws.com"),
 		endpoint.NewEndpoint("delete-test-ns.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeNS, "foo.elb.amazonaws.com"),
+		endpoint.NewEndpoint("update-test-txt.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeTXT, "heritage=external-dns"),
 	})
 
 	validateChangeRecords(t, records, []*dns.ResourceRecordSet{
@@ -484,9 +560,31 @@ func TestNewFilteredRecords(t *testing.T) {
 		{Name: "delete-test.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"8.8.8.8"}, Type: "A", Ttl: 300},
 		{Name: "delete-test-cname.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"qux.elb.amazonaws.com."}, Type: "CNAME", Ttl: 300},
 		{Name: "delete-test-ns.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"foo.elb.amazonaws.com."}, Type: "NS", Ttl: 300},
+		{Name: "update-test-txt.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{`"heritage=external-dns"`}, Type: "TXT", Ttl: 300},
 	})
 }
 
+func TestGoogleAdjustEndpoints(t *testing.T) {
+	p := &GoogleProvider{}
+	long := strings.Repeat("a", 300)
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "8.8.8.8"),
+		endpoint.NewEndpoint("txt.example.org", endpoint.RecordTypeTXT, "heritage=external-dns", long, `"already quoted"`),
+	}
+
+	testutils.TestHelperAdjustEndpointsContract(t, p.AdjustEndpoints, endpoints)
+
+	adjusted, err := p.AdjustEndpoints(endpoints)
+	require.NoError(t, err)
+	require.Len(t, adjusted, 2)
+	assert.Equal(t, endpoint.Targets{"8.8.8.8"}, adjusted[0].Targets)
+	assert.Equal(t, endpoint.Targets{
+		`"heritage=external-dns"`,
+		`"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 45) + `"`,
+		`"already quoted"`,
+	}, adjusted[1].Targets)
+}
+
 func TestSeparateChanges(t *testing.T) {
 	change := &dns.Change{
 		Additions: []*dns.ResourceRecordSet{
@@ -612,6 +710,107 @@ func TestGoogleBatchChangeSetExceedingNameChange(t *testing.T) {
 	require.Empty(t, batchCs)
 }
 
+func TestGoogleBatchChangeSetBoundaries(t *testing.T) {
+	cs := &dns.Change{
+		Additions: []*dns.ResourceRecordSet{
+			{Name: "host-1.example.org.", Ttl: 2},
+			{Name: "host-2.example.org.", Ttl: 2},
+			{Name: "host-3.example.org.", Ttl: 2},
+		},
+		Deletions: []*dns.ResourceRecordSet{
+			{Name: "host-1.example.org.", Ttl: 20},
+			{Name: "host-2.example.org.", Ttl: 20},
+		},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		batchSize int
+		expected  [][]string
+	}{
+		{name: "update of a name filling a batch", batchSize: 2, expected: [][]string{
+			{"host-1.example.org."},
+			{"host-2.example.org."},
+			{"host-3.example.org."},
+		}},
+		{name: "update of a name not fitting in the current batch", batchSize: 3, expected: [][]string{
+			{"host-1.example.org."},
+			{"host-2.example.org.", "host-3.example.org."},
+		}},
+		{name: "updates filling a batch", batchSize: 4, expected: [][]string{
+			{"host-1.example.org.", "host-2.example.org."},
+			{"host-3.example.org."},
+		}},
+		{name: "all changes in a batch", batchSize: 5, expected: [][]string{
+			{"host-1.example.org.", "host-2.example.org.", "host-3.example.org."},
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := batchChange(cs, tt.batchSize)
+			require.Len(t, batches, len(tt.expected))
+			for i, batch := range batches {
+				assert.LessOrEqual(t, len(batch.Additions)+len(batch.Deletions), tt.batchSize)
+				names := map[string]bool{}
+				for _, r := range append(batch.Additions, batch.Deletions...) {
+					names[r.Name] = true
+				}
+				assert.ElementsMatch(t, tt.expected[i], slices.Collect(maps.Keys(names)))
+			}
+		})
+	}
+}
+
+func TestGoogleBatchChangeSetNameCase(t *testing.T) {
+	cs := &dns.Change{
+		Additions: []*dns.ResourceRecordSet{
+			{Name: "Host-1.example.org.", Ttl: 2},
+			{Name: "host-0.example.org.", Ttl: 2},
+		},
+		Deletions: []*dns.ResourceRecordSet{
+			{Name: "host-1.example.org.", Ttl: 20},
+		},
+	}
+
+	batches := batchChange(cs, 2)
+
+	require.Len(t, batches, 2)
+	validateChange(t, batches[0], &dns.Change{
+		Additions: []*dns.ResourceRecordSet{{Name: "host-0.example.org.", Ttl: 2}},
+	})
+	validateChange(t, batches[1], &dns.Change{
+		Additions: []*dns.ResourceRecordSet{{Name: "Host-1.example.org.", Ttl: 2}},
+		Deletions: []*dns.ResourceRecordSet{{Name: "host-1.example.org.", Ttl: 20}},
+	})
+}
+
+// recordingChangesClient records the zones changes are submitted to.
+type recordingChangesClient struct {
+	mockChangesClient
+	zones []string
+}
+
+func (m *recordingChangesClient) Create(project string, managedZone string, change *dns.Change) changesCreateCallInterface {
+	m.zones = append(m.zones, managedZone)
+	return m.mockChangesClient.Create(project, managedZone, change)
+}
+
+func TestGoogleApplyChangesZoneOrder(t *testing.T) {
+	p := newGoogleProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.gcp.zalan.do."}), provider.NewZoneIDFilter([]string{""}), false, []*endpoint.Endpoint{}, nil, nil)
+	client := &recordingChangesClient{}
+	p.changesClient = client
+	p.batchChangeSize = 1
+
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("b.zone-2.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, "8.8.4.4"),
+			endpoint.NewEndpoint("a.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, "8.8.8.8"),
+			endpoint.NewEndpoint("a.zone-2.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, "8.8.4.4"),
+		},
+	}))
+
+	assert.Equal(t, []string{"zone-1-ext-dns-test-2-gcp-zalan-do", "zone-2-ext-dns-test-2-gcp-zalan-do", "zone-2-ext-dns-test-2-gcp-zalan-do"}, client.zones)
+}
+
 func TestSoftErrListZonesConflict(t *testing.T) {
 	p := newGoogleProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.gcp.zalan.do."}), provider.NewZoneIDFilter([]string{}), false, []*endpoint.Endpoint{}, provider.NewSoftErrorf("failed to list zones"), nil)
 
@@ -678,7 +877,7 @@ func validateChangeRecord(t *testing.T, record *dns.ResourceRecordSet, expected
 
 func newGoogleProviderZoneOverlap(t *testing.T, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, zoneTypeFilter provider.ZoneTypeFilter, _ []*endpoint.Endpoint) *GoogleProvider {
 	provider := &GoogleProvider{
-		project:                  "zalando-external-dns-test",
+		projects:                 []string{"zalando-external-dns-test"},
 		dryRun:                   false,
 		domainFilter:             domainFilter,
 		zoneIDFilter:             zoneIDFilter,
@@ -743,7 +942,7 @@ func newGoogleProviderZoneOverlap(t *testing.T, domainFilter *endpoint.DomainFil
 
 func newGoogleProvider(t *testing.T, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, dryRun bool, records []*endpoint.Endpoint, zonesErr, recordsErr error) *GoogleProvider {
 	provider := &GoogleProvider{
-		project:      "zalando-external-dns-test",
+		projects:     []string{"zalando-external-dns-test"},
 		dryRun:       false,
 		domainFilter: domainFilter,
 		zoneIDFilter: zoneIDFilter,
@@ -817,7 +1016,7 @@ func setupGoogleRecords(t *testing.T, provider *GoogleProvider, endpoints []*end
 func clearGoogleRecords(t *testing.T, provider *GoogleProvider, zone string) {
 	recordSets := []*dns.ResourceRecordSet{}
 
-	provider.resourceRecordSetsClient.List(provider.project, zone).Pages(t.Context(), func(resp *dns.ResourceRecordSetsListResponse) error {
+	provider.resourceRecordSetsClient.List(provider.projects[0], zone).Pages(t.Context(), func(resp *dns.ResourceRecordSetsListResponse) error {
 		for _, r := range resp.Rrsets {
 			switch r.Type {
 			case endpoint.RecordTypeA, endpoint.RecordTypeCNAME:
@@ -828,7 +1027,7 @@ func clearGoogleRecords(t *testing.T, provider *GoogleProvider, zone string) {
 	})
 
 	if len(recordSets) != 0 {
-		_, err := provider.changesClient.Create(provider.project, zone, &dns.Change{
+		_, err := provider.changesClient.Create(provider.projects[0], zone, &dns.Change{
 			Deletions: recordSets,
 		}).Do()
 		require.NoError(t, err)
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"maps"
 	"strings"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 
@@ -51,13 +52,18 @@ type InMemoryProvider struct {
 	domain         endpoint.DomainFilterInterface
 	client         *inMemoryClient
 	filter         *filter
+	stateFile      string
 	OnApplyChanges func(ctx context.Context, changes *plan.Changes)
 	OnRecords      func()
 }
 
 // New creates an InMemory provider from the given configuration.
 func New(_ context.Context, cfg *externaldns.Config, domainFilter *endpoint.DomainFilter) (provider.Provider, error) {
-	return newProvider(InMemoryInitZones(cfg.InMemoryZones), InMemoryWithDomain(domainFilter), InMemoryWithLogging()), nil
+	im := newProvider(InMemoryInitZones(cfg.InMemoryZones), InMemoryWithDomain(domainFilter), InMemoryWithLogging(), InMemoryWithStateFile(cfg.InMemoryStateFile))
+	if err := im.loadState(); err != nil {
+		return nil, err
+	}
+	return im, nil
 }
 
 // InMemoryOption allows to extend in-memory provider
@@ -91,6 +97,14 @@ func InMemoryWithDomain(domainFilter *endpoint.DomainFilter) InMemoryOption {
 	}
 }
 
+// InMemoryWithStateFile persists the zones and records to the given JSON file after
+// every change, see New for loading them back. An empty path disables persistence.
+func InMemoryWithStateFile(path string) InMemoryOption {
+	return func(p *InMemoryProvider) {
+		p.stateFile = path
+	}
+}
+
 // InMemoryInitZones pre-seeds the InMemoryProvider with given zones
 func InMemoryInitZones(zones []string) InMemoryOption {
 	return func(p *InMemoryProvider) {
@@ -134,6 +148,11 @@ func (im *InMemoryProvider) Zones() map[string]string {
 	return im.filter.Zones(im.client.Zones())
 }
 
+// SupportedRecordTypes returns nil, the in-memory store accepts any record type.
+func (im *InMemoryProvider) SupportedRecordTypes() []string {
+	return nil
+}
+
 // Records returns the list of endpoints
 func (im *InMemoryProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
 	defer im.OnRecords()
@@ -205,7 +224,7 @@ func (im *InMemoryProvider) ApplyChanges(ctx context.Context, changes *plan.Chan
 		}
 	}
 
-	return nil
+	return im.saveState()
 }
 
 func copyEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
@@ -251,14 +270,18 @@ func (f *filter) EndpointZoneID(endpoint *endpoint.Endpoint, zones map[string]st
 type zone map[endpoint.EndpointKey]*endpoint.Endpoint
 
 type inMemoryClient struct {
+	// mu guards zones, which are also read by the inspection handler.
+	mu    sync.RWMutex
 	zones map[string]zone
 }
 
 func newInMemoryClient() *inMemoryClient {
-	return &inMemoryClient{map[string]zone{}}
+	return &inMemoryClient{zones: map[string]zone{}}
 }
 
 func (c *inMemoryClient) Records(zone string) ([]*endpoint.Endpoint, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	if _, ok := c.zones[zone]; !ok {
 		return nil, ErrZoneNotFound
 	}
@@ -271,6 +294,8 @@ func (c *inMemoryClient) Records(zone string) ([]*endpoint.Endpoint, error) {
 }
 
 func (c *inMemoryClient) Zones() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	zones := map[string]string{}
 	for zone := range c.zones {
 		zones[zone] = zone
@@ -279,6 +304,8 @@ func (c *inMemoryClient) Zones() map[string]string {
 }
 
 func (c *inMemoryClient) CreateZone(zone string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if _, ok := c.zones[zone]; ok {
 		return ErrZoneAlreadyExists
 	}
@@ -288,6 +315,8 @@ func (c *inMemoryClient) CreateZone(zone string) error {
 }
 
 func (c *inMemoryClient) ApplyChanges(_ context.Context, zoneID string, changes *plan.Changes) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if err := c.validateChangeBatch(zoneID, changes); err != nil {
 		return err
 	}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inmemory
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// InspectHandler returns a read-only handler listing the zones and records of the
+// provider as JSON. The zone query parameter restricts the listing to one zone.
+func (im *InMemoryProvider) InspectHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		zones := im.client.snapshot()
+		visible := im.Zones()
+		for zoneName := range zones {
+			if _, ok := visible[zoneName]; !ok {
+				delete(zones, zoneName)
+			}
+		}
+		if name := r.URL.Query().Get("zone"); name != "" {
+			records, ok := zones[name]
+			if !ok {
+				http.Error(w, ErrZoneNotFound.Error(), http.StatusNotFound)
+				return
+			}
+			zones = map[string][]*endpoint.Endpoint{name: records}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(state{Zones: zones})
+	})
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inmemory
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestInspectHandler(t *testing.T) {
+	p := NewInMemoryProvider(InMemoryInitZones([]string{"example.org", "example.com"}))
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeTXT, "text"),
+		endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeCNAME, "foo.example.org"),
+	}}))
+	handler := p.InspectHandler()
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	rec := get("/inmemory")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var st state
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &st))
+	require.Len(t, st.Zones, 2)
+	require.Len(t, st.Zones["example.org"], 2)
+	assert.Equal(t, endpoint.RecordTypeA, st.Zones["example.org"][0].RecordType, "records are sorted")
+	assert.Equal(t, endpoint.RecordTypeTXT, st.Zones["example.org"][1].RecordType)
+
+	rec = get("/inmemory?zone=example.com")
+	require.Equal(t, http.StatusOK, rec.Code)
+	st = state{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &st))
+	require.Len(t, st.Zones, 1)
+	assert.Equal(t, "bar.example.com", st.Zones["example.com"][0].DNSName)
+
+	assert.Equal(t, http.StatusNotFound, get("/inmemory?zone=example.net").Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/inmemory", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
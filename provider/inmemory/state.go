@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// state is the content of the state file, and of the inspection endpoint.
+type state struct {
+	Zones map[string][]*endpoint.Endpoint `json:"zones"`
+}
+
+// loadState restores the zones and records of the state file, if any. The zones
+// of the state file are added to the pre-configured zones.
+func (im *InMemoryProvider) loadState() error {
+	if im.stateFile == "" {
+		return nil
+	}
+	data, err := provider.FileStateStore{Path: im.stateFile}.Load(context.Background())
+	if err != nil {
+		return fmt.Errorf("reading inmemory state file %q: %w", im.stateFile, err)
+	}
+	if data == nil {
+		log.Infof("Inmemory state file %q not found, starting empty", im.stateFile)
+		return nil
+	}
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		return fmt.Errorf("decoding inmemory state file %q: %w", im.stateFile, err)
+	}
+	for zoneName, records := range st.Zones {
+		im.client.restore(zoneName, records)
+	}
+	log.Infof("Restored %d inmemory zones from %q", len(st.Zones), im.stateFile)
+	return nil
+}
+
+// saveState writes the zones and records to the state file, if any.
+func (im *InMemoryProvider) saveState() error {
+	if im.stateFile == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(state{Zones: im.client.snapshot()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := (provider.FileStateStore{Path: im.stateFile}).Save(context.Background(), data); err != nil {
+		return fmt.Errorf("writing inmemory state file %q: %w", im.stateFile, err)
+	}
+	return nil
+}
+
+// restore sets the records of zoneName, creating the zone if needed.
+func (c *inMemoryClient) restore(zoneName string, records []*endpoint.Endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	z := zone{}
+	for _, record := range records {
+		z[record.Key()] = record
+	}
+	c.zones[zoneName] = z
+}
+
+// snapshot returns copies of the records of every zone, sorted by name, type and
+// set identifier.
+func (c *inMemoryClient) snapshot() map[string][]*endpoint.Endpoint {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	zones := make(map[string][]*endpoint.Endpoint, len(c.zones))
+	for zoneName, z := range c.zones {
+		records := copyEndpoints(slices.Collect(maps.Values(z)))
+		slices.SortFunc(records, func(a, b *endpoint.Endpoint) int {
+			return cmp.Or(
+				strings.Compare(a.DNSName, b.DNSName),
+				strings.Compare(a.RecordType, b.RecordType),
+				strings.Compare(a.SetIdentifier, b.SetIdentifier),
+			)
+		})
+		zones[zoneName] = records
+	}
+	return zones
+}
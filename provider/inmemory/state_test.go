@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inmemory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestInMemoryStateFile(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	cfg := &externaldns.Config{InMemoryZones: []string{"example.org"}, InMemoryStateFile: stateFile}
+
+	p, err := New(t.Context(), cfg, endpoint.NewDomainFilter(nil))
+	require.NoError(t, err)
+	require.NoFileExists(t, stateFile)
+
+	ep := endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 300, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "default")
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{ep}}))
+	require.FileExists(t, stateFile)
+
+	cfg.InMemoryZones = []string{"example.com"}
+	restored, err := New(t.Context(), cfg, endpoint.NewDomainFilter(nil))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"example.org": "example.org", "example.com": "example.com"}, restored.(*InMemoryProvider).Zones())
+	records, err := restored.Records(t.Context())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.True(t, testutils.SameEndpoint(records[0], ep), "restored %v", records[0])
+
+	require.NoError(t, restored.ApplyChanges(context.Background(), &plan.Changes{Delete: []*endpoint.Endpoint{ep}}))
+	restored, err = New(t.Context(), cfg, endpoint.NewDomainFilter(nil))
+	require.NoError(t, err)
+	records, err = restored.Records(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestInMemoryStateFileInvalid(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, os.WriteFile(stateFile, []byte("{"), 0o600))
+
+	_, err := New(t.Context(), &externaldns.Config{InMemoryStateFile: stateFile}, endpoint.NewDomainFilter(nil))
+	require.ErrorContains(t, err, "decoding inmemory state file")
+}
@@ -18,9 +18,11 @@ package linode
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -35,6 +37,12 @@ import (
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 )
 
+// linodePageSize is the largest number of domains or records listed per request.
+const linodePageSize = 500
+
+// linodeTTLs are the TTLs accepted by the Linode API, which rounds other TTLs up to the next one.
+var linodeTTLs = []endpoint.TTL{30, 120, 300, 3600, 7200, 14400, 28800, 57600, 86400, 172800, 345600, 604800, 1209600, 2419200}
+
 // LinodeDomainClient interface to ease testing
 type LinodeDomainClient interface {
 	ListDomainRecords(ctx context.Context, domainID int, opts *linodego.ListOptions) ([]linodego.DomainRecord, error)
@@ -49,7 +57,11 @@ type LinodeProvider struct {
 	provider.BaseProvider
 	Client       LinodeDomainClient
 	domainFilter *endpoint.DomainFilter
-	DryRun       bool
+	// zoneTags are the tags the managed domains must all have.
+	zoneTags []string
+	// zoneConcurrency is the number of domains whose records are listed in parallel.
+	zoneConcurrency int
+	DryRun          bool
 }
 
 // LinodeChanges All API calls calculated from the plan
@@ -80,11 +92,11 @@ type LinodeChangeDelete struct {
 
 // New creates a Linode provider from the given configuration.
 func New(_ context.Context, cfg *externaldns.Config, domainFilter *endpoint.DomainFilter) (provider.Provider, error) {
-	return newProvider(domainFilter, cfg.DryRun)
+	return newProvider(domainFilter, cfg.LinodeZoneTags, cfg.ProviderZoneConcurrency, cfg.DryRun)
 }
 
 // newProvider initializes a new Linode DNS based Provider.
-func newProvider(domainFilter *endpoint.DomainFilter, dryRun bool) (*LinodeProvider, error) {
+func newProvider(domainFilter *endpoint.DomainFilter, zoneTags []string, zoneConcurrency int, dryRun bool) (*LinodeProvider, error) {
 	token, ok := os.LookupEnv("LINODE_TOKEN")
 	if !ok {
 		return nil, fmt.Errorf("no token found")
@@ -102,9 +114,11 @@ func newProvider(domainFilter *endpoint.DomainFilter, dryRun bool) (*LinodeProvi
 	linodeClient.SetUserAgent(fmt.Sprintf("%s linodego/%s", externaldns.UserAgent(), linodego.Version))
 
 	return &LinodeProvider{
-		Client:       &linodeClient,
-		domainFilter: domainFilter,
-		DryRun:       dryRun,
+		Client:          &linodeClient,
+		domainFilter:    domainFilter,
+		zoneTags:        trimZoneTags(zoneTags),
+		zoneConcurrency: zoneConcurrency,
+		DryRun:          dryRun,
 	}, nil
 }
 
@@ -118,6 +132,11 @@ func (p *LinodeProvider) Zones(ctx context.Context) ([]linodego.Domain, error) {
 	return zones, nil
 }
 
+// SupportedRecordTypes returns the record types managed with Linode.
+func (p *LinodeProvider) SupportedRecordTypes() []string {
+	return provider.DefaultSupportedRecordTypes()
+}
+
 // Records returns the list of records in a given zone.
 func (p *LinodeProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.Zones(ctx)
@@ -125,14 +144,13 @@ func (p *LinodeProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 		return nil, err
 	}
 
-	var endpoints []*endpoint.Endpoint
-
-	for _, zone := range zones {
+	return provider.ZoneRecords(ctx, zones, p.zoneConcurrency, func(ctx context.Context, zone linodego.Domain) ([]*endpoint.Endpoint, error) {
 		records, err := p.fetchRecords(ctx, zone.ID)
 		if err != nil {
 			return nil, err
 		}
 
+		var endpoints []*endpoint.Endpoint
 		for _, r := range records {
 			if provider.SupportedRecordType(string(r.Type)) {
 				name := fmt.Sprintf("%s.%s", r.Name, zone.Domain)
@@ -143,29 +161,79 @@ func (p *LinodeProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 					name = zone.Domain
 				}
 
-				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, string(r.Type), endpoint.TTL(r.TTLSec), r.Target))
+				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, string(r.Type), recordTTL(zone, r), r.Target))
 			}
 		}
+		return endpoints, nil
+	})
+}
+
+// AdjustEndpoints rounds the configured TTLs up to the next TTL accepted by Linode,
+// as the Linode API does, so that the records are not updated on every synchronization.
+func (p *LinodeProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return provider.EndpointAdjuster(func(ep *endpoint.Endpoint) []*endpoint.Endpoint {
+		if ep.RecordTTL.IsConfigured() {
+			ep.RecordTTL = roundTTL(ep.RecordTTL)
+		}
+		return []*endpoint.Endpoint{ep}
+	}).AdjustEndpoints(endpoints)
+}
+
+// roundTTL returns the TTL accepted by Linode that ttl is rounded up to.
+func roundTTL(ttl endpoint.TTL) endpoint.TTL {
+	for _, t := range linodeTTLs {
+		if ttl <= t {
+			return t
+		}
 	}
+	return linodeTTLs[len(linodeTTLs)-1]
+}
+
+// recordTTL returns the TTL of the record, the default TTL of its zone when it has none.
+func recordTTL(zone linodego.Domain, r linodego.DomainRecord) endpoint.TTL {
+	if r.TTLSec == 0 {
+		return endpoint.TTL(zone.TTLSec)
+	}
+	return endpoint.TTL(r.TTLSec)
+}
 
-	return endpoints, nil
+// listOptions returns the options listing the domains or records in pages of linodePageSize.
+func listOptions() *linodego.ListOptions {
+	return &linodego.ListOptions{PageOptions: &linodego.PageOptions{}, PageSize: linodePageSize}
 }
 
 func (p *LinodeProvider) fetchRecords(ctx context.Context, domainID int) ([]linodego.DomainRecord, error) {
-	records, err := p.Client.ListDomainRecords(ctx, domainID, nil)
+	records, err := p.Client.ListDomainRecords(ctx, domainID, listOptions())
 	if err != nil {
-		return nil, err
+		return nil, classifyError(fmt.Errorf("failed to list the records of domain %d: %w", domainID, err))
 	}
 
 	return records, nil
 }
 
+// fetchZoneRecords returns the records of the zones by zone ID, listing up to
+// zoneConcurrency zones in parallel.
+func (p *LinodeProvider) fetchZoneRecords(ctx context.Context, zones []linodego.Domain) (map[string][]linodego.DomainRecord, error) {
+	records, err := provider.ListZones(ctx, zones, p.zoneConcurrency, func(ctx context.Context, zone linodego.Domain) ([]linodego.DomainRecord, error) {
+		return p.fetchRecords(ctx, zone.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recordsByZoneID := make(map[string][]linodego.DomainRecord, len(zones))
+	for i, zone := range zones {
+		recordsByZoneID[strconv.Itoa(zone.ID)] = append(recordsByZoneID[strconv.Itoa(zone.ID)], records[i]...)
+	}
+	return recordsByZoneID, nil
+}
+
 func (p *LinodeProvider) fetchZones(ctx context.Context) ([]linodego.Domain, error) {
 	var zones []linodego.Domain
 
-	allZones, err := p.Client.ListDomains(ctx, linodego.NewListOptions(0, ""))
+	allZones, err := p.Client.ListDomains(ctx, listOptions())
 	if err != nil {
-		return nil, err
+		return nil, classifyError(fmt.Errorf("failed to list domains: %w", err))
 	}
 
 	for _, zone := range allZones {
@@ -173,12 +241,54 @@ func (p *LinodeProvider) fetchZones(ctx context.Context) ([]linodego.Domain, err
 			continue
 		}
 
+		if !hasZoneTags(zone, p.zoneTags) {
+			log.Debugf("Skipping domain %s because its tags do not match the zone tag filter", zone.Domain)
+			continue
+		}
+
 		zones = append(zones, zone)
 	}
 
 	return zones, nil
 }
 
+// trimZoneTags returns the tags without surrounding spaces, dropping the empty ones.
+func trimZoneTags(tags []string) []string {
+	var trimmed []string
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			trimmed = append(trimmed, tag)
+		}
+	}
+	return trimmed
+}
+
+// hasZoneTags returns whether the zone has every one of the tags. Linode tags are plain
+// strings, so a tag matches only the same string: a bare "external-dns" tag does not
+// match "external-dns=false".
+func hasZoneTags(zone linodego.Domain, tags []string) bool {
+	for _, tag := range tags {
+		if !slices.ContainsFunc(zone.Tags, func(t string) bool { return strings.TrimSpace(t) == tag }) {
+			return false
+		}
+	}
+	return true
+}
+
+// classifyError returns the errors of the Linode API worth retrying later, like
+// rate limits (429) and server errors, as soft errors.
+func classifyError(err error) error {
+	var apiErr *linodego.Error
+	if errors.As(err, &apiErr) {
+		var header http.Header
+		if apiErr.Response != nil {
+			header = apiErr.Response.Header
+		}
+		return provider.ClassifyHTTPError(err, apiErr.Code, header)
+	}
+	return provider.ClassifyError(err)
+}
+
 // submitChanges takes a zone and a collection of Changes and sends them as a single transaction.
 func (p *LinodeProvider) submitChanges(ctx context.Context, changes LinodeChanges) error {
 	for _, change := range changes.Creates {
@@ -269,8 +379,6 @@ func getPriority() *int {
 
 // ApplyChanges applies a given set of changes in a given zone.
 func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
-	recordsByZoneID := make(map[string][]linodego.DomainRecord)
-
 	zones, err := p.fetchZones(ctx)
 	if err != nil {
 		return err
@@ -285,14 +393,9 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 		zonesByID[strconv.Itoa(z.ID)] = z
 	}
 
-	// Fetch records for each zone
-	for _, zone := range zones {
-		records, err := p.fetchRecords(ctx, zone.ID)
-		if err != nil {
-			return err
-		}
-
-		recordsByZoneID[strconv.Itoa(zone.ID)] = append(recordsByZoneID[strconv.Itoa(zone.ID)], records...)
+	recordsByZoneID, err := p.fetchZoneRecords(ctx, zones)
+	if err != nil {
+		return err
 	}
 
 	createsByZone := endpointsByZone(zoneNameIDMapper, changes.Create)
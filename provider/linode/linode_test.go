@@ -18,6 +18,9 @@ package linode
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
 	"testing"
 
@@ -27,7 +30,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
 )
 
 type MockDomainClient struct {
@@ -145,11 +150,11 @@ func TestLinodeConvertRecordType(t *testing.T) {
 
 func TestNewProvider(t *testing.T) {
 	t.Setenv("LINODE_TOKEN", "xxxxxxxxxxxxxxxxx")
-	_, err := newProvider(endpoint.NewDomainFilter([]string{"ext-dns-test.zalando.to."}), true)
+	_, err := newProvider(endpoint.NewDomainFilter([]string{"ext-dns-test.zalando.to."}), nil, 1, true)
 	require.NoError(t, err)
 
 	_ = os.Unsetenv("LINODE_TOKEN")
-	_, err = newProvider(endpoint.NewDomainFilter([]string{"ext-dns-test.zalando.to."}), true)
+	_, err = newProvider(endpoint.NewDomainFilter([]string{"ext-dns-test.zalando.to."}), nil, 1, true)
 	require.Error(t, err)
 }
 
@@ -216,6 +221,99 @@ func TestLinodeFetchZonesWithFilter(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestLinodeFetchZonesWithTagFilter(t *testing.T) {
+	mockDomainClient := MockDomainClient{}
+
+	provider := &LinodeProvider{
+		Client:       &mockDomainClient,
+		domainFilter: endpoint.NewDomainFilter([]string{}),
+		zoneTags:     trimZoneTags([]string{"external-dns", " env=prod", ""}),
+	}
+
+	mockDomainClient.On(
+		"ListDomains",
+		mock.Anything,
+		mock.MatchedBy(func(opts *linodego.ListOptions) bool { return opts.PageSize == linodePageSize }),
+	).Return([]linodego.Domain{
+		{ID: 1, Domain: "foo.com", Tags: []string{"external-dns", "env=prod"}},
+		{ID: 2, Domain: "bar.io", Tags: []string{"external-dns", "env=dev"}},
+		{ID: 3, Domain: "baz.com", Tags: []string{"env=prod"}},
+		{ID: 4, Domain: "qux.com"},
+		{ID: 5, Domain: "quux.com", Tags: []string{"external-dns=false", "env=prod"}},
+	}, nil).Once()
+
+	actual, err := provider.fetchZones(t.Context())
+	require.NoError(t, err)
+
+	mockDomainClient.AssertExpectations(t)
+	assert.Equal(t, []linodego.Domain{{ID: 1, Domain: "foo.com", Tags: []string{"external-dns", "env=prod"}}}, actual)
+}
+
+func TestLinodeFetchZonesRateLimited(t *testing.T) {
+	mockDomainClient := MockDomainClient{}
+
+	p := &LinodeProvider{
+		Client:       &mockDomainClient,
+		domainFilter: endpoint.NewDomainFilter([]string{}),
+	}
+
+	mockDomainClient.On(
+		"ListDomains",
+		mock.Anything,
+		mock.Anything,
+	).Return([]linodego.Domain{}, &linodego.Error{Code: http.StatusTooManyRequests, Message: "Too Many Requests"}).Once()
+
+	_, err := p.fetchZones(t.Context())
+	require.ErrorIs(t, err, provider.SoftError)
+}
+
+func TestLinodeClassifyError(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		soft bool
+	}{
+		{name: "rate limited", err: &linodego.Error{Code: http.StatusTooManyRequests}, soft: true},
+		{name: "server error", err: &linodego.Error{Code: http.StatusBadGateway}, soft: true},
+		{name: "retry after", err: &linodego.Error{Code: http.StatusBadRequest, Response: &http.Response{Header: http.Header{"Retry-After": {"10"}}}}, soft: true},
+		{name: "unauthorized", err: &linodego.Error{Code: http.StatusUnauthorized}, soft: false},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, soft: true},
+		{name: "other error", err: errors.New("invalid"), soft: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := classifyError(fmt.Errorf("failed: %w", tc.err))
+			assert.Equal(t, tc.soft, errors.Is(err, provider.SoftError))
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestLinodeAdjustEndpoints(t *testing.T) {
+	provider := &LinodeProvider{}
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("default.foo.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("short.foo.com", endpoint.RecordTypeA, 60, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("exact.foo.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("long.foo.com", endpoint.RecordTypeA, 5000000, "1.2.3.4"),
+	}
+	testutils.TestHelperAdjustEndpointsContract(t, provider.AdjustEndpoints, endpoints)
+
+	actual, err := provider.AdjustEndpoints(endpoints)
+	require.NoError(t, err)
+
+	var ttls []endpoint.TTL
+	for _, ep := range actual {
+		ttls = append(ttls, ep.RecordTTL)
+	}
+	assert.Equal(t, []endpoint.TTL{0, 120, 3600, 2419200}, ttls)
+}
+
+func TestLinodeRecordTTL(t *testing.T) {
+	zone := linodego.Domain{ID: 1, Domain: "foo.com", TTLSec: 3600}
+	assert.Equal(t, endpoint.TTL(300), recordTTL(zone, linodego.DomainRecord{TTLSec: 300}))
+	assert.Equal(t, endpoint.TTL(3600), recordTTL(zone, linodego.DomainRecord{}), "a record without TTL has the TTL of its zone")
+}
+
 func TestLinodeGetStrippedRecordName(t *testing.T) {
 	assert.Empty(t, getStrippedRecordName(linodego.Domain{
 		Domain: "foo.com",
@@ -234,9 +332,10 @@ func TestLinodeRecords(t *testing.T) {
 	mockDomainClient := MockDomainClient{}
 
 	provider := &LinodeProvider{
-		Client:       &mockDomainClient,
-		domainFilter: endpoint.NewDomainFilter([]string{}),
-		DryRun:       false,
+		Client:          &mockDomainClient,
+		domainFilter:    endpoint.NewDomainFilter([]string{}),
+		zoneConcurrency: 3,
+		DryRun:          false,
 	}
 
 	mockDomainClient.On(
@@ -17,6 +17,7 @@ limitations under the License.
 package ns1
 
 import (
+	"cmp"
 	"context"
 	"crypto/tls"
 	"fmt"
@@ -29,6 +30,7 @@ import (
 	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/credentials"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
@@ -106,12 +108,13 @@ type NS1Provider struct {
 }
 
 // New creates an NS1 provider from the given configuration.
-func New(_ context.Context, cfg *externaldns.Config, domainFilter *endpoint.DomainFilter) (provider.Provider, error) {
+func New(ctx context.Context, cfg *externaldns.Config, domainFilter *endpoint.DomainFilter) (provider.Provider, error) {
 	return newProvider(
+		ctx,
 		NS1Config{
 			DomainFilter:  domainFilter,
 			ZoneIDFilter:  provider.NewZoneIDFilter(cfg.ZoneIDFilter),
-			NS1Endpoint:   cfg.NS1Endpoint,
+			NS1Endpoint:   cmp.Or(cfg.ProviderEndpoint, cfg.NS1Endpoint),
 			NS1IgnoreSSL:  cfg.NS1IgnoreSSL,
 			DryRun:        cfg.DryRun,
 			MinTTLSeconds: cfg.NS1MinTTLSeconds,
@@ -120,16 +123,21 @@ func New(_ context.Context, cfg *externaldns.Config, domainFilter *endpoint.Doma
 }
 
 // newProvider creates a new NS1 Provider
-func newProvider(config NS1Config) (*NS1Provider, error) {
-	return newNS1ProviderWithHTTPClient(config, http.DefaultClient)
+func newProvider(ctx context.Context, config NS1Config) (*NS1Provider, error) {
+	return newNS1ProviderWithHTTPClient(ctx, config, http.DefaultClient)
 }
 
-func newNS1ProviderWithHTTPClient(config NS1Config, client *http.Client) (*NS1Provider, error) {
+func newNS1ProviderWithHTTPClient(ctx context.Context, config NS1Config, client *http.Client) (*NS1Provider, error) {
 	token, ok := os.LookupEnv("NS1_APIKEY")
 	if !ok {
 		return nil, fmt.Errorf("NS1_APIKEY environment variable is not set")
 	}
-	clientArgs := []func(*api.Client){api.SetAPIKey(token)}
+	// an API key read from a file is reloaded when the file changes, e.g. on Secret rotation
+	apiKey, err := credentials.Resolve(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NS1_APIKEY: %w", err)
+	}
+	clientArgs := []func(*api.Client){api.SetAPIKey(apiKey.Get())}
 	if config.NS1Endpoint != "" {
 		log.Infof("ns1-endpoint flag is set, targeting endpoint at %s", config.NS1Endpoint)
 		clientArgs = append(clientArgs, api.SetEndpoint(config.NS1Endpoint))
@@ -150,7 +158,10 @@ func newNS1ProviderWithHTTPClient(config NS1Config, client *http.Client) (*NS1Pr
 		client.Transport = tr
 	}
 
-	apiClient := api.NewClient(client, clientArgs...)
+	// copy the client to not alter the transport of http.DefaultClient
+	authClient := *client
+	authClient.Transport = credentials.NewHeaderRoundTripper(client.Transport, "X-NSONE-Key", "", apiKey)
+	apiClient := api.NewClient(&authClient, clientArgs...)
 
 	return &NS1Provider{
 		client:        NS1DomainService{apiClient},
@@ -160,6 +171,11 @@ func newNS1ProviderWithHTTPClient(config NS1Config, client *http.Client) (*NS1Pr
 	}, nil
 }
 
+// SupportedRecordTypes returns the record types managed with NS1.
+func (p *NS1Provider) SupportedRecordTypes() []string {
+	return provider.DefaultSupportedRecordTypes()
+}
+
 // Records returns the endpoints this provider knows about
 func (p *NS1Provider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.zonesFiltered()
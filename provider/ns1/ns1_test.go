@@ -19,7 +19,9 @@ package ns1
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -155,14 +157,38 @@ func TestNewNS1Provider(t *testing.T) {
 		ZoneIDFilter: provider.NewZoneIDFilter([]string{""}),
 		DryRun:       false,
 	}
-	_, err := newProvider(testNS1Config)
+	_, err := newProvider(t.Context(), testNS1Config)
 	require.NoError(t, err)
 
 	_ = os.Unsetenv("NS1_APIKEY")
-	_, err = newProvider(testNS1Config)
+	_, err = newProvider(t.Context(), testNS1Config)
 	require.Error(t, err)
 }
 
+func TestNewNS1ProviderAPIKeyFromFile(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-NSONE-Key")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "apikey")
+	require.NoError(t, os.WriteFile(path, []byte("key-from-file\n"), 0o600))
+	t.Setenv("NS1_APIKEY", "file:"+path)
+
+	p, err := newNS1ProviderWithHTTPClient(t.Context(), NS1Config{NS1Endpoint: server.URL + "/v1/"}, &http.Client{})
+	require.NoError(t, err)
+
+	_, _, err = p.client.ListZones()
+	require.NoError(t, err)
+	assert.Equal(t, "key-from-file", got)
+
+	t.Setenv("NS1_APIKEY", "file:"+filepath.Join(t.TempDir(), "missing"))
+	_, err = newProvider(t.Context(), NS1Config{})
+	require.ErrorContains(t, err, "failed to read NS1_APIKEY")
+}
+
 func TestNS1Zones(t *testing.T) {
 	provider := &NS1Provider{
 		client:       &MockNS1DomainClient{},
@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -56,6 +58,8 @@ type OCIConfig struct {
 	Auth              OCIAuthConfig `yaml:"auth"`
 	CompartmentID     string        `yaml:"compartment"`
 	ZoneCacheDuration time.Duration
+	// ZoneConcurrency is the number of zones whose records are listed in parallel
+	ZoneConcurrency int
 }
 
 // OCIProvider is an implementation of Provider for Oracle Cloud Infrastructure
@@ -79,25 +83,56 @@ type ociDNSClient interface {
 	PatchZoneRecords(ctx context.Context, request dns.PatchZoneRecordsRequest) (response dns.PatchZoneRecordsResponse, err error)
 }
 
+// workloadIdentityConfigurationProvider creates the configuration provider of the OKE
+// workload identity, replaced in tests.
+var workloadIdentityConfigurationProvider = func() (common.ConfigurationProvider, error) {
+	return auth.OkeWorkloadIdentityConfigurationProvider()
+}
+
 // New creates an OCI provider from the given configuration.
 func New(_ context.Context, cfg *externaldns.Config, domainFilter *endpoint.DomainFilter) (provider.Provider, error) {
 	var config *OCIConfig
-	if cfg.OCIAuthInstancePrincipal {
+	switch {
+	case cfg.OCIAuthInstancePrincipal && cfg.OCIAuthWorkloadIdentity:
+		return nil, errors.New("only one of --oci-auth-instance-principal and --oci-auth-workload-identity may be enabled")
+	case cfg.OCIAuthInstancePrincipal:
 		if len(cfg.OCICompartmentOCID) == 0 {
 			return nil, fmt.Errorf("instance principal authentication requested, but no compartment OCID provided")
 		}
 		authConfig := OCIAuthConfig{UseInstancePrincipal: true}
 		config = &OCIConfig{Auth: authConfig, CompartmentID: cfg.OCICompartmentOCID}
-	} else {
+	case cfg.OCIAuthWorkloadIdentity:
+		if len(cfg.OCICompartmentOCID) == 0 {
+			return nil, fmt.Errorf("workload identity authentication requested, but no compartment OCID provided")
+		}
+		authConfig := OCIAuthConfig{UseWorkloadIdentity: true}
+		config = &OCIConfig{Auth: authConfig, CompartmentID: cfg.OCICompartmentOCID}
+	default:
 		var err error
 		if config, err = loadOCIConfig(cfg.OCIConfigFile); err != nil {
 			return nil, err
 		}
+		detectWorkloadIdentity(&config.Auth)
 	}
 	config.ZoneCacheDuration = cfg.OCIZoneCacheDuration
+	config.ZoneConcurrency = cfg.ProviderZoneConcurrency
 	return newProvider(*config, domainFilter, provider.NewZoneIDFilter(cfg.ZoneIDFilter), cfg.OCIZoneScope, cfg.DryRun)
 }
 
+// detectWorkloadIdentity enables the workload identity authentication when the config file
+// sets neither an authentication method nor key credentials and ExternalDNS runs in a
+// Kubernetes pod, which then authenticates with the OKE workload identity of its service account.
+func detectWorkloadIdentity(authConfig *OCIAuthConfig) {
+	if authConfig.UseInstancePrincipal || authConfig.UseWorkloadIdentity || authConfig.UserID != "" || authConfig.PrivateKey != "" {
+		return
+	}
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" {
+		return
+	}
+	log.Info("No OCI key credentials configured, using OKE workload identity authentication")
+	authConfig.UseWorkloadIdentity = true
+}
+
 // loadOCIConfig reads and parses the OCI ExternalDNS config file at the given path.
 func loadOCIConfig(path string) (*OCIConfig, error) {
 	contents, err := os.ReadFile(path)
@@ -122,14 +157,22 @@ func newProvider(cfg OCIConfig, domainFilter *endpoint.DomainFilter, zoneIDFilte
 	}
 	switch {
 	case cfg.Auth.UseWorkloadIdentity:
+		// The region may also be set in the environment of the pod, e.g. with --oci-auth-workload-identity.
+		region := cfg.Auth.Region
+		if region == "" {
+			region = os.Getenv(auth.ResourcePrincipalRegionEnvVar)
+		}
+		if region == "" {
+			return nil, fmt.Errorf("workload identity authentication requires a region, set in the OCI config file or in the %s environment variable", auth.ResourcePrincipalRegionEnvVar)
+		}
 		// OCI SDK requires specific, dynamic environment variables for workload identity.
 		if err := os.Setenv(auth.ResourcePrincipalVersionEnvVar, auth.ResourcePrincipalVersion2_2); err != nil {
 			return nil, fmt.Errorf("unable to set OCI SDK environment variable: %s: %w", auth.ResourcePrincipalVersionEnvVar, err)
 		}
-		if err := os.Setenv(auth.ResourcePrincipalRegionEnvVar, cfg.Auth.Region); err != nil {
+		if err := os.Setenv(auth.ResourcePrincipalRegionEnvVar, region); err != nil {
 			return nil, fmt.Errorf("unable to set OCI SDK environment variable: %s: %w", auth.ResourcePrincipalRegionEnvVar, err)
 		}
-		configProvider, err = auth.OkeWorkloadIdentityConfigurationProvider()
+		configProvider, err = workloadIdentityConfigurationProvider()
 		if err != nil {
 			return nil, fmt.Errorf("error creating OCI workload identity config provider: %w", err)
 		}
@@ -275,6 +318,11 @@ func (p *OCIProvider) newFilteredRecordOperations(endpoints []*endpoint.Endpoint
 	return ops
 }
 
+// SupportedRecordTypes returns the record types managed with OCI DNS.
+func (p *OCIProvider) SupportedRecordTypes() []string {
+	return provider.DefaultSupportedRecordTypes()
+}
+
 // Records returns the list of records in a given hosted zone.
 func (p *OCIProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.zones(ctx)
@@ -282,41 +330,53 @@ func (p *OCIProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error)
 		return nil, provider.NewSoftErrorf("getting zones: %w", err)
 	}
 
+	// list the zones in a stable order, whatever the order of the map
+	summaries := make([]dns.ZoneSummary, 0, len(zones))
+	for _, id := range slices.Sorted(maps.Keys(zones)) {
+		summaries = append(summaries, zones[id])
+	}
+	endpoints, err := provider.ZoneRecords(ctx, summaries, p.cfg.ZoneConcurrency, p.zoneRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints = mergeEndpointsMultiTargets(endpoints)
+
+	return endpoints, nil
+}
+
+// zoneRecords returns the records of zone.
+func (p *OCIProvider) zoneRecords(ctx context.Context, zone dns.ZoneSummary) ([]*endpoint.Endpoint, error) {
 	var endpoints []*endpoint.Endpoint
-	for _, zone := range zones {
-		var page *string
-		for {
-			resp, err := p.client.GetZoneRecords(ctx, dns.GetZoneRecordsRequest{
-				ZoneNameOrId:  zone.Id,
-				Page:          page,
-				CompartmentId: &p.cfg.CompartmentID,
-			})
-			if err != nil {
-				return nil, provider.NewSoftErrorf("getting records for zone %q: %w", *zone.Id, err)
-			}
+	var page *string
+	for {
+		resp, err := p.client.GetZoneRecords(ctx, dns.GetZoneRecordsRequest{
+			ZoneNameOrId:  zone.Id,
+			Page:          page,
+			CompartmentId: &p.cfg.CompartmentID,
+		})
+		if err != nil {
+			return nil, provider.NewSoftErrorf("getting records for zone %q: %w", *zone.Id, err)
+		}
 
-			for _, record := range resp.Items {
-				if !provider.SupportedRecordType(*record.Rtype) {
-					continue
-				}
-				endpoints = append(endpoints,
-					endpoint.NewEndpointWithTTL(
-						*record.Domain,
-						*record.Rtype,
-						endpoint.TTL(*record.Ttl),
-						*record.Rdata,
-					),
-				)
+		for _, record := range resp.Items {
+			if !provider.SupportedRecordType(*record.Rtype) {
+				continue
 			}
+			endpoints = append(endpoints,
+				endpoint.NewEndpointWithTTL(
+					*record.Domain,
+					*record.Rtype,
+					endpoint.TTL(*record.Ttl),
+					*record.Rdata,
+				),
+			)
+		}
 
-			if page = resp.OpcNextPage; resp.OpcNextPage == nil {
-				break
-			}
+		if page = resp.OpcNextPage; resp.OpcNextPage == nil {
+			break
 		}
 	}
-
-	endpoints = mergeEndpointsMultiTargets(endpoints)
-
 	return endpoints, nil
 }
 
@@ -154,6 +154,11 @@ func newProvider(
 	}, nil
 }
 
+// SupportedRecordTypes returns the record types managed with OVH.
+func (p *OVHProvider) SupportedRecordTypes() []string {
+	return provider.DefaultSupportedRecordTypes()
+}
+
 // Records returns the list of records in all relevant zones.
 func (p *OVHProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, records, err := p.zonesRecords(ctx)
@@ -17,16 +17,19 @@ limitations under the License.
 package pdns
 
 import (
+	"cmp"
 	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math"
 	"net"
 	"net/http"
 	"net/url"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,9 +37,11 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/credentials"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/tlsutils"
+	"sigs.k8s.io/external-dns/pkg/txt"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -75,6 +80,10 @@ type PDNSConfig struct {
 	ServerID     string
 	APIKey       string
 	TLSConfig    TLSConfig
+	// IncreaseSOASerial increments the SOA serial of the patched Native zones, see increaseSOASerial
+	IncreaseSOASerial bool
+	// ZoneConcurrency is the number of zones whose records are listed in parallel
+	ZoneConcurrency int
 }
 
 // TLSConfig is comprised of the TLS-related fields necessary to create a new PDNSProvider
@@ -153,19 +162,15 @@ type PDNSAPIClient struct {
 // ref: https://doc.powerdns.com/authoritative/http-api/zone.html#get--servers-server_id-zones
 func (c *PDNSAPIClient) ListZones() ([]pgo.Zone, error) {
 	var zones []pgo.Zone
-	var err error
-	for i := range retryLimit {
+	err := retry("ListZones", func() error {
+		var err error
 		zones, err = c.client.Zones.List(c.authCtx)
-		if err != nil {
-			log.Debugf("Unable to fetch zones %v", err)
-			log.Debugf("Retrying ListZones() ... %d", i)
-			time.Sleep(retryAfterTime * (1 << uint(i)))
-			continue
-		}
-		return zones, err
+		return err
+	})
+	if err != nil {
+		return zones, fmt.Errorf("unable to list zones: %w", err)
 	}
-
-	return zones, provider.NewSoftErrorf("unable to list zones: %v", err)
+	return zones, nil
 }
 
 // partitionZones returns a slice of zones that adhere to the domain filter and a slice of ones that do not adhere to the filter.
@@ -189,44 +194,79 @@ func partitionZones(zones []pgo.Zone, domainFilter *endpoint.DomainFilter) ([]pg
 // ListZone : Method returns the details of a specific zone from PowerDNS
 // ref: https://doc.powerdns.com/authoritative/http-api/zone.html#get--servers-server_id-zones-zone_id
 func (c *PDNSAPIClient) ListZone(zoneID string) (*pgo.Zone, error) {
-	for i := range retryLimit {
-		zone, err := c.client.Zones.Get(c.authCtx, zoneID)
-		if err != nil {
-			log.Debugf("Unable to fetch zone %v", err)
-			log.Debugf("Retrying ListZone() ... %d", i)
-			time.Sleep(retryAfterTime * (1 << uint(i)))
-			continue
-		}
-		return zone, err
+	var zone *pgo.Zone
+	err := retry("ListZone", func() error {
+		var err error
+		zone, err = c.client.Zones.Get(c.authCtx, zoneID)
+		return err
+	})
+	if err != nil {
+		return &pgo.Zone{}, fmt.Errorf("unable to list zone: %w", err)
 	}
-
-	return &pgo.Zone{}, provider.NewSoftErrorf("unable to list zone")
+	return zone, nil
 }
 
 // PatchZone : Method used to update the contents of a particular zone from PowerDNS
 // ref: https://doc.powerdns.com/authoritative/http-api/zone.html#patch--servers-server_id-zones-zone_id
 func (c *PDNSAPIClient) PatchZone(zoneID string, zoneStruct *pgo.Zone) error {
 	rrSets := &pgo.RRsets{Sets: zoneStruct.RRsets}
+	err := retry("PatchZone", func() error {
+		return c.client.Records.Patch(c.authCtx, zoneID, rrSets)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to patch zone: %w", err)
+	}
+	return nil
+}
+
+// retry calls fn until it succeeds, fails permanently or has been called retryLimit
+// times, backing off exponentially. The error is classified as soft when the last
+// failure is transient, see classifyError, or when PowerDNS rejected the request,
+// see rejectedError.
+func retry(operation string, fn func() error) error {
 	var err error
 	for i := range retryLimit {
-		err = c.client.Records.Patch(c.authCtx, zoneID, rrSets)
-		if err != nil {
-			log.Debugf("Unable to patch zone %v", err)
-			log.Debugf("Retrying PatchZone() ... %d", i)
-			time.Sleep(retryAfterTime * (1 << uint(i)))
-			continue
+		if err = classifyError(fn()); err == nil || !errors.Is(err, provider.SoftError) {
+			return rejectedError(err)
 		}
-		return err
+		log.Debugf("Unable to %s: %v", operation, err)
+		log.Debugf("Retrying %s() ... %d", operation, i)
+		time.Sleep(retryAfterTime * (1 << uint(i)))
 	}
+	return err
+}
 
-	return provider.NewSoftErrorf("unable to patch zone: %v", err)
+// classifyError classifies the errors of the PowerDNS API by their HTTP status code.
+func classifyError(err error) error {
+	var apiErr *pgo.Error
+	if errors.As(err, &apiErr) {
+		return provider.ClassifyHTTPError(err, apiErr.StatusCode, nil)
+	}
+	return provider.ClassifyError(err)
+}
+
+// rejectedError classifies the client errors of the PowerDNS API, e.g. 422 Unprocessable
+// Entity for an invalid record, as soft: retrying the request does not help, but failing
+// hard would restart the controller over and over on a single invalid record. The
+// authentication and authorization failures stay hard.
+func rejectedError(err error) error {
+	var apiErr *pgo.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode < http.StatusBadRequest || apiErr.StatusCode >= http.StatusInternalServerError {
+		return err
+	}
+	if apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden {
+		return err
+	}
+	return provider.NewSoftError(err)
 }
 
 // PDNSProvider is an implementation of the Provider interface for PowerDNS
 type PDNSProvider struct {
 	provider.BaseProvider
-	client       PDNSAPIProvider
-	domainFilter *endpoint.DomainFilter
+	client            PDNSAPIProvider
+	domainFilter      *endpoint.DomainFilter
+	increaseSOASerial bool
+	zoneConcurrency   int
 }
 
 // New creates a PowerDNS provider from the given configuration.
@@ -236,7 +276,7 @@ func New(ctx context.Context, cfg *externaldns.Config, domainFilter *endpoint.Do
 		PDNSConfig{
 			DomainFilter: domainFilter,
 			DryRun:       cfg.DryRun,
-			Server:       cfg.PDNSServer,
+			Server:       cmp.Or(cfg.ProviderEndpoint, cfg.PDNSServer),
 			ServerID:     cfg.PDNSServerID,
 			APIKey:       cfg.PDNSAPIKey,
 			TLSConfig: TLSConfig{
@@ -245,6 +285,8 @@ func New(ctx context.Context, cfg *externaldns.Config, domainFilter *endpoint.Do
 				ClientCertFilePath:    cfg.TLSClientCert,
 				ClientCertKeyFilePath: cfg.TLSClientCertKey,
 			},
+			IncreaseSOASerial: cfg.PDNSIncreaseSOASerial,
+			ZoneConcurrency:   cfg.ProviderZoneConcurrency,
 		},
 	)
 }
@@ -280,13 +322,22 @@ func newProvider(ctx context.Context, config PDNSConfig) (*PDNSProvider, error)
 		httpClient.Transport = &pathPrefixRoundTripper{prefix: prefix, next: httpClient.Transport}
 	}
 
+	// an API key read from a file is reloaded when the file changes, e.g. on Secret rotation
+	apiKey, err := credentials.Resolve(ctx, config.APIKey)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Transport = credentials.NewHeaderRoundTripper(httpClient.Transport, "X-API-Key", "", apiKey)
+
 	provider := &PDNSProvider{
 		client: &PDNSAPIClient{
 			dryRun:  config.DryRun,
 			authCtx: ctx,
-			client:  pgo.New(config.Server, config.ServerID, pgo.WithAPIKey(config.APIKey), pgo.WithHTTPClient(httpClient)),
+			client:  pgo.New(config.Server, config.ServerID, pgo.WithAPIKey(apiKey.Get()), pgo.WithHTTPClient(httpClient)),
 		},
-		domainFilter: config.DomainFilter,
+		domainFilter:      config.DomainFilter,
+		increaseSOASerial: config.IncreaseSOASerial,
+		zoneConcurrency:   config.ZoneConcurrency,
 	}
 	return provider, nil
 }
@@ -342,6 +393,11 @@ func (p *PDNSProvider) convertRRSetToEndpoints(rr pgo.RRset) []*endpoint.Endpoin
 			targets = append(targets, pgo.StringValue(record.Content))
 		}
 	}
+	if rrType == endpoint.RecordTypeTXT {
+		for i, target := range targets {
+			targets[i] = txt.Normalize(target)
+		}
+	}
 	if rrType == string(pgo.RRTypeALIAS) {
 		rrType = endpoint.RecordTypeCNAME
 	}
@@ -391,10 +447,7 @@ func (p *PDNSProvider) ConvertEndpointsToZones(eps []*endpoint.Endpoint, changet
 				records := []pgo.Record{}
 				recordType := ep.RecordType
 				for _, t := range ep.Targets {
-					if slices.Contains(trailingTypes, ep.RecordType) {
-						t = provider.EnsureTrailingDot(t)
-					}
-					records = append(records, pgo.Record{Content: new(t), Disabled: new(false)})
+					records = append(records, pgo.Record{Content: new(recordContent(ep.RecordType, t)), Disabled: new(false)})
 				}
 
 				// Check if we should use ALIAS instead of CNAME:
@@ -470,6 +523,29 @@ func (p *PDNSProvider) ConvertEndpointsToZones(eps []*endpoint.Endpoint, changet
 	return zoneList, nil
 }
 
+// recordContent returns target formatted as the content of a PowerDNS record of the
+// given type. The hosts of MX and SRV targets are fully qualified, TXT targets are
+// quoted and split in strings of at most 255 bytes and CAA values are quoted.
+func recordContent(recordType, target string) string {
+	switch recordType {
+	case endpoint.RecordTypeTXT:
+		return txt.Normalize(target)
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		if st, err := endpoint.ParseTarget(recordType, target); err == nil {
+			st.Host = provider.EnsureTrailingDot(st.Host)
+			return st.String()
+		}
+	case endpoint.RecordTypeCAA:
+		if caa, err := endpoint.NewCAARecord(target); err == nil {
+			return caa.String()
+		}
+	}
+	if slices.Contains(trailingTypes, recordType) {
+		return provider.EnsureTrailingDot(target)
+	}
+	return target
+}
+
 // mutateRecords takes a list of endpoints and creates, replaces or deletes them based on the changetype
 func (p *PDNSProvider) mutateRecords(endpoints []*endpoint.Endpoint, changetype pdnsChangeType) error {
 	zonelist, err := p.ConvertEndpointsToZones(endpoints, changetype)
@@ -486,28 +562,94 @@ func (p *PDNSProvider) mutateRecords(endpoints []*endpoint.Endpoint, changetype
 		if err := p.client.PatchZone(pgo.StringValue(zone.ID), &zone); err != nil {
 			return err
 		}
+		if p.increaseSOASerial && zone.Kind != nil && *zone.Kind == pgo.NativeZoneKind {
+			if err := p.increaseSOASerialOf(pgo.StringValue(zone.ID)); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
 
+// increaseSOASerialOf increments the SOA serial of a Native zone after it was patched, so
+// that the secondaries transferring the zone, e.g. with AXFR, pick up the changes. PowerDNS
+// already increments the serial of the zones with a SOA-EDIT-API setting, these are left alone.
+func (p *PDNSProvider) increaseSOASerialOf(zoneID string) error {
+	zone, err := p.client.ListZone(zoneID)
+	if err != nil {
+		return err
+	}
+	if soaEditAPI := pgo.StringValue(zone.SOAEditAPI); soaEditAPI != "" && !strings.EqualFold(soaEditAPI, "OFF") {
+		log.Debugf("Not increasing the SOA serial of zone %s, PowerDNS increases it with SOA-EDIT-API %s", zoneID, soaEditAPI)
+		return nil
+	}
+	for _, rr := range zone.RRsets {
+		if rr.Type == nil || *rr.Type != pgo.RRTypeSOA || len(rr.Records) == 0 {
+			continue
+		}
+		content, err := nextSOASerial(pgo.StringValue(rr.Records[0].Content))
+		if err != nil {
+			return fmt.Errorf("unable to increase the SOA serial of zone %s: %w", zoneID, err)
+		}
+		log.Debugf("Increasing the SOA serial of zone %s: %s", zoneID, content)
+		return p.client.PatchZone(zoneID, &pgo.Zone{RRsets: []pgo.RRset{{
+			Name:       rr.Name,
+			Type:       rr.Type,
+			TTL:        rr.TTL,
+			ChangeType: new(pgo.ChangeType(PdnsReplace)),
+			Records:    []pgo.Record{{Content: new(content), Disabled: new(false)}},
+		}}})
+	}
+	return fmt.Errorf("unable to increase the SOA serial of zone %s: no SOA record", zoneID)
+}
+
+// nextSOASerial returns the content of a SOA record with its serial incremented, wrapping
+// around as per RFC 1982.
+func nextSOASerial(content string) (string, error) {
+	fields := strings.Fields(content)
+	if len(fields) != 7 {
+		return "", fmt.Errorf("invalid SOA record %q", content)
+	}
+	serial, err := strconv.ParseUint(fields[2], 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid SOA serial %q: %w", fields[2], err)
+	}
+	fields[2] = strconv.FormatUint(uint64(uint32(serial)+1), 10)
+	return strings.Join(fields, " "), nil
+}
+
+// SupportedRecordTypes returns the record types managed with PowerDNS.
+func (p *PDNSProvider) SupportedRecordTypes() []string {
+	return slices.Clone(endpoint.KnownRecordTypes)
+}
+
+// DefaultManagedRecordTypes adds CAA to the default managed record types; their
+// values are quoted by recordContent as PowerDNS expects.
+func (p *PDNSProvider) DefaultManagedRecordTypes() []string {
+	return append(provider.DefaultManagedRecordTypes(p.SupportedRecordTypes()), endpoint.RecordTypeCAA)
+}
+
 // Records returns all DNS records controlled by the configured PDNS server (for all zones)
-func (p *PDNSProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (p *PDNSProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	filteredZones, _, err := p.filteredZones()
 	if err != nil {
 		return nil, err
 	}
 
-	var endpoints []*endpoint.Endpoint
-
-	for _, zone := range filteredZones {
+	endpoints, err := provider.ZoneRecords(ctx, filteredZones, p.zoneConcurrency, func(_ context.Context, zone pgo.Zone) ([]*endpoint.Endpoint, error) {
 		z, err := p.client.ListZone(pgo.StringValue(zone.ID))
 		if err != nil {
-			return nil, provider.NewSoftErrorf("unable to fetch records: %v", err)
+			return nil, fmt.Errorf("unable to fetch records: %w", err)
 		}
 
+		var endpoints []*endpoint.Endpoint
 		for _, rr := range z.RRsets {
 			endpoints = append(endpoints, p.convertRRSetToEndpoints(rr)...)
 		}
+		return endpoints, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	log.Debugf("Records fetched:\n%+v", endpoints)
@@ -516,15 +658,23 @@ func (p *PDNSProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error)
 
 // AdjustEndpoints performs checks on the provided endpoints and will skip any potentially failing changes.
 func (p *PDNSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
-	var validEndpoints []*endpoint.Endpoint
-	for i := range endpoints {
-		if !endpoints[i].CheckEndpoint() {
-			log.Warnf("Ignoring Endpoint because of invalid %v record formatting: {Target: '%v'}", endpoints[i].RecordType, endpoints[i].Targets)
-			continue
+	return provider.EndpointAdjuster(validEndpoint).AdjustEndpoints(endpoints)
+}
+
+// validEndpoint drops ep when its targets are not formatted as required by its record type.
+// TXT and CAA targets are converted to the form they are read back in.
+func validEndpoint(ep *endpoint.Endpoint) []*endpoint.Endpoint {
+	if !ep.CheckEndpoint() {
+		log.Warnf("Ignoring Endpoint because of invalid %v record formatting: {Target: '%v'}", ep.RecordType, ep.Targets)
+		return nil
+	}
+	txt.NormalizeEndpoint(ep)
+	if ep.RecordType == endpoint.RecordTypeCAA {
+		for i, target := range ep.Targets {
+			ep.Targets[i] = recordContent(endpoint.RecordTypeCAA, target)
 		}
-		validEndpoints = append(validEndpoints, endpoints[i])
 	}
-	return validEndpoints, nil
+	return []*endpoint.Endpoint{ep}
 }
 
 // ApplyChanges takes a list of changes (endpoints) and updates the PDNS server
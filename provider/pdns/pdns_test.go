@@ -18,16 +18,21 @@ package pdns
 
 import (
 	"context"
+	"net/http"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	pgo "github.com/joeig/go-powerdns/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/sets"
+	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/provider"
 )
 
@@ -101,7 +106,7 @@ var (
 		Type: pgo.RRTypePtr(pgo.RRTypeTXT),
 		TTL:  pgo.Uint32(300),
 		Records: []pgo.Record{
-			{Content: new("'would smell as sweet'"), Disabled: new(false)},
+			{Content: new("\"would smell as sweet\""), Disabled: new(false)},
 		},
 	}
 
@@ -201,7 +206,7 @@ var (
 
 	endpointsMixedRecords = []*endpoint.Endpoint{
 		endpoint.NewEndpointWithTTL("cname.example.com", endpoint.RecordTypeCNAME, endpoint.TTL(300), "example.com"),
-		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeTXT, endpoint.TTL(300), "'would smell as sweet'"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeTXT, endpoint.TTL(300), "\"would smell as sweet\""),
 		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, endpoint.TTL(300), "8.8.8.8", "8.8.4.4", "4.4.4.4"),
 		endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, endpoint.TTL(300), "example.by.any.other.name.com"),
 		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, endpoint.TTL(300), "10 mailhost1.example.com", "10 mailhost2.example.com"),
@@ -601,6 +606,28 @@ func (c *PDNSAPIClientStub) PatchZone(_ string, _ *pgo.Zone) error {
 	return nil
 }
 
+/******************************************************************************/
+// API that returns several zones with an A record each, the first zones slower to list
+type PDNSAPIClientStubManyZones struct {
+	PDNSAPIClientStub
+	names []string
+}
+
+func (c *PDNSAPIClientStubManyZones) ListZones() ([]pgo.Zone, error) {
+	zones := make([]pgo.Zone, 0, len(c.names))
+	for _, name := range c.names {
+		zones = append(zones, pgo.Zone{ID: new(name), Name: new(name), Kind: pgo.ZoneKindPtr(pgo.NativeZoneKind)})
+	}
+	return zones, nil
+}
+
+func (c *PDNSAPIClientStubManyZones) ListZone(zoneID string) (*pgo.Zone, error) {
+	time.Sleep(time.Duration(len(c.names)-slices.Index(c.names, zoneID)) * time.Millisecond)
+	rr := RRSetSimpleARecord
+	rr.Name = new(zoneID)
+	return &pgo.Zone{ID: new(zoneID), Name: new(zoneID), RRsets: []pgo.RRset{rr}}, nil
+}
+
 /******************************************************************************/
 // API that returns a zones with no records
 type PDNSAPIClientStubEmptyZones struct {
@@ -712,6 +739,25 @@ func (c *PDNSAPIClientStubConfigurable) PatchZone(_ string, _ *pgo.Zone) error {
 	return nil
 }
 
+/******************************************************************************/
+// API that returns a zone with a SOA record, and keeps track of the patches
+type PDNSAPIClientStubSOA struct {
+	PDNSAPIClientStubEmptyZones
+	soaEditAPI string
+}
+
+func (c *PDNSAPIClientStubSOA) ListZone(_ string) (*pgo.Zone, error) {
+	zone := ZoneEmpty
+	zone.SOAEditAPI = new(c.soaEditAPI)
+	zone.RRsets = []pgo.RRset{{
+		Name:    new("example.com."),
+		Type:    pgo.RRTypePtr(pgo.RRTypeSOA),
+		TTL:     pgo.Uint32(3600),
+		Records: []pgo.Record{{Content: new("ns1.example.com. hostmaster.example.com. 2026101801 10800 3600 604800 3600")}},
+	}}
+	return &zone, nil
+}
+
 /******************************************************************************/
 
 type NewPDNSProviderTestSuite struct {
@@ -846,6 +892,19 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSRRSetToEndpoints() {
 	*/
 	eps = p.convertRRSetToEndpoints(RRSetDisabledRecord)
 	suite.Equal(endpointsDisabledRecord, eps)
+
+	/* Given a TXT RRSet with a value split by hand, we test:
+	   - We read it back in the same form as the value is written in
+	*/
+	eps = p.convertRRSetToEndpoints(pgo.RRset{
+		Name:    new("example.com."),
+		Type:    pgo.RRTypePtr(pgo.RRTypeTXT),
+		TTL:     pgo.Uint32(300),
+		Records: []pgo.Record{{Content: new(`"v=DKIM1; p=MIIB" "IjANBg"`), Disabled: new(false)}},
+	})
+	suite.Equal([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com.", endpoint.RecordTypeTXT, endpoint.TTL(300), `"v=DKIM1; p=MIIBIjANBg"`),
+	}, eps)
 }
 
 func (suite *NewPDNSProviderTestSuite) TestPDNSRecords() {
@@ -881,6 +940,24 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSRecords() {
 	suite.ErrorIs(err, provider.SoftError)
 }
 
+func (suite *NewPDNSProviderTestSuite) TestPDNSRecordsZoneConcurrency() {
+	client := &PDNSAPIClientStubManyZones{names: []string{"a.com.", "b.com.", "c.com.", "d.com."}}
+	expected := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("a.com", endpoint.RecordTypeA, endpoint.TTL(300), "8.8.8.8"),
+		endpoint.NewEndpointWithTTL("b.com", endpoint.RecordTypeA, endpoint.TTL(300), "8.8.8.8"),
+		endpoint.NewEndpointWithTTL("c.com", endpoint.RecordTypeA, endpoint.TTL(300), "8.8.8.8"),
+		endpoint.NewEndpointWithTTL("d.com", endpoint.RecordTypeA, endpoint.TTL(300), "8.8.8.8"),
+	}
+
+	// the records keep the order of the zones whatever the concurrency
+	for _, concurrency := range []int{1, 4} {
+		p := &PDNSProvider{client: client, zoneConcurrency: concurrency}
+		eps, err := p.Records(context.Background())
+		suite.Require().NoError(err)
+		suite.Equal(expected, eps, "concurrency %d", concurrency)
+	}
+}
+
 func (suite *NewPDNSProviderTestSuite) TestPDNSConvertEndpointsToZones() {
 	// Function definition: ConvertEndpointsToZones(endpoints []*endpoint.Endpoint, changetype pdnsChangeType) (zonelist []pgo.Zone, _ error)
 
@@ -1043,6 +1120,46 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSmutateRecords() {
 	suite.ErrorIs(err, provider.SoftError)
 }
 
+func (suite *NewPDNSProviderTestSuite) TestPDNSmutateRecordsIncreaseSOASerial() {
+	soaPatch := pgo.Zone{RRsets: []pgo.RRset{{
+		Name:       new("example.com."),
+		Type:       pgo.RRTypePtr(pgo.RRTypeSOA),
+		TTL:        pgo.Uint32(3600),
+		ChangeType: pgo.ChangeTypePtr(pgo.ChangeTypeReplace),
+		Records:    []pgo.Record{{Content: new("ns1.example.com. hostmaster.example.com. 2026101802 10800 3600 604800 3600"), Disabled: new(false)}},
+	}}}
+
+	// The serial is increased after the records are patched
+	c := &PDNSAPIClientStubSOA{}
+	p := &PDNSProvider{client: c, increaseSOASerial: true}
+	suite.Require().NoError(p.mutateRecords(endpointsSimpleRecord, PdnsReplace))
+	suite.Equal([]pgo.Zone{ZoneEmptyToSimplePatch, soaPatch}, c.patchedZones)
+
+	// PowerDNS increases the serial of the zones with SOA-EDIT-API
+	c = &PDNSAPIClientStubSOA{soaEditAPI: "DEFAULT"}
+	p = &PDNSProvider{client: c, increaseSOASerial: true}
+	suite.Require().NoError(p.mutateRecords(endpointsSimpleRecord, PdnsReplace))
+	suite.Equal([]pgo.Zone{ZoneEmptyToSimplePatch}, c.patchedZones)
+
+	// The serial is left alone unless enabled
+	c = &PDNSAPIClientStubSOA{}
+	p = &PDNSProvider{client: c}
+	suite.Require().NoError(p.mutateRecords(endpointsSimpleRecord, PdnsReplace))
+	suite.Equal([]pgo.Zone{ZoneEmptyToSimplePatch}, c.patchedZones)
+}
+
+func (suite *NewPDNSProviderTestSuite) TestPDNSNextSOASerial() {
+	content, err := nextSOASerial("ns1.example.com. hostmaster.example.com. 4294967295 10800 3600 604800 3600")
+	suite.Require().NoError(err)
+	suite.Equal("ns1.example.com. hostmaster.example.com. 0 10800 3600 604800 3600", content)
+
+	_, err = nextSOASerial("ns1.example.com. hostmaster.example.com. serial 10800 3600 604800 3600")
+	suite.Error(err)
+
+	_, err = nextSOASerial("ns1.example.com.")
+	suite.Error(err)
+}
+
 func (suite *NewPDNSProviderTestSuite) TestPDNSClientPartitionZones() {
 	zoneList := []pgo.Zone{
 		ZoneEmpty,
@@ -1114,7 +1231,7 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSAdjustEndpoints() {
 		{
 			description: "Invalid MX endpoint with too many arguments is removed",
 			endpoints:   endpointsMXRecordInvalidFormatTooManyArgs,
-			expected:    []*endpoint.Endpoint([]*endpoint.Endpoint(nil)),
+			expected:    []*endpoint.Endpoint{},
 		},
 		{
 			description: "Invalid MX endpoint is removed among valid endpoints",
@@ -1126,11 +1243,37 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSAdjustEndpoints() {
 		{
 			description: "Multiple invalid MX endpoints are removed",
 			endpoints:   endpointsMultipleInvalidMXRecords,
-			expected:    []*endpoint.Endpoint([]*endpoint.Endpoint(nil)),
+			expected:    []*endpoint.Endpoint{},
+		},
+		{
+			description: "TXT targets are quoted",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeTXT, endpoint.TTL(300), "v=spf1 -all", `"v=DKIM1; p=MIIB" "IjANBg"`),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeTXT, endpoint.TTL(300), `"v=spf1 -all"`, `"v=DKIM1; p=MIIBIjANBg"`),
+			},
+		},
+		{
+			description: "CAA values are quoted and tags lowercased",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeCAA, endpoint.TTL(300), "0 ISSUE letsencrypt.org"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeCAA, endpoint.TTL(300), `0 issue "letsencrypt.org"`),
+			},
+		},
+		{
+			description: "Invalid CAA endpoint is removed",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeCAA, endpoint.TTL(300), "issue letsencrypt.org"),
+			},
+			expected: []*endpoint.Endpoint{},
 		},
 	}
 
 	for _, tt := range tests {
+		testutils.TestHelperAdjustEndpointsContract(suite.T(), p.AdjustEndpoints, tt.endpoints)
 		actual, err := p.AdjustEndpoints(tt.endpoints)
 		suite.NoError(err)
 		suite.Equal(tt.expected, actual)
@@ -1366,3 +1509,93 @@ func TestPDNSPartitionZonesRegexBehavior(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		errs      []error
+		calls     int
+		expectErr bool
+		soft      bool
+	}{
+		{
+			name:  "transient failure is retried",
+			errs:  []error{&pgo.Error{StatusCode: http.StatusServiceUnavailable}, nil},
+			calls: 2,
+		},
+		{
+			name:      "rejected request is not retried",
+			errs:      []error{&pgo.Error{StatusCode: http.StatusUnprocessableEntity, Message: "invalid record"}},
+			calls:     1,
+			expectErr: true,
+			soft:      true,
+		},
+		{
+			name:      "authentication failure is not retried",
+			errs:      []error{&pgo.Error{StatusCode: http.StatusUnauthorized, Message: "unauthorized"}},
+			calls:     1,
+			expectErr: true,
+		},
+		{
+			name:      "authorization failure is not retried",
+			errs:      []error{&pgo.Error{StatusCode: http.StatusForbidden, Message: "forbidden"}},
+			calls:     1,
+			expectErr: true,
+		},
+		{
+			name:      "transient failures exhaust the retries",
+			errs:      []error{context.DeadlineExceeded, context.DeadlineExceeded, context.DeadlineExceeded},
+			calls:     retryLimit,
+			expectErr: true,
+			soft:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := retry("Test", func() error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+			assert.Equal(t, tt.calls, calls)
+			if !tt.expectErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if tt.soft {
+				assert.ErrorIs(t, err, provider.SoftError)
+			} else {
+				assert.NotErrorIs(t, err, provider.SoftError)
+			}
+		})
+	}
+}
+
+func TestRecordContent(t *testing.T) {
+	tests := []struct {
+		recordType string
+		target     string
+		expected   string
+	}{
+		{recordType: endpoint.RecordTypeA, target: "8.8.8.8", expected: "8.8.8.8"},
+		{recordType: endpoint.RecordTypeCNAME, target: "example.com", expected: "example.com."},
+		{recordType: endpoint.RecordTypeMX, target: "10 mail.example.com", expected: "10 mail.example.com."},
+		{recordType: endpoint.RecordTypeMX, target: " 10   mail.example.com. ", expected: "10 mail.example.com."},
+		{recordType: endpoint.RecordTypeSRV, target: "10 5 5060 sip.example.com", expected: "10 5 5060 sip.example.com."},
+		{recordType: endpoint.RecordTypeSRV, target: "10  5 5060 sip.example.com.", expected: "10 5 5060 sip.example.com."},
+		// unparsable targets only get a trailing dot, like other record types
+		{recordType: endpoint.RecordTypeMX, target: "mail.example.com", expected: "mail.example.com."},
+		{recordType: endpoint.RecordTypeTXT, target: "v=spf1 -all", expected: `"v=spf1 -all"`},
+		{recordType: endpoint.RecordTypeTXT, target: `"heritage=external-dns,external-dns/owner=tower-pdns"`, expected: `"heritage=external-dns,external-dns/owner=tower-pdns"`},
+		{recordType: endpoint.RecordTypeCAA, target: `0 issue "letsencrypt.org"`, expected: `0 issue "letsencrypt.org"`},
+		{recordType: endpoint.RecordTypeCAA, target: "128 ISSUEWILD letsencrypt.org", expected: `128 issuewild "letsencrypt.org"`},
+		{recordType: endpoint.RecordTypeTXT, target: strings.Repeat("a", 300), expected: `"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 45) + `"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.recordType+" "+tt.target, func(t *testing.T) {
+			assert.Equal(t, tt.expected, recordContent(tt.recordType, tt.target))
+		})
+	}
+}
@@ -80,6 +80,11 @@ func newProvider(cfg PiholeConfig) (*PiholeProvider, error) {
 	return &PiholeProvider{api: api}, nil
 }
 
+// SupportedRecordTypes returns the record types managed with Pi-hole.
+func (p *PiholeProvider) SupportedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}
+}
+
 // Records implements Provider, populating a slice of endpoints from
 // Pi-Hole local DNS.
 func (p *PiholeProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
@@ -53,9 +53,32 @@ type Provider interface {
 	// adding, removing, and modifying the ProviderSpecific properties to match
 	// the endpoints that the provider returns in `Records` so that the change plan will not have
 	// unnecessary (potentially failing) changes. It may also modify other fields, add, or remove
-	// Endpoints. It is permitted to modify the supplied endpoints.
+	// Endpoints.
+	//
+	// AdjustEndpoints must not modify the supplied endpoints or slice: it returns adjusted
+	// copies, and adjusting its result again must not change it. EndpointAdjuster implements
+	// this contract on top of a function adjusting a single endpoint.
 	AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error)
 	GetDomainFilter() endpoint.DomainFilterInterface
+	// SupportedRecordTypes returns the DNS record types the provider can manage.
+	// Records of other types are excluded from the plan instead of failing when
+	// they are applied. A nil result means that any record type is supported.
+	SupportedRecordTypes() []string
+}
+
+// EndpointNormalizer is implemented by providers returning records in a
+// representation the sources do not produce, e.g. with their own casing of
+// the targets. NormalizeEndpoint canonicalizes a copy of a current or desired
+// record before the plan compares them; it may modify the endpoint in place.
+type EndpointNormalizer interface {
+	NormalizeEndpoint(ep *endpoint.Endpoint)
+}
+
+// ManagedRecordTypesDefaulter is implemented by providers managing other record
+// types than DefaultManagedRecordTypes when --managed-record-types is not set,
+// e.g. the ones they support end to end.
+type ManagedRecordTypesDefaulter interface {
+	DefaultManagedRecordTypes() []string
 }
 
 type BaseProvider struct{}
@@ -72,6 +95,12 @@ func (b BaseProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return &endpoint.DomainFilter{}
 }
 
+// SupportedRecordTypes returns the conservative default set of record types.
+// Providers supporting other record types should override this method.
+func (b BaseProvider) SupportedRecordTypes() []string {
+	return DefaultSupportedRecordTypes()
+}
+
 type contextKey struct {
 	name string
 }
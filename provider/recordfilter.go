@@ -16,13 +16,53 @@ limitations under the License.
 
 package provider
 
+import (
+	"slices"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// defaultSupportedRecordTypes are the record types supported by most providers.
+var defaultSupportedRecordTypes = []string{
+	endpoint.RecordTypeA,
+	endpoint.RecordTypeAAAA,
+	endpoint.RecordTypeCNAME,
+	endpoint.RecordTypeSRV,
+	endpoint.RecordTypeTXT,
+	endpoint.RecordTypeNS,
+}
+
+// defaultManagedRecordTypes are the record types managed when --managed-record-types is not set.
+var defaultManagedRecordTypes = []string{
+	endpoint.RecordTypeA,
+	endpoint.RecordTypeAAAA,
+	endpoint.RecordTypeCNAME,
+}
+
+// DefaultSupportedRecordTypes returns the record types supported by most
+// providers: A, AAAA, CNAME, SRV, TXT and NS. Extra types can be appended.
+func DefaultSupportedRecordTypes(extra ...string) []string {
+	return append(slices.Clone(defaultSupportedRecordTypes), extra...)
+}
+
 // SupportedRecordType returns true only for supported record types.
 // Currently A, AAAA, CNAME, SRV, TXT and NS record types are supported.
 func SupportedRecordType(recordType string) bool {
-	switch recordType {
-	case "A", "AAAA", "CNAME", "SRV", "TXT", "NS":
-		return true
-	default:
-		return false
+	return slices.Contains(defaultSupportedRecordTypes, recordType)
+}
+
+// DefaultManagedRecordTypes returns the record types managed by default with a
+// provider supporting the given record types: A, AAAA and CNAME, restricted to
+// the supported ones. Like with Provider.SupportedRecordTypes, nil supports any type.
+func DefaultManagedRecordTypes(supported []string) []string {
+	if supported == nil {
+		return slices.Clone(defaultManagedRecordTypes)
+	}
+	managed := make([]string, 0, len(defaultManagedRecordTypes))
+	for _, recordType := range defaultManagedRecordTypes {
+		if slices.Contains(supported, recordType) {
+			managed = append(managed, recordType)
+		}
 	}
+	return managed
 }
@@ -16,7 +16,10 @@ limitations under the License.
 
 package provider
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestRecordTypeFilter(t *testing.T) {
 	records := []struct {
@@ -52,3 +55,37 @@ func TestRecordTypeFilter(t *testing.T) {
 
 	}
 }
+
+func TestDefaultSupportedRecordTypes(t *testing.T) {
+	got := DefaultSupportedRecordTypes("MX")
+	want := []string{"A", "AAAA", "CNAME", "SRV", "TXT", "NS", "MX"}
+	if !slices.Equal(want, got) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if slices.Contains(DefaultSupportedRecordTypes(), "MX") {
+		t.Error("extra record types must not leak into the default set")
+	}
+	if !slices.Equal(DefaultSupportedRecordTypes(), (&BaseProvider{}).SupportedRecordTypes()) {
+		t.Error("BaseProvider must return the default set")
+	}
+}
+
+func TestDefaultManagedRecordTypes(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		supported []string
+		want      []string
+	}{
+		{name: "any record type", supported: nil, want: []string{"A", "AAAA", "CNAME"}},
+		{name: "default record types", supported: DefaultSupportedRecordTypes(), want: []string{"A", "AAAA", "CNAME"}},
+		{name: "no AAAA", supported: []string{"A", "CNAME", "TXT"}, want: []string{"A", "CNAME"}},
+		{name: "no default record type", supported: []string{"TXT"}, want: []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DefaultManagedRecordTypes(tc.supported)
+			if !slices.Equal(tc.want, got) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
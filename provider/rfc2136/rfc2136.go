@@ -195,6 +195,11 @@ func (r *rfc2136Provider) KeyData(nameserver string) (string, *gss.Client, error
 	return keyName, handle, nil
 }
 
+// SupportedRecordTypes returns the record types managed with the zone transfer.
+func (r *rfc2136Provider) SupportedRecordTypes() []string {
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeNS, endpoint.RecordTypePTR}
+}
+
 // Records returns the list of records.
 func (r *rfc2136Provider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
 	rrs, err := r.List()
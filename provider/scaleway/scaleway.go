@@ -39,6 +39,13 @@ const (
 	scalewayPriorityKey     string = "scw/priority"
 )
 
+// PropertySpecs returns the provider-specific properties understood by the Scaleway provider.
+func PropertySpecs() []endpoint.PropertySpec {
+	return []endpoint.PropertySpec{
+		{Name: scalewayPriorityKey, Type: endpoint.PropertyTypeInt},
+	}
+}
+
 // ScalewayProvider implements the DNS provider for Scaleway DNS
 type ScalewayProvider struct {
 	provider.BaseProvider
@@ -142,6 +149,11 @@ func (p *ScalewayProvider) Zones(ctx context.Context) ([]*domain.DNSZone, error)
 	return res, nil
 }
 
+// SupportedRecordTypes returns the record types managed with Scaleway.
+func (p *ScalewayProvider) SupportedRecordTypes() []string {
+	return provider.DefaultSupportedRecordTypes()
+}
+
 // Records returns the list of records in a given zone.
 func (p *ScalewayProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	endpoints := map[string]*endpoint.Endpoint{}
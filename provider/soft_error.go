@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// rateLimitRemainingHeaders are the headers reporting the requests left in the
+// current rate limit window, as sent by the DNS APIs.
+var rateLimitRemainingHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining"}
+
+// ClassifyHTTPError returns err as a SoftError when the request failed transiently:
+// the status code is retryable, the response headers report a rate limit, or err
+// is a transient transport error, see IsTransientError. Other errors are returned
+// unchanged, and so are errors already classified as soft. statusCode and header
+// are those of the failed response, zero and nil when there was none.
+func ClassifyHTTPError(err error, statusCode int, header http.Header) error {
+	if err == nil || errors.Is(err, SoftError) {
+		return err
+	}
+	if IsRetryableStatus(statusCode) || IsRateLimited(header) || IsTransientError(err) {
+		return NewSoftError(err)
+	}
+	return err
+}
+
+// ClassifyError is ClassifyHTTPError for errors without an HTTP response.
+func ClassifyError(err error) error {
+	return ClassifyHTTPError(err, 0, nil)
+}
+
+// IsRetryableStatus reports whether an HTTP status code denotes a failure worth
+// retrying later: request timeouts, rate limits and server errors, except for
+// the methods the server does not implement.
+func IsRetryableStatus(statusCode int) bool {
+	switch {
+	case statusCode == http.StatusRequestTimeout, statusCode == http.StatusTooManyRequests:
+		return true
+	case statusCode == http.StatusNotImplemented:
+		return false
+	default:
+		return statusCode >= http.StatusInternalServerError
+	}
+}
+
+// IsRateLimited reports whether response headers denote a rate limit: a Retry-After
+// header, or no requests remaining in the current rate limit window.
+func IsRateLimited(header http.Header) bool {
+	if header == nil {
+		return false
+	}
+	if header.Get("Retry-After") != "" {
+		return true
+	}
+	for _, name := range rateLimitRemainingHeaders {
+		if header.Get(name) == "0" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTransientError reports whether err is a transport failure worth retrying later:
+// a deadline exceeded, a network timeout, a temporary DNS lookup failure, or a
+// connection reset, refused or closed before the response was read. A canceled
+// context is not transient, it means the controller is terminating.
+func IsTransientError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsTemporary {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyHTTPError(t *testing.T) {
+	apiErr := errors.New("api error")
+	tests := []struct {
+		name       string
+		err        error
+		statusCode int
+		header     http.Header
+		soft       bool
+	}{
+		{name: "too many requests", err: apiErr, statusCode: http.StatusTooManyRequests, soft: true},
+		{name: "request timeout", err: apiErr, statusCode: http.StatusRequestTimeout, soft: true},
+		{name: "internal server error", err: apiErr, statusCode: http.StatusInternalServerError, soft: true},
+		{name: "service unavailable", err: apiErr, statusCode: http.StatusServiceUnavailable, soft: true},
+		{name: "not implemented", err: apiErr, statusCode: http.StatusNotImplemented},
+		{name: "bad request", err: apiErr, statusCode: http.StatusBadRequest},
+		{name: "unauthorized", err: apiErr, statusCode: http.StatusUnauthorized},
+		{name: "retry after header", err: apiErr, statusCode: http.StatusForbidden, header: http.Header{"Retry-After": {"10"}}, soft: true},
+		{name: "no remaining requests", err: apiErr, statusCode: http.StatusForbidden, header: http.Header{"X-Ratelimit-Remaining": {"0"}}, soft: true},
+		{name: "remaining requests", err: apiErr, statusCode: http.StatusForbidden, header: http.Header{"Ratelimit-Remaining": {"10"}}},
+		{name: "deadline exceeded", err: fmt.Errorf("listing zones: %w", context.DeadlineExceeded), soft: true},
+		{name: "canceled", err: context.Canceled},
+		{name: "unexpected EOF", err: io.ErrUnexpectedEOF, soft: true},
+		{name: "connection reset", err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, soft: true},
+		{name: "network timeout", err: &net.DNSError{Err: "i/o timeout", IsTimeout: true}, soft: true},
+		{name: "temporary lookup failure", err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}, soft: true},
+		{name: "unknown host", err: &net.DNSError{Err: "no such host", IsNotFound: true}},
+		{name: "already soft", err: NewSoftError(apiErr), statusCode: http.StatusBadRequest, soft: true},
+		{name: "generic error", err: apiErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ClassifyHTTPError(tt.err, tt.statusCode, tt.header)
+			assert.ErrorIs(t, err, tt.err)
+			if tt.soft {
+				assert.ErrorIs(t, err, SoftError)
+			} else {
+				assert.NotErrorIs(t, err, SoftError)
+				assert.Equal(t, tt.err, err, "hard errors are returned unchanged")
+			}
+		})
+	}
+
+	assert.NoError(t, ClassifyHTTPError(nil, http.StatusServiceUnavailable, nil))
+	assert.NoError(t, ClassifyError(nil))
+}
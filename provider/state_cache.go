@@ -0,0 +1,272 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// StateStore persists the records and zones of a provider across restarts.
+// Load returns nil when no state was saved, saving nil drops the state.
+type StateStore interface {
+	Load(ctx context.Context) ([]byte, error)
+	Save(ctx context.Context, state []byte) error
+}
+
+// persistedState is the state saved by a StateCachedProvider: the records of
+// the provider and its domain filter, i.e. the zones it manages.
+type persistedState struct {
+	Records      []*endpoint.Endpoint   `json:"records"`
+	DomainFilter *endpoint.DomainFilter `json:"domainFilter,omitempty"`
+}
+
+// StateCachedProvider serves the first Records and GetDomainFilter calls from
+// the state persisted by a previous run and refreshes them from the provider in
+// the background. Later calls read the provider and persist the state for the
+// next restart.
+type StateCachedProvider struct {
+	Provider
+	store StateStore
+
+	mu        sync.Mutex
+	started   bool
+	refresh   chan struct{}
+	refreshed []*endpoint.Endpoint
+	applied   bool
+	saved     []byte
+	// persistedFilter is the domain filter loaded from the state, served once
+	persistedFilter *endpoint.DomainFilter
+	// state is persisted on change, its records are nil once changes were applied
+	state persistedState
+}
+
+// NewStateCachedProvider returns a StateCachedProvider persisting the records of provider in store.
+func NewStateCachedProvider(provider Provider, store StateStore) *StateCachedProvider {
+	return &StateCachedProvider{Provider: provider, store: store}
+}
+
+func (c *StateCachedProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	c.mu.Lock()
+	if !c.started {
+		c.started = true
+		if records := c.load(ctx); records != nil {
+			c.refresh = make(chan struct{})
+			go c.refreshRecords(context.WithoutCancel(ctx), c.refresh)
+			c.mu.Unlock()
+			log.Infof("State cache: serving %d records persisted by the previous run", len(records))
+			markRecordsFromCache(ctx)
+			return records, nil
+		}
+	}
+	refresh := c.refresh
+	c.mu.Unlock()
+
+	if refresh != nil {
+		select {
+		case <-refresh:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		c.mu.Lock()
+		records, applied := c.refreshed, c.applied
+		c.refresh, c.refreshed = nil, nil
+		c.mu.Unlock()
+		// records refreshed while the first changes were applied may be outdated
+		if records != nil && !applied {
+			return records, nil
+		}
+	}
+	return c.read(ctx)
+}
+
+// GetDomainFilter returns the domain filter persisted by the previous run on
+// the first call, so that the first sync does not list the zones either.
+func (c *StateCachedProvider) GetDomainFilter() endpoint.DomainFilterInterface {
+	c.mu.Lock()
+	filter := c.persistedFilter
+	c.persistedFilter = nil
+	c.mu.Unlock()
+	if filter != nil {
+		return filter
+	}
+	return c.readDomainFilter(context.Background())
+}
+
+// ApplyChanges drops the persisted state before applying changes, so that a
+// restart before the next read does not serve outdated records.
+func (c *StateCachedProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if changes.HasChanges() {
+		c.mu.Lock()
+		c.applied = true
+		c.state.Records = nil
+		c.mu.Unlock()
+		c.save(ctx, nil)
+	}
+	return c.Provider.ApplyChanges(ctx, changes)
+}
+
+// Reset drops the records cached by the wrapped provider.
+func (c *StateCachedProvider) Reset() {
+	if r, ok := c.Provider.(CacheResetter); ok {
+		r.Reset()
+	}
+}
+
+// Unwrap returns the wrapped provider.
+func (c *StateCachedProvider) Unwrap() Provider {
+	return c.Provider
+}
+
+func (c *StateCachedProvider) load(ctx context.Context) []*endpoint.Endpoint {
+	state, err := c.store.Load(ctx)
+	if err != nil {
+		log.Warnf("State cache: failed to load the persisted records, reading them from the provider: %v", err)
+		return nil
+	}
+	if len(state) == 0 {
+		return nil
+	}
+	var persisted persistedState
+	if err := json.Unmarshal(state, &persisted); err != nil {
+		log.Warnf("State cache: ignoring invalid persisted records: %v", err)
+		return nil
+	}
+	if persisted.Records == nil {
+		log.Warn("State cache: ignoring persisted state without records")
+		return nil
+	}
+	c.saved = state
+	c.state = persisted
+	c.persistedFilter = persisted.DomainFilter
+	return persisted.Records
+}
+
+func (c *StateCachedProvider) refreshRecords(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	records, err := c.Provider.Records(ctx)
+	if err != nil {
+		log.Warnf("State cache: failed to refresh the records: %v", err)
+		return
+	}
+	c.mu.Lock()
+	applied := c.applied
+	c.refreshed = records
+	c.mu.Unlock()
+	if !applied {
+		c.persist(ctx, records)
+	}
+	c.readDomainFilter(ctx)
+}
+
+// read returns the records of the provider and persists them.
+func (c *StateCachedProvider) read(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	records, err := c.Provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.persist(ctx, records)
+	return records, nil
+}
+
+// readDomainFilter returns the domain filter of the provider and persists it
+// along with the records last read.
+func (c *StateCachedProvider) readDomainFilter(ctx context.Context) endpoint.DomainFilterInterface {
+	filter := c.Provider.GetDomainFilter()
+	persisted, _ := filter.(*endpoint.DomainFilter)
+	c.mu.Lock()
+	c.state.DomainFilter = persisted
+	records := c.state.Records
+	c.mu.Unlock()
+	if records != nil {
+		c.persist(ctx, records)
+	}
+	return filter
+}
+
+func (c *StateCachedProvider) persist(ctx context.Context, records []*endpoint.Endpoint) {
+	c.mu.Lock()
+	c.state.Records = records
+	state, err := json.Marshal(c.state)
+	c.mu.Unlock()
+	if err != nil {
+		log.Warnf("State cache: failed to encode the records: %v", err)
+		return
+	}
+	c.save(ctx, state)
+}
+
+func (c *StateCachedProvider) save(ctx context.Context, state []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if bytes.Equal(c.saved, state) {
+		return
+	}
+	if err := c.store.Save(ctx, state); err != nil {
+		log.Warnf("State cache: failed to persist the records: %v", err)
+		return
+	}
+	c.saved = state
+}
+
+// FileStateStore persists the state in a local file.
+type FileStateStore struct {
+	Path string
+}
+
+func (s FileStateStore) Load(_ context.Context) ([]byte, error) {
+	state, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return state, err
+}
+
+// Save writes the state to a temporary file renamed over the previous state,
+// so that a crash never leaves a partially written file behind.
+func (s FileStateStore) Save(_ context.Context, state []byte) error {
+	if state == nil {
+		if err := os.Remove(s.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(state); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func stateOf(t *testing.T, records []*endpoint.Endpoint) []byte {
+	t.Helper()
+	state, err := json.Marshal(persistedState{Records: records})
+	require.NoError(t, err)
+	return state
+}
+
+func TestStateCachedProviderColdStart(t *testing.T) {
+	live := []*endpoint.Endpoint{endpoint.NewEndpoint("live.example.com", endpoint.RecordTypeA, "1.2.3.4")}
+	store := FileStateStore{Path: filepath.Join(t.TempDir(), "state.json")}
+	p := NewStateCachedProvider(&testProviderFunc{
+		records: func(_ context.Context) ([]*endpoint.Endpoint, error) { return live, nil },
+	}, store)
+
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, live, records)
+
+	state, err := store.Load(t.Context())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(stateOf(t, live)), string(state))
+}
+
+func TestStateCachedProviderWarmStart(t *testing.T) {
+	persisted := []*endpoint.Endpoint{endpoint.NewEndpoint("persisted.example.com", endpoint.RecordTypeA, "1.2.3.4")}
+	live := []*endpoint.Endpoint{endpoint.NewEndpoint("live.example.com", endpoint.RecordTypeA, "5.6.7.8")}
+	store := FileStateStore{Path: filepath.Join(t.TempDir(), "state.json")}
+	require.NoError(t, store.Save(t.Context(), stateOf(t, persisted)))
+
+	var calls atomic.Int32
+	p := NewStateCachedProvider(&testProviderFunc{
+		records: func(_ context.Context) ([]*endpoint.Endpoint, error) {
+			calls.Add(1)
+			return live, nil
+		},
+	}, store)
+
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, persisted[0].DNSName, records[0].DNSName)
+
+	// the second call waits for the background refresh and reuses its result
+	records, err = p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, live, records)
+	assert.Equal(t, int32(1), calls.Load())
+
+	state, err := store.Load(t.Context())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(stateOf(t, live)), string(state))
+
+	records, err = p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, live, records)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestStateCachedProviderApplyChangesDropsState(t *testing.T) {
+	persisted := []*endpoint.Endpoint{endpoint.NewEndpoint("persisted.example.com", endpoint.RecordTypeA, "1.2.3.4")}
+	live := []*endpoint.Endpoint{endpoint.NewEndpoint("live.example.com", endpoint.RecordTypeA, "5.6.7.8")}
+	store := FileStateStore{Path: filepath.Join(t.TempDir(), "state.json")}
+	require.NoError(t, store.Save(t.Context(), stateOf(t, persisted)))
+
+	refresh := make(chan struct{})
+	var calls atomic.Int32
+	p := NewStateCachedProvider(&testProviderFunc{
+		records: func(_ context.Context) ([]*endpoint.Endpoint, error) {
+			if calls.Add(1) == 1 {
+				<-refresh
+			}
+			return live, nil
+		},
+		applyChanges: func(_ context.Context, _ *plan.Changes) error { return nil },
+	}, store)
+
+	_, err := p.Records(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Create: live}))
+
+	state, err := store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	// the refresh raced with the changes, the records are read again
+	close(refresh)
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, live, records)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestStateCachedProviderIgnoresInvalidState(t *testing.T) {
+	live := []*endpoint.Endpoint{endpoint.NewEndpoint("live.example.com", endpoint.RecordTypeA, "1.2.3.4")}
+	store := FileStateStore{Path: filepath.Join(t.TempDir(), "state.json")}
+	require.NoError(t, store.Save(t.Context(), []byte("{invalid")))
+
+	p := NewStateCachedProvider(&testProviderFunc{
+		records: func(_ context.Context) ([]*endpoint.Endpoint, error) { return live, nil },
+	}, store)
+
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, live, records)
+}
+
+func TestStateCachedProviderDomainFilter(t *testing.T) {
+	persisted := []*endpoint.Endpoint{endpoint.NewEndpoint("persisted.example.com", endpoint.RecordTypeA, "1.2.3.4")}
+	store := FileStateStore{Path: filepath.Join(t.TempDir(), "state.json")}
+	state, err := json.Marshal(persistedState{Records: persisted, DomainFilter: endpoint.NewDomainFilter([]string{"persisted.example.com"})})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(t.Context(), state))
+
+	var calls atomic.Int32
+	p := NewStateCachedProvider(&testProviderFunc{
+		records: func(_ context.Context) ([]*endpoint.Endpoint, error) { return persisted, nil },
+		getDomainFilter: func() endpoint.DomainFilterInterface {
+			calls.Add(1)
+			return endpoint.NewDomainFilter([]string{"live.example.com"})
+		},
+	}, store)
+
+	_, err = p.Records(t.Context())
+	require.NoError(t, err)
+	// the first call is served from the state without listing the zones
+	filter := p.GetDomainFilter()
+	assert.True(t, filter.Match("persisted.example.com"))
+	assert.False(t, filter.Match("live.example.com"))
+
+	// the background refresh listed the zones and persisted them
+	_, err = p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+	state, err = store.Load(t.Context())
+	require.NoError(t, err)
+	var saved persistedState
+	require.NoError(t, json.Unmarshal(state, &saved))
+	assert.True(t, saved.DomainFilter.Match("live.example.com"))
+
+	filter = p.GetDomainFilter()
+	assert.True(t, filter.Match("live.example.com"))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestFileStateStore(t *testing.T) {
+	store := FileStateStore{Path: filepath.Join(t.TempDir(), "state.json")}
+
+	state, err := store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	require.NoError(t, store.Save(t.Context(), []byte("[]")))
+	state, err = store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("[]"), state)
+
+	require.NoError(t, store.Save(t.Context(), nil))
+	require.NoError(t, store.Save(t.Context(), nil))
+	state, err = store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestConfigMapStateStore(t *testing.T) {
+	store := ConfigMapStateStore{Client: fake.NewClientset(), Namespace: "external-dns", Name: "state"}
+
+	state, err := store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	require.NoError(t, store.Save(t.Context(), nil))
+	require.NoError(t, store.Save(t.Context(), []byte("[]")))
+	require.NoError(t, store.Save(t.Context(), []byte(`[{"dnsName":"foo"}]`)))
+	state, err = store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`[{"dnsName":"foo"}]`), state)
+
+	require.NoError(t, store.Save(t.Context(), nil))
+	state, err = store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
@@ -18,12 +18,17 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
+	"crypto/tls"
 	"encoding/json"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/credentials"
+	"sigs.k8s.io/external-dns/pkg/tlsutils"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 
@@ -32,10 +37,22 @@ import (
 
 const (
 	MediaTypeFormatAndVersion = "application/external.dns.webhook+json;version=1"
-	ContentTypeHeader         = "Content-Type"
-	UrlAdjustEndpoints        = "/adjustendpoints"
-	UrlApplyChanges           = "/applychanges"
-	UrlRecords                = "/records"
+	// MediaTypeFormatAndVersion2 is the media type of a negotiation response able to
+	// carry any domain filter, including a combination of them, see
+	// endpoint.UnmarshalDomainFilter. It is only answered when accepted by the client.
+	MediaTypeFormatAndVersion2 = "application/external.dns.webhook+json;version=2"
+	ContentTypeHeader          = "Content-Type"
+	AcceptHeader               = "Accept"
+	UrlAdjustEndpoints         = "/adjustendpoints"
+	UrlApplyChanges            = "/applychanges"
+	UrlRecords                 = "/records"
+	UrlProviders               = "/providers/"
+
+	// AdjustEndpointsCacheHeader is set by a webhook on its negotiation response to
+	// AdjustEndpointsCacheDisabled when the response of /adjustendpoints may differ
+	// for the same endpoints, so that ExternalDNS does not reuse it.
+	AdjustEndpointsCacheHeader   = "External-DNS-Adjust-Endpoints-Cache"
+	AdjustEndpointsCacheDisabled = "disabled"
 )
 
 type WebhookServer struct {
@@ -104,14 +121,113 @@ func (p *WebhookServer) AdjustEndpointsHandler(w http.ResponseWriter, req *http.
 	}
 }
 
-func (p *WebhookServer) NegotiateHandler(w http.ResponseWriter, _ *http.Request) {
-	w.Header().Set(ContentTypeHeader, MediaTypeFormatAndVersion)
-	err := json.NewEncoder(w).Encode(p.Provider.GetDomainFilter())
+func (p *WebhookServer) NegotiateHandler(w http.ResponseWriter, req *http.Request) {
+	domainFilter := p.Provider.GetDomainFilter()
+	mediaType := MediaTypeFormatAndVersion
+	if strings.Contains(req.Header.Get(AcceptHeader), MediaTypeFormatAndVersion2) {
+		mediaType = MediaTypeFormatAndVersion2
+	} else if _, ok := domainFilter.(*endpoint.DomainFilter); !ok && domainFilter != nil {
+		// the first version only carries a DomainFilter, failing is safer than
+		// having the client match every domain
+		log.Errorf("Failed to negotiate: the domain filter %T requires %s", domainFilter, MediaTypeFormatAndVersion2)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	w.Header().Set(ContentTypeHeader, mediaType)
+	err := json.NewEncoder(w).Encode(domainFilter)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
 
+// HTTPApiOption configures the server started by StartHTTPApi.
+type HTTPApiOption func(*httpApiConfig)
+
+type httpApiConfig struct {
+	named        map[string]*WebhookServer
+	token        *credentials.Credential
+	certFile     string
+	keyFile      string
+	clientCAFile string
+}
+
+func newHTTPApiConfig(opts ...HTTPApiOption) *httpApiConfig {
+	cfg := &httpApiConfig{named: map[string]*WebhookServer{}}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithNamedProvider serves provider under /providers/<name>, next to the
+// default provider served at the root. Each external-dns instance selects a
+// backend by pointing --webhook-provider-url to its prefix.
+func WithNamedProvider(name string, provider provider.Provider) HTTPApiOption {
+	return func(c *httpApiConfig) {
+		c.named[name] = &WebhookServer{Provider: provider}
+	}
+}
+
+// WithBearerToken rejects the requests that do not carry token as bearer token
+// with 401 Unauthorized.
+func WithBearerToken(token *credentials.Credential) HTTPApiOption {
+	return func(c *httpApiConfig) {
+		c.token = token
+	}
+}
+
+// WithTLS serves HTTPS with the certificate and key files. With a client CA file,
+// clients must present a certificate signed by it (mTLS).
+func WithTLS(certFile, keyFile, clientCAFile string) HTTPApiOption {
+	return func(c *httpApiConfig) {
+		c.certFile = certFile
+		c.keyFile = keyFile
+		c.clientCAFile = clientCAFile
+	}
+}
+
+// tlsConfig returns the TLS configuration of the server, nil when serving HTTP.
+func (c *httpApiConfig) tlsConfig() (*tls.Config, error) {
+	if c.certFile == "" {
+		return nil, nil //nolint:nilnil // no TLS configured
+	}
+	cfg, err := tlsutils.NewTLSConfig(c.certFile, c.keyFile, c.clientCAFile, "", false, tls.VersionTLS12)
+	if err != nil {
+		return nil, err
+	}
+	if c.clientCAFile != "" {
+		// NewTLSConfig loads the CA as root CAs, which verify the server side of a connection.
+		cfg.ClientCAs, cfg.RootCAs = cfg.RootCAs, nil
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// requireBearerToken rejects the requests whose Authorization header is not the bearer token.
+func requireBearerToken(next http.Handler, token *credentials.Credential) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token.Get())) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// namedHandler dispatches a request to the handler of the provider named in its path.
+func namedHandler(servers map[string]*WebhookServer, handler func(*WebhookServer, http.ResponseWriter, *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		server, ok := servers[req.PathValue("name")]
+		if !ok {
+			log.Errorf("Unknown provider %q", req.PathValue("name"))
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		handler(server, w, req)
+	}
+}
+
 // StartHTTPApi starts a HTTP server given any provider.
 // the function takes an optional channel as input which is used to signal that the server has started.
 // The server will listen on port `providerPort`.
@@ -120,19 +236,21 @@ func (p *WebhookServer) NegotiateHandler(w http.ResponseWriter, _ *http.Request)
 // - /records (GET): returns the current records
 // - /records (POST): applies the changes
 // - /adjustendpoints (POST): executes the AdjustEndpoints method
-func StartHTTPApi(provider provider.Provider, startedChan chan struct{}, readTimeout, writeTimeout time.Duration, providerPort string) {
-	p := WebhookServer{
-		Provider: provider,
+//
+// Providers added with WithNamedProvider respond to the same endpoints under
+// /providers/<name>, e.g. /providers/<name>/records.
+//
+// WithTLS serves HTTPS, optionally with mTLS, and WithBearerToken requires a
+// bearer token, so that the server can be reached from outside the pod.
+func StartHTTPApi(provider provider.Provider, startedChan chan struct{}, readTimeout, writeTimeout time.Duration, providerPort string, opts ...HTTPApiOption) {
+	cfg := newHTTPApiConfig(opts...)
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		log.Fatal(err)
 	}
-
-	m := http.NewServeMux()
-	m.HandleFunc("/", p.NegotiateHandler)
-	m.HandleFunc(UrlRecords, p.RecordsHandler)
-	m.HandleFunc(UrlAdjustEndpoints, p.AdjustEndpointsHandler)
-
 	s := &http.Server{
 		Addr:         providerPort,
-		Handler:      m,
+		Handler:      cfg.handler(provider),
 		ReadTimeout:  readTimeout,
 		WriteTimeout: writeTimeout,
 	}
@@ -141,6 +259,9 @@ func StartHTTPApi(provider provider.Provider, startedChan chan struct{}, readTim
 	if err != nil {
 		log.Fatal(err)
 	}
+	if tlsConfig != nil {
+		l = tls.NewListener(l, tlsConfig)
+	}
 
 	if startedChan != nil {
 		startedChan <- struct{}{}
@@ -150,3 +271,32 @@ func StartHTTPApi(provider provider.Provider, startedChan chan struct{}, readTim
 		log.Fatal(err)
 	}
 }
+
+// newHTTPApiHandler returns the handler serving provider at the root and the
+// named providers under their prefix.
+func newHTTPApiHandler(provider provider.Provider, opts ...HTTPApiOption) http.Handler {
+	return newHTTPApiConfig(opts...).handler(provider)
+}
+
+func (c *httpApiConfig) handler(provider provider.Provider) http.Handler {
+	p := WebhookServer{
+		Provider: provider,
+	}
+
+	m := http.NewServeMux()
+	m.HandleFunc("/", p.NegotiateHandler)
+	m.HandleFunc(UrlRecords, p.RecordsHandler)
+	m.HandleFunc(UrlAdjustEndpoints, p.AdjustEndpointsHandler)
+
+	if len(c.named) > 0 {
+		prefix := UrlProviders + "{name}"
+		m.HandleFunc(prefix, namedHandler(c.named, (*WebhookServer).NegotiateHandler))
+		m.HandleFunc(prefix+"/{$}", namedHandler(c.named, (*WebhookServer).NegotiateHandler))
+		m.HandleFunc(prefix+UrlRecords, namedHandler(c.named, (*WebhookServer).RecordsHandler))
+		m.HandleFunc(prefix+UrlAdjustEndpoints, namedHandler(c.named, (*WebhookServer).AdjustEndpointsHandler))
+	}
+	if c.token != nil {
+		return requireBearerToken(m, c.token)
+	}
+	return m
+}
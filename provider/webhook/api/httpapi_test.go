@@ -19,11 +19,15 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -32,6 +36,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/credentials"
 	"sigs.k8s.io/external-dns/plan"
 )
 
@@ -39,7 +45,7 @@ var records []*endpoint.Endpoint
 
 type FakeWebhookProvider struct {
 	err           error
-	domainFilter  *endpoint.DomainFilter
+	domainFilter  endpoint.DomainFilterInterface
 	assertChanges func(*plan.Changes)
 }
 
@@ -69,6 +75,10 @@ func (p FakeWebhookProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]
 	return endpoints, nil
 }
 
+func (p FakeWebhookProvider) SupportedRecordTypes() []string {
+	return nil
+}
+
 func (p FakeWebhookProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return p.domainFilter
 }
@@ -341,6 +351,50 @@ func TestNegotiateHandler_Success(t *testing.T) {
 	require.Equal(t, provider.domainFilter, df)
 }
 
+func TestNegotiateHandler_Version2(t *testing.T) {
+	combined := endpoint.MatchAllDomainFilters{
+		endpoint.NewDomainFilterWithExclusions([]string{"bar.com"}, []string{"foo.bar.com"}),
+		endpoint.NewRegexDomainFilter(regexp.MustCompile(`\.bar\.com$`), regexp.MustCompile(`^internal\.`)),
+	}
+	negotiate := func(domainFilter endpoint.DomainFilterInterface, accept string) *http.Response {
+		t.Helper()
+		server := &WebhookServer{Provider: &FakeWebhookProvider{domainFilter: domainFilter}}
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(AcceptHeader, accept)
+		server.NegotiateHandler(w, req)
+		return w.Result()
+	}
+
+	t.Run("a combination of filters is sent with the second version", func(t *testing.T) {
+		res := negotiate(combined, MediaTypeFormatAndVersion2+", "+MediaTypeFormatAndVersion)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, MediaTypeFormatAndVersion2, res.Header.Get(ContentTypeHeader))
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		df, err := endpoint.UnmarshalDomainFilter(body)
+		require.NoError(t, err)
+		for _, domain := range []string{"a.bar.com", "foo.bar.com", "internal.bar.com", "a.foo.com"} {
+			assert.Equal(t, combined.Match(domain), df.Match(domain), domain)
+		}
+	})
+
+	t.Run("a combination of filters is not acceptable with the first version", func(t *testing.T) {
+		res := negotiate(combined, MediaTypeFormatAndVersion)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusNotAcceptable, res.StatusCode)
+	})
+
+	t.Run("a domain filter is sent with the first version", func(t *testing.T) {
+		res := negotiate(endpoint.NewDomainFilter([]string{"bar.com"}), MediaTypeFormatAndVersion)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, MediaTypeFormatAndVersion, res.Header.Get(ContentTypeHeader))
+	})
+}
+
 func TestNegotiateHandler_FiltersWithSpecialEncodings(t *testing.T) {
 	provider := &FakeWebhookProvider{
 		domainFilter: endpoint.NewDomainFilter([]string{"\\u001a", "\\Xfoo.\\u2028, \\u0000.com", "<invalid json>"}),
@@ -355,3 +409,126 @@ func TestNegotiateHandler_FiltersWithSpecialEncodings(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, res.StatusCode)
 }
+
+func TestHTTPApiHandlerNamedProviders(t *testing.T) {
+	handler := newHTTPApiHandler(
+		FakeWebhookProvider{domainFilter: endpoint.NewDomainFilter([]string{"default.com"})},
+		WithNamedProvider("first", FakeWebhookProvider{domainFilter: endpoint.NewDomainFilter([]string{"first.com"})}),
+		WithNamedProvider("second", FakeWebhookProvider{err: fmt.Errorf("error")}),
+	)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	negotiate := func(path string) *endpoint.DomainFilter {
+		t.Helper()
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		df := &endpoint.DomainFilter{}
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, df.UnmarshalJSON(b))
+		return df
+	}
+	assert.Equal(t, []string{"default.com"}, negotiate("/").Filters)
+	assert.Equal(t, []string{"first.com"}, negotiate("/providers/first").Filters)
+	assert.Equal(t, []string{"first.com"}, negotiate("/providers/first/").Filters)
+
+	for _, tc := range []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "/providers/first" + UrlRecords, "", http.StatusOK},
+		{http.MethodGet, "/providers/second" + UrlRecords, "", http.StatusInternalServerError},
+		{http.MethodPost, "/providers/first" + UrlAdjustEndpoints, "[]", http.StatusOK},
+		{http.MethodGet, "/providers/unknown" + UrlRecords, "", http.StatusNotFound},
+		{http.MethodGet, "/providers/unknown", "", http.StatusNotFound},
+	} {
+		req, err := http.NewRequest(tc.method, server.URL+tc.path, strings.NewReader(tc.body))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, tc.status, resp.StatusCode, "%s %s", tc.method, tc.path)
+	}
+}
+
+func TestHTTPApiHandlerBearerToken(t *testing.T) {
+	handler := newHTTPApiHandler(FakeWebhookProvider{}, WithBearerToken(credentials.Static("secret")))
+
+	for _, tc := range []struct {
+		name          string
+		authorization string
+		expected      int
+	}{
+		{name: "valid token", authorization: "Bearer secret", expected: http.StatusOK},
+		{name: "missing token", expected: http.StatusUnauthorized},
+		{name: "wrong token", authorization: "Bearer other", expected: http.StatusUnauthorized},
+		{name: "wrong scheme", authorization: "Basic secret", expected: http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, UrlRecords, nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, tc.expected, w.Code)
+		})
+	}
+}
+
+func TestHTTPApiConfigTLS(t *testing.T) {
+	tlsConfig, err := newHTTPApiConfig().tlsConfig()
+	require.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+
+	_, err = newHTTPApiConfig(WithTLS("/missing.crt", "/missing.key", "")).tlsConfig()
+	require.Error(t, err)
+
+	serverCert, serverKey := testutils.TestHelperWriteCertificate(t, "server")
+	clientCert, clientKey := testutils.TestHelperWriteCertificate(t, "client")
+	tlsConfig, err = newHTTPApiConfig(WithTLS(serverCert, serverKey, clientCert)).tlsConfig()
+	require.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+	assert.Nil(t, tlsConfig.RootCAs)
+
+	server := httptest.NewUnstartedServer(newHTTPApiHandler(FakeWebhookProvider{}))
+	server.TLS = tlsConfig
+	server.StartTLS()
+	defer server.Close()
+
+	get := func(certificates ...tls.Certificate) error {
+		t.Helper()
+		pem, err := os.ReadFile(serverCert)
+		require.NoError(t, err)
+		roots := x509.NewCertPool()
+		require.True(t, roots.AppendCertsFromPEM(pem))
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: certificates,
+			MinVersion:   tls.VersionTLS12,
+		}}}
+		resp, err := client.Get(server.URL + UrlRecords)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		return nil
+	}
+
+	require.Error(t, get(), "the server must require a client certificate")
+
+	other, otherKey := testutils.TestHelperWriteCertificate(t, "other")
+	certificate, err := tls.LoadX509KeyPair(other, otherKey)
+	require.NoError(t, err)
+	require.Error(t, get(certificate), "the server must reject a client certificate of another CA")
+
+	certificate, err = tls.LoadX509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+	require.NoError(t, get(certificate))
+}
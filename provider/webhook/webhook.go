@@ -19,16 +19,23 @@ package webhook
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/credentials"
 	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
 	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/pkg/tlsutils"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
@@ -86,12 +93,48 @@ var (
 			Help:      "Requests with AdjustEndpoints method",
 		},
 	)
+	adjustEndpointsCacheHitsTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "webhook_provider",
+			Name:      "adjustendpoints_cache_hits_total",
+			Help:      "AdjustEndpoints calls answered with the cached response of the webhook for the same endpoints",
+		},
+	)
 )
 
 type WebhookProvider struct {
 	client          *http.Client
 	remoteServerURL *url.URL
-	DomainFilter    *endpoint.DomainFilter
+	// DomainFilter is the filter served by the webhook, nil when the webhook serves
+	// combined domain filters
+	DomainFilter *endpoint.DomainFilter
+	// domainFilter is any filter served by the webhook, see GetDomainFilter
+	domainFilter endpoint.DomainFilterInterface
+	// adjustCache holds the last AdjustEndpoints response, nil when caching is disabled
+	adjustCache *adjustEndpointsCache
+}
+
+// adjustEndpointsCache holds the last response of the webhook to AdjustEndpoints,
+// reused as long as the endpoints sent do not change.
+type adjustEndpointsCache struct {
+	mu   sync.Mutex
+	hash [sha256.Size]byte
+	body []byte
+}
+
+func (c *adjustEndpointsCache) get(hash [sha256.Size]byte) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.body == nil || c.hash != hash {
+		return nil, false
+	}
+	return c.body, true
+}
+
+func (c *adjustEndpointsCache) set(hash [sha256.Size]byte, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hash, c.body = hash, body
 }
 
 func init() {
@@ -101,51 +144,121 @@ func init() {
 	metrics.RegisterMetric.MustRegister(applyChangesRequestsGauge)
 	metrics.RegisterMetric.MustRegister(adjustEndpointsErrorsGauge)
 	metrics.RegisterMetric.MustRegister(adjustEndpointsRequestsGauge)
+	metrics.RegisterMetric.MustRegister(adjustEndpointsCacheHitsTotal)
 }
 
 // New creates a webhook provider from the given configuration.
 func New(ctx context.Context, cfg *externaldns.Config, _ *endpoint.DomainFilter) (provider.Provider, error) {
-	return newProvider(ctx, cfg.WebhookProviderURL, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout)
-}
-
-func newProvider(ctx context.Context, u string, readTimeout, writeTimeout time.Duration) (*WebhookProvider, error) {
-	parsedURL, err := url.Parse(u)
+	transport, err := newTransport(ctx, cfg.TLSCA, cfg.TLSClientCert, cfg.TLSClientCertKey, cfg.WebhookProviderToken)
 	if err != nil {
 		return nil, err
 	}
+	return newProvider(ctx, cfg.WebhookProviderURL, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout, transport)
+}
 
-	// covers the entire round-trip — writing the request body + waiting for + reading the response
-	client := extdnshttp.NewInstrumentedClient(&http.Client{Timeout: readTimeout + writeTimeout})
+// newTransport returns the transport of the requests to the webhook, nil for the default one.
+// With a CA or a client certificate, the webhook is verified with the CA and the client
+// certificate is presented to it (mTLS). With a token, a bearer token is sent with every
+// request; a token read from a file is reloaded when the file changes.
+func newTransport(ctx context.Context, caFile, certFile, keyFile, token string) (http.RoundTripper, error) {
+	var transport http.RoundTripper
+	if caFile != "" || certFile != "" || keyFile != "" {
+		tlsConfig, err := tlsutils.NewTLSConfig(certFile, keyFile, caFile, "", false, tls.VersionTLS12)
+		if err != nil {
+			return nil, err
+		}
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.TLSClientConfig = tlsConfig
+		transport = t
+	}
+	if token != "" {
+		c, err := credentials.Resolve(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		transport = credentials.NewHeaderRoundTripper(transport, "Authorization", "Bearer ", c)
+	}
+	return transport, nil
+}
 
-	// negotiate API information
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+func newProvider(ctx context.Context, u string, readTimeout, writeTimeout time.Duration, transport http.RoundTripper) (*WebhookProvider, error) {
+	parsedURL, err := url.Parse(u)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set(acceptHeader, webhookapi.MediaTypeFormatAndVersion)
 
-	resp, err := requestWithRetry(client, req)
+	// covers the entire round-trip — writing the request body + waiting for + reading the response
+	client := extdnshttp.NewInstrumentedClient(&http.Client{Timeout: readTimeout + writeTimeout, Transport: transport})
+
+	// negotiate API information, webhooks knowing only the first version keep answering with it
+	resp, err := negotiate(ctx, client, u, webhookapi.MediaTypeFormatAndVersion2+", "+webhookapi.MediaTypeFormatAndVersion)
+	var statusErr *unexpectedStatusError
+	if errors.As(err, &statusErr) && (statusErr.code == http.StatusNotAcceptable || statusErr.code == http.StatusUnsupportedMediaType) {
+		// webhooks matching the Accept header exactly reject the list of versions
+		log.Infof("The webhook rejected the version 2 negotiation with status code %d, negotiating version 1", statusErr.code)
+		resp, err = negotiate(ctx, client, u, webhookapi.MediaTypeFormatAndVersion)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to webhook: %w", err)
 	}
 	defer extdnshttp.DrainAndClose(resp.Body)
 
-	if ct := resp.Header.Get(webhookapi.ContentTypeHeader); ct != webhookapi.MediaTypeFormatAndVersion {
+	var df endpoint.DomainFilterInterface
+	switch ct := resp.Header.Get(webhookapi.ContentTypeHeader); ct {
+	case webhookapi.MediaTypeFormatAndVersion:
+		v1 := &endpoint.DomainFilter{}
+		if err := json.NewDecoder(resp.Body).Decode(v1); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response body of DomainFilter: %w", err)
+		}
+		df = v1
+	case webhookapi.MediaTypeFormatAndVersion2:
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body of DomainFilter: %w", err)
+		}
+		if df, err = endpoint.UnmarshalDomainFilter(b); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response body of DomainFilter: %w", err)
+		}
+	default:
 		return nil, fmt.Errorf("wrong content type returned from server: %s", ct)
 	}
 
-	df := &endpoint.DomainFilter{}
-	if err := json.NewDecoder(resp.Body).Decode(df); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response body of DomainFilter: %w", err)
+	var adjustCache *adjustEndpointsCache
+	if resp.Header.Get(webhookapi.AdjustEndpointsCacheHeader) != webhookapi.AdjustEndpointsCacheDisabled {
+		adjustCache = &adjustEndpointsCache{}
+	} else {
+		log.Info("The webhook disabled the caching of AdjustEndpoints responses")
 	}
 
+	concrete, _ := df.(*endpoint.DomainFilter)
 	return &WebhookProvider{
 		client:          client,
 		remoteServerURL: parsedURL,
-		DomainFilter:    df,
+		DomainFilter:    concrete,
+		domainFilter:    df,
+		adjustCache:     adjustCache,
 	}, nil
 }
 
+// negotiate requests the API information of the webhook accepting the given media types.
+func negotiate(ctx context.Context, client *http.Client, u, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(acceptHeader, accept)
+	return requestWithRetry(client, req)
+}
+
+// unexpectedStatusError is returned by requestWithRetry for non 2xx and 5xx responses.
+type unexpectedStatusError struct {
+	code int
+}
+
+func (e *unexpectedStatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d", e.code)
+}
+
 func requestWithRetry(client *http.Client, req *http.Request) (*http.Response, error) {
 	resp, err := backoff.Retry(req.Context(), func() (*http.Response, error) {
 		// Reset the body before each attempt so retries send the full payload.
@@ -176,7 +289,7 @@ func requestWithRetry(client *http.Client, req *http.Request) (*http.Response, e
 		// we currently only use 200 as success, but considering okay all 2XX for future usage
 		if resp.StatusCode >= http.StatusMultipleChoices {
 			extdnshttp.DrainAndClose(resp.Body)
-			return nil, backoff.Permanent(fmt.Errorf("unexpected status code %d", resp.StatusCode))
+			return nil, backoff.Permanent(&unexpectedStatusError{code: resp.StatusCode})
 		}
 		return resp, nil
 	}, backoff.WithMaxTries(maxRetries))
@@ -267,9 +380,9 @@ func (p WebhookProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 // AdjustEndpoints will call the provider doing a POST on `/adjustendpoints` which will return a list of modified endpoints
 // based on a provider-specific requirement.
 // This method returns an empty slice in case there is a technical error on the provider's side so that no endpoints will be considered.
+// The response is cached and reused while the endpoints are the same, unless the webhook disabled it
+// with the AdjustEndpointsCacheHeader header of its negotiation response.
 func (p WebhookProvider) AdjustEndpoints(e []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
-	adjustEndpointsRequestsGauge.Gauge.Inc()
-
 	// refObjects are not serialized to JSON (tagged json:"-"), so we must
 	// preserve them across the webhook round-trip to keep event emission working.
 	refObjects := make(map[endpoint.EndpointKey][]*endpoint.ObjectRef, len(e))
@@ -279,18 +392,46 @@ func (p WebhookProvider) AdjustEndpoints(e []*endpoint.Endpoint) ([]*endpoint.En
 		}
 	}
 
-	var endpoints []*endpoint.Endpoint
-	u, err := url.JoinPath(p.remoteServerURL.String(), webhookapi.UrlAdjustEndpoints)
+	b := new(bytes.Buffer)
+	if err := json.NewEncoder(b).Encode(e); err != nil {
+		adjustEndpointsRequestsGauge.Gauge.Inc()
+		adjustEndpointsErrorsGauge.Gauge.Inc()
+		log.Debugf("Failed to encode endpoints, %s", err)
+		return nil, err
+	}
+
+	hash := sha256.Sum256(b.Bytes())
+	if p.adjustCache != nil {
+		if body, ok := p.adjustCache.get(hash); ok {
+			adjustEndpointsCacheHitsTotal.Counter.Inc()
+			return decodeAdjustedEndpoints(body, refObjects)
+		}
+	}
+
+	body, err := p.adjustEndpoints(b)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := decodeAdjustedEndpoints(body, refObjects)
 	if err != nil {
 		adjustEndpointsErrorsGauge.Gauge.Inc()
-		log.Debugf("Failed to join path, %s", err)
+		log.Debugf("Failed to decode response body: %s", err.Error())
 		return nil, err
 	}
+	if p.adjustCache != nil {
+		p.adjustCache.set(hash, body)
+	}
+	return endpoints, nil
+}
 
-	b := new(bytes.Buffer)
-	if err := json.NewEncoder(b).Encode(e); err != nil {
+// adjustEndpoints posts the encoded endpoints to the webhook and returns the body of its response.
+func (p WebhookProvider) adjustEndpoints(b *bytes.Buffer) ([]byte, error) {
+	adjustEndpointsRequestsGauge.Gauge.Inc()
+
+	u, err := url.JoinPath(p.remoteServerURL.String(), webhookapi.UrlAdjustEndpoints)
+	if err != nil {
 		adjustEndpointsErrorsGauge.Gauge.Inc()
-		log.Debugf("Failed to encode endpoints, %s", err)
+		log.Debugf("Failed to join path, %s", err)
 		return nil, err
 	}
 
@@ -310,8 +451,6 @@ func (p WebhookProvider) AdjustEndpoints(e []*endpoint.Endpoint) ([]*endpoint.En
 		log.Debugf("Failed executing http request, %s", err)
 		return nil, err
 	}
-	// drainAndClose is deferred here and runs after json.Decode below consumes the
-	// success-path body, so the drain only sees any trailing bytes left unread.
 	defer extdnshttp.DrainAndClose(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
@@ -324,24 +463,41 @@ func (p WebhookProvider) AdjustEndpoints(e []*endpoint.Endpoint) ([]*endpoint.En
 		return nil, err
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&endpoints); err != nil {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
 		adjustEndpointsErrorsGauge.Gauge.Inc()
-		log.Debugf("Failed to decode response body: %s", err.Error())
+		log.Debugf("Failed to read response body: %s", err.Error())
 		return nil, err
 	}
+	return body, nil
+}
 
+// decodeAdjustedEndpoints decodes the endpoints of an AdjustEndpoints response
+// and sets back their reference objects.
+func decodeAdjustedEndpoints(body []byte, refObjects map[endpoint.EndpointKey][]*endpoint.ObjectRef) ([]*endpoint.Endpoint, error) {
+	var endpoints []*endpoint.Endpoint
+	if err := json.Unmarshal(body, &endpoints); err != nil {
+		return nil, err
+	}
 	for _, ep := range endpoints {
 		for _, ref := range refObjects[ep.Key()] {
 			ep.WithRefObject(ref)
 		}
 	}
-
 	return endpoints, nil
 }
 
 // GetDomainFilter make calls to get the serialized version of the domain filter
 func (p WebhookProvider) GetDomainFilter() endpoint.DomainFilterInterface {
-	return p.DomainFilter
+	if p.domainFilter == nil {
+		return p.DomainFilter
+	}
+	return p.domainFilter
+}
+
+// SupportedRecordTypes returns nil, the webhook server decides which record types it accepts.
+func (p WebhookProvider) SupportedRecordTypes() []string {
+	return nil
 }
 
 // isRetryableError returns true for HTTP status codes between 500 and 510 (inclusive)
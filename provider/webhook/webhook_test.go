@@ -17,11 +17,15 @@ limitations under the License.
 package webhook
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"regexp"
 	"testing"
 	"time"
 
@@ -45,12 +49,12 @@ const (
 )
 
 func TestNewWebhookProvider_InvalidURL(t *testing.T) {
-	_, err := newProvider(t.Context(), "://invalid-url", testReadTimeout, testWriteTimeout)
+	_, err := newProvider(t.Context(), "://invalid-url", testReadTimeout, testWriteTimeout, nil)
 	require.Error(t, err)
 }
 
 func TestNewWebhookProvider_HTTPRequestFailure(t *testing.T) {
-	_, err := newProvider(t.Context(), "http://nonexistent.url", testReadTimeout, testWriteTimeout)
+	_, err := newProvider(t.Context(), "http://nonexistent.url", testReadTimeout, testWriteTimeout, nil)
 	require.Error(t, err)
 }
 
@@ -62,7 +66,7 @@ func TestNewWebhookProvider_InvalidResponseBody(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	_, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	_, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "failed to unmarshal response body of DomainFilter")
 }
@@ -73,7 +77,7 @@ func TestNewWebhookProvider_Non2XXStatusCode(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	_, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	_, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "unexpected status code 400")
 }
@@ -88,7 +92,7 @@ func TestNewWebhookProvider_WrongContentTypeHeader(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	_, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	_, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "wrong content type returned from server")
 }
@@ -106,7 +110,7 @@ func TestInvalidDomainFilter(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	_, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	_, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.Error(t, err)
 }
 
@@ -122,9 +126,59 @@ func TestValidDomainfilter(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.NoError(t, err)
 	require.Equal(t, p.GetDomainFilter(), endpoint.NewDomainFilter([]string{"example.com"}))
+	require.Equal(t, endpoint.NewDomainFilter([]string{"example.com"}), p.DomainFilter)
+}
+
+func TestNegotiateVersion2(t *testing.T) {
+	combined := endpoint.MatchAllDomainFilters{
+		endpoint.NewDomainFilterWithExclusions([]string{"example.com"}, []string{"internal.example.com"}),
+		endpoint.NewRegexDomainFilter(regexp.MustCompile(`^api\.`), nil),
+	}
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			assert.Contains(t, r.Header.Get(acceptHeader), webhookapi.MediaTypeFormatAndVersion2)
+			assert.Contains(t, r.Header.Get(acceptHeader), webhookapi.MediaTypeFormatAndVersion)
+			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion2)
+			json.NewEncoder(w).Encode(combined)
+			return
+		}
+	}))
+	defer svr.Close()
+
+	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
+	require.NoError(t, err)
+	df := p.GetDomainFilter()
+	assert.True(t, df.Match("api.example.com"))
+	assert.False(t, df.Match("www.example.com"))
+	assert.False(t, df.Match("api.internal.example.com"))
+	assert.False(t, df.Match("api.example.org"))
+	assert.Nil(t, p.DomainFilter, "a combined filter is not a DomainFilter")
+}
+
+func TestNegotiateFallsBackToVersion1(t *testing.T) {
+	for _, code := range []int{http.StatusNotAcceptable, http.StatusUnsupportedMediaType} {
+		t.Run(http.StatusText(code), func(t *testing.T) {
+			var requests int
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Header.Get(acceptHeader) != webhookapi.MediaTypeFormatAndVersion {
+					w.WriteHeader(code)
+					return
+				}
+				w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+				json.NewEncoder(w).Encode(endpoint.NewDomainFilter([]string{"example.com"}))
+			}))
+			defer svr.Close()
+
+			p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
+			require.NoError(t, err)
+			assert.Equal(t, endpoint.NewDomainFilter([]string{"example.com"}), p.GetDomainFilter())
+			assert.Equal(t, 2, requests)
+		})
+	}
 }
 
 func TestRecords(t *testing.T) {
@@ -141,7 +195,7 @@ func TestRecords(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	provider, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	provider, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.NoError(t, err)
 	endpoints, err := provider.Records(t.Context())
 	require.NoError(t, err)
@@ -163,7 +217,7 @@ func TestRecordsWithErrors(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.NoError(t, err)
 	_, err = p.Records(t.Context())
 	require.Error(t, err)
@@ -249,7 +303,7 @@ func TestApplyChanges(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.NoError(t, err)
 	err = p.ApplyChanges(t.Context(), nil)
 	require.NoError(t, err)
@@ -295,7 +349,7 @@ func TestApplyChanges_StatusCodeError(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.NoError(t, err)
 
 	err = p.ApplyChanges(t.Context(), nil)
@@ -332,7 +386,7 @@ func TestAdjustEndpoints(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	provider, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	provider, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.NoError(t, err)
 	endpoints := []*endpoint.Endpoint{
 		{
@@ -379,7 +433,7 @@ func TestAdjustEndpoints_PreservesRefObjects(t *testing.T) {
 		svr := echoSvr(t)
 		defer svr.Close()
 
-		p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+		p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 		require.NoError(t, err)
 
 		ref := events.NewObjectReferenceFromParts("Service", "v1", "default", "my-svc", "uid-1", "service")
@@ -397,7 +451,7 @@ func TestAdjustEndpoints_PreservesRefObjects(t *testing.T) {
 		svr := echoSvr(t)
 		defer svr.Close()
 
-		p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+		p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 		require.NoError(t, err)
 
 		ref1 := events.NewObjectReferenceFromParts("Service", "v1", "default", "svc-a", "uid-1", "service")
@@ -417,6 +471,64 @@ func TestAdjustEndpoints_PreservesRefObjects(t *testing.T) {
 	})
 }
 
+func TestAdjustEndpoints_Cache(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		cacheHeader      string
+		expectedRequests int
+	}{
+		{name: "cached", expectedRequests: 2},
+		{name: "disabled by the webhook", cacheHeader: webhookapi.AdjustEndpointsCacheDisabled, expectedRequests: 3},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/" {
+					w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+					if tt.cacheHeader != "" {
+						w.Header().Set(webhookapi.AdjustEndpointsCacheHeader, tt.cacheHeader)
+					}
+					w.Write([]byte(`{}`))
+					return
+				}
+				requests++
+				var eps []*endpoint.Endpoint
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&eps))
+				for _, ep := range eps {
+					ep.RecordTTL = 300
+				}
+				j, _ := json.Marshal(eps)
+				w.Write(j)
+			}))
+			defer svr.Close()
+
+			p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
+			require.NoError(t, err)
+
+			ref := events.NewObjectReferenceFromParts("Service", "v1", "default", "my-svc", "uid-1", "service")
+			first, err := p.AdjustEndpoints([]*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", "A", "1.2.3.4").WithRefObject(ref)})
+			require.NoError(t, err)
+			first[0].Targets[0] = "5.6.7.8"
+
+			// the same endpoints get a fresh copy of the same response
+			second, err := p.AdjustEndpoints([]*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", "A", "1.2.3.4").WithRefObject(ref)})
+			require.NoError(t, err)
+			require.Len(t, second, 1)
+			assert.Equal(t, endpoint.TTL(300), second[0].RecordTTL)
+			assert.Equal(t, endpoint.Targets{"1.2.3.4"}, second[0].Targets)
+			assert.Equal(t, []*endpoint.ObjectRef{ref}, second[0].RefObjects())
+
+			// other endpoints invalidate the cache
+			third, err := p.AdjustEndpoints([]*endpoint.Endpoint{endpoint.NewEndpoint("b.example.com", "A", "1.2.3.4")})
+			require.NoError(t, err)
+			require.Len(t, third, 1)
+			assert.Equal(t, "b.example.com", third[0].DNSName)
+
+			assert.Equal(t, tt.expectedRequests, requests)
+		})
+	}
+}
+
 func TestAdjustendpointsWithError(t *testing.T) {
 	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
@@ -429,7 +541,7 @@ func TestAdjustendpointsWithError(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.NoError(t, err)
 	endpoints := []*endpoint.Endpoint{
 		{
@@ -473,7 +585,7 @@ func TestApplyChangesWithProviderSpecificProperty(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.NoError(t, err)
 	e := &endpoint.Endpoint{
 		DNSName:    "test.example.com",
@@ -633,7 +745,7 @@ func TestNewWebhookProvider_UsesInstrumentedTransport(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.NoError(t, err)
 
 	assert.IsType(t, &extdnshttp.CustomRoundTripper{}, p.client.Transport, "webhook provider client should use an instrumented transport")
@@ -651,7 +763,7 @@ func TestRecords_EmitsHTTPDurationMetric(t *testing.T) {
 	}))
 	defer svr.Close()
 
-	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout)
+	p, err := newProvider(t.Context(), svr.URL, testReadTimeout, testWriteTimeout, nil)
 	require.NoError(t, err)
 
 	before := httpDurationSampleCount(t, "records", http.MethodGet)
@@ -670,3 +782,54 @@ func httpDurationSampleCount(t *testing.T, path, method string) uint64 {
 		metrics.LabelMethod: method,
 	})
 }
+
+func TestNewTransport(t *testing.T) {
+	transport, err := newTransport(t.Context(), "", "", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, transport)
+
+	_, err = newTransport(t.Context(), "/missing-ca.crt", "", "", "")
+	require.Error(t, err)
+
+	serverCert, serverKey := testutils.TestHelperWriteCertificate(t, "server")
+	clientCert, clientKey := testutils.TestHelperWriteCertificate(t, "client")
+
+	certificate, err := tls.LoadX509KeyPair(serverCert, serverKey)
+	require.NoError(t, err)
+	clientCA, err := os.ReadFile(clientCert)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(clientCA))
+
+	var authorization string
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	svr.TLS = &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	svr.StartTLS()
+	defer svr.Close()
+
+	get := func(transport http.RoundTripper) error {
+		t.Helper()
+		resp, err := (&http.Client{Transport: transport}).Get(svr.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	transport, err = newTransport(t.Context(), serverCert, "", "", "")
+	require.NoError(t, err)
+	require.Error(t, get(transport), "the webhook requires a client certificate")
+
+	transport, err = newTransport(t.Context(), serverCert, clientCert, clientKey, "secret")
+	require.NoError(t, err)
+	require.NoError(t, get(transport))
+	assert.Equal(t, "Bearer secret", authorization)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// ZoneRecords lists the records of every zone with list, up to concurrency zones at a
+// time, and returns them in the order of zones. The first error cancels the other listings.
+func ZoneRecords[Z any](ctx context.Context, zones []Z, concurrency int, list func(context.Context, Z) ([]*endpoint.Endpoint, error)) ([]*endpoint.Endpoint, error) {
+	records, err := ListZones(ctx, zones, concurrency, list)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, r := range records {
+		endpoints = append(endpoints, r...)
+	}
+	return endpoints, nil
+}
+
+// ListZones calls list for every zone like ZoneRecords, and returns the result of each
+// zone at its index, for the providers needing the records of the zones apart.
+func ListZones[Z, R any](ctx context.Context, zones []Z, concurrency int, list func(context.Context, Z) (R, error)) ([]R, error) {
+	results := make([]R, len(zones))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(max(concurrency, 1))
+	for i, zone := range zones {
+		eg.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				// a listing failed, or the caller gave up
+				return err
+			}
+			var err error
+			results[i], err = list(ctx, zone)
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
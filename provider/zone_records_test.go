@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestZoneRecords(t *testing.T) {
+	zones := []string{"a.com", "b.com", "c.com", "d.com", "e.com"}
+	expected := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("b.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("c.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("d.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("e.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}
+
+	for _, concurrency := range []int{0, 1, 2, 10} {
+		var inFlight, maxInFlight atomic.Int32
+		records, err := ZoneRecords(t.Context(), zones, concurrency, func(_ context.Context, zone string) ([]*endpoint.Endpoint, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			// the first zones complete last, so that the order of completion is not the order of zones
+			time.Sleep(time.Duration(len(zones)-int(zone[0]-'a')) * time.Millisecond)
+			return []*endpoint.Endpoint{endpoint.NewEndpoint(zone, endpoint.RecordTypeA, "1.1.1.1")}, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, records, "concurrency %d", concurrency)
+		assert.LessOrEqual(t, maxInFlight.Load(), int32(max(concurrency, 1)), "concurrency %d", concurrency)
+	}
+}
+
+func TestZoneRecordsError(t *testing.T) {
+	errList := errors.New("list failed")
+
+	var listed atomic.Int32
+	_, err := ZoneRecords(t.Context(), []string{"a.com", "b.com", "c.com"}, 1, func(_ context.Context, zone string) ([]*endpoint.Endpoint, error) {
+		listed.Add(1)
+		if zone == "a.com" {
+			return nil, errList
+		}
+		return nil, nil
+	})
+	require.ErrorIs(t, err, errList)
+	assert.Equal(t, int32(1), listed.Load(), "the zones after a failure are not listed")
+
+	records, err := ZoneRecords(t.Context(), []string{}, 4, func(context.Context, string) ([]*endpoint.Endpoint, error) {
+		return nil, errList
+	})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
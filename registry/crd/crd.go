@@ -27,6 +27,7 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	rbacv1 "k8s.io/api/rbac/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -59,6 +60,12 @@ type CRDRegistry struct {
 	ownerID   string // refers to the owner id of the current instance
 }
 
+// RBACRules are the rules the registry needs on the DNSRecords of its namespace.
+var RBACRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"dnsrecords"}, Verbs: []string{"create", "delete", "get", "list", "update", "watch"}},
+	{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"dnsrecords/status"}, Verbs: []string{"update"}},
+}
+
 func New(cfg *externaldns.Config, p provider.Provider) (registry.Registry, error) {
 	return NewCRDRegistry(p, cfg.KubeConfig, cfg.APIServerURL, cfg.Namespace, cfg.TXTOwnerID, cfg.RequestTimeout)
 }
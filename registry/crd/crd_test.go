@@ -518,6 +518,10 @@ func (m *mockProvider) AdjustEndpoints(eps []*endpoint.Endpoint) ([]*endpoint.En
 	return eps, nil
 }
 
+func (m *mockProvider) SupportedRecordTypes() []string {
+	return nil
+}
+
 func (m *mockProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return &endpoint.DomainFilter{}
 }
@@ -58,7 +58,7 @@ type DynamoDBRegistry struct {
 
 	// For migration from TXT registry
 	mapper              mapper.NameMapper
-	wildcardReplacement string
+	wildcardReplacement mapper.WildcardReplacement
 	managedRecordTypes  []string
 	excludeRecordTypes  []string
 	txtEncryptAESKey    []byte
@@ -119,7 +119,7 @@ func newRegistry(
 		dynamodbAPI:         dynamodbAPI,
 		table:               table,
 		mapper:              mapper.NewAffixNameMapper(txtPrefix, txtSuffix, txtWildcardReplacement),
-		wildcardReplacement: txtWildcardReplacement,
+		wildcardReplacement: mapper.NewWildcardReplacement(txtWildcardReplacement),
 		managedRecordTypes:  managedRecordTypes,
 		excludeRecordTypes:  excludeRecordTypes,
 		txtEncryptAESKey:    txtEncryptAESKey,
@@ -197,14 +197,8 @@ func (im *DynamoDBRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint,
 				continue
 			}
 
-			dnsNameSplit := strings.Split(ep.DNSName, ".")
-			// If specified, replace a leading asterisk in the generated txt record name with some other string
-			if im.wildcardReplacement != "" && dnsNameSplit[0] == "*" {
-				dnsNameSplit[0] = im.wildcardReplacement
-			}
-			dnsName := strings.Join(dnsNameSplit, ".")
 			key := endpoint.EndpointKey{
-				DNSName:       dnsName,
+				DNSName:       im.wildcardReplacement.Replace(ep.DNSName),
 				SetIdentifier: ep.SetIdentifier,
 			}
 			if ep.RecordType == endpoint.RecordTypeAAAA {
@@ -236,6 +230,17 @@ func (im *DynamoDBRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint,
 	return endpoints, nil
 }
 
+// Reset drops the cached records and labels, and those of the provider, so that
+// the next Records call reads the live state.
+func (im *DynamoDBRegistry) Reset() {
+	im.recordsCache = nil
+	im.recordsCacheRefreshTime = time.Time{}
+	im.labels = nil
+	if r, ok := im.provider.(provider.CacheResetter); ok {
+		r.Reset()
+	}
+}
+
 // ApplyChanges updates the DNS provider and DynamoDB table with the changes.
 func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	filteredChanges := &plan.Changes{
@@ -247,6 +252,13 @@ func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Chan
 
 	statements := make([]dynamodbtypes.BatchStatementRequest, 0, len(filteredChanges.Create)+len(filteredChanges.UpdateNew))
 	for _, r := range filteredChanges.Create {
+		if r.IsUnmanagedLifecycle() {
+			// created without an ownership item, so it is never updated or deleted
+			if im.cacheInterval > 0 {
+				im.addToCache(r)
+			}
+			continue
+		}
 		if r.Labels == nil {
 			r.Labels = make(map[string]string)
 		}
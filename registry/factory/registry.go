@@ -19,6 +19,8 @@ package factory
 import (
 	"fmt"
 
+	rbacv1 "k8s.io/api/rbac/v1"
+
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/registry"
@@ -27,6 +29,7 @@ import (
 	"sigs.k8s.io/external-dns/registry/dynamodb"
 	"sigs.k8s.io/external-dns/registry/noop"
 	"sigs.k8s.io/external-dns/registry/txt"
+	"sigs.k8s.io/external-dns/registry/txtzone"
 )
 
 // RegistryConstructor is a function that creates a Registry from configuration and a provider.
@@ -49,7 +52,20 @@ func registries(selector string) (RegistryConstructor, bool) {
 		externaldns.RegistryTXT:      txt.New,
 		externaldns.RegistryAWSSD:    awssd.New,
 		externaldns.RegistryCRD:      crd.New,
+		externaldns.RegistryTXTZone:  txtzone.New,
 	}
 	c, ok := m[selector]
 	return c, ok
 }
+
+// RBACRules returns the Kubernetes API access needed by the named registry,
+// none for registries that keep their state outside the cluster.
+func RBACRules(selector string) ([]rbacv1.PolicyRule, error) {
+	if _, ok := registries(selector); !ok {
+		return nil, fmt.Errorf("unknown registry: %s", selector)
+	}
+	if selector == externaldns.RegistryCRD {
+		return crd.RBACRules, nil
+	}
+	return nil, nil
+}
@@ -29,9 +29,11 @@ import (
 	"sigs.k8s.io/external-dns/provider/inmemory"
 	"sigs.k8s.io/external-dns/registry"
 	"sigs.k8s.io/external-dns/registry/awssd"
+	"sigs.k8s.io/external-dns/registry/crd"
 	"sigs.k8s.io/external-dns/registry/dynamodb"
 	"sigs.k8s.io/external-dns/registry/noop"
 	"sigs.k8s.io/external-dns/registry/txt"
+	"sigs.k8s.io/external-dns/registry/txtzone"
 )
 
 var (
@@ -39,6 +41,7 @@ var (
 	_ registry.Registry = &dynamodb.DynamoDBRegistry{}
 	_ registry.Registry = &noop.NoopRegistry{}
 	_ registry.Registry = &txt.TXTRegistry{}
+	_ registry.Registry = &txtzone.TXTZoneRegistry{}
 )
 
 func TestSelectRegistry(t *testing.T) {
@@ -89,6 +92,26 @@ func TestSelectRegistry(t *testing.T) {
 			wantErr:  false,
 			wantType: "TXTRegistry",
 		},
+		{
+			name: "TXT zone registry",
+			cfg: &externaldns.Config{
+				Registry:      externaldns.RegistryTXTZone,
+				TXTOwnerID:    "owner-id",
+				TXTZoneApexes: []string{"example.org"},
+			},
+			provider: &fakeprovider.MockProvider{},
+			wantErr:  false,
+			wantType: "TXTZoneRegistry",
+		},
+		{
+			name: "TXT zone registry without zone apex",
+			cfg: &externaldns.Config{
+				Registry:   externaldns.RegistryTXTZone,
+				TXTOwnerID: "owner-id",
+			},
+			provider: &fakeprovider.MockProvider{},
+			wantErr:  true,
+		},
 		{
 			name: "aws-sd registry",
 			cfg: &externaldns.Config{
@@ -134,3 +157,16 @@ func TestSelectRegistryUnknown(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, reg)
 }
+
+func TestRBACRules(t *testing.T) {
+	rules, err := RBACRules(externaldns.RegistryCRD)
+	require.NoError(t, err)
+	assert.Equal(t, crd.RBACRules, rules)
+
+	rules, err = RBACRules(externaldns.RegistryTXT)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	_, err = RBACRules("bogus")
+	require.EqualError(t, err, "unknown registry: bogus")
+}
@@ -38,6 +38,8 @@ var (
 		endpoint.RecordTypePTR,
 		endpoint.RecordTypeSRV,
 		endpoint.RecordTypeNAPTR,
+		endpoint.RecordTypeCAA,
+		endpoint.RecordTypeDS,
 		endpoint.RecordTypeTXT,
 	}
 )
@@ -53,7 +55,7 @@ type NameMapper interface {
 type AffixNameMapper struct {
 	prefix              string
 	suffix              string
-	wildcardReplacement string
+	wildcardReplacement WildcardReplacement
 }
 
 // NewAffixNameMapper returns a new AffixNameMapper.
@@ -61,7 +63,7 @@ func NewAffixNameMapper(prefix, suffix, wildcardReplacement string) AffixNameMap
 	return AffixNameMapper{
 		prefix:              strings.ToLower(prefix),
 		suffix:              strings.ToLower(suffix),
-		wildcardReplacement: strings.ToLower(wildcardReplacement),
+		wildcardReplacement: NewWildcardReplacement(wildcardReplacement),
 	}
 }
 
@@ -91,18 +93,13 @@ func (a AffixNameMapper) ToEndpointName(dns string) (string, string) {
 }
 
 func (a AffixNameMapper) ToTXTName(dns, recordType string) string {
-	parts := strings.SplitN(dns, ".", 2)
+	parts := strings.SplitN(a.wildcardReplacement.Replace(dns), ".", 2)
 	recordType = strings.ToLower(recordType)
 	recordT := recordType + "-"
 
 	prefix := a.normalizeAffixTemplate(a.prefix, recordType)
 	suffix := a.normalizeAffixTemplate(a.suffix, recordType)
 
-	// If specified, replace a leading asterisk in the generated txt record name with some other string
-	if a.wildcardReplacement != "" && parts[0] == "*" {
-		parts[0] = a.wildcardReplacement
-	}
-
 	if !a.recordTypeInAffix() {
 		parts[0] = recordT + parts[0]
 	}
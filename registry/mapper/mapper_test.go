@@ -94,6 +94,20 @@ func TestAffixNameMapper_ToEndpointName(t *testing.T) {
 			wantEndpointName: "foo.example.com",
 			wantRecordType:   endpoint.RecordTypeNAPTR,
 		},
+		{
+			name:             "prefix with CAA record type in affix",
+			mapper:           NewAffixNameMapper("%{record_type}-", "", ""),
+			input:            "caa-foo.example.com",
+			wantEndpointName: "foo.example.com",
+			wantRecordType:   endpoint.RecordTypeCAA,
+		},
+		{
+			name:             "prefix with DS record type in affix",
+			mapper:           NewAffixNameMapper("%{record_type}-", "", ""),
+			input:            "ds-foo.example.com",
+			wantEndpointName: "foo.example.com",
+			wantRecordType:   endpoint.RecordTypeDS,
+		},
 		{
 			name:             "suffix with A record type in affix",
 			mapper:           NewAffixNameMapper("", "-%{record_type}", ""),
@@ -286,6 +300,20 @@ func TestAffixNameMapper_ToTXTName(t *testing.T) {
 			recordType:  endpoint.RecordTypeNAPTR,
 			wantTXTName: "naptr-foo.example.com",
 		},
+		{
+			name:        "prefix with CAA record type in affix",
+			mapper:      NewAffixNameMapper("%{record_type}-", "", ""),
+			dns:         "foo.example.com",
+			recordType:  endpoint.RecordTypeCAA,
+			wantTXTName: "caa-foo.example.com",
+		},
+		{
+			name:        "prefix with DS record type in affix",
+			mapper:      NewAffixNameMapper("%{record_type}-", "", ""),
+			dns:         "foo.example.com",
+			recordType:  endpoint.RecordTypeDS,
+			wantTXTName: "ds-foo.example.com",
+		},
 		{
 			name:        "prefix with TXT record type in affix",
 			mapper:      NewAffixNameMapper("%{record_type}-", "", ""),
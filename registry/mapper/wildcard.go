@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mapper
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// wildcardLabel is the leftmost label of a wildcard DNS name.
+const wildcardLabel = "*"
+
+// replacementLabel is what a wildcard replacement may look like: a single DNS
+// label, as it takes the place of one.
+var replacementLabel = regexp.MustCompile(`^[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9_])?$`)
+
+// WildcardReplacement is the label used in place of the leading "*" of wildcard
+// DNS names in the names of registry records, as some providers reject or
+// mangle an asterisk in TXT record names. Registries only map names this way,
+// from the record to its registry entry, so no replacement is ever reversed.
+// The zero value leaves names unchanged.
+type WildcardReplacement string
+
+// NewWildcardReplacement returns the replacement for the given label. DNS names
+// are compared in lower case, so is the replacement.
+func NewWildcardReplacement(label string) WildcardReplacement {
+	return WildcardReplacement(strings.ToLower(label))
+}
+
+// ValidateWildcardReplacement checks that label can replace a wildcard label.
+func ValidateWildcardReplacement(label string) error {
+	if label == "" {
+		return nil
+	}
+	if !replacementLabel.MatchString(strings.ToLower(label)) {
+		return fmt.Errorf("wildcard replacement %q must be a single DNS label", label)
+	}
+	return nil
+}
+
+// Replace returns name with its leading wildcard label replaced.
+func (w WildcardReplacement) Replace(name string) string {
+	if w == "" {
+		return name
+	}
+	label, rest, found := strings.Cut(name, ".")
+	if label != wildcardLabel {
+		return name
+	}
+	if !found {
+		return string(w)
+	}
+	return string(w) + "." + rest
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWildcardReplacement(t *testing.T) {
+	for _, tt := range []struct {
+		replacement string
+		name        string
+		expected    string
+	}{
+		{replacement: "", name: "*.example.com", expected: "*.example.com"},
+		{replacement: "wildcard", name: "*.example.com", expected: "wildcard.example.com"},
+		{replacement: "WildCard", name: "*.example.com", expected: "wildcard.example.com"},
+		{replacement: "wildcard", name: "*", expected: "wildcard"},
+		{replacement: "wildcard", name: "a.*.example.com", expected: "a.*.example.com"},
+		{replacement: "wildcard", name: "*a.example.com", expected: "*a.example.com"},
+		{replacement: "wildcard", name: "www.example.com", expected: "www.example.com"},
+	} {
+		t.Run(tt.replacement+"/"+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewWildcardReplacement(tt.replacement).Replace(tt.name))
+		})
+	}
+}
+
+func TestValidateWildcardReplacement(t *testing.T) {
+	for _, label := range []string{"", "wildcard", "Star", "_wc", "any-thing"} {
+		assert.NoError(t, ValidateWildcardReplacement(label), label)
+	}
+	for _, label := range []string{"*", "any.thing", "-wc", "wc-", "with space"} {
+		assert.Error(t, ValidateWildcardReplacement(label), label)
+	}
+}
@@ -58,6 +58,13 @@ func (im *NoopRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, erro
 	return im.provider.Records(ctx)
 }
 
+// Reset drops the records cached by the dns provider, if any.
+func (im *NoopRegistry) Reset() {
+	if r, ok := im.provider.(provider.CacheResetter); ok {
+		r.Reset()
+	}
+}
+
 // ApplyChanges propagates changes to the dns provider
 func (im *NoopRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	return im.provider.ApplyChanges(ctx, changes)
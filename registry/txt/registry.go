@@ -21,7 +21,7 @@ import (
 	b64 "encoding/base64"
 	"errors"
 	"maps"
-	"strings"
+	"slices"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/sets"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	txtvalue "sigs.k8s.io/external-dns/pkg/txt"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/registry"
@@ -53,7 +54,7 @@ type TXTRegistry struct {
 	// optional string to use to replace the asterisk in wildcard entries - without using this,
 	// registry TXT records corresponding to wildcard records will be invalid (and rejected by most providers), due to
 	// having a '*' appear (not as the first character) - see https://tools.ietf.org/html/rfc1034#section-4.3.3
-	wildcardReplacement string
+	wildcardReplacement mapper.WildcardReplacement
 
 	managedRecordTypes []string
 	excludeRecordTypes []string
@@ -68,6 +69,20 @@ type TXTRegistry struct {
 	// existingTXTs is the TXT records that already exist in the zone so that
 	// ApplyChanges() can skip re-creating them. See the struct below for details.
 	existingTXTs *existingTXTs
+
+	// cleanupOrphans deletes the TXT records of this owner left without the
+	// record they own, e.g. after the set identifier of a record changed.
+	cleanupOrphans bool
+	// labelUpdatesRegistryOnly applies the updates changing only the labels of
+	// a record to its TXT records, without rewriting the record itself.
+	labelUpdatesRegistryOnly bool
+	// resourceRefs stores the UIDs and namespaces of the source objects in the TXT records.
+	resourceRefs bool
+	// desiredHash stores the desired hash of the records in the TXT records.
+	desiredHash bool
+	// orphanedTXTs are the orphaned TXT records found by the last Records() call,
+	// by the key of the record they owned.
+	orphanedTXTs map[endpoint.EndpointKey]*endpoint.Endpoint
 }
 
 // existingTXTs stores pre‑existing TXT records to avoid duplicate creation.
@@ -114,10 +129,18 @@ func (im *existingTXTs) reset() {
 
 // New creates a TXTRegistry from the given configuration.
 func New(cfg *externaldns.Config, p provider.Provider) (registry.Registry, error) {
-	return newRegistry(p, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTOwnerID,
+	r, err := newRegistry(p, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTOwnerID,
 		cfg.TXTCacheInterval, cfg.TXTWildcardReplacement,
 		cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes,
 		cfg.TXTEncryptEnabled, []byte(cfg.TXTEncryptAESKey), cfg.TXTOwnerOld)
+	if err != nil {
+		return nil, err
+	}
+	r.cleanupOrphans = cfg.TXTCleanupOrphans
+	r.resourceRefs = cfg.TXTResourceRefs
+	r.labelUpdatesRegistryOnly = cfg.TXTLabelUpdatesRegistryOnly
+	r.desiredHash = cfg.RecordDesiredHash
+	return r, nil
 }
 
 // newRegistry returns a new TXTRegistry object. When newFormatOnly is true, it will only
@@ -155,7 +178,7 @@ func newRegistry(provider provider.Provider, txtPrefix, txtSuffix, ownerID strin
 		ownerID:             ownerID,
 		mapper:              mapper.NewAffixNameMapper(txtPrefix, txtSuffix, txtWildcardReplacement),
 		cacheInterval:       cacheInterval,
-		wildcardReplacement: txtWildcardReplacement,
+		wildcardReplacement: mapper.NewWildcardReplacement(txtWildcardReplacement),
 		managedRecordTypes:  managedRecordTypes,
 		excludeRecordTypes:  excludeRecordTypes,
 		txtEncryptEnabled:   txtEncryptEnabled,
@@ -201,6 +224,7 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 
 	labelMap := map[endpoint.EndpointKey]endpoint.Labels{}
 	txtRecordsSet := make(sets.Set[string], len(records))
+	txtRecords := map[endpoint.EndpointKey]*endpoint.Endpoint{}
 
 	for _, record := range records {
 		if record.RecordType != endpoint.RecordTypeTXT {
@@ -213,7 +237,7 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 			log.Errorf("TXT record has no targets %s", record.DNSName)
 			continue
 		}
-		labels, err := endpoint.NewLabelsFromString(record.Targets[0], im.txtEncryptAESKey)
+		labels, err := endpoint.NewLabelsFromString(txtvalue.Decode(record.Targets[0]), im.txtEncryptAESKey)
 		if errors.Is(err, endpoint.ErrInvalidHeritage) {
 			// if no heritage is found or it is invalid
 			// case when value of txt record cannot be identified
@@ -229,29 +253,18 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 			SetIdentifier: record.SetIdentifier,
 		}
 		labelMap[key] = labels
+		txtRecords[key] = record
 		txtRecordsSet.Insert(record.DNSName)
 		im.existingTXTs.add(record)
 	}
 
+	ownedKeys := make(sets.Set[endpoint.EndpointKey], len(endpoints))
 	for _, ep := range endpoints {
 		if ep.Labels == nil {
 			ep.Labels = endpoint.NewLabels()
 		}
-		dnsNameSplit := strings.Split(ep.DNSName, ".")
-		// If specified, replace a leading asterisk in the generated txt record name with some other string
-		if im.wildcardReplacement != "" && dnsNameSplit[0] == "*" {
-			dnsNameSplit[0] = im.wildcardReplacement
-		}
-		dnsName := strings.Join(dnsNameSplit, ".")
-		key := endpoint.EndpointKey{
-			DNSName:       dnsName,
-			RecordType:    ep.RecordType,
-			SetIdentifier: ep.SetIdentifier,
-		}
-
-		if shouldUseCNAMEForTxtRecord(ep) {
-			key.RecordType = endpoint.RecordTypeCNAME
-		}
+		key := im.ownershipKey(ep)
+		ownedKeys.Insert(key)
 
 		// Handle both new and old registry format with the preference for the new one
 		labels, labelsExist := labelMap[key]
@@ -283,6 +296,8 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 		}
 	}
 
+	im.orphanedTXTs = im.findOrphanedTXTs(txtRecords, labelMap, ownedKeys)
+
 	// Update the cache.
 	if im.cacheInterval > 0 {
 		im.recordsCache = endpoints
@@ -292,6 +307,41 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 	return endpoints, nil
 }
 
+// ownershipKey returns the key of the TXT record owning ep: its name, with the
+// wildcard replaced, record type and set identifier.
+func (im *TXTRegistry) ownershipKey(ep *endpoint.Endpoint) endpoint.EndpointKey {
+	key := endpoint.EndpointKey{
+		DNSName:       im.wildcardReplacement.Replace(ep.DNSName),
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+	}
+	if shouldUseCNAMEForTxtRecord(ep) {
+		key.RecordType = endpoint.RecordTypeCNAME
+	}
+	return key
+}
+
+// findOrphanedTXTs returns the TXT records of this owner whose owned record,
+// identified by name, type and set identifier, does not exist anymore. Only
+// records in the new format for managed record types are considered, as the
+// owned record of other TXT records cannot be told apart reliably.
+func (im *TXTRegistry) findOrphanedTXTs(txtRecords map[endpoint.EndpointKey]*endpoint.Endpoint,
+	labelMap map[endpoint.EndpointKey]endpoint.Labels, ownedKeys sets.Set[endpoint.EndpointKey]) map[endpoint.EndpointKey]*endpoint.Endpoint {
+	if !im.cleanupOrphans {
+		return nil
+	}
+	orphans := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for key, record := range txtRecords {
+		if key.RecordType == "" || ownedKeys.Has(key) || labelMap[key][endpoint.OwnerLabelKey] != im.ownerID ||
+			!plan.IsManagedRecord(key.RecordType, im.managedRecordTypes, im.excludeRecordTypes) {
+			continue
+		}
+		log.Infof("Found orphaned TXT record %s (set identifier %q) without %s record %s", record.DNSName, key.SetIdentifier, key.RecordType, key.DNSName)
+		orphans[key] = record
+	}
+	return orphans
+}
+
 // shouldUseCNAMEForTxtRecord checks if the endpoint is an alias A record converted from CNAME.
 // TXT ownership records use CNAME as the record type for such records.
 func shouldUseCNAMEForTxtRecord(ep *endpoint.Endpoint) bool {
@@ -341,12 +391,26 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 		UpdateOld: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateOld),
 		Delete:    endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.Delete),
 	}
+	labelOnly := im.labelOnlyUpdates(filteredChanges)
 
 	for _, r := range filteredChanges.Create {
 		if r.Labels == nil {
 			r.Labels = make(map[string]string)
 		}
+		if r.IsUnmanagedLifecycle() {
+			// created without an ownership record, so it is never updated or deleted
+			if im.cacheInterval > 0 {
+				im.addToCache(r)
+			}
+			continue
+		}
 		r.Labels[endpoint.OwnerLabelKey] = im.ownerID
+		if im.resourceRefs {
+			r.Labels.SetResourceRefs(r.RefObjects())
+		}
+		if im.desiredHash {
+			r.Labels[endpoint.DesiredHashLabelKey] = r.DesiredHash()
+		}
 
 		filteredChanges.Create = append(filteredChanges.Create, im.generateTXTRecordWithFilter(r, im.existingTXTs.isAbsent)...)
 
@@ -379,6 +443,12 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 
 	// make sure TXT records are consistently updated as well
 	for _, r := range filteredChanges.UpdateNew {
+		if im.resourceRefs {
+			r.Labels.SetResourceRefs(r.RefObjects())
+		}
+		if im.desiredHash {
+			r.Labels[endpoint.DesiredHashLabelKey] = r.DesiredHash()
+		}
 		filteredChanges.UpdateNew = append(filteredChanges.UpdateNew, im.generateTXTRecord(r)...)
 		// add new version of record to cache
 		if im.cacheInterval > 0 {
@@ -386,6 +456,16 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 		}
 	}
 
+	filteredChanges.Delete = append(filteredChanges.Delete, im.orphansToDelete(filteredChanges)...)
+	im.orphanedTXTs = nil
+
+	if len(labelOnly) > 0 {
+		updates := len(filteredChanges.UpdateNew)
+		filteredChanges.UpdateOld = slices.DeleteFunc(filteredChanges.UpdateOld, labelOnly.Has)
+		filteredChanges.UpdateNew = slices.DeleteFunc(filteredChanges.UpdateNew, labelOnly.Has)
+		log.Debugf("Applied %d label only updates to the TXT registry records only", updates-len(filteredChanges.UpdateNew))
+	}
+
 	// when caching is enabled, disable the provider from using the cache
 	if im.cacheInterval > 0 {
 		ctx = context.WithValue(ctx, provider.RecordsContextKey, nil)
@@ -393,6 +473,60 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	return im.provider.ApplyChanges(ctx, filteredChanges)
 }
 
+// Reset drops the cached records, and those of the provider, so that the next
+// Records call reads the live provider state.
+func (im *TXTRegistry) Reset() {
+	im.recordsCache = nil
+	im.recordsCacheRefreshTime = time.Time{}
+	if r, ok := im.provider.(provider.CacheResetter); ok {
+		r.Reset()
+	}
+}
+
+// labelOnlyUpdates returns the old and new records of the updates which only change
+// the labels of a record, when they are applied to the TXT records only.
+func (im *TXTRegistry) labelOnlyUpdates(changes *plan.Changes) sets.Set[*endpoint.Endpoint] {
+	labelOnly := sets.New[*endpoint.Endpoint]()
+	if !im.labelUpdatesRegistryOnly || len(changes.UpdateOld) != len(changes.UpdateNew) {
+		return labelOnly
+	}
+	for i, updateNew := range changes.UpdateNew {
+		updateOld := changes.UpdateOld[i]
+		if updateOld.Key() != updateNew.Key() || plan.RecordChanged(updateNew, updateOld) {
+			continue
+		}
+		labelOnly.Insert(updateOld)
+		labelOnly.Insert(updateNew)
+	}
+	return labelOnly
+}
+
+// orphansToDelete returns the orphaned TXT records which are still orphaned
+// after changes: not deleted already, nor reused by a created or updated record.
+func (im *TXTRegistry) orphansToDelete(changes *plan.Changes) []*endpoint.Endpoint {
+	if len(im.orphanedTXTs) == 0 {
+		return nil
+	}
+	owned := sets.New[endpoint.EndpointKey]()
+	deleted := sets.New[endpoint.EndpointKey]()
+	for _, ep := range slices.Concat(changes.Create, changes.UpdateNew) {
+		owned.Insert(im.ownershipKey(ep))
+	}
+	for _, ep := range changes.Delete {
+		deleted.Insert(ep.Key())
+	}
+	var orphans []*endpoint.Endpoint
+	for key, orphan := range im.orphanedTXTs {
+		if !owned.Has(key) && !deleted.Has(orphan.Key()) {
+			orphans = append(orphans, orphan)
+		}
+	}
+	if len(orphans) > 0 {
+		log.Infof("Deleting %d orphaned TXT records", len(orphans))
+	}
+	return orphans
+}
+
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
 func (im *TXTRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	return im.provider.AdjustEndpoints(endpoints)
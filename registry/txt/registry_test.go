@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -35,6 +36,7 @@ import (
 	"sigs.k8s.io/external-dns/internal/testutils"
 	logtest "sigs.k8s.io/external-dns/internal/testutils/log"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/provider/inmemory"
@@ -1432,6 +1434,19 @@ func TestCacheMethods(t *testing.T) {
 	}
 }
 
+func TestResetClearsCache(t *testing.T) {
+	registry := &TXTRegistry{
+		recordsCache:            []*endpoint.Endpoint{newEndpointWithOwner("thing.com", "1.2.3.4", "A", "owner")},
+		recordsCacheRefreshTime: time.Now(),
+		cacheInterval:           time.Hour,
+	}
+
+	registry.Reset()
+
+	assert.Nil(t, registry.recordsCache)
+	assert.True(t, registry.recordsCacheRefreshTime.IsZero())
+}
+
 func TestNewTXTScheme(t *testing.T) {
 	p := inmemory.NewInMemoryProvider()
 	err := p.CreateZone(testZone)
@@ -1991,6 +2006,10 @@ func TestTXTRegistryRecreatesMissingRecords(t *testing.T) {
 	for _, tt := range tests {
 		for _, setIdentifier := range []string{"", "set-identifier"} {
 			for pName, policy := range plan.Policies {
+				if _, ok := policy.(*plan.DeleteOnlyPolicy); ok {
+					// delete-only never creates records, missing or not.
+					continue
+				}
 				// Clone inputs per policy to avoid data races when using t.Parallel.
 				desired := cloneEndpointsWithOpts(tt.desired, func(e *endpoint.Endpoint) {
 					e.WithSetIdentifier(setIdentifier)
@@ -2194,3 +2213,276 @@ func TestRecreateRecordAfterDeletion(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, testutils.SameEndpoints(records, append(desired, txtRecord...)), "Expected records after reconciliation: %v, but got: %v", append(desired, txtRecord...), records)
 }
+
+func TestTXTRegistryCleanupOrphans(t *testing.T) {
+	withSetIdentifier := func(ep *endpoint.Endpoint, id string) *endpoint.Endpoint {
+		return ep.WithSetIdentifier(id)
+	}
+	existing := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			// weighted record whose identifier changed from "blue" to "green" out of band
+			withSetIdentifier(newEndpointWithOwner("app.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, ""), "green"),
+			withSetIdentifier(newEndpointWithOwner("a-app.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""), "green"),
+			withSetIdentifier(newEndpointWithOwner("a-app.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""), "blue"),
+			// orphan owned by another instance
+			withSetIdentifier(newEndpointWithOwner("a-app.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=other\"", endpoint.RecordTypeTXT, ""), "red"),
+			// orphan of a record created again in this sync
+			newEndpointWithOwner("a-new.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			// orphan in the old format
+			newEndpointWithOwner("old.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			// orphan of an unmanaged record type
+			newEndpointWithOwner("mx-mail.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+		},
+	}
+
+	for _, cleanup := range []bool{false, true} {
+		t.Run(fmt.Sprintf("cleanup=%t", cleanup), func(t *testing.T) {
+			ctx := t.Context()
+			p := inmemory.NewInMemoryProvider()
+			require.NoError(t, p.CreateZone(testZone))
+			require.NoError(t, p.ApplyChanges(ctx, existing))
+
+			r, err := newRegistry(p, "%{record_type}-", "", "owner", 0, "", []string{endpoint.RecordTypeA}, []string{}, false, nil, "")
+			require.NoError(t, err)
+			r.cleanupOrphans = cleanup
+
+			records, err := r.Records(ctx)
+			require.NoError(t, err)
+			assert.Len(t, records, 1)
+
+			require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+				Create: []*endpoint.Endpoint{newEndpointWithOwner("new.test-zone.example.org", "2.2.2.2", endpoint.RecordTypeA, "")},
+			}))
+
+			current, err := p.Records(ctx)
+			require.NoError(t, err)
+			var txtKeys []string
+			for _, ep := range current {
+				if ep.RecordType == endpoint.RecordTypeTXT {
+					txtKeys = append(txtKeys, ep.DNSName+"/"+ep.SetIdentifier)
+				}
+			}
+			expected := []string{
+				"a-app.test-zone.example.org/green",
+				"a-app.test-zone.example.org/red",
+				"a-new.test-zone.example.org/",
+				"old.test-zone.example.org/",
+				"mx-mail.test-zone.example.org/",
+			}
+			if !cleanup {
+				expected = append(expected, "a-app.test-zone.example.org/blue")
+			}
+			assert.ElementsMatch(t, expected, txtKeys)
+		})
+	}
+}
+
+func TestTXTRegistryLabelUpdatesRegistryOnly(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("enabled=%t", enabled), func(t *testing.T) {
+			ctx := t.Context()
+			p := inmemory.NewInMemoryProvider()
+			require.NoError(t, p.CreateZone(testZone))
+			require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+				Create: []*endpoint.Endpoint{
+					newEndpointWithOwner("owner.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, ""),
+					newEndpointWithOwner("a-owner.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=new,external-dns/resource=service/default/a\"", endpoint.RecordTypeTXT, ""),
+					newEndpointWithOwner("target.test-zone.example.org", "2.2.2.2", endpoint.RecordTypeA, ""),
+					newEndpointWithOwner("a-target.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=new\"", endpoint.RecordTypeTXT, ""),
+				},
+			}))
+
+			r, err := newRegistry(p, "%{record_type}-", "", "new", 0, "", []string{endpoint.RecordTypeA}, []string{}, false, nil, "")
+			require.NoError(t, err)
+			r.labelUpdatesRegistryOnly = enabled
+
+			records, err := r.Records(ctx)
+			require.NoError(t, err)
+			require.Len(t, records, 2)
+			slices.SortFunc(records, func(a, b *endpoint.Endpoint) int { return strings.Compare(a.DNSName, b.DNSName) })
+
+			// the resource update only changes the labels, the target update changes the record
+			labelUpdate := records[0].DeepCopy()
+			labelUpdate.Labels[endpoint.ResourceLabelKey] = "service/default/b"
+			targetUpdate := records[1].DeepCopy()
+			targetUpdate.Targets = endpoint.Targets{"3.3.3.3"}
+
+			var got *plan.Changes
+			p.OnApplyChanges = func(_ context.Context, changes *plan.Changes) {
+				got = changes
+			}
+			require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+				UpdateOld: []*endpoint.Endpoint{records[0], records[1]},
+				UpdateNew: []*endpoint.Endpoint{labelUpdate, targetUpdate},
+			}))
+
+			updated := func(eps []*endpoint.Endpoint) []string {
+				var names []string
+				for _, ep := range eps {
+					names = append(names, ep.RecordType+" "+ep.DNSName)
+				}
+				return names
+			}
+			expected := []string{
+				"A owner.test-zone.example.org",
+				"A target.test-zone.example.org",
+				"TXT a-owner.test-zone.example.org",
+				"TXT a-target.test-zone.example.org",
+			}
+			if enabled {
+				expected = slices.Delete(expected, 0, 1)
+			}
+			require.NotNil(t, got)
+			assert.ElementsMatch(t, expected, updated(got.UpdateOld))
+			assert.ElementsMatch(t, expected, updated(got.UpdateNew))
+
+			records, err = r.Records(ctx)
+			require.NoError(t, err)
+			slices.SortFunc(records, func(a, b *endpoint.Endpoint) int { return strings.Compare(a.DNSName, b.DNSName) })
+			assert.Equal(t, "service/default/b", records[0].Labels[endpoint.ResourceLabelKey])
+			assert.Equal(t, "1.1.1.1", records[0].Targets[0])
+			assert.Equal(t, "3.3.3.3", records[1].Targets[0])
+		})
+	}
+}
+
+func TestTXTRegistryResourceRefs(t *testing.T) {
+	ref := events.NewObjectReferenceFromParts("Service", "v1", "web", "frontend", "1234-abcd", "service")
+
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("enabled=%t", enabled), func(t *testing.T) {
+			ctx := t.Context()
+			p := inmemory.NewInMemoryProvider()
+			require.NoError(t, p.CreateZone(testZone))
+
+			r, err := newRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA}, []string{}, false, nil, "")
+			require.NoError(t, err)
+			r.resourceRefs = enabled
+
+			require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+				Create: []*endpoint.Endpoint{
+					newEndpointWithOwner("new.test-zone.example.org", "2.2.2.2", endpoint.RecordTypeA, "").WithRefObject(ref),
+				},
+			}))
+
+			records, err := r.Records(ctx)
+			require.NoError(t, err)
+			require.Len(t, records, 1)
+			if enabled {
+				assert.Equal(t, []string{"1234-abcd"}, records[0].Labels.ResourceUIDs())
+				assert.Equal(t, []string{"web"}, records[0].Labels.ResourceNamespaces())
+			} else {
+				assert.Nil(t, records[0].Labels.ResourceUIDs())
+				assert.Nil(t, records[0].Labels.ResourceNamespaces())
+			}
+		})
+	}
+}
+
+func TestTXTRegistryDesiredHash(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+
+	r, err := newRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA}, []string{}, false, nil, "")
+	require.NoError(t, err)
+	r.desiredHash = true
+
+	desired := newEndpointWithOwner("new.test-zone.example.org", "2.2.2.2", endpoint.RecordTypeA, "")
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{desired}}))
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, desired.DesiredHash(), records[0].Labels[endpoint.DesiredHashLabelKey])
+
+	updated := newEndpointWithOwner("new.test-zone.example.org", "3.3.3.3", endpoint.RecordTypeA, "owner")
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{records[0]},
+		UpdateNew: []*endpoint.Endpoint{updated},
+	}))
+
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, updated.DesiredHash(), records[0].Labels[endpoint.DesiredHashLabelKey])
+	assert.NotEqual(t, desired.DesiredHash(), updated.DesiredHash())
+}
+
+func TestTXTRegistryUnmanagedLifecycle(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+
+	r, err := newRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA, endpoint.RecordTypeNS}, []string{}, false, nil, "")
+	require.NoError(t, err)
+
+	delegation := endpoint.NewEndpoint("sub.test-zone.example.org", endpoint.RecordTypeNS, "ns1.example.net")
+	delegation.SetProviderSpecificProperty(endpoint.ProviderSpecificUnmanagedLifecycle, "true")
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			delegation,
+			newEndpointWithOwner("new.test-zone.example.org", "2.2.2.2", endpoint.RecordTypeA, ""),
+		},
+	}))
+
+	providerRecords, err := p.Records(ctx)
+	require.NoError(t, err)
+	// only the managed record gets an ownership TXT record
+	assert.Len(t, providerRecords, 3)
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	for _, record := range records {
+		if record.RecordType == endpoint.RecordTypeNS {
+			assert.Empty(t, record.Labels[endpoint.OwnerLabelKey])
+		} else {
+			assert.Equal(t, "owner", record.Labels[endpoint.OwnerLabelKey])
+		}
+	}
+}
+
+func TestTXTRegistryWildcardReplacementRoundTrip(t *testing.T) {
+	for _, replacement := range []string{"", "wildcard", "WildCard"} {
+		t.Run(replacement, func(t *testing.T) {
+			ctx := t.Context()
+			p := inmemory.NewInMemoryProvider()
+			require.NoError(t, p.CreateZone(testZone))
+
+			r, err := newRegistry(p, "", "", "owner", 0, replacement, []string{endpoint.RecordTypeCNAME}, []string{}, false, nil, "")
+			require.NoError(t, err)
+			require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+				Create: []*endpoint.Endpoint{
+					newEndpointWithOwner("*.wc.test-zone.example.org", "lb.example.com", endpoint.RecordTypeCNAME, ""),
+				},
+			}))
+
+			records, err := r.Records(ctx)
+			require.NoError(t, err)
+			require.Len(t, records, 1)
+			assert.Equal(t, "*.wc.test-zone.example.org", records[0].DNSName)
+			assert.Equal(t, "owner", records[0].Labels[endpoint.OwnerLabelKey])
+		})
+	}
+}
+
+func TestTXTRegistryRecordsSplitLabels(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("split.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+			// a label value split into two character strings, as providers return long values
+			newEndpointWithOwner("a-split.test-zone.example.org", `"heritage=external-dns,external-dns/owner=" "owner"`, endpoint.RecordTypeTXT, ""),
+		},
+	}))
+
+	r, err := newRegistry(p, "", "", "owner", 0, "", []string{}, []string{}, false, nil, "")
+	require.NoError(t, err)
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "owner", records[0].Labels[endpoint.OwnerLabelKey])
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txtzone
+
+import (
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// The ownership data of an owner is stored as the gzipped JSON list of its records, base64
+// encoded and split into chunks that each fit a TXT character string:
+//
+//	"heritage=external-dns-v3,owner=<owner>,chunk=<i>/<n>,data=<chunk>"
+//
+// Values of other owners and values not written by the registry are kept as they are.
+const (
+	valuePrefix = "heritage=external-dns-v3,owner="
+	// maxValueLength is the maximum length of a TXT character string, including the quotes.
+	maxValueLength = 255
+	// minChunkLength guards against owner IDs leaving no room for the data.
+	minChunkLength = 64
+)
+
+// ownershipEntry is the ownership data of a single record. The owner label is implied by the value.
+type ownershipEntry struct {
+	DNSName       string            `json:"n"`
+	RecordType    string            `json:"t"`
+	SetIdentifier string            `json:"s,omitempty"`
+	Labels        map[string]string `json:"l,omitempty"`
+}
+
+// encodeOwnership returns the values storing the labels of the records owned by ownerID.
+func encodeOwnership(ownerID string, owned map[endpoint.EndpointKey]endpoint.Labels) ([]string, error) {
+	if len(owned) == 0 {
+		return nil, nil
+	}
+
+	entries := make([]ownershipEntry, 0, len(owned))
+	for key, labels := range owned {
+		labels = maps.Clone(labels)
+		delete(labels, endpoint.OwnerLabelKey)
+		if len(labels) == 0 {
+			labels = nil
+		}
+		entries = append(entries, ownershipEntry{
+			DNSName:       key.DNSName,
+			RecordType:    key.RecordType,
+			SetIdentifier: key.SetIdentifier,
+			Labels:        labels,
+		})
+	}
+	slices.SortFunc(entries, func(a, b ownershipEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.DNSName, b.DNSName),
+			cmp.Compare(a.RecordType, b.RecordType),
+			cmp.Compare(a.SetIdentifier, b.SetIdentifier),
+		)
+	})
+
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ownership data: %w", err)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		return nil, fmt.Errorf("failed to compress ownership data: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress ownership data: %w", err)
+	}
+	data := base64.RawStdEncoding.EncodeToString(buf.Bytes())
+
+	// Reserve room for chunk numbers of up to four digits.
+	chunkLength := maxValueLength - len(`"`+valuePrefix+ownerID+",chunk=9999/9999,data="+`"`)
+	if chunkLength < minChunkLength {
+		return nil, fmt.Errorf("owner id %q is too long to store ownership data in TXT records", ownerID)
+	}
+	chunks := slices.Collect(slices.Chunk([]byte(data), chunkLength))
+	values := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		values = append(values, fmt.Sprintf(`"%s%s,chunk=%d/%d,data=%s"`, valuePrefix, ownerID, i+1, len(chunks), chunk))
+	}
+	return values, nil
+}
+
+// decodeOwnership returns the labels of the records stored in the values of the ownership
+// record set name, by owner. The data of an owner with missing or invalid chunks is skipped.
+func decodeOwnership(name string, values []string) map[string]map[endpoint.EndpointKey]endpoint.Labels {
+	chunks := map[string][]string{}
+	for _, value := range values {
+		owner, ok := valueOwner(value)
+		if !ok {
+			continue
+		}
+		var index, total int
+		rest := strings.TrimPrefix(strings.Trim(value, `"`), valuePrefix+owner+",chunk=")
+		position, data, found := strings.Cut(rest, ",data=")
+		if _, err := fmt.Sscanf(position, "%d/%d", &index, &total); !found || err != nil || index < 1 || index > total {
+			log.Warnf("Ignoring invalid ownership value %q in %s", value, name)
+			continue
+		}
+		if chunks[owner] == nil {
+			chunks[owner] = make([]string, total)
+		}
+		if len(chunks[owner]) != total {
+			log.Warnf("Ignoring ownership data of %s in %s: inconsistent chunk count", owner, name)
+			chunks[owner] = []string{}
+			continue
+		}
+		chunks[owner][index-1] = data
+	}
+
+	result := make(map[string]map[endpoint.EndpointKey]endpoint.Labels, len(chunks))
+	for owner, parts := range chunks {
+		if len(parts) == 0 || slices.Contains(parts, "") {
+			log.Warnf("Ignoring incomplete ownership data of %s in %s", owner, name)
+			continue
+		}
+		entries, err := decodeEntries(strings.Join(parts, ""))
+		if err != nil {
+			log.Warnf("Ignoring ownership data of %s in %s: %v", owner, name, err)
+			continue
+		}
+		owned := make(map[endpoint.EndpointKey]endpoint.Labels, len(entries))
+		for _, entry := range entries {
+			labels := endpoint.NewLabels()
+			maps.Copy(labels, entry.Labels)
+			labels[endpoint.OwnerLabelKey] = owner
+			owned[endpoint.EndpointKey{
+				DNSName:       entry.DNSName,
+				RecordType:    entry.RecordType,
+				SetIdentifier: entry.SetIdentifier,
+			}] = labels
+		}
+		result[owner] = owned
+	}
+	return result
+}
+
+func decodeEntries(data string) ([]ownershipEntry, error) {
+	compressed, err := base64.RawStdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	var entries []ownershipEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// valueOwner returns the owner of an ownership value, or false for values not written by the registry.
+func valueOwner(value string) (string, bool) {
+	rest, ok := strings.CutPrefix(strings.Trim(value, `"`), valuePrefix)
+	if !ok {
+		return "", false
+	}
+	owner, _, ok := strings.Cut(rest, ",chunk=")
+	return owner, ok
+}
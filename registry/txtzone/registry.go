@@ -0,0 +1,381 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txtzone
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/sets"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+	"sigs.k8s.io/external-dns/registry/mapper"
+)
+
+const (
+	// recordLabel is the leftmost label of the TXT record set holding the ownership data of a zone.
+	recordLabel = "_external-dns"
+
+	providerSpecificMigrate = "txt-zone/needs-migration"
+)
+
+// TXTZoneRegistry implements registry interface with ownership implemented via a single
+// TXT record set per zone, instead of a TXT record next to every owned record.
+type TXTZoneRegistry struct {
+	provider provider.Provider
+	ownerID  string // refers to the owner id of the current instance
+	// apexes are the zones holding an ownership record set, longest first.
+	apexes []string
+
+	// For migration from TXT registry
+	mapper              mapper.NameMapper
+	wildcardReplacement mapper.WildcardReplacement
+	managedRecordTypes  []string
+	excludeRecordTypes  []string
+
+	// zoneRecords are the ownership record sets found by the last Records() call, by zone apex.
+	zoneRecords map[string]*endpoint.Endpoint
+	// owned are the labels of the records of this owner, by zone apex.
+	owned map[string]map[endpoint.EndpointKey]endpoint.Labels
+}
+
+// New creates a TXTZoneRegistry from the given configuration.
+func New(cfg *externaldns.Config, p provider.Provider) (registry.Registry, error) {
+	return newRegistry(p, cfg.TXTOwnerID, cfg.TXTZoneApexes,
+		cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTWildcardReplacement,
+		cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes)
+}
+
+// newRegistry returns a new TXTZoneRegistry object. The TXT prefix, suffix and wildcard
+// replacement are only used to read the records of the TXT registry when migrating from it.
+func newRegistry(provider provider.Provider, ownerID string, apexes []string,
+	txtPrefix, txtSuffix, txtWildcardReplacement string,
+	managedRecordTypes, excludeRecordTypes []string) (*TXTZoneRegistry, error) {
+	if ownerID == "" {
+		return nil, errors.New("owner id cannot be empty")
+	}
+	if strings.ContainsAny(ownerID, ",\"") {
+		return nil, errors.New("owner id cannot contain commas or quotes")
+	}
+	if len(txtPrefix) > 0 && len(txtSuffix) > 0 {
+		return nil, errors.New("txt-prefix and txt-suffix are mutually exclusive")
+	}
+
+	zones := sets.New[string]()
+	for _, apex := range apexes {
+		if apex = normalizeName(apex); apex != "" {
+			zones.Insert(apex)
+		}
+	}
+	if len(zones) == 0 {
+		return nil, errors.New("at least one zone apex must be set")
+	}
+	sorted := sets.Sorted(zones)
+	slices.SortStableFunc(sorted, func(a, b string) int { return len(b) - len(a) })
+
+	return &TXTZoneRegistry{
+		provider:            provider,
+		ownerID:             ownerID,
+		apexes:              sorted,
+		mapper:              mapper.NewAffixNameMapper(txtPrefix, txtSuffix, txtWildcardReplacement),
+		wildcardReplacement: mapper.NewWildcardReplacement(txtWildcardReplacement),
+		managedRecordTypes:  managedRecordTypes,
+		excludeRecordTypes:  excludeRecordTypes,
+		owned:               map[string]map[endpoint.EndpointKey]endpoint.Labels{},
+	}, nil
+}
+
+// GetDomainFilter returns the domain filter from the underlying provider.
+func (im *TXTZoneRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
+	return im.provider.GetDomainFilter()
+}
+
+// OwnerID returns the owner identifier used to label records managed by this registry.
+func (im *TXTZoneRegistry) OwnerID() string {
+	return im.ownerID
+}
+
+// Records returns the current records from the registry, excluding the ownership record sets.
+// The labels of the records are read from the ownership record set of their zone or, for
+// records not migrated yet, from the TXT records of the TXT registry.
+func (im *TXTZoneRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	records, err := im.provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneRecords := map[string]*endpoint.Endpoint{}
+	owned := map[string]map[endpoint.EndpointKey]endpoint.Labels{}
+	labelMap := map[endpoint.EndpointKey]endpoint.Labels{}
+	txtLabelMap := map[endpoint.EndpointKey]endpoint.Labels{}
+	txtRecordsMap := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	endpoints := make([]*endpoint.Endpoint, 0, len(records))
+
+	for _, record := range records {
+		if record.RecordType != endpoint.RecordTypeTXT || len(record.Targets) == 0 {
+			endpoints = append(endpoints, record)
+			continue
+		}
+
+		if apex, ok := im.zoneOfRecordSet(record.DNSName); ok {
+			zoneRecords[apex] = record
+			for owner, entries := range decodeOwnership(record.DNSName, record.Targets) {
+				for key, labels := range entries {
+					labelMap[key] = labels
+				}
+				if owner == im.ownerID {
+					owned[apex] = entries
+				}
+			}
+			continue
+		}
+
+		// We simply assume that TXT records for the TXT registry will always have only one target.
+		if labels, err := endpoint.NewLabelsFromString(record.Targets[0], nil); err == nil {
+			endpointName, recordType := im.mapper.ToEndpointName(record.DNSName)
+			key := endpoint.EndpointKey{
+				DNSName:       endpointName,
+				RecordType:    recordType,
+				SetIdentifier: record.SetIdentifier,
+			}
+			txtLabelMap[key] = labels
+			txtRecordsMap[key] = record
+			continue
+		}
+
+		endpoints = append(endpoints, record)
+	}
+
+	for _, ep := range endpoints {
+		if ep.Labels == nil {
+			ep.Labels = endpoint.NewLabels()
+		}
+		if labels, ok := labelMap[ep.Key()]; ok {
+			maps.Copy(ep.Labels, labels)
+			continue
+		}
+
+		// Migrate label data from TXT registry.
+		key := im.txtOwnershipKey(ep)
+		labels, ok := txtLabelMap[key]
+		if !ok && ep.RecordType != endpoint.RecordTypeAAAA {
+			key.RecordType = ""
+			labels, ok = txtLabelMap[key]
+		}
+		if !ok {
+			continue
+		}
+		maps.Copy(ep.Labels, labels)
+		if _, inZone := im.apexOf(ep.DNSName); inZone && labels[endpoint.OwnerLabelKey] == im.ownerID {
+			// Keep the TXT record until the ownership is stored in the zone record set,
+			// it is deleted by the next synchronization.
+			ep.WithProviderSpecific(providerSpecificMigrate, "true")
+			delete(txtRecordsMap, key)
+		}
+	}
+
+	// Remove the TXT ownership records owned by us that are not needed anymore
+	if len(txtRecordsMap) > 0 && !plan.IsManagedRecord(endpoint.RecordTypeTXT, im.managedRecordTypes, im.excludeRecordTypes) {
+		log.Infof("Old TXT ownership records will not be deleted because \"TXT\" is not in the set of managed record types.")
+	}
+	for key, record := range txtRecordsMap {
+		if txtLabelMap[key][endpoint.OwnerLabelKey] != im.ownerID {
+			continue
+		}
+		if record.Labels == nil {
+			record.Labels = endpoint.NewLabels()
+		}
+		record.Labels[endpoint.OwnerLabelKey] = im.ownerID
+		endpoints = append(endpoints, record)
+	}
+
+	im.zoneRecords = zoneRecords
+	im.owned = owned
+
+	return endpoints, nil
+}
+
+// ApplyChanges updates dns provider with the changes, together with the ownership
+// record sets of the zones of the changed records.
+func (im *TXTZoneRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	filteredChanges := &plan.Changes{
+		UpdateNew: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateNew),
+		UpdateOld: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateOld),
+		Delete:    endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.Delete),
+	}
+
+	owned := maps.Clone(im.owned)
+	changed := sets.New[string]()
+	// ownedIn returns the labels of the zone of name, copied on first use
+	// so that a failed apply leaves the registry state untouched.
+	ownedIn := func(name string) (map[endpoint.EndpointKey]endpoint.Labels, bool) {
+		apex, ok := im.apexOf(name)
+		if !ok {
+			return nil, false
+		}
+		if !changed.Has(apex) {
+			owned[apex] = maps.Clone(owned[apex])
+			if owned[apex] == nil {
+				owned[apex] = map[endpoint.EndpointKey]endpoint.Labels{}
+			}
+			changed.Insert(apex)
+		}
+		return owned[apex], true
+	}
+
+	for _, r := range changes.Create {
+		if r.IsUnmanagedLifecycle() {
+			filteredChanges.Create = append(filteredChanges.Create, r)
+			continue
+		}
+		entries, ok := ownedIn(r.DNSName)
+		if !ok {
+			log.Warnf("Skipping creation of %s: it is not in a zone of --txt-zone-apex, so its ownership cannot be recorded", r)
+			continue
+		}
+		if r.Labels == nil {
+			r.Labels = make(map[string]string)
+		}
+		r.Labels[endpoint.OwnerLabelKey] = im.ownerID
+		entries[r.Key()] = r.Labels
+		filteredChanges.Create = append(filteredChanges.Create, r)
+	}
+
+	for _, r := range slices.Concat(filteredChanges.Delete, filteredChanges.UpdateOld) {
+		if entries, ok := ownedIn(r.DNSName); ok {
+			delete(entries, r.Key())
+		}
+	}
+
+	for _, r := range filteredChanges.UpdateNew {
+		if entries, ok := ownedIn(r.DNSName); ok {
+			entries[r.Key()] = r.Labels
+		}
+	}
+
+	zoneRecords := maps.Clone(im.zoneRecords)
+	for _, apex := range sets.Sorted(changed) {
+		current := zoneRecords[apex]
+		desired, err := im.zoneRecordSet(apex, current, owned[apex])
+		if err != nil {
+			return err
+		}
+		switch {
+		case current == nil && desired == nil:
+		case current == nil:
+			filteredChanges.Create = append(filteredChanges.Create, desired)
+		case desired == nil:
+			filteredChanges.Delete = append(filteredChanges.Delete, current)
+		case !current.Targets.Same(desired.Targets):
+			filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, current)
+			filteredChanges.UpdateNew = append(filteredChanges.UpdateNew, desired)
+		}
+		zoneRecords[apex] = desired
+	}
+
+	if err := im.provider.ApplyChanges(ctx, filteredChanges); err != nil {
+		return err
+	}
+
+	im.owned = owned
+	im.zoneRecords = zoneRecords
+	return nil
+}
+
+// zoneRecordSet returns the ownership record set of the zone apex holding entries as the data
+// of this owner next to the data of other owners in current, or nil when it would be empty.
+func (im *TXTZoneRegistry) zoneRecordSet(apex string, current *endpoint.Endpoint, entries map[endpoint.EndpointKey]endpoint.Labels) (*endpoint.Endpoint, error) {
+	values, err := encodeOwnership(im.ownerID, entries)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		for _, value := range current.Targets {
+			if owner, ok := valueOwner(value); !ok || owner != im.ownerID {
+				values = append(values, value)
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil, nil //nolint:nilnil // nil signals that the record set is not needed
+	}
+	rs := endpoint.NewEndpoint(recordLabel+"."+apex, endpoint.RecordTypeTXT, values...)
+	if current != nil {
+		rs.RecordTTL = current.RecordTTL
+	}
+	return rs, nil
+}
+
+// Reset drops the cached records of the provider, so that the next Records call
+// reads the live provider state.
+func (im *TXTZoneRegistry) Reset() {
+	if r, ok := im.provider.(provider.CacheResetter); ok {
+		r.Reset()
+	}
+}
+
+// AdjustEndpoints modifies the endpoints as needed by the specific provider
+func (im *TXTZoneRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return im.provider.AdjustEndpoints(endpoints)
+}
+
+// apexOf returns the longest zone apex name belongs to.
+func (im *TXTZoneRegistry) apexOf(name string) (string, bool) {
+	name = normalizeName(name)
+	for _, apex := range im.apexes {
+		if name == apex || strings.HasSuffix(name, "."+apex) {
+			return apex, true
+		}
+	}
+	return "", false
+}
+
+// zoneOfRecordSet returns the zone apex whose ownership record set is named name.
+func (im *TXTZoneRegistry) zoneOfRecordSet(name string) (string, bool) {
+	apex, ok := strings.CutPrefix(normalizeName(name), recordLabel+".")
+	if !ok || !slices.Contains(im.apexes, apex) {
+		return "", false
+	}
+	return apex, true
+}
+
+// txtOwnershipKey returns the key of the TXT registry record owning ep, matching
+// the way the TXT registry names its records.
+func (im *TXTZoneRegistry) txtOwnershipKey(ep *endpoint.Endpoint) endpoint.EndpointKey {
+	key := endpoint.EndpointKey{
+		DNSName:       im.wildcardReplacement.Replace(ep.DNSName),
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+	}
+	// TXT ownership records use CNAME as the record type for alias A records converted from CNAME.
+	if aliasType := ep.GetAliasProperty(); (aliasType == endpoint.AliasTrue || aliasType == endpoint.AliasA) && ep.RecordType == endpoint.RecordTypeA {
+		key.RecordType = endpoint.RecordTypeCNAME
+	}
+	return key
+}
+
+func normalizeName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
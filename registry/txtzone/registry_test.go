@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txtzone
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry/mapper"
+)
+
+const (
+	testZone       = "test-zone.example.org"
+	testZoneRecord = "_external-dns.test-zone.example.org"
+)
+
+func newTestRegistry(t *testing.T, p *inmemory.InMemoryProvider, ownerID string) *TXTZoneRegistry {
+	t.Helper()
+	r, err := newRegistry(p, ownerID, []string{testZone}, "", "", "", []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME}, nil)
+	require.NoError(t, err)
+	return r
+}
+
+func newTestProvider(t *testing.T, records ...*endpoint.Endpoint) *inmemory.InMemoryProvider {
+	t.Helper()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	if len(records) > 0 {
+		require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Create: records}))
+	}
+	return p
+}
+
+func recordsByKey(t *testing.T, records []*endpoint.Endpoint) map[endpoint.EndpointKey]*endpoint.Endpoint {
+	t.Helper()
+	result := make(map[endpoint.EndpointKey]*endpoint.Endpoint, len(records))
+	for _, r := range records {
+		result[r.Key()] = r
+	}
+	return result
+}
+
+func TestNewRegistry(t *testing.T) {
+	p := inmemory.NewInMemoryProvider()
+
+	_, err := newRegistry(p, "", []string{testZone}, "", "", "", nil, nil)
+	require.EqualError(t, err, "owner id cannot be empty")
+
+	_, err = newRegistry(p, "a,b", []string{testZone}, "", "", "", nil, nil)
+	require.EqualError(t, err, "owner id cannot contain commas or quotes")
+
+	_, err = newRegistry(p, "owner", []string{testZone}, "prefix", "suffix", "", nil, nil)
+	require.EqualError(t, err, "txt-prefix and txt-suffix are mutually exclusive")
+
+	_, err = newRegistry(p, "owner", []string{"", " "}, "", "", "", nil, nil)
+	require.EqualError(t, err, "at least one zone apex must be set")
+
+	r, err := newRegistry(p, "owner", []string{"example.org.", "Test-Zone.example.org", "example.org"}, "", "", "", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testZone, "example.org"}, r.apexes)
+	assert.Equal(t, "owner", r.OwnerID())
+}
+
+func TestApplyChangesStoresOwnershipInZoneRecord(t *testing.T) {
+	ctx := t.Context()
+	p := newTestProvider(t)
+	r := newTestRegistry(t, p, "owner")
+
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.test-zone.example.org", endpoint.RecordTypeA, "1.1.1.1").
+				WithLabel(endpoint.ResourceLabelKey, "ingress/default/app"),
+			endpoint.NewEndpoint("www.test-zone.example.org", endpoint.RecordTypeCNAME, "app.test-zone.example.org"),
+			endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+		},
+	}))
+
+	current, err := p.Records(ctx)
+	require.NoError(t, err)
+	byKey := recordsByKey(t, current)
+	require.Len(t, current, 3, "only the owned records and a single ownership record set are created")
+	zoneRecord := byKey[endpoint.EndpointKey{DNSName: testZoneRecord, RecordType: endpoint.RecordTypeTXT}]
+	require.NotNil(t, zoneRecord)
+	for _, value := range zoneRecord.Targets {
+		assert.LessOrEqual(t, len(value), maxValueLength)
+	}
+
+	records, err := newTestRegistry(t, p, "owner").Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	byKey = recordsByKey(t, records)
+	app := byKey[endpoint.EndpointKey{DNSName: "app.test-zone.example.org", RecordType: endpoint.RecordTypeA}]
+	require.NotNil(t, app)
+	assert.Equal(t, "owner", app.Labels[endpoint.OwnerLabelKey])
+	assert.Equal(t, "ingress/default/app", app.Labels[endpoint.ResourceLabelKey])
+	www := byKey[endpoint.EndpointKey{DNSName: "www.test-zone.example.org", RecordType: endpoint.RecordTypeCNAME}]
+	require.NotNil(t, www)
+	assert.Equal(t, "owner", www.Labels[endpoint.OwnerLabelKey])
+}
+
+func TestApplyChangesKeepsOtherOwners(t *testing.T) {
+	ctx := t.Context()
+	otherValues, err := encodeOwnership("other", map[endpoint.EndpointKey]endpoint.Labels{
+		{DNSName: "theirs.test-zone.example.org", RecordType: endpoint.RecordTypeA}: {endpoint.OwnerLabelKey: "other"},
+	})
+	require.NoError(t, err)
+	p := newTestProvider(t,
+		endpoint.NewEndpoint("theirs.test-zone.example.org", endpoint.RecordTypeA, "3.3.3.3"),
+		endpoint.NewEndpoint(testZoneRecord, endpoint.RecordTypeTXT, append(otherValues, `"v=spf1 -all"`)...),
+	)
+	r := newTestRegistry(t, p, "owner")
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "other", records[0].Labels[endpoint.OwnerLabelKey])
+
+	mine := endpoint.NewEndpoint("mine.test-zone.example.org", endpoint.RecordTypeA, "1.1.1.1")
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{mine}}))
+
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	owners := map[string]string{}
+	for _, record := range records {
+		owners[record.DNSName] = record.Labels[endpoint.OwnerLabelKey]
+	}
+	assert.Equal(t, map[string]string{
+		"theirs.test-zone.example.org": "other",
+		"mine.test-zone.example.org":   "owner",
+	}, owners)
+	assert.Contains(t, r.zoneRecords[testZone].Targets, `"v=spf1 -all"`)
+
+	// Deleting the last record of this owner keeps the values of the other owner.
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{mine}}))
+	current, err := p.Records(ctx)
+	require.NoError(t, err)
+	zoneRecord := recordsByKey(t, current)[endpoint.EndpointKey{DNSName: testZoneRecord, RecordType: endpoint.RecordTypeTXT}]
+	require.NotNil(t, zoneRecord)
+	assert.ElementsMatch(t, append(otherValues, `"v=spf1 -all"`), zoneRecord.Targets)
+}
+
+func TestApplyChangesDeletesEmptyZoneRecord(t *testing.T) {
+	ctx := t.Context()
+	p := newTestProvider(t)
+	r := newTestRegistry(t, p, "owner")
+
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("app.test-zone.example.org", endpoint.RecordTypeA, "1.1.1.1")},
+	}))
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Delete: records}))
+
+	current, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, current)
+}
+
+func TestRecordsMigratesFromTXTRegistry(t *testing.T) {
+	ctx := t.Context()
+	txtName := mapper.NewAffixNameMapper("", "", "").ToTXTName("app.test-zone.example.org", endpoint.RecordTypeA)
+	txtLabels := endpoint.Labels{endpoint.OwnerLabelKey: "owner", endpoint.ResourceLabelKey: "service/default/app"}
+	otherTXTName := mapper.NewAffixNameMapper("", "", "").ToTXTName("theirs.test-zone.example.org", endpoint.RecordTypeA)
+	p := newTestProvider(t,
+		endpoint.NewEndpoint("app.test-zone.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint(txtName, endpoint.RecordTypeTXT, txtLabels.SerializePlain(true)),
+		endpoint.NewEndpoint("theirs.test-zone.example.org", endpoint.RecordTypeA, "3.3.3.3"),
+		endpoint.NewEndpoint(otherTXTName, endpoint.RecordTypeTXT, endpoint.Labels{endpoint.OwnerLabelKey: "other"}.SerializePlain(true)),
+	)
+	r := newTestRegistry(t, p, "owner")
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2, "the TXT record being migrated is kept back")
+	byKey := recordsByKey(t, records)
+	app := byKey[endpoint.EndpointKey{DNSName: "app.test-zone.example.org", RecordType: endpoint.RecordTypeA}]
+	assert.Equal(t, "owner", app.Labels[endpoint.OwnerLabelKey])
+	assert.Equal(t, "service/default/app", app.Labels[endpoint.ResourceLabelKey])
+	_, ok := app.GetProviderSpecificProperty(providerSpecificMigrate)
+	assert.True(t, ok)
+	theirs := byKey[endpoint.EndpointKey{DNSName: "theirs.test-zone.example.org", RecordType: endpoint.RecordTypeA}]
+	assert.Equal(t, "other", theirs.Labels[endpoint.OwnerLabelKey])
+	_, ok = theirs.GetProviderSpecificProperty(providerSpecificMigrate)
+	assert.False(t, ok)
+
+	desired := app.DeepCopy()
+	desired.DeleteProviderSpecificProperty(providerSpecificMigrate)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{app},
+		UpdateNew: []*endpoint.Endpoint{desired},
+	}))
+
+	// Once migrated, the old TXT record is returned as owned so that it gets deleted.
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	byKey = recordsByKey(t, records)
+	require.Len(t, records, 3)
+	app = byKey[endpoint.EndpointKey{DNSName: "app.test-zone.example.org", RecordType: endpoint.RecordTypeA}]
+	assert.Equal(t, "service/default/app", app.Labels[endpoint.ResourceLabelKey])
+	_, ok = app.GetProviderSpecificProperty(providerSpecificMigrate)
+	assert.False(t, ok)
+	oldTXT := byKey[endpoint.EndpointKey{DNSName: txtName, RecordType: endpoint.RecordTypeTXT}]
+	require.NotNil(t, oldTXT)
+	assert.Equal(t, "owner", oldTXT.Labels[endpoint.OwnerLabelKey])
+}
+
+func TestOwnershipEncoding(t *testing.T) {
+	owned := map[endpoint.EndpointKey]endpoint.Labels{}
+	for i := range 200 {
+		key := endpoint.EndpointKey{DNSName: fmt.Sprintf("host-%d.test-zone.example.org", i), RecordType: endpoint.RecordTypeA}
+		if i%2 == 0 {
+			key.SetIdentifier = fmt.Sprintf("set-%d", i)
+		}
+		owned[key] = endpoint.Labels{
+			endpoint.OwnerLabelKey:    "owner",
+			endpoint.ResourceLabelKey: fmt.Sprintf("service/ns-%d/svc-%d", i, i*7919),
+		}
+	}
+
+	values, err := encodeOwnership("owner", owned)
+	require.NoError(t, err)
+	require.Greater(t, len(values), 1, "the data is split across several values")
+	for _, value := range values {
+		assert.LessOrEqual(t, len(value), maxValueLength)
+	}
+
+	again, err := encodeOwnership("owner", owned)
+	require.NoError(t, err)
+	assert.Equal(t, values, again, "encoding is stable")
+
+	// Values are decoded regardless of their order.
+	reversed := make([]string, 0, len(values)+1)
+	for i := len(values) - 1; i >= 0; i-- {
+		reversed = append(reversed, values[i])
+	}
+	reversed = append(reversed, `"unrelated"`)
+	decoded := decodeOwnership(testZoneRecord, reversed)
+	assert.Equal(t, map[string]map[endpoint.EndpointKey]endpoint.Labels{"owner": owned}, decoded)
+
+	assert.Empty(t, decodeOwnership(testZoneRecord, values[1:]), "incomplete data is ignored")
+
+	_, err = encodeOwnership(string(make([]byte, 200)), owned)
+	require.Error(t, err)
+}
@@ -0,0 +1,124 @@
+#!/usr/bin/env bash
+
+# Copyright 2026 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Runs the sync loop benchmarks of the controller package and compares them with a baseline.
+#
+# Execute
+# scripts/bench.sh              run the benchmarks and compare them with build/bench/baseline.txt
+# scripts/bench.sh --baseline   run the benchmarks and save them as build/bench/baseline.txt
+# scripts/bench.sh -h
+#
+# Environment
+# BENCH          benchmarks to run, a go test -bench regular expression (default: .)
+# BENCH_COUNT    runs of every benchmark (default: 6)
+# BENCH_SHORT    set to true to skip the 100k endpoints sizes
+# BENCH_BUDGET   allowed increase of time, memory and allocations per op in percent (default: 10)
+
+set -e -u -o pipefail
+
+cd "$(git rev-parse --show-toplevel)"
+
+BENCH="${BENCH:-.}"
+BENCH_COUNT="${BENCH_COUNT:-6}"
+BENCH_SHORT="${BENCH_SHORT:-false}"
+BENCH_BUDGET="${BENCH_BUDGET:-10}"
+BENCH_DIR=build/bench
+BENCH_PACKAGE=./controller/
+
+show_help() {
+cat << EOF
+'external-dns' sync loop benchmarks
+
+Usage: $(basename "$0") <options>
+    -h, --help          Display help
+    --baseline          Save the results as the baseline of the next runs
+EOF
+}
+
+run_benchmarks() {
+  local output=$1
+  mkdir -p "${BENCH_DIR}"
+  echo ">> running ${BENCH_PACKAGE} benchmarks matching '${BENCH}' ${BENCH_COUNT} times"
+  go test -run='^$' -bench="${BENCH}" -benchmem -count="${BENCH_COUNT}" -short="${BENCH_SHORT}" \
+    -memprofile="${BENCH_DIR}/mem.pprof" -cpuprofile="${BENCH_DIR}/cpu.pprof" -o "${BENCH_DIR}/controller.test" \
+    "${BENCH_PACKAGE}" | tee "${output}"
+  echo ">> profiles written to ${BENCH_DIR}, inspect them with: go tool pprof ${BENCH_DIR}/controller.test ${BENCH_DIR}/mem.pprof"
+}
+
+# compare prints the mean of every metric per benchmark in both files and fails when one grew over the budget.
+compare() {
+  local baseline=$1 current=$2
+  awk -v budget="${BENCH_BUDGET}" '
+    function record(file, name, value, unit) {
+      key = name SUBSEP unit
+      sum[file, key] += value; n[file, key]++
+      if (!(key in seen)) { seen[key] = 1; order[++keys] = key }
+    }
+    FNR == 1 { file++ }
+    /^Benchmark/ {
+      name = $1; sub(/-[0-9]+$/, "", name)
+      for (i = 3; i < NF; i += 2) record(file, name, $i, $(i + 1))
+    }
+    END {
+      printf "%-50s %-10s %15s %15s %9s\n", "benchmark", "unit", "baseline", "current", "delta"
+      for (k = 1; k <= keys; k++) {
+        key = order[k]; split(key, parts, SUBSEP)
+        if (!n[1, key] || !n[2, key]) continue
+        old = sum[1, key] / n[1, key]; new = sum[2, key] / n[2, key]
+        delta = old ? (new - old) * 100 / old : 0
+        mark = ""
+        if (delta > budget) { mark = "  over budget"; failed++ }
+        printf "%-50s %-10s %15.0f %15.0f %+8.1f%%%s\n", parts[1], parts[2], old, new, delta, mark
+      }
+      if (failed) {
+        printf "\n%d metrics grew by more than the %s%% budget\n", failed, budget
+        exit 1
+      }
+    }
+  ' "${baseline}" "${current}"
+}
+
+main() {
+  case ${1:-} in
+    --baseline)
+      run_benchmarks "${BENCH_DIR}/baseline.txt"
+      ;;
+    "")
+      run_benchmarks "${BENCH_DIR}/current.txt"
+      if [[ ! -f "${BENCH_DIR}/baseline.txt" ]]; then
+        echo ">> no baseline to compare with, save one with: make bench-baseline"
+        exit 0
+      fi
+      if [[ -x $(command -v benchstat) ]]; then
+        benchstat "${BENCH_DIR}/baseline.txt" "${BENCH_DIR}/current.txt"
+      else
+        echo ">> install benchstat for a statistical comparison: go install golang.org/x/perf/cmd/benchstat@latest"
+      fi
+      echo ">> comparing with ${BENCH_DIR}/baseline.txt, budget ${BENCH_BUDGET}%"
+      compare "${BENCH_DIR}/baseline.txt" "${BENCH_DIR}/current.txt"
+      ;;
+    -h|--help)
+      show_help
+      ;;
+    *)
+      echo "unknown sub-command" >&2
+      show_help
+      exit 1
+      ;;
+  esac
+}
+
+main "$@"
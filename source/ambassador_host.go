@@ -88,7 +88,7 @@ func NewAmbassadorHostSource(
 ) (Source, error) {
 	// Use shared informer to listen for add/update/delete of Host in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, cfg.Namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, cfg.Namespace, informers.ListPageSize(cfg.KubeAPIListPageSize))
 	ambassadorHostInformer := informerFactory.ForResource(ambHostGVR)
 
 	informers.MustSetTransform(ambassadorHostInformer.Informer(), informers.TransformerWithOptions[*unstructured.Unstructured](
@@ -98,6 +98,7 @@ func NewAmbassadorHostSource(
 
 	// Add default resource event handlers to properly initialize informer.
 	informers.MustAddEventHandler(ambassadorHostInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(ambassadorHostInformer.Informer(), "ambassador-host/host")
 
 	informerFactory.Start(ctx.Done())
 
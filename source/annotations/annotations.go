@@ -15,6 +15,8 @@ package annotations
 
 import (
 	"math"
+	"slices"
+	"strings"
 )
 
 const (
@@ -23,6 +25,16 @@ const (
 
 	ttlMinimum = 1
 	ttlMaximum = math.MaxInt32
+
+	// Cloudflare provider-specific property names. Unlike other providers, Cloudflare
+	// properties are named after their annotation key with the default prefix. They
+	// keep that name with a custom annotation prefix, so that records and webhook
+	// providers do not depend on the prefix of the instance that created them.
+	CloudflareProxiedProperty        = DefaultAnnotationPrefix + "cloudflare-proxied"
+	CloudflareCustomHostnameProperty = DefaultAnnotationPrefix + "cloudflare-custom-hostname"
+	CloudflareRegionKeyProperty      = DefaultAnnotationPrefix + "cloudflare-region-key"
+	CloudflareRecordCommentProperty  = DefaultAnnotationPrefix + "cloudflare-record-comment"
+	CloudflareTagsProperty           = DefaultAnnotationPrefix + "cloudflare-tags"
 )
 
 var (
@@ -30,6 +42,10 @@ var (
 	// to provide easy filtering. Can be customized via SetAnnotationPrefix.
 	AnnotationKeyPrefix = DefaultAnnotationPrefix
 
+	// deprecatedPrefixes are honored after AnnotationKeyPrefix, in decreasing order of
+	// precedence, e.g. while migrating to another prefix.
+	deprecatedPrefixes []string
+
 	// CloudflareProxiedKey The annotation used for determining if traffic will go through Cloudflare
 	CloudflareProxiedKey        = AnnotationKeyPrefix + "cloudflare-proxied"
 	CloudflareCustomHostnameKey = AnnotationKeyPrefix + "cloudflare-custom-hostname"
@@ -51,6 +67,12 @@ var (
 	AliasKey         = AnnotationKeyPrefix + "alias"
 	RecordTypeKey    = AnnotationKeyPrefix + "record-type"
 	TargetKey        = AnnotationKeyPrefix + "target"
+	// TargetFromKey The annotation used for reading the targets from a ConfigMap or Secret key
+	TargetFromKey = AnnotationKeyPrefix + "target-from"
+	// CollisionPriorityKey The annotation used for ranking the namespaces claiming the same hostname
+	CollisionPriorityKey = AnnotationKeyPrefix + "collision-priority"
+	// ViewTargetPrefix The annotation prefix used for the targets of a split-horizon view, e.g. view-target-internal
+	ViewTargetPrefix = AnnotationKeyPrefix + "view-target-"
 	// ControllerKey The annotation used for figuring out which controller is responsible
 	ControllerKey = AnnotationKeyPrefix + "controller"
 	// HostnameKey The annotation used for defining the desired hostname
@@ -68,15 +90,28 @@ var (
 	ControllerValue = "dns-controller"
 	// InternalHostnameKey The annotation used for defining the desired hostname
 	InternalHostnameKey = AnnotationKeyPrefix + "internal-hostname"
+	// UnmanagedLifecycleKey The annotation used for creating records without registry ownership
+	UnmanagedLifecycleKey = AnnotationKeyPrefix + "unmanaged-lifecycle"
+	// StagingTTLKey The annotation used for creating new records with a short TTL first
+	StagingTTLKey = AnnotationKeyPrefix + "staging-ttl"
+	// DryRunKey The annotation used for planning the changes of a resource without applying them
+	DryRunKey = AnnotationKeyPrefix + "dry-run"
+	// HealthCheckKey The annotation used for probing the targets and dropping the failing ones
+	HealthCheckKey = AnnotationKeyPrefix + "health-check"
 	// The annotation used for defining the desired hostname source for gateways
 	GatewayHostnameSourceKey = AnnotationKeyPrefix + "gateway-hostname-source"
+	// SuspendKey The annotation used for suspending the synchronizations, set on the namespace of --suspend-namespace
+	SuspendKey = AnnotationKeyPrefix + "suspend"
 )
 
 // SetAnnotationPrefix sets a custom annotation prefix and rebuilds all annotation keys.
+// The deprecated prefixes are honored too, with a lower precedence than prefix and in
+// the given order, see MigrateDeprecatedPrefixes.
 // This must be called before any sources are initialized.
-// The prefix must end with '/'.
-func SetAnnotationPrefix(prefix string) {
+// The prefixes must end with '/'.
+func SetAnnotationPrefix(prefix string, deprecated ...string) {
 	AnnotationKeyPrefix = prefix
+	deprecatedPrefixes = slices.DeleteFunc(slices.Clone(deprecated), func(p string) bool { return p == prefix })
 
 	// Cloudflare annotations
 	CloudflareProxiedKey = AnnotationKeyPrefix + "cloudflare-proxied"
@@ -101,6 +136,9 @@ func SetAnnotationPrefix(prefix string) {
 	AliasKey = AnnotationKeyPrefix + "alias"
 	RecordTypeKey = AnnotationKeyPrefix + "record-type"
 	TargetKey = AnnotationKeyPrefix + "target"
+	TargetFromKey = AnnotationKeyPrefix + "target-from"
+	CollisionPriorityKey = AnnotationKeyPrefix + "collision-priority"
+	ViewTargetPrefix = AnnotationKeyPrefix + "view-target-"
 	ControllerKey = AnnotationKeyPrefix + "controller"
 	HostnameKey = AnnotationKeyPrefix + "hostname"
 	AccessKey = AnnotationKeyPrefix + "access"
@@ -108,5 +146,50 @@ func SetAnnotationPrefix(prefix string) {
 	Ingress = AnnotationKeyPrefix + "ingress"
 	IngressHostnameSourceKey = AnnotationKeyPrefix + "ingress-hostname-source"
 	InternalHostnameKey = AnnotationKeyPrefix + "internal-hostname"
+	UnmanagedLifecycleKey = AnnotationKeyPrefix + "unmanaged-lifecycle"
+	StagingTTLKey = AnnotationKeyPrefix + "staging-ttl"
+	DryRunKey = AnnotationKeyPrefix + "dry-run"
+	HealthCheckKey = AnnotationKeyPrefix + "health-check"
 	GatewayHostnameSourceKey = AnnotationKeyPrefix + "gateway-hostname-source"
+	SuspendKey = AnnotationKeyPrefix + "suspend"
+}
+
+// DeprecatedAnnotationPrefixes returns the deprecated prefixes set by SetAnnotationPrefix,
+// in decreasing order of precedence.
+func DeprecatedAnnotationPrefixes() []string {
+	return slices.Clone(deprecatedPrefixes)
+}
+
+// MigrateDeprecatedPrefixes copies the annotations with a deprecated prefix to the current
+// prefix, unless an annotation with the current prefix, or with a deprecated prefix of
+// higher precedence, sets the same key. The annotations with a deprecated prefix are kept,
+// so that the annotation filter still matches them. It returns the deprecated prefix of
+// every copied annotation.
+func MigrateDeprecatedPrefixes(annotations map[string]string) []string {
+	if len(deprecatedPrefixes) == 0 || len(annotations) == 0 {
+		return nil
+	}
+	migrated := make(map[string]string)
+	used := make(map[string]string)
+	for i := len(deprecatedPrefixes) - 1; i >= 0; i-- {
+		prefix := deprecatedPrefixes[i]
+		for key, value := range annotations {
+			name, ok := strings.CutPrefix(key, prefix)
+			if !ok || name == "" {
+				continue
+			}
+			migrated[AnnotationKeyPrefix+name] = value
+			used[AnnotationKeyPrefix+name] = prefix
+		}
+	}
+	var prefixes []string
+	for key, value := range migrated {
+		if _, ok := annotations[key]; ok {
+			continue
+		}
+		annotations[key] = value
+		prefixes = append(prefixes, used[key])
+	}
+	slices.Sort(prefixes)
+	return prefixes
 }
@@ -34,6 +34,8 @@ func TestSetAnnotationPrefix(t *testing.T) {
 	assert.Equal(t, "custom.io/internal-hostname", InternalHostnameKey)
 	assert.Equal(t, "custom.io/ttl", TtlKey)
 	assert.Equal(t, "custom.io/target", TargetKey)
+	assert.Equal(t, "custom.io/target-from", TargetFromKey)
+	assert.Equal(t, "custom.io/view-target-", ViewTargetPrefix)
 	assert.Equal(t, "custom.io/controller", ControllerKey)
 	assert.Equal(t, "custom.io/cloudflare-proxied", CloudflareProxiedKey)
 	assert.Equal(t, "custom.io/cloudflare-custom-hostname", CloudflareCustomHostnameKey)
@@ -84,3 +86,71 @@ func TestSetAnnotationPrefixMultipleTimes(t *testing.T) {
 	assert.Equal(t, DefaultAnnotationPrefix, AnnotationKeyPrefix)
 	assert.Equal(t, DefaultAnnotationPrefix+"hostname", HostnameKey)
 }
+
+func TestSetAnnotationPrefixDeprecated(t *testing.T) {
+	t.Cleanup(func() { SetAnnotationPrefix(DefaultAnnotationPrefix) })
+
+	SetAnnotationPrefix("new.io/", "old.io/", "new.io/", "older.io/")
+	assert.Equal(t, "new.io/hostname", HostnameKey)
+	assert.Equal(t, []string{"old.io/", "older.io/"}, DeprecatedAnnotationPrefixes())
+
+	SetAnnotationPrefix(DefaultAnnotationPrefix)
+	assert.Empty(t, DeprecatedAnnotationPrefixes())
+}
+
+func TestMigrateDeprecatedPrefixes(t *testing.T) {
+	t.Cleanup(func() { SetAnnotationPrefix(DefaultAnnotationPrefix) })
+	SetAnnotationPrefix("new.io/", "old.io/", "older.io/")
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    map[string]string
+		prefixes    []string
+	}{
+		{
+			name:        "current prefix only",
+			annotations: map[string]string{"new.io/hostname": "a.example.com"},
+			expected:    map[string]string{"new.io/hostname": "a.example.com"},
+		},
+		{
+			name:        "deprecated prefix is copied",
+			annotations: map[string]string{"old.io/hostname": "a.example.com", "other.io/ttl": "60"},
+			expected: map[string]string{
+				"old.io/hostname": "a.example.com",
+				"new.io/hostname": "a.example.com",
+				"other.io/ttl":    "60",
+			},
+			prefixes: []string{"old.io/"},
+		},
+		{
+			name:        "current prefix wins",
+			annotations: map[string]string{"new.io/ttl": "60", "old.io/ttl": "120", "older.io/ttl": "300"},
+			expected:    map[string]string{"new.io/ttl": "60", "old.io/ttl": "120", "older.io/ttl": "300"},
+		},
+		{
+			name:        "deprecated prefixes in order of precedence",
+			annotations: map[string]string{"old.io/ttl": "120", "older.io/ttl": "300", "older.io/target": "1.2.3.4"},
+			expected: map[string]string{
+				"old.io/ttl":      "120",
+				"older.io/ttl":    "300",
+				"older.io/target": "1.2.3.4",
+				"new.io/ttl":      "120",
+				"new.io/target":   "1.2.3.4",
+			},
+			prefixes: []string{"old.io/", "older.io/"},
+		},
+		{
+			name:        "bare prefix is ignored",
+			annotations: map[string]string{"old.io/": "value"},
+			expected:    map[string]string{"old.io/": "value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.prefixes, MigrateDeprecatedPrefixes(tt.annotations))
+			assert.Equal(t, tt.expected, tt.annotations)
+			assert.Empty(t, MigrateDeprecatedPrefixes(tt.annotations), "migrating twice copies nothing")
+		})
+	}
+}
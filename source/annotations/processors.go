@@ -53,6 +53,25 @@ func TTLFromAnnotations(annotations map[string]string, resource string) endpoint
 	return endpoint.TTL(ttlValue)
 }
 
+// stagingTTLFromAnnotations returns the TTL of the staging-ttl annotation, in
+// seconds, and false when it is not set or not a valid TTL.
+func stagingTTLFromAnnotations(annotations map[string]string) (endpoint.TTL, bool) {
+	annotation, ok := annotations[StagingTTLKey]
+	if !ok {
+		return 0, false
+	}
+	ttl, err := parseTTL(annotation)
+	if err != nil {
+		log.Warnf("%q is not a valid staging TTL value: %v", annotation, err)
+		return 0, false
+	}
+	if ttl < ttlMinimum || ttl > ttlMaximum {
+		log.Warnf("Staging TTL value %d must be between [%d, %d]", ttl, ttlMinimum, ttlMaximum)
+		return 0, false
+	}
+	return endpoint.TTL(ttl), true
+}
+
 // IsControllerMismatch returns true when the resource should be skipped because
 // the controller annotation is present and does not match the expected controller value.
 // It also logs the reason.
@@ -15,6 +15,7 @@ package annotations
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"sigs.k8s.io/external-dns/endpoint"
@@ -35,7 +36,44 @@ func ProviderSpecificAnnotations(annotations map[string]string) (endpoint.Provid
 			Value: v,
 		})
 	}
+	if v, ok := annotations[TargetFromKey]; ok {
+		providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+			Name:  endpoint.ProviderSpecificTargetFrom,
+			Value: v,
+		})
+	}
+	if v, ok := annotations[CollisionPriorityKey]; ok {
+		providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+			Name:  endpoint.ProviderSpecificCollisionPriority,
+			Value: v,
+		})
+	}
+	if v, ok := annotations[UnmanagedLifecycleKey]; ok {
+		providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+			Name:  endpoint.ProviderSpecificUnmanagedLifecycle,
+			Value: v,
+		})
+	}
+	if v, ok := annotations[DryRunKey]; ok {
+		providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+			Name:  endpoint.ProviderSpecificDryRun,
+			Value: v,
+		})
+	}
+	if v, ok := annotations[HealthCheckKey]; ok {
+		providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+			Name:  endpoint.ProviderSpecificHealthCheck,
+			Value: v,
+		})
+	}
+	if ttl, ok := stagingTTLFromAnnotations(annotations); ok {
+		providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+			Name:  endpoint.ProviderSpecificStagingTTL,
+			Value: strconv.FormatInt(int64(ttl), 10),
+		})
+	}
 	setIdentifier := ""
+	cloudflare := cloudflareProperties()
 	for k, v := range annotations {
 		if k == SetIdentifierKey {
 			setIdentifier = v
@@ -60,45 +98,39 @@ func ProviderSpecificAnnotations(annotations map[string]string) (endpoint.Provid
 				Name:  fmt.Sprintf("coredns/%s", attr),
 				Value: v,
 			})
+		} else if view, ok := strings.CutPrefix(k, ViewTargetPrefix); ok && view != "" {
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  endpoint.ProviderSpecificViewPrefix + view,
+				Value: v,
+			})
 		} else if k == AzureTagsKey {
 			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
 				Name:  "azure/tags",
 				Value: v,
 			})
-		} else if strings.HasPrefix(k, CloudflarePrefix) {
+		} else if name, ok := cloudflare[k]; ok {
 			// TODO: unlike other providers which normalise to "provider/attr",
-			// Cloudflare retains the full annotation key as the property name
+			// Cloudflare retains the default annotation key as the property name
 			// (e.g. "external-dns.kubernetes.io/cloudflare-proxied").
 			// This is why RetainProviderProperties has a special case for cloudflare.
 			// Should be aligned with the standard convention in a future change.
-			switch {
-			case strings.Contains(k, CloudflareCustomHostnameKey):
-				providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
-					Name:  CloudflareCustomHostnameKey,
-					Value: v,
-				})
-			case strings.Contains(k, CloudflareProxiedKey):
-				providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
-					Name:  CloudflareProxiedKey,
-					Value: v,
-				})
-			case strings.Contains(k, CloudflareRegionKey):
-				providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
-					Name:  CloudflareRegionKey,
-					Value: v,
-				})
-			case strings.Contains(k, CloudflareRecordCommentKey):
-				providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
-					Name:  CloudflareRecordCommentKey,
-					Value: v,
-				})
-			case strings.Contains(k, CloudflareTagsKey):
-				providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
-					Name:  CloudflareTagsKey,
-					Value: v,
-				})
-			}
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  name,
+				Value: v,
+			})
 		}
 	}
 	return providerSpecificAnnotations, setIdentifier
 }
+
+// cloudflareProperties maps the Cloudflare annotation keys, which follow the
+// annotation prefix, to their provider-specific property names.
+func cloudflareProperties() map[string]string {
+	return map[string]string{
+		CloudflareProxiedKey:        CloudflareProxiedProperty,
+		CloudflareCustomHostnameKey: CloudflareCustomHostnameProperty,
+		CloudflareRegionKey:         CloudflareRegionKeyProperty,
+		CloudflareRecordCommentKey:  CloudflareRecordCommentProperty,
+		CloudflareTagsKey:           CloudflareTagsProperty,
+	}
+}
@@ -73,6 +73,16 @@ func TestProviderSpecificAnnotations(t *testing.T) {
 			},
 			setIdentifier: "",
 		},
+		{
+			name: "view target annotation",
+			annotations: map[string]string{
+				"external-dns.kubernetes.io/view-target-internal": "10.0.0.1,10.0.0.2",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: "view/internal", Value: "10.0.0.1,10.0.0.2"},
+			},
+			setIdentifier: "",
+		},
 		{
 			name: "CoreDNS annotation",
 			annotations: map[string]string{
@@ -121,6 +131,64 @@ func TestProviderSpecificAnnotations(t *testing.T) {
 			},
 			setIdentifier: "",
 		},
+		{
+			name: "Target from annotation",
+			annotations: map[string]string{
+				TargetFromKey: "configmap:default/lb#ips",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: endpoint.ProviderSpecificTargetFrom, Value: "configmap:default/lb#ips"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "Unmanaged lifecycle annotation",
+			annotations: map[string]string{
+				UnmanagedLifecycleKey: "true",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: endpoint.ProviderSpecificUnmanagedLifecycle, Value: "true"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "Dry run annotation",
+			annotations: map[string]string{
+				DryRunKey: "true",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: endpoint.ProviderSpecificDryRun, Value: "true"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "Health check annotation",
+			annotations: map[string]string{
+				HealthCheckKey: "http:8080/healthz",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: endpoint.ProviderSpecificHealthCheck, Value: "http:8080/healthz"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "Staging TTL annotation",
+			annotations: map[string]string{
+				StagingTTLKey: "30s",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: endpoint.ProviderSpecificStagingTTL, Value: "30"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "Invalid staging TTL annotation",
+			annotations: map[string]string{
+				StagingTTLKey: "soon",
+			},
+			expected:      endpoint.ProviderSpecific{},
+			setIdentifier: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -398,6 +466,33 @@ func TestProviderSpecificPropertyNameConvention(t *testing.T) {
 	}
 }
 
+func TestProviderSpecificAnnotationsCustomPrefix(t *testing.T) {
+	t.Cleanup(func() { SetAnnotationPrefix(DefaultAnnotationPrefix) })
+	SetAnnotationPrefix("custom.io/")
+
+	props, setIdentifier := ProviderSpecificAnnotations(map[string]string{
+		"custom.io/aws-weight":                                "10",
+		"custom.io/webhook-something":                         "val",
+		"custom.io/set-identifier":                            "id",
+		"custom.io/alias":                                     "true",
+		"custom.io/cloudflare-proxied":                        "true",
+		"custom.io/cloudflare-tags":                           "tag1",
+		"custom.io/cloudflare-proxied-extra":                  "ignored",
+		DefaultAnnotationPrefix + "aws-ignored":               "ignored",
+		DefaultAnnotationPrefix + "cloudflare-record-comment": "ignored",
+	})
+
+	assert.Equal(t, "id", setIdentifier)
+	assert.ElementsMatch(t, endpoint.ProviderSpecific{
+		{Name: endpoint.ProviderSpecificAlias, Value: "true"},
+		{Name: "aws/weight", Value: "10"},
+		{Name: "webhook/something", Value: "val"},
+		// Cloudflare properties keep their name whatever the annotation prefix
+		{Name: CloudflareProxiedProperty, Value: "true"},
+		{Name: CloudflareTagsProperty, Value: "tag1"},
+	}, props)
+}
+
 func TestGetProviderSpecificIdentifierAnnotations(t *testing.T) {
 	for _, tc := range []struct {
 		title              string
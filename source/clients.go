@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+
+	openshift "github.com/openshift/client-go/route/clientset/versioned"
+	log "github.com/sirupsen/logrus"
+	istioclient "istio.io/client-go/pkg/clientset/versioned"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+
+	kubeclient "sigs.k8s.io/external-dns/pkg/client"
+)
+
+// Names of the built-in clients, see RegisterClient.
+const (
+	KubeClientName              = "kubernetes"
+	GatewayClientName           = "gateway"
+	IstioClientName             = "istio"
+	DynamicKubernetesClientName = "dynamic"
+	OpenShiftClientName         = "openshift"
+)
+
+// ErrClientNotRegistered is returned when a client is requested under a name
+// no factory was registered for.
+var ErrClientNotRegistered = errors.New("client not registered")
+
+// ClientFactory creates a client from the instrumented REST config shared by
+// all the clients of a ClientGenerator.
+type ClientFactory func(config *rest.Config) (any, error)
+
+var (
+	clientFactoriesMu sync.RWMutex
+	clientFactories   = map[string]ClientFactory{}
+)
+
+func init() {
+	RegisterClient(KubeClientName, func(config *rest.Config) (any, error) {
+		return kubeclient.NewKubeClient(config)
+	})
+	RegisterClient(GatewayClientName, func(config *rest.Config) (any, error) {
+		client, err := gateway.NewForConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Created GatewayAPI client %s", config.Host)
+		return client, nil
+	})
+	RegisterClient(IstioClientName, func(config *rest.Config) (any, error) {
+		return NewIstioClient(config)
+	})
+	RegisterClient(DynamicKubernetesClientName, func(config *rest.Config) (any, error) {
+		client, err := dynamic.NewForConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Created Dynamic Kubernetes client %s", config.Host)
+		return client, nil
+	})
+	RegisterClient(OpenShiftClientName, func(config *rest.Config) (any, error) {
+		client, err := openshift.NewForConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Created OpenShift client %s", config.Host)
+		return client, nil
+	})
+}
+
+// RegisterClient registers the factory of the client named name, so that a
+// source needing a client which is not built in, e.g. the clientset of its
+// custom resources, gets it from any ClientGenerator with Client. It is meant
+// to be called from an init function and panics when name is already registered.
+func RegisterClient(name string, factory ClientFactory) {
+	clientFactoriesMu.Lock()
+	defer clientFactoriesMu.Unlock()
+	if _, ok := clientFactories[name]; ok {
+		panic(fmt.Sprintf("client %q already registered", name))
+	}
+	clientFactories[name] = factory
+}
+
+// RegisteredClients returns the sorted names of the registered clients.
+func RegisteredClients() []string {
+	clientFactoriesMu.RLock()
+	defer clientFactoriesMu.RUnlock()
+	names := make([]string, 0, len(clientFactories))
+	for name := range clientFactories {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func clientFactory(name string) (ClientFactory, bool) {
+	clientFactoriesMu.RLock()
+	defer clientFactoriesMu.RUnlock()
+	factory, ok := clientFactories[name]
+	return factory, ok
+}
+
+// Client returns the client named name generated by p, typed as T.
+func Client[T any](p ClientGenerator, name string) (T, error) {
+	var zero T
+	client, err := p.Client(name)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := client.(T)
+	if !ok {
+		return zero, fmt.Errorf("client %q is a %T, not a %s", name, client, reflect.TypeFor[T]())
+	}
+	return typed, nil
+}
+
+// KubeClient returns the standard Kubernetes API client generated by p.
+func KubeClient(p ClientGenerator) (kubernetes.Interface, error) {
+	return Client[kubernetes.Interface](p, KubeClientName)
+}
+
+// GatewayClient returns the Gateway API client generated by p.
+func GatewayClient(p ClientGenerator) (gateway.Interface, error) {
+	return Client[gateway.Interface](p, GatewayClientName)
+}
+
+// IstioClient returns the Istio client generated by p.
+func IstioClient(p ClientGenerator) (istioclient.Interface, error) {
+	return Client[istioclient.Interface](p, IstioClientName)
+}
+
+// DynamicKubernetesClient returns the dynamic client for custom resources generated by p.
+func DynamicKubernetesClient(p ClientGenerator) (dynamic.Interface, error) {
+	return Client[dynamic.Interface](p, DynamicKubernetesClientName)
+}
+
+// OpenShiftClient returns the OpenShift client for Route resources generated by p.
+func OpenShiftClient(p ClientGenerator) (openshift.Interface, error) {
+	return Client[openshift.Interface](p, OpenShiftClientName)
+}
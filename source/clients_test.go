@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+type customClient struct {
+	host string
+}
+
+func TestRegisterClient(t *testing.T) {
+	created := 0
+	RegisterClient("test-custom", func(config *rest.Config) (any, error) {
+		created++
+		return &customClient{host: config.Host}, nil
+	})
+	assert.Contains(t, RegisteredClients(), "test-custom")
+	assert.Panics(t, func() {
+		RegisterClient("test-custom", func(*rest.Config) (any, error) { return nil, nil })
+	})
+
+	kubeConfig := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeConfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: http://127.0.0.1:8080
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`), 0o600))
+
+	gen := &SingletonClientGenerator{KubeConfig: kubeConfig}
+	client, err := Client[*customClient](gen, "test-custom")
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:8080", client.host)
+	again, err := Client[*customClient](gen, "test-custom")
+	require.NoError(t, err)
+	assert.Same(t, client, again)
+	assert.Equal(t, 1, created)
+
+	_, err = Client[kubernetes.Interface](gen, "test-custom")
+	require.ErrorContains(t, err, `client "test-custom" is a *source.customClient, not a kubernetes.Interface`)
+
+	_, err = gen.Client("unknown")
+	require.ErrorIs(t, err, ErrClientNotRegistered)
+}
+
+func TestRegisteredClients(t *testing.T) {
+	assert.Subset(t, RegisteredClients(), []string{
+		KubeClientName, GatewayClientName, IstioClientName, DynamicKubernetesClientName, OpenShiftClientName,
+	})
+}
+
+func TestClientMock(t *testing.T) {
+	kubeClient := fakeKube.NewClientset()
+	gen := new(testutils.MockClientGenerator)
+	gen.On("Client", KubeClientName).Return(kubeClient, nil)
+	gen.On("Client", IstioClientName).Return(nil, errors.New("istio unavailable"))
+
+	client, err := KubeClient(gen)
+	require.NoError(t, err)
+	assert.Same(t, kubeClient, client)
+	_, err = IstioClient(gen)
+	require.EqualError(t, err, "istio unavailable")
+	assert.Equal(t, map[string]any{KubeClientName: kubeClient}, gen.Clients)
+}
@@ -54,16 +54,24 @@ func NewConnectorSource(remoteServer string) (Source, error) {
 }
 
 // Endpoints returns endpoint objects.
-func (cs *connectorSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (cs *connectorSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	endpoints := []*endpoint.Endpoint{}
 
-	conn, err := net.DialTimeout("tcp", cs.remoteServer, dialTimeout)
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", cs.remoteServer)
 	if err != nil {
 		log.Errorf("Connection error: %v", err)
 		return nil, err
 	}
 	defer conn.Close()
 
+	// The decode below blocks on the connection, so bound it by the caller's deadline.
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
 	decoder := gob.NewDecoder(conn)
 	if err := decoder.Decode(&endpoints); err != nil {
 		log.Errorf("Decode error: %v", err)
@@ -0,0 +1,266 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+	"sigs.k8s.io/external-dns/source/types"
+)
+
+const (
+	// consulHostnameMeta is the service metadata key listing the hostnames of a service, comma separated.
+	consulHostnameMeta = "external-dns-hostname"
+	// consulTargetMeta is the service metadata key listing the targets of a service, comma separated,
+	// instead of its address.
+	consulTargetMeta = "external-dns-target"
+	// consulTTLMeta is the service metadata key setting the TTL of the records of a service, in seconds.
+	consulTTLMeta = "external-dns-ttl"
+	// consulIndexHeader holds the index of the data returned by the Consul API, used by blocking queries.
+	consulIndexHeader = "X-Consul-Index"
+
+	consulRequestTimeout = 30 * time.Second
+	// consulWaitTime is the longest time a blocking query waits for a change of the catalog.
+	consulWaitTime = 5 * time.Minute
+	// consulRetryInterval is the time waited after a failed blocking query.
+	consulRetryInterval = 10 * time.Second
+)
+
+// consulCatalogService is an item of the response of the /v1/catalog/service/:name endpoint.
+type consulCatalogService struct {
+	Datacenter     string
+	Node           string
+	Address        string
+	ServiceName    string
+	ServiceAddress string
+	ServiceTags    []string
+	ServiceMeta    map[string]string
+}
+
+// consulSource is an implementation of Source that provides endpoints for the
+// services of the HashiCorp Consul catalog. The hostnames of a service are read
+// from its "external-dns-hostname" metadata, and the targets are the addresses
+// of its instances.
+//
+// +externaldns:source:name=consul
+// +externaldns:source:category=Special
+// +externaldns:source:description=Creates DNS entries from the services of the HashiCorp Consul catalog
+// +externaldns:source:resources=Consul catalog services
+// +externaldns:source:filters=
+// +externaldns:source:namespace=
+// +externaldns:source:fqdn-template=false
+// +externaldns:source:events=true
+// +externaldns:source:provider-specific=false
+type consulSource struct {
+	client     *http.Client
+	address    *url.URL
+	token      string
+	datacenter string
+	tags       []string
+}
+
+// NewConsulSource creates a new consulSource with the given config.
+func NewConsulSource(cfg *Config) (Source, error) {
+	address, err := url.Parse(cfg.ConsulAddress)
+	if err != nil || (address.Scheme != "http" && address.Scheme != "https") || address.Host == "" {
+		return nil, fmt.Errorf("consul source requires an http or https --consul-address, got %q", cfg.ConsulAddress)
+	}
+	return &consulSource{
+		// requests are bounded by their context, since blocking queries outlast consulRequestTimeout
+		client:     extdnshttp.NewInstrumentedClient(&http.Client{}),
+		address:    address,
+		token:      cfg.ConsulToken,
+		datacenter: cfg.ConsulDatacenter,
+		tags:       cfg.ConsulTags,
+	}, nil
+}
+
+// Endpoints returns the endpoints of the Consul services with hostname metadata.
+func (cs *consulSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	var services map[string][]string
+	if _, err := cs.get(ctx, "/v1/catalog/services", nil, &services); err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, name := range slices.Sorted(maps.Keys(services)) {
+		// the metadata of a service is only returned with its instances
+		if !cs.matchesTags(services[name]) {
+			continue
+		}
+		var instances []consulCatalogService
+		if _, err := cs.get(ctx, "/v1/catalog/service/"+url.PathEscape(name), nil, &instances); err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, cs.endpointsForService(instances)...)
+	}
+	return endpoints, nil
+}
+
+// endpointsForService returns the endpoints of the hostnames of the instances of a service.
+func (cs *consulSource) endpointsForService(instances []consulCatalogService) []*endpoint.Endpoint {
+	var resource string
+	targets := map[string]endpoint.Targets{}
+	ttls := map[string]endpoint.TTL{}
+	for _, instance := range instances {
+		hostnames := splitServiceHostnames(instance.ServiceMeta[consulHostnameMeta])
+		if len(hostnames) == 0 || !cs.matchesTags(instance.ServiceTags) {
+			continue
+		}
+		resource = fmt.Sprintf("%s/%s/%s", types.Consul, instance.Datacenter, instance.ServiceName)
+		instanceTargets := splitServiceHostnames(instance.ServiceMeta[consulTargetMeta])
+		if len(instanceTargets) == 0 {
+			instanceTargets = []string{cmp.Or(instance.ServiceAddress, instance.Address)}
+		}
+		var ttl endpoint.TTL
+		if value, ok := instance.ServiceMeta[consulTTLMeta]; ok {
+			ttl = parseServiceTTL(resource, value)
+		}
+		for _, hostname := range hostnames {
+			for _, target := range instanceTargets {
+				if target != "" && !slices.Contains(targets[hostname], target) {
+					targets[hostname] = append(targets[hostname], target)
+				}
+			}
+			if ttl.IsConfigured() {
+				ttls[hostname] = ttl
+			}
+		}
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, hostname := range slices.Sorted(maps.Keys(targets)) {
+		slices.Sort(targets[hostname])
+		endpoints = append(endpoints, endpoint.EndpointsForHostname(hostname, targets[hostname], ttls[hostname], nil, "", resource)...)
+	}
+	return endpoints
+}
+
+// matchesTags returns whether tags include all the tags required with --consul-tag.
+func (cs *consulSource) matchesTags(tags []string) bool {
+	for _, t := range cs.tags {
+		if !slices.Contains(tags, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// get decodes the response of the Consul API at path into out, and returns its index.
+func (cs *consulSource) get(ctx context.Context, path string, query url.Values, out any) (uint64, error) {
+	timeout := consulRequestTimeout
+	if query.Has("wait") {
+		timeout += consulWaitTime
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if query == nil {
+		query = url.Values{}
+	}
+	if cs.datacenter != "" {
+		query.Set("dc", cs.datacenter)
+	}
+	u := cs.address.JoinPath(path)
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	if cs.token != "" {
+		req.Header.Set("X-Consul-Token", cs.token)
+	}
+	resp, err := cs.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query Consul: %w", err)
+	}
+	defer extdnshttp.DrainAndClose(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return 0, fmt.Errorf("failed to query Consul %s: %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return 0, fmt.Errorf("failed to decode Consul %s: %w", path, err)
+	}
+	index, _ := strconv.ParseUint(resp.Header.Get(consulIndexHeader), 10, 64)
+	return index, nil
+}
+
+// AddEventHandler watches the catalog with blocking queries and calls handler when it changes.
+func (cs *consulSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("consul: adding event handler")
+	go func() {
+		var index uint64
+		for {
+			query := url.Values{"index": {strconv.FormatUint(index, 10)}, "wait": {consulWaitTime.String()}}
+			var services map[string][]string
+			next, err := cs.get(ctx, "/v1/catalog/services", query, &services)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				log.Warnf("Watching Consul catalog: %v", err)
+				if !sleepContext(ctx, consulRetryInterval) {
+					return
+				}
+				continue
+			}
+			if next == 0 {
+				// a query without index does not block, the catalog is polled instead of looping
+				log.Debugf("Watching Consul catalog: no %s in the response, retrying in %s", consulIndexHeader, consulRetryInterval)
+				index = 0
+				if !sleepContext(ctx, consulRetryInterval) {
+					return
+				}
+				continue
+			}
+			if index != 0 && next != index {
+				handler()
+			}
+			// the index may go backwards, e.g. after a restore of the servers, and must then be reset
+			if next < index {
+				next = 0
+			}
+			index = next
+		}
+	}()
+}
+
+// sleepContext waits for d, and reports false when ctx is done before.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
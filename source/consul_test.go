@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+// fakeConsul serves the catalog of the Consul HTTP API, with blocking queries.
+type fakeConsul struct {
+	instances []consulCatalogService
+	token     string
+	index     atomic.Uint64
+	requests  atomic.Int32
+}
+
+func (f *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests.Add(1)
+	if f.token != "" && r.Header.Get("X-Consul-Token") != f.token {
+		http.Error(w, "ACL not found", http.StatusForbidden)
+		return
+	}
+	dc := r.URL.Query().Get("dc")
+	if dc == "" {
+		dc = "dc1"
+	}
+	if index, err := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64); err == nil && index > 0 {
+		for f.index.Load() == index {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}
+	w.Header().Set(consulIndexHeader, strconv.FormatUint(f.index.Load(), 10))
+
+	if r.URL.Path == "/v1/catalog/services" {
+		services := map[string][]string{}
+		for _, instance := range f.instances {
+			if instance.Datacenter == dc {
+				services[instance.ServiceName] = append(services[instance.ServiceName], instance.ServiceTags...)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(services)
+		return
+	}
+	name, ok := strings.CutPrefix(r.URL.Path, "/v1/catalog/service/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	instances := []consulCatalogService{}
+	for _, instance := range f.instances {
+		if instance.ServiceName == name && instance.Datacenter == dc {
+			instances = append(instances, instance)
+		}
+	}
+	_ = json.NewEncoder(w).Encode(instances)
+}
+
+func newTestConsulSource(t *testing.T, server *httptest.Server, cfg *Config) Source {
+	t.Helper()
+	cfg.ConsulAddress = server.URL
+	src, err := NewConsulSource(cfg)
+	require.NoError(t, err)
+	return src
+}
+
+func TestConsulSourceEndpoints(t *testing.T) {
+	web := map[string]string{"external-dns-hostname": "web.example.org, www.example.org.", "external-dns-ttl": "60"}
+	consul := &fakeConsul{instances: []consulCatalogService{
+		{Datacenter: "dc1", Node: "node1", Address: "10.0.0.1", ServiceName: "web", ServiceTags: []string{"public"}, ServiceMeta: web},
+		{Datacenter: "dc1", Node: "node2", Address: "10.0.0.99", ServiceAddress: "10.0.0.2", ServiceName: "web", ServiceTags: []string{"public"}, ServiceMeta: web},
+		{Datacenter: "dc1", Node: "node3", Address: "10.0.0.3", ServiceName: "web", ServiceTags: []string{"canary"}, ServiceMeta: web},
+		{Datacenter: "dc1", Node: "node1", Address: "10.0.0.1", ServiceName: "api", ServiceMeta: map[string]string{"external-dns-hostname": "api.example.org", "external-dns-target": "lb.example.org", "external-dns-ttl": "invalid"}},
+		{Datacenter: "dc1", Node: "node1", Address: "10.0.0.1", ServiceName: "db", ServiceTags: []string{"public"}},
+		{Datacenter: "dc2", Node: "node4", Address: "10.1.0.1", ServiceName: "web", ServiceTags: []string{"public"}, ServiceMeta: map[string]string{"external-dns-hostname": "web.dc2.example.org"}},
+	}}
+	server := httptest.NewServer(consul)
+	defer server.Close()
+
+	for _, tt := range []struct {
+		title      string
+		datacenter string
+		tags       []string
+		expected   []*endpoint.Endpoint
+	}{
+		{
+			title: "datacenter of the agent",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeCNAME, "lb.example.org").
+					WithLabel(endpoint.ResourceLabelKey, "consul/dc1/api"),
+				endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2", "10.0.0.3").
+					WithLabel(endpoint.ResourceLabelKey, "consul/dc1/web"),
+				endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2", "10.0.0.3").
+					WithLabel(endpoint.ResourceLabelKey, "consul/dc1/web"),
+			},
+		},
+		{
+			title: "tag filter",
+			tags:  []string{"public"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2").
+					WithLabel(endpoint.ResourceLabelKey, "consul/dc1/web"),
+				endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2").
+					WithLabel(endpoint.ResourceLabelKey, "consul/dc1/web"),
+			},
+		},
+		{
+			title:      "other datacenter",
+			datacenter: "dc2",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.dc2.example.org", endpoint.RecordTypeA, "10.1.0.1").
+					WithLabel(endpoint.ResourceLabelKey, "consul/dc2/web"),
+			},
+		},
+		{
+			title: "no matching service",
+			tags:  []string{"public", "internal"},
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			src := newTestConsulSource(t, server, &Config{ConsulDatacenter: tt.datacenter, ConsulTags: tt.tags})
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+			assert.True(t, testutils.SameEndpoints(tt.expected, endpoints), "expected %v, got %v", tt.expected, endpoints)
+		})
+	}
+}
+
+func TestConsulSourceToken(t *testing.T) {
+	consul := &fakeConsul{token: "secret", instances: []consulCatalogService{
+		{Datacenter: "dc1", Address: "10.0.0.1", ServiceName: "web", ServiceMeta: map[string]string{"external-dns-hostname": "web.example.org"}},
+	}}
+	server := httptest.NewServer(consul)
+	defer server.Close()
+
+	_, err := newTestConsulSource(t, server, &Config{}).Endpoints(t.Context())
+	require.ErrorContains(t, err, "403 Forbidden: ACL not found")
+
+	endpoints, err := newTestConsulSource(t, server, &Config{ConsulToken: "secret"}).Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+}
+
+func TestConsulSourceAddEventHandler(t *testing.T) {
+	consul := &fakeConsul{}
+	consul.index.Store(1)
+	server := httptest.NewServer(consul)
+	// closed after the cancellation of the context of the test, which ends the pending blocking query
+	t.Cleanup(server.Close)
+
+	src := newTestConsulSource(t, server, &Config{})
+	var calls atomic.Int32
+	src.AddEventHandler(t.Context(), func() { calls.Add(1) })
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, calls.Load(), "an unchanged index must not trigger the handler")
+
+	consul.index.Store(2)
+	assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+	consul.index.Store(3)
+	assert.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestConsulSourceAddEventHandlerWithoutIndex(t *testing.T) {
+	consul := &fakeConsul{}
+	server := httptest.NewServer(consul)
+	t.Cleanup(server.Close)
+
+	src := newTestConsulSource(t, server, &Config{})
+	var calls atomic.Int32
+	src.AddEventHandler(t.Context(), func() { calls.Add(1) })
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), consul.requests.Load(), "a response without index must not be followed by a query right away")
+	assert.Zero(t, calls.Load())
+}
+
+func TestNewConsulSource(t *testing.T) {
+	_, err := NewConsulSource(&Config{ConsulAddress: "https://consul.example.org:8501"})
+	require.NoError(t, err)
+
+	_, err = NewConsulSource(&Config{ConsulAddress: "consul.example.org:8501"})
+	require.ErrorContains(t, err, "--consul-address")
+}
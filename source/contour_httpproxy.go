@@ -17,17 +17,21 @@ limitations under the License.
 package source
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	projectcontour "github.com/projectcontour/contour/apis/projectcontour/v1"
 	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
 
 	"sigs.k8s.io/external-dns/source/types"
 
@@ -38,9 +42,16 @@ import (
 	"sigs.k8s.io/external-dns/source/template"
 )
 
+const (
+	// httpProxyStatusInvalid is the status Contour sets on HTTPProxies it refuses to serve.
+	httpProxyStatusInvalid = "invalid"
+)
+
 // HTTPProxySource is an implementation of Source for ProjectContour HTTPProxy objects.
 // The HTTPProxy implementation uses the spec.virtualHost.fqdn value for the hostname.
 // Use annotations.TargetKey to explicitly set Endpoint.
+// Included HTTPProxies resolve their targets through the root HTTPProxies including them,
+// and the Envoy service is used when no load balancer status is available.
 //
 // +externaldns:source:name=contour-httpproxy
 // +externaldns:source:category=Ingress Controllers
@@ -52,6 +63,8 @@ import (
 // +externaldns:source:provider-specific=true
 type httpProxySource struct {
 	dynamicKubeClient        dynamic.Interface
+	kubeClient               kubernetes.Interface
+	envoyService             string
 	namespace                string
 	annotationFilter         labels.Selector
 	templateEngine           template.Engine
@@ -64,11 +77,12 @@ type httpProxySource struct {
 func NewContourHTTPProxySource(
 	ctx context.Context,
 	dynamicKubeClient dynamic.Interface,
+	kubeClient kubernetes.Interface,
 	cfg *Config,
 ) (Source, error) {
 	// Use shared informer to listen for add/update/delete of HTTPProxys in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, cfg.Namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, cfg.Namespace, informers.ListPageSize(cfg.KubeAPIListPageSize))
 	httpProxyInformer := informerFactory.ForResource(projectcontour.HTTPProxyGVR)
 
 	informers.MustSetTransform(httpProxyInformer.Informer(), informers.TransformerWithOptions[*unstructured.Unstructured](
@@ -79,6 +93,7 @@ func NewContourHTTPProxySource(
 
 	// Add default resource event handlers to properly initialize informer.
 	informers.MustAddEventHandler(httpProxyInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(httpProxyInformer.Informer(), "contour-httpproxy/httpproxy")
 
 	informerFactory.Start(ctx.Done())
 
@@ -94,6 +109,8 @@ func NewContourHTTPProxySource(
 
 	return &httpProxySource{
 		dynamicKubeClient:        dynamicKubeClient,
+		kubeClient:               kubeClient,
+		envoyService:             cfg.ContourEnvoyService,
 		namespace:                cfg.Namespace,
 		annotationFilter:         cfg.AnnotationFilter,
 		templateEngine:           cfg.TemplateEngine,
@@ -105,7 +122,7 @@ func NewContourHTTPProxySource(
 
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all HTTPProxy resources in the source's namespace(s).
-func (sc *httpProxySource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (sc *httpProxySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	hps, err := sc.httpProxyInformer.Lister().ByNamespace(sc.namespace).List(labels.Everything())
 	if err != nil {
 		return nil, err
@@ -126,21 +143,48 @@ func (sc *httpProxySource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, e
 		httpProxies = append(httpProxies, hpConverted)
 	}
 
+	roots := includingRoots(httpProxies)
+
 	httpProxies = annotations.Filter(httpProxies, sc.annotationFilter)
 
 	endpoints := []*endpoint.Endpoint{}
 
+	var envoyTargets endpoint.Targets
+	envoyResolved := false
+
 	for _, hp := range httpProxies {
 		if annotations.IsControllerMismatch(hp, types.ContourHTTPProxy) {
 			continue
 		}
 
-		hpEndpoints := sc.endpointsFromHTTPProxy(hp)
+		if hp.Status.CurrentStatus == httpProxyStatusInvalid {
+			log.Debugf("Skipping HTTPProxy %s/%s: status is %s: %s", hp.Namespace, hp.Name, hp.Status.CurrentStatus, hp.Status.Description)
+			continue
+		}
+
+		targets := targetsFromHTTPProxy(hp)
+		if len(targets) == 0 && hp.Spec.VirtualHost == nil {
+			for _, root := range roots[httpProxyKey(hp.Namespace, hp.Name)] {
+				targets = mergeTargets(targets, targetsFromHTTPProxy(root))
+			}
+		}
+		if len(targets) == 0 && sc.envoyService != "" {
+			if !envoyResolved {
+				envoyTargets, err = sc.targetsFromEnvoyService(ctx)
+				if err != nil {
+					log.Warnf("Could not find targets for Envoy service %s: %v", sc.envoyService, err)
+				}
+				envoyResolved = true
+			}
+			targets = envoyTargets
+		}
+
+		hpEndpoints := sc.endpointsFromHTTPProxy(hp, targets)
 
 		// apply template if fqdn is missing on HTTPProxy
 		hpEndpoints, err = sc.templateEngine.CombineWithEndpoints(
 			hpEndpoints,
-			func() ([]*endpoint.Endpoint, error) { return sc.endpointsFromTemplate(hp) },
+			func() ([]*endpoint.Endpoint, error) { return sc.endpointsFromTemplate(hp, targets) },
 		)
 		if err != nil {
 			return nil, err
@@ -159,7 +203,7 @@ func (sc *httpProxySource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, e
 	return endpoint.MergeEndpoints(endpoints), nil
 }
 
-func (sc *httpProxySource) endpointsFromTemplate(httpProxy *projectcontour.HTTPProxy) ([]*endpoint.Endpoint, error) {
+func (sc *httpProxySource) endpointsFromTemplate(httpProxy *projectcontour.HTTPProxy, targets endpoint.Targets) ([]*endpoint.Endpoint, error) {
 	hostnames, err := sc.templateEngine.ExecFQDN(httpProxy)
 	if err != nil {
 		return nil, err
@@ -169,18 +213,6 @@ func (sc *httpProxySource) endpointsFromTemplate(httpProxy *projectcontour.HTTPP
 
 	ttl := annotations.TTLFromAnnotations(httpProxy.Annotations, resource)
 
-	targets := annotations.TargetsFromTargetAnnotation(httpProxy.Annotations)
-	if len(targets) == 0 {
-		for _, lb := range httpProxy.Status.LoadBalancer.Ingress {
-			if lb.IP != "" {
-				targets = append(targets, lb.IP)
-			}
-			if lb.Hostname != "" {
-				targets = append(targets, lb.Hostname)
-			}
-		}
-	}
-
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(httpProxy.Annotations)
 
 	var endpoints []*endpoint.Endpoint
@@ -191,24 +223,11 @@ func (sc *httpProxySource) endpointsFromTemplate(httpProxy *projectcontour.HTTPP
 }
 
 // endpointsFromHTTPProxyConfig extracts the endpoints from a Contour HTTPProxy object
-func (sc *httpProxySource) endpointsFromHTTPProxy(httpProxy *projectcontour.HTTPProxy) []*endpoint.Endpoint {
+func (sc *httpProxySource) endpointsFromHTTPProxy(httpProxy *projectcontour.HTTPProxy, targets endpoint.Targets) []*endpoint.Endpoint {
 	resource := fmt.Sprintf("HTTPProxy/%s/%s", httpProxy.Namespace, httpProxy.Name)
 
 	ttl := annotations.TTLFromAnnotations(httpProxy.Annotations, resource)
 
-	targets := annotations.TargetsFromTargetAnnotation(httpProxy.Annotations)
-
-	if len(targets) == 0 {
-		for _, lb := range httpProxy.Status.LoadBalancer.Ingress {
-			if lb.IP != "" {
-				targets = append(targets, lb.IP)
-			}
-			if lb.Hostname != "" {
-				targets = append(targets, lb.Hostname)
-			}
-		}
-	}
-
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(httpProxy.Annotations)
 
 	var endpoints []*endpoint.Endpoint
@@ -230,6 +249,88 @@ func (sc *httpProxySource) endpointsFromHTTPProxy(httpProxy *projectcontour.HTTP
 	return endpoints
 }
 
+// targetsFromHTTPProxy returns the targets from the target annotation, or from the load balancer status of the HTTPProxy.
+func targetsFromHTTPProxy(httpProxy *projectcontour.HTTPProxy) endpoint.Targets {
+	targets := annotations.TargetsFromTargetAnnotation(httpProxy.Annotations)
+	if len(targets) > 0 {
+		return targets
+	}
+
+	for _, lb := range httpProxy.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			targets = append(targets, lb.IP)
+		}
+		if lb.Hostname != "" {
+			targets = append(targets, lb.Hostname)
+		}
+	}
+	return targets
+}
+
+// targetsFromEnvoyService returns the load balancer targets of the Envoy service fronting Contour.
+func (sc *httpProxySource) targetsFromEnvoyService(ctx context.Context) (endpoint.Targets, error) {
+	namespace, name, err := ParseIngress(sc.envoyService)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := sc.kubeClient.CoreV1().Services(cmp.Or(namespace, metav1.NamespaceDefault)).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return extractLoadBalancerTargets(svc, false), nil
+}
+
+// includingRoots maps each included HTTPProxy to the root HTTPProxies that include it, directly or
+// through a chain of includes. Roots are HTTPProxies defining a virtual host, and Contour ignores
+// includes of invalid roots.
+func includingRoots(httpProxies []*projectcontour.HTTPProxy) map[string][]*projectcontour.HTTPProxy {
+	byKey := make(map[string]*projectcontour.HTTPProxy, len(httpProxies))
+	for _, hp := range httpProxies {
+		byKey[httpProxyKey(hp.Namespace, hp.Name)] = hp
+	}
+
+	roots := make(map[string][]*projectcontour.HTTPProxy)
+	for _, root := range httpProxies {
+		if root.Spec.VirtualHost == nil || root.Status.CurrentStatus == httpProxyStatusInvalid {
+			continue
+		}
+
+		visited := map[string]bool{httpProxyKey(root.Namespace, root.Name): true}
+		queue := []*projectcontour.HTTPProxy{root}
+		for len(queue) > 0 {
+			parent := queue[0]
+			queue = queue[1:]
+			for _, include := range parent.Spec.Includes {
+				key := httpProxyKey(cmp.Or(include.Namespace, parent.Namespace), include.Name)
+				if visited[key] {
+					continue
+				}
+				visited[key] = true
+				roots[key] = append(roots[key], root)
+				if child, ok := byKey[key]; ok {
+					queue = append(queue, child)
+				}
+			}
+		}
+	}
+	return roots
+}
+
+func httpProxyKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func mergeTargets(targets, more endpoint.Targets) endpoint.Targets {
+	for _, target := range more {
+		if !slices.Contains(targets, target) {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
 func (sc *httpProxySource) AddEventHandler(_ context.Context, handler func()) {
 	log.Debug("Adding event handler for httpproxy")
 
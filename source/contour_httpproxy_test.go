@@ -24,6 +24,7 @@ import (
 	"sigs.k8s.io/external-dns/internal/testutils"
 
 	fakeDynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
 
 	projectcontour "github.com/projectcontour/contour/apis/projectcontour/v1"
 	"github.com/stretchr/testify/assert"
@@ -97,6 +98,7 @@ func (suite *HTTPProxySuite) SetupTest() {
 	suite.source, err = NewContourHTTPProxySource(
 		context.TODO(),
 		fakeDynamicClient,
+		fake.NewClientset(),
 		&Config{
 			Namespace:      "default",
 			TemplateEngine: templatetest.MustEngine(suite.T(), "{{.Name}}", "", "", false),
@@ -252,7 +254,8 @@ func testEndpointsFromHTTPProxy(t *testing.T) {
 			source, err := newTestHTTPProxySource(t)
 			require.NoError(t, err)
 
-			endpoints := source.endpointsFromHTTPProxy(ti.httpProxy.HTTPProxy())
+			httpProxy := ti.httpProxy.HTTPProxy()
+			endpoints := source.endpointsFromHTTPProxy(httpProxy, targetsFromHTTPProxy(httpProxy))
 			testutils.ValidateEndpoints(t, endpoints, ti.expected)
 		})
 	}
@@ -1022,6 +1025,7 @@ func testHTTPProxyEndpoints(t *testing.T) {
 			httpProxySource, err := NewContourHTTPProxySource(
 				t.Context(),
 				fakeDynamicClient,
+				fake.NewClientset(),
 				&Config{
 					Namespace:                ti.targetNamespace,
 					AnnotationFilter:         parseAnnotationFilterOrNil(ti.annotationFilter),
@@ -1050,6 +1054,7 @@ func newTestHTTPProxySource(t *testing.T) (*httpProxySource, error) {
 	src, err := NewContourHTTPProxySource(
 		t.Context(),
 		fakeDynamicClient,
+		fake.NewClientset(),
 		&Config{
 			TemplateEngine: templatetest.MustEngine(t, "{{.Name}}", "", "", false),
 		},
@@ -1074,6 +1079,8 @@ type fakeHTTPProxy struct {
 
 	host         string
 	delegate     bool
+	includes     []projectcontour.Include
+	status       string
 	loadBalancer fakeLoadBalancerService
 }
 
@@ -1089,6 +1096,8 @@ func (ir fakeHTTPProxy) HTTPProxy() *projectcontour.HTTPProxy {
 		}
 	}
 
+	spec.Includes = ir.includes
+
 	lb := v1.LoadBalancerStatus{
 		Ingress: []v1.LoadBalancerIngress{},
 	}
@@ -1113,7 +1122,8 @@ func (ir fakeHTTPProxy) HTTPProxy() *projectcontour.HTTPProxy {
 		},
 		Spec: spec,
 		Status: projectcontour.HTTPProxyStatus{
-			LoadBalancer: lb,
+			CurrentStatus: ir.status,
+			LoadBalancer:  lb,
 		},
 	}
 
@@ -1125,7 +1135,7 @@ func TestContourHTTPProxySource_InformerTransform(t *testing.T) {
 
 	fakeDynamicClient, _ := newContourDynamicKubernetesClient()
 
-	source, err := NewContourHTTPProxySource(t.Context(), fakeDynamicClient, &Config{})
+	source, err := NewContourHTTPProxySource(t.Context(), fakeDynamicClient, fake.NewClientset(), &Config{})
 	require.NoError(t, err)
 	require.IsType(t, &httpProxySource{}, source)
 
@@ -1137,3 +1147,144 @@ func TestContourHTTPProxySource_InformerTransform(t *testing.T) {
 		withRemovedStatusConditions(),
 	)
 }
+
+func TestHTTPProxyIncludesAndEnvoyService(t *testing.T) {
+	t.Parallel()
+
+	envoy := fakeLoadBalancerService{
+		name:      "envoy",
+		namespace: "projectcontour",
+		hostnames: []string{"envoy.lb.com"},
+	}
+
+	for _, ti := range []struct {
+		title        string
+		envoyService string
+		httpProxies  []fakeHTTPProxy
+		expected     []*endpoint.Endpoint
+	}{
+		{
+			title: "included httpproxy resolves targets through its root",
+			httpProxies: []fakeHTTPProxy{
+				{
+					name:         "root",
+					namespace:    "default",
+					host:         "example.org",
+					includes:     []projectcontour.Include{{Name: "child", Namespace: "apps"}},
+					loadBalancer: fakeLoadBalancerService{ips: []string{"8.8.8.8"}},
+				},
+				{
+					name:      "child",
+					namespace: "apps",
+					delegate:  true,
+					includes:  []projectcontour.Include{{Name: "grandchild"}},
+					annotations: map[string]string{
+						annotations.HostnameKey: "child.example.org",
+					},
+				},
+				{
+					name:      "grandchild",
+					namespace: "apps",
+					delegate:  true,
+					annotations: map[string]string{
+						annotations.HostnameKey: "grandchild.example.org",
+					},
+				},
+				{
+					name:      "orphan",
+					namespace: "apps",
+					delegate:  true,
+					annotations: map[string]string{
+						annotations.HostnameKey: "orphan.example.org",
+					},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"8.8.8.8"}},
+				{DNSName: "child.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"8.8.8.8"}},
+				{DNSName: "grandchild.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"8.8.8.8"}},
+			},
+		},
+		{
+			title: "invalid httpproxies are skipped and do not lend targets to their includes",
+			httpProxies: []fakeHTTPProxy{
+				{
+					name:         "root",
+					namespace:    "default",
+					host:         "example.org",
+					status:       "invalid",
+					includes:     []projectcontour.Include{{Name: "child"}},
+					loadBalancer: fakeLoadBalancerService{ips: []string{"8.8.8.8"}},
+				},
+				{
+					name:      "child",
+					namespace: "default",
+					delegate:  true,
+					annotations: map[string]string{
+						annotations.HostnameKey: "child.example.org",
+					},
+				},
+				{
+					name:         "valid",
+					namespace:    "default",
+					host:         "valid.example.org",
+					status:       "valid",
+					loadBalancer: fakeLoadBalancerService{ips: []string{"8.8.4.4"}},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "valid.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"8.8.4.4"}},
+			},
+		},
+		{
+			title:        "envoy service is used when there is no load balancer status",
+			envoyService: "projectcontour/envoy",
+			httpProxies: []fakeHTTPProxy{
+				{
+					name:      "pending",
+					namespace: "default",
+					host:      "pending.example.org",
+				},
+				{
+					name:         "ready",
+					namespace:    "default",
+					host:         "ready.example.org",
+					loadBalancer: fakeLoadBalancerService{ips: []string{"8.8.8.8"}},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "pending.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"envoy.lb.com"}},
+				{DNSName: "ready.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"8.8.8.8"}},
+			},
+		},
+	} {
+		t.Run(ti.title, func(t *testing.T) {
+			t.Parallel()
+
+			fakeKubeClient := fake.NewClientset(envoy.Service())
+			fakeDynamicClient, scheme := newContourDynamicKubernetesClient()
+			for _, item := range ti.httpProxies {
+				converted, err := convertHTTPProxyToUnstructured(item.HTTPProxy(), scheme)
+				require.NoError(t, err)
+				_, err = fakeDynamicClient.Resource(projectcontour.HTTPProxyGVR).Namespace(item.namespace).Create(t.Context(), converted, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			src, err := NewContourHTTPProxySource(
+				t.Context(),
+				fakeDynamicClient,
+				fakeKubeClient,
+				&Config{
+					TemplateEngine:      templatetest.MustEngine(t, "", "", "", false),
+					ContourEnvoyService: ti.envoyService,
+				},
+			)
+			require.NoError(t, err)
+
+			res, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+
+			testutils.ValidateEndpoints(t, res, ti.expected)
+		})
+	}
+}
@@ -19,19 +19,23 @@ package source
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/source/informers"
 	"sigs.k8s.io/external-dns/source/types"
 )
@@ -50,9 +54,14 @@ import (
 // +externaldns:source:provider-specific=true
 type crdSource struct {
 	crReader client.Reader
-	crWriter client.Client // status writes
+	crWriter client.Client // status and finalizer writes
 	informer crcache.Informer
 	listOpts []client.ListOption
+	// finalizer enables DNSEndpointFinalizer management, so deleted resources
+	// are only released once their provider records are gone.
+	finalizer bool
+	// defaultTTL is the TTL of the endpoints without recordTTL, unset when not configured.
+	defaultTTL endpoint.TTL
 }
 
 // NewCRDSource creates a new crdSource backed by a controller-runtime cache.
@@ -68,13 +77,23 @@ func NewCRDSource(ctx context.Context, restConfig *rest.Config, cfg *Config) (So
 		return nil, err
 	}
 
-	// crWriter is used exclusively for status writes; reads come from the cache.
+	// crWriter is used exclusively for status and finalizer writes; reads come from the cache.
 	crWriter, err := client.New(restConfig, client.Options{Scheme: opts.Scheme})
 	if err != nil {
 		return nil, err
 	}
+	if cfg.Diff {
+		// the diff mode leaves the status and finalizers of the DNSEndpoints untouched
+		crWriter = client.NewDryRunClient(crWriter)
+	}
 
-	return newCrdSource(ctx, c, crWriter, cfg.Namespace, cfg.LabelFilter)
+	cs, err := newCrdSource(ctx, c, crWriter, cfg.Namespace, cfg.LabelFilter)
+	if err != nil {
+		return nil, err
+	}
+	cs.finalizer = cfg.EnableDNSEndpointFinalizer
+	cs.defaultTTL = endpoint.TTL(cfg.CRDDefaultTTL.Seconds())
+	return cs, nil
 }
 
 func (cs *crdSource) AddEventHandler(_ context.Context, handler func()) {
@@ -94,9 +113,22 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 	}
 
 	endpoints := make([]*endpoint.Endpoint, 0, len(list.Items))
+	var live []*apiv1alpha1.DNSEndpoint
 	for i := range list.Items {
 		dnsEndpoint := &list.Items[i]
+		if cs.finalizer {
+			if !dnsEndpoint.DeletionTimestamp.IsZero() {
+				// Endpoints of a deleted resource are left out of the desired state,
+				// so the plan removes them from the provider.
+				cs.finalizeDeletion(ctx, dnsEndpoint)
+				continue
+			}
+			cs.ensureFinalizer(ctx, dnsEndpoint)
+		}
+		live = append(live, dnsEndpoint)
+
 		var crdEndpoints []*endpoint.Endpoint
+		var defaulted []string
 		for _, ep := range dnsEndpoint.Spec.Endpoints {
 			if ep == nil {
 				log.Debugf(
@@ -107,13 +139,18 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 				continue
 			}
 
+			if values := cs.applyDefaults(ep); values != "" {
+				log.Debugf("Endpoint %s/%s with DNSName %s was defaulted: %s", dnsEndpoint.Namespace, dnsEndpoint.Name, ep.DNSName, values)
+				defaulted = append(defaulted, fmt.Sprintf("%s: %s", ep.DNSName, values))
+			}
+
 			if (ep.RecordType == endpoint.RecordTypeCNAME || ep.RecordType == endpoint.RecordTypeA || ep.RecordType == endpoint.RecordTypeAAAA) && len(ep.Targets) < 1 {
 				log.Debugf("Endpoint %s with DNSName %s has an empty list of targets, allowing it to pass through for default-targets processing", dnsEndpoint.Name, ep.DNSName)
 			}
 			illegalTarget := false
 			for _, target := range ep.Targets {
 				switch ep.RecordType {
-				case endpoint.RecordTypeTXT, endpoint.RecordTypeMX:
+				case endpoint.RecordTypeTXT, endpoint.RecordTypeMX, endpoint.RecordTypeCAA:
 					continue // no format constraint on targets
 				case endpoint.RecordTypeCNAME:
 					continue // RFC 1035 §5.1: trailing dot denotes an absolute FQDN in zone file notation; both forms are valid
@@ -157,7 +194,8 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 		endpoint.AttachRefObject(crdEndpoints, events.NewObjectReference(dnsEndpoint, types.CRD))
 		endpoints = append(endpoints, crdEndpoints...)
 
-		if dnsEndpoint.Status.ObservedGeneration == dnsEndpoint.Generation {
+		defaultedChanged := setDefaultedCondition(dnsEndpoint, defaulted)
+		if dnsEndpoint.Status.ObservedGeneration == dnsEndpoint.Generation && !defaultedChanged {
 			continue
 		}
 
@@ -168,9 +206,235 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 		}
 	}
 
+	OnSyncOutcome(ctx, func(ctx context.Context, outcome SyncOutcome) {
+		for _, dnsEndpoint := range live {
+			changes := ResourceChangesFromContext(ctx, fmt.Sprintf("crd/%s/%s", dnsEndpoint.Namespace, dnsEndpoint.Name))
+			cs.setSynced(ctx, dnsEndpoint, outcome, changes)
+		}
+	})
+
 	return endpoint.MergeEndpoints(endpoints), nil
 }
 
+// applyDefaults sets the recordType of ep from its targets and its recordTTL to the
+// default TTL when they are empty, so that the endpoint does not fail later in the
+// plan or the provider. It returns the values it set, empty when none.
+func (cs *crdSource) applyDefaults(ep *endpoint.Endpoint) string {
+	var values []string
+	if ep.RecordType == "" {
+		if recordType := inferRecordType(ep.Targets); recordType != "" {
+			ep.RecordType = recordType
+			values = append(values, "recordType="+recordType)
+		}
+	}
+	if !ep.RecordTTL.IsConfigured() && cs.defaultTTL.IsConfigured() {
+		ep.RecordTTL = cs.defaultTTL
+		values = append(values, fmt.Sprintf("recordTTL=%d", cs.defaultTTL))
+	}
+	return strings.Join(values, ", ")
+}
+
+// inferRecordType returns the record type suiting every target, A, AAAA or CNAME,
+// or an empty string when there is no target or the targets need different types.
+func inferRecordType(targets endpoint.Targets) string {
+	var recordType string
+	for _, target := range targets {
+		t := endpoint.SuitableType(target)
+		if recordType != "" && t != recordType {
+			return ""
+		}
+		recordType = t
+	}
+	return recordType
+}
+
+// setDefaultedCondition sets the Defaulted condition of dnsEndpoint listing the
+// defaulted values, or removes it when there is none. It reports whether the
+// status changed.
+func setDefaultedCondition(dnsEndpoint *apiv1alpha1.DNSEndpoint, defaulted []string) bool {
+	if len(defaulted) == 0 {
+		return meta.RemoveStatusCondition(&dnsEndpoint.Status.Conditions, apiv1alpha1.DefaultedCondition)
+	}
+	return meta.SetStatusCondition(&dnsEndpoint.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.DefaultedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: dnsEndpoint.Generation,
+		Reason:             apiv1alpha1.ValuesDefaultedReason,
+		Message:            strings.Join(defaulted, "; "),
+	})
+}
+
+// syncedCondition returns the Synced condition reporting outcome, given the changes
+// to the records of dnsEndpoint in the synchronization.
+func syncedCondition(dnsEndpoint *apiv1alpha1.DNSEndpoint, outcome SyncOutcome, changes ResourceChanges) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               apiv1alpha1.SyncedCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: dnsEndpoint.Generation,
+	}
+	// the failed chunks of a partial synchronization may not hold changes of dnsEndpoint
+	if outcome == SyncOutcomePartial && changes.Pending == 0 {
+		outcome = SyncOutcomeNoop
+		if changes.Applied > 0 {
+			outcome = SyncOutcomeApplied
+		}
+	}
+	switch outcome {
+	case SyncOutcomeNoop:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = apiv1alpha1.NoChangesReason
+		condition.Message = "records are up to date"
+	case SyncOutcomeSkippedDueToCache:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = apiv1alpha1.SkippedDueToCacheReason
+		condition.Message = "records are up to date with the records cached from the provider"
+	case SyncOutcomeApplied:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = apiv1alpha1.ChangesAppliedReason
+		condition.Message = "changes applied to the provider"
+	case SyncOutcomePartial:
+		condition.Reason = apiv1alpha1.PartiallyAppliedReason
+		condition.Message = fmt.Sprintf("%d of %d changes failed to be applied to the provider", changes.Pending, changes.Applied+changes.Pending)
+	case SyncOutcomeSkipped:
+		condition.Reason = apiv1alpha1.ChangesSkippedReason
+		condition.Message = "changes are held back, e.g. outside of the change window"
+	case SyncOutcomeSuspended:
+		condition.Reason = apiv1alpha1.ChangesSkippedReason
+		condition.Message = "changes are held back while the synchronizations are suspended"
+	default:
+		condition.Reason = apiv1alpha1.SyncFailedReason
+		condition.Message = "synchronization failed"
+	}
+	return condition
+}
+
+// setChangeCounts sets the change counts of the dnsEndpoint status to changes. A
+// synchronization without changes of dnsEndpoint keeps the counts of the last one,
+// except the pending changes which are cleared once the records are up to date.
+// It reports whether the status changed.
+func setChangeCounts(dnsEndpoint *apiv1alpha1.DNSEndpoint, outcome SyncOutcome, changes ResourceChanges) bool {
+	status := &dnsEndpoint.Status
+	applied, pending := status.AppliedChanges, status.PendingChanges
+	switch {
+	case changes.Applied+changes.Pending > 0:
+		applied, pending = int32(changes.Applied), int32(changes.Pending)
+	case outcome == SyncOutcomeNoop, outcome == SyncOutcomeSkippedDueToCache, outcome == SyncOutcomeApplied, outcome == SyncOutcomePartial:
+		pending = 0
+	}
+	if applied == status.AppliedChanges && pending == status.PendingChanges {
+		return false
+	}
+	status.AppliedChanges, status.PendingChanges = applied, pending
+	return true
+}
+
+// ensureFinalizer adds DNSEndpointFinalizer to a live DNSEndpoint. Failures are
+// logged and retried on the next sync rather than failing the whole source.
+func (cs *crdSource) ensureFinalizer(ctx context.Context, dnsEndpoint *apiv1alpha1.DNSEndpoint) {
+	if !controllerutil.AddFinalizer(dnsEndpoint, apiv1alpha1.DNSEndpointFinalizer) {
+		return
+	}
+	if err := cs.crWriter.Update(ctx, dnsEndpoint); err != nil {
+		log.Warnf("Could not add finalizer to [%s/%s/%s]: %v",
+			"dnsendpoint", dnsEndpoint.Namespace, dnsEndpoint.Name, err)
+	}
+}
+
+// finalizeDeletion releases DNSEndpointFinalizer once none of the records
+// produced by dnsEndpoint are present in the current registry state. While
+// records remain, the Deleting condition reports how many are outstanding.
+// The current state is read from the context the controller populates with
+// the registry records of this sync; without it, deletion cannot be verified
+// and the finalizer is kept.
+func (cs *crdSource) finalizeDeletion(ctx context.Context, dnsEndpoint *apiv1alpha1.DNSEndpoint) {
+	if !controllerutil.ContainsFinalizer(dnsEndpoint, apiv1alpha1.DNSEndpointFinalizer) {
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               apiv1alpha1.DeletingCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: dnsEndpoint.Generation,
+	}
+
+	current, ok := ctx.Value(provider.RecordsContextKey).([]*endpoint.Endpoint)
+	if !ok {
+		condition.Reason = apiv1alpha1.VerificationFailedReason
+		condition.Message = "current provider records are not available, deletion cannot be verified"
+		cs.setCondition(ctx, dnsEndpoint, condition)
+		return
+	}
+
+	if pending := pendingRecords(dnsEndpoint, current); pending > 0 {
+		log.Debugf("DNSEndpoint %s/%s is being deleted, %d records still present in provider",
+			dnsEndpoint.Namespace, dnsEndpoint.Name, pending)
+		condition.Reason = apiv1alpha1.RecordsPendingReason
+		condition.Message = fmt.Sprintf("waiting for %d records to be deleted from provider", pending)
+		cs.setCondition(ctx, dnsEndpoint, condition)
+		return
+	}
+
+	controllerutil.RemoveFinalizer(dnsEndpoint, apiv1alpha1.DNSEndpointFinalizer)
+	if err := cs.crWriter.Update(ctx, dnsEndpoint); err != nil {
+		log.Warnf("Could not remove finalizer from [%s/%s/%s]: %v",
+			"dnsendpoint", dnsEndpoint.Namespace, dnsEndpoint.Name, err)
+		return
+	}
+	log.Infof("All records of DNSEndpoint %s/%s deleted from provider, finalizer removed",
+		dnsEndpoint.Namespace, dnsEndpoint.Name)
+}
+
+// setSynced writes the Synced condition reporting outcome and the change counts of
+// the synchronization to the DNSEndpoint status when they changed.
+func (cs *crdSource) setSynced(ctx context.Context, dnsEndpoint *apiv1alpha1.DNSEndpoint, outcome SyncOutcome, changes ResourceChanges) {
+	countsChanged := setChangeCounts(dnsEndpoint, outcome, changes)
+	if !meta.SetStatusCondition(&dnsEndpoint.Status.Conditions, syncedCondition(dnsEndpoint, outcome, changes)) && !countsChanged {
+		return
+	}
+	if err := cs.crWriter.Status().Update(ctx, dnsEndpoint); err != nil {
+		log.Warnf("Could not update %s condition of [%s/%s/%s]: %v",
+			apiv1alpha1.SyncedCondition, "dnsendpoint", dnsEndpoint.Namespace, dnsEndpoint.Name, err)
+	}
+}
+
+// setCondition writes condition to the DNSEndpoint status when it changed.
+func (cs *crdSource) setCondition(ctx context.Context, dnsEndpoint *apiv1alpha1.DNSEndpoint, condition metav1.Condition) {
+	if !meta.SetStatusCondition(&dnsEndpoint.Status.Conditions, condition) {
+		return
+	}
+	if err := cs.crWriter.Status().Update(ctx, dnsEndpoint); err != nil {
+		log.Warnf("Could not update %s condition of [%s/%s/%s]: %v",
+			condition.Type, "dnsendpoint", dnsEndpoint.Namespace, dnsEndpoint.Name, err)
+	}
+}
+
+// pendingRecords counts the records in current that still belong to
+// dnsEndpoint. Records managed by a registry are matched on the resource
+// label, which may list several merged resources; unlabeled records fall back to matching the spec endpoint keys.
+func pendingRecords(dnsEndpoint *apiv1alpha1.DNSEndpoint, current []*endpoint.Endpoint) int {
+	resource := fmt.Sprintf("crd/%s/%s", dnsEndpoint.Namespace, dnsEndpoint.Name)
+	keys := make(map[endpoint.EndpointKey]struct{}, len(dnsEndpoint.Spec.Endpoints))
+	for _, ep := range dnsEndpoint.Spec.Endpoints {
+		if ep != nil {
+			keys[ep.Key()] = struct{}{}
+		}
+	}
+
+	pending := 0
+	for _, record := range current {
+		if _, owned := record.Labels[endpoint.OwnerLabelKey]; owned {
+			if slices.Contains(record.Labels.Resources(), resource) {
+				pending++
+			}
+			continue
+		}
+		if _, ok := keys[record.Key()]; ok {
+			pending++
+		}
+	}
+	return pending
+}
+
 // newCrdSource wires a cache and writer into a running crdSource.
 func newCrdSource(
 	ctx context.Context,
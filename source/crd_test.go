@@ -26,6 +26,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -46,6 +48,7 @@ import (
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	logtest "sigs.k8s.io/external-dns/internal/testutils/log"
+	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/source/types"
 )
 
@@ -648,6 +651,326 @@ func TestCRDSource_Endpoints_ObservedGenerationUpdateFailure(t *testing.T) {
 	logtest.TestHelperLogContainsWithLogLevel("Could not update ObservedGeneration", log.WarnLevel, hook, t)
 }
 
+func TestCRDSource_Endpoints_AddsFinalizer(t *testing.T) {
+	obj := &apiv1alpha1.DNSEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: apiv1alpha1.DNSEndpointSpec{
+			Endpoints: []*endpoint.Endpoint{
+				{DNSName: "example.org", Targets: endpoint.Targets{"1.2.3.4"}, RecordType: endpoint.RecordTypeA},
+			},
+		},
+	}
+
+	fakeCache := newFakeCRDCache(t, nil, fakeCRDCacheFilter{}, obj)
+	cs, err := newCrdSource(t.Context(), fakeCache, fakeCache.Client, "", nil)
+	require.NoError(t, err)
+	cs.finalizer = true
+
+	endpoints, err := cs.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+
+	updated := &apiv1alpha1.DNSEndpoint{}
+	require.NoError(t, fakeCache.Get(t.Context(), client.ObjectKeyFromObject(obj), updated))
+	require.Contains(t, updated.Finalizers, apiv1alpha1.DNSEndpointFinalizer)
+}
+
+func TestCRDSource_Endpoints_SyncedCondition(t *testing.T) {
+	tests := []struct {
+		outcome SyncOutcome
+		status  metav1.ConditionStatus
+		reason  string
+	}{
+		{outcome: SyncOutcomeNoop, status: metav1.ConditionTrue, reason: apiv1alpha1.NoChangesReason},
+		{outcome: SyncOutcomeSkippedDueToCache, status: metav1.ConditionTrue, reason: apiv1alpha1.SkippedDueToCacheReason},
+		{outcome: SyncOutcomeApplied, status: metav1.ConditionTrue, reason: apiv1alpha1.ChangesAppliedReason},
+		{outcome: SyncOutcomeFailed, status: metav1.ConditionFalse, reason: apiv1alpha1.SyncFailedReason},
+		{outcome: SyncOutcomeSkipped, status: metav1.ConditionFalse, reason: apiv1alpha1.ChangesSkippedReason},
+		{outcome: SyncOutcomeSuspended, status: metav1.ConditionFalse, reason: apiv1alpha1.ChangesSkippedReason},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.outcome), func(t *testing.T) {
+			obj := &apiv1alpha1.DNSEndpoint{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 2},
+				Spec: apiv1alpha1.DNSEndpointSpec{
+					Endpoints: []*endpoint.Endpoint{
+						{DNSName: "example.org", Targets: endpoint.Targets{"1.2.3.4"}, RecordType: endpoint.RecordTypeA},
+					},
+				},
+			}
+
+			fakeCache := newFakeCRDCache(t, nil, fakeCRDCacheFilter{}, obj)
+			cs, err := newCrdSource(t.Context(), fakeCache, fakeCache.Client, "", nil)
+			require.NoError(t, err)
+
+			notifier := &SyncOutcomeNotifier{}
+			_, err = cs.Endpoints(ContextWithSyncOutcomeNotifier(t.Context(), notifier))
+			require.NoError(t, err)
+			notifier.Notify(t.Context(), tt.outcome)
+
+			updated := &apiv1alpha1.DNSEndpoint{}
+			require.NoError(t, fakeCache.Get(t.Context(), client.ObjectKeyFromObject(obj), updated))
+			condition := meta.FindStatusCondition(updated.Status.Conditions, apiv1alpha1.SyncedCondition)
+			require.NotNil(t, condition)
+			require.Equal(t, tt.status, condition.Status)
+			require.Equal(t, tt.reason, condition.Reason)
+			require.Equal(t, int64(2), condition.ObservedGeneration)
+		})
+	}
+}
+
+func TestCRDSource_Endpoints_ChangeCounts(t *testing.T) {
+	tests := []struct {
+		name        string
+		outcome     SyncOutcome
+		status      apiv1alpha1.DNSEndpointStatus
+		changes     []bool
+		reason      string
+		message     string
+		wantApplied int32
+		wantPending int32
+	}{
+		{
+			name:        "partial with pending changes",
+			outcome:     SyncOutcomePartial,
+			changes:     []bool{true, false, false},
+			reason:      apiv1alpha1.PartiallyAppliedReason,
+			message:     "2 of 3 changes failed to be applied to the provider",
+			wantApplied: 1,
+			wantPending: 2,
+		},
+		{
+			name:        "partial with all changes applied",
+			outcome:     SyncOutcomePartial,
+			changes:     []bool{true, true},
+			reason:      apiv1alpha1.ChangesAppliedReason,
+			wantApplied: 2,
+		},
+		{
+			name:    "partial without changes",
+			outcome: SyncOutcomePartial,
+			reason:  apiv1alpha1.NoChangesReason,
+		},
+		{
+			name:        "noop clears the pending changes",
+			outcome:     SyncOutcomeNoop,
+			status:      apiv1alpha1.DNSEndpointStatus{AppliedChanges: 3, PendingChanges: 2},
+			reason:      apiv1alpha1.NoChangesReason,
+			wantApplied: 3,
+		},
+		{
+			name:        "failed keeps the counts",
+			outcome:     SyncOutcomeFailed,
+			status:      apiv1alpha1.DNSEndpointStatus{AppliedChanges: 3, PendingChanges: 2},
+			reason:      apiv1alpha1.SyncFailedReason,
+			wantApplied: 3,
+			wantPending: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &apiv1alpha1.DNSEndpoint{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 1},
+				Spec: apiv1alpha1.DNSEndpointSpec{
+					Endpoints: []*endpoint.Endpoint{
+						{DNSName: "example.org", Targets: endpoint.Targets{"1.2.3.4"}, RecordType: endpoint.RecordTypeA},
+					},
+				},
+				Status: tt.status,
+			}
+
+			fakeCache := newFakeCRDCache(t, nil, fakeCRDCacheFilter{}, obj)
+			cs, err := newCrdSource(t.Context(), fakeCache, fakeCache.Client, "", nil)
+			require.NoError(t, err)
+
+			notifier := &SyncOutcomeNotifier{}
+			ctx := ContextWithSyncOutcomeNotifier(t.Context(), notifier)
+			_, err = cs.Endpoints(ctx)
+			require.NoError(t, err)
+			for _, applied := range tt.changes {
+				ep := endpoint.NewEndpoint("example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "crd/default/test")
+				CountChanges(ctx, applied, []*endpoint.Endpoint{ep})
+			}
+			notifier.Notify(t.Context(), tt.outcome)
+
+			updated := &apiv1alpha1.DNSEndpoint{}
+			require.NoError(t, fakeCache.Get(t.Context(), client.ObjectKeyFromObject(obj), updated))
+			condition := meta.FindStatusCondition(updated.Status.Conditions, apiv1alpha1.SyncedCondition)
+			require.NotNil(t, condition)
+			require.Equal(t, tt.reason, condition.Reason)
+			if tt.message != "" {
+				require.Equal(t, tt.message, condition.Message)
+			}
+			require.Equal(t, tt.wantApplied, updated.Status.AppliedChanges)
+			require.Equal(t, tt.wantPending, updated.Status.PendingChanges)
+		})
+	}
+}
+
+func TestCRDSource_Endpoints_Defaults(t *testing.T) {
+	obj := &apiv1alpha1.DNSEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 1},
+		Status:     apiv1alpha1.DNSEndpointStatus{ObservedGeneration: 1},
+		Spec: apiv1alpha1.DNSEndpointSpec{
+			Endpoints: []*endpoint.Endpoint{
+				{DNSName: "v4.example.org", Targets: endpoint.Targets{"1.2.3.4", "1.2.3.5"}},
+				{DNSName: "v6.example.org", Targets: endpoint.Targets{"2001:db8::1"}, RecordTTL: 60},
+				{DNSName: "alias.example.org", Targets: endpoint.Targets{"target.example.org"}},
+				{DNSName: "mixed.example.org", Targets: endpoint.Targets{"1.2.3.4", "2001:db8::1"}, RecordTTL: 60},
+				{DNSName: "set.example.org", Targets: endpoint.Targets{"1.2.3.4"}, RecordType: endpoint.RecordTypeA, RecordTTL: 60},
+			},
+		},
+	}
+
+	fakeCache := newFakeCRDCache(t, nil, fakeCRDCacheFilter{}, obj)
+	cs, err := newCrdSource(t.Context(), fakeCache, fakeCache.Client, "", nil)
+	require.NoError(t, err)
+	cs.defaultTTL = 300
+
+	endpoints, err := cs.Endpoints(t.Context())
+	require.NoError(t, err)
+	got := map[string]*endpoint.Endpoint{}
+	for _, ep := range endpoints {
+		got[ep.DNSName] = ep
+	}
+	for name, expected := range map[string]struct {
+		recordType string
+		ttl        endpoint.TTL
+	}{
+		"v4.example.org":    {recordType: endpoint.RecordTypeA, ttl: 300},
+		"v6.example.org":    {recordType: endpoint.RecordTypeAAAA, ttl: 60},
+		"alias.example.org": {recordType: endpoint.RecordTypeCNAME, ttl: 300},
+		"mixed.example.org": {recordType: "", ttl: 60},
+		"set.example.org":   {recordType: endpoint.RecordTypeA, ttl: 60},
+	} {
+		require.Contains(t, got, name)
+		require.Equal(t, expected.recordType, got[name].RecordType, name)
+		require.Equal(t, expected.ttl, got[name].RecordTTL, name)
+	}
+
+	updated := &apiv1alpha1.DNSEndpoint{}
+	require.NoError(t, fakeCache.Get(t.Context(), client.ObjectKeyFromObject(obj), updated))
+	condition := meta.FindStatusCondition(updated.Status.Conditions, apiv1alpha1.DefaultedCondition)
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionTrue, condition.Status)
+	require.Equal(t, apiv1alpha1.ValuesDefaultedReason, condition.Reason)
+	require.Equal(t,
+		"v4.example.org: recordType=A, recordTTL=300; v6.example.org: recordType=AAAA; alias.example.org: recordType=CNAME, recordTTL=300",
+		condition.Message)
+
+	// the condition is removed once the spec sets the values
+	for _, ep := range updated.Spec.Endpoints {
+		ep.RecordType = endpoint.SuitableType(ep.Targets[0])
+		ep.RecordTTL = 60
+	}
+	require.NoError(t, fakeCache.Update(t.Context(), updated))
+	_, err = cs.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, fakeCache.Get(t.Context(), client.ObjectKeyFromObject(obj), updated))
+	require.Nil(t, meta.FindStatusCondition(updated.Status.Conditions, apiv1alpha1.DefaultedCondition))
+}
+
+func TestCRDSource_Endpoints_FinalizeDeletion(t *testing.T) {
+	obj := &apiv1alpha1.DNSEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test",
+			Namespace:  "default",
+			Finalizers: []string{apiv1alpha1.DNSEndpointFinalizer},
+		},
+		Spec: apiv1alpha1.DNSEndpointSpec{
+			Endpoints: []*endpoint.Endpoint{
+				{DNSName: "example.org", Targets: endpoint.Targets{"1.2.3.4"}, RecordType: endpoint.RecordTypeA},
+			},
+		},
+	}
+
+	fakeCache := newFakeCRDCache(t, nil, fakeCRDCacheFilter{}, obj)
+	cs, err := newCrdSource(t.Context(), fakeCache, fakeCache.Client, "", nil)
+	require.NoError(t, err)
+	cs.finalizer = true
+
+	require.NoError(t, fakeCache.Delete(t.Context(), obj))
+
+	key := client.ObjectKeyFromObject(obj)
+	owned := endpoint.NewEndpoint("example.org", endpoint.RecordTypeA, "1.2.3.4").
+		WithLabel(endpoint.OwnerLabelKey, "default").
+		WithLabel(endpoint.ResourceLabelKey, "crd/default/test")
+	foreign := endpoint.NewEndpoint("example.org", endpoint.RecordTypeA, "1.2.3.4").
+		WithLabel(endpoint.OwnerLabelKey, "default").
+		WithLabel(endpoint.ResourceLabelKey, "crd/default/other")
+	merged := endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4").
+		WithLabel(endpoint.OwnerLabelKey, "default").
+		WithLabel(endpoint.ResourceLabelKey, "crd/default/other;crd/default/test")
+
+	t.Run("no registry records in context", func(t *testing.T) {
+		endpoints, err := cs.Endpoints(t.Context())
+		require.NoError(t, err)
+		require.Empty(t, endpoints)
+
+		current := &apiv1alpha1.DNSEndpoint{}
+		require.NoError(t, fakeCache.Get(t.Context(), key, current))
+		require.Contains(t, current.Finalizers, apiv1alpha1.DNSEndpointFinalizer)
+		cond := meta.FindStatusCondition(current.Status.Conditions, apiv1alpha1.DeletingCondition)
+		require.NotNil(t, cond)
+		require.Equal(t, apiv1alpha1.VerificationFailedReason, cond.Reason)
+	})
+
+	t.Run("records still present", func(t *testing.T) {
+		ctx := context.WithValue(t.Context(), provider.RecordsContextKey, []*endpoint.Endpoint{owned, foreign, merged})
+		endpoints, err := cs.Endpoints(ctx)
+		require.NoError(t, err)
+		require.Empty(t, endpoints, "endpoints of a deleted resource must not be desired")
+
+		current := &apiv1alpha1.DNSEndpoint{}
+		require.NoError(t, fakeCache.Get(t.Context(), key, current))
+		require.Contains(t, current.Finalizers, apiv1alpha1.DNSEndpointFinalizer)
+		cond := meta.FindStatusCondition(current.Status.Conditions, apiv1alpha1.DeletingCondition)
+		require.NotNil(t, cond)
+		require.Equal(t, apiv1alpha1.RecordsPendingReason, cond.Reason)
+		require.Equal(t, "waiting for 2 records to be deleted from provider", cond.Message)
+	})
+
+	t.Run("records deleted", func(t *testing.T) {
+		ctx := context.WithValue(t.Context(), provider.RecordsContextKey, []*endpoint.Endpoint{foreign})
+		_, err := cs.Endpoints(ctx)
+		require.NoError(t, err)
+
+		err = fakeCache.Get(t.Context(), key, &apiv1alpha1.DNSEndpoint{})
+		require.True(t, apierrors.IsNotFound(err), "resource must be released once its records are gone")
+	})
+}
+
+func TestPendingRecords(t *testing.T) {
+	dnsEndpoint := &apiv1alpha1.DNSEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: apiv1alpha1.DNSEndpointSpec{
+			Endpoints: []*endpoint.Endpoint{
+				nil,
+				{DNSName: "a.example.org", RecordType: endpoint.RecordTypeA},
+				{DNSName: "b.example.org", RecordType: endpoint.RecordTypeCNAME},
+			},
+		},
+	}
+
+	current := []*endpoint.Endpoint{
+		// unowned records are matched by key
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		// owned records are matched by resource label
+		endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeCNAME, "example.com").
+			WithLabel(endpoint.OwnerLabelKey, "owner").
+			WithLabel(endpoint.ResourceLabelKey, "crd/default/other"),
+		endpoint.NewEndpoint("d.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.OwnerLabelKey, "owner").
+			WithLabel(endpoint.ResourceLabelKey, "crd/default/test"),
+	}
+
+	require.Equal(t, 2, pendingRecords(dnsEndpoint, current))
+	require.Zero(t, pendingRecords(dnsEndpoint, nil))
+}
+
 func TestCRDSource_AddEventHandler(t *testing.T) {
 	tests := []struct {
 		name      string
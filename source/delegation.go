@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source/types"
+)
+
+// DelegationConfig is the content of the file passed with --delegation-config.
+type DelegationConfig struct {
+	// Resolver is the address, host or host:port, of the DNS server the nameservers
+	// are looked up from. The resolver of the system is used when empty.
+	Resolver    string       `yaml:"resolver"`
+	Delegations []Delegation `yaml:"delegations"`
+}
+
+// Delegation describes a child zone to delegate from the parent zone managed by this instance.
+type Delegation struct {
+	// Zone is the name of the child zone, e.g. "dev.example.com".
+	Zone string `yaml:"zone"`
+	// Nameservers of the child zone. When empty, they are looked up from DNS,
+	// which returns the nameservers assigned by the provider hosting the child zone.
+	Nameservers []string `yaml:"nameservers"`
+	// DS records of the child zone in presentation format, e.g. "12345 13 2 <digest>".
+	DS []string `yaml:"ds"`
+	// TTL of the delegation records, the provider default is used when zero.
+	TTL int64 `yaml:"ttl"`
+}
+
+// nsResolver looks up the nameservers of a zone.
+type nsResolver interface {
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// delegationSource is an implementation of Source that provides the NS and,
+// optionally, DS records delegating child zones hosted elsewhere, e.g. by
+// another provider, from the parent zone managed by this instance.
+//
+// +externaldns:source:name=delegation
+// +externaldns:source:category=Special
+// +externaldns:source:description=Creates NS and DS records delegating child zones
+// +externaldns:source:resources=Delegation config file
+// +externaldns:source:filters=
+// +externaldns:source:namespace=
+// +externaldns:source:fqdn-template=false
+// +externaldns:source:provider-specific=false
+type delegationSource struct {
+	delegations []Delegation
+	resolver    nsResolver
+	// resolved holds the nameservers last looked up per zone, used while lookups fail
+	resolved map[string][]string
+}
+
+// NewDelegationSource creates a new delegationSource from the given config file.
+func NewDelegationSource(configFile string) (Source, error) {
+	if configFile == "" {
+		return nil, errors.New("delegation source requires --delegation-config")
+	}
+	contents, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read delegation config %q: %w", configFile, err)
+	}
+	cfg := DelegationConfig{}
+	if err := yaml.Unmarshal(contents, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse delegation config %q: %w", configFile, err)
+	}
+	return newDelegationSource(cfg.Delegations, newNSResolver(cfg.Resolver))
+}
+
+// newNSResolver returns a resolver querying the DNS server at address, port 53
+// by default, or the resolver of the system when address is empty.
+func newNSResolver(address string) nsResolver {
+	if address == "" {
+		return net.DefaultResolver
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "53")
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, address)
+		},
+	}
+}
+
+func newDelegationSource(delegations []Delegation, resolver nsResolver) (*delegationSource, error) {
+	for i, d := range delegations {
+		if d.Zone == "" {
+			return nil, fmt.Errorf("delegation %d: zone is required", i)
+		}
+		if d.TTL < 0 {
+			return nil, fmt.Errorf("delegation %q: ttl must not be negative", d.Zone)
+		}
+	}
+	return &delegationSource{delegations: delegations, resolver: resolver, resolved: map[string][]string{}}, nil
+}
+
+// Endpoints returns the delegation records of every configured child zone.
+// A zone whose nameservers fail to be looked up keeps the nameservers of the
+// last successful lookup. When there was none, a soft error aborts the
+// synchronization so that the live delegation is not deleted.
+func (ds *delegationSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints := make([]*endpoint.Endpoint, 0, len(ds.delegations))
+	for _, d := range ds.delegations {
+		zone := strings.TrimSuffix(d.Zone, ".")
+		nameservers, err := ds.nameservers(ctx, zone, d.Nameservers)
+		if err != nil {
+			if last, ok := ds.resolved[zone]; ok {
+				log.Warnf("Delegating zone %s to its last known nameservers: %v", zone, err)
+				nameservers = last
+			} else {
+				return nil, provider.NewSoftError(err)
+			}
+		} else if len(d.Nameservers) == 0 {
+			ds.resolved[zone] = nameservers
+		}
+		resource := fmt.Sprintf("%s/%s", types.Delegation, zone)
+		ttl := endpoint.TTL(d.TTL)
+
+		ns := endpoint.NewEndpointWithTTL(zone, endpoint.RecordTypeNS, ttl, nameservers...)
+		ns.WithLabel(endpoint.ResourceLabelKey, resource)
+		endpoints = append(endpoints, ns)
+
+		if len(d.DS) > 0 {
+			dsEp := endpoint.NewEndpointWithTTL(zone, endpoint.RecordTypeDS, ttl, d.DS...)
+			dsEp.WithLabel(endpoint.ResourceLabelKey, resource)
+			endpoints = append(endpoints, dsEp)
+		}
+	}
+	return endpoints, nil
+}
+
+// nameservers returns the configured nameservers or looks them up.
+func (ds *delegationSource) nameservers(ctx context.Context, zone string, configured []string) ([]string, error) {
+	var nameservers []string
+	if len(configured) > 0 {
+		for _, ns := range configured {
+			nameservers = append(nameservers, strings.TrimSuffix(ns, "."))
+		}
+	} else {
+		records, err := ds.resolver.LookupNS(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up nameservers of delegated zone %q: %w", zone, err)
+		}
+		for _, r := range records {
+			nameservers = append(nameservers, strings.TrimSuffix(r.Host, "."))
+		}
+	}
+	if len(nameservers) == 0 {
+		return nil, fmt.Errorf("no nameservers found for delegated zone %q", zone)
+	}
+	slices.Sort(nameservers)
+	log.Debugf("Delegating zone %s to %s", zone, strings.Join(nameservers, ","))
+	return slices.Compact(nameservers), nil
+}
+
+func (ds *delegationSource) AddEventHandler(_ context.Context, _ func()) {}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+type fakeNSResolver map[string][]string
+
+func (r fakeNSResolver) LookupNS(_ context.Context, name string) ([]*net.NS, error) {
+	hosts, ok := r[name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	records := make([]*net.NS, 0, len(hosts))
+	for _, h := range hosts {
+		records = append(records, &net.NS{Host: h})
+	}
+	return records, nil
+}
+
+func TestDelegationSourceEndpoints(t *testing.T) {
+	resolver := fakeNSResolver{
+		"dev.example.com": {"ns-2.awsdns.org.", "ns-1.awsdns.com."},
+	}
+
+	for _, tt := range []struct {
+		title       string
+		delegations []Delegation
+		expected    []*endpoint.Endpoint
+	}{
+		{
+			title:       "explicit nameservers",
+			delegations: []Delegation{{Zone: "team.example.com.", Nameservers: []string{"ns2.other.net.", "ns1.other.net"}, TTL: 3600}},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("team.example.com", endpoint.RecordTypeNS, 3600, "ns1.other.net", "ns2.other.net").
+					WithLabel(endpoint.ResourceLabelKey, "delegation/team.example.com"),
+			},
+		},
+		{
+			title:       "looked up nameservers and DS",
+			delegations: []Delegation{{Zone: "dev.example.com", DS: []string{"12345 13 2 ABCDEF"}}},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("dev.example.com", endpoint.RecordTypeNS, "ns-1.awsdns.com", "ns-2.awsdns.org").
+					WithLabel(endpoint.ResourceLabelKey, "delegation/dev.example.com"),
+				endpoint.NewEndpoint("dev.example.com", endpoint.RecordTypeDS, "12345 13 2 ABCDEF").
+					WithLabel(endpoint.ResourceLabelKey, "delegation/dev.example.com"),
+			},
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			src, err := newDelegationSource(tt.delegations, resolver)
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+			assert.True(t, testutils.SameEndpoints(tt.expected, endpoints), "expected %v, got %v", tt.expected, endpoints)
+		})
+	}
+}
+
+func TestDelegationSourceKeepsLastKnownNameservers(t *testing.T) {
+	resolver := fakeNSResolver{"dev.example.com": {"ns1.other.net."}}
+	src, err := newDelegationSource([]Delegation{{Zone: "dev.example.com"}}, resolver)
+	require.NoError(t, err)
+
+	_, err = src.Endpoints(t.Context())
+	require.NoError(t, err)
+
+	delete(resolver, "dev.example.com")
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, endpoint.Targets{"ns1.other.net"}, endpoints[0].Targets)
+}
+
+func TestDelegationSourceFailsWithoutKnownNameservers(t *testing.T) {
+	resolver := fakeNSResolver{}
+	src, err := newDelegationSource([]Delegation{{Zone: "team.example.com", Nameservers: []string{"ns1.other.net"}}, {Zone: "dev.example.com"}}, resolver)
+	require.NoError(t, err)
+
+	_, err = src.Endpoints(t.Context())
+	require.ErrorIs(t, err, provider.SoftError)
+
+	resolver["dev.example.com"] = []string{"ns1.awsdns.com."}
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, endpoints, 2)
+}
+
+func TestNewNSResolver(t *testing.T) {
+	assert.Same(t, net.DefaultResolver, newNSResolver(""))
+
+	// a closed local port makes the lookup fail through the configured server, not the system resolver
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.LocalAddr().String()
+	require.NoError(t, listener.Close())
+
+	resolver, ok := newNSResolver(address).(*net.Resolver)
+	require.True(t, ok)
+	assert.True(t, resolver.PreferGo)
+	_, err = resolver.LookupNS(t.Context(), "example.com")
+	require.Error(t, err)
+}
+
+func TestNewDelegationSource(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte(`
+delegations:
+  - zone: dev.example.com
+    nameservers: [ns1.other.net]
+    ttl: 300
+`), 0o600))
+	missingZone := filepath.Join(dir, "missing-zone.yaml")
+	require.NoError(t, os.WriteFile(missingZone, []byte(`
+delegations:
+  - nameservers: [ns1.other.net]
+`), 0o600))
+
+	src, err := NewDelegationSource(valid)
+	require.NoError(t, err)
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, endpoint.TTL(300), endpoints[0].RecordTTL)
+
+	_, err = NewDelegationSource(missingZone)
+	require.ErrorContains(t, err, "zone is required")
+
+	_, err = NewDelegationSource("")
+	require.Error(t, err)
+
+	_, err = NewDelegationSource(filepath.Join(dir, "absent.yaml"))
+	require.Error(t, err)
+}
@@ -44,7 +44,7 @@ func BenchmarkEndpointTargetsFromServicesMedium(b *testing.B) {
 	sel := map[string]string{"app": "nginx", "env": "prod"}
 
 	for b.Loop() {
-		targets, _ := EndpointTargetsFromServices(svcInformer, "default", sel)
+		targets, _ := EndpointTargetsFromServices(svcInformer, "default", sel, nil)
 		assert.Equal(b, 36, targets.Len())
 	}
 }
@@ -57,7 +57,7 @@ func BenchmarkEndpointTargetsFromServicesMediumIterateOverGateways(b *testing.B)
 
 	for b.Loop() {
 		for _, gateway := range gateways {
-			_, _ = EndpointTargetsFromServices(svcInformer, gateway.Namespace, gateway.Spec.Selector)
+			_, _ = EndpointTargetsFromServices(svcInformer, gateway.Namespace, gateway.Spec.Selector, nil)
 		}
 	}
 }
@@ -68,7 +68,7 @@ func BenchmarkEndpointTargetsFromServicesHigh(b *testing.B) {
 	sel := map[string]string{"app": "nginx", "env": "prod"}
 
 	for b.Loop() {
-		targets, _ := EndpointTargetsFromServices(svcInformer, "default", sel)
+		targets, _ := EndpointTargetsFromServices(svcInformer, "default", sel, nil)
 		assert.Equal(b, 36, targets.Len())
 	}
 }
@@ -82,7 +82,7 @@ func BenchmarkEndpointTargetsFromServicesHighIterateOverGateways(b *testing.B) {
 
 	for b.Loop() {
 		for _, gateway := range gateways {
-			_, _ = EndpointTargetsFromServices(svcInformer, gateway.Namespace, gateway.Spec.Selector)
+			_, _ = EndpointTargetsFromServices(svcInformer, gateway.Namespace, gateway.Spec.Selector, nil)
 		}
 	}
 }
@@ -24,12 +24,17 @@ import (
 
 // EndpointTargetsFromServices retrieves endpoint targets from services in a given namespace
 // that match the specified selector. It returns external IPs or load balancer addresses.
+// Only the services whose labels match serviceSelector are considered, a nil
+// serviceSelector matches every service.
 //
 // TODO: add support for service.Spec.Ports (type NodePort) and service.Spec.ClusterIPs (type ClusterIP)
-func EndpointTargetsFromServices(svcInformer coreinformers.ServiceInformer, namespace string, selector map[string]string) (endpoint.Targets, error) {
+func EndpointTargetsFromServices(svcInformer coreinformers.ServiceInformer, namespace string, selector map[string]string, serviceSelector labels.Selector) (endpoint.Targets, error) {
 	targets := endpoint.Targets{}
 
-	services, err := svcInformer.Lister().Services(namespace).List(labels.Everything())
+	if serviceSelector == nil {
+		serviceSelector = labels.Everything()
+	}
+	services, err := svcInformer.Lister().Services(namespace).List(serviceSelector)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to list labels for services in namespace %q: %w", namespace, err)
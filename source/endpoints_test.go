@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -310,7 +311,7 @@ func TestEndpointTargetsFromServices(t *testing.T) {
 				assert.NoError(t, err)
 			}
 
-			result, err := EndpointTargetsFromServices(serviceInformer, tt.namespace, tt.selector)
+			result, err := EndpointTargetsFromServices(serviceInformer, tt.namespace, tt.selector, nil)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -321,13 +322,42 @@ func TestEndpointTargetsFromServices(t *testing.T) {
 	}
 }
 
+func TestEndpointTargetsFromServicesWithServiceSelector(t *testing.T) {
+	client := fake.NewClientset()
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(client, 0,
+		kubeinformers.WithNamespace(corev1.NamespaceDefault))
+	serviceInformer := informerFactory.Core().V1().Services()
+
+	for _, svc := range []*corev1.Service{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: corev1.NamespaceDefault, Labels: map[string]string{"exposure": "public"}},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "gateway"}, ExternalIPs: []string{"192.0.2.1"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: corev1.NamespaceDefault, Labels: map[string]string{"exposure": "internal"}},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "gateway"}, ExternalIPs: []string{"10.0.0.1"}},
+		},
+	} {
+		assert.NoError(t, serviceInformer.Informer().GetIndexer().Add(svc))
+	}
+	selector := map[string]string{"app": "gateway"}
+
+	targets, err := EndpointTargetsFromServices(serviceInformer, corev1.NamespaceDefault, selector, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{"10.0.0.1", "192.0.2.1"}, targets)
+
+	targets, err = EndpointTargetsFromServices(serviceInformer, corev1.NamespaceDefault, selector, labels.SelectorFromSet(labels.Set{"exposure": "public"}))
+	assert.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{"192.0.2.1"}, targets)
+}
+
 func TestEndpointTargetsFromServicesWithFixtures(t *testing.T) {
 	svcInformer, err := svcInformerWithServices(2, 9)
 	assert.NoError(t, err)
 
 	sel := map[string]string{"app": "nginx", "env": "prod"}
 
-	targets, err := EndpointTargetsFromServices(svcInformer, corev1.NamespaceDefault, sel)
+	targets, err := EndpointTargetsFromServices(svcInformer, corev1.NamespaceDefault, sel, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 2, targets.Len())
 }
@@ -76,7 +76,7 @@ func NewF5TransportServerSource(
 	kubeClient kubernetes.Interface,
 	cfg *Config,
 ) (Source, error) {
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, cfg.Namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, cfg.Namespace, informers.ListPageSize(cfg.KubeAPIListPageSize))
 	transportServerInformer := informerFactory.ForResource(f5TransportServerGVR)
 
 	informers.MustSetTransform(transportServerInformer.Informer(), informers.TransformerWithOptions[*unstructured.Unstructured](
@@ -85,6 +85,7 @@ func NewF5TransportServerSource(
 	))
 
 	informers.MustAddEventHandler(transportServerInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(transportServerInformer.Informer(), "f5-transportserver/transportserver")
 
 	informerFactory.Start(ctx.Done())
 
@@ -111,7 +112,7 @@ func NewF5TransportServerSource(
 
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all TransportServers in the source's namespace(s).
-func (ts *f5TransportServerSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (ts *f5TransportServerSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	transportServerObjects, err := ts.transportServerInformer.Lister().ByNamespace(ts.namespace).List(labels.Everything())
 	if err != nil {
 		return nil, err
@@ -138,7 +139,7 @@ func (ts *f5TransportServerSource) Endpoints(_ context.Context) ([]*endpoint.End
 
 	transportServers = annotations.Filter(transportServers, ts.annotationFilter)
 
-	endpoints, err := ts.endpointsFromTransportServers(transportServers)
+	endpoints, err := ts.endpointsFromTransportServers(ctx, transportServers)
 	if err != nil {
 		return nil, err
 	}
@@ -153,10 +154,13 @@ func (ts *f5TransportServerSource) AddEventHandler(_ context.Context, handler fu
 }
 
 // endpointsFromTransportServers extracts the endpoints from a slice of TransportServers.
-func (ts *f5TransportServerSource) endpointsFromTransportServers(transportServers []*f5.TransportServer) ([]*endpoint.Endpoint, error) {
+func (ts *f5TransportServerSource) endpointsFromTransportServers(ctx context.Context, transportServers []*f5.TransportServer) ([]*endpoint.Endpoint, error) {
 	var endpoints []*endpoint.Endpoint
 
 	for _, transportServer := range transportServers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		var tsEndpoints []*endpoint.Endpoint
 
 		if hasValidTransportServerIP(transportServer) {
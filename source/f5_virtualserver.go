@@ -75,7 +75,7 @@ func NewF5VirtualServerSource(
 	kubeClient kubernetes.Interface,
 	cfg *Config,
 ) (Source, error) {
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, cfg.Namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, cfg.Namespace, informers.ListPageSize(cfg.KubeAPIListPageSize))
 	virtualServerInformer := informerFactory.ForResource(f5VirtualServerGVR)
 
 	informers.MustSetTransform(virtualServerInformer.Informer(), informers.TransformerWithOptions[*unstructured.Unstructured](
@@ -84,6 +84,7 @@ func NewF5VirtualServerSource(
 	))
 
 	informers.MustAddEventHandler(virtualServerInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(virtualServerInformer.Informer(), "f5-virtualserver/virtualserver")
 
 	informerFactory.Start(ctx.Done())
 
@@ -110,7 +111,7 @@ func NewF5VirtualServerSource(
 
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all VirtualServers in the source's namespace(s).
-func (vs *f5VirtualServerSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (vs *f5VirtualServerSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	virtualServerObjects, err := vs.virtualServerInformer.Lister().ByNamespace(vs.namespace).List(labels.Everything())
 	if err != nil {
 		return nil, err
@@ -137,7 +138,7 @@ func (vs *f5VirtualServerSource) Endpoints(_ context.Context) ([]*endpoint.Endpo
 
 	virtualServers = annotations.Filter(virtualServers, vs.annotationFilter)
 
-	endpoints, err := vs.endpointsFromVirtualServers(virtualServers)
+	endpoints, err := vs.endpointsFromVirtualServers(ctx, virtualServers)
 	if err != nil {
 		return nil, err
 	}
@@ -152,10 +153,13 @@ func (vs *f5VirtualServerSource) AddEventHandler(_ context.Context, handler func
 }
 
 // endpointsFromVirtualServers extracts the endpoints from a slice of VirtualServers.
-func (vs *f5VirtualServerSource) endpointsFromVirtualServers(virtualServers []*f5.VirtualServer) ([]*endpoint.Endpoint, error) {
+func (vs *f5VirtualServerSource) endpointsFromVirtualServers(ctx context.Context, virtualServers []*f5.VirtualServer) ([]*endpoint.Endpoint, error) {
 	var endpoints []*endpoint.Endpoint
 
 	for _, virtualServer := range virtualServers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		var vsEndpoints []*endpoint.Endpoint
 
 		if hasValidVirtualServerIP(virtualServer) {
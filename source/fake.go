@@ -135,6 +135,12 @@ func (sc *fakeSource) generateEndpointForType(recordType, dnsName string) (*endp
 	case endpoint.RecordTypeNAPTR:
 		// NAPTR target format: "order preference flags service regexp replacement"
 		ep = endpoint.NewEndpoint(fmt.Sprintf("_sip._udp.%s", dnsName), endpoint.RecordTypeNAPTR, fmt.Sprintf(`100 10 "u" "E2U+sip" "!^.*$!sip:info@%s!" .`, dnsName))
+	case endpoint.RecordTypeCAA:
+		// CAA target format: "flags tag value"
+		ep = endpoint.NewEndpoint(dnsName, endpoint.RecordTypeCAA, `0 issue "letsencrypt.org"`)
+	case endpoint.RecordTypeDS:
+		// DS target format: "key-tag algorithm digest-type digest"
+		ep = endpoint.NewEndpoint(sc.generateDNSName(4, dnsName), endpoint.RecordTypeDS, "12345 13 2 3b0a1a3e7e15b2c1ad8d77c3a1b5d6e9f6f7b2a4c9d8e1f0a3b4c5d6e7f8a9b0")
 	default:
 		return nil, fmt.Errorf("unsupported record type: %s", recordType)
 	}
@@ -73,17 +73,22 @@ type gatewayRouteInformer interface {
 	Informer() cache.SharedIndexInformer
 }
 
-func newGatewayInformerFactory(client gateway.Interface, namespace string, labelSelector labels.Selector) gwinformers.SharedInformerFactory {
+func newGatewayInformerFactory(client gateway.Interface, namespace string, labelSelector labels.Selector, listPageSize int) gwinformers.SharedInformerFactory {
 	var opts []gwinformers.SharedInformerOption
 	if namespace != "" {
 		opts = append(opts, gwinformers.WithNamespace(namespace))
 	}
+	var lbls string
 	if labelSelector != nil && !labelSelector.Empty() {
-		lbls := labelSelector.String()
-		opts = append(opts, gwinformers.WithTweakListOptions(func(o *metav1.ListOptions) {
-			o.LabelSelector = lbls
-		}))
+		lbls = labelSelector.String()
 	}
+	pageSize := informers.ListPageSize(listPageSize)
+	opts = append(opts, gwinformers.WithTweakListOptions(func(o *metav1.ListOptions) {
+		if lbls != "" {
+			o.LabelSelector = lbls
+		}
+		pageSize(o)
+	}))
 	return gwinformers.NewSharedInformerFactoryWithOptions(client, 0, opts...)
 }
 
@@ -171,12 +176,12 @@ func newGatewayRouteSource(
 		rtAnnotations = labels.Everything()
 	}
 
-	client, err := clients.GatewayClient()
+	client, err := GatewayClient(clients)
 	if err != nil {
 		return nil, err
 	}
 
-	gwInformerFactory := newGatewayInformerFactory(client, config.GatewayNamespace, gwLabels)
+	gwInformerFactory := newGatewayInformerFactory(client, config.GatewayNamespace, gwLabels, config.KubeAPIListPageSize)
 	gwInformer := gwInformerFactory.Gateway().V1().Gateways() // TODO: Gateway informer should be shared across gateway sources.
 
 	informers.MustSetTransform(gwInformer.Informer(), informers.TransformerWithOptions[*v1.Gateway](
@@ -190,7 +195,7 @@ func newGatewayRouteSource(
 	if config.GatewayListenerSets {
 		// Gateway filters should apply only to Gateways, not ListenerSets.
 		if config.GatewayNamespace != "" || (gwLabels != nil && !gwLabels.Empty()) {
-			lsInformerFactory = newGatewayInformerFactory(client, "", nil)
+			lsInformerFactory = newGatewayInformerFactory(client, "", nil, config.KubeAPIListPageSize)
 		}
 		lsInformer = lsInformerFactory.Gateway().V1().ListenerSets() // TODO: ListenerSet informer should be shared across gateway sources.
 		informers.MustSetTransform(lsInformer.Informer(), informers.TransformerWithOptions[*v1.ListenerSet](
@@ -201,7 +206,7 @@ func newGatewayRouteSource(
 
 	rtInformerFactory := gwInformerFactory
 	if config.Namespace != config.GatewayNamespace || !selectorsEqual(rtLabels, gwLabels) {
-		rtInformerFactory = newGatewayInformerFactory(client, config.Namespace, rtLabels)
+		rtInformerFactory = newGatewayInformerFactory(client, config.Namespace, rtLabels, config.KubeAPIListPageSize)
 	}
 	rtInformer := newInformerFn(rtInformerFactory)
 	informers.MustSetTransform(rtInformer.Informer(), informers.TransformerWithOptions[informers.Object](
@@ -210,12 +215,12 @@ func newGatewayRouteSource(
 		informers.TransformRemoveStatusConditions(),
 	))
 
-	kubeClient, err := clients.KubeClient()
+	kubeClient, err := KubeClient(clients)
 	if err != nil {
 		return nil, err
 	}
 
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithTweakListOptions(informers.ListPageSize(config.KubeAPIListPageSize)))
 	nsInformer := kubeInformerFactory.Core().V1().Namespaces() // TODO: Namespace informer should be shared across gateway sources.
 	informers.MustSetTransform(nsInformer.Informer(), informers.TransformerWithOptions[*corev1.Namespace](
 		informers.TransformRemoveManagedFields(),
@@ -223,6 +228,14 @@ func newGatewayRouteSource(
 		informers.TransformRemoveStatusConditions(),
 	))
 
+	watchName := "gateway-" + strings.ToLower(kind)
+	informers.TrackWatch(gwInformer.Informer(), watchName+"/gateway")
+	if lsInformer != nil {
+		informers.TrackWatch(lsInformer.Informer(), watchName+"/listenerset")
+	}
+	informers.TrackWatch(rtInformer.Informer(), watchName+"/"+strings.ToLower(kind))
+	informers.TrackWatch(nsInformer.Informer(), watchName+"/namespace")
+
 	gwInformerFactory.Start(ctx.Done())
 	if lsInformerFactory != gwInformerFactory {
 		lsInformerFactory.Start(ctx.Done())
@@ -278,7 +291,7 @@ func (src *gatewayRouteSource) AddEventHandler(_ context.Context, handler func()
 	informers.MustAddEventHandler(src.nsInformer.Informer(), eventHandler)
 }
 
-func (src *gatewayRouteSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	var endpoints []*endpoint.Endpoint
 	routes, err := src.rtInformer.List(src.rtNamespace, src.rtLabels)
 	if err != nil {
@@ -302,6 +315,9 @@ func (src *gatewayRouteSource) Endpoints(_ context.Context) ([]*endpoint.Endpoin
 	kind := strings.ToLower(src.rtKind)
 	resolver := newGatewayRouteResolver(src, gateways, listenerSets, namespaces)
 	for _, rt := range routes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		// Filter by annotations.
 		meta := rt.Metadata()
 		annots := meta.Annotations
@@ -1681,8 +1681,8 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 			}
 
 			clients := new(testutils.MockClientGenerator)
-			clients.On("GatewayClient").Return(gwClient, nil)
-			clients.On("KubeClient").Return(kubeClient, nil)
+			clients.On("Client", GatewayClientName).Return(gwClient, nil)
+			clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 			src, err := NewGatewayHTTPRouteSource(ctx, clients, tt.config)
 			require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
@@ -1714,8 +1714,8 @@ func TestGatewayHTTPRouteSource_RouteInformerTransform(t *testing.T) {
 	require.NoError(t, err)
 
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	source, err := NewGatewayHTTPRouteSource(t.Context(), clients, &Config{})
 	require.NoError(t, err)
@@ -1751,8 +1751,8 @@ func TestGatewayHTTPRouteSource_InformerTransform(t *testing.T) {
 	require.NoError(t, err)
 
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	source, err := NewGatewayHTTPRouteSource(t.Context(), clients, &Config{})
 	require.NoError(t, err)
@@ -99,8 +99,8 @@ func TestGatewayHTTPRouteWithListenerSetParentRef(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -179,8 +179,8 @@ func TestGatewayHTTPRouteWithListenerSetWildcardHostnameIntersection(t *testing.
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -259,8 +259,8 @@ func TestGatewayHTTPRouteWithListenerSetDisabled(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -319,8 +319,8 @@ func TestGatewayHTTPRouteWithListenerSetGatewayLabelFilter(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -423,8 +423,8 @@ func TestGatewayHTTPRouteWithListenerSetRouteLabelFilter(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -506,8 +506,8 @@ func TestGatewayHTTPRouteWithListenerSetNotAccepted(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -576,8 +576,8 @@ func TestGatewayHTTPRouteWithListenerSetNotAllowedByGateway(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -635,8 +635,8 @@ func TestGatewayHTTPRouteWithListenerSetStatusNotAccepted(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -693,8 +693,8 @@ func TestGatewayHTTPRouteWithListenerSetListenerStatusRequired(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -755,8 +755,8 @@ func TestGatewayHTTPRouteWithListenerSetListenerStatusNotAccepted(t *testing.T)
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -821,8 +821,8 @@ func TestGatewayHTTPRouteWithListenerSetTargetAnnotation(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -886,8 +886,8 @@ func TestGatewayHTTPRouteWithListenerSetAllowedRoutesSame(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	for _, name := range []string{"default", "other"} {
 		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
@@ -948,8 +948,8 @@ func TestGatewayHTTPRouteWithListenerSetSectionName(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -1019,8 +1019,8 @@ func TestGatewayHTTPRouteWithListenerSetCrossNamespaceRoute(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	for _, name := range []string{"infra", "apps"} {
 		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
@@ -1087,8 +1087,8 @@ func TestGatewayHTTPRouteWithCrossNamespaceListenerSetSelectedByGateway(t *testi
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	for _, ns := range []*corev1.Namespace{
 		{ObjectMeta: metav1.ObjectMeta{Name: "infra"}},
@@ -1160,8 +1160,8 @@ func TestGatewayHTTPRouteWithCrossNamespaceListenerSetAllowedWhenGatewayNamespac
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	for _, ns := range []*corev1.Namespace{
 		{ObjectMeta: metav1.ObjectMeta{Name: "infra"}},
@@ -1234,8 +1234,8 @@ func TestGatewayHTTPRouteWithCrossNamespaceListenerSetGatewayLabelFilter(t *test
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	for _, ns := range []*corev1.Namespace{
 		{ObjectMeta: metav1.ObjectMeta{Name: "infra"}},
@@ -1344,8 +1344,8 @@ func TestGatewayHTTPRouteWithListenerSetOwnTargetAnnotation(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -1410,8 +1410,8 @@ func TestGatewayHTTPRouteWithListenerSetTargetAnnotationPrecedence(t *testing.T)
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -1480,8 +1480,8 @@ func TestGatewayHTTPRouteWithListenerSetGatewayNotFound(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
 	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -1541,8 +1541,8 @@ func TestGatewayListenerSetSource_InformerTransform(t *testing.T) {
 	require.NoError(t, err)
 
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	source, err := NewGatewayHTTPRouteSource(t.Context(), clients, &Config{GatewayListenerSets: true})
 	require.NoError(t, err)
@@ -44,8 +44,8 @@ func TestGatewayTLSRouteSourceEndpoints(t *testing.T) {
 	gwClient := gatewayfake.NewSimpleClientset()
 	kubeClient := kubefake.NewClientset()
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -122,8 +122,8 @@ func TestGatewayTLSRouteSource_InformerTransform(t *testing.T) {
 	require.NoError(t, err)
 
 	clients := new(testutils.MockClientGenerator)
-	clients.On("GatewayClient").Return(gwClient, nil)
-	clients.On("KubeClient").Return(kubeClient, nil)
+	clients.On("Client", GatewayClientName).Return(gwClient, nil)
+	clients.On("Client", KubeClientName).Return(kubeClient, nil)
 
 	source, err := NewGatewayTLSRouteSource(t.Context(), clients, &Config{})
 	require.NoError(t, err)
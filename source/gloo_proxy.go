@@ -160,7 +160,7 @@ func NewGlooSource(
 	dynamicKubeClient dynamic.Interface,
 	kubeClient kubernetes.Interface,
 	cfg *Config) (Source, error) {
-	informerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithTweakListOptions(informers.ListPageSize(cfg.KubeAPIListPageSize)))
 	serviceInformer := informerFactory.Core().V1().Services()
 	ingressInformer := informerFactory.Networking().V1().Ingresses()
 
@@ -175,9 +175,11 @@ func NewGlooSource(
 	))
 
 	informers.MustAddEventHandler(serviceInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(serviceInformer.Informer(), "gloo-proxy/service")
 	informers.MustAddEventHandler(ingressInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(ingressInformer.Informer(), "gloo-proxy/ingress")
 
-	dynamicInformerFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicKubeClient, 0)
+	dynamicInformerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, metav1.NamespaceAll, informers.ListPageSize(cfg.KubeAPIListPageSize))
 
 	proxyInformer := dynamicInformerFactory.ForResource(proxyGVR)
 	virtualServiceInformer := dynamicInformerFactory.ForResource(virtualServiceGVR)
@@ -191,6 +193,9 @@ func NewGlooSource(
 		informers.MustSetTransform(inf.Informer(), unstructuredTransformer)
 		informers.MustAddEventHandler(inf.Informer(), informers.DefaultEventHandler())
 	}
+	informers.TrackWatch(proxyInformer.Informer(), "gloo-proxy/proxy")
+	informers.TrackWatch(virtualServiceInformer.Informer(), "gloo-proxy/virtualservice")
+	informers.TrackWatch(gatewayInformer.Informer(), "gloo-proxy/gateway")
 	allowedNS := sets.New(cfg.GlooNamespaces...)
 	informers.MustAddIndexers(proxyInformer.Informer(), informers.IndexerWithOptions[*unstructured.Unstructured](
 		informers.IndexSelectorWithAnnotationFilter(cfg.AnnotationFilter),
@@ -225,10 +230,13 @@ func (gs *glooSource) AddEventHandler(_ context.Context, handler func()) {
 }
 
 // Endpoints returns endpoint objects
-func (gs *glooSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (gs *glooSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	endpoints := []*endpoint.Endpoint{}
 
 	for _, unstructuredObj := range informers.ListIndexed[*unstructured.Unstructured](gs.proxyInformer.Informer().GetIndexer()) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		jsonData, err := json.Marshal(unstructuredObj.Object)
 		if err != nil {
 			return nil, err
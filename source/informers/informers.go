@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -49,6 +50,16 @@ func WaitForDynamicCacheSync(ctx context.Context, factory dynamicInformerFactory
 	return waitForCacheSync(ctx, factory.WaitForCacheSync)
 }
 
+// ListPageSize returns a list options tweak that requests at most size objects per page
+// when an informer lists its resources. Zero or less keeps the client-go default page size.
+func ListPageSize(size int) func(*metav1.ListOptions) {
+	return func(o *metav1.ListOptions) {
+		if size > 0 && !o.Watch {
+			o.Limit = int64(size)
+		}
+	}
+}
+
 // waitForCacheSync waits for informer caches to sync with a default timeout.
 // Returns an error if any cache fails to sync, wrapping the context error if a timeout occurred.
 func waitForCacheSync[K comparable](ctx context.Context, waitFunc func(<-chan struct{}) map[K]bool) error {
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -123,3 +124,17 @@ func TestWaitForDynamicCacheSync(t *testing.T) {
 		})
 	}
 }
+
+func TestListPageSize(t *testing.T) {
+	opts := metav1.ListOptions{Limit: 500}
+	ListPageSize(100)(&opts)
+	assert.Equal(t, int64(100), opts.Limit)
+
+	opts = metav1.ListOptions{Limit: 500}
+	ListPageSize(0)(&opts)
+	assert.Equal(t, int64(500), opts.Limit, "zero keeps the client default")
+
+	opts = metav1.ListOptions{Watch: true}
+	ListPageSize(100)(&opts)
+	assert.Zero(t, opts.Limit, "watch requests are not paginated")
+}
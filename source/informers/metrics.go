@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+var deprecatedAnnotations = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Subsystem: "source",
+		Name:      "deprecated_annotations_total",
+		Help:      "Number of annotations with a deprecated annotation prefix taking effect, partitioned by resource kind and prefix.",
+	},
+	[]string{"kind", "prefix"},
+)
+
+var watchActivity = metrics.NewGaugedVectorOpts(
+	prometheus.GaugeOpts{
+		Subsystem: "source",
+		Name:      "informer_last_activity_timestamp_seconds",
+		Help:      "Timestamp of the last activity seen on the watch of an informer, partitioned by informer and activity type (event, resync or watch).",
+	},
+	[]string{"informer", "type"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(deprecatedAnnotations)
+	metrics.RegisterMetric.MustRegister(watchActivity)
+}
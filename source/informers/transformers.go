@@ -20,6 +20,7 @@ import (
 	"slices"
 	"strings"
 
+	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -28,6 +29,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 // Object is a composite interface that combines runtime.Object and metav1.Object.
@@ -101,6 +104,9 @@ func TransformRequireAnnotation(selector labels.Selector) func(*TransformOptions
 // informers strip TypeMeta when returning objects because the client already knows the
 // type — populating it here makes cached objects self-describing for templates and logging.
 //
+// It also copies the annotations with a deprecated annotation prefix to the current one,
+// see MigrateDeprecatedAnnotations.
+//
 // The transform is naturally idempotent: nil-ing an already-nil field and filtering an
 // already-filtered map are both no-ops, so calling it multiple times on the same object
 // is safe.
@@ -127,6 +133,7 @@ func TransformerWithOptions[T Object](optFns ...func(*TransformOptions)) cache.T
 			}
 		}
 		populateGVK(entity)
+		MigrateDeprecatedAnnotations(entity.GetObjectKind().GroupVersionKind().Kind, entity)
 		if options.removeManagedFields {
 			entity.SetManagedFields(nil)
 		}
@@ -151,6 +158,22 @@ func TransformerWithOptions[T Object](optFns ...func(*TransformOptions)) cache.T
 	}
 }
 
+// MigrateDeprecatedAnnotations copies the annotations of obj with a deprecated annotation
+// prefix to the current one, as annotations.MigrateDeprecatedPrefixes does, counting each
+// copied annotation in the deprecated annotations metric.
+func MigrateDeprecatedAnnotations(kind string, obj metav1.Object) {
+	anns := obj.GetAnnotations()
+	prefixes := annotations.MigrateDeprecatedPrefixes(anns)
+	if len(prefixes) == 0 {
+		return
+	}
+	obj.SetAnnotations(anns)
+	for _, prefix := range prefixes {
+		deprecatedAnnotations.CounterVec.WithLabelValues(kind, prefix).Inc()
+	}
+	log.Debugf("%s %s/%s uses the deprecated annotation prefixes %v", kind, obj.GetNamespace(), obj.GetName(), slices.Compact(prefixes))
+}
+
 // MustSetTransform calls SetTransform on the informer and panics on error.
 // SetTransform only errors if the informer has already been started, which is a
 // programming error — callers must invoke it before factory.Start().
@@ -16,6 +16,7 @@ package informers
 import (
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
@@ -27,6 +28,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 func TestTransformRemoveManagedFields(t *testing.T) {
@@ -216,6 +219,25 @@ func TestTransformKeepAnnotationPrefix(t *testing.T) {
 	})
 }
 
+func TestTransformerWithOptions_MigratesDeprecatedAnnotations(t *testing.T) {
+	t.Cleanup(func() { annotations.SetAnnotationPrefix(annotations.DefaultAnnotationPrefix) })
+	annotations.SetAnnotationPrefix(annotations.DefaultAnnotationPrefix, "custom.io/")
+	before := testutil.ToFloat64(deprecatedAnnotations.CounterVec.WithLabelValues("Service", "custom.io/"))
+
+	svc := fakeService()
+	svc.Annotations["custom.io/hostname"] = "ignored.example.com"
+	svc.Annotations["custom.io/ttl"] = "60"
+
+	transform := TransformerWithOptions[*corev1.Service](TransformKeepAnnotationPrefix(annotations.AnnotationKeyPrefix))
+	got, err := transform(svc)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"external-dns.kubernetes.io/hostname": "example.com",
+		"external-dns.kubernetes.io/ttl":      "60",
+	}, got.(*corev1.Service).Annotations)
+	assert.InDelta(t, 1, testutil.ToFloat64(deprecatedAnnotations.CounterVec.WithLabelValues("Service", "custom.io/"))-before, 0)
+}
+
 func TestTransformRequireAnnotation(t *testing.T) {
 	t.Run("matching selector keeps object", func(t *testing.T) {
 		svc := fakeService() // annotations include external-dns.kubernetes.io/hostname=example.com
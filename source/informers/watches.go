@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// watchActivityEvent is an added, updated or deleted object.
+	watchActivityEvent = "event"
+	// watchActivityResync is an update of an object to the same resource version,
+	// replayed from the informer cache.
+	watchActivityResync = "resync"
+	// watchActivityWatch is a change of the resource version last synced by the watch,
+	// e.g. from a bookmark or a relist, without an event.
+	watchActivityWatch = "watch"
+)
+
+// watches are the informers whose watch activity is tracked, checked by CheckWatches.
+var watches struct {
+	sync.Mutex
+	trackers []*watchTracker
+}
+
+// watchTracker records the last activity seen on the watch of an informer.
+type watchTracker struct {
+	name     string
+	informer cache.SharedInformer
+
+	mu              sync.Mutex
+	lastActivity    time.Time
+	resourceVersion string
+}
+
+// TrackWatch records the events and resyncs of informer, and the resource versions
+// synced by its watch, so that CheckWatches detects a watch which stopped receiving them.
+// The name identifies the informer in the metrics and in the errors of CheckWatches.
+func TrackWatch(informer cache.SharedInformer, name string) {
+	t := &watchTracker{name: name, informer: informer, lastActivity: time.Now()}
+	MustAddEventHandler(informer, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(any) { t.observe(watchActivityEvent) },
+		UpdateFunc: func(oldObj, newObj any) {
+			if isResync(oldObj, newObj) {
+				t.observe(watchActivityResync)
+				return
+			}
+			t.observe(watchActivityEvent)
+		},
+		DeleteFunc: func(any) { t.observe(watchActivityEvent) },
+	})
+	watches.Lock()
+	defer watches.Unlock()
+	watches.trackers = append(watches.trackers, t)
+}
+
+// CheckWatches returns an error naming the synced informers tracked by TrackWatch
+// which saw no event, resync or new resource version for longer than staleAfter.
+// Zero or less disables the check.
+func CheckWatches(staleAfter time.Duration) error {
+	if staleAfter <= 0 {
+		return nil
+	}
+	watches.Lock()
+	trackers := watches.trackers
+	watches.Unlock()
+
+	var errs []error
+	now := time.Now()
+	for _, t := range trackers {
+		if t.informer.IsStopped() || !t.informer.HasSynced() {
+			continue
+		}
+		if idle := now.Sub(t.observeResourceVersion()); idle > staleAfter {
+			errs = append(errs, fmt.Errorf("informer %s: no watch activity for %s", t.name, idle.Round(time.Second)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// observe records an activity of the given type now.
+func (t *watchTracker) observe(activity string) {
+	now := time.Now()
+	t.mu.Lock()
+	t.lastActivity = now
+	t.mu.Unlock()
+	watchActivity.SetWithLabels(float64(now.Unix()), t.name, activity)
+}
+
+// observeResourceVersion records an activity when the resource version synced by the
+// watch changed since the last call, and returns the time of the last activity.
+func (t *watchTracker) observeResourceVersion() time.Time {
+	resourceVersion := t.informer.LastSyncResourceVersion()
+	t.mu.Lock()
+	changed := resourceVersion != t.resourceVersion
+	t.resourceVersion = resourceVersion
+	t.mu.Unlock()
+	if changed {
+		t.observe(watchActivityWatch)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.lastActivity
+}
+
+// isResync returns whether an update notification replays an unchanged object.
+func isResync(oldObj, newObj any) bool {
+	oldMeta, ok := oldObj.(metav1.Object)
+	if !ok {
+		return false
+	}
+	newMeta, ok := newObj.(metav1.Object)
+	return ok && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion()
+}
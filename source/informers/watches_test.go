@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckWatches(t *testing.T) {
+	t.Cleanup(func() { watches.trackers = nil })
+
+	client := fake.NewClientset()
+	factory := kubeinformers.NewSharedInformerFactory(client, 0)
+	informer := factory.Core().V1().Services().Informer()
+	TrackWatch(informer, "test/service")
+	factory.Start(t.Context().Done())
+	require.NoError(t, WaitForCacheSync(t.Context(), factory))
+
+	_, err := client.CoreV1().Services("default").Create(t.Context(), &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(informer.GetStore().List()) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, CheckWatches(time.Hour))
+
+	require.Len(t, watches.trackers, 1)
+	tracker := watches.trackers[0]
+	tracker.mu.Lock()
+	tracker.lastActivity = time.Now().Add(-2 * time.Hour)
+	tracker.mu.Unlock()
+
+	err = CheckWatches(time.Hour)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "informer test/service: no watch activity for 2h0m0s")
+	require.NoError(t, CheckWatches(0), "zero disables the check")
+
+	tracker.observe(watchActivityEvent)
+	require.NoError(t, CheckWatches(time.Hour))
+}
+
+func TestIsResync(t *testing.T) {
+	svc := func(resourceVersion string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", ResourceVersion: resourceVersion}}
+	}
+	assert.True(t, isResync(svc("1"), svc("1")))
+	assert.False(t, isResync(svc("1"), svc("2")))
+	assert.False(t, isResync("invalid", svc("1")))
+}
@@ -80,6 +80,15 @@ func (suite *IngressSuite) TestResourceLabelIsSet() {
 	}
 }
 
+func (suite *IngressSuite) TestEndpointsStopsOnCancelledContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	endpoints, err := suite.sc.Endpoints(ctx)
+	suite.ErrorIs(err, context.Canceled)
+	suite.Empty(endpoints)
+}
+
 func TestIngress(t *testing.T) {
 	t.Parallel()
 
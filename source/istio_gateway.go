@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 	networkingv1 "istio.io/client-go/pkg/apis/networking/v1"
@@ -28,6 +29,7 @@ import (
 	networkingv1informer "istio.io/client-go/pkg/informers/externalversions/networking/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	kubeinformers "k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	netinformers "k8s.io/client-go/informers/networking/v1"
@@ -43,9 +45,24 @@ import (
 )
 
 // IstioGatewayIngressSource is the annotation used to determine if the gateway is implemented by an Ingress object
-// instead of a standard LoadBalancer service type
-// Using var instead of const because annotation keys can be customized
-var IstioGatewayIngressSource = annotations.Ingress
+// instead of a standard LoadBalancer service type.
+//
+// Deprecated: use annotations.Ingress, which follows the annotation prefix. Setting IstioGatewayIngressSource
+// to another annotation still overrides it, with a warning.
+var IstioGatewayIngressSource = annotations.DefaultAnnotationPrefix + "ingress"
+
+var istioGatewayIngressSourceWarning sync.Once
+
+// istioGatewayIngressKey returns the annotation naming the Ingress implementing a gateway.
+func istioGatewayIngressKey() string {
+	if IstioGatewayIngressSource == annotations.DefaultAnnotationPrefix+"ingress" {
+		return annotations.Ingress
+	}
+	istioGatewayIngressSourceWarning.Do(func() {
+		log.Warnf("IstioGatewayIngressSource is deprecated, set the annotation prefix instead: reading the Ingress of gateways from the %q annotation", IstioGatewayIngressSource)
+	})
+	return IstioGatewayIngressSource
+}
 
 // gatewaySource is an implementation of Source for Istio Gateway objects.
 // The gateway implementation uses the spec.servers.hosts values for the hostnames.
@@ -64,8 +81,10 @@ type gatewaySource struct {
 	templateEngine           template.Engine
 	ignoreHostnameAnnotation bool
 	serviceInformer          coreinformers.ServiceInformer
-	gatewayInformer          networkingv1informer.GatewayInformer
-	ingressInformer          netinformers.IngressInformer
+	// targetServiceSelector restricts the services the targets are read from
+	targetServiceSelector labels.Selector
+	gatewayInformer       networkingv1informer.GatewayInformer
+	ingressInformer       netinformers.IngressInformer
 }
 
 // NewIstioGatewaySource creates a new gatewaySource with the given config.
@@ -77,9 +96,9 @@ func NewIstioGatewaySource(
 ) (Source, error) {
 	// Use shared informers to listen for add/update/delete of services/pods/nodes in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(cfg.Namespace))
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(cfg.Namespace), kubeinformers.WithTweakListOptions(informers.ListPageSize(cfg.KubeAPIListPageSize)))
 	serviceInformer := informerFactory.Core().V1().Services()
-	istioInformerFactory := istioinformers.NewSharedInformerFactoryWithOptions(istioClient, 0, istioinformers.WithNamespace(cfg.Namespace))
+	istioInformerFactory := istioinformers.NewSharedInformerFactoryWithOptions(istioClient, 0, istioinformers.WithNamespace(cfg.Namespace), istioinformers.WithTweakListOptions(informers.ListPageSize(cfg.KubeAPIListPageSize)))
 	gatewayInformer := istioInformerFactory.Networking().V1().Gateways()
 	ingressInformer := informerFactory.Networking().V1().Ingresses()
 
@@ -105,8 +124,11 @@ func NewIstioGatewaySource(
 
 	// Add default resource event handlers to properly initialize informer.
 	informers.MustAddEventHandler(serviceInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(serviceInformer.Informer(), "istio-gateway/service")
 	informers.MustAddEventHandler(ingressInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(ingressInformer.Informer(), "istio-gateway/ingress")
 	informers.MustAddEventHandler(gatewayInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(gatewayInformer.Informer(), "istio-gateway/gateway")
 
 	informerFactory.Start(ctx.Done())
 	istioInformerFactory.Start(ctx.Done())
@@ -124,6 +146,7 @@ func NewIstioGatewaySource(
 		templateEngine:           cfg.TemplateEngine,
 		ignoreHostnameAnnotation: cfg.IgnoreHostnameAnnotation,
 		serviceInformer:          serviceInformer,
+		targetServiceSelector:    cfg.TargetServiceSelector,
 		gatewayInformer:          gatewayInformer,
 		ingressInformer:          ingressInformer,
 	}, nil
@@ -131,7 +154,7 @@ func NewIstioGatewaySource(
 
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all gateway resources in the source's namespace(s).
-func (sc *gatewaySource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (sc *gatewaySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	indexer := sc.gatewayInformer.Informer().GetIndexer()
 	indexKeys := indexer.ListIndexFuncValues(informers.IndexWithSelectors)
 
@@ -140,6 +163,9 @@ func (sc *gatewaySource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 	log.Debugf("Found %d gateways in namespace %s", len(indexKeys), sc.namespace)
 
 	for _, key := range indexKeys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		gateway, err := informers.GetByKey[*networkingv1.Gateway](indexer, key)
 		if err != nil || gateway == nil {
 			continue
@@ -221,12 +247,12 @@ func (sc *gatewaySource) targetsFromGateway(gateway *networkingv1.Gateway) (endp
 		return targets, nil
 	}
 
-	ingressStr, ok := gateway.Annotations[IstioGatewayIngressSource]
+	ingressStr, ok := gateway.Annotations[istioGatewayIngressKey()]
 	if ok && ingressStr != "" {
 		return sc.targetsFromIngress(ingressStr, gateway)
 	}
 
-	return EndpointTargetsFromServices(sc.serviceInformer, sc.namespace, gateway.Spec.Selector)
+	return EndpointTargetsFromServices(sc.serviceInformer, sc.namespace, gateway.Spec.Selector, sc.targetServiceSelector)
 }
 
 // endpointsFromGatewayConfig extracts the endpoints from an Istio Gateway Config object
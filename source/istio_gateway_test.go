@@ -20,8 +20,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -37,6 +39,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/internal/testutils"
+	logtest "sigs.k8s.io/external-dns/internal/testutils/log"
 	"sigs.k8s.io/external-dns/source/annotations"
 
 	"sigs.k8s.io/external-dns/endpoint"
@@ -207,7 +210,7 @@ func testEndpointsFromGatewayConfig(t *testing.T) {
 			},
 			config: fakeGatewayConfig{
 				annotations: map[string]string{
-					IstioGatewayIngressSource: "ingress1",
+					annotations.Ingress: "ingress1",
 				},
 				dnsnames: [][]string{
 					{"foo.bar"},
@@ -259,7 +262,7 @@ func testEndpointsFromGatewayConfig(t *testing.T) {
 			},
 			config: fakeGatewayConfig{
 				annotations: map[string]string{
-					IstioGatewayIngressSource: "ingress1",
+					annotations.Ingress: "ingress1",
 				},
 				dnsnames: [][]string{
 					{"foo.bar"},
@@ -320,7 +323,7 @@ func testEndpointsFromGatewayConfig(t *testing.T) {
 			},
 			config: fakeGatewayConfig{
 				annotations: map[string]string{
-					IstioGatewayIngressSource: "ingress1",
+					annotations.Ingress: "ingress1",
 				},
 				dnsnames: [][]string{
 					{""},
@@ -381,7 +384,7 @@ func testEndpointsFromGatewayConfig(t *testing.T) {
 			},
 			config: fakeGatewayConfig{
 				annotations: map[string]string{
-					IstioGatewayIngressSource: "istio-other2/ingress1",
+					annotations.Ingress: "istio-other2/ingress1",
 				},
 				dnsnames: [][]string{
 					{"foo.bar"}, // Kubernetes requires removal of trailing dot
@@ -635,7 +638,7 @@ func testGatewayEndpoints(t *testing.T) {
 					namespace: "testing1",
 					dnsnames:  [][]string{{"example.org"}},
 					annotations: map[string]string{
-						IstioGatewayIngressSource: "testing2/ingress1",
+						annotations.Ingress: "testing2/ingress1",
 					},
 				},
 			},
@@ -1002,8 +1005,8 @@ func testGatewayEndpoints(t *testing.T) {
 					name:      "fake3",
 					namespace: "",
 					annotations: map[string]string{
-						IstioGatewayIngressSource: "not-real/ingress1",
-						annotations.TargetKey:     "1.2.3.4",
+						annotations.Ingress:   "not-real/ingress1",
+						annotations.TargetKey: "1.2.3.4",
 					},
 					dnsnames: [][]string{{"example3.org"}},
 				},
@@ -1144,9 +1147,9 @@ func testGatewayEndpoints(t *testing.T) {
 					name:      "fake1",
 					namespace: "",
 					annotations: map[string]string{
-						IstioGatewayIngressSource: "ingress1",
-						annotations.HostnameKey:   "dns-through-hostname.com",
-						annotations.TargetKey:     "gateway-target.com",
+						annotations.Ingress:     "ingress1",
+						annotations.HostnameKey: "dns-through-hostname.com",
+						annotations.TargetKey:   "gateway-target.com",
 					},
 					dnsnames: [][]string{{"example.org"}},
 				},
@@ -1319,7 +1322,7 @@ func testGatewayEndpoints(t *testing.T) {
 					name:      "fake1",
 					namespace: "",
 					annotations: map[string]string{
-						IstioGatewayIngressSource: "",
+						annotations.Ingress: "",
 					},
 					dnsnames: [][]string{},
 				},
@@ -1453,7 +1456,7 @@ func testGatewayEndpoints(t *testing.T) {
 					name:      "fake1",
 					namespace: "",
 					annotations: map[string]string{
-						IstioGatewayIngressSource: "ingress2",
+						annotations.Ingress: "ingress2",
 					},
 					dnsnames: [][]string{{"new.org"}},
 				},
@@ -1777,7 +1780,7 @@ func TestSingleGatewayMultipleServicesPointingToSameLoadBalancer(t *testing.T) {
 				{
 					Hosts: []string{"example.org"},
 					Tls: &istionetworking.ServerTLSSettings{
-						ServerCertificate: IstioGatewayIngressSource,
+						ServerCertificate: annotations.Ingress,
 						Mode:              istionetworking.ServerTLSSettings_SIMPLE,
 					},
 				},
@@ -2022,3 +2025,14 @@ func (c fakeGatewayConfig) Config() *networkingv1.Gateway {
 
 	return gw
 }
+
+func TestIstioGatewayIngressKey(t *testing.T) {
+	assert.Equal(t, annotations.Ingress, istioGatewayIngressKey())
+
+	hook := logtest.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+	t.Cleanup(func() { IstioGatewayIngressSource = annotations.DefaultAnnotationPrefix + "ingress" })
+	IstioGatewayIngressSource = "custom.example.com/ingress"
+	istioGatewayIngressSourceWarning = sync.Once{}
+	assert.Equal(t, "custom.example.com/ingress", istioGatewayIngressKey())
+	logtest.TestHelperLogContains("IstioGatewayIngressSource is deprecated", hook, t)
+}
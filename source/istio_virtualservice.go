@@ -31,6 +31,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	kubeinformers "k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	netinformers "k8s.io/client-go/informers/networking/v1"
@@ -65,9 +66,11 @@ type virtualServiceSource struct {
 	templateEngine           template.Engine
 	ignoreHostnameAnnotation bool
 	serviceInformer          coreinformers.ServiceInformer
-	vServiceInformer         networkingv1informer.VirtualServiceInformer
-	gatewayInformer          networkingv1informer.GatewayInformer
-	ingressInformer          netinformers.IngressInformer
+	// targetServiceSelector restricts the services the targets are read from
+	targetServiceSelector labels.Selector
+	vServiceInformer      networkingv1informer.VirtualServiceInformer
+	gatewayInformer       networkingv1informer.GatewayInformer
+	ingressInformer       netinformers.IngressInformer
 }
 
 // NewIstioVirtualServiceSource creates a new virtualServiceSource with the given config.
@@ -79,9 +82,9 @@ func NewIstioVirtualServiceSource(
 ) (Source, error) {
 	// Use shared informers to listen for add/update/delete of services/pods/nodes in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(cfg.Namespace))
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(cfg.Namespace), kubeinformers.WithTweakListOptions(informers.ListPageSize(cfg.KubeAPIListPageSize)))
 	serviceInformer := informerFactory.Core().V1().Services()
-	istioInformerFactory := istioinformers.NewSharedInformerFactoryWithOptions(istioClient, 0, istioinformers.WithNamespace(cfg.Namespace))
+	istioInformerFactory := istioinformers.NewSharedInformerFactoryWithOptions(istioClient, 0, istioinformers.WithNamespace(cfg.Namespace), istioinformers.WithTweakListOptions(informers.ListPageSize(cfg.KubeAPIListPageSize)))
 	virtualServiceInformer := istioInformerFactory.Networking().V1().VirtualServices()
 	gatewayInformer := istioInformerFactory.Networking().V1().Gateways()
 	ingressInformer := informerFactory.Networking().V1().Ingresses()
@@ -112,9 +115,13 @@ func NewIstioVirtualServiceSource(
 
 	// Add default resource event handlers to properly initialize informer.
 	informers.MustAddEventHandler(ingressInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(ingressInformer.Informer(), "istio-virtualservice/ingress")
 	informers.MustAddEventHandler(serviceInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(serviceInformer.Informer(), "istio-virtualservice/service")
 	informers.MustAddEventHandler(virtualServiceInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(virtualServiceInformer.Informer(), "istio-virtualservice/virtualservice")
 	informers.MustAddEventHandler(gatewayInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(gatewayInformer.Informer(), "istio-virtualservice/gateway")
 
 	informerFactory.Start(ctx.Done())
 	istioInformerFactory.Start(ctx.Done())
@@ -132,6 +139,7 @@ func NewIstioVirtualServiceSource(
 		templateEngine:           cfg.TemplateEngine,
 		ignoreHostnameAnnotation: cfg.IgnoreHostnameAnnotation,
 		serviceInformer:          serviceInformer,
+		targetServiceSelector:    cfg.TargetServiceSelector,
 		vServiceInformer:         virtualServiceInformer,
 		gatewayInformer:          gatewayInformer,
 		ingressInformer:          ingressInformer,
@@ -154,6 +162,11 @@ func (sc *virtualServiceSource) Endpoints(ctx context.Context) ([]*endpoint.Endp
 			continue
 		}
 
+		if !sc.hasReachableRoutes(vService) {
+			log.Debugf("Skipping VirtualService %s/%s: all HTTP routes delegate to unreachable VirtualServices", vService.Namespace, vService.Name)
+			continue
+		}
+
 		gwEndpoints, err := sc.endpointsFromVirtualService(ctx, vService)
 		if err != nil {
 			return nil, err
@@ -239,9 +252,34 @@ func (sc *virtualServiceSource) endpointsFromTemplate(ctx context.Context, virtu
 	return endpoints, nil
 }
 
-// append a target to the list of targets unless it's already in the list
+// targetsFromVirtualService collects the targets of the gateways the VirtualService binds to for the given host.
+// A delegate VirtualService has no gateways of its own and is resolved through the root VirtualServices
+// that delegate to it.
 func (sc *virtualServiceSource) targetsFromVirtualService(ctx context.Context, vService *networkingv1.VirtualService, vsHost string) ([]string, error) {
-	var targets []string
+	targets, err := sc.targetsFromGateways(ctx, vService, vsHost, nil)
+	if err != nil {
+		return targets, err
+	}
+	if len(vService.Spec.Gateways) > 0 {
+		return targets, nil
+	}
+
+	roots, err := sc.delegatingVirtualServices(vService)
+	if err != nil {
+		return targets, err
+	}
+	for _, root := range roots {
+		targets, err = sc.targetsFromGateways(ctx, root, vsHost, targets)
+		if err != nil {
+			return targets, err
+		}
+	}
+	return targets, nil
+}
+
+// targetsFromGateways appends to targets the targets of the gateways of vService serving vsHost, skipping
+// the ones already in the list. vService is either the VirtualService itself or a root delegating to it.
+func (sc *virtualServiceSource) targetsFromGateways(ctx context.Context, vService *networkingv1.VirtualService, vsHost string, targets []string) ([]string, error) {
 	// for each host we need to iterate through the gateways because each host might match for only one of the gateways
 	for _, gateway := range vService.Spec.Gateways {
 		gw, err := sc.getGateway(ctx, gateway, vService)
@@ -267,6 +305,68 @@ func (sc *virtualServiceSource) targetsFromVirtualService(ctx context.Context, v
 	return targets, nil
 }
 
+// delegatingVirtualServices returns the root VirtualServices with an HTTP route delegating to the given VirtualService.
+// Istio supports a single level of delegation, so roots are not resolved any further.
+func (sc *virtualServiceSource) delegatingVirtualServices(delegate *networkingv1.VirtualService) ([]*networkingv1.VirtualService, error) {
+	vServices, err := sc.vServiceInformer.Lister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var roots []*networkingv1.VirtualService
+	for _, root := range vServices {
+		for _, route := range root.Spec.Http {
+			if route.GetDelegate() == nil {
+				continue
+			}
+			namespace := cmp.Or(route.Delegate.Namespace, root.Namespace)
+			if route.Delegate.Name == delegate.Name && namespace == delegate.Namespace && isExportedTo(delegate, root.Namespace) {
+				roots = append(roots, root)
+				break
+			}
+		}
+	}
+	return roots, nil
+}
+
+// hasReachableRoutes reports whether the VirtualService routes any traffic. A VirtualService whose HTTP routes
+// all delegate to VirtualServices that don't exist or aren't exported to its namespace serves nothing.
+func (sc *virtualServiceSource) hasReachableRoutes(vService *networkingv1.VirtualService) bool {
+	if len(vService.Spec.Http) == 0 || len(vService.Spec.Tcp) > 0 || len(vService.Spec.Tls) > 0 {
+		return true
+	}
+
+	for _, route := range vService.Spec.Http {
+		if route.GetDelegate() == nil {
+			return true
+		}
+		namespace := cmp.Or(route.Delegate.Namespace, vService.Namespace)
+		delegate, err := sc.vServiceInformer.Lister().VirtualServices(namespace).Get(route.Delegate.Name)
+		if err != nil {
+			log.Debugf("VirtualService %s/%s delegates to unknown VirtualService %s/%s", vService.Namespace, vService.Name, namespace, route.Delegate.Name)
+			continue
+		}
+		if isExportedTo(delegate, vService.Namespace) {
+			return true
+		}
+		log.Debugf("VirtualService %s/%s delegates to %s/%s which is not exported to namespace %s", vService.Namespace, vService.Name, namespace, route.Delegate.Name, vService.Namespace)
+	}
+	return false
+}
+
+// isExportedTo reports whether the VirtualService is visible from the given namespace according to its exportTo field.
+func isExportedTo(vService *networkingv1.VirtualService, namespace string) bool {
+	if len(vService.Spec.ExportTo) == 0 {
+		return true
+	}
+	for _, ns := range vService.Spec.ExportTo {
+		if ns == "*" || ns == namespace || (ns == "." && namespace == vService.Namespace) {
+			return true
+		}
+	}
+	return false
+}
+
 // endpointsFromVirtualService extracts the endpoints from an Istio VirtualService Config object
 func (sc *virtualServiceSource) endpointsFromVirtualService(ctx context.Context, vService *networkingv1.VirtualService) ([]*endpoint.Endpoint, error) {
 	var endpoints []*endpoint.Endpoint
@@ -325,17 +425,7 @@ func (sc *virtualServiceSource) endpointsFromVirtualService(ctx context.Context,
 // checks if the given VirtualService should actually bind to the given gateway
 // see requirements here: https://istio.io/docs/reference/config/networking/gateway/#Server
 func virtualServiceBindsToGateway(vService *networkingv1.VirtualService, gateway *networkingv1.Gateway, vsHost string) bool {
-	isValid := false
-	if len(vService.Spec.ExportTo) == 0 {
-		isValid = true
-	} else {
-		for _, ns := range vService.Spec.ExportTo {
-			if ns == "*" || ns == gateway.Namespace || (ns == "." && gateway.Namespace == vService.Namespace) {
-				isValid = true
-			}
-		}
-	}
-	if !isValid {
+	if !isExportedTo(vService, gateway.Namespace) {
 		return false
 	}
 
@@ -404,10 +494,10 @@ func (sc *virtualServiceSource) targetsFromGateway(gateway *networkingv1.Gateway
 		return targets, nil
 	}
 
-	ingressStr, ok := gateway.Annotations[IstioGatewayIngressSource]
+	ingressStr, ok := gateway.Annotations[istioGatewayIngressKey()]
 	if ok && ingressStr != "" {
 		return sc.targetsFromIngress(ingressStr, gateway)
 	}
 
-	return EndpointTargetsFromServices(sc.serviceInformer, sc.namespace, gateway.Spec.Selector)
+	return EndpointTargetsFromServices(sc.serviceInformer, sc.namespace, gateway.Spec.Selector, sc.targetServiceSelector)
 }
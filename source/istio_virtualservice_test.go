@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"sigs.k8s.io/external-dns/internal/testutils"
@@ -584,7 +585,7 @@ func testEndpointsFromVirtualServiceConfig(t *testing.T) {
 				name:     "mygw",
 				dnsnames: [][]string{{"*"}},
 				annotations: map[string]string{
-					IstioGatewayIngressSource: "ingress1",
+					annotations.Ingress: "ingress1",
 				},
 			},
 			vsconfig: fakeVirtualServiceConfig{
@@ -617,7 +618,7 @@ func testEndpointsFromVirtualServiceConfig(t *testing.T) {
 				name:     "mygw",
 				dnsnames: [][]string{{"*"}},
 				annotations: map[string]string{
-					IstioGatewayIngressSource: "ingress/ingress2",
+					annotations.Ingress: "ingress/ingress2",
 				},
 			},
 			vsconfig: fakeVirtualServiceConfig{
@@ -791,7 +792,7 @@ func testVirtualServiceEndpoints(t *testing.T) {
 					namespace: namespace,
 					dnsnames:  [][]string{{"example.org"}},
 					annotations: map[string]string{
-						IstioGatewayIngressSource: "ingress1",
+						annotations.Ingress: "ingress1",
 					},
 				},
 				{
@@ -799,7 +800,7 @@ func testVirtualServiceEndpoints(t *testing.T) {
 					namespace: namespace,
 					dnsnames:  [][]string{{"new.org"}},
 					annotations: map[string]string{
-						IstioGatewayIngressSource: "ingress1",
+						annotations.Ingress: "ingress1",
 					},
 				},
 			},
@@ -1064,7 +1065,7 @@ func testVirtualServiceEndpoints(t *testing.T) {
 					namespace: "testing1",
 					dnsnames:  [][]string{{"*"}},
 					annotations: map[string]string{
-						IstioGatewayIngressSource: "ingress1",
+						annotations.Ingress: "ingress1",
 					},
 				},
 			},
@@ -1298,7 +1299,7 @@ func testVirtualServiceEndpoints(t *testing.T) {
 					namespace: namespace,
 					dnsnames:  [][]string{{"*"}},
 					annotations: map[string]string{
-						IstioGatewayIngressSource: "ingress2",
+						annotations.Ingress: "ingress2",
 					},
 				},
 			},
@@ -1603,8 +1604,8 @@ func testVirtualServiceEndpoints(t *testing.T) {
 					namespace: namespace,
 					dnsnames:  [][]string{{"*"}},
 					annotations: map[string]string{
-						annotations.TargetKey:     "gateway-target.com",
-						IstioGatewayIngressSource: "ingress1",
+						annotations.TargetKey: "gateway-target.com",
+						annotations.Ingress:   "ingress1",
 					},
 				},
 			},
@@ -1674,6 +1675,116 @@ func testVirtualServiceEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			title: "delegate virtualservice with hostname annotation resolves targets through its root",
+			lbServices: []fakeIngressGatewayService{
+				{
+					ips:       []string{"1.2.3.4"},
+					namespace: namespace,
+				},
+			},
+			gwConfigs: []fakeGatewayConfig{
+				{
+					name:      "fake1",
+					namespace: namespace,
+					dnsnames:  [][]string{{"*.bar.com"}},
+				},
+			},
+			vsConfigs: []fakeVirtualServiceConfig{
+				{
+					name:      "root",
+					namespace: namespace,
+					gateways:  []string{"fake1"},
+					dnsnames:  []string{"root.bar.com"},
+					delegates: []string{"apps/delegate"},
+				},
+				{
+					name:      "delegate",
+					namespace: "apps",
+					annotations: map[string]string{
+						annotations.HostnameKey: "app.bar.com",
+					},
+				},
+				{
+					name:      "orphan",
+					namespace: "apps",
+					annotations: map[string]string{
+						annotations.HostnameKey: "orphan.bar.com",
+					},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "root.bar.com",
+					Targets:    endpoint.Targets{"1.2.3.4"},
+					RecordType: endpoint.RecordTypeA,
+				},
+				{
+					DNSName:    "app.bar.com",
+					Targets:    endpoint.Targets{"1.2.3.4"},
+					RecordType: endpoint.RecordTypeA,
+				},
+			},
+		},
+		{
+			title: "root virtualservice delegating to a virtualservice not exported to its namespace is skipped",
+			lbServices: []fakeIngressGatewayService{
+				{
+					ips:       []string{"1.2.3.4"},
+					namespace: namespace,
+				},
+			},
+			gwConfigs: []fakeGatewayConfig{
+				{
+					name:      "fake1",
+					namespace: namespace,
+					dnsnames:  [][]string{{"*"}},
+				},
+			},
+			vsConfigs: []fakeVirtualServiceConfig{
+				{
+					name:      "root",
+					namespace: namespace,
+					gateways:  []string{"fake1"},
+					dnsnames:  []string{"root.bar.com"},
+					delegates: []string{"apps/delegate"},
+				},
+				{
+					name:      "delegate",
+					namespace: "apps",
+					exportTo:  ".",
+					annotations: map[string]string{
+						annotations.HostnameKey: "app.bar.com",
+					},
+				},
+				{
+					name:      "exported-root",
+					namespace: namespace,
+					gateways:  []string{"fake1"},
+					dnsnames:  []string{"exported.bar.com"},
+					delegates: []string{"exported"},
+				},
+				{
+					name:      "exported",
+					namespace: namespace,
+					exportTo:  ".",
+				},
+				{
+					name:      "missing-root",
+					namespace: namespace,
+					gateways:  []string{"fake1"},
+					dnsnames:  []string{"missing.bar.com"},
+					delegates: []string{"missing"},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "exported.bar.com",
+					Targets:    endpoint.Targets{"1.2.3.4"},
+					RecordType: endpoint.RecordTypeA,
+				},
+			},
+		},
 		{
 			title: "virtualservice with hostname annotation having multiple hostnames, restricted by gw.hosts",
 			lbServices: []fakeIngressGatewayService{
@@ -1958,7 +2069,7 @@ func testVirtualServiceEndpoints(t *testing.T) {
 						"app": "igw4",
 					},
 					annotations: map[string]string{
-						IstioGatewayIngressSource: "testing1/ingress1",
+						annotations.Ingress: "testing1/ingress1",
 					},
 				},
 			},
@@ -2159,6 +2270,7 @@ type fakeVirtualServiceConfig struct {
 	labels      map[string]string
 	dnsnames    []string
 	exportTo    string
+	delegates   []string
 }
 
 func (c fakeVirtualServiceConfig) Config() *networkingv1.VirtualService {
@@ -2169,6 +2281,15 @@ func (c fakeVirtualServiceConfig) Config() *networkingv1.VirtualService {
 	if c.exportTo != "" {
 		vs.ExportTo = []string{c.exportTo}
 	}
+	for _, delegate := range c.delegates {
+		ns, name, _ := strings.Cut(delegate, "/")
+		if name == "" {
+			ns, name = "", ns
+		}
+		vs.Http = append(vs.Http, &istionetworking.HTTPRoute{
+			Delegate: &istionetworking.Delegate{Name: name, Namespace: ns},
+		})
+	}
 
 	return &networkingv1.VirtualService{
 		ObjectMeta: metav1.ObjectMeta{
@@ -76,7 +76,7 @@ func NewKongTCPIngressSource(
 ) (Source, error) {
 	// Use shared informer to listen for add/update/delete of Host in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, cfg.Namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, cfg.Namespace, informers.ListPageSize(cfg.KubeAPIListPageSize))
 	kongTCPIngressInformer := informerFactory.ForResource(kongGroupdVersionResource)
 
 	informers.MustSetTransform(kongTCPIngressInformer.Informer(), informers.TransformerWithOptions[*unstructured.Unstructured](
@@ -86,6 +86,7 @@ func NewKongTCPIngressSource(
 
 	// Add default resource event handlers to properly initialize informer.
 	informers.MustAddEventHandler(kongTCPIngressInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(kongTCPIngressInformer.Informer(), "kong-tcpingress/tcpingress")
 
 	informerFactory.Start(ctx.Done())
 
@@ -112,7 +113,7 @@ func NewKongTCPIngressSource(
 
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all TCPIngresses in the source's namespace(s).
-func (sc *kongTCPIngressSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (sc *kongTCPIngressSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	tis, err := sc.kongTCPIngressInformer.Lister().ByNamespace(sc.namespace).List(labels.Everything())
 	if err != nil {
 		return nil, err
@@ -137,6 +138,9 @@ func (sc *kongTCPIngressSource) Endpoints(_ context.Context) ([]*endpoint.Endpoi
 
 	var endpoints []*endpoint.Endpoint
 	for _, tcpIngress := range tcpIngresses {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		targets := annotations.TargetsFromTargetAnnotation(tcpIngress.Annotations)
 		if len(targets) == 0 {
 			for _, lb := range tcpIngress.Status.LoadBalancer.Ingress {
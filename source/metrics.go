@@ -0,0 +1,36 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+var listedObjects = metrics.NewGaugedVectorOpts(
+	prometheus.GaugeOpts{
+		Subsystem: "source",
+		Name:      "listed_objects",
+		Help:      "Number of objects returned by the last paginated list of a source that lists its resources without an informer, partitioned by source.",
+	},
+	[]string{"source_type"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(listedObjects)
+}
@@ -53,6 +53,8 @@ type nodeSource struct {
 	nodeInformer         coreinformers.NodeInformer
 	excludeUnschedulable bool
 	exposeInternalIPv6   bool
+	// zoneTemplateEngine generates the per-zone names of nodes carrying the topology zone label.
+	zoneTemplateEngine template.Engine
 }
 
 // NewNodeSource creates a new nodeSource with the given config.
@@ -62,7 +64,7 @@ func NewNodeSource(
 	cfg *Config) (Source, error) {
 	// Use shared informers to listen for add/update/delete of nodes.
 	// Set resync period to 0, to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0)
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithTweakListOptions(informers.ListPageSize(cfg.KubeAPIListPageSize)))
 	nodeInformer := informerFactory.Core().V1().Nodes()
 
 	informers.MustAddIndexers(nodeInformer.Informer(), informers.IndexerWithOptions[*v1.Node](
@@ -79,6 +81,7 @@ func NewNodeSource(
 
 	// Add default resource event handler to properly initialize informer.
 	informers.MustAddEventHandler(nodeInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(nodeInformer.Informer(), "node/node")
 
 	informerFactory.Start(ctx.Done())
 
@@ -93,16 +96,20 @@ func NewNodeSource(
 		nodeInformer:         nodeInformer,
 		excludeUnschedulable: cfg.ExcludeUnschedulable,
 		exposeInternalIPv6:   cfg.ExposeInternalIPv6,
+		zoneTemplateEngine:   cfg.NodeZoneTemplateEngine,
 	}, nil
 }
 
 // Endpoints returns endpoint objects for each service that should be processed.
-func (ns *nodeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (ns *nodeSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	nodes := informers.ListIndexed[*v1.Node](ns.nodeInformer.Informer().GetIndexer())
 
 	endpoints := make([]*endpoint.Endpoint, 0, len(nodes))
 
 	for _, node := range nodes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if node.Spec.Unschedulable && ns.excludeUnschedulable {
 			log.Debugf("Skipping node %s because it is unschedulable", node.Name)
 			continue
@@ -133,6 +140,12 @@ func (ns *nodeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 			return nil, err
 		}
 
+		zoneEndpoints, err := ns.endpointsForZone(node)
+		if err != nil {
+			return nil, err
+		}
+		nodeEndpoints = append(nodeEndpoints, zoneEndpoints...)
+
 		if len(nodeEndpoints) == 0 {
 			log.Debugf("No endpoints could be generated from node %s", node.Name)
 			continue
@@ -164,6 +177,26 @@ func (ns *nodeSource) endpointsFromNodeTemplate(node *v1.Node) ([]*endpoint.Endp
 	return ns.endpointsForDNSNames(node, names)
 }
 
+// endpointsForZone creates endpoints for the names the zone template generates for a node in a
+// topology zone. Nodes of a zone usually resolve to the same names, so merging the endpoints of
+// all nodes groups their addresses into one record per zone.
+func (ns *nodeSource) endpointsForZone(node *v1.Node) ([]*endpoint.Endpoint, error) {
+	if !ns.zoneTemplateEngine.IsConfigured() || node.Labels[v1.LabelTopologyZone] == "" {
+		return nil, nil
+	}
+
+	names, err := ns.zoneTemplateEngine.ExecFQDN(node)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		log.Debugf("applied zone template for %s in zone %s, converting to %s", node.Name, node.Labels[v1.LabelTopologyZone], name)
+	}
+
+	return ns.endpointsForDNSNames(node, names)
+}
+
 // endpointsForDNSNames creates endpoints for the given DNS names using the node's addresses.
 func (ns *nodeSource) endpointsForDNSNames(node *v1.Node, dnsNames []string) ([]*endpoint.Endpoint, error) {
 	ttl := annotations.TTLFromAnnotations(node.Annotations, fmt.Sprintf("node/%s", node.Name))
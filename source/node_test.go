@@ -43,6 +43,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/template"
 	templatetest "sigs.k8s.io/external-dns/source/template/testutil"
 )
 
@@ -858,3 +859,46 @@ func createTestNodes(count int, funcs ...func([]*v1.Node)) []*v1.Node {
 	}
 	return nodes
 }
+
+func TestNodeSourceZoneTemplate(t *testing.T) {
+	node := func(name, zone, address string) *v1.Node {
+		n := &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}},
+			Status: v1.NodeStatus{
+				Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: address}},
+			},
+		}
+		if zone != "" {
+			n.Labels[v1.LabelTopologyZone] = zone
+		}
+		return n
+	}
+	kubeClient := fake.NewClientset(
+		node("node1", "eu-west-1a", "1.1.1.1"),
+		node("node2", "eu-west-1a", "1.1.1.2"),
+		node("node3", "eu-west-1b", "1.1.1.3"),
+		node("node4", "", "1.1.1.4"),
+	)
+
+	zoneTmpl, err := template.NewFQDNEngine(
+		[]string{`zone-{{ trimPrefix (index .Labels "topology.kubernetes.io/zone") "eu-west-1" }}.nodes.example.com`},
+		"--node-zone-fqdn-template")
+	require.NoError(t, err)
+
+	src, err := NewNodeSource(t.Context(), kubeClient, &Config{
+		LabelFilter:            labels.Everything(),
+		NodeZoneTemplateEngine: zoneTmpl,
+	})
+	require.NoError(t, err)
+
+	got, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	testutils.ValidateEndpoints(t, got, []*endpoint.Endpoint{
+		{DNSName: "node1", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.1"}},
+		{DNSName: "node2", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.2"}},
+		{DNSName: "node3", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.3"}},
+		{DNSName: "node4", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.4"}},
+		{DNSName: "zone-a.nodes.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.1", "1.1.1.2"}},
+		{DNSName: "zone-b.nodes.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.3"}},
+	})
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+	"sigs.k8s.io/external-dns/source/types"
+)
+
+const (
+	// nomadHostnameTag is the prefix of the service tags listing the hostnames of a service, comma separated.
+	nomadHostnameTag = "external-dns.hostname="
+	// nomadTTLTag is the prefix of the service tag setting the TTL of the records of a service, in seconds.
+	nomadTTLTag = "external-dns.ttl="
+	// nomadIndexHeader holds the Raft index of the data returned by the Nomad API.
+	nomadIndexHeader = "X-Nomad-Index"
+
+	nomadRequestTimeout = 30 * time.Second
+)
+
+// nomadServiceList is an item of the response of the /v1/services endpoint.
+type nomadServiceList struct {
+	Namespace string
+	Services  []nomadServiceStub
+}
+
+type nomadServiceStub struct {
+	ServiceName string
+	Tags        []string
+}
+
+// nomadServiceRegistration is an item of the response of the /v1/service/:name endpoint.
+type nomadServiceRegistration struct {
+	ServiceName string
+	Namespace   string
+	Address     string
+	Port        int
+	Tags        []string
+}
+
+// nomadSource is an implementation of Source that provides endpoints for the
+// services registered in HashiCorp Nomad. The hostnames of a service are read
+// from its "external-dns.hostname=" tags, and the targets are the addresses of
+// its registrations.
+//
+// +externaldns:source:name=nomad
+// +externaldns:source:category=Special
+// +externaldns:source:description=Creates DNS entries from the services registered in HashiCorp Nomad
+// +externaldns:source:resources=Nomad services
+// +externaldns:source:filters=
+// +externaldns:source:namespace=all,single
+// +externaldns:source:fqdn-template=false
+// +externaldns:source:events=true
+// +externaldns:source:provider-specific=false
+type nomadSource struct {
+	client       *http.Client
+	address      *url.URL
+	token        string
+	namespace    string
+	tags         []string
+	pollInterval time.Duration
+}
+
+// NewNomadSource creates a new nomadSource with the given config.
+func NewNomadSource(cfg *Config) (Source, error) {
+	address, err := url.Parse(cfg.NomadAddress)
+	if err != nil || (address.Scheme != "http" && address.Scheme != "https") || address.Host == "" {
+		return nil, fmt.Errorf("nomad source requires an http or https --nomad-address, got %q", cfg.NomadAddress)
+	}
+	if cfg.NomadPollInterval <= 0 {
+		return nil, fmt.Errorf("nomad source requires a positive --nomad-poll-interval, got %s", cfg.NomadPollInterval)
+	}
+	namespace := cfg.NomadNamespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	return &nomadSource{
+		client:       extdnshttp.NewInstrumentedClient(&http.Client{Timeout: nomadRequestTimeout}),
+		address:      address,
+		token:        cfg.NomadToken,
+		namespace:    namespace,
+		tags:         cfg.NomadTags,
+		pollInterval: cfg.NomadPollInterval,
+	}, nil
+}
+
+// Endpoints returns the endpoints of the tagged Nomad services.
+func (ns *nomadSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	var lists []nomadServiceList
+	if _, err := ns.get(ctx, "/v1/services", ns.namespace, &lists); err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, list := range lists {
+		for _, stub := range list.Services {
+			// the tags of a stub are the union of the tags of the registrations of the service
+			if !ns.matchesTags(stub.Tags) || len(nomadHostnames(stub.Tags)) == 0 {
+				continue
+			}
+			var registrations []nomadServiceRegistration
+			if _, err := ns.get(ctx, "/v1/service/"+url.PathEscape(stub.ServiceName), list.Namespace, &registrations); err != nil {
+				return nil, err
+			}
+			resource := fmt.Sprintf("%s/%s/%s", types.Nomad, list.Namespace, stub.ServiceName)
+			endpoints = append(endpoints, ns.endpointsForService(resource, registrations)...)
+		}
+	}
+	return endpoints, nil
+}
+
+// endpointsForService returns the endpoints of the hostnames of the registrations of a service.
+func (ns *nomadSource) endpointsForService(resource string, registrations []nomadServiceRegistration) []*endpoint.Endpoint {
+	targets := map[string]endpoint.Targets{}
+	ttls := map[string]endpoint.TTL{}
+	for _, r := range registrations {
+		if !ns.matchesTags(r.Tags) {
+			continue
+		}
+		if r.Address == "" {
+			log.Debugf("Skipping registration of Nomad service %s without address", resource)
+			continue
+		}
+		ttl := nomadTTL(resource, r.Tags)
+		for _, hostname := range nomadHostnames(r.Tags) {
+			if !slices.Contains(targets[hostname], r.Address) {
+				targets[hostname] = append(targets[hostname], r.Address)
+			}
+			if ttl.IsConfigured() {
+				ttls[hostname] = ttl
+			}
+		}
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, hostname := range slices.Sorted(maps.Keys(targets)) {
+		slices.Sort(targets[hostname])
+		endpoints = append(endpoints, endpoint.EndpointsForHostname(hostname, targets[hostname], ttls[hostname], nil, "", resource)...)
+	}
+	return endpoints
+}
+
+// matchesTags returns whether tags include all the tags required with --nomad-tag.
+func (ns *nomadSource) matchesTags(tags []string) bool {
+	for _, t := range ns.tags {
+		if !slices.Contains(tags, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// nomadHostnames returns the hostnames listed in the hostname tags.
+func nomadHostnames(tags []string) []string {
+	var hostnames []string
+	for _, t := range tags {
+		if value, ok := strings.CutPrefix(t, nomadHostnameTag); ok {
+			hostnames = append(hostnames, splitServiceHostnames(value)...)
+		}
+	}
+	return hostnames
+}
+
+// nomadTTL returns the TTL set by the TTL tag, or an unconfigured TTL.
+func nomadTTL(resource string, tags []string) endpoint.TTL {
+	for _, t := range tags {
+		if value, ok := strings.CutPrefix(t, nomadTTLTag); ok {
+			return parseServiceTTL(resource, value)
+		}
+	}
+	return 0
+}
+
+// get decodes the response of the Nomad API at path into out, and returns its index.
+func (ns *nomadSource) get(ctx context.Context, path, namespace string, out any) (uint64, error) {
+	u := ns.address.JoinPath(path)
+	u.RawQuery = url.Values{"namespace": {namespace}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	if ns.token != "" {
+		req.Header.Set("X-Nomad-Token", ns.token)
+	}
+	resp, err := ns.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query Nomad: %w", err)
+	}
+	defer extdnshttp.DrainAndClose(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return 0, fmt.Errorf("failed to query Nomad %s: %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return 0, fmt.Errorf("failed to decode Nomad %s: %w", path, err)
+	}
+	index, _ := strconv.ParseUint(resp.Header.Get(nomadIndexHeader), 10, 64)
+	return index, nil
+}
+
+// AddEventHandler polls the index of the Nomad services and calls handler when it changes,
+// since Nomad has no watch API shared with the Kubernetes informers.
+func (ns *nomadSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("nomad: adding event handler")
+	go func() {
+		ticker := time.NewTicker(ns.pollInterval)
+		defer ticker.Stop()
+		var last uint64
+		for {
+			var lists []nomadServiceList
+			index, err := ns.get(ctx, "/v1/services", ns.namespace, &lists)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Warnf("Polling Nomad services: %v", err)
+				}
+			} else {
+				if last != 0 && index != last {
+					handler()
+				}
+				last = index
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+// fakeNomad serves the service registrations of the Nomad HTTP API.
+type fakeNomad struct {
+	registrations []nomadServiceRegistration
+	token         string
+	index         atomic.Uint64
+}
+
+func (f *fakeNomad) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.token != "" && r.Header.Get("X-Nomad-Token") != f.token {
+		http.Error(w, "Permission denied", http.StatusForbidden)
+		return
+	}
+	namespace := r.URL.Query().Get("namespace")
+	inNamespace := func(reg nomadServiceRegistration) bool {
+		return namespace == "*" || reg.Namespace == namespace
+	}
+	w.Header().Set(nomadIndexHeader, strconv.FormatUint(f.index.Load(), 10))
+
+	if r.URL.Path == "/v1/services" {
+		lists := []nomadServiceList{}
+		for _, reg := range f.registrations {
+			if !inNamespace(reg) {
+				continue
+			}
+			if len(lists) == 0 || lists[len(lists)-1].Namespace != reg.Namespace {
+				lists = append(lists, nomadServiceList{Namespace: reg.Namespace})
+			}
+			list := &lists[len(lists)-1]
+			if len(list.Services) == 0 || list.Services[len(list.Services)-1].ServiceName != reg.ServiceName {
+				list.Services = append(list.Services, nomadServiceStub{ServiceName: reg.ServiceName})
+			}
+			stub := &list.Services[len(list.Services)-1]
+			stub.Tags = append(stub.Tags, reg.Tags...)
+		}
+		_ = json.NewEncoder(w).Encode(lists)
+		return
+	}
+	name, ok := strings.CutPrefix(r.URL.Path, "/v1/service/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	registrations := []nomadServiceRegistration{}
+	for _, reg := range f.registrations {
+		if reg.ServiceName == name && inNamespace(reg) {
+			registrations = append(registrations, reg)
+		}
+	}
+	_ = json.NewEncoder(w).Encode(registrations)
+}
+
+func newTestNomadSource(t *testing.T, server *httptest.Server, cfg *Config) Source {
+	t.Helper()
+	cfg.NomadAddress = server.URL
+	if cfg.NomadPollInterval == 0 {
+		cfg.NomadPollInterval = time.Minute
+	}
+	src, err := NewNomadSource(cfg)
+	require.NoError(t, err)
+	return src
+}
+
+func TestNomadSourceEndpoints(t *testing.T) {
+	// registrations are grouped by namespace then by service, as returned by Nomad
+	nomad := &fakeNomad{registrations: []nomadServiceRegistration{
+		{ServiceName: "web", Namespace: "default", Address: "10.0.0.2", Port: 8080, Tags: []string{"public", "external-dns.hostname=web.example.org,www.example.org.", "external-dns.ttl=60"}},
+		{ServiceName: "web", Namespace: "default", Address: "10.0.0.1", Port: 8080, Tags: []string{"public", "external-dns.hostname=web.example.org,www.example.org.", "external-dns.ttl=60"}},
+		{ServiceName: "web", Namespace: "default", Address: "2001:db8::1", Port: 8080, Tags: []string{"public", "external-dns.hostname=web.example.org"}},
+		{ServiceName: "api", Namespace: "default", Address: "10.0.1.1", Port: 9090, Tags: []string{"external-dns.hostname=api.example.org", "external-dns.ttl=invalid"}},
+		{ServiceName: "db", Namespace: "default", Address: "10.0.2.1", Port: 5432, Tags: []string{"public"}},
+		{ServiceName: "batch", Namespace: "default", Address: "", Port: 80, Tags: []string{"public", "external-dns.hostname=batch.example.org"}},
+		{ServiceName: "web", Namespace: "staging", Address: "10.1.0.1", Port: 8080, Tags: []string{"public", "external-dns.hostname=web.staging.example.org"}},
+	}}
+	server := httptest.NewServer(nomad)
+	defer server.Close()
+
+	for _, tt := range []struct {
+		title     string
+		namespace string
+		tags      []string
+		expected  []*endpoint.Endpoint
+	}{
+		{
+			title: "default namespace",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2").
+					WithLabel(endpoint.ResourceLabelKey, "nomad/default/web"),
+				endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeAAAA, 60, "2001:db8::1").
+					WithLabel(endpoint.ResourceLabelKey, "nomad/default/web"),
+				endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2").
+					WithLabel(endpoint.ResourceLabelKey, "nomad/default/web"),
+				endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "10.0.1.1").
+					WithLabel(endpoint.ResourceLabelKey, "nomad/default/api"),
+			},
+		},
+		{
+			title:     "all namespaces with a tag filter",
+			namespace: "*",
+			tags:      []string{"public"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2").
+					WithLabel(endpoint.ResourceLabelKey, "nomad/default/web"),
+				endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeAAAA, 60, "2001:db8::1").
+					WithLabel(endpoint.ResourceLabelKey, "nomad/default/web"),
+				endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2").
+					WithLabel(endpoint.ResourceLabelKey, "nomad/default/web"),
+				endpoint.NewEndpoint("web.staging.example.org", endpoint.RecordTypeA, "10.1.0.1").
+					WithLabel(endpoint.ResourceLabelKey, "nomad/staging/web"),
+			},
+		},
+		{
+			title:     "no matching service",
+			namespace: "staging",
+			tags:      []string{"public", "internal"},
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			src := newTestNomadSource(t, server, &Config{NomadNamespace: tt.namespace, NomadTags: tt.tags})
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+			assert.True(t, testutils.SameEndpoints(tt.expected, endpoints), "expected %v, got %v", tt.expected, endpoints)
+		})
+	}
+}
+
+func TestNomadSourceToken(t *testing.T) {
+	nomad := &fakeNomad{token: "secret", registrations: []nomadServiceRegistration{
+		{ServiceName: "web", Namespace: "default", Address: "10.0.0.1", Tags: []string{"external-dns.hostname=web.example.org"}},
+	}}
+	server := httptest.NewServer(nomad)
+	defer server.Close()
+
+	_, err := newTestNomadSource(t, server, &Config{}).Endpoints(t.Context())
+	require.ErrorContains(t, err, "403 Forbidden: Permission denied")
+
+	endpoints, err := newTestNomadSource(t, server, &Config{NomadToken: "secret"}).Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+}
+
+func TestNomadSourceAddEventHandler(t *testing.T) {
+	nomad := &fakeNomad{}
+	nomad.index.Store(1)
+	server := httptest.NewServer(nomad)
+	defer server.Close()
+
+	src := newTestNomadSource(t, server, &Config{NomadPollInterval: 10 * time.Millisecond})
+	var calls atomic.Int32
+	src.AddEventHandler(t.Context(), func() { calls.Add(1) })
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, calls.Load(), "an unchanged index must not trigger the handler")
+
+	nomad.index.Store(2)
+	assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func TestNewNomadSource(t *testing.T) {
+	for _, tt := range []struct {
+		title string
+		cfg   *Config
+		err   string
+	}{
+		{
+			title: "valid",
+			cfg:   &Config{NomadAddress: "https://nomad.example.org:4646", NomadPollInterval: time.Minute},
+		},
+		{
+			title: "no scheme",
+			cfg:   &Config{NomadAddress: "nomad.example.org:4646", NomadPollInterval: time.Minute},
+			err:   "--nomad-address",
+		},
+		{
+			title: "no poll interval",
+			cfg:   &Config{NomadAddress: "http://127.0.0.1:4646"},
+			err:   "--nomad-poll-interval",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			_, err := NewNomadSource(tt.cfg)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
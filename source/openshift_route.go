@@ -71,7 +71,7 @@ func NewOcpRouteSource(
 ) (Source, error) {
 	// Use a shared informer to listen for add/update/delete of Routes in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := extInformers.NewSharedInformerFactoryWithOptions(ocpClient, 0*time.Second, extInformers.WithNamespace(cfg.Namespace))
+	informerFactory := extInformers.NewSharedInformerFactoryWithOptions(ocpClient, 0*time.Second, extInformers.WithNamespace(cfg.Namespace), extInformers.WithTweakListOptions(informers.ListPageSize(cfg.KubeAPIListPageSize)))
 	informer := informerFactory.Route().V1().Routes()
 
 	informers.MustSetTransform(informer.Informer(), informers.TransformerWithOptions[*routev1.Route](
@@ -81,6 +81,7 @@ func NewOcpRouteSource(
 
 	// Add default resource event handlers to properly initialize informer.
 	informers.MustAddEventHandler(informer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(informer.Informer(), "openshift-route/route")
 
 	informerFactory.Start(ctx.Done())
 
@@ -112,7 +113,7 @@ func (ors *ocpRouteSource) AddEventHandler(_ context.Context, handler func()) {
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all OpenShift Route resources on all namespaces, unless an explicit namespace
 // is specified in ocpRouteSource.
-func (ors *ocpRouteSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (ors *ocpRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	ocpRoutes, err := ors.routeInformer.Lister().Routes(ors.namespace).List(ors.labelSelector)
 	if err != nil {
 		return nil, err
@@ -123,6 +124,9 @@ func (ors *ocpRouteSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, e
 	endpoints := []*endpoint.Endpoint{}
 
 	for _, ocpRoute := range ocpRoutes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if annotations.IsControllerMismatch(ocpRoute, types.OpenShiftRoute) {
 			continue
 		}
@@ -31,6 +31,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/logging"
 	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/informers"
@@ -71,7 +72,7 @@ func NewPodSource(
 	annotationFilter := cfg.AnnotationFilter
 	labelSelector := cfg.LabelFilter
 
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace), kubeinformers.WithTweakListOptions(informers.ListPageSize(cfg.KubeAPIListPageSize)))
 	podInformer := informerFactory.Core().V1().Pods()
 	nodeInformer := informerFactory.Core().V1().Nodes()
 
@@ -92,7 +93,9 @@ func NewPodSource(
 	))
 
 	informers.MustAddEventHandler(podInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(podInformer.Informer(), "pod/pod")
 	informers.MustAddEventHandler(nodeInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(nodeInformer.Informer(), "pod/node")
 
 	informerFactory.Start(ctx.Done())
 
@@ -117,11 +120,14 @@ func (ps *podSource) AddEventHandler(_ context.Context, handler func()) {
 	informers.MustAddEventHandler(ps.podInformer.Informer(), eventHandlerFunc(handler))
 }
 
-func (ps *podSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (ps *podSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	pods := informers.ListIndexed[*v1.Pod](ps.podInformer.Informer().GetIndexer())
 
 	endpoints := make([]*endpoint.Endpoint, 0, len(pods))
 	for _, pod := range pods {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		podEndpoints := ps.endpointsFromPodAnnotations(pod)
 
 		podEndpoints, err := ps.templateEngine.ApplyFQDNTargetTemplate(podEndpoints, pod)
@@ -171,7 +177,7 @@ func (ps *podSource) endpointsFromPodTemplate(pod *v1.Pod) ([]*endpoint.Endpoint
 
 func (ps *podSource) addPodEndpointsToEndpointMap(endpointMap map[endpoint.EndpointKey][]string, pod *v1.Pod) {
 	if ps.ignoreNonHostNetworkPods && !pod.Spec.HostNetwork {
-		log.Debugf("skipping pod %s. hostNetwork=false", pod.Name)
+		logging.Debugf("skipping pod %s. hostNetwork=false", pod.Name)
 		return
 	}
 
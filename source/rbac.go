@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"sigs.k8s.io/external-dns/source/types"
+)
+
+var readVerbs = []string{"get", "watch", "list"}
+
+// rbacRules declares the Kubernetes API access each source needs. The rules
+// mirror the ClusterRole of the Helm chart, plus the Services the ambassador-host
+// and contour-httpproxy sources read their load balancer from; sources with no
+// rules do not talk to the Kubernetes API or, like unstructured, depend on their flags.
+var rbacRules = map[types.Type][]rbacv1.PolicyRule{
+	types.Node: {
+		{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"list", "watch"}},
+	},
+	types.Service: {
+		{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"list", "watch"}},
+		{APIGroups: []string{""}, Resources: []string{"pods", "services"}, Verbs: readVerbs},
+		{APIGroups: []string{"discovery.k8s.io"}, Resources: []string{"endpointslices"}, Verbs: readVerbs},
+	},
+	types.Ingress: {
+		{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: readVerbs},
+	},
+	types.Pod: {
+		{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"list", "watch"}},
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: readVerbs},
+	},
+	types.GatewayHttpRoute: gatewayRouteRules("httproutes"),
+	types.GatewayGrpcRoute: gatewayRouteRules("grpcroutes"),
+	types.GatewayTlsRoute:  gatewayRouteRules("tlsroutes"),
+	types.GatewayTcpRoute:  gatewayRouteRules("tcproutes"),
+	types.GatewayUdpRoute:  gatewayRouteRules("udproutes"),
+	types.IstioGateway: {
+		{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: readVerbs},
+		{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: readVerbs},
+		{APIGroups: []string{"networking.istio.io"}, Resources: []string{"gateways"}, Verbs: readVerbs},
+	},
+	types.IstioVirtualService: {
+		{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: readVerbs},
+		{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: readVerbs},
+		{APIGroups: []string{"networking.istio.io"}, Resources: []string{"gateways", "virtualservices"}, Verbs: readVerbs},
+	},
+	types.AmbassadorHost: {
+		{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: readVerbs},
+		{APIGroups: []string{"getambassador.io"}, Resources: []string{"hosts", "ingresses"}, Verbs: readVerbs},
+	},
+	types.ContourHTTPProxy: {
+		{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: readVerbs},
+		{APIGroups: []string{"projectcontour.io"}, Resources: []string{"httpproxies"}, Verbs: readVerbs},
+	},
+	types.GlooProxy: {
+		{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: readVerbs},
+		{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: readVerbs},
+		{APIGroups: []string{"gloo.solo.io", "gateway.solo.io"}, Resources: []string{"proxies", "virtualservices", "gateways"}, Verbs: readVerbs},
+	},
+	types.TraefikProxy: {
+		{APIGroups: []string{"traefik.containo.us", "traefik.io"}, Resources: []string{"ingressroutes", "ingressroutetcps", "ingressrouteudps"}, Verbs: readVerbs},
+	},
+	types.OpenShiftRoute: {
+		{APIGroups: []string{"route.openshift.io"}, Resources: []string{"routes"}, Verbs: readVerbs},
+	},
+	types.CRD: {
+		{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"dnsendpoints"}, Verbs: readVerbs},
+		{APIGroups: []string{"externaldns.k8s.io"}, Resources: []string{"dnsendpoints/status"}, Verbs: []string{"update"}},
+	},
+	types.SkipperRouteGroup: {
+		{APIGroups: []string{"zalando.org"}, Resources: []string{"routegroups"}, Verbs: readVerbs},
+		{APIGroups: []string{"zalando.org"}, Resources: []string{"routegroups/status"}, Verbs: []string{"patch", "update"}},
+	},
+	types.KongTCPIngress: {
+		{APIGroups: []string{"configuration.konghq.com"}, Resources: []string{"tcpingresses"}, Verbs: readVerbs},
+	},
+	types.F5VirtualServer: {
+		{APIGroups: []string{"cis.f5.com"}, Resources: []string{"virtualservers"}, Verbs: readVerbs},
+	},
+	types.F5TransportServer: {
+		{APIGroups: []string{"cis.f5.com"}, Resources: []string{"transportservers"}, Verbs: readVerbs},
+	},
+	types.Fake:         nil,
+	types.Connector:    nil,
+	types.Delegation:   nil,
+	types.Nomad:        nil,
+	types.Consul:       nil,
+	types.Unstructured: nil,
+}
+
+// gatewayRouteRules returns the rules of a Gateway API route source, which
+// also reads the parent gateways and the namespaces they allow routes from.
+func gatewayRouteRules(routes string) []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"namespaces"}, Verbs: readVerbs},
+		{APIGroups: []string{"gateway.networking.k8s.io"}, Resources: []string{"gateways", routes}, Verbs: readVerbs},
+	}
+}
+
+// RBACRules returns the rules the given sources need, merged per API group and
+// resource so every resource appears once with the union of the verbs.
+func RBACRules(sources []string) ([]rbacv1.PolicyRule, error) {
+	var rules []rbacv1.PolicyRule
+	for _, name := range sources {
+		sourceRules, ok := rbacRules[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown source: %s", name)
+		}
+		rules = append(rules, sourceRules...)
+	}
+	return MergeRBACRules(rules), nil
+}
+
+// MergeRBACRules merges rules granting verbs on the same API group and
+// resource, returning them sorted by API group and resource.
+func MergeRBACRules(rules []rbacv1.PolicyRule) []rbacv1.PolicyRule {
+	type groupResource struct{ group, resource string }
+
+	verbs := map[groupResource][]string{}
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				key := groupResource{group, resource}
+				for _, verb := range rule.Verbs {
+					if !slices.Contains(verbs[key], verb) {
+						verbs[key] = append(verbs[key], verb)
+					}
+				}
+			}
+		}
+	}
+
+	keys := make([]groupResource, 0, len(verbs))
+	for key := range verbs {
+		slices.Sort(verbs[key])
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b groupResource) int {
+		if c := strings.Compare(a.group, b.group); c != 0 {
+			return c
+		}
+		return strings.Compare(a.resource, b.resource)
+	})
+
+	// resources of the same group sharing the exact verbs are folded into one rule
+	var merged []rbacv1.PolicyRule
+	for _, key := range keys {
+		v := verbs[key]
+		if n := len(merged); n > 0 && merged[n-1].APIGroups[0] == key.group && slices.Equal(merged[n-1].Verbs, v) {
+			merged[n-1].Resources = append(merged[n-1].Resources, key.resource)
+			continue
+		}
+		merged = append(merged, rbacv1.PolicyRule{APIGroups: []string{key.group}, Resources: []string{key.resource}, Verbs: v})
+	}
+	return merged
+}
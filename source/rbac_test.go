@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"sigs.k8s.io/external-dns/source/types"
+)
+
+func TestRBACRulesDeclaredForEverySource(t *testing.T) {
+	// the source types are read from their declarations, so that a new source cannot be missed
+	file, err := parser.ParseFile(token.NewFileSet(), filepath.Join("types", "types.go"), nil, 0)
+	require.NoError(t, err)
+	var names []types.Type
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			for _, value := range spec.(*ast.ValueSpec).Values {
+				lit, ok := value.(*ast.BasicLit)
+				require.True(t, ok)
+				name, err := strconv.Unquote(lit.Value)
+				require.NoError(t, err)
+				names = append(names, name)
+			}
+		}
+	}
+	require.Contains(t, names, types.Service)
+
+	for _, name := range names {
+		_, err := RBACRules([]string{name})
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestRBACRules(t *testing.T) {
+	rules, err := RBACRules([]string{types.Ingress, types.GatewayHttpRoute, types.GatewayGrpcRoute, types.Fake})
+	require.NoError(t, err)
+
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"namespaces"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"gateway.networking.k8s.io"}, Resources: []string{"gateways", "grpcroutes", "httproutes"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: []string{"get", "list", "watch"}},
+	}, rules)
+}
+
+func TestRBACRulesLoadBalancerServices(t *testing.T) {
+	for _, name := range []string{types.AmbassadorHost, types.ContourHTTPProxy} {
+		rules, err := RBACRules([]string{name})
+		require.NoError(t, err)
+		assert.Contains(t, rules, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: []string{"get", "list", "watch"}}, name)
+	}
+}
+
+func TestRBACRulesUnknownSource(t *testing.T) {
+	_, err := RBACRules([]string{"bogus"})
+	require.EqualError(t, err, "unknown source: bogus")
+}
+
+func TestMergeRBACRules(t *testing.T) {
+	rules := MergeRBACRules([]rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"list", "watch"}},
+		{APIGroups: []string{""}, Resources: []string{"pods", "nodes"}, Verbs: []string{"get", "watch", "list"}},
+		{APIGroups: []string{"zalando.org"}, Resources: []string{"routegroups/status"}, Verbs: []string{"update", "patch"}},
+	})
+
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"nodes", "pods"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"zalando.org"}, Resources: []string{"routegroups/status"}, Verbs: []string{"patch", "update"}},
+	}, rules)
+}
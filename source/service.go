@@ -99,7 +99,7 @@ func NewServiceSource(
 ) (Source, error) {
 	// Use shared informers to listen for add/update/delete of services/pods/nodes in the specified namespace.
 	// Set the resync period to 0 to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(config.Namespace))
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(config.Namespace), kubeinformers.WithTweakListOptions(informers.ListPageSize(config.KubeAPIListPageSize)))
 	serviceInformer := informerFactory.Core().V1().Services()
 
 	// Transform the slice into a map so it will be way much easier and fast to filter later
@@ -125,6 +125,7 @@ func NewServiceSource(
 
 	// Add default resource event handlers to properly initialize informer.
 	informers.MustAddEventHandler(serviceInformer.Informer(), informers.DefaultEventHandler())
+	informers.TrackWatch(serviceInformer.Informer(), "service/service")
 
 	var endpointSlicesInformer discoveryinformers.EndpointSliceInformer
 	var podInformer coreinformers.PodInformer
@@ -147,7 +148,9 @@ func NewServiceSource(
 		))
 
 		informers.MustAddEventHandler(endpointSlicesInformer.Informer(), informers.DefaultEventHandler())
+		informers.TrackWatch(endpointSlicesInformer.Informer(), "service/endpointslice")
 		informers.MustAddEventHandler(podInformer.Informer(), informers.DefaultEventHandler())
+		informers.TrackWatch(podInformer.Informer(), "service/pod")
 	}
 
 	var nodeInformer coreinformers.NodeInformer
@@ -159,6 +162,7 @@ func NewServiceSource(
 			informers.TransformRemoveStatusConditions(),
 		))
 		informers.MustAddEventHandler(nodeInformer.Informer(), informers.DefaultEventHandler())
+		informers.TrackWatch(nodeInformer.Informer(), "service/node")
 	}
 
 	informerFactory.Start(ctx.Done())
@@ -190,12 +194,15 @@ func NewServiceSource(
 }
 
 // Endpoints return endpoint objects for each service that should be processed.
-func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (sc *serviceSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	services := informers.ListIndexed[*v1.Service](sc.serviceInformer.Informer().GetIndexer())
 
 	endpoints := make([]*endpoint.Endpoint, 0, len(services))
 
 	for _, svc := range services {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		var err error
 
 		svcEndpoints := sc.endpoints(svc)
@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -40,6 +41,7 @@ import (
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
 	"sigs.k8s.io/external-dns/source/template"
 )
 
@@ -67,11 +69,12 @@ type routeGroupSource struct {
 	annotationFilter         labels.Selector
 	templateEngine           template.Engine
 	ignoreHostnameAnnotation bool
+	listPageSize             int
 }
 
 // for testing
 type routeGroupListClient interface {
-	getRouteGroupList(string) (*routeGroupList, error)
+	getRouteGroupList(context.Context, string) (*routeGroupList, error)
 }
 
 type routeGroupClient struct {
@@ -167,8 +170,8 @@ func (cli *routeGroupClient) getToken() string {
 	return cli.token
 }
 
-func (cli *routeGroupClient) getRouteGroupList(url string) (*routeGroupList, error) {
-	resp, err := cli.get(url)
+func (cli *routeGroupClient) getRouteGroupList(ctx context.Context, url string) (*routeGroupList, error) {
+	resp, err := cli.get(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -187,8 +190,8 @@ func (cli *routeGroupClient) getRouteGroupList(url string) (*routeGroupList, err
 	return &rgs, nil
 }
 
-func (cli *routeGroupClient) get(url string) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (cli *routeGroupClient) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -235,6 +238,7 @@ func NewRouteGroupSource(cfg *Config, token, tokenPath, apiServerURL string) (So
 		annotationFilter:         cfg.AnnotationFilter,
 		templateEngine:           cfg.TemplateEngine,
 		ignoreHostnameAnnotation: cfg.IgnoreHostnameAnnotation,
+		listPageSize:             cfg.KubeAPIListPageSize,
 	}, nil
 }
 
@@ -244,14 +248,14 @@ func (sc *routeGroupSource) AddEventHandler(_ context.Context, _ func()) {}
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all routeGroup resources on all namespaces.
 // Logic is ported from ingress without fqdnTemplate
-func (sc *routeGroupSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
-	rgList, err := sc.cli.getRouteGroupList(sc.apiEndpoint)
+func (sc *routeGroupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	items, err := sc.listRouteGroups(ctx)
 	if err != nil {
 		log.Errorf("Failed to get RouteGroup list: %v", err)
 		return nil, err
 	}
 
-	filtered := annotations.Filter(rgList.Items, sc.annotationFilter)
+	filtered := annotations.Filter(items, sc.annotationFilter)
 
 	endpoints := []*endpoint.Endpoint{}
 	for _, rg := range filtered {
@@ -282,6 +286,42 @@ func (sc *routeGroupSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint,
 	return endpoint.MergeEndpoints(endpoints), nil
 }
 
+// listRouteGroups lists the RouteGroups in pages of listPageSize items, following the
+// continue token of each page. A page size of zero lists them in a single request.
+func (sc *routeGroupSource) listRouteGroups(ctx context.Context) ([]*routeGroup, error) {
+	var items []*routeGroup
+	continueToken := ""
+	for {
+		rgList, err := sc.cli.getRouteGroupList(ctx, sc.listURL(continueToken))
+		if err != nil {
+			return nil, err
+		}
+		for _, rg := range rgList.Items {
+			informers.MigrateDeprecatedAnnotations("RouteGroup", rg)
+		}
+		items = append(items, rgList.Items...)
+		continueToken = rgList.Metadata.Continue
+		if sc.listPageSize <= 0 || continueToken == "" {
+			break
+		}
+	}
+	listedObjects.SetWithLabels(float64(len(items)), types.SkipperRouteGroup)
+	return items, nil
+}
+
+// listURL returns the list URL of the RouteGroup page starting at continueToken.
+func (sc *routeGroupSource) listURL(continueToken string) string {
+	if sc.listPageSize <= 0 {
+		return sc.apiEndpoint
+	}
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(sc.listPageSize))
+	if continueToken != "" {
+		query.Set("continue", continueToken)
+	}
+	return sc.apiEndpoint + "?" + query.Encode()
+}
+
 func (sc *routeGroupSource) endpointsFromTemplate(rg *routeGroup) ([]*endpoint.Endpoint, error) {
 	hostnames, err := sc.templateEngine.ExecFQDN(rg)
 	if err != nil {
@@ -370,6 +410,7 @@ type routeGroupList struct {
 type routeGroupListMetadata struct {
 	SelfLink        string `json:"selfLink"`
 	ResourceVersion string `json:"resourceVersion"`
+	Continue        string `json:"continue,omitempty"`
 }
 
 type routeGroup struct {
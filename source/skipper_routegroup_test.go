@@ -17,9 +17,13 @@ limitations under the License.
 package source
 
 import (
+	"context"
 	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"sigs.k8s.io/external-dns/internal/testutils"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -320,13 +324,53 @@ type fakeRouteGroupClient struct {
 	rg        *routeGroupList
 }
 
-func (f *fakeRouteGroupClient) getRouteGroupList(string) (*routeGroupList, error) {
+func (f *fakeRouteGroupClient) getRouteGroupList(context.Context, string) (*routeGroupList, error) {
 	if f.returnErr {
 		return nil, errors.New("Fake route group list error")
 	}
 	return f.rg, nil
 }
 
+type pagedRouteGroupClient struct {
+	pages map[string]*routeGroupList
+	urls  []string
+}
+
+func (f *pagedRouteGroupClient) getRouteGroupList(_ context.Context, url string) (*routeGroupList, error) {
+	f.urls = append(f.urls, url)
+	rgList, ok := f.pages[url]
+	if !ok {
+		return nil, errors.New("unexpected url " + url)
+	}
+	return rgList, nil
+}
+
+func TestRouteGroupsEndpointsPaginated(t *testing.T) {
+	lb := []routeGroupLoadBalancer{{Hostname: "lb.example.org"}}
+	endpointURL := "https://api.example.org/apis/zalando.org/v1/routegroups"
+	cli := &pagedRouteGroupClient{
+		pages: map[string]*routeGroupList{
+			endpointURL + "?limit=1": {
+				Metadata: routeGroupListMetadata{Continue: "next"},
+				Items:    []*routeGroup{createTestRouteGroup("namespace1", "rg1", nil, []string{"rg1.k8s.example"}, lb)},
+			},
+			endpointURL + "?continue=next&limit=1": {
+				Items: []*routeGroup{createTestRouteGroup("namespace1", "rg2", nil, []string{"rg2.k8s.example"}, lb)},
+			},
+		},
+	}
+	source := &routeGroupSource{cli: cli, apiEndpoint: endpointURL, listPageSize: 1}
+
+	got, err := source.Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, cli.urls, 2)
+	testutils.ValidateEndpoints(t, got, []*endpoint.Endpoint{
+		{DNSName: "rg1.k8s.example", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets([]string{"lb.example.org"})},
+		{DNSName: "rg2.k8s.example", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets([]string{"lb.example.org"})},
+	})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 2, listedObjects.Gauge, map[string]string{"source_type": types.SkipperRouteGroup})
+}
+
 func TestRouteGroupsEndpoints(t *testing.T) {
 	for _, tt := range []struct {
 		name        string
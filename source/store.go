@@ -20,17 +20,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
+	gotemplate "text/template"
 	"time"
 
-	openshift "github.com/openshift/client-go/route/clientset/versioned"
-	log "github.com/sirupsen/logrus"
 	istioclient "istio.io/client-go/pkg/clientset/versioned"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	kubeclient "sigs.k8s.io/external-dns/pkg/client"
@@ -58,9 +55,13 @@ var ErrSourceNotFound = errors.New("source not found")
 // The config is created from externaldns.Config via NewSourceConfig() which handles
 // type conversions and validation.
 type Config struct {
-	Namespace                      string
-	AnnotationFilter               labels.Selector
-	LabelFilter                    labels.Selector
+	Namespace string
+	// ExcludeNamespaces are glob patterns of the namespaces whose resources are ignored
+	ExcludeNamespaces []string
+	AnnotationFilter  labels.Selector
+	LabelFilter       labels.Selector
+	// TargetServiceSelector restricts the services the targets of Istio gateways are read from
+	TargetServiceSelector          labels.Selector
 	IngressClassNames              []string
 	TemplateEngine                 template.Engine
 	IgnoreHostnameAnnotation       bool
@@ -79,15 +80,30 @@ type Config struct {
 	PublishHostIP                  bool
 	AlwaysPublishNotReadyAddresses bool
 	ConnectorServer                string
+	DelegationConfig               string
+	NomadAddress                   string
+	NomadToken                     string
+	NomadNamespace                 string
+	NomadTags                      []string
+	NomadPollInterval              time.Duration
+	ConsulAddress                  string
+	ConsulToken                    string
+	ConsulDatacenter               string
+	ConsulTags                     []string
 	CRDSourceAPIVersion            string
 	CRDSourceKind                  string
+	CRDDefaultTTL                  time.Duration
+	EnableDNSEndpointFinalizer     bool
+	Diff                           bool
 	KubeConfig                     string
 	APIServerURL                   string
 	ServiceTypeFilter              []string
 	GlooNamespaces                 []string
+	ContourEnvoyService            string
 	SkipperRouteGroupVersion       string
 	KubeAPIRequestTimeout          time.Duration
 	KubeAPIQPS                     int
+	KubeAPIListPageSize            int
 	KubeAPIBurst                   int
 	DefaultTargets                 []string
 	ForceDefaultTargets            bool
@@ -98,6 +114,7 @@ type Config struct {
 	TraefikDisableNew              bool
 	ExcludeUnschedulable           bool
 	ExposeInternalIPv6             bool
+	NodeZoneTemplateEngine         template.Engine
 	ExcludeTargetNets              []string
 	TargetNetFilter                []string
 	NAT64Networks                  []string
@@ -106,6 +123,24 @@ type Config struct {
 	PreferAlias                    bool
 	PTRSupported                   bool
 	CreatePTR                      bool
+	MergeEndpoints                 bool
+	SourceWrapperOrder             []string
+	DisabledSourceWrappers         []string
+	View                           string
+	ClusterName                    string
+	RecordPrefix                   string
+	RecordSuffix                   string
+	RecordSharedParent             bool
+	NamespaceCollisionPolicy       string
+	TargetFromKinds                []string
+	CloudflareNamespaceDefaults    bool
+	HealthChecks                   bool
+	HealthCheckTimeout             time.Duration
+	HealthCheckFailureThreshold    int
+	HealthCheckSuccessThreshold    int
+	// SourceTimeouts maps a source name to the time its Endpoints call may take;
+	// the timeout under the empty name applies to sources without their own.
+	SourceTimeouts map[string]time.Duration
 
 	sources []string
 
@@ -129,15 +164,34 @@ func WithClientGenerator(gen ClientGenerator) OverrideConfigOption {
 func NewSourceConfig(cfg *externaldns.Config, opts ...OverrideConfigOption) (*Config, error) {
 	// errors are explicitly ignored because the filters are already validated in validation.ValidateConfig
 	labelSelector, _ := labels.Parse(cfg.LabelFilter)
+	targetServiceSelector, _ := labels.Parse(cfg.TargetServiceSelector)
 	annotationSelector, _ := annotations.ParseFilter(cfg.AnnotationFilter)
 	tmpls, err := template.NewEngine(cfg.FQDNTemplate, cfg.TargetTemplate, cfg.FQDNTargetTemplate, cfg.CombineFQDNAndAnnotation)
 	if err != nil {
 		return nil, err
 	}
+	nodeZoneTmpl, err := template.NewFQDNEngine([]string{cfg.NodeZoneFQDNTemplate}, "--node-zone-fqdn-template")
+	if err != nil {
+		return nil, err
+	}
+	sourceTimeouts, err := cfg.SourceTimeoutsByName()
+	if err != nil {
+		return nil, err
+	}
+	recordPrefix, err := renderClusterTemplate("--record-prefix", cfg.RecordPrefix, cfg.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	recordSuffix, err := renderClusterTemplate("--record-suffix", cfg.RecordSuffix, cfg.ClusterName)
+	if err != nil {
+		return nil, err
+	}
 	c := &Config{
 		Namespace:                      cfg.Namespace,
+		ExcludeNamespaces:              cfg.ExcludedNamespacePatterns(),
 		AnnotationFilter:               annotationSelector,
 		LabelFilter:                    labelSelector,
+		TargetServiceSelector:          targetServiceSelector,
 		IngressClassNames:              cfg.IngressClassNames,
 		IgnoreHostnameAnnotation:       cfg.IgnoreHostnameAnnotation,
 		IgnoreNonHostNetworkPods:       cfg.IgnoreNonHostNetworkPods,
@@ -155,14 +209,29 @@ func NewSourceConfig(cfg *externaldns.Config, opts ...OverrideConfigOption) (*Co
 		Provider:                       cfg.Provider,
 		AlwaysPublishNotReadyAddresses: cfg.AlwaysPublishNotReadyAddresses,
 		ConnectorServer:                cfg.ConnectorSourceServer,
+		DelegationConfig:               cfg.DelegationConfig,
+		NomadAddress:                   cfg.NomadAddress,
+		NomadToken:                     cfg.NomadToken,
+		NomadNamespace:                 cfg.NomadNamespace,
+		NomadTags:                      cfg.NomadTags,
+		NomadPollInterval:              cfg.NomadPollInterval,
+		ConsulAddress:                  cfg.ConsulAddress,
+		ConsulToken:                    cfg.ConsulToken,
+		ConsulDatacenter:               cfg.ConsulDatacenter,
+		ConsulTags:                     cfg.ConsulTags,
 		CRDSourceAPIVersion:            cfg.CRDSourceAPIVersion,
 		CRDSourceKind:                  cfg.CRDSourceKind,
+		CRDDefaultTTL:                  cfg.CRDDefaultTTL,
+		EnableDNSEndpointFinalizer:     cfg.EnableDNSEndpointFinalizer,
+		Diff:                           cfg.Diff,
 		KubeConfig:                     cfg.KubeConfig,
 		APIServerURL:                   cfg.APIServerURL,
 		ServiceTypeFilter:              cfg.ServiceTypeFilter,
 		GlooNamespaces:                 cfg.GlooNamespaces,
+		ContourEnvoyService:            cfg.ContourEnvoyService,
 		SkipperRouteGroupVersion:       cfg.SkipperRouteGroupVersion,
 		KubeAPIRequestTimeout:          cfg.KubeAPIRequestTimeout,
+		KubeAPIListPageSize:            cfg.KubeAPIListPageSize,
 		KubeAPIQPS:                     cfg.KubeAPIQPS,
 		KubeAPIBurst:                   cfg.KubeAPIBurst,
 		DefaultTargets:                 cfg.DefaultTargets,
@@ -174,6 +243,7 @@ func NewSourceConfig(cfg *externaldns.Config, opts ...OverrideConfigOption) (*Co
 		TraefikDisableNew:              cfg.TraefikDisableNew,
 		ExcludeUnschedulable:           cfg.ExcludeUnschedulable,
 		ExposeInternalIPv6:             cfg.ExposeInternalIPV6,
+		NodeZoneTemplateEngine:         nodeZoneTmpl,
 		ExcludeTargetNets:              cfg.ExcludeTargetNets,
 		TargetNetFilter:                cfg.TargetNetFilter,
 		NAT64Networks:                  cfg.NAT64Networks,
@@ -183,6 +253,22 @@ func NewSourceConfig(cfg *externaldns.Config, opts ...OverrideConfigOption) (*Co
 		PreferAlias:                    cfg.PreferAlias,
 		PTRSupported:                   cfg.IsPTRSupported(),
 		CreatePTR:                      cfg.CreatePTR,
+		MergeEndpoints:                 cfg.MergeEndpoints,
+		SourceWrapperOrder:             cfg.SourceWrapperOrder,
+		DisabledSourceWrappers:         cfg.DisabledSourceWrappers,
+		View:                           cfg.View,
+		ClusterName:                    cfg.ClusterName,
+		RecordPrefix:                   recordPrefix,
+		RecordSuffix:                   recordSuffix,
+		RecordSharedParent:             cfg.RecordSharedParent,
+		NamespaceCollisionPolicy:       cfg.NamespaceCollisionPolicy,
+		TargetFromKinds:                cfg.TargetFromKinds,
+		CloudflareNamespaceDefaults:    cfg.CloudflareNamespaceDefaults,
+		HealthChecks:                   cfg.HealthChecks,
+		HealthCheckTimeout:             cfg.HealthCheckTimeout,
+		HealthCheckFailureThreshold:    cfg.HealthCheckFailureThreshold,
+		HealthCheckSuccessThreshold:    cfg.HealthCheckSuccessThreshold,
+		SourceTimeouts:                 sourceTimeouts,
 		sources:                        cfg.Sources,
 	}
 	for _, opt := range opts {
@@ -191,6 +277,22 @@ func NewSourceConfig(cfg *externaldns.Config, opts ...OverrideConfigOption) (*Co
 	return c, nil
 }
 
+// renderClusterTemplate renders a --record-prefix or --record-suffix template with the cluster name.
+func renderClusterTemplate(flag, text, clusterName string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tmpl, err := gotemplate.New(flag).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse %s template: %w", flag, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, struct{ ClusterName string }{ClusterName: clusterName}); err != nil {
+		return "", fmt.Errorf("render %s template: %w", flag, err)
+	}
+	return b.String(), nil
+}
+
 // ClientGenerator returns the ClientGenerator for this Config.
 // If one was not provided via WithClientGenerator, a SingletonClientGenerator is
 // lazily created from the Config's connection settings.
@@ -225,29 +327,25 @@ func (cfg *Config) ClientGenerator() ClientGenerator {
 // It uses the singleton pattern to ensure only one instance of each client is created
 // and reused across multiple source instances.
 //
-// Supported Client Types:
-// - KubeClient: Standard Kubernetes API client
-// - GatewayClient: Gateway API client for Gateway resources
-// - IstioClient: Istio service mesh client
-// - DynamicKubernetesClient: Dynamic client for custom resources
-// - OpenShiftClient: OpenShift-specific client for Route resources
-// - RESTConfig: Instrumented REST config for creating custom clients
+// Clients are requested by name and created by the factory registered under that
+// name with RegisterClient, so that a new client type does not change this interface.
+// Client returns them typed, KubeClient, GatewayClient, IstioClient,
+// DynamicKubernetesClient and OpenShiftClient the built-in ones.
 //
 // The singleton behavior is implemented in SingletonClientGenerator which uses
-// sync.Once to guarantee single initialization of each client type.
+// sync.Once to guarantee single initialization of each client.
 type ClientGenerator interface {
-	KubeClient() (kubernetes.Interface, error)
-	GatewayClient() (gateway.Interface, error)
-	IstioClient() (istioclient.Interface, error)
-	DynamicKubernetesClient() (dynamic.Interface, error)
-	OpenShiftClient() (openshift.Interface, error)
+	// Client returns the client named name, ErrClientNotRegistered when no factory
+	// was registered under that name.
+	Client(name string) (any, error)
+	// RESTConfig returns the instrumented REST config for creating custom clients.
 	RESTConfig() (*rest.Config, error)
 }
 
 // SingletonClientGenerator stores provider clients and guarantees that only one instance of each client
 // will be generated throughout the application lifecycle.
 //
-// Thread Safety: Uses sync.Once for each client type to ensure thread-safe initialization.
+// Thread Safety: Uses sync.Once for each client to ensure thread-safe initialization.
 // This is important because external-dns may create multiple sources concurrently.
 //
 // Memory Efficiency: Prevents creating multiple instances of expensive client objects
@@ -256,42 +354,51 @@ type ClientGenerator interface {
 // Configuration: Clients are configured using KubeConfig, APIServerURL, RequestTimeout,
 // QPS, and Burst which are set during SingletonClientGenerator initialization.
 type SingletonClientGenerator struct {
-	KubeConfig         string
-	APIServerURL       string
-	RequestTimeout     time.Duration
-	QPS                int
-	Burst              int
-	restConfig         *rest.Config
-	restConfigErr      error
-	kubeClient         kubernetes.Interface
-	kubeClientErr      error
-	gatewayClient      gateway.Interface
-	gatewayClientErr   error
-	istioClient        *istioclient.Clientset
-	istioClientErr     error
-	dynKubeClient      dynamic.Interface
-	dynKubeClientErr   error
-	openshiftClient    openshift.Interface
-	openshiftClientErr error
-	restConfigOnce     sync.Once
-	kubeOnce           sync.Once
-	gatewayOnce        sync.Once
-	istioOnce          sync.Once
-	dynCliOnce         sync.Once
-	openshiftOnce      sync.Once
-}
-
-// KubeClient generates a kube client if it was not created before
-func (p *SingletonClientGenerator) KubeClient() (kubernetes.Interface, error) {
-	p.kubeOnce.Do(func() {
+	KubeConfig     string
+	APIServerURL   string
+	RequestTimeout time.Duration
+	QPS            int
+	Burst          int
+	restConfig     *rest.Config
+	restConfigErr  error
+	restConfigOnce sync.Once
+	mu             sync.Mutex
+	clients        map[string]*singletonClient
+}
+
+// singletonClient holds a client created on first request.
+type singletonClient struct {
+	once   sync.Once
+	client any
+	err    error
+}
+
+// Client generates the client named name if it was not created before.
+func (p *SingletonClientGenerator) Client(name string) (any, error) {
+	factory, ok := clientFactory(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrClientNotRegistered, name)
+	}
+	p.mu.Lock()
+	if p.clients == nil {
+		p.clients = map[string]*singletonClient{}
+	}
+	c, ok := p.clients[name]
+	if !ok {
+		c = &singletonClient{}
+		p.clients[name] = c
+	}
+	p.mu.Unlock()
+
+	c.once.Do(func() {
 		var config *rest.Config
-		config, p.kubeClientErr = p.RESTConfig()
-		if p.kubeClientErr != nil {
+		config, c.err = p.RESTConfig()
+		if c.err != nil {
 			return
 		}
-		p.kubeClient, p.kubeClientErr = kubeclient.NewKubeClient(config)
+		c.client, c.err = factory(config)
 	})
-	return p.kubeClient, p.kubeClientErr
+	return c.client, c.err
 }
 
 // RESTConfig generates an instrumented REST config if it was not created before.
@@ -304,70 +411,6 @@ func (p *SingletonClientGenerator) RESTConfig() (*rest.Config, error) {
 	return p.restConfig, p.restConfigErr
 }
 
-// GatewayClient generates a gateway client if it was not created before
-func (p *SingletonClientGenerator) GatewayClient() (gateway.Interface, error) {
-	p.gatewayOnce.Do(func() {
-		var config *rest.Config
-		config, p.gatewayClientErr = p.RESTConfig()
-		if p.gatewayClientErr != nil {
-			return
-		}
-		p.gatewayClient, p.gatewayClientErr = gateway.NewForConfig(config)
-		if p.gatewayClientErr != nil {
-			return
-		}
-		log.Infof("Created GatewayAPI client %s", config.Host)
-	})
-	return p.gatewayClient, p.gatewayClientErr
-}
-
-// IstioClient generates an istio go client if it was not created before
-func (p *SingletonClientGenerator) IstioClient() (istioclient.Interface, error) {
-	p.istioOnce.Do(func() {
-		var config *rest.Config
-		config, p.istioClientErr = p.RESTConfig()
-		if p.istioClientErr != nil {
-			return
-		}
-		p.istioClient, p.istioClientErr = NewIstioClient(config)
-	})
-	return p.istioClient, p.istioClientErr
-}
-
-// DynamicKubernetesClient generates a dynamic client if it was not created before
-func (p *SingletonClientGenerator) DynamicKubernetesClient() (dynamic.Interface, error) {
-	p.dynCliOnce.Do(func() {
-		var config *rest.Config
-		config, p.dynKubeClientErr = p.RESTConfig()
-		if p.dynKubeClientErr != nil {
-			return
-		}
-		p.dynKubeClient, p.dynKubeClientErr = dynamic.NewForConfig(config)
-		if p.dynKubeClientErr != nil {
-			return
-		}
-		log.Infof("Created Dynamic Kubernetes client %s", config.Host)
-	})
-	return p.dynKubeClient, p.dynKubeClientErr
-}
-
-// OpenShiftClient generates an openshift client if it was not created before
-func (p *SingletonClientGenerator) OpenShiftClient() (openshift.Interface, error) {
-	p.openshiftOnce.Do(func() {
-		var config *rest.Config
-		config, p.openshiftClientErr = p.RESTConfig()
-		if p.openshiftClientErr != nil {
-			return
-		}
-		p.openshiftClient, p.openshiftClientErr = openshift.NewForConfig(config)
-		if p.openshiftClientErr != nil {
-			return
-		}
-		log.Infof("Created OpenShift client %s", config.Host)
-	})
-	return p.openshiftClient, p.openshiftClientErr
-}
-
 // ByNames returns multiple Sources given multiple names.
 func ByNames(ctx context.Context, cfg *Config, p ClientGenerator) ([]Source, error) {
 	sources := make([]Source, 0, len(cfg.sources))
@@ -382,6 +425,11 @@ func ByNames(ctx context.Context, cfg *Config, p ClientGenerator) ([]Source, err
 	return sources, nil
 }
 
+// SourceNames returns the names of the configured sources, in the order ByNames builds them.
+func (cfg *Config) SourceNames() []string {
+	return cfg.sources
+}
+
 // BuildWithConfig creates a Source implementation using the factory pattern.
 // This function serves as the central registry for all available source types.
 //
@@ -409,6 +457,9 @@ func ByNames(ctx context.Context, cfg *Config, p ClientGenerator) ([]Source, err
 // - "f5-*": F5 resources (virtualserver, transportserver)
 // - "fake": Fake source for testing
 // - "connector": Connector source for external systems
+// - "delegation": NS/DS records delegating child zones
+// - "nomad": HashiCorp Nomad services
+// - "consul": HashiCorp Consul catalog services
 //
 // Design Note: Gateway API sources use a different pattern (direct constructor calls)
 // because they have simpler initialization requirements.
@@ -450,6 +501,12 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		return NewFakeSource(cfg)
 	case types.Connector:
 		return NewConnectorSource(cfg.ConnectorServer)
+	case types.Delegation:
+		return NewDelegationSource(cfg.DelegationConfig)
+	case types.Nomad:
+		return NewNomadSource(cfg)
+	case types.Consul:
+		return NewConsulSource(cfg)
 	case types.CRD:
 		return buildCRDSource(ctx, p, cfg)
 	case types.SkipperRouteGroup:
@@ -497,7 +554,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 // buildNodeSource creates a Node source for exposing node information as DNS records.
 // Follows standard pattern: ctx, client, annotationFilter, fqdnTemplate, labelFilter, ...other
 func buildNodeSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	client, err := p.KubeClient()
+	client, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -507,7 +564,7 @@ func buildNodeSource(ctx context.Context, p ClientGenerator, cfg *Config) (Sourc
 // buildServiceSource creates a Service source for exposing Kubernetes services as DNS records.
 // Follows standard pattern: ctx, client, namespace, annotationFilter, fqdnTemplate, ...other
 func buildServiceSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	client, err := p.KubeClient()
+	client, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -517,7 +574,7 @@ func buildServiceSource(ctx context.Context, p ClientGenerator, cfg *Config) (So
 // buildIngressSource creates an Ingress source for exposing Kubernetes ingresses as DNS records.
 // Follows standard pattern: ctx, client, namespace, annotationFilter, fqdnTemplate, ...other
 func buildIngressSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	client, err := p.KubeClient()
+	client, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -527,7 +584,7 @@ func buildIngressSource(ctx context.Context, p ClientGenerator, cfg *Config) (So
 // buildPodSource creates a Pod source for exposing Kubernetes pods as DNS records.
 // Follows standard pattern: ctx, client, namespace, ...other (no annotation/label filters)
 func buildPodSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	client, err := p.KubeClient()
+	client, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -537,11 +594,11 @@ func buildPodSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source
 // buildIstioGatewaySource creates an Istio Gateway source for exposing Istio gateways as DNS records.
 // Requires both Kubernetes and Istio clients. Follows standard parameter pattern.
 func buildIstioGatewaySource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	kubernetesClient, err := p.KubeClient()
+	kubernetesClient, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
-	istioClient, err := p.IstioClient()
+	istioClient, err := IstioClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -551,11 +608,11 @@ func buildIstioGatewaySource(ctx context.Context, p ClientGenerator, cfg *Config
 // buildIstioVirtualServiceSource creates an Istio VirtualService source for exposing virtual services as DNS records.
 // Requires both Kubernetes and Istio clients. Follows standard parameter pattern.
 func buildIstioVirtualServiceSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	kubernetesClient, err := p.KubeClient()
+	kubernetesClient, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
-	istioClient, err := p.IstioClient()
+	istioClient, err := IstioClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -563,11 +620,11 @@ func buildIstioVirtualServiceSource(ctx context.Context, p ClientGenerator, cfg
 }
 
 func buildAmbassadorHostSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	kubernetesClient, err := p.KubeClient()
+	kubernetesClient, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
-	dynamicClient, err := p.DynamicKubernetesClient()
+	dynamicClient, err := DynamicKubernetesClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -575,22 +632,26 @@ func buildAmbassadorHostSource(ctx context.Context, p ClientGenerator, cfg *Conf
 }
 
 func buildContourHTTPProxySource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	dynamicClient, err := p.DynamicKubernetesClient()
+	kubernetesClient, err := KubeClient(p)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := DynamicKubernetesClient(p)
 	if err != nil {
 		return nil, err
 	}
-	return NewContourHTTPProxySource(ctx, dynamicClient, cfg)
+	return NewContourHTTPProxySource(ctx, dynamicClient, kubernetesClient, cfg)
 }
 
 // buildGlooProxySource creates a Gloo source for exposing Gloo proxies as DNS records.
 // Requires both dynamic and standard Kubernetes clients.
 // Note: Does not accept context parameter in constructor (legacy design).
 func buildGlooProxySource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	kubernetesClient, err := p.KubeClient()
+	kubernetesClient, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
-	dynamicClient, err := p.DynamicKubernetesClient()
+	dynamicClient, err := DynamicKubernetesClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -598,11 +659,11 @@ func buildGlooProxySource(ctx context.Context, p ClientGenerator, cfg *Config) (
 }
 
 func buildTraefikProxySource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	kubernetesClient, err := p.KubeClient()
+	kubernetesClient, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
-	dynamicClient, err := p.DynamicKubernetesClient()
+	dynamicClient, err := DynamicKubernetesClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -610,7 +671,7 @@ func buildTraefikProxySource(ctx context.Context, p ClientGenerator, cfg *Config
 }
 
 func buildOpenShiftRouteSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	ocpClient, err := p.OpenShiftClient()
+	ocpClient, err := OpenShiftClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -643,11 +704,11 @@ func buildSkipperRouteGroupSource(_ context.Context, p ClientGenerator, cfg *Con
 }
 
 func buildKongTCPIngressSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	kubernetesClient, err := p.KubeClient()
+	kubernetesClient, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
-	dynamicClient, err := p.DynamicKubernetesClient()
+	dynamicClient, err := DynamicKubernetesClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -655,11 +716,11 @@ func buildKongTCPIngressSource(ctx context.Context, p ClientGenerator, cfg *Conf
 }
 
 func buildF5VirtualServerSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	kubernetesClient, err := p.KubeClient()
+	kubernetesClient, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
-	dynamicClient, err := p.DynamicKubernetesClient()
+	dynamicClient, err := DynamicKubernetesClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -667,11 +728,11 @@ func buildF5VirtualServerSource(ctx context.Context, p ClientGenerator, cfg *Con
 }
 
 func buildF5TransportServerSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	kubernetesClient, err := p.KubeClient()
+	kubernetesClient, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
-	dynamicClient, err := p.DynamicKubernetesClient()
+	dynamicClient, err := DynamicKubernetesClient(p)
 	if err != nil {
 		return nil, err
 	}
@@ -679,11 +740,11 @@ func buildF5TransportServerSource(ctx context.Context, p ClientGenerator, cfg *C
 }
 
 func buildUnstructuredSource(ctx context.Context, p ClientGenerator, cfg *Config) (Source, error) {
-	kubeClient, err := p.KubeClient()
+	kubeClient, err := KubeClient(p)
 	if err != nil {
 		return nil, err
 	}
-	dynamicClient, err := p.DynamicKubernetesClient()
+	dynamicClient, err := DynamicKubernetesClient(p)
 	if err != nil {
 		return nil, err
 	}
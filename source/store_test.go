@@ -43,9 +43,9 @@ type ByNamesTestSuite struct {
 
 func (suite *ByNamesTestSuite) TestAllInitialized() {
 	mockClientGenerator := new(testutils.MockClientGenerator)
-	mockClientGenerator.On("KubeClient").Return(fakeKube.NewSimpleClientset(), nil)
-	mockClientGenerator.On("IstioClient").Return(istiofake.NewSimpleClientset(), nil)
-	mockClientGenerator.On("DynamicKubernetesClient").Return(fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+	mockClientGenerator.On("Client", KubeClientName).Return(fakeKube.NewSimpleClientset(), nil)
+	mockClientGenerator.On("Client", IstioClientName).Return(istiofake.NewSimpleClientset(), nil)
+	mockClientGenerator.On("Client", DynamicKubernetesClientName).Return(fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
 		map[schema.GroupVersionResource]string{
 			{
 				Group:    "projectcontour.io",
@@ -117,19 +117,19 @@ func (suite *ByNamesTestSuite) TestAllInitialized() {
 
 func (suite *ByNamesTestSuite) TestOnlyFake() {
 	mockClientGenerator := new(testutils.MockClientGenerator)
-	mockClientGenerator.On("KubeClient").Return(fakeKube.NewClientset(), nil)
+	mockClientGenerator.On("Client", KubeClientName).Return(fakeKube.NewClientset(), nil)
 
 	sources, err := ByNames(context.TODO(), &Config{
 		sources: []string{types.Fake},
 	}, mockClientGenerator)
 	suite.NoError(err, "should not generate errors")
 	suite.Len(sources, 1, "should generate fake source")
-	suite.Nil(mockClientGenerator.KubeClientValue, "client should not be created")
+	suite.NotContains(mockClientGenerator.Clients, KubeClientName, "client should not be created")
 }
 
 func (suite *ByNamesTestSuite) TestSourceNotFound() {
 	mockClientGenerator := new(testutils.MockClientGenerator)
-	mockClientGenerator.On("KubeClient").Return(fakeKube.NewClientset(), nil)
+	mockClientGenerator.On("Client", KubeClientName).Return(fakeKube.NewClientset(), nil)
 	sources, err := ByNames(context.TODO(), &Config{
 		sources: []string{"foo"},
 	}, mockClientGenerator)
@@ -139,7 +139,7 @@ func (suite *ByNamesTestSuite) TestSourceNotFound() {
 
 func (suite *ByNamesTestSuite) TestKubeClientFails() {
 	mockClientGenerator := new(testutils.MockClientGenerator)
-	mockClientGenerator.On("KubeClient").Return(nil, errors.New("foo"))
+	mockClientGenerator.On("Client", KubeClientName).Return(nil, errors.New("foo"))
 	mockClientGenerator.On("RESTConfig").Return(nil, errors.New("foo"))
 
 	sourceUnderTest := []string{
@@ -158,9 +158,9 @@ func (suite *ByNamesTestSuite) TestKubeClientFails() {
 
 func (suite *ByNamesTestSuite) TestIstioClientFails() {
 	mockClientGenerator := new(testutils.MockClientGenerator)
-	mockClientGenerator.On("KubeClient").Return(fakeKube.NewSimpleClientset(), nil)
-	mockClientGenerator.On("IstioClient").Return(nil, errors.New("foo"))
-	mockClientGenerator.On("DynamicKubernetesClient").Return(nil, errors.New("foo"))
+	mockClientGenerator.On("Client", KubeClientName).Return(fakeKube.NewSimpleClientset(), nil)
+	mockClientGenerator.On("Client", IstioClientName).Return(nil, errors.New("foo"))
+	mockClientGenerator.On("Client", DynamicKubernetesClientName).Return(nil, errors.New("foo"))
 
 	sourcesDependentOnIstioClient := []string{types.IstioGateway, types.IstioVirtualService}
 
@@ -174,9 +174,9 @@ func (suite *ByNamesTestSuite) TestIstioClientFails() {
 
 func (suite *ByNamesTestSuite) TestDynamicKubernetesClientFails() {
 	mockClientGenerator := new(testutils.MockClientGenerator)
-	mockClientGenerator.On("KubeClient").Return(fakeKube.NewClientset(), nil)
-	mockClientGenerator.On("IstioClient").Return(istiofake.NewSimpleClientset(), nil)
-	mockClientGenerator.On("DynamicKubernetesClient").Return(nil, errors.New("foo"))
+	mockClientGenerator.On("Client", KubeClientName).Return(fakeKube.NewClientset(), nil)
+	mockClientGenerator.On("Client", IstioClientName).Return(istiofake.NewSimpleClientset(), nil)
+	mockClientGenerator.On("Client", DynamicKubernetesClientName).Return(nil, errors.New("foo"))
 
 	sourcesDependentOnDynamicKubernetesClient := []string{
 		types.AmbassadorHost, types.ContourHTTPProxy, types.GlooProxy, types.TraefikProxy,
@@ -334,11 +334,11 @@ func TestSingletonClientGenerator_ErrorPersistence(t *testing.T) {
 		call func() (any, error)
 	}{
 		{"RESTConfig", func() (any, error) { return gen.RESTConfig() }},
-		{"KubeClient", func() (any, error) { return gen.KubeClient() }},
-		{"GatewayClient", func() (any, error) { return gen.GatewayClient() }},
-		{"IstioClient", func() (any, error) { return gen.IstioClient() }},
-		{"DynamicKubernetesClient", func() (any, error) { return gen.DynamicKubernetesClient() }},
-		{"OpenShiftClient", func() (any, error) { return gen.OpenShiftClient() }},
+		{"KubeClient", func() (any, error) { return KubeClient(gen) }},
+		{"GatewayClient", func() (any, error) { return GatewayClient(gen) }},
+		{"IstioClient", func() (any, error) { return IstioClient(gen) }},
+		{"DynamicKubernetesClient", func() (any, error) { return DynamicKubernetesClient(gen) }},
+		{"OpenShiftClient", func() (any, error) { return OpenShiftClient(gen) }},
 	}
 
 	for _, m := range methods {
@@ -479,3 +479,23 @@ func TestKubeAPIRateLimitPropagation(t *testing.T) {
 		assert.Equal(t, 30, scg.Burst)
 	})
 }
+
+func TestNewSourceConfigClusterRecords(t *testing.T) {
+	got, err := NewSourceConfig(&externaldns.Config{
+		ClusterName:        "eu",
+		RecordPrefix:       "{{ .ClusterName }}-",
+		RecordSuffix:       ".{{ .ClusterName }}",
+		RecordSharedParent: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "eu", got.ClusterName)
+	assert.Equal(t, "eu-", got.RecordPrefix)
+	assert.Equal(t, ".eu", got.RecordSuffix)
+	assert.True(t, got.RecordSharedParent)
+
+	_, err = NewSourceConfig(&externaldns.Config{ClusterName: "eu", RecordSuffix: ".{{ .ClusterName"})
+	require.ErrorContains(t, err, "--record-suffix")
+
+	_, err = NewSourceConfig(&externaldns.Config{ClusterName: "eu", RecordPrefix: "{{ .Region }}."})
+	require.ErrorContains(t, err, "--record-prefix")
+}
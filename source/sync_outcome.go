@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// SyncOutcome is the result of a synchronization of the controller.
+type SyncOutcome string
+
+const (
+	// SyncOutcomeNoop is reported when the records already match the sources.
+	SyncOutcomeNoop SyncOutcome = "noop"
+	// SyncOutcomeApplied is reported when all planned changes were applied.
+	SyncOutcomeApplied SyncOutcome = "applied"
+	// SyncOutcomePartial is reported when some chunks of the changes were applied and others failed.
+	SyncOutcomePartial SyncOutcome = "partial"
+	// SyncOutcomeFailed is reported when the synchronization failed before or while applying the changes.
+	SyncOutcomeFailed SyncOutcome = "failed"
+	// SyncOutcomeSkippedDueToCache is reported when no changes were planned against records
+	// served from a provider cache, so the records were not checked against the provider.
+	SyncOutcomeSkippedDueToCache SyncOutcome = "skipped-due-to-cache"
+	// SyncOutcomeSkipped is reported when the planned changes were all held back, e.g. outside of the change window.
+	SyncOutcomeSkipped SyncOutcome = "skipped"
+	// SyncOutcomeSuspended is reported when the planned changes were not applied because the synchronizations are suspended.
+	SyncOutcomeSuspended SyncOutcome = "suspended"
+)
+
+// SyncOutcomeNotifier calls the functions registered with OnSyncOutcome during a
+// synchronization once its outcome is known. A synchronization failing before the
+// sources registered, e.g. when the registry records cannot be read, is reported to
+// the functions registered during the previous one.
+type SyncOutcomeNotifier struct {
+	mu       sync.Mutex
+	fns      []func(context.Context, SyncOutcome)
+	previous []func(context.Context, SyncOutcome)
+	// changes counts the changes of the synchronization per resource label value
+	changes map[string]ResourceChanges
+}
+
+// ResourceChanges counts the changes to the records of a resource in a synchronization.
+type ResourceChanges struct {
+	// Applied is the number of changes applied to the provider.
+	Applied int
+	// Pending is the number of changes that failed to be applied.
+	Pending int
+}
+
+type syncOutcomeNotifierKey struct{}
+
+type resourceChangesKey struct{}
+
+// ContextWithSyncOutcomeNotifier returns a copy of ctx carrying n, so that sources can
+// register for the outcome of the synchronization with OnSyncOutcome.
+func ContextWithSyncOutcomeNotifier(ctx context.Context, n *SyncOutcomeNotifier) context.Context {
+	return context.WithValue(ctx, syncOutcomeNotifierKey{}, n)
+}
+
+// OnSyncOutcome registers fn to be called with the outcome of the synchronization ctx
+// belongs to. It is a no-op when ctx carries no notifier.
+func OnSyncOutcome(ctx context.Context, fn func(context.Context, SyncOutcome)) {
+	n, ok := ctx.Value(syncOutcomeNotifierKey{}).(*SyncOutcomeNotifier)
+	if !ok {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.fns = append(n.fns, fn)
+}
+
+// CountChanges counts the endpoints as changes applied, or pending when applied is
+// false, for each resource labelled on them. The counts are reported with the
+// outcome of the synchronization ctx belongs to. It is a no-op when ctx carries no
+// notifier.
+func CountChanges(ctx context.Context, applied bool, endpoints ...[]*endpoint.Endpoint) {
+	n, ok := ctx.Value(syncOutcomeNotifierKey{}).(*SyncOutcomeNotifier)
+	if !ok {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.changes == nil {
+		n.changes = map[string]ResourceChanges{}
+	}
+	for _, eps := range endpoints {
+		for _, ep := range eps {
+			for _, resource := range ep.Labels.Resources() {
+				changes := n.changes[resource]
+				if applied {
+					changes.Applied++
+				} else {
+					changes.Pending++
+				}
+				n.changes[resource] = changes
+			}
+		}
+	}
+}
+
+// ResourceChangesFromContext returns the changes counted for resource, a value of
+// the resource label like "crd/default/my-endpoint", in the synchronization whose
+// outcome is reported to a function registered with OnSyncOutcome.
+func ResourceChangesFromContext(ctx context.Context, resource string) ResourceChanges {
+	changes, _ := ctx.Value(resourceChangesKey{}).(map[string]ResourceChanges)
+	return changes[resource]
+}
+
+// Notify calls the functions registered since the last call with outcome, or the
+// ones registered before when there are none and outcome is SyncOutcomeFailed.
+// The functions can read the changes counted with CountChanges from their context.
+func (n *SyncOutcomeNotifier) Notify(ctx context.Context, outcome SyncOutcome) {
+	n.mu.Lock()
+	fns := n.fns
+	if fns == nil && outcome == SyncOutcomeFailed {
+		fns = n.previous
+	} else {
+		n.previous = fns
+	}
+	n.fns = nil
+	ctx = context.WithValue(ctx, resourceChangesKey{}, n.changes)
+	n.changes = nil
+	n.mu.Unlock()
+	for _, fn := range fns {
+		fn(ctx, outcome)
+	}
+}
@@ -71,6 +71,16 @@ func NewEngine(fqdnTemplates, targetTemplates, fqdnTargetTemplates []string, com
 	return Engine{fqdn: fqdnTmpl, target: targetTmpl, fqdnTarget: fqdnTargetTmpl, combine: combineFQDN}, nil
 }
 
+// NewFQDNEngine parses templates into an Engine that only generates DNS names, reporting
+// parse errors against flag. Sources use it for name templates of their own next to --fqdn-template.
+func NewFQDNEngine(templates []string, flag string) (Engine, error) {
+	fqdnTmpl, err := validateAndParse(templates, flag)
+	if err != nil {
+		return Engine{}, err
+	}
+	return Engine{fqdn: fqdnTmpl}, nil
+}
+
 // IsConfigured reports whether the FQDN template is set and ready to use.
 func (e Engine) IsConfigured() bool {
 	return e.fqdn != nil
@@ -590,6 +590,20 @@ func TestCombineWithEndpoints(t *testing.T) {
 	}
 }
 
+func TestNewFQDNEngine(t *testing.T) {
+	_, err := NewFQDNEngine([]string{"{{.Name"}, "--node-zone-fqdn-template")
+	require.ErrorContains(t, err, `--node-zone-fqdn-template[0] "{{.Name"`)
+
+	engine, err := NewFQDNEngine([]string{""}, "--node-zone-fqdn-template")
+	require.NoError(t, err)
+	assert.False(t, engine.IsConfigured())
+
+	engine, err = NewFQDNEngine([]string{"{{.Name}}.example.com"}, "--node-zone-fqdn-template")
+	require.NoError(t, err)
+	assert.True(t, engine.IsConfigured())
+	assert.False(t, engine.Combining())
+}
+
 func TestNewEngine_DebugLogging(t *testing.T) {
 	fqdn := []string{"{{.Name}}.example.com"}
 	target := []string{"{{.Name}}.targets.example.com"}
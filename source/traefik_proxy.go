@@ -116,7 +116,7 @@ func NewTraefikSource(
 ) (Source, error) {
 	// Use shared informer to listen for add/update/delete of Host in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, cfg.Namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, cfg.Namespace, informers.ListPageSize(cfg.KubeAPIListPageSize))
 	var ingressRouteInformer, ingressRouteTcpInformer, ingressRouteUdpInformer kubeinformers.GenericInformer
 	var oldIngressRouteInformer, oldIngressRouteTcpInformer, oldIngressRouteUdpInformer kubeinformers.GenericInformer
 
@@ -138,8 +138,11 @@ func NewTraefikSource(
 			informers.TransformRemoveLastAppliedConfig(),
 		))
 		informers.MustAddEventHandler(ingressRouteInformer.Informer(), informers.DefaultEventHandler())
+		informers.TrackWatch(ingressRouteInformer.Informer(), "traefik-proxy/ingressroute")
 		informers.MustAddEventHandler(ingressRouteTcpInformer.Informer(), informers.DefaultEventHandler())
+		informers.TrackWatch(ingressRouteTcpInformer.Informer(), "traefik-proxy/ingressroutetcp")
 		informers.MustAddEventHandler(ingressRouteUdpInformer.Informer(), informers.DefaultEventHandler())
+		informers.TrackWatch(ingressRouteUdpInformer.Informer(), "traefik-proxy/ingressrouteudp")
 	}
 	if cfg.TraefikEnableLegacy {
 		oldIngressRouteInformer = informerFactory.ForResource(oldIngressRouteGVR)
@@ -158,8 +161,11 @@ func NewTraefikSource(
 			informers.TransformRemoveLastAppliedConfig(),
 		))
 		informers.MustAddEventHandler(oldIngressRouteInformer.Informer(), informers.DefaultEventHandler())
+		informers.TrackWatch(oldIngressRouteInformer.Informer(), "traefik-proxy/ingressroute.traefik.containo.us")
 		informers.MustAddEventHandler(oldIngressRouteTcpInformer.Informer(), informers.DefaultEventHandler())
+		informers.TrackWatch(oldIngressRouteTcpInformer.Informer(), "traefik-proxy/ingressroutetcp.traefik.containo.us")
 		informers.MustAddEventHandler(oldIngressRouteUdpInformer.Informer(), informers.DefaultEventHandler())
+		informers.TrackWatch(oldIngressRouteUdpInformer.Informer(), "traefik-proxy/ingressrouteudp.traefik.containo.us")
 	}
 
 	informerFactory.Start(ctx.Done())
@@ -191,50 +197,31 @@ func NewTraefikSource(
 	}, nil
 }
 
-func (ts *traefikSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (ts *traefikSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	var endpoints []*endpoint.Endpoint
 
-	if ts.ingressRouteInformer != nil {
-		ingressRouteEndpoints, err := ts.ingressRouteEndpoints()
-		if err != nil {
-			return nil, err
-		}
-		endpoints = append(endpoints, ingressRouteEndpoints...)
-	}
-	if ts.oldIngressRouteInformer != nil {
-		oldIngressRouteEndpoints, err := ts.oldIngressRouteEndpoints()
-		if err != nil {
-			return nil, err
-		}
-		endpoints = append(endpoints, oldIngressRouteEndpoints...)
-	}
-	if ts.ingressRouteTcpInformer != nil {
-		ingressRouteTcpEndpoints, err := ts.ingressRouteTCPEndpoints()
-		if err != nil {
-			return nil, err
-		}
-		endpoints = append(endpoints, ingressRouteTcpEndpoints...)
-	}
-	if ts.oldIngressRouteTcpInformer != nil {
-		oldIngressRouteTcpEndpoints, err := ts.oldIngressRouteTCPEndpoints()
-		if err != nil {
-			return nil, err
+	for _, kind := range []struct {
+		informer kubeinformers.GenericInformer
+		extract  func() ([]*endpoint.Endpoint, error)
+	}{
+		{ts.ingressRouteInformer, ts.ingressRouteEndpoints},
+		{ts.oldIngressRouteInformer, ts.oldIngressRouteEndpoints},
+		{ts.ingressRouteTcpInformer, ts.ingressRouteTCPEndpoints},
+		{ts.oldIngressRouteTcpInformer, ts.oldIngressRouteTCPEndpoints},
+		{ts.ingressRouteUdpInformer, ts.ingressRouteUDPEndpoints},
+		{ts.oldIngressRouteUdpInformer, ts.oldIngressRouteUDPEndpoints},
+	} {
+		if kind.informer == nil {
+			continue
 		}
-		endpoints = append(endpoints, oldIngressRouteTcpEndpoints...)
-	}
-	if ts.ingressRouteUdpInformer != nil {
-		ingressRouteUdpEndpoints, err := ts.ingressRouteUDPEndpoints()
-		if err != nil {
+		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
-		endpoints = append(endpoints, ingressRouteUdpEndpoints...)
-	}
-	if ts.oldIngressRouteUdpInformer != nil {
-		oldIngressRouteUdpEndpoints, err := ts.oldIngressRouteUDPEndpoints()
+		kindEndpoints, err := kind.extract()
 		if err != nil {
 			return nil, err
 		}
-		endpoints = append(endpoints, oldIngressRouteUdpEndpoints...)
+		endpoints = append(endpoints, kindEndpoints...)
 	}
 
 	return endpoint.MergeEndpoints(endpoints), nil
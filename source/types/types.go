@@ -37,6 +37,9 @@ const (
 	OpenShiftRoute      Type = "openshift-route"
 	Fake                Type = "fake"
 	Connector           Type = "connector"
+	Delegation          Type = "delegation"
+	Nomad               Type = "nomad"
+	Consul              Type = "consul"
 	CRD                 Type = "crd"
 	SkipperRouteGroup   Type = "skipper-routegroup"
 	KongTCPIngress      Type = "kong-tcpingress"
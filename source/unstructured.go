@@ -72,7 +72,7 @@ func NewUnstructuredFQDNSource(
 		dynamicClient,
 		0,
 		cfg.Namespace,
-		nil,
+		informers.ListPageSize(cfg.KubeAPIListPageSize),
 	)
 
 	// Create informers for each resource
@@ -92,6 +92,7 @@ func NewUnstructuredFQDNSource(
 		))
 
 		informers.MustAddEventHandler(informer.Informer(), informers.DefaultEventHandler())
+		informers.TrackWatch(informer.Informer(), "unstructured/"+gvr.String())
 		resourceInformers = append(resourceInformers, informer)
 	}
 
@@ -107,10 +108,13 @@ func NewUnstructuredFQDNSource(
 }
 
 // Endpoints returns the list of endpoints from unstructured resources.
-func (us *unstructuredSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (us *unstructuredSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	var endpoints []*endpoint.Endpoint
 
 	for _, informer := range us.informers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		resourceEndpoints, err := us.endpointsFromInformer(informer)
 		if err != nil {
 			return nil, err
@@ -15,7 +15,12 @@ package source
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
 // ParseIngress parses an ingress string in the format "namespace/name" or "name".
@@ -36,3 +41,26 @@ func ParseIngress(ingress string) (string, string, error) {
 
 	return namespace, name, err
 }
+
+// splitServiceHostnames splits the comma separated hostnames of a service registered
+// outside of Kubernetes, e.g. in Nomad or Consul, ignoring empty ones and trailing dots.
+func splitServiceHostnames(value string) []string {
+	var hostnames []string
+	for h := range strings.SplitSeq(value, ",") {
+		if h = strings.TrimSuffix(strings.TrimSpace(h), "."); h != "" {
+			hostnames = append(hostnames, h)
+		}
+	}
+	return hostnames
+}
+
+// parseServiceTTL parses the TTL in seconds of the records of a service registered outside
+// of Kubernetes. An invalid value is logged and leaves the TTL unconfigured.
+func parseServiceTTL(resource, value string) endpoint.TTL {
+	ttl, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ttl <= 0 {
+		log.Warnf("Ignoring invalid TTL %q of %s", value, resource)
+		return 0
+	}
+	return endpoint.TTL(ttl)
+}
@@ -22,14 +22,17 @@ import (
 	"sigs.k8s.io/external-dns/source"
 )
 
-// Build creates all named sources using cfg's ClientGenerator and wraps them
-// with the standard pipeline (dedup, optional NAT64, optional target filter,
-// post-processor). Inject a custom ClientGenerator via source.WithClientGenerator.
-func Build(ctx context.Context, cfg *source.Config) (source.Source, error) {
+// Build creates all named sources using cfg's ClientGenerator, drops the endpoints
+// of the excluded namespaces and wraps them with the source wrapper pipeline (dedup, then by default optional namespace collision,
+// target-from, namespace defaults, cluster records, view, NAT64, target filter, health check, PTR, custom wrappers and post-processor). Inject a custom ClientGenerator via source.WithClientGenerator.
+// Additional options, such as an event emitter, are applied after the ones derived from cfg.
+func Build(ctx context.Context, cfg *source.Config, extra ...Option) (source.Source, error) {
 	sources, err := source.ByNames(ctx, cfg, cfg.ClientGenerator())
 	if err != nil {
 		return nil, err
 	}
+	sources = withSourceTimeouts(sources, cfg.SourceNames(), cfg.SourceTimeouts)
+	sources = withExcludedNamespaces(sources, cfg.ExcludeNamespaces)
 	opts := NewConfig(
 		WithDefaultTargets(cfg.DefaultTargets),
 		WithForceDefaultTargets(cfg.ForceDefaultTargets),
@@ -41,6 +44,44 @@ func Build(ctx context.Context, cfg *source.Config) (source.Source, error) {
 		WithPreferAlias(cfg.PreferAlias),
 		WithPTRSupported(cfg.PTRSupported),
 		WithCreatePTR(cfg.CreatePTR),
+		WithMergeEndpoints(cfg.MergeEndpoints),
+		WithSourceWrapperOrder(cfg.SourceWrapperOrder),
+		WithDisabledSourceWrappers(cfg.DisabledSourceWrappers),
+		WithView(cfg.View),
+		WithClusterRecords(cfg.ClusterName, cfg.RecordPrefix, cfg.RecordSuffix, cfg.RecordSharedParent),
+		WithNamespaceCollisionPolicy(cfg.NamespaceCollisionPolicy),
 	)
+	if len(cfg.TargetFromKinds) > 0 {
+		kubeClient, err := source.KubeClient(cfg.ClientGenerator())
+		if err != nil {
+			return nil, err
+		}
+		resolver, err := NewTargetFromResolver(ctx, kubeClient, cfg.Namespace, cfg.TargetFromKinds)
+		if err != nil {
+			return nil, err
+		}
+		WithTargetFromResolver(resolver)(opts)
+	}
+	if cfg.CloudflareNamespaceDefaults {
+		kubeClient, err := source.KubeClient(cfg.ClientGenerator())
+		if err != nil {
+			return nil, err
+		}
+		defaults, err := NewNamespaceDefaults(ctx, kubeClient, cfg.KubeAPIListPageSize)
+		if err != nil {
+			return nil, err
+		}
+		WithNamespaceDefaults(defaults)(opts)
+	}
+	if cfg.HealthChecks {
+		WithHealthChecks(HealthCheckConfig{
+			Timeout:          cfg.HealthCheckTimeout,
+			FailureThreshold: cfg.HealthCheckFailureThreshold,
+			SuccessThreshold: cfg.HealthCheckSuccessThreshold,
+		})(opts)
+	}
+	for _, opt := range extra {
+		opt(opts)
+	}
 	return wrapSources(sources, opts)
 }
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// clusterRecordsSource is a Source that makes the records of a cluster distinct
+// from the records of the other clusters publishing the same resources, in a
+// multi-cluster active/active setup. The prefix and the suffix are added to the
+// first label of the DNS names, e.g. app.example.com is published as
+// app.eu.example.com with the suffix ".eu". With sharedParent, the endpoints are
+// also published under their original DNS names with the cluster name as set
+// identifier, so that each cluster owns its own record of a shared routing policy;
+// the records of the cluster then carry the same set identifier.
+type clusterRecordsSource struct {
+	source       source.Source
+	clusterName  string
+	prefix       string
+	suffix       string
+	sharedParent bool
+}
+
+// NewClusterRecordsSource creates a new clusterRecordsSource wrapping the provided Source.
+func NewClusterRecordsSource(source source.Source, clusterName, prefix, suffix string, sharedParent bool) source.Source {
+	return &clusterRecordsSource{source: source, clusterName: clusterName, prefix: prefix, suffix: suffix, sharedParent: sharedParent}
+}
+
+// Endpoints collects endpoints from its wrapped source and renames them for the cluster.
+func (s *clusterRecordsSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	log.Debugf("clusterRecordsSource: collecting endpoints for cluster %q", s.clusterName)
+
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		// the routing properties of the endpoint, e.g. a weight, apply to both records and require a set identifier
+		if s.sharedParent && ep.SetIdentifier == "" {
+			ep.SetIdentifier = s.clusterName
+		}
+		if s.prefix != "" || s.suffix != "" {
+			renamed := ep.DeepCopy()
+			renamed.DNSName = s.recordName(ep.DNSName)
+			result = append(result, renamed)
+			if !s.sharedParent {
+				continue
+			}
+		}
+		result = append(result, ep)
+	}
+	return result, nil
+}
+
+// recordName adds the prefix and the suffix to the first label of dnsName.
+func (s *clusterRecordsSource) recordName(dnsName string) string {
+	label, rest, found := strings.Cut(dnsName, ".")
+	name := s.prefix + label + s.suffix
+	if found {
+		name += "." + rest
+	}
+	return name
+}
+
+func (s *clusterRecordsSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("clusterRecordsSource: adding event handler")
+	s.source.AddEventHandler(ctx, handler)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+)
+
+var _ source.Source = &clusterRecordsSource{}
+
+func TestClusterRecordsSourceEndpoints(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		prefix       string
+		suffix       string
+		sharedParent bool
+		expected     []*endpoint.Endpoint
+	}{
+		{
+			name:   "suffix",
+			suffix: ".eu",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.eu.example.com", endpoint.RecordTypeA, "192.0.2.1").
+					WithProviderSpecific("aws/weight", "10").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpoint("api.eu.example.com", endpoint.RecordTypeCNAME, "lb.example.com").WithSetIdentifier("blue"),
+				endpoint.NewEndpoint("localhost.eu", endpoint.RecordTypeA, "127.0.0.1"),
+			},
+		},
+		{
+			name:   "prefix",
+			prefix: "eu-",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("eu-app.example.com", endpoint.RecordTypeA, "192.0.2.1").
+					WithProviderSpecific("aws/weight", "10").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpoint("eu-api.example.com", endpoint.RecordTypeCNAME, "lb.example.com").WithSetIdentifier("blue"),
+				endpoint.NewEndpoint("eu-localhost", endpoint.RecordTypeA, "127.0.0.1"),
+			},
+		},
+		{
+			name:         "suffix and shared parent",
+			suffix:       ".eu",
+			sharedParent: true,
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.eu.example.com", endpoint.RecordTypeA, "192.0.2.1").WithSetIdentifier("eu").
+					WithProviderSpecific("aws/weight", "10").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.1").WithSetIdentifier("eu").
+					WithProviderSpecific("aws/weight", "10").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpoint("api.eu.example.com", endpoint.RecordTypeCNAME, "lb.example.com").WithSetIdentifier("blue"),
+				endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "lb.example.com").WithSetIdentifier("blue"),
+				endpoint.NewEndpoint("localhost.eu", endpoint.RecordTypeA, "127.0.0.1").WithSetIdentifier("eu"),
+				endpoint.NewEndpoint("localhost", endpoint.RecordTypeA, "127.0.0.1").WithSetIdentifier("eu"),
+			},
+		},
+		{
+			name:         "shared parent only",
+			sharedParent: true,
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.1").WithSetIdentifier("eu").
+					WithProviderSpecific("aws/weight", "10").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "lb.example.com").WithSetIdentifier("blue"),
+				endpoint.NewEndpoint("localhost", endpoint.RecordTypeA, "127.0.0.1").WithSetIdentifier("eu"),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints := []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.1").
+					WithProviderSpecific("aws/weight", "10").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "lb.example.com").WithSetIdentifier("blue"),
+				endpoint.NewEndpoint("localhost", endpoint.RecordTypeA, "127.0.0.1"),
+			}
+
+			src := NewClusterRecordsSource(testutils.NewMockSource(endpoints...), "eu", tt.prefix, tt.suffix, tt.sharedParent)
+			got, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+
+			testutils.ValidateEndpoints(t, got, tt.expected)
+		})
+	}
+}
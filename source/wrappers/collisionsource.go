@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Namespace collision policies, selected with --namespace-collision-policy.
+const (
+	// CollisionPolicyFirstWins keeps the DNS name of the namespace holding the oldest resource.
+	CollisionPolicyFirstWins = "first-wins"
+	// CollisionPolicyDenyAll drops the DNS name from every namespace claiming it.
+	CollisionPolicyDenyAll = "deny-all"
+	// CollisionPolicyAnnotationPriority keeps the DNS name of the namespace with the
+	// highest collision-priority annotation, falling back to first-wins on ties.
+	CollisionPolicyAnnotationPriority = "annotation-priority"
+)
+
+// collisionSource is a Source that detects DNS names claimed by resources of
+// different namespaces and keeps the endpoints of at most one namespace,
+// chosen by the configured policy. Every resource involved in a collision is
+// notified with a warning event.
+type collisionSource struct {
+	source       source.Source
+	policy       string
+	eventEmitter events.EventEmitter
+}
+
+// NewCollisionSource creates a new collisionSource wrapping the provided Source.
+func NewCollisionSource(source source.Source, policy string, eventEmitter events.EventEmitter) source.Source {
+	return &collisionSource{source: source, policy: policy, eventEmitter: eventEmitter}
+}
+
+// collisionKey identifies the endpoints competing for a DNS name.
+type collisionKey struct {
+	dnsName       string
+	setIdentifier string
+}
+
+// namespaceClaim is the claim of the resources of one namespace on a DNS name.
+type namespaceClaim struct {
+	namespace string
+	// created is the creation of the oldest resource, zero when unknown.
+	created  time.Time
+	priority int
+}
+
+// Endpoints collects endpoints from its wrapped source and drops the endpoints
+// of the namespaces losing a collision.
+func (s *collisionSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	log.Debugf("collisionSource: resolving namespace collisions with policy %q", s.policy)
+
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[collisionKey][]*endpoint.Endpoint)
+	var keys []collisionKey
+	for _, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		key := collisionKey{dnsName: ep.DNSName, setIdentifier: ep.SetIdentifier}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], ep)
+	}
+
+	dropped := make(map[*endpoint.Endpoint]bool)
+	for _, key := range keys {
+		group := groups[key]
+		claims := namespaceClaims(group)
+		if len(claims) < 2 {
+			continue
+		}
+		winner := s.winner(claims)
+		for _, ep := range group {
+			if namespaces := endpointNamespaces(ep); len(namespaces) > 0 && !slices.Contains(namespaces, winner) {
+				dropped[ep] = true
+				namespaceCollisionEndpoints.AddWithLabels(1, ep.RecordType, endpointSource(ep))
+			}
+		}
+		s.reportCollision(key, claims, winner, group)
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep == nil || dropped[ep] {
+			continue
+		}
+		dropCollisionPriorityProperty(ep)
+		result = append(result, ep)
+	}
+	return result, nil
+}
+
+func (s *collisionSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("collisionSource: adding event handler")
+	s.source.AddEventHandler(ctx, handler)
+}
+
+// winner returns the namespace keeping the DNS name, none for deny-all.
+func (s *collisionSource) winner(claims []namespaceClaim) string {
+	switch s.policy {
+	case CollisionPolicyDenyAll:
+		return ""
+	case CollisionPolicyAnnotationPriority:
+		top := slices.MaxFunc(claims, func(a, b namespaceClaim) int { return cmp.Compare(a.priority, b.priority) }).priority
+		claims = slices.DeleteFunc(slices.Clone(claims), func(c namespaceClaim) bool { return c.priority != top })
+	}
+	return slices.MinFunc(claims, compareClaimAge).namespace
+}
+
+// compareClaimAge orders claims from the oldest to the newest, claims of
+// unknown age last, then by namespace name.
+func compareClaimAge(a, b namespaceClaim) int {
+	switch {
+	case a.created.IsZero() != b.created.IsZero():
+		if a.created.IsZero() {
+			return 1
+		}
+		return -1
+	case !a.created.Equal(b.created):
+		return a.created.Compare(b.created)
+	}
+	return strings.Compare(a.namespace, b.namespace)
+}
+
+// reportCollision logs and emits a warning to every resource claiming the DNS name.
+func (s *collisionSource) reportCollision(key collisionKey, claims []namespaceClaim, winner string, group []*endpoint.Endpoint) {
+	namespaces := make([]string, 0, len(claims))
+	for _, c := range claims {
+		namespaces = append(namespaces, c.namespace)
+	}
+	kept := winner
+	if kept == "" {
+		kept = "none"
+	}
+
+	log.Warnf("DNS name %s is claimed by namespaces %s, policy %s keeps namespace %s",
+		key.dnsName, strings.Join(namespaces, ", "), s.policy, kept)
+	if s.eventEmitter == nil {
+		return
+	}
+	var refs []*events.ObjectReference
+	for _, ep := range group {
+		refs = append(refs, ep.RefObjects()...)
+	}
+	msg := fmt.Sprintf("(external-dns) record:%s,set-identifier:%s claimed by namespaces:%s,policy:%s,kept:%s",
+		key.dnsName, key.setIdentifier, strings.Join(namespaces, ","), s.policy, kept)
+	s.eventEmitter.Add(events.NewWarningEvent(refs, msg, events.ActionConflict, events.RecordConflict))
+}
+
+// namespaceClaims returns the claims of the namespaces of the endpoints, sorted
+// by namespace. Endpoints without a namespace, e.g. of cluster-scoped
+// resources, take part in no collision.
+func namespaceClaims(group []*endpoint.Endpoint) []namespaceClaim {
+	byNamespace := make(map[string]*namespaceClaim)
+	for _, ep := range group {
+		priority := collisionPriority(ep)
+		for _, ns := range endpointNamespaces(ep) {
+			claim, ok := byNamespace[ns]
+			if !ok {
+				claim = &namespaceClaim{namespace: ns, priority: priority}
+				byNamespace[ns] = claim
+			}
+			claim.priority = max(claim.priority, priority)
+			for _, ref := range ep.RefObjects() {
+				created := ref.CreationTimestamp()
+				if ref.Namespace() == ns && !created.IsZero() && (claim.created.IsZero() || created.Before(claim.created)) {
+					claim.created = created
+				}
+			}
+		}
+	}
+
+	claims := make([]namespaceClaim, 0, len(byNamespace))
+	for _, claim := range byNamespace {
+		claims = append(claims, *claim)
+	}
+	slices.SortFunc(claims, func(a, b namespaceClaim) int { return strings.Compare(a.namespace, b.namespace) })
+	return claims
+}
+
+// endpointNamespaces returns the namespaces of the resources of the endpoint,
+// read from its object references or, without any, its resource labels.
+func endpointNamespaces(ep *endpoint.Endpoint) []string {
+	var namespaces []string
+	for _, ref := range ep.RefObjects() {
+		if ns := ref.Namespace(); ns != "" && !slices.Contains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	if len(namespaces) > 0 {
+		return namespaces
+	}
+	for _, resource := range ep.Labels.Resources() {
+		// resources are labelled as <kind>/<namespace>/<name>
+		if parts := strings.Split(resource, "/"); len(parts) == 3 && parts[1] != "" && !slices.Contains(namespaces, parts[1]) {
+			namespaces = append(namespaces, parts[1])
+		}
+	}
+	return namespaces
+}
+
+// collisionPriority returns the collision priority of the endpoint, 0 when unset or invalid.
+func collisionPriority(ep *endpoint.Endpoint) int {
+	value, ok := ep.GetProviderSpecificProperty(endpoint.ProviderSpecificCollisionPriority)
+	if !ok {
+		return 0
+	}
+	priority, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		log.Warnf("Ignoring invalid collision priority %q of endpoint %s", value, ep.DNSName)
+		return 0
+	}
+	return priority
+}
+
+// dropCollisionPriorityProperty removes the collision priority from the endpoint so it never
+// reaches a provider. The provider-specific slice may be shared between endpoints, so it is cloned.
+func dropCollisionPriorityProperty(ep *endpoint.Endpoint) {
+	isPriority := func(p endpoint.ProviderSpecificProperty) bool {
+		return p.Name == endpoint.ProviderSpecificCollisionPriority
+	}
+	if slices.ContainsFunc(ep.ProviderSpecific, isPriority) {
+		ep.ProviderSpecific = slices.DeleteFunc(slices.Clone(ep.ProviderSpecific), isPriority)
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/pkg/events/fake"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/types"
+)
+
+// Validates that collisionSource is a Source
+var _ source.Source = &collisionSource{}
+
+func collisionEndpoint(name, namespace string, created time.Time, target string, priority string) *endpoint.Endpoint {
+	svc := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace, CreationTimestamp: metav1.NewTime(created)}}
+	ep := endpoint.NewEndpoint(name, endpoint.RecordTypeA, target).
+		WithLabel(endpoint.ResourceLabelKey, "service/"+namespace+"/web").
+		WithRefObject(events.NewObjectReference(svc, types.Service))
+	if priority != "" {
+		ep.WithProviderSpecific(endpoint.ProviderSpecificCollisionPriority, priority)
+	}
+	return ep
+}
+
+func TestCollisionSource(t *testing.T) {
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	for _, tc := range []struct {
+		name        string
+		policy      string
+		endpoints   []*endpoint.Endpoint
+		wantTargets []string
+		wantEvent   bool
+	}{
+		{
+			name:   "no collision within a namespace",
+			policy: CollisionPolicyDenyAll,
+			endpoints: []*endpoint.Endpoint{
+				collisionEndpoint("web.example.org", "team-a", older, "1.1.1.1", ""),
+				collisionEndpoint("web.example.org", "team-a", newer, "2.2.2.2", ""),
+				collisionEndpoint("api.example.org", "team-b", newer, "3.3.3.3", ""),
+			},
+			wantTargets: []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"},
+		},
+		{
+			name:   "first-wins keeps the oldest resource",
+			policy: CollisionPolicyFirstWins,
+			endpoints: []*endpoint.Endpoint{
+				collisionEndpoint("web.example.org", "team-a", newer, "1.1.1.1", ""),
+				collisionEndpoint("web.example.org", "team-b", older, "2.2.2.2", ""),
+				collisionEndpoint("api.example.org", "team-a", newer, "3.3.3.3", ""),
+			},
+			wantTargets: []string{"2.2.2.2", "3.3.3.3"},
+			wantEvent:   true,
+		},
+		{
+			name:   "deny-all drops every namespace",
+			policy: CollisionPolicyDenyAll,
+			endpoints: []*endpoint.Endpoint{
+				collisionEndpoint("web.example.org", "team-a", newer, "1.1.1.1", ""),
+				collisionEndpoint("web.example.org", "team-b", older, "2.2.2.2", ""),
+				endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "4.4.4.4"),
+			},
+			wantTargets: []string{"4.4.4.4"},
+			wantEvent:   true,
+		},
+		{
+			name:   "annotation-priority keeps the highest priority",
+			policy: CollisionPolicyAnnotationPriority,
+			endpoints: []*endpoint.Endpoint{
+				collisionEndpoint("web.example.org", "team-a", newer, "1.1.1.1", "10"),
+				collisionEndpoint("web.example.org", "team-b", older, "2.2.2.2", ""),
+			},
+			wantTargets: []string{"1.1.1.1"},
+			wantEvent:   true,
+		},
+		{
+			name:   "annotation-priority falls back to first-wins on ties",
+			policy: CollisionPolicyAnnotationPriority,
+			endpoints: []*endpoint.Endpoint{
+				collisionEndpoint("web.example.org", "team-a", newer, "1.1.1.1", "5"),
+				collisionEndpoint("web.example.org", "team-b", older, "2.2.2.2", "5"),
+				collisionEndpoint("web.example.org", "team-c", older, "3.3.3.3", "invalid"),
+			},
+			wantTargets: []string{"2.2.2.2"},
+			wantEvent:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			emitter := fake.NewFakeEventEmitter()
+			src := NewCollisionSource(testutils.NewMockSource(tc.endpoints...), tc.policy, emitter)
+
+			result, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+
+			var targets []string
+			for _, ep := range result {
+				targets = append(targets, ep.Targets...)
+				_, ok := ep.GetProviderSpecificProperty(endpoint.ProviderSpecificCollisionPriority)
+				assert.False(t, ok, "collision priority must not reach the provider")
+			}
+			assert.Equal(t, tc.wantTargets, targets)
+
+			if !tc.wantEvent {
+				emitter.AssertNotCalled(t, "Add", mock.Anything)
+				return
+			}
+			emitter.AssertNumberOfCalls(t, "Add", 1)
+			event := emitter.Calls[0].Arguments.Get(0).(events.Event)
+			assert.Equal(t, events.EventTypeWarning, event.EventType())
+			assert.Equal(t, events.RecordConflict, event.Reason())
+			assert.Contains(t, event.Message(), "policy:"+tc.policy)
+		})
+	}
+}
+
+func TestCollisionSourceNamespacesFromLabels(t *testing.T) {
+	eps := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.ResourceLabelKey, "ingress/team-b/web"),
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.ResourceLabelKey, "ingress/team-a/web"),
+	}
+
+	result, err := NewCollisionSource(testutils.NewMockSource(eps...), CollisionPolicyFirstWins, nil).Endpoints(t.Context())
+	require.NoError(t, err)
+
+	// without creation timestamps the first namespace by name wins
+	require.Len(t, result, 1)
+	assert.Equal(t, endpoint.Targets{"2.2.2.2"}, result[0].Targets)
+}
@@ -18,22 +18,51 @@ package wrappers
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/logging"
+	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/source"
 )
 
 // dedupSource is a Source that removes duplicate endpoints from its wrapped source.
+// When merging is enabled, endpoints sharing a DNS name, record type and set
+// identifier are merged into one, recording every contributing resource.
 type dedupSource struct {
-	source source.Source
+	source       source.Source
+	merge        bool
+	eventEmitter events.EventEmitter
+}
+
+type DedupOption func(*dedupSource)
+
+// WithDedupMerge enables merging the targets of compatible endpoints produced by
+// different resources for the same DNS name, record type and set identifier.
+func WithDedupMerge(enabled bool) DedupOption {
+	return func(ds *dedupSource) {
+		ds.merge = enabled
+	}
+}
+
+// WithDedupEventEmitter sets the emitter used to report endpoints that cannot be merged.
+func WithDedupEventEmitter(emitter events.EventEmitter) DedupOption {
+	return func(ds *dedupSource) {
+		ds.eventEmitter = emitter
+	}
 }
 
 // NewDedupSource creates a new dedupSource wrapping the provided Source.
-func NewDedupSource(source source.Source) source.Source {
-	return &dedupSource{source: source}
+func NewDedupSource(source source.Source, opts ...DedupOption) source.Source {
+	ds := &dedupSource{source: source}
+	for _, opt := range opts {
+		opt(ds)
+	}
+	return ds
 }
 
 // Endpoints collects endpoints from its wrapped source and returns them without duplicates.
@@ -71,7 +100,7 @@ func (ms *dedupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, err
 			for _, ref := range ep.RefObjects() {
 				existing.WithRefObject(ref)
 			}
-			log.Debugf("Removing duplicate endpoint %s", ep)
+			logging.Debugf("Removing duplicate endpoint %s", ep)
 			deduplicatedEndpoints.AddWithLabels(1, ep.RecordType, endpointSource(ep))
 			continue
 		}
@@ -80,9 +109,118 @@ func (ms *dedupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, err
 		result = append(result, ep)
 	}
 
+	if ms.merge {
+		result = ms.mergeEndpoints(result)
+	}
+
 	return result, nil
 }
 
+// mergeEndpoints merges endpoints that share a DNS name, record type and set
+// identifier. Endpoints are compatible when their record type allows multiple
+// targets and they agree on TTL and provider-specific properties; the merged
+// endpoint keeps the targets, resources and object references of all of them.
+// Incompatible endpoints are kept as they are for the plan to resolve, and a
+// warning listing the conflicting resources is logged and emitted as an event.
+func (ms *dedupSource) mergeEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	groups := make(map[endpoint.EndpointKey][]*endpoint.Endpoint, len(endpoints))
+	var keys []endpoint.EndpointKey
+	for _, ep := range endpoints {
+		key := ep.Key()
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], ep)
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) == 1 {
+			result = append(result, group[0])
+			continue
+		}
+
+		if !mergeable(group) {
+			ms.reportConflict(key, group)
+			result = append(result, group...)
+			continue
+		}
+
+		merged := group[0]
+		if merged.Labels == nil {
+			merged.Labels = endpoint.NewLabels()
+		}
+		targets := slices.Clone(merged.Targets)
+		for _, ep := range group[1:] {
+			targets = append(targets, ep.Targets...)
+			for _, resource := range ep.Labels.Resources() {
+				merged.Labels.AddResource(resource)
+			}
+			for _, ref := range ep.RefObjects() {
+				merged.WithRefObject(ref)
+			}
+			mergedEndpoints.AddWithLabels(1, ep.RecordType, endpointSource(ep))
+		}
+		merged.Targets = endpoint.NewTargets(targets...)
+		log.Debugf("Merged %d endpoints for %s from resources %s", len(group), merged, merged.Labels[endpoint.ResourceLabelKey])
+		result = append(result, merged)
+	}
+	return result
+}
+
+// reportConflict logs and emits a warning for endpoints of several resources
+// that compete for the same record but cannot be merged.
+func (ms *dedupSource) reportConflict(key endpoint.EndpointKey, group []*endpoint.Endpoint) {
+	var resources []string
+	var refs []*events.ObjectReference
+	for _, ep := range group {
+		resources = append(resources, ep.Labels.Resources()...)
+		refs = append(refs, ep.RefObjects()...)
+	}
+	slices.Sort(resources)
+	resources = slices.Compact(resources)
+	if len(resources) < 2 {
+		return
+	}
+
+	msg := fmt.Sprintf("(external-dns) record:%s,type:%s,set-identifier:%s conflicting owners:%s",
+		key.DNSName, key.RecordType, key.SetIdentifier, strings.Join(resources, ","))
+	log.Warnf("Endpoints for %s %s cannot be merged, conflicting owners: %s", key.RecordType, key.DNSName, strings.Join(resources, ", "))
+	conflictingEndpoints.AddWithLabels(float64(len(group)), key.RecordType, endpointSource(group[0]))
+	if ms.eventEmitter != nil {
+		ms.eventEmitter.Add(events.NewWarningEvent(refs, msg, events.ActionConflict, events.RecordConflict))
+	}
+}
+
+// mergeable reports whether all endpoints of group can be served as a single record.
+func mergeable(group []*endpoint.Endpoint) bool {
+	first := group[0]
+	if first.RecordType == endpoint.RecordTypeCNAME {
+		// RFC 1034 3.6.2: a name with a CNAME record cannot have any other data.
+		return false
+	}
+	for _, ep := range group[1:] {
+		if ep.RecordTTL != first.RecordTTL || !sameProviderSpecific(ep.ProviderSpecific, first.ProviderSpecific) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameProviderSpecific compares provider-specific properties regardless of order.
+func sameProviderSpecific(a, b endpoint.ProviderSpecific) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, p := range a {
+		if !slices.Contains(b, p) {
+			return false
+		}
+	}
+	return true
+}
+
 func (ms *dedupSource) AddEventHandler(ctx context.Context, handler func()) {
 	log.Debug("dedupSource: adding event handler")
 	ms.source.AddEventHandler(ctx, handler)
@@ -21,6 +21,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -29,6 +30,8 @@ import (
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	logtest "sigs.k8s.io/external-dns/internal/testutils/log"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/pkg/events/fake"
 	"sigs.k8s.io/external-dns/source"
 	"sigs.k8s.io/external-dns/source/types"
 )
@@ -724,3 +727,127 @@ func TestDedupSource_InvalidEndpointsMetric(t *testing.T) {
 		map[string]string{"record_type": "srv", "source_type": "unknown"},
 	)
 }
+
+func TestDedupSource_MergeEndpoints(t *testing.T) {
+	ingressRef := events.NewObjectReferenceFromParts("Ingress", "networking.k8s.io/v1", "default", "web", "", types.Ingress)
+	crdRef := events.NewObjectReferenceFromParts("DNSEndpoint", "externaldns.k8s.io/v1alpha1", "default", "web", "", types.CRD)
+
+	newEndpoint := func(recordType, resource string, ref *events.ObjectReference, targets ...string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint("web.example.org", recordType, targets...).
+			WithLabel(endpoint.ResourceLabelKey, resource).
+			WithRefObject(ref)
+	}
+
+	for _, tc := range []struct {
+		name          string
+		endpoints     []*endpoint.Endpoint
+		wantTargets   []endpoint.Targets
+		wantResources []string
+		wantConflict  bool
+	}{
+		{
+			name: "compatible endpoints are merged",
+			endpoints: []*endpoint.Endpoint{
+				newEndpoint(endpoint.RecordTypeA, "ingress/default/web", ingressRef, "1.2.3.4"),
+				newEndpoint(endpoint.RecordTypeA, "crd/default/web", crdRef, "5.6.7.8", "1.2.3.4"),
+			},
+			wantTargets:   []endpoint.Targets{{"1.2.3.4", "5.6.7.8"}},
+			wantResources: []string{"crd/default/web;ingress/default/web"},
+		},
+		{
+			name: "different TTLs are not merged",
+			endpoints: []*endpoint.Endpoint{
+				newEndpoint(endpoint.RecordTypeA, "ingress/default/web", ingressRef, "1.2.3.4"),
+				func() *endpoint.Endpoint {
+					ep := newEndpoint(endpoint.RecordTypeA, "crd/default/web", crdRef, "5.6.7.8")
+					ep.RecordTTL = 60
+					return ep
+				}(),
+			},
+			wantTargets:   []endpoint.Targets{{"1.2.3.4"}, {"5.6.7.8"}},
+			wantResources: []string{"ingress/default/web", "crd/default/web"},
+			wantConflict:  true,
+		},
+		{
+			name: "different provider-specific properties are not merged",
+			endpoints: []*endpoint.Endpoint{
+				newEndpoint(endpoint.RecordTypeA, "ingress/default/web", ingressRef, "1.2.3.4").WithProviderSpecific("alias", "true"),
+				newEndpoint(endpoint.RecordTypeA, "crd/default/web", crdRef, "5.6.7.8"),
+			},
+			wantTargets:   []endpoint.Targets{{"1.2.3.4"}, {"5.6.7.8"}},
+			wantResources: []string{"ingress/default/web", "crd/default/web"},
+			wantConflict:  true,
+		},
+		{
+			name: "CNAME endpoints are not merged",
+			endpoints: []*endpoint.Endpoint{
+				newEndpoint(endpoint.RecordTypeCNAME, "ingress/default/web", ingressRef, "a.example.org"),
+				newEndpoint(endpoint.RecordTypeCNAME, "crd/default/web", crdRef, "b.example.org"),
+			},
+			wantTargets:   []endpoint.Targets{{"a.example.org"}, {"b.example.org"}},
+			wantResources: []string{"ingress/default/web", "crd/default/web"},
+			wantConflict:  true,
+		},
+		{
+			name: "endpoints of the same resource are not reported",
+			endpoints: []*endpoint.Endpoint{
+				newEndpoint(endpoint.RecordTypeCNAME, "ingress/default/web", ingressRef, "a.example.org"),
+				newEndpoint(endpoint.RecordTypeCNAME, "ingress/default/web", ingressRef, "b.example.org"),
+			},
+			wantTargets:   []endpoint.Targets{{"a.example.org"}, {"b.example.org"}},
+			wantResources: []string{"ingress/default/web", "ingress/default/web"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			emitter := fake.NewFakeEventEmitter()
+			src := NewDedupSource(testutils.NewMockSource(tc.endpoints...), WithDedupMerge(true), WithDedupEventEmitter(emitter))
+
+			result, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+			require.Len(t, result, len(tc.wantTargets))
+			for i, ep := range result {
+				assert.Equal(t, tc.wantTargets[i], ep.Targets)
+				assert.Equal(t, tc.wantResources[i], ep.Labels[endpoint.ResourceLabelKey])
+			}
+
+			if !tc.wantConflict {
+				emitter.AssertNotCalled(t, "Add", mock.Anything)
+				return
+			}
+			emitter.AssertNumberOfCalls(t, "Add", 1)
+			event := emitter.Calls[0].Arguments.Get(0).(events.Event)
+			assert.Equal(t, events.EventTypeWarning, event.EventType())
+			assert.Equal(t, events.RecordConflict, event.Reason())
+		})
+	}
+}
+
+func TestDedupSource_MergeDisabled(t *testing.T) {
+	eps := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/web"),
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "5.6.7.8").WithLabel(endpoint.ResourceLabelKey, "crd/default/web"),
+	}
+
+	result, err := NewDedupSource(testutils.NewMockSource(eps...)).Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+}
+
+func TestDedupSource_MergedEndpointsMetric(t *testing.T) {
+	mergedEndpoints.Reset()
+
+	eps := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/a"),
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "5.6.7.8").WithLabel(endpoint.ResourceLabelKey, "ingress/default/b"),
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "9.9.9.9").WithLabel(endpoint.ResourceLabelKey, "ingress/default/c"),
+	}
+
+	result, err := NewDedupSource(testutils.NewMockSource(eps...), WithDedupMerge(true)).Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(
+		t, 2.0, mergedEndpoints.Gauge,
+		map[string]string{"record_type": "a", "source_type": "unknown"},
+	)
+}
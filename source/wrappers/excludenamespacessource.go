@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"path"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// excludeNamespacesSource is a Source that drops the endpoints of resources in
+// excluded namespaces. Namespaces are matched against glob patterns, e.g. "kube-*".
+// Endpoints without a namespace, like those of nodes, are kept, and so are the
+// endpoints produced by resources of both excluded and included namespaces.
+type excludeNamespacesSource struct {
+	source   source.Source
+	patterns []string
+}
+
+// NewExcludeNamespacesSource creates a new excludeNamespacesSource wrapping the provided Source.
+func NewExcludeNamespacesSource(source source.Source, patterns []string) source.Source {
+	return &excludeNamespacesSource{source: source, patterns: patterns}
+}
+
+// Endpoints collects endpoints from its wrapped source and drops those of excluded namespaces.
+func (s *excludeNamespacesSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := endpoints[:0:0]
+	for _, ep := range endpoints {
+		if ep != nil && s.excluded(ep) {
+			log.Debugf("excludeNamespacesSource: dropping %s %s of an excluded namespace", ep.RecordType, ep.DNSName)
+			continue
+		}
+		result = append(result, ep)
+	}
+	return result, nil
+}
+
+func (s *excludeNamespacesSource) AddEventHandler(ctx context.Context, handler func()) {
+	s.source.AddEventHandler(ctx, handler)
+}
+
+// excluded reports whether all the namespaces of the resources which produced ep are excluded.
+func (s *excludeNamespacesSource) excluded(ep *endpoint.Endpoint) bool {
+	namespaces := endpointNamespaces(ep)
+	if len(namespaces) == 0 {
+		return false
+	}
+	for _, namespace := range namespaces {
+		if !matchNamespace(s.patterns, namespace) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchNamespace reports whether namespace matches one of the glob patterns,
+// using the syntax of path.Match. Invalid patterns never match.
+func matchNamespace(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// withExcludedNamespaces wraps each source to drop the endpoints of the namespaces
+// matching patterns, before the endpoints of all sources are deduplicated.
+func withExcludedNamespaces(sources []source.Source, patterns []string) []source.Source {
+	if len(patterns) == 0 {
+		return sources
+	}
+	wrapped := make([]source.Source, len(sources))
+	for i, src := range sources {
+		wrapped[i] = NewExcludeNamespacesSource(src, patterns)
+	}
+	return wrapped
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/types"
+)
+
+func TestExcludeNamespacesSource(t *testing.T) {
+	kept := endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.ResourceLabelKey, "service/team-a/web")
+	system := endpoint.NewEndpoint("dns.example.org", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.ResourceLabelKey, "service/kube-system/dns")
+	excluded := endpoint.NewEndpoint("test.example.org", endpoint.RecordTypeA, "3.3.3.3").WithLabel(endpoint.ResourceLabelKey, "ingress/sandbox/test")
+	node := endpoint.NewEndpoint("node.example.org", endpoint.RecordTypeA, "4.4.4.4").WithLabel(endpoint.ResourceLabelKey, "node//node-1")
+	mixed := endpoint.NewEndpoint("shared.example.org", endpoint.RecordTypeA, "5.5.5.5").
+		WithLabel(endpoint.ResourceLabelKey, "service/team-a/shared").
+		WithRefObject(events.NewObjectReferenceFromParts("Service", "v1", "team-a", "shared", "", types.Service)).
+		WithRefObject(events.NewObjectReferenceFromParts("Service", "v1", "sandbox", "shared", "", types.Service))
+
+	src := NewExcludeNamespacesSource(testutils.NewMockSource(kept, system, excluded, node, mixed), []string{"kube-*", "sandbox"})
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{kept, node, mixed}, endpoints)
+}
+
+func TestExcludeNamespacesSourceError(t *testing.T) {
+	failing := &testutils.MockSource{}
+	failing.On("Endpoints").Return(nil, errors.New("list failed"))
+
+	_, err := NewExcludeNamespacesSource(failing, []string{"kube-*"}).Endpoints(t.Context())
+	require.EqualError(t, err, "list failed")
+}
+
+func TestMatchNamespace(t *testing.T) {
+	patterns := []string{"kube-*", "team-?", "sandbox"}
+
+	assert.True(t, matchNamespace(patterns, "kube-system"))
+	assert.True(t, matchNamespace(patterns, "team-a"))
+	assert.True(t, matchNamespace(patterns, "sandbox"))
+	assert.False(t, matchNamespace(patterns, "team-ab"))
+	assert.False(t, matchNamespace(patterns, "default"))
+	assert.False(t, matchNamespace([]string{"[invalid"}, "[invalid"))
+}
+
+func TestWithExcludedNamespaces(t *testing.T) {
+	service, node := testutils.NewMockSource(), testutils.NewMockSource()
+	sources := []source.Source{service, node}
+
+	assert.Equal(t, sources, withExcludedNamespaces(sources, nil))
+
+	wrapped := withExcludedNamespaces(sources, []string{"kube-*"})
+	assert.Equal(t, &excludeNamespacesSource{source: service, patterns: []string{"kube-*"}}, wrapped[0])
+	assert.Equal(t, &excludeNamespacesSource{source: node, patterns: []string{"kube-*"}}, wrapped[1])
+}
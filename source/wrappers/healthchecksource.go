@@ -0,0 +1,349 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Protocols of the health-check probes.
+const (
+	healthCheckTCP   = "tcp"
+	healthCheckHTTP  = "http"
+	healthCheckHTTPS = "https"
+)
+
+const defaultHealthCheckTimeout = 2 * time.Second
+
+// HealthCheckConfig configures the probes of the health-check source wrapper.
+type HealthCheckConfig struct {
+	// Timeout of each probe.
+	Timeout time.Duration
+	// FailureThreshold is the number of consecutive failed probes marking a healthy target unhealthy.
+	FailureThreshold int
+	// SuccessThreshold is the number of consecutive successful probes marking an unhealthy target healthy.
+	SuccessThreshold int
+}
+
+// healthProbe is a parsed health-check annotation, e.g. "tcp:443" or "https:8443/healthz".
+type healthProbe struct {
+	protocol string
+	port     string
+	path     string
+}
+
+func (p healthProbe) String() string {
+	return p.protocol + ":" + p.port + p.path
+}
+
+// parseHealthProbe parses a probe as <protocol>:<port>[<path>], the path only
+// being allowed for the http and https protocols.
+func parseHealthProbe(value string) (healthProbe, error) {
+	protocol, rest, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok {
+		return healthProbe{}, fmt.Errorf("health check %q is not <protocol>:<port>[<path>]", value)
+	}
+	probe := healthProbe{protocol: strings.ToLower(protocol), port: rest}
+	if i := strings.Index(rest, "/"); i >= 0 {
+		probe.port, probe.path = rest[:i], rest[i:]
+	}
+	switch probe.protocol {
+	case healthCheckTCP:
+		if probe.path != "" {
+			return healthProbe{}, fmt.Errorf("health check %q: a tcp probe has no path", value)
+		}
+	case healthCheckHTTP, healthCheckHTTPS:
+		if probe.path == "" {
+			probe.path = "/"
+		}
+	default:
+		return healthProbe{}, fmt.Errorf("health check %q: unsupported protocol %q, expected tcp, http or https", value, protocol)
+	}
+	if port, err := strconv.Atoi(probe.port); err != nil || port < 1 || port > 65535 {
+		return healthProbe{}, fmt.Errorf("health check %q: invalid port %q", value, probe.port)
+	}
+	return probe, nil
+}
+
+// healthTarget identifies a probed target. The DNS name is the host of the HTTP
+// requests, so that the target serves the endpoint rather than a default backend.
+type healthTarget struct {
+	probe   healthProbe
+	dnsName string
+	target  string
+}
+
+// targetHealth tracks the consecutive probe results of a target.
+type targetHealth struct {
+	unhealthy bool
+	failures  int
+	successes int
+}
+
+// record updates the health with a probe result; a target changes state only
+// after the threshold of consecutive opposite results, so that it does not flap.
+func (h *targetHealth) record(ok bool, cfg HealthCheckConfig) {
+	if ok {
+		h.failures = 0
+		h.successes++
+		if h.unhealthy && h.successes >= cfg.SuccessThreshold {
+			h.unhealthy = false
+		}
+		return
+	}
+	h.successes = 0
+	h.failures++
+	if !h.unhealthy && h.failures >= cfg.FailureThreshold {
+		h.unhealthy = true
+	}
+}
+
+// healthCheckSource is a Source that probes the targets of the endpoints having
+// a health-check property and drops the unhealthy ones. The targets are probed on
+// every call of Endpoints, i.e. once per synchronization.
+type healthCheckSource struct {
+	source source.Source
+	cfg    HealthCheckConfig
+	// probe returns an error when the target fails the probe, replaced in tests.
+	probe func(ctx context.Context, target healthTarget, timeout time.Duration) error
+
+	mu     sync.Mutex
+	health map[healthTarget]*targetHealth
+}
+
+// NewHealthCheckSource creates a new healthCheckSource wrapping the provided Source.
+func NewHealthCheckSource(source source.Source, cfg HealthCheckConfig) source.Source {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultHealthCheckTimeout
+	}
+	cfg.FailureThreshold = max(cfg.FailureThreshold, 1)
+	cfg.SuccessThreshold = max(cfg.SuccessThreshold, 1)
+	return &healthCheckSource{
+		source: source,
+		cfg:    cfg,
+		probe:  probeTarget,
+		health: make(map[healthTarget]*targetHealth),
+	}
+}
+
+// Endpoints collects endpoints from its wrapped source and drops their unhealthy targets.
+func (s *healthCheckSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	probes := make(map[*endpoint.Endpoint]healthProbe)
+	var targets []healthTarget
+	seen := make(map[healthTarget]bool)
+	for _, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		value, ok := ep.GetProviderSpecificProperty(endpoint.ProviderSpecificHealthCheck)
+		if !ok || !isAddressRecordType(ep.RecordType) {
+			continue
+		}
+		probe, err := parseHealthProbe(value)
+		if err != nil {
+			log.Warnf("Not probing the targets of %s: %v", ep.DNSName, err)
+			continue
+		}
+		probes[ep] = probe
+		for _, target := range ep.Targets {
+			ht := healthTarget{probe: probe, dnsName: ep.DNSName, target: target}
+			if !seen[ht] {
+				seen[ht] = true
+				targets = append(targets, ht)
+			}
+		}
+	}
+	s.probeAll(ctx, targets)
+
+	for i, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		probe, ok := probes[ep]
+		if !ok {
+			dropHealthCheckProperty(ep)
+			continue
+		}
+		healthy := make(endpoint.Targets, 0, len(ep.Targets))
+		for _, target := range ep.Targets {
+			if !s.isUnhealthy(healthTarget{probe: probe, dnsName: ep.DNSName, target: target}) {
+				healthy = append(healthy, target)
+			}
+		}
+		switch {
+		case len(healthy) == len(ep.Targets):
+			dropHealthCheckProperty(ep)
+		case len(healthy) == 0:
+			// failing open: publishing no target at all would be worse than
+			// publishing failing ones, e.g. when the probe itself is wrong
+			log.Warnf("All the targets of %s fail the health check %s, keeping them", ep.DNSName, probe)
+			dropHealthCheckProperty(ep)
+		default:
+			log.Infof("Dropping the targets of %s failing the health check %s: keeping %v out of %v", ep.DNSName, probe, healthy, ep.Targets)
+			healthCheckUnhealthyTargets.AddWithLabels(float64(len(ep.Targets)-len(healthy)), ep.RecordType, endpointSource(ep))
+			out := ep.DeepCopy()
+			out.Targets = healthy
+			dropHealthCheckProperty(out)
+			endpoints[i] = out
+		}
+	}
+	return endpoints, nil
+}
+
+// probeAll probes the targets concurrently and records the results, forgetting
+// the targets no longer probed.
+func (s *healthCheckSource) probeAll(ctx context.Context, targets []healthTarget) {
+	results := make([]bool, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Go(func() {
+			err := s.probe(ctx, target, s.cfg.Timeout)
+			if err != nil {
+				log.Debugf("Health check %s of %s for %s failed: %v", target.probe, target.target, target.dnsName, err)
+			}
+			results[i] = err == nil
+			healthCheckProbesTotal.CounterVec.WithLabelValues(target.probe.protocol, probeResult(err)).Inc()
+		})
+	}
+	wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	health := make(map[healthTarget]*targetHealth, len(targets))
+	for i, target := range targets {
+		h, ok := s.health[target]
+		if !ok {
+			// targets are healthy until proven otherwise
+			h = &targetHealth{}
+		}
+		wasUnhealthy := h.unhealthy
+		h.record(results[i], s.cfg)
+		if h.unhealthy != wasUnhealthy {
+			log.Infof("Target %s of %s is now %s according to the health check %s", target.target, target.dnsName, healthState(h.unhealthy), target.probe)
+		}
+		health[target] = h
+	}
+	s.health = health
+}
+
+func (s *healthCheckSource) isUnhealthy(target healthTarget) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.health[target]
+	return ok && h.unhealthy
+}
+
+func (s *healthCheckSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("healthCheckSource: adding event handler")
+	s.source.AddEventHandler(ctx, handler)
+}
+
+func probeResult(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+func healthState(unhealthy bool) string {
+	if unhealthy {
+		return "unhealthy"
+	}
+	return "healthy"
+}
+
+// probeTarget connects to the target on the port of the probe and, for the http
+// and https probes, expects a 2xx or 3xx response to a GET request for the DNS
+// name of the endpoint.
+func probeTarget(ctx context.Context, target healthTarget, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	address := net.JoinHostPort(target.target, target.probe.port)
+	dialer := &net.Dialer{}
+	if target.probe.protocol == healthCheckTCP {
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	host := target.dnsName
+	if strings.HasPrefix(host, "*") {
+		host = target.target
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			// the request is for the DNS name, but must reach this very target
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, address)
+			},
+			// the probe checks the availability of the target, not its certificate
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // G402: see above
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	url := target.probe.protocol + "://" + net.JoinHostPort(host, target.probe.port) + target.probe.path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// dropHealthCheckProperty removes the health check from the endpoint so it never
+// reaches a provider. The provider-specific slice is cloned rather than modified
+// in place, as for the target-from property.
+func dropHealthCheckProperty(ep *endpoint.Endpoint) {
+	isHealthCheck := func(p endpoint.ProviderSpecificProperty) bool {
+		return p.Name == endpoint.ProviderSpecificHealthCheck
+	}
+	if slices.ContainsFunc(ep.ProviderSpecific, isHealthCheck) {
+		ep.ProviderSpecific = slices.DeleteFunc(slices.Clone(ep.ProviderSpecific), isHealthCheck)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Validates that healthCheckSource is a Source
+var _ source.Source = &healthCheckSource{}
+
+func TestParseHealthProbe(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected healthProbe
+		err      string
+	}{
+		{value: "tcp:443", expected: healthProbe{protocol: "tcp", port: "443"}},
+		{value: " HTTP:8080 ", expected: healthProbe{protocol: "http", port: "8080", path: "/"}},
+		{value: "https:8443/healthz?full=1", expected: healthProbe{protocol: "https", port: "8443", path: "/healthz?full=1"}},
+		{value: "443", err: "is not <protocol>:<port>[<path>]"},
+		{value: "udp:53", err: `unsupported protocol "udp"`},
+		{value: "tcp:443/healthz", err: "a tcp probe has no path"},
+		{value: "http:0/", err: `invalid port "0"`},
+		{value: "http:http/", err: `invalid port "http"`},
+		{value: "tcp:65536", err: `invalid port "65536"`},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			probe, err := parseHealthProbe(tc.value)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, probe)
+		})
+	}
+}
+
+func TestTargetHealthRecord(t *testing.T) {
+	cfg := HealthCheckConfig{FailureThreshold: 2, SuccessThreshold: 2}
+	h := &targetHealth{}
+
+	for i, step := range []struct {
+		ok        bool
+		unhealthy bool
+	}{
+		{ok: false, unhealthy: false},
+		{ok: true, unhealthy: false},
+		{ok: false, unhealthy: false},
+		{ok: false, unhealthy: true},
+		{ok: true, unhealthy: true},
+		{ok: false, unhealthy: true},
+		{ok: true, unhealthy: true},
+		{ok: true, unhealthy: false},
+	} {
+		h.record(step.ok, cfg)
+		assert.Equal(t, step.unhealthy, h.unhealthy, "step %d", i)
+	}
+}
+
+// fakeProber fails the probes of the targets it is told to.
+type fakeProber struct {
+	mu      sync.Mutex
+	failing map[string]bool
+	probed  []healthTarget
+}
+
+func (f *fakeProber) probe(_ context.Context, target healthTarget, _ time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.probed = append(f.probed, target)
+	if f.failing[target.target] {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+// newFakeHealthCheckSource returns a healthCheckSource probing with the prober,
+// its wrapped source returning fresh endpoints on each of the calls.
+func newFakeHealthCheckSource(t *testing.T, endpoints func() []*endpoint.Endpoint, calls int, prober *fakeProber, cfg HealthCheckConfig) source.Source {
+	t.Helper()
+	mockSource := new(testutils.MockSource)
+	for range calls {
+		mockSource.On("Endpoints").Return(endpoints(), nil).Once()
+	}
+	src := NewHealthCheckSource(mockSource, cfg)
+	src.(*healthCheckSource).probe = prober.probe
+	return src
+}
+
+func TestHealthCheckSourceEndpoints(t *testing.T) {
+	endpoints := func() []*endpoint.Endpoint {
+		return []*endpoint.Endpoint{
+			endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "10.0.0.1", "10.0.0.2").
+				WithProviderSpecific(endpoint.ProviderSpecificHealthCheck, "http:80/healthz"),
+			endpoint.NewEndpoint("db.example.org", endpoint.RecordTypeA, "10.0.0.3").
+				WithProviderSpecific(endpoint.ProviderSpecificHealthCheck, "tcp:5432"),
+			endpoint.NewEndpoint("alias.example.org", endpoint.RecordTypeCNAME, "web.example.org").
+				WithProviderSpecific(endpoint.ProviderSpecificHealthCheck, "tcp:80"),
+			endpoint.NewEndpoint("plain.example.org", endpoint.RecordTypeA, "10.0.0.2"),
+			endpoint.NewEndpoint("txt.example.org", endpoint.RecordTypeTXT, "10.0.0.2").
+				WithProviderSpecific(endpoint.ProviderSpecificHealthCheck, "tcp:80"),
+		}
+	}
+	prober := &fakeProber{failing: map[string]bool{"10.0.0.2": true, "10.0.0.3": true}}
+	src := newFakeHealthCheckSource(t, endpoints, 3, prober, HealthCheckConfig{FailureThreshold: 2, SuccessThreshold: 1})
+
+	// the failing targets are kept until they reach the failure threshold
+	got, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{"10.0.0.1", "10.0.0.2"}, got[0].Targets)
+	assert.Len(t, prober.probed, 4, "only the A, AAAA and CNAME records having a health check are probed")
+
+	got, err = src.Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{"10.0.0.1"}, got[0].Targets)
+	// failing open, a record is never left without targets
+	assert.Equal(t, endpoint.Targets{"10.0.0.3"}, got[1].Targets)
+	assert.Equal(t, endpoint.Targets{"web.example.org"}, got[2].Targets)
+	assert.Equal(t, endpoint.Targets{"10.0.0.2"}, got[3].Targets, "the targets of endpoints without health check are kept")
+	assert.Equal(t, endpoint.Targets{"10.0.0.2"}, got[4].Targets, "the targets of other record types are kept")
+	for _, ep := range got {
+		_, ok := ep.GetProviderSpecificProperty(endpoint.ProviderSpecificHealthCheck)
+		assert.False(t, ok, "the health check property of %s should be dropped", ep.DNSName)
+	}
+
+	// a recovered target is restored after the success threshold
+	prober.failing = nil
+	got, err = src.Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{"10.0.0.1", "10.0.0.2"}, got[0].Targets)
+}
+
+func TestHealthCheckSourceInvalidProbe(t *testing.T) {
+	endpoints := func() []*endpoint.Endpoint {
+		return []*endpoint.Endpoint{
+			endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "10.0.0.1").
+				WithProviderSpecific(endpoint.ProviderSpecificHealthCheck, "icmp:0"),
+		}
+	}
+	prober := &fakeProber{failing: map[string]bool{"10.0.0.1": true}}
+	src := newFakeHealthCheckSource(t, endpoints, 1, prober, HealthCheckConfig{})
+
+	got, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, prober.probed)
+	assert.Equal(t, endpoint.Targets{"10.0.0.1"}, got[0].Targets)
+	assert.Empty(t, got[0].ProviderSpecific)
+}
+
+func TestProbeTarget(t *testing.T) {
+	var host string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		switch r.URL.Path {
+		case "/healthz":
+			w.WriteHeader(http.StatusOK)
+		case "/moved":
+			http.Redirect(w, r, "/elsewhere", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	ip, port, err := net.SplitHostPort(u.Host)
+	require.NoError(t, err)
+
+	target := func(probe, dnsName string) healthTarget {
+		p, err := parseHealthProbe(probe)
+		require.NoError(t, err)
+		return healthTarget{probe: p, dnsName: dnsName, target: ip}
+	}
+
+	require.NoError(t, probeTarget(t.Context(), target("http:"+port+"/healthz", "web.example.org"), time.Second))
+	assert.Equal(t, net.JoinHostPort("web.example.org", port), host, "the request should be for the DNS name")
+	require.NoError(t, probeTarget(t.Context(), target("http:"+port+"/moved", "web.example.org"), time.Second))
+	require.NoError(t, probeTarget(t.Context(), target("tcp:"+port, "web.example.org"), time.Second))
+	require.NoError(t, probeTarget(t.Context(), target("http:"+port+"/healthz", "*.example.org"), time.Second))
+	assert.Equal(t, u.Host, host, "a wildcard should be probed by its target")
+
+	assert.ErrorContains(t, probeTarget(t.Context(), target("http:"+port+"/down", "web.example.org"), time.Second), "unexpected status code 503")
+
+	srv.Close()
+	assert.Error(t, probeTarget(t.Context(), target("tcp:"+port, "web.example.org"), time.Second))
+}
@@ -41,6 +41,69 @@ var (
 		},
 		[]string{"record_type", "source_type"},
 	)
+
+	invalidProviderSpecificProperties = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "source",
+			Name:      "invalid_provider_specific_properties",
+			Help:      "Number of provider-specific properties currently dropped due to failed validation, partitioned by record type and source.",
+		},
+		[]string{"record_type", "source_type"},
+	)
+
+	mergedEndpoints = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "source",
+			Name:      "merged_endpoints",
+			Help:      "Number of endpoints currently merged into an endpoint of another resource, partitioned by record type and source.",
+		},
+		[]string{"record_type", "source_type"},
+	)
+
+	conflictingEndpoints = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "source",
+			Name:      "conflicting_endpoints",
+			Help:      "Number of endpoints currently competing for the same record without being mergeable, partitioned by record type and source.",
+		},
+		[]string{"record_type", "source_type"},
+	)
+
+	namespaceCollisionEndpoints = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "source",
+			Name:      "namespace_collision_endpoints",
+			Help:      "Number of endpoints currently dropped because their DNS name is claimed by resources of another namespace, partitioned by record type and source.",
+		},
+		[]string{"record_type", "source_type"},
+	)
+
+	healthCheckUnhealthyTargets = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "source",
+			Name:      "health_check_unhealthy_targets",
+			Help:      "Number of targets currently dropped because they fail their health check, partitioned by record type and source.",
+		},
+		[]string{"record_type", "source_type"},
+	)
+
+	healthCheckProbesTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "source",
+			Name:      "health_check_probes_total",
+			Help:      "Number of health check probes of the targets, partitioned by protocol and result.",
+		},
+		[]string{"protocol", "result"},
+	)
+
+	sourceTimeouts = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "source",
+			Name:      "timeouts_total",
+			Help:      "Number of times a source exceeded its --source-timeout budget while listing endpoints, partitioned by source.",
+		},
+		[]string{"source_type"},
+	)
 )
 
 // endpointSource returns the source type from the endpoint's object reference,
@@ -60,9 +123,21 @@ func endpointSource(ep *endpoint.Endpoint) string {
 func resetMetrics() {
 	invalidEndpoints.Reset()
 	deduplicatedEndpoints.Reset()
+	invalidProviderSpecificProperties.Reset()
+	mergedEndpoints.Reset()
+	conflictingEndpoints.Reset()
+	namespaceCollisionEndpoints.Reset()
+	healthCheckUnhealthyTargets.Reset()
 }
 
 func init() {
 	metrics.RegisterMetric.MustRegister(invalidEndpoints)
 	metrics.RegisterMetric.MustRegister(deduplicatedEndpoints)
+	metrics.RegisterMetric.MustRegister(invalidProviderSpecificProperties)
+	metrics.RegisterMetric.MustRegister(mergedEndpoints)
+	metrics.RegisterMetric.MustRegister(conflictingEndpoints)
+	metrics.RegisterMetric.MustRegister(namespaceCollisionEndpoints)
+	metrics.RegisterMetric.MustRegister(healthCheckUnhealthyTargets)
+	metrics.RegisterMetric.MustRegister(healthCheckProbesTotal)
+	metrics.RegisterMetric.MustRegister(sourceTimeouts)
 }
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// namespaceDefaultProperties maps the annotations read from Namespace objects to
+// the provider-specific properties they default on the endpoints of the resources
+// in the namespace. The keys depend on the annotation prefix, so they are not
+// resolved before it is set.
+func namespaceDefaultProperties() map[string]string {
+	return map[string]string{
+		annotations.CloudflareProxiedKey: annotations.CloudflareProxiedProperty,
+		annotations.CloudflareRegionKey:  annotations.CloudflareRegionKeyProperty,
+	}
+}
+
+// NamespaceDefaults looks up the default annotations of namespaces, kept up to
+// date by an informer.
+type NamespaceDefaults struct {
+	namespaces corev1listers.NamespaceLister
+	informer   cache.SharedIndexInformer
+}
+
+// NewNamespaceDefaults starts the namespace informer.
+func NewNamespaceDefaults(ctx context.Context, kubeClient kubernetes.Interface, listPageSize int) (*NamespaceDefaults, error) {
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0,
+		kubeinformers.WithTweakListOptions(informers.ListPageSize(listPageSize)))
+	informer := informerFactory.Core().V1().Namespaces()
+	informers.MustSetTransform(informer.Informer(), informers.TransformerWithOptions[*corev1.Namespace](
+		informers.TransformRemoveManagedFields(),
+		informers.TransformRemoveLastAppliedConfig(),
+	))
+	d := &NamespaceDefaults{namespaces: informer.Lister(), informer: informer.Informer()}
+	informers.MustAddEventHandler(d.informer, informers.DefaultEventHandler())
+	informers.TrackWatch(d.informer, "namespace-defaults/namespace")
+
+	informerFactory.Start(ctx.Done())
+	if err := informers.WaitForCacheSync(ctx, informerFactory); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// defaults returns the provider-specific properties defaulted by the annotations
+// of namespace.
+func (d *NamespaceDefaults) defaults(namespace string) map[string]string {
+	ns, err := d.namespaces.Get(namespace)
+	if err != nil {
+		return nil
+	}
+	properties := make(map[string]string)
+	for key, property := range namespaceDefaultProperties() {
+		if value, ok := ns.Annotations[key]; ok {
+			properties[property] = value
+		}
+	}
+	return properties
+}
+
+// namespaceDefaultsSource is a Source that sets the provider-specific properties
+// annotated on the namespace of the resources which produced an endpoint, when the
+// resources do not set them. Together with the provider flags this gives the
+// precedence: resource annotation, then namespace annotation, then flag.
+type namespaceDefaultsSource struct {
+	source   source.Source
+	defaults *NamespaceDefaults
+}
+
+// NewNamespaceDefaultsSource creates a new namespaceDefaultsSource wrapping the provided Source.
+func NewNamespaceDefaultsSource(source source.Source, defaults *NamespaceDefaults) source.Source {
+	return &namespaceDefaultsSource{source: source, defaults: defaults}
+}
+
+// Endpoints collects endpoints from its wrapped source and applies the namespace defaults.
+func (s *namespaceDefaultsSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		properties := slices.Clone(ep.ProviderSpecific)
+		for property, value := range s.namespaceDefaults(ep) {
+			if _, ok := ep.GetProviderSpecificProperty(property); !ok {
+				properties = append(properties, endpoint.ProviderSpecificProperty{Name: property, Value: value})
+			}
+		}
+		if len(properties) > len(ep.ProviderSpecific) {
+			ep.ProviderSpecific = properties
+		}
+	}
+	return endpoints, nil
+}
+
+// namespaceDefaults returns the defaults shared by the namespaces of the resources
+// which produced ep. A property the namespaces disagree on is not defaulted.
+func (s *namespaceDefaultsSource) namespaceDefaults(ep *endpoint.Endpoint) map[string]string {
+	namespaces := endpointNamespaces(ep)
+	if len(namespaces) == 0 {
+		return nil
+	}
+	shared := s.defaults.defaults(namespaces[0])
+	for _, namespace := range namespaces[1:] {
+		other := s.defaults.defaults(namespace)
+		for property, value := range shared {
+			if other[property] != value {
+				log.Debugf("namespaceDefaultsSource: namespaces of %s disagree on %s, not defaulting it", ep.DNSName, property)
+				delete(shared, property)
+			}
+		}
+	}
+	return shared
+}
+
+// AddEventHandler also triggers the handler on changes of the namespace annotations.
+func (s *namespaceDefaultsSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("namespaceDefaultsSource: adding event handler")
+	informers.MustAddEventHandler(s.defaults.informer, cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj any) {
+			if !maps.Equal(namespaceDefaultAnnotations(oldObj), namespaceDefaultAnnotations(newObj)) {
+				handler()
+			}
+		},
+	})
+	s.source.AddEventHandler(ctx, handler)
+}
+
+// namespaceDefaultAnnotations returns the default annotations of a namespace object.
+func namespaceDefaultAnnotations(obj any) map[string]string {
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return nil
+	}
+	defaults := make(map[string]string)
+	for key := range namespaceDefaultProperties() {
+		if value, ok := ns.Annotations[key]; ok {
+			defaults[key] = value
+		}
+	}
+	return defaults
+}
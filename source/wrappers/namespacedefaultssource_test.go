@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+var _ source.Source = &namespaceDefaultsSource{}
+
+func TestNamespaceDefaultsSourceEndpoints(t *testing.T) {
+	client := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "proxied", Annotations: map[string]string{
+			annotations.CloudflareProxiedKey: "true",
+			annotations.CloudflareRegionKey:  "eu",
+		}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "direct", Annotations: map[string]string{
+			annotations.CloudflareProxiedKey: "false",
+			annotations.CloudflareRegionKey:  "eu",
+		}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "plain"}},
+	)
+	defaults, err := NewNamespaceDefaults(t.Context(), client, 0)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name     string
+		resource string
+		specific endpoint.ProviderSpecific
+		expected endpoint.ProviderSpecific
+	}{
+		{
+			name:     "namespace annotations are applied",
+			resource: "service/proxied/app",
+			expected: endpoint.ProviderSpecific{
+				{Name: annotations.CloudflareProxiedProperty, Value: "true"},
+				{Name: annotations.CloudflareRegionKeyProperty, Value: "eu"},
+			},
+		},
+		{
+			name:     "resource annotations take precedence",
+			resource: "service/proxied/app",
+			specific: endpoint.ProviderSpecific{{Name: annotations.CloudflareProxiedProperty, Value: "false"}},
+			expected: endpoint.ProviderSpecific{
+				{Name: annotations.CloudflareProxiedProperty, Value: "false"},
+				{Name: annotations.CloudflareRegionKeyProperty, Value: "eu"},
+			},
+		},
+		{
+			name:     "namespace without annotations",
+			resource: "service/plain/app",
+		},
+		{
+			name:     "unknown namespace",
+			resource: "service/missing/app",
+		},
+		{
+			name:     "cluster-scoped resource",
+			resource: "node/app",
+		},
+		{
+			name:     "namespaces disagreeing are not applied",
+			resource: "service/direct/app;service/proxied/app",
+			expected: endpoint.ProviderSpecific{
+				{Name: annotations.CloudflareRegionKeyProperty, Value: "eu"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ep := endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.1").
+				WithLabel(endpoint.ResourceLabelKey, tt.resource)
+			ep.ProviderSpecific = tt.specific
+
+			src := NewNamespaceDefaultsSource(testutils.NewMockSource(ep), defaults)
+			got, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			assert.ElementsMatch(t, tt.expected, got[0].ProviderSpecific)
+		})
+	}
+}
+
+func TestNamespaceDefaultAnnotations(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Annotations: map[string]string{
+		annotations.CloudflareProxiedKey: "true",
+		"unrelated":                      "value",
+	}}}
+	assert.Equal(t, map[string]string{annotations.CloudflareProxiedKey: "true"}, namespaceDefaultAnnotations(ns))
+	assert.Nil(t, namespaceDefaultAnnotations("not a namespace"))
+}
+
+func TestNamespaceDefaultsSourceCustomPrefix(t *testing.T) {
+	t.Cleanup(func() { annotations.SetAnnotationPrefix(annotations.DefaultAnnotationPrefix) })
+	annotations.SetAnnotationPrefix("custom.io/")
+
+	client := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "proxied", Annotations: map[string]string{
+		"custom.io/cloudflare-proxied":                                "true",
+		annotations.DefaultAnnotationPrefix + "cloudflare-region-key": "eu",
+	}}})
+	defaults, err := NewNamespaceDefaults(t.Context(), client, 0)
+	require.NoError(t, err)
+
+	ep := endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.1").
+		WithLabel(endpoint.ResourceLabelKey, "service/proxied/app")
+	got, err := NewNamespaceDefaultsSource(testutils.NewMockSource(ep), defaults).Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, endpoint.ProviderSpecific{{Name: annotations.CloudflareProxiedProperty, Value: "true"}}, got[0].ProviderSpecific)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// SourceWrapper is a named stage of the source wrapper pipeline, applied on top
+// of the deduplicated combination of all sources.
+type SourceWrapper struct {
+	// Name identifies the wrapper in --source-wrapper-order and --disable-source-wrapper.
+	Name string
+	// Enabled reports whether the wrapper applies with the given Config. A nil
+	// Enabled always applies the wrapper.
+	Enabled func(cfg *Config) bool
+	// UsesProperties reports that the wrapper reads or sets provider-specific
+	// properties, which the post-processor strips: it must run before it.
+	UsesProperties bool
+	// Wrap returns src wrapped by this stage.
+	Wrap func(src source.Source, cfg *Config) (source.Source, error)
+}
+
+// builtinWrappers returns the built-in source wrappers in their default order,
+// excluding the post-processor which closes the pipeline.
+func builtinWrappers() []SourceWrapper {
+	return []SourceWrapper{
+		{
+			Name:           "namespace-collision",
+			UsesProperties: true,
+			Enabled:        func(cfg *Config) bool { return cfg.collisionPolicy != "" },
+			Wrap: func(src source.Source, cfg *Config) (source.Source, error) {
+				return NewCollisionSource(src, cfg.collisionPolicy, cfg.eventEmitter), nil
+			},
+		},
+		{
+			Name:           "target-from",
+			UsesProperties: true,
+			Enabled:        func(cfg *Config) bool { return cfg.targetFrom != nil },
+			Wrap: func(src source.Source, cfg *Config) (source.Source, error) {
+				return NewTargetFromSource(src, cfg.targetFrom), nil
+			},
+		},
+		{
+			Name:           "namespace-defaults",
+			UsesProperties: true,
+			Enabled:        func(cfg *Config) bool { return cfg.namespaceDefaults != nil },
+			Wrap: func(src source.Source, cfg *Config) (source.Source, error) {
+				return NewNamespaceDefaultsSource(src, cfg.namespaceDefaults), nil
+			},
+		},
+		{
+			Name: "cluster-records",
+			Enabled: func(cfg *Config) bool {
+				return cfg.recordPrefix != "" || cfg.recordSuffix != "" || cfg.recordSharedParent
+			},
+			Wrap: func(src source.Source, cfg *Config) (source.Source, error) {
+				return NewClusterRecordsSource(src, cfg.clusterName, cfg.recordPrefix, cfg.recordSuffix, cfg.recordSharedParent), nil
+			},
+		},
+		{
+			Name:           "view",
+			UsesProperties: true,
+			Enabled:        func(cfg *Config) bool { return cfg.view != "" },
+			Wrap: func(src source.Source, cfg *Config) (source.Source, error) {
+				return NewViewSource(src, cfg.view), nil
+			},
+		},
+		{
+			Name:    "nat64",
+			Enabled: func(cfg *Config) bool { return len(cfg.nat64Networks) > 0 },
+			Wrap: func(src source.Source, cfg *Config) (source.Source, error) {
+				wrapped, err := NewNAT64Source(src, cfg.nat64Networks)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create NAT64 source wrapper: %w", err)
+				}
+				return wrapped, nil
+			},
+		},
+		{
+			Name: "target-filter",
+			Enabled: func(cfg *Config) bool {
+				return endpoint.NewTargetNetFilterWithExclusions(cfg.targetNetFilter, cfg.excludeTargetNets).IsEnabled()
+			},
+			Wrap: func(src source.Source, cfg *Config) (source.Source, error) {
+				return NewTargetFilterSource(src, endpoint.NewTargetNetFilterWithExclusions(cfg.targetNetFilter, cfg.excludeTargetNets)), nil
+			},
+		},
+		{
+			Name:           "health-check",
+			UsesProperties: true,
+			Enabled:        func(cfg *Config) bool { return cfg.healthCheck != nil },
+			Wrap: func(src source.Source, cfg *Config) (source.Source, error) {
+				return NewHealthCheckSource(src, *cfg.healthCheck), nil
+			},
+		},
+		{
+			Name:    "ptr",
+			Enabled: func(cfg *Config) bool { return cfg.ptrSupported },
+			Wrap: func(src source.Source, cfg *Config) (source.Source, error) {
+				return NewPTRSource(src, cfg.createPTR), nil
+			},
+		},
+	}
+}
+
+// postProcessorWrapper normalizes endpoints, it is the last wrapper by default.
+func postProcessorWrapper() SourceWrapper {
+	return SourceWrapper{
+		Name: "post-processor",
+		Wrap: func(src source.Source, cfg *Config) (source.Source, error) {
+			return NewPostProcessor(src, WithTTL(cfg.minTTL), WithPostProcessorPreferAlias(cfg.preferAlias),
+				WithPostProcessorProvider(cfg.provider), WithPostProcessorPropertyValidator(cfg.propertyValidator)), nil
+		},
+	}
+}
+
+// pipeline returns the source wrappers to apply in order: the wrappers listed
+// in the configured order first, then the remaining ones in default order, with
+// the disabled wrappers removed. Wrappers using provider-specific properties
+// can't follow the post-processor.
+func (o *Config) pipeline() ([]SourceWrapper, error) {
+	all := append(builtinWrappers(), o.customWrappers...)
+	all = append(all, postProcessorWrapper())
+
+	byName := make(map[string]SourceWrapper, len(all))
+	for _, w := range all {
+		if w.Name == "" || w.Wrap == nil {
+			return nil, fmt.Errorf("source wrapper %q must have a name and a wrap function", w.Name)
+		}
+		if _, ok := byName[w.Name]; ok || w.Name == "dedup" {
+			return nil, fmt.Errorf("duplicate source wrapper %q", w.Name)
+		}
+		byName[w.Name] = w
+	}
+	for _, name := range slices.Concat(o.wrapperOrder, o.disabledWrappers) {
+		if _, ok := byName[name]; !ok {
+			return nil, fmt.Errorf("unknown source wrapper %q", name)
+		}
+	}
+
+	pipeline := make([]SourceWrapper, 0, len(all))
+	for _, name := range o.wrapperOrder {
+		if !slices.ContainsFunc(pipeline, func(w SourceWrapper) bool { return w.Name == name }) {
+			pipeline = append(pipeline, byName[name])
+		}
+	}
+	for _, w := range all {
+		if !slices.Contains(o.wrapperOrder, w.Name) {
+			pipeline = append(pipeline, w)
+		}
+	}
+	pipeline = slices.DeleteFunc(pipeline, func(w SourceWrapper) bool {
+		return slices.Contains(o.disabledWrappers, w.Name)
+	})
+	if i := slices.IndexFunc(pipeline, func(w SourceWrapper) bool { return w.Name == postProcessorWrapper().Name }); i >= 0 {
+		for _, w := range pipeline[i+1:] {
+			if w.UsesProperties {
+				return nil, fmt.Errorf("source wrapper %q must be applied before the post-processor, which strips its provider-specific properties", w.Name)
+			}
+		}
+	}
+	return pipeline, nil
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// appendSource adds an endpoint to the endpoints of the wrapped source.
+type appendSource struct {
+	source.Source
+	ep *endpoint.Endpoint
+}
+
+func (s *appendSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	eps, err := s.Source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(eps, s.ep.DeepCopy()), nil
+}
+
+func appendWrapper(name string, ep *endpoint.Endpoint) SourceWrapper {
+	return SourceWrapper{
+		Name: name,
+		Wrap: func(src source.Source, _ *Config) (source.Source, error) {
+			return &appendSource{Source: src, ep: ep}, nil
+		},
+	}
+}
+
+func pipelineNames(t *testing.T, cfg *Config) []string {
+	t.Helper()
+	pipeline, err := cfg.pipeline()
+	require.NoError(t, err)
+	var names []string
+	for _, w := range pipeline {
+		names = append(names, w.Name)
+	}
+	return names
+}
+
+func TestPipelineOrder(t *testing.T) {
+	custom := appendWrapper("custom", endpoint.NewEndpoint("custom.example.com", endpoint.RecordTypeA, "1.2.3.4"))
+
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected []string
+	}{
+		{
+			name:     "default order",
+			cfg:      NewConfig(),
+			expected: []string{"namespace-collision", "target-from", "namespace-defaults", "cluster-records", "view", "nat64", "target-filter", "health-check", "ptr", "post-processor"},
+		},
+		{
+			name:     "custom wrapper before post-processor",
+			cfg:      NewConfig(WithSourceWrapper(custom)),
+			expected: []string{"namespace-collision", "target-from", "namespace-defaults", "cluster-records", "view", "nat64", "target-filter", "health-check", "ptr", "custom", "post-processor"},
+		},
+		{
+			name:     "listed wrappers first",
+			cfg:      NewConfig(WithSourceWrapper(custom), WithSourceWrapperOrder([]string{"custom", "ptr"})),
+			expected: []string{"custom", "ptr", "namespace-collision", "target-from", "namespace-defaults", "cluster-records", "view", "nat64", "target-filter", "health-check", "post-processor"},
+		},
+		{
+			name:     "repeated wrapper applied once",
+			cfg:      NewConfig(WithSourceWrapperOrder([]string{"ptr", "ptr"})),
+			expected: []string{"ptr", "namespace-collision", "target-from", "namespace-defaults", "cluster-records", "view", "nat64", "target-filter", "health-check", "post-processor"},
+		},
+		{
+			name: "post-processor before wrappers without properties",
+			cfg: NewConfig(WithSourceWrapper(custom),
+				WithSourceWrapperOrder([]string{"namespace-collision", "target-from", "namespace-defaults", "view", "health-check", "post-processor"})),
+			expected: []string{"namespace-collision", "target-from", "namespace-defaults", "view", "health-check", "post-processor", "cluster-records", "nat64", "target-filter", "ptr", "custom"},
+		},
+		{
+			name:     "disabled wrappers",
+			cfg:      NewConfig(WithSourceWrapperOrder([]string{"ptr"}), WithDisabledSourceWrappers([]string{"ptr", "post-processor"})),
+			expected: []string{"namespace-collision", "target-from", "namespace-defaults", "cluster-records", "view", "nat64", "target-filter", "health-check"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, pipelineNames(t, tt.cfg))
+		})
+	}
+}
+
+func TestPipelineErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "unknown wrapper in order",
+			cfg:  NewConfig(WithSourceWrapperOrder([]string{"nat46"})),
+			err:  `unknown source wrapper "nat46"`,
+		},
+		{
+			name: "unknown disabled wrapper",
+			cfg:  NewConfig(WithDisabledSourceWrappers([]string{"dedup"})),
+			err:  `unknown source wrapper "dedup"`,
+		},
+		{
+			name: "custom wrapper shadowing a built-in",
+			cfg:  NewConfig(WithSourceWrapper(appendWrapper("ptr", nil))),
+			err:  `duplicate source wrapper "ptr"`,
+		},
+		{
+			name: "custom wrapper named dedup",
+			cfg:  NewConfig(WithSourceWrapper(appendWrapper("dedup", nil))),
+			err:  `duplicate source wrapper "dedup"`,
+		},
+		{
+			name: "post-processor before view",
+			cfg:  NewConfig(WithSourceWrapperOrder([]string{"post-processor", "view"})),
+			err:  `source wrapper "view" must be applied before the post-processor, which strips its provider-specific properties`,
+		},
+		{
+			name: "custom wrapper using properties after post-processor",
+			cfg: NewConfig(WithSourceWrapper(SourceWrapper{Name: "custom", UsesProperties: true, Wrap: appendWrapper("custom", nil).Wrap}),
+				WithSourceWrapperOrder([]string{"namespace-collision", "target-from", "namespace-defaults", "view", "health-check", "post-processor"})),
+			err: `source wrapper "custom" must be applied before the post-processor, which strips its provider-specific properties`,
+		},
+		{
+			name: "custom wrapper without wrap function",
+			cfg:  NewConfig(WithSourceWrapper(SourceWrapper{Name: "custom"})),
+			err:  `source wrapper "custom" must have a name and a wrap function`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := wrapSources(nil, tt.cfg)
+			assert.Nil(t, src)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestWrapSources_CustomWrapperOrder(t *testing.T) {
+	// an AAAA record in the NAT64 network gets an A record when added before the NAT64 wrapper
+	aaaa := endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "64:ff9b::102:304")
+
+	tests := []struct {
+		name     string
+		order    []string
+		expected []*endpoint.Endpoint
+	}{
+		{
+			name:  "custom wrapper after nat64",
+			order: nil,
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "64:ff9b::102:304"),
+			},
+		},
+		{
+			name:  "custom wrapper before nat64",
+			order: []string{"add-aaaa", "nat64"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "64:ff9b::102:304"),
+				endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig(
+				WithNAT64Networks([]string{"64:ff9b::/96"}),
+				WithSourceWrapper(appendWrapper("add-aaaa", aaaa)),
+				WithSourceWrapperOrder(tt.order),
+			)
+			src, err := wrapSources([]source.Source{testutils.NewMockSource()}, cfg)
+			require.NoError(t, err)
+			assert.True(t, cfg.isSourceWrapperInstrumented("add-aaaa"))
+
+			endpoints, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+			assert.True(t, testutils.SameEndpoints(endpoints, tt.expected), "got %v", endpoints)
+		})
+	}
+}
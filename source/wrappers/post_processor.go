@@ -18,6 +18,7 @@ package wrappers
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"time"
 
@@ -33,10 +34,11 @@ type postProcessor struct {
 }
 
 type PostProcessorConfig struct {
-	ttl          int64
-	provider     string
-	preferAlias  bool
-	isConfigured bool
+	ttl               int64
+	provider          string
+	preferAlias       bool
+	propertyValidator *endpoint.PropertyValidator
+	isConfigured      bool
 }
 
 type PostProcessorOption func(*PostProcessorConfig)
@@ -73,6 +75,17 @@ func WithPostProcessorPreferAlias(enabled bool) PostProcessorOption {
 	}
 }
 
+// WithPostProcessorPropertyValidator enables validation of provider-specific
+// properties; invalid properties are dropped with a warning.
+func WithPostProcessorPropertyValidator(v *endpoint.PropertyValidator) PostProcessorOption {
+	return func(cfg *PostProcessorConfig) {
+		if v.IsEnabled() {
+			cfg.isConfigured = true
+			cfg.propertyValidator = v
+		}
+	}
+}
+
 func NewPostProcessor(source source.Source, opts ...PostProcessorOption) source.Source {
 	cfg := PostProcessorConfig{}
 	for _, opt := range opts {
@@ -97,6 +110,10 @@ func (pp *postProcessor) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 		}
 		ep.WithMinTTL(pp.cfg.ttl)
 		ep.RetainProviderProperties(pp.cfg.provider)
+		dropViewProperties(ep)
+		dropTargetFromProperty(ep)
+		dropCollisionPriorityProperty(ep)
+		pp.dropInvalidProperties(ep)
 		// Set alias annotation for CNAME records when preferAlias is enabled
 		// Only set if not already explicitly configured at the source level
 		if pp.cfg.preferAlias && ep.RecordType == endpoint.RecordTypeCNAME {
@@ -109,6 +126,23 @@ func (pp *postProcessor) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 	return endpoints, nil
 }
 
+// dropInvalidProperties removes provider-specific properties rejected by the
+// configured validator, so they fail early instead of at apply time.
+func (pp *postProcessor) dropInvalidProperties(ep *endpoint.Endpoint) {
+	if pp.cfg.propertyValidator == nil {
+		return
+	}
+	ep.ProviderSpecific = slices.DeleteFunc(ep.ProviderSpecific, func(prop endpoint.ProviderSpecificProperty) bool {
+		err := pp.cfg.propertyValidator.Validate(prop)
+		if err == nil {
+			return false
+		}
+		log.Warnf("Dropping provider-specific property of endpoint %s: %v", ep, err)
+		invalidProviderSpecificProperties.AddWithLabels(1, ep.RecordType, endpointSource(ep))
+		return true
+	})
+}
+
 func (pp *postProcessor) AddEventHandler(ctx context.Context, handler func()) {
 	log.Debug("postProcessor: adding event handler")
 	pp.source.AddEventHandler(ctx, handler)
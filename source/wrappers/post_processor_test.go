@@ -525,3 +525,40 @@ func TestPostProcessorEndpointsWithPreferAlias(t *testing.T) {
 		})
 	}
 }
+
+func TestPostProcessorEndpointsWithPropertyValidator(t *testing.T) {
+	invalidProviderSpecificProperties.Reset()
+
+	validator := endpoint.NewPropertyValidator(
+		endpoint.PropertySpec{Name: "aws/weight", Type: endpoint.PropertyTypeInt},
+		endpoint.PropertySpec{Name: "aws/failover", Type: endpoint.PropertyTypeString, Allowed: []string{"PRIMARY", "SECONDARY"}},
+	)
+	ep := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	ep.ProviderSpecific = endpoint.ProviderSpecific{
+		{Name: "aws/weight", Value: "10"},
+		{Name: "aws/failover", Value: "TERTIARY"},
+		{Name: "aws/wieght", Value: "5"},
+		{Name: "webhook/custom", Value: "x"},
+	}
+
+	src := NewPostProcessor(testutils.NewMockSource(ep), WithPostProcessorPropertyValidator(validator))
+	result, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	assert.Equal(t, endpoint.ProviderSpecific{
+		{Name: "aws/weight", Value: "10"},
+		{Name: "webhook/custom", Value: "x"},
+	}, result[0].ProviderSpecific)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(
+		t, 2.0, invalidProviderSpecificProperties.Gauge,
+		map[string]string{"record_type": "a", "source_type": "unknown"},
+	)
+}
+
+func TestWithPostProcessorPropertyValidator_Disabled(t *testing.T) {
+	cfg := &PostProcessorConfig{}
+	WithPostProcessorPropertyValidator(endpoint.NewPropertyValidator())(cfg)
+	assert.False(t, cfg.isConfigured)
+	assert.Nil(t, cfg.propertyValidator)
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/sets"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+const (
+	// TargetFromConfigMap is the kind of target-from references to a ConfigMap key.
+	TargetFromConfigMap = "configmap"
+	// TargetFromSecret is the kind of target-from references to a Secret key.
+	TargetFromSecret = "secret"
+)
+
+// TargetFromResolver looks up the targets referenced by the target-from
+// annotation in ConfigMaps and Secrets, kept up to date by informers.
+type TargetFromResolver struct {
+	configMaps corev1listers.ConfigMapLister
+	secrets    corev1listers.SecretLister
+	informers  []cache.SharedIndexInformer
+
+	// referenced holds the <namespace>/<name> of the objects referenced by the
+	// last collected endpoints, changes to other objects are ignored.
+	referenced   sets.Set[string]
+	referencedMu sync.Mutex
+}
+
+// NewTargetFromResolver starts the informers of the given kinds, configmap or
+// secret, in namespace or in all namespaces when empty.
+func NewTargetFromResolver(ctx context.Context, kubeClient kubernetes.Interface, namespace string, kinds []string) (*TargetFromResolver, error) {
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	r := &TargetFromResolver{}
+	for _, kind := range kinds {
+		switch kind {
+		case TargetFromConfigMap:
+			informer := informerFactory.Core().V1().ConfigMaps()
+			r.configMaps = informer.Lister()
+			r.informers = append(r.informers, informer.Informer())
+		case TargetFromSecret:
+			informer := informerFactory.Core().V1().Secrets()
+			r.secrets = informer.Lister()
+			r.informers = append(r.informers, informer.Informer())
+		default:
+			return nil, fmt.Errorf("unknown target-from kind %q", kind)
+		}
+	}
+	for i, informer := range r.informers {
+		informers.MustAddEventHandler(informer, informers.DefaultEventHandler())
+		informers.TrackWatch(informer, "target-from/"+kinds[i])
+	}
+
+	informerFactory.Start(ctx.Done())
+	if err := informers.WaitForCacheSync(ctx, informerFactory); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// targetRef is a parsed target-from reference, <kind>:[<namespace>/]<name>#<key>.
+type targetRef struct {
+	kind, namespace, name, key string
+}
+
+func parseTargetRef(value string) (targetRef, error) {
+	kind, rest, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok {
+		return targetRef{}, fmt.Errorf("invalid target-from reference %q, expected <kind>:[<namespace>/]<name>#<key>", value)
+	}
+	object, key, ok := strings.Cut(rest, "#")
+	if !ok || key == "" {
+		return targetRef{}, fmt.Errorf("invalid target-from reference %q, the key is missing", value)
+	}
+	ref := targetRef{kind: strings.ToLower(kind), name: object, key: key}
+	if namespace, name, ok := strings.Cut(object, "/"); ok {
+		ref.namespace, ref.name = namespace, name
+	}
+	if ref.name == "" {
+		return targetRef{}, fmt.Errorf("invalid target-from reference %q, the name is missing", value)
+	}
+	return ref, nil
+}
+
+// lookup returns the value of the referenced key.
+func (r *TargetFromResolver) lookup(ref targetRef) (string, error) {
+	switch ref.kind {
+	case TargetFromConfigMap:
+		if r.configMaps == nil {
+			return "", errors.New("configmap references are not enabled, see --target-from-kind")
+		}
+		cm, err := r.configMaps.ConfigMaps(ref.namespace).Get(ref.name)
+		if err != nil {
+			return "", err
+		}
+		value, ok := cm.Data[ref.key]
+		if !ok {
+			return "", fmt.Errorf("key %q not found in configmap %s/%s", ref.key, ref.namespace, ref.name)
+		}
+		return value, nil
+	case TargetFromSecret:
+		if r.secrets == nil {
+			return "", errors.New("secret references are not enabled, see --target-from-kind")
+		}
+		secret, err := r.secrets.Secrets(ref.namespace).Get(ref.name)
+		if err != nil {
+			return "", err
+		}
+		value, ok := secret.Data[ref.key]
+		if !ok {
+			return "", fmt.Errorf("key %q not found in secret %s/%s", ref.key, ref.namespace, ref.name)
+		}
+		return string(value), nil
+	default:
+		return "", fmt.Errorf("unknown target-from kind %q", ref.kind)
+	}
+}
+
+// resolve returns the targets referenced by the endpoint. A reference must stay
+// within the namespace of the resources which produced the endpoint, it defaults
+// to that namespace when omitted.
+func (r *TargetFromResolver) resolve(ep *endpoint.Endpoint, value string) (endpoint.Targets, error) {
+	ref, err := parseTargetRef(value)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := endpointNamespaces(ep)
+	if ref.namespace == "" {
+		if len(namespaces) != 1 {
+			return nil, fmt.Errorf("target-from reference %q needs a namespace", value)
+		}
+		ref.namespace = namespaces[0]
+	}
+	r.reference(ref.namespace + "/" + ref.name)
+	for _, namespace := range namespaces {
+		if namespace != ref.namespace {
+			return nil, fmt.Errorf("target-from reference %q is outside of namespace %s", value, namespace)
+		}
+	}
+
+	data, err := r.lookup(ref)
+	if err != nil {
+		return nil, err
+	}
+	var targets endpoint.Targets
+	for _, target := range strings.FieldsFunc(data, func(c rune) bool { return c == ',' || c == '\n' || c == ' ' }) {
+		targets = append(targets, target)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("target-from reference %q holds no target", value)
+	}
+	return targets, nil
+}
+
+// reference records a referenced object, see isReferenced.
+func (r *TargetFromResolver) reference(key string) {
+	r.referencedMu.Lock()
+	defer r.referencedMu.Unlock()
+	r.referenced.Insert(key)
+}
+
+// resetReferences forgets the referenced objects before collecting endpoints.
+func (r *TargetFromResolver) resetReferences() {
+	r.referencedMu.Lock()
+	defer r.referencedMu.Unlock()
+	r.referenced = sets.New[string]()
+}
+
+// isReferenced reports whether obj was referenced by the last collected endpoints.
+// ConfigMaps and Secrets of both kinds share the set: a few spurious events are
+// cheaper than tracking them separately.
+func (r *TargetFromResolver) isReferenced(obj any) bool {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	o, ok := obj.(metav1.Object)
+	if !ok {
+		return false
+	}
+	r.referencedMu.Lock()
+	defer r.referencedMu.Unlock()
+	return r.referenced.Has(o.GetNamespace() + "/" + o.GetName())
+}
+
+// targetFromSource is a Source that replaces the targets of endpoints carrying
+// a target-from reference, set with the target-from annotation, by the targets
+// stored in the referenced ConfigMap or Secret key. Endpoints whose reference
+// cannot be resolved are skipped rather than published with the default targets.
+type targetFromSource struct {
+	source   source.Source
+	resolver *TargetFromResolver
+}
+
+// NewTargetFromSource creates a new targetFromSource wrapping the provided Source.
+func NewTargetFromSource(source source.Source, resolver *TargetFromResolver) source.Source {
+	return &targetFromSource{source: source, resolver: resolver}
+}
+
+// Endpoints collects endpoints from its wrapped source and applies the referenced targets.
+func (s *targetFromSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.resolver.resetReferences()
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	replaced := make(map[viewGroupKey]bool)
+	for _, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		value, ok := ep.GetProviderSpecificProperty(endpoint.ProviderSpecificTargetFrom)
+		if !ok || !isAddressRecordType(ep.RecordType) {
+			dropTargetFromProperty(ep)
+			result = append(result, ep)
+			continue
+		}
+
+		key := viewGroupKey{dnsName: ep.DNSName, setIdentifier: ep.SetIdentifier, resource: ep.Labels[endpoint.ResourceLabelKey]}
+		if replaced[key] {
+			continue
+		}
+		replaced[key] = true
+
+		targets, err := s.resolver.resolve(ep, value)
+		if err != nil {
+			log.Warnf("Skipping endpoint %s: %v", ep.DNSName, err)
+			continue
+		}
+		for _, resolved := range endpoint.EndpointsForHostname(ep.DNSName, targets, ep.RecordTTL, nil, ep.SetIdentifier, "") {
+			out := ep.DeepCopy()
+			out.RecordType = resolved.RecordType
+			out.Targets = resolved.Targets
+			dropTargetFromProperty(out)
+			result = append(result, out)
+		}
+		log.Debugf("targetFromSource: using targets %v of %q for %s", targets, value, ep.DNSName)
+	}
+	return result, nil
+}
+
+// AddEventHandler also triggers the handler on changes of the referenced objects.
+func (s *targetFromSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("targetFromSource: adding event handler")
+	onChange := func(obj any) {
+		if s.resolver.isReferenced(obj) {
+			handler()
+		}
+	}
+	for _, informer := range s.resolver.informers {
+		informers.MustAddEventHandler(informer, cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj any) { onChange(obj) },
+			UpdateFunc: func(_, obj any) { onChange(obj) },
+			DeleteFunc: onChange,
+		})
+	}
+	s.source.AddEventHandler(ctx, handler)
+}
+
+// dropTargetFromProperty removes the target-from reference from the endpoint so it
+// never reaches a provider. The provider-specific slice is cloned rather than
+// modified in place, as for the view properties.
+func dropTargetFromProperty(ep *endpoint.Endpoint) {
+	isTargetFrom := func(p endpoint.ProviderSpecificProperty) bool {
+		return p.Name == endpoint.ProviderSpecificTargetFrom
+	}
+	if slices.ContainsFunc(ep.ProviderSpecific, isTargetFrom) {
+		ep.ProviderSpecific = slices.DeleteFunc(slices.Clone(ep.ProviderSpecific), isTargetFrom)
+	}
+}
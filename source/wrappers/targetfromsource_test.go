@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+)
+
+var _ source.Source = &targetFromSource{}
+
+func TestParseTargetRef(t *testing.T) {
+	for _, tt := range []struct {
+		value    string
+		expected targetRef
+		err      string
+	}{
+		{value: "configmap:ns/name#key", expected: targetRef{kind: "configmap", namespace: "ns", name: "name", key: "key"}},
+		{value: "Secret:name#key", expected: targetRef{kind: "secret", name: "name", key: "key"}},
+		{value: "name#key", err: "expected <kind>"},
+		{value: "configmap:ns/name", err: "key is missing"},
+		{value: "configmap:ns/#key", err: "name is missing"},
+	} {
+		t.Run(tt.value, func(t *testing.T) {
+			ref, err := parseTargetRef(tt.value)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ref)
+		})
+	}
+}
+
+func TestTargetFromSourceEndpoints(t *testing.T) {
+	client := fake.NewClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "lb"},
+			Data:       map[string]string{"ips": "10.0.0.1, 2001:db8::2", "empty": ""},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "lb"},
+			Data:       map[string][]byte{"hostname": []byte("lb.example.net")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "lb"},
+			Data:       map[string]string{"ips": "10.0.0.9"},
+		},
+	)
+	resolver, err := NewTargetFromResolver(t.Context(), client, "", []string{TargetFromConfigMap})
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name     string
+		ref      string
+		expected []*endpoint.Endpoint
+	}{
+		{
+			name: "configmap targets replace the default targets",
+			ref:  "configmap:default/lb#ips",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 300, "10.0.0.1").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::2").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+			},
+		},
+		{
+			name: "namespace defaults to the one of the resource",
+			ref:  "configmap:lb#ips",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 300, "10.0.0.1").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::2").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+			},
+		},
+		{name: "other namespace is refused", ref: "configmap:other/lb#ips"},
+		{name: "missing key", ref: "configmap:default/lb#missing"},
+		{name: "no target", ref: "configmap:default/lb#empty"},
+		{name: "kind not enabled", ref: "secret:default/lb#hostname"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints := endpoint.EndpointsForHostname("app.example.com", endpoint.Targets{"192.0.2.1"}, 300,
+				endpoint.ProviderSpecific{{Name: endpoint.ProviderSpecificTargetFrom, Value: tt.ref}}, "", "service/default/app")
+			endpoints = append(endpoints, endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "192.0.2.10"))
+
+			src := NewTargetFromSource(testutils.NewMockSource(endpoints...), resolver)
+			got, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+
+			expected := append(tt.expected, endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "192.0.2.10"))
+			testutils.ValidateEndpoints(t, got, expected)
+		})
+	}
+}
+
+func TestTargetFromResolverIsReferenced(t *testing.T) {
+	resolver, err := NewTargetFromResolver(t.Context(), fake.NewClientset(), "", []string{TargetFromConfigMap, TargetFromSecret})
+	require.NoError(t, err)
+	resolver.resetReferences()
+	resolver.reference("default/lb")
+
+	assert.True(t, resolver.isReferenced(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "lb"}}))
+	assert.False(t, resolver.isReferenced(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "leader"}}))
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// timeoutSource is a Source that bounds the time its wrapped source may take to
+// list endpoints. A source exceeding its budget fails the listing with an error
+// wrapping context.DeadlineExceeded and is counted in source_timeouts_total.
+type timeoutSource struct {
+	source  source.Source
+	name    string
+	timeout time.Duration
+}
+
+// NewTimeoutSource creates a new timeoutSource wrapping the source with the given name.
+func NewTimeoutSource(source source.Source, name string, timeout time.Duration) source.Source {
+	return &timeoutSource{source: source, name: name, timeout: timeout}
+}
+
+// Endpoints collects endpoints from its wrapped source within the time budget.
+func (s *timeoutSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	endpoints, err := s.source.Endpoints(ctx)
+	if err == nil {
+		return endpoints, nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		sourceTimeouts.CounterVec.WithLabelValues(s.name).Inc()
+		log.Warnf("Source %q exceeded its time budget of %s", s.name, s.timeout)
+		return nil, fmt.Errorf("source %q exceeded its time budget of %s: %w", s.name, s.timeout, err)
+	}
+	return nil, err
+}
+
+func (s *timeoutSource) AddEventHandler(ctx context.Context, handler func()) {
+	s.source.AddEventHandler(ctx, handler)
+}
+
+// withSourceTimeouts wraps each source with the timeout configured for its name,
+// falling back to the timeout stored under the empty name.
+func withSourceTimeouts(sources []source.Source, names []string, timeouts map[string]time.Duration) []source.Source {
+	if len(timeouts) == 0 {
+		return sources
+	}
+	wrapped := make([]source.Source, len(sources))
+	for i, src := range sources {
+		wrapped[i] = src
+		if i >= len(names) {
+			continue
+		}
+		timeout, ok := timeouts[names[i]]
+		if !ok {
+			timeout, ok = timeouts[""]
+		}
+		if ok {
+			wrapped[i] = NewTimeoutSource(src, names[i], timeout)
+		}
+	}
+	return wrapped
+}
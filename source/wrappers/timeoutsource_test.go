@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// slowSource blocks until its context is done, like a source stuck on a list call.
+type slowSource struct {
+	testutils.MockSource
+}
+
+func (s *slowSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func sourceTimeoutsCount(t *testing.T, name string) float64 {
+	t.Helper()
+	m := &dto.Metric{}
+	require.NoError(t, sourceTimeouts.CounterVec.WithLabelValues(name).Write(m))
+	return m.GetCounter().GetValue()
+}
+
+func TestTimeoutSourceWithinBudget(t *testing.T) {
+	ep := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	src := NewTimeoutSource(testutils.NewMockSource(ep), "service", time.Minute)
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{ep}, endpoints)
+}
+
+func TestTimeoutSourceExceedsBudget(t *testing.T) {
+	before := sourceTimeoutsCount(t, "ingress")
+	src := NewTimeoutSource(&slowSource{}, "ingress", 10*time.Millisecond)
+
+	_, err := src.Endpoints(t.Context())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, `source "ingress" exceeded its time budget of 10ms`)
+	assert.InDelta(t, 1.0, sourceTimeoutsCount(t, "ingress")-before, 0)
+}
+
+func TestTimeoutSourceOtherErrors(t *testing.T) {
+	before := sourceTimeoutsCount(t, "node")
+	failing := &testutils.MockSource{}
+	failing.On("Endpoints").Return(nil, errors.New("list failed"))
+
+	_, err := NewTimeoutSource(failing, "node", time.Minute).Endpoints(t.Context())
+	require.EqualError(t, err, "list failed")
+	assert.InDelta(t, 0.0, sourceTimeoutsCount(t, "node")-before, 0)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = NewTimeoutSource(&slowSource{}, "node", time.Minute).Endpoints(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.InDelta(t, 0.0, sourceTimeoutsCount(t, "node")-before, 0)
+}
+
+func TestWithSourceTimeouts(t *testing.T) {
+	service, ingress, node := testutils.NewMockSource(), testutils.NewMockSource(), testutils.NewMockSource()
+	sources := []source.Source{service, ingress, node}
+	names := []string{"service", "ingress", "node"}
+
+	assert.Equal(t, sources, withSourceTimeouts(sources, names, nil))
+
+	wrapped := withSourceTimeouts(sources, names, map[string]time.Duration{"ingress": time.Second})
+	assert.Same(t, service, wrapped[0])
+	assert.Equal(t, &timeoutSource{source: ingress, name: "ingress", timeout: time.Second}, wrapped[1])
+	assert.Same(t, node, wrapped[2])
+
+	wrapped = withSourceTimeouts(sources, names, map[string]time.Duration{"": time.Minute, "node": time.Second})
+	assert.Equal(t, &timeoutSource{source: service, name: "service", timeout: time.Minute}, wrapped[0])
+	assert.Equal(t, &timeoutSource{source: ingress, name: "ingress", timeout: time.Minute}, wrapped[1])
+	assert.Equal(t, &timeoutSource{source: node, name: "node", timeout: time.Second}, wrapped[2])
+}
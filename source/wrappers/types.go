@@ -17,11 +17,11 @@ limitations under the License.
 package wrappers
 
 import (
-	"fmt"
 	"time"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/sets"
+	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/source"
 )
 
@@ -34,9 +34,24 @@ type Config struct {
 	excludeTargetNets   []string
 	minTTL              time.Duration
 	preferAlias         bool
-	ptrSupported        bool             // PTR is in --managed-record-types
-	createPTR           bool             // --create-ptr default for all A/AAAA records
-	sourceWrappers      sets.Set[string] // set of source wrappers, e.g. "targetfilter", "nat64"
+	ptrSupported        bool                        // PTR is in --managed-record-types
+	createPTR           bool                        // --create-ptr default for all A/AAAA records
+	propertyValidator   *endpoint.PropertyValidator // validates provider-specific properties
+	mergeEndpoints      bool                        // merge endpoints of different resources in the dedup wrapper
+	eventEmitter        events.EventEmitter         // optional, used to report endpoint conflicts
+	sourceWrappers      sets.Set[string]            // set of source wrappers, e.g. "targetfilter", "nat64"
+	wrapperOrder        []string                    // --source-wrapper-order
+	disabledWrappers    []string                    // --disable-source-wrapper
+	customWrappers      []SourceWrapper             // wrappers added with WithSourceWrapper
+	view                string                      // --view, the split-horizon view to publish
+	clusterName         string                      // --cluster-name
+	recordPrefix        string                      // rendered --record-prefix
+	recordSuffix        string                      // rendered --record-suffix
+	recordSharedParent  bool                        // --record-shared-parent
+	targetFrom          *TargetFromResolver         // resolves target-from references, nil when disabled
+	collisionPolicy     string                      // --namespace-collision-policy, empty when disabled
+	namespaceDefaults   *NamespaceDefaults          // namespace default annotations, nil when disabled
+	healthCheck         *HealthCheckConfig          // probes of the health-check annotation, nil when disabled
 }
 
 func NewConfig(opts ...Option) *Config {
@@ -118,6 +133,104 @@ func WithCreatePTR(enabled bool) Option {
 	}
 }
 
+// WithPropertyValidator sets the validator used to drop invalid provider-specific properties.
+func WithPropertyValidator(v *endpoint.PropertyValidator) Option {
+	return func(o *Config) {
+		o.propertyValidator = v
+	}
+}
+
+// WithMergeEndpoints enables merging endpoints produced by different resources
+// for the same DNS name, record type and set identifier.
+func WithMergeEndpoints(enabled bool) Option {
+	return func(o *Config) {
+		o.mergeEndpoints = enabled
+	}
+}
+
+// WithEventEmitter sets the emitter used by wrappers to report Kubernetes events.
+func WithEventEmitter(emitter events.EventEmitter) Option {
+	return func(o *Config) {
+		o.eventEmitter = emitter
+	}
+}
+
+// WithView sets the split-horizon view whose targets are published in place of
+// the default targets of endpoints declaring targets for it.
+func WithView(view string) Option {
+	return func(o *Config) {
+		o.view = view
+	}
+}
+
+// WithClusterRecords enables the cluster-records wrapper, adding the prefix and the
+// suffix to the DNS names of the endpoints and, with sharedParent, also publishing
+// them under their original names with the cluster name as set identifier.
+func WithClusterRecords(clusterName, prefix, suffix string, sharedParent bool) Option {
+	return func(o *Config) {
+		o.clusterName = clusterName
+		o.recordPrefix = prefix
+		o.recordSuffix = suffix
+		o.recordSharedParent = sharedParent
+	}
+}
+
+// WithNamespaceCollisionPolicy enables the detection of DNS names claimed by
+// resources of different namespaces, resolved with the given policy.
+func WithNamespaceCollisionPolicy(policy string) Option {
+	return func(o *Config) {
+		o.collisionPolicy = policy
+	}
+}
+
+// WithTargetFromResolver enables the target-from wrapper, resolving the targets
+// referenced by the target-from annotation with the given resolver.
+func WithTargetFromResolver(resolver *TargetFromResolver) Option {
+	return func(o *Config) {
+		o.targetFrom = resolver
+	}
+}
+
+// WithHealthChecks enables the health-check wrapper, probing the targets of the
+// endpoints with the health-check annotation and dropping the failing ones.
+func WithHealthChecks(cfg HealthCheckConfig) Option {
+	return func(o *Config) {
+		o.healthCheck = &cfg
+	}
+}
+
+// WithNamespaceDefaults enables the namespace-defaults wrapper, defaulting the
+// provider-specific properties of endpoints from the annotations of their namespace.
+func WithNamespaceDefaults(defaults *NamespaceDefaults) Option {
+	return func(o *Config) {
+		o.namespaceDefaults = defaults
+	}
+}
+
+// WithSourceWrapperOrder sets the order in which the source wrappers are applied.
+// Wrappers not listed are applied afterwards, in their default order.
+func WithSourceWrapperOrder(names []string) Option {
+	return func(o *Config) {
+		o.wrapperOrder = names
+	}
+}
+
+// WithDisabledSourceWrappers disables the source wrappers with the given names.
+func WithDisabledSourceWrappers(names []string) Option {
+	return func(o *Config) {
+		o.disabledWrappers = names
+	}
+}
+
+// WithSourceWrapper adds a custom source wrapper to the pipeline. By default it is
+// applied after the built-in wrappers and before the post-processor; its name can be
+// used with WithSourceWrapperOrder and WithDisabledSourceWrappers.
+func WithSourceWrapper(w SourceWrapper) Option {
+	return func(o *Config) {
+		o.customWrappers = append(o.customWrappers, w)
+	}
+}
+
 // addSourceWrapper registers a source wrapper by name in the Config.
 // It initializes the sourceWrappers map if it is nil.
 func (o *Config) addSourceWrapper(name string) {
@@ -135,34 +248,30 @@ func (o *Config) isSourceWrapperInstrumented(name string) bool {
 	return o.sourceWrappers.Has(name)
 }
 
-// wrapSources combines multiple sources into a single source,
-// applies optional NAT64 and target network filtering wrappers, and sets a minimum TTL.
+// wrapSources combines multiple sources into a single deduplicated source and
+// applies the source wrapper pipeline in the configured order.
 // It registers each applied wrapper in the Config for instrumentation.
 func wrapSources(
 	sources []source.Source,
 	opts *Config,
 ) (source.Source, error) {
-	combinedSource := NewDedupSource(NewMultiSource(sources, opts.defaultTargets, opts.forceDefaultTargets))
+	pipeline, err := opts.pipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	combinedSource := NewDedupSource(NewMultiSource(sources, opts.defaultTargets, opts.forceDefaultTargets),
+		WithDedupMerge(opts.mergeEndpoints), WithDedupEventEmitter(opts.eventEmitter))
 	opts.addSourceWrapper("dedup")
-	if len(opts.nat64Networks) > 0 {
-		var err error
-		combinedSource, err = NewNAT64Source(combinedSource, opts.nat64Networks)
+	for _, w := range pipeline {
+		if w.Enabled != nil && !w.Enabled(opts) {
+			continue
+		}
+		combinedSource, err = w.Wrap(combinedSource, opts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create NAT64 source wrapper: %w", err)
+			return nil, err
 		}
-		opts.addSourceWrapper("nat64")
-	}
-	targetFilter := endpoint.NewTargetNetFilterWithExclusions(opts.targetNetFilter, opts.excludeTargetNets)
-	if targetFilter.IsEnabled() {
-		combinedSource = NewTargetFilterSource(combinedSource, targetFilter)
-		opts.addSourceWrapper("target-filter")
-	}
-	if opts.ptrSupported {
-		combinedSource = NewPTRSource(combinedSource, opts.createPTR)
-		opts.addSourceWrapper("ptr")
+		opts.addSourceWrapper(w.Name)
 	}
-	combinedSource = NewPostProcessor(combinedSource, WithTTL(opts.minTTL), WithPostProcessorPreferAlias(opts.preferAlias),
-		WithPostProcessorProvider(opts.provider))
-	opts.addSourceWrapper("post-processor")
 	return combinedSource, nil
 }
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// viewSource is a Source that publishes the targets of a split-horizon view.
+// Endpoints carrying targets for the configured view, set with the
+// view-target-<view> annotation, have their A, AAAA and CNAME records replaced
+// by records built from those targets. Endpoints without targets for the view
+// keep their default targets.
+type viewSource struct {
+	source source.Source
+	view   string
+}
+
+// NewViewSource creates a new viewSource wrapping the provided Source.
+func NewViewSource(source source.Source, view string) source.Source {
+	return &viewSource{source: source, view: view}
+}
+
+// viewGroupKey identifies the endpoints generated for one hostname by one resource.
+type viewGroupKey struct {
+	dnsName       string
+	setIdentifier string
+	resource      string
+}
+
+// Endpoints collects endpoints from its wrapped source and applies the view targets.
+func (s *viewSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	log.Debugf("viewSource: collecting endpoints for view %q", s.view)
+
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	replaced := make(map[viewGroupKey]bool)
+	for _, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		targets := viewTargets(ep, s.view)
+		if len(targets) == 0 || !isAddressRecordType(ep.RecordType) {
+			dropViewProperties(ep)
+			result = append(result, ep)
+			continue
+		}
+
+		key := viewGroupKey{dnsName: ep.DNSName, setIdentifier: ep.SetIdentifier, resource: ep.Labels[endpoint.ResourceLabelKey]}
+		if replaced[key] {
+			continue
+		}
+		replaced[key] = true
+
+		for _, viewEp := range endpoint.EndpointsForHostname(ep.DNSName, targets, ep.RecordTTL, nil, ep.SetIdentifier, "") {
+			out := ep.DeepCopy()
+			out.RecordType = viewEp.RecordType
+			out.Targets = viewEp.Targets
+			dropViewProperties(out)
+			result = append(result, out)
+		}
+		log.Debugf("viewSource: using targets %v of view %q for %s", targets, s.view, ep.DNSName)
+	}
+	return result, nil
+}
+
+func (s *viewSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("viewSource: adding event handler")
+	s.source.AddEventHandler(ctx, handler)
+}
+
+// viewTargets returns the targets the endpoint declares for the given view.
+func viewTargets(ep *endpoint.Endpoint, view string) endpoint.Targets {
+	value, ok := ep.GetProviderSpecificProperty(endpoint.ProviderSpecificViewPrefix + view)
+	if !ok {
+		return nil
+	}
+	var targets endpoint.Targets
+	for target := range strings.SplitSeq(value, ",") {
+		if target = strings.TrimSpace(target); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// dropViewProperties removes the view targets from the endpoint so they never reach a provider.
+// Sources share the provider-specific slice between the endpoints of a hostname, so it is
+// cloned rather than modified in place.
+func dropViewProperties(ep *endpoint.Endpoint) {
+	isView := func(p endpoint.ProviderSpecificProperty) bool {
+		return strings.HasPrefix(p.Name, endpoint.ProviderSpecificViewPrefix)
+	}
+	if slices.ContainsFunc(ep.ProviderSpecific, isView) {
+		ep.ProviderSpecific = slices.DeleteFunc(slices.Clone(ep.ProviderSpecific), isView)
+	}
+}
+
+func isAddressRecordType(recordType string) bool {
+	return recordType == endpoint.RecordTypeA || recordType == endpoint.RecordTypeAAAA || recordType == endpoint.RecordTypeCNAME
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrappers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source"
+)
+
+var _ source.Source = &viewSource{}
+
+func TestViewSourceEndpoints(t *testing.T) {
+	views := endpoint.ProviderSpecific{
+		{Name: "aws/weight", Value: "10"},
+		{Name: "view/internal", Value: "10.0.0.1, internal.lb.example.com"},
+		{Name: "view/external", Value: "203.0.113.1"},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		view     string
+		expected []*endpoint.Endpoint
+	}{
+		{
+			name: "view targets replace the default targets",
+			view: "internal",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 300, "10.0.0.1").
+					WithProviderSpecific("aws/weight", "10").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeCNAME, 300, "internal.lb.example.com").
+					WithProviderSpecific("aws/weight", "10").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpoint("mail.example.com", endpoint.RecordTypeMX, "10 mail.example.com"),
+				endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "192.0.2.10"),
+			},
+		},
+		{
+			name: "endpoints keep their targets in a view they declare no targets for",
+			view: "partner",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 300, "192.0.2.1").
+					WithProviderSpecific("aws/weight", "10").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::1").
+					WithProviderSpecific("aws/weight", "10").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+				endpoint.NewEndpoint("mail.example.com", endpoint.RecordTypeMX, "10 mail.example.com"),
+				endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "192.0.2.10"),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints := endpoint.EndpointsForHostname("app.example.com", endpoint.Targets{"192.0.2.1", "2001:db8::1"}, 300, views, "", "service/default/app")
+			endpoints = append(endpoints,
+				endpoint.NewEndpoint("mail.example.com", endpoint.RecordTypeMX, "10 mail.example.com").WithProviderSpecific("view/internal", "10.0.0.1"),
+				endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "192.0.2.10"),
+			)
+
+			src := NewViewSource(testutils.NewMockSource(endpoints...), tt.view)
+			got, err := src.Endpoints(t.Context())
+			require.NoError(t, err)
+
+			testutils.ValidateEndpoints(t, got, tt.expected)
+			for _, ep := range got {
+				_, ok := ep.GetProviderSpecificProperty("view/internal")
+				assert.False(t, ok, "view properties must not reach the provider: %s", ep)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/tests/integration/toolkit"
+)
+
+var (
+	//go:embed scenarios/provider.yaml
+	providerYAML []byte
+)
+
+func TestProviderIntegration(t *testing.T) {
+	scenarios, err := toolkit.LoadScenarios(providerYAML)
+	require.NoError(t, err, "failed to load scenarios")
+	require.NotEmpty(t, scenarios.Scenarios, "no scenarios found")
+
+	for _, scenario := range scenarios.Scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			ctx := t.Context()
+			require.NotNil(t, scenario.Provider, "provider scenarios must declare a provider section")
+
+			loaded, err := toolkit.LoadResources(ctx, scenario)
+			require.NoError(t, err, "failed to populate resources")
+
+			records, err := toolkit.RunProviderSync(ctx, loaded, scenario)
+			require.NoError(t, err, "failed to run controller sync")
+			toolkit.ValidateScenarioEndpoints(t, records, scenario.Provider.Expected)
+		})
+	}
+}
@@ -47,7 +47,8 @@ func TestParseResources(t *testing.T) {
 			require.NoError(t, err, "failed to parse resources")
 
 			totalParsed := len(parsed.Services) + len(parsed.Ingresses) + len(parsed.Pods) +
-				len(parsed.EndpointSlices) + len(parsed.Nodes) + len(parsed.DNSEndpoints)
+				len(parsed.EndpointSlices) + len(parsed.Nodes) + len(parsed.Namespaces) + len(parsed.DNSEndpoints) +
+				len(parsed.Gateways) + len(parsed.HTTPRoutes)
 			// Pods and EndpointSlices may be auto-generated from dependencies, so count
 			// only the explicitly declared resources when checking nothing was silently dropped.
 			explicitResources := 0
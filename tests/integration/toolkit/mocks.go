@@ -18,13 +18,33 @@ package toolkit
 
 import (
 	"k8s.io/client-go/kubernetes/fake"
+	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/source"
 )
 
-// newMockClientGenerator returns a ClientGenerator whose KubeClient returns the
+// newMockClientGenerator returns a ClientGenerator whose Kubernetes client is the
 // provided fake clientset.
 func newMockClientGenerator(client *fake.Clientset) source.ClientGenerator {
 	return testutils.NewFakeClientGenerator(client)
 }
+
+// gatewayClientGenerator wraps a ClientGenerator and overrides the Gateway API
+// client to return the fake Gateway API clientset.
+type gatewayClientGenerator struct {
+	source.ClientGenerator
+	gatewayClient gateway.Interface
+}
+
+func (g gatewayClientGenerator) Client(name string) (any, error) {
+	if name == source.GatewayClientName {
+		return g.gatewayClient, nil
+	}
+	return g.ClientGenerator.Client(name)
+}
+
+// newGatewayClientGenerator returns gen with its Gateway API client replaced by client.
+func newGatewayClientGenerator(gen source.ClientGenerator, client gateway.Interface) source.ClientGenerator {
+	return gatewayClientGenerator{ClientGenerator: gen, gatewayClient: client}
+}
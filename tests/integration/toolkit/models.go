@@ -22,6 +22,8 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
 
 	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 
@@ -77,6 +79,27 @@ type Scenario struct {
 	Config      ScenarioConfig             `json:"config"`
 	Resources   []ResourceWithDependencies `json:"resources"`
 	Expected    []*ExpectedEndpoint        `json:"expected"`
+	// Provider is optional. When set, the scenario runs a full controller sync
+	// against the inmemory provider and asserts the resulting zone state.
+	Provider *ProviderScenario `json:"provider,omitempty"`
+}
+
+// ProviderScenario declares the inmemory provider zone before and after a sync.
+type ProviderScenario struct {
+	// Zone is the zone created in the inmemory provider.
+	Zone string `json:"zone"`
+	// Registry selects the registry, defaults to "txt".
+	Registry string `json:"registry,omitempty"`
+	// OwnerID is the registry owner ID, defaults to "default".
+	OwnerID string `json:"ownerID,omitempty"`
+	// Policy is the sync policy, defaults to "sync".
+	Policy string `json:"policy,omitempty"`
+	// ManagedRecordTypes defaults to A, AAAA and CNAME.
+	ManagedRecordTypes []string `json:"managedRecordTypes,omitempty"`
+	// Initial records are created in the zone before the sync, including any TXT ownership records.
+	Initial []*ExpectedEndpoint `json:"initial,omitempty"`
+	// Expected is the full zone content after the sync, including TXT ownership records.
+	Expected []*ExpectedEndpoint `json:"expected"`
 }
 
 // ResourceWithDependencies wraps a K8s resource with optional dependencies.
@@ -115,13 +138,18 @@ type ParsedResources struct {
 	EndpointSlices []*discoveryv1.EndpointSlice
 	Pods           []*corev1.Pod
 	Nodes          []*corev1.Node
+	Namespaces     []*corev1.Namespace
 	DNSEndpoints   []*apiv1alpha1.DNSEndpoint
+	Gateways       []*gatewayv1.Gateway
+	HTTPRoutes     []*gatewayv1.HTTPRoute
 }
 
 // LoadedResources holds the clients.
 type LoadedResources struct {
 	// K8sClient is the fake Kubernetes clientset for core/networking/discovery resources.
 	K8sClient *fake.Clientset
+	// GatewayClient is the fake Gateway API clientset for Gateway and route resources.
+	GatewayClient *gatewayfake.Clientset
 	// DNSEndpoints are the parsed DNSEndpoint CRD objects ready to be injected into the CRD source fake cache.
 	DNSEndpoints []*apiv1alpha1.DNSEndpoint
 }
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package toolkit
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/external-dns/controller"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	registryfactory "sigs.k8s.io/external-dns/registry/factory"
+)
+
+// providerScenarioToConfig fills the registry and controller settings of a provider scenario,
+// applying the same defaults as the command line flags.
+func providerScenarioToConfig(ps *ProviderScenario) *externaldns.Config {
+	cfg := externaldns.NewConfig()
+	cfg.Registry = externaldns.RegistryTXT
+	if ps.Registry != "" {
+		cfg.Registry = ps.Registry
+	}
+	cfg.TXTOwnerID = "default"
+	if ps.OwnerID != "" {
+		cfg.TXTOwnerID = ps.OwnerID
+	}
+	cfg.Policy = "sync"
+	if ps.Policy != "" {
+		cfg.Policy = ps.Policy
+	}
+	cfg.ManagedDNSRecordTypes = []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}
+	if len(ps.ManagedRecordTypes) > 0 {
+		cfg.ManagedDNSRecordTypes = ps.ManagedRecordTypes
+	}
+	return cfg
+}
+
+// RunProviderSync seeds the inmemory provider with the scenario's initial zone state,
+// runs a single controller sync from the scenario sources through the plan and
+// registry, and returns the zone content afterwards.
+func RunProviderSync(ctx context.Context, loaded *LoadedResources, scenario Scenario) ([]*endpoint.Endpoint, error) {
+	ps := scenario.Provider
+	if ps == nil {
+		return nil, fmt.Errorf("scenario %q has no provider section", scenario.Name)
+	}
+
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{ps.Zone}))
+	if len(ps.Initial) > 0 {
+		initial := make([]*endpoint.Endpoint, len(ps.Initial))
+		for i, e := range ps.Initial {
+			initial[i] = e.ToEndpoint()
+		}
+		if err := p.ApplyChanges(ctx, &plan.Changes{Create: initial}); err != nil {
+			return nil, fmt.Errorf("failed to seed zone %q: %w", ps.Zone, err)
+		}
+	}
+
+	src, err := CreateWrappedSource(ctx, loaded, scenario.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := providerScenarioToConfig(ps)
+	reg, err := registryfactory.Select(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	policy, ok := plan.Policies[cfg.Policy]
+	if !ok {
+		return nil, fmt.Errorf("unknown policy: %s", cfg.Policy)
+	}
+
+	ctrl := &controller.Controller{
+		Source:               src,
+		Registry:             reg,
+		Policy:               policy,
+		DomainFilter:         &endpoint.DomainFilter{},
+		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
+		SupportedRecordTypes: p.SupportedRecordTypes(),
+	}
+	if err := ctrl.RunOnce(ctx); err != nil {
+		return nil, err
+	}
+
+	return p.Records(ctx)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package toolkit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+func TestLoadScenarios_MissingProviderZone(t *testing.T) {
+	yaml := []byte(`
+scenarios:
+  - name: my-scenario
+    description: A test scenario
+    config:
+      sources: ["service"]
+    provider:
+      expected: []
+`)
+	_, err := LoadScenarios(yaml)
+	assert.ErrorContains(t, err, "missing required field: provider.zone")
+}
+
+func TestProviderScenarioToConfig_Defaults(t *testing.T) {
+	cfg := providerScenarioToConfig(&ProviderScenario{Zone: "example.com"})
+	assert.Equal(t, externaldns.RegistryTXT, cfg.Registry)
+	assert.Equal(t, "default", cfg.TXTOwnerID)
+	assert.Equal(t, "sync", cfg.Policy)
+	assert.Equal(t, []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}, cfg.ManagedDNSRecordTypes)
+}
+
+func TestProviderScenarioToConfig_Overrides(t *testing.T) {
+	cfg := providerScenarioToConfig(&ProviderScenario{
+		Zone:               "example.com",
+		Registry:           externaldns.RegistryNoop,
+		OwnerID:            "team-a",
+		Policy:             "upsert-only",
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	})
+	assert.Equal(t, externaldns.RegistryNoop, cfg.Registry)
+	assert.Equal(t, "team-a", cfg.TXTOwnerID)
+	assert.Equal(t, "upsert-only", cfg.Policy)
+	assert.Equal(t, []string{endpoint.RecordTypeA}, cfg.ManagedDNSRecordTypes)
+}
+
+func TestRunProviderSync_NoProvider(t *testing.T) {
+	_, err := RunProviderSync(t.Context(), &LoadedResources{}, Scenario{Name: "no-provider"})
+	assert.ErrorContains(t, err, "has no provider section")
+}
+
+func TestRunProviderSync_NoopRegistry(t *testing.T) {
+	scenario := Scenario{
+		Name:   "noop",
+		Config: ScenarioConfig{Sources: []string{"service"}},
+		Resources: []ResourceWithDependencies{
+			{Resource: runtime.RawExtension{Raw: []byte(`apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+  namespace: default
+  annotations:
+    external-dns.kubernetes.io/hostname: svc.example.com
+spec:
+  type: LoadBalancer
+status:
+  loadBalancer:
+    ingress:
+      - ip: 1.2.3.4
+`)}},
+		},
+		Provider: &ProviderScenario{Zone: "example.com", Registry: externaldns.RegistryNoop},
+	}
+	loaded, err := LoadResources(t.Context(), scenario)
+	require.NoError(t, err)
+
+	records, err := RunProviderSync(t.Context(), loaded, scenario)
+	require.NoError(t, err)
+	ValidateScenarioEndpoints(t, records, []*ExpectedEndpoint{
+		{DNSName: "svc.example.com", Targets: endpoint.Targets{"1.2.3.4"}, RecordType: endpoint.RecordTypeA},
+	})
+}
@@ -30,6 +30,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
 	"sigs.k8s.io/yaml"
 
 	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
@@ -46,6 +48,7 @@ var (
 		utilruntime.Must(discoveryv1.AddToScheme(s))
 		utilruntime.Must(networkingv1.AddToScheme(s))
 		utilruntime.Must(apiv1alpha1.AddToScheme(s))
+		utilruntime.Must(gatewayv1.Install(s))
 		return s
 	}()
 	decoder = serializer.NewCodecFactory(scheme).UniversalDeserializer()
@@ -69,6 +72,9 @@ func LoadScenarios(data []byte) (*TestScenarios, error) {
 		if len(s.Config.Sources) == 0 {
 			return nil, fmt.Errorf("scenario %d (%q) is missing required field: config.sources", i, s.Name)
 		}
+		if s.Provider != nil && s.Provider.Zone == "" {
+			return nil, fmt.Errorf("scenario %d (%q) is missing required field: provider.zone", i, s.Name)
+		}
 	}
 
 	return &scenarios, nil
@@ -97,12 +103,18 @@ func ParseResources(resources []ResourceWithDependencies) (*ParsedResources, err
 			}
 		case *corev1.Node:
 			parsed.Nodes = append(parsed.Nodes, res)
+		case *corev1.Namespace:
+			parsed.Namespaces = append(parsed.Namespaces, res)
 		case *networkingv1.Ingress:
 			parsed.Ingresses = append(parsed.Ingresses, res)
 		case *discoveryv1.EndpointSlice:
 			parsed.EndpointSlices = append(parsed.EndpointSlices, res)
 		case *apiv1alpha1.DNSEndpoint:
 			parsed.DNSEndpoints = append(parsed.DNSEndpoints, res)
+		case *gatewayv1.Gateway:
+			parsed.Gateways = append(parsed.Gateways, res)
+		case *gatewayv1.HTTPRoute:
+			parsed.HTTPRoutes = append(parsed.HTTPRoutes, res)
 		default:
 			return nil, fmt.Errorf("unsupported resource type %T", obj)
 		}
@@ -215,11 +227,28 @@ func createServiceWithOptionalStatus(ctx context.Context, client *fake.Clientset
 	return nil
 }
 
-// LoadResources creates the Kubernetes resources in a fake clientset and collects
+// createGatewayResources creates Gateways and HTTPRoutes in the fake Gateway API clientset.
+// Unlike the core fake clientset, the Gateway API one keeps the status set on Create.
+func createGatewayResources(ctx context.Context, client *gatewayfake.Clientset, resources *ParsedResources) error {
+	for _, gw := range resources.Gateways {
+		if _, err := client.GatewayV1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	}
+	for _, rt := range resources.HTTPRoutes {
+		if _, err := client.GatewayV1().HTTPRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadResources creates the Kubernetes resources in fake clientsets and collects
 // any DNSEndpoint CRD objects for use by the CRD source.
 // This must be called BEFORE creating sources so the informers can see the resources.
 func LoadResources(ctx context.Context, scenario Scenario) (*LoadedResources, error) {
 	k8sClient := fake.NewClientset()
+	gatewayClient := gatewayfake.NewSimpleClientset()
 
 	// Parse resources from scenario
 	resources, err := ParseResources(scenario.Resources)
@@ -227,6 +256,11 @@ func LoadResources(ctx context.Context, scenario Scenario) (*LoadedResources, er
 		return nil, err
 	}
 
+	for _, ns := range resources.Namespaces {
+		if _, err := k8sClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil {
+			return nil, err
+		}
+	}
 	for _, node := range resources.Nodes {
 		if err := createNodeWithAddresses(ctx, k8sClient, node); err != nil {
 			return nil, err
@@ -254,9 +288,13 @@ func LoadResources(ctx context.Context, scenario Scenario) (*LoadedResources, er
 			return nil, err
 		}
 	}
+	if err := createGatewayResources(ctx, gatewayClient, resources); err != nil {
+		return nil, err
+	}
 	return &LoadedResources{
-		K8sClient:    k8sClient,
-		DNSEndpoints: resources.DNSEndpoints,
+		K8sClient:     k8sClient,
+		GatewayClient: gatewayClient,
+		DNSEndpoints:  resources.DNSEndpoints,
 	}, nil
 }
 
@@ -280,6 +318,7 @@ func scenarioToConfig(scenarioCfg ScenarioConfig, opts ...source.OverrideConfigO
 // If the "crd" source is requested, a minimal fake Kubernetes REST API server is
 // started (serving only DNSEndpoint resources) so the CRD source's
 // controller-runtime cache can initialize without a real cluster.
+// Gateway API sources are served by the fake Gateway API clientset.
 func CreateWrappedSource(
 	ctx context.Context,
 	loaded *LoadedResources,
@@ -290,6 +329,9 @@ func CreateWrappedSource(
 		restCfg := newFakeDNSEndpointServer(ctx, loaded.DNSEndpoints)
 		gen = newCRDClientGenerator(loaded.K8sClient, restCfg)
 	}
+	if loaded.GatewayClient != nil {
+		gen = newGatewayClientGenerator(gen, loaded.GatewayClient)
+	}
 
 	cfg, err := scenarioToConfig(scenarioCfg, source.WithClientGenerator(gen))
 	if err != nil {
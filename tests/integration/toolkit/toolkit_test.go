@@ -141,6 +141,38 @@ spec:
 `)
 }
 
+func rawGateway() []byte {
+	return []byte(`apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: gw
+  namespace: default
+spec:
+  gatewayClassName: example
+  listeners:
+    - name: http
+      protocol: HTTP
+      port: 80
+status:
+  addresses:
+    - type: IPAddress
+      value: 10.0.0.10
+`)
+}
+
+func rawHTTPRoute() []byte {
+	return []byte(`apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route
+  namespace: default
+spec:
+  hostnames: ["app.example.com"]
+  parentRefs:
+    - name: gw
+`)
+}
+
 func TestParseResources_Service(t *testing.T) {
 	parsed, err := ParseResources([]ResourceWithDependencies{
 		{Resource: runtime.RawExtension{Raw: rawService()}},
@@ -199,6 +231,32 @@ func TestParseResources_DNSEndpoint(t *testing.T) {
 	assert.Equal(t, "my-dns", parsed.DNSEndpoints[0].Name)
 }
 
+func TestParseResources_Gateway(t *testing.T) {
+	parsed, err := ParseResources([]ResourceWithDependencies{
+		{Resource: runtime.RawExtension{Raw: rawGateway()}},
+		{Resource: runtime.RawExtension{Raw: rawHTTPRoute()}},
+	})
+	require.NoError(t, err)
+	require.Len(t, parsed.Gateways, 1)
+	assert.Equal(t, "gw", parsed.Gateways[0].Name)
+	require.Len(t, parsed.HTTPRoutes, 1)
+	assert.Equal(t, "route", parsed.HTTPRoutes[0].Name)
+}
+
+func TestParseResources_Namespace(t *testing.T) {
+	raw := []byte(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: team-a
+`)
+	parsed, err := ParseResources([]ResourceWithDependencies{
+		{Resource: runtime.RawExtension{Raw: raw}},
+	})
+	require.NoError(t, err)
+	require.Len(t, parsed.Namespaces, 1)
+	assert.Equal(t, "team-a", parsed.Namespaces[0].Name)
+}
+
 func TestParseResources_UnsupportedType(t *testing.T) {
 	raw := []byte(`apiVersion: v1
 kind: ConfigMap
@@ -314,6 +372,22 @@ func TestLoadResources_DNSEndpoint(t *testing.T) {
 	assert.Equal(t, "my-dns", loaded.DNSEndpoints[0].Name)
 }
 
+func TestLoadResources_Gateway(t *testing.T) {
+	loaded, err := LoadResources(t.Context(), Scenario{
+		Resources: []ResourceWithDependencies{
+			{Resource: runtime.RawExtension{Raw: rawGateway()}},
+			{Resource: runtime.RawExtension{Raw: rawHTTPRoute()}},
+		},
+	})
+	require.NoError(t, err)
+	gw, err := loaded.GatewayClient.GatewayV1().Gateways("default").Get(t.Context(), "gw", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Len(t, gw.Status.Addresses, 1, "gateway status must be kept")
+	routes, err := loaded.GatewayClient.GatewayV1().HTTPRoutes("default").List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, routes.Items, 1)
+}
+
 func TestLoadResources_ParseError(t *testing.T) {
 	_, err := LoadResources(t.Context(), Scenario{
 		Resources: []ResourceWithDependencies{
@@ -353,6 +427,22 @@ func TestCreateWrappedSource_CRD(t *testing.T) {
 	assert.NotNil(t, src)
 }
 
+func TestCreateWrappedSource_Gateway(t *testing.T) {
+	loaded, err := LoadResources(t.Context(), Scenario{
+		Resources: []ResourceWithDependencies{
+			{Resource: runtime.RawExtension{Raw: rawGateway()}},
+			{Resource: runtime.RawExtension{Raw: rawHTTPRoute()}},
+		},
+	})
+	require.NoError(t, err)
+
+	src, err := CreateWrappedSource(t.Context(), loaded, ScenarioConfig{
+		Sources: []string{"gateway-httproute"},
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, src)
+}
+
 func TestScenarioToConfig(t *testing.T) {
 	cfg, err := scenarioToConfig(ScenarioConfig{
 		Sources:           []string{"service"},